@@ -32,21 +32,36 @@ import (
 
 	"eventos-backend/internal/domain/checkin"
 	"eventos-backend/internal/domain/checkout"
+	"eventos-backend/internal/domain/datasubject"
+	"eventos-backend/internal/domain/device"
 	"eventos-backend/internal/domain/employee"
 	"eventos-backend/internal/domain/event"
+	"eventos-backend/internal/domain/fraud"
+	"eventos-backend/internal/domain/occupancy"
+	"eventos-backend/internal/domain/offlinesync"
 	"eventos-backend/internal/domain/partner"
 	"eventos-backend/internal/domain/permission"
+	"eventos-backend/internal/domain/qrcode"
+	"eventos-backend/internal/domain/retention"
 	"eventos-backend/internal/domain/role"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/storage"
 	"eventos-backend/internal/domain/tenant"
 	"eventos-backend/internal/domain/user"
+	"eventos-backend/internal/infrastructure/alerts"
+	"eventos-backend/internal/infrastructure/auth/devicekey"
 	"eventos-backend/internal/infrastructure/auth/jwt"
+	"eventos-backend/internal/infrastructure/blobstore"
 	"eventos-backend/internal/infrastructure/cache"
 	redisCache "eventos-backend/internal/infrastructure/cache/redis"
 	"eventos-backend/internal/infrastructure/config"
+	"eventos-backend/internal/infrastructure/envelope"
+	"eventos-backend/internal/infrastructure/jobs"
 	"eventos-backend/internal/infrastructure/messaging/handlers"
 	"eventos-backend/internal/infrastructure/messaging/rabbitmq"
 	"eventos-backend/internal/infrastructure/persistence/postgres"
 	"eventos-backend/internal/infrastructure/persistence/postgres/repositories"
+	"eventos-backend/internal/infrastructure/realtime"
 	"eventos-backend/internal/interfaces/http/router"
 
 	"go.uber.org/zap"
@@ -145,8 +160,13 @@ func main() {
 		rabbitClient = nil
 	}
 
+	// Hub do feed em tempo real desta instância
+	feedHub := realtime.NewHub(logger)
+
 	// Configurar Publisher e Consumer
+	var publisher *rabbitmq.Publisher
 	var consumer *rabbitmq.Consumer
+	var feedConsumer *rabbitmq.Consumer
 	var keyBuilder cache.KeyBuilder
 	if rabbitClient != nil {
 		// Declarar exchanges e filas básicas
@@ -154,6 +174,11 @@ func main() {
 			logger.Error("Failed to setup RabbitMQ topology", zap.Error(err))
 		}
 
+		// Configurar Publisher
+		publisher = rabbitmq.NewPublisher(rabbitClient, rabbitmq.PublisherConfig{
+			DefaultExchange: "eventos.events",
+		}, logger)
+
 		// Configurar key builder para o consumer
 		if cacheService != nil {
 			keyBuilder = cache.NewDefaultKeyBuilder("eventos", 15*time.Minute)
@@ -180,7 +205,32 @@ func main() {
 			consumer.RegisterHandler(rabbitmq.MessageTypeCheckinInvalid, checkinHandler)
 		}
 
+		// Configurar consumer do feed: fila exclusiva desta instância, que recebe todas as notificações
+		feedQueue, err := setupFeedQueue(rabbitClient)
+		if err != nil {
+			logger.Error("Failed to setup feed queue, real-time feed limited to this instance", zap.Error(err))
+		} else {
+			feedConsumer = rabbitmq.NewConsumer(rabbitClient, rabbitmq.ConsumerConfig{
+				QueueName:           feedQueue,
+				ConsumerTag:         feedQueue,
+				AutoAck:             true,
+				PrefetchCount:       50,
+				ProcessingTimeout:   5 * time.Second,
+				ConcurrentConsumers: 1,
+			}, logger)
+
+			feedHandler := handlers.NewFeedEventHandler(feedHub, logger)
+			for _, messageType := range realtime.MessageTypes {
+				feedConsumer.RegisterHandler(messageType, feedHandler)
+			}
+		}
+
 		defer func() {
+			if feedConsumer != nil && feedConsumer.IsRunning() {
+				if err := feedConsumer.Stop(); err != nil {
+					logger.Error("Failed to stop feed consumer", zap.Error(err))
+				}
+			}
 			if consumer != nil && consumer.IsRunning() {
 				if err := consumer.Stop(); err != nil {
 					logger.Error("Failed to stop consumer", zap.Error(err))
@@ -201,46 +251,168 @@ func main() {
 	}
 	jwtService := jwt.NewJWTService(jwtConfig)
 
+	// Configurar cifragem dos dados biométricos: chaves por tenant embrulhadas pela chave mestra
+	keyring, err := envelope.ParseKeyring(cfg.Biometry.MasterKeys, cfg.Biometry.ActiveMasterKey)
+	if err != nil {
+		logger.Fatal("Failed to configure biometric master keys", zap.Error(err))
+	}
+	biometricCipher := envelope.NewService(repositories.NewBiometricRepository(db.DB, logger), keyring, cfg.Biometry.KeyCacheTTL, logger)
+
 	// Configurar repositórios
 	tenantRepo := repositories.NewTenantRepository(db.DB, logger)
 	userRepo := repositories.NewUserRepository(db.DB, logger)
 	eventRepo := repositories.NewEventRepository(db.DB, logger)
 	partnerRepo := repositories.NewPartnerRepository(db.DB, logger)
-	employeeRepo := repositories.NewEmployeeRepository(db.DB, logger)
+	employeeRepo := repositories.NewEmployeeRepository(db.DB, biometricCipher, logger)
 	roleRepo := repositories.NewRoleRepository(db.DB, logger)
 	permissionRepo := repositories.NewPermissionRepository(db.DB, logger)
-	checkinRepo := repositories.NewCheckinRepository(db.DB, logger)
+	checkinRepo := repositories.NewCheckinRepository(db.DB, biometricCipher, logger)
 	checkoutRepo := repositories.NewCheckoutRepository(db.DB, logger)
+	qrCodeRepo := repositories.NewQRCodeRepository(db.DB, logger)
+	checkinStatsRepo := repositories.NewCheckinStatsRepository(db.DB, cacheService, logger)
+	checkoutStatsRepo := repositories.NewCheckoutStatsRepository(db.DB, cacheService, logger)
+
+	// Configurar armazenamento de fotos: diretório local ou bucket S3 compatível, sempre cifrado e servido pela API
+	var blobStore storage.BlobStore
+	switch cfg.Storage.Backend {
+	case "s3":
+		blobStore, err = blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:        cfg.Storage.S3Endpoint,
+			Region:          cfg.Storage.S3Region,
+			Bucket:          cfg.Storage.S3Bucket,
+			AccessKeyID:     cfg.Storage.S3AccessKeyID,
+			SecretAccessKey: cfg.Storage.S3SecretKey,
+			PathStyle:       cfg.Storage.S3PathStyle,
+		})
+	default:
+		blobStore, err = blobstore.NewLocalStore(cfg.Storage.LocalPath, cfg.Storage.PublicBaseURL, cfg.Storage.SigningSecret)
+	}
+	if err != nil {
+		logger.Fatal("Failed to configure photo storage", zap.Error(err))
+	}
+	urlSigner, err := blobstore.NewURLSigner(cfg.Storage.PublicBaseURL, cfg.Storage.SigningSecret)
+	if err != nil {
+		logger.Fatal("Failed to configure photo storage", zap.Error(err))
+	}
+	fileStore := blobstore.NewEncryptedStore(blobStore, biometricCipher, urlSigner)
+	photoStorage := storage.NewService(fileStore, storage.Config{
+		MaxPhotoSize: cfg.Storage.MaxPhotoSize,
+		SignedURLTTL: cfg.Storage.SignedURLTTL,
+	})
 
 	// Configurar serviços de domínio
 	tenantService := tenant.NewDomainService(tenantRepo, logger)
 	userService := user.NewDomainService(userRepo, logger)
 	eventService := event.NewDomainService(eventRepo, logger)
 	partnerService := partner.NewDomainService(partnerRepo, logger)
-	employeeService := employee.NewDomainService(employeeRepo, logger)
+	employeeService := employee.NewDomainService(employeeRepo, photoStorage, employee.Config{
+		FaceDuplicateThreshold: float32(cfg.Facial.SimilarityThreshold),
+		MinFaceTemplateQuality: float32(cfg.Facial.MinTemplateQuality),
+		MaxFaceTemplates:       cfg.Facial.MaxTemplates,
+	}, logger)
 	roleService := role.NewService(roleRepo)
 	permissionService := permission.NewService(permissionRepo)
 
+	// Configurar serviço de QR Codes rotativos
+	qrCodeService := qrcode.NewService(qrCodeRepo, eventRepo, qrcode.Config{
+		Secret:           cfg.QRCode.Secret,
+		RotationInterval: cfg.QRCode.RotationInterval,
+		Validity:         cfg.QRCode.Validity,
+	})
+
+	// Configurar ocupação dos eventos (contadores no Redis quando disponível)
+	var occupancyCounter occupancy.Counter
+	if redisClient != nil {
+		occupancyCounter = cache.NewOccupancyCounter(redisClient, cache.NewDefaultKeyBuilder("eventos", 15*time.Minute), constants.OccupancyCounterTTL*time.Second)
+	}
+	occupancyService := occupancy.NewService(repositories.NewOccupancyRepository(db.DB, logger), occupancyCounter, eventRepo)
+
+	// Configurar análise de fraude (alertas system.warning no RabbitMQ quando disponível)
+	var fraudPublisher alerts.SystemEventPublisher
+	if publisher != nil {
+		fraudPublisher = publisher
+	}
+	fraudService := fraud.NewService(repositories.NewFraudRepository(db.DB, biometricCipher, logger), alerts.NewFraudNotifier(fraudPublisher, logger), fraud.Config{
+		MaxTravelSpeed:    cfg.Fraud.MaxTravelSpeed,
+		BuddyWindow:       cfg.Fraud.BuddyWindow,
+		BuddyThreshold:    cfg.Fraud.BuddyThreshold,
+		EmbeddingLookback: cfg.Fraud.EmbeddingLookback,
+	})
+
 	// Configurar serviços de check-in/check-out
-	// Nota: Os serviços precisam de StatsRepository, mas por enquanto usaremos nil
-	checkinService := checkin.NewService(checkinRepo, nil)    // TODO: Implementar CheckinStatsRepository
-	checkoutService := checkout.NewService(checkoutRepo, nil) // TODO: Implementar CheckoutStatsRepository
+	facialThreshold := float32(cfg.Facial.SimilarityThreshold)
+	checkinService := checkin.NewService(checkinRepo, checkinStatsRepo, employeeRepo, eventRepo, partnerRepo, tenantRepo, qrCodeService, occupancyService, fraudService, photoStorage, checkin.Config{
+		FacialSimilarityThreshold: facialThreshold,
+		FacialApprovalThreshold:   float32(cfg.Facial.ApprovalThreshold),
+		WalkUpSimilarityMargin:    float32(cfg.Facial.WalkUpMargin),
+	})
+	checkoutService := checkout.NewService(checkoutRepo, checkoutStatsRepo, checkinRepo, employeeRepo, eventRepo, tenantRepo, qrCodeService, occupancyService, checkout.Config{
+		FacialSimilarityThreshold: facialThreshold,
+	})
+
+	// Configurar sincronização offline dos dispositivos
+	offlineSyncService := offlinesync.NewService(checkinService, checkoutService, checkinRepo, checkoutRepo)
+
+	// Configurar registro de dispositivos (quiosques e coletores)
+	deviceKeyCipher, err := devicekey.NewCipher(cfg.Devices.KeySecret)
+	if err != nil {
+		logger.Fatal("Failed to create device key cipher", zap.Error(err))
+	}
+
+	// Nonces das requisições assinadas: compartilhados no Redis, ou apenas nesta instância sem Redis
+	var deviceNonces device.NonceStore = cache.NewMemoryNonceStore()
+	if redisClient != nil {
+		deviceNonces = cache.NewDeviceNonceStore(redisClient, cache.NewDefaultKeyBuilder("eventos", 15*time.Minute))
+	}
+
+	deviceService := device.NewService(repositories.NewDeviceRepository(db.DB, logger), eventRepo, deviceKeyCipher, deviceNonces, device.Config{
+		SignatureMaxSkew: cfg.Devices.SignatureMaxSkew,
+	})
+
+	// Eventos de check-in/check-out: RabbitMQ quando disponível, senão entregues direto ao feed desta instância
+	eventPublisher := realtime.SelectPublisher(publisher, feedHub)
+
+	// Armazenamento das respostas idempotentes (desabilitado sem Redis)
+	var idempotencyStore cache.Cache
+	if redisClient != nil {
+		idempotencyStore = redisClient
+	}
+
+	// Configurar atendimento dos titulares de dados (LGPD)
+	dataSubjectService := datasubject.NewService(repositories.NewDataSubjectRepository(db.DB, biometricCipher, logger), photoStorage, logger)
+
+	// Configurar retenção de dados
+	retentionService := retention.NewService(repositories.NewRetentionRepository(db.DB, logger), photoStorage, logger)
 
 	// Configurar router
 	routerConfig := router.Config{
-		Logger:            logger,
-		DB:                db.DB.DB, // Acessar o *sql.DB através do sqlx.DB embutido
-		JWTService:        jwtService,
-		TenantService:     tenantService,
-		UserService:       userService,
-		EventService:      eventService,
-		PartnerService:    partnerService,
-		EmployeeService:   employeeService,
-		RoleService:       roleService,
-		PermissionService: permissionService,
-		CheckinService:    checkinService,
-		CheckoutService:   checkoutService,
-		Debug:             cfg.Logging.Level == "debug",
+		Logger:             logger,
+		DB:                 db.DB.DB, // Acessar o *sql.DB através do sqlx.DB embutido
+		JWTService:         jwtService,
+		TenantService:      tenantService,
+		UserService:        userService,
+		EventService:       eventService,
+		PartnerService:     partnerService,
+		EmployeeService:    employeeService,
+		RoleService:        roleService,
+		PermissionService:  permissionService,
+		CheckinService:     checkinService,
+		CheckoutService:    checkoutService,
+		QRCodeService:      qrCodeService,
+		OfflineSyncService: offlineSyncService,
+		OccupancyService:   occupancyService,
+		DeviceService:      deviceService,
+		FraudService:       fraudService,
+		DataSubjectService: dataSubjectService,
+		RetentionService:   retentionService,
+		PhotoStorage:       photoStorage,
+		FileStore:          fileStore,
+		DeviceAuthRequired: cfg.Devices.AuthRequired,
+		Publisher:          eventPublisher,
+		FeedHub:            feedHub,
+		IdempotencyStore:   idempotencyStore,
+		CacheKeyBuilder:    cache.NewDefaultKeyBuilder("eventos", 15*time.Minute),
+		Debug:              cfg.Logging.Level == "debug",
 	}
 
 	appRouter := router.New(routerConfig)
@@ -255,6 +427,38 @@ func main() {
 		}()
 	}
 
+	// Iniciar consumer do feed em tempo real
+	if feedConsumer != nil {
+		if err := feedConsumer.Start(context.Background()); err != nil {
+			logger.Error("Failed to start feed consumer", zap.Error(err))
+		}
+	}
+
+	// Iniciar jobs periódicos: encerramento automático de sessões abertas e eliminação por retenção
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	if cfg.Jobs.SessionAutoCloseEnabled {
+		sessionAutoCloseJob := jobs.NewSessionAutoCloseJob(
+			checkoutService,
+			eventPublisher,
+			cfg.Jobs.SessionAutoCloseInterval,
+			cfg.Jobs.SessionAutoCloseBatchSize,
+			logger,
+		)
+		go sessionAutoCloseJob.Start(jobsCtx)
+	}
+
+	if cfg.Jobs.RetentionPurgeEnabled {
+		retentionPurgeJob := jobs.NewRetentionPurgeJob(
+			retentionService,
+			cfg.Jobs.RetentionPurgeInterval,
+			cfg.Jobs.RetentionPurgeBatchSize,
+			logger,
+		)
+		go retentionPurgeJob.Start(jobsCtx)
+	}
+
 	// Configurar servidor HTTP
 	server := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
@@ -280,6 +484,7 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down server...")
+	stopJobs()
 
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -306,6 +511,7 @@ func setupRabbitMQTopology(client *rabbitmq.Client, logger *zap.Logger) error {
 	}{
 		{"eventos.checkin.events", "checkin.events"},
 		{"eventos.checkout.events", "checkout.events"},
+		{"eventos.work_session.events", "work_session.events"},
 		{"eventos.user.events", "user.events"},
 		{"eventos.system.events", "system.events"},
 		{"eventos.notification.events", "notification.events"},
@@ -332,6 +538,28 @@ func setupRabbitMQTopology(client *rabbitmq.Client, logger *zap.Logger) error {
 	return nil
 }
 
+// setupFeedQueue declara a fila do feed desta instância, removida automaticamente quando a instância desconecta.
+// Cada instância recebe uma cópia de todos os eventos de check-in, check-out e sessões de trabalho.
+func setupFeedQueue(client *rabbitmq.Client) (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "instance"
+	}
+
+	queue, err := client.DeclareQueue(fmt.Sprintf("eventos.feed.%s.%d", hostname, os.Getpid()), false, true, false, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to declare feed queue: %w", err)
+	}
+
+	for _, routingKey := range []string{"checkin.events", "checkout.events", "work_session.events"} {
+		if err := client.BindQueue(queue.Name, routingKey, "eventos.events", nil); err != nil {
+			return "", fmt.Errorf("failed to bind feed queue: %w", err)
+		}
+	}
+
+	return queue.Name, nil
+}
+
 func setupLogger(cfg config.LoggingConfig) (*zap.Logger, error) {
 	var zapConfig zap.Config
 
@@ -0,0 +1,175 @@
+// Comando de manutenção das chaves biométricas.
+//
+//	biometric-keys rewrap               reembrulha as chaves dos tenants com a chave mestra ativa
+//	biometric-keys rotate -tenant <id>  cria uma nova chave de dados do tenant e recifra embeddings e fotos
+//	biometric-keys seal                 cifra os embeddings e as fotos gravados antes da cifragem (após a migração 018)
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/domain/storage"
+	"eventos-backend/internal/infrastructure/blobstore"
+	"eventos-backend/internal/infrastructure/config"
+	"eventos-backend/internal/infrastructure/envelope"
+	"eventos-backend/internal/infrastructure/persistence/postgres"
+	"eventos-backend/internal/infrastructure/persistence/postgres/repositories"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	tenant := flags.String("tenant", "", "Tenant cuja chave de dados será rotacionada")
+	if err := flags.Parse(os.Args[2:]); err != nil {
+		usage()
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("Failed to setup logger: %v", err)
+	}
+	defer logger.Sync()
+
+	db, err := postgres.NewConnection(postgres.Config{
+		Host:            cfg.Database.Host,
+		Port:            cfg.Database.Port,
+		Database:        cfg.Database.Name,
+		Username:        cfg.Database.User,
+		Password:        cfg.Database.Password,
+		SSLMode:         cfg.Database.SSLMode,
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+	}, logger)
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer db.Close()
+
+	keyring, err := envelope.ParseKeyring(cfg.Biometry.MasterKeys, cfg.Biometry.ActiveMasterKey)
+	if err != nil {
+		logger.Fatal("Failed to configure biometric master keys", zap.Error(err))
+	}
+
+	repo := repositories.NewBiometricRepository(db.DB, logger)
+	service := envelope.NewService(repo, keyring, cfg.Biometry.KeyCacheTTL, logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	switch command {
+	case "rewrap":
+		rewrapped, err := service.Rewrap(ctx)
+		if err != nil {
+			logger.Fatal("Failed to rewrap biometric keys", zap.Error(err), zap.Int("rewrapped", rewrapped))
+		}
+		fmt.Printf("%d chaves reembrulhadas com a chave mestra %s\n", rewrapped, keyring.ActiveKeyID())
+
+	case "rotate":
+		tenantID, err := value_objects.ParseUUID(*tenant)
+		if err != nil {
+			logger.Fatal("Invalid tenant", zap.Error(err))
+		}
+
+		key, err := service.RotateDataKey(ctx, tenantID)
+		if err != nil {
+			logger.Fatal("Failed to rotate biometric data key", zap.Error(err))
+		}
+		fmt.Printf("Chave de dados do tenant %s na versão %d\n", tenantID, key.Version)
+
+		reseal(ctx, service, repo, cfg, &tenantID, false, logger)
+
+	case "seal":
+		reseal(ctx, service, repo, cfg, nil, true, logger)
+
+	default:
+		usage()
+	}
+}
+
+// reseal recifra os embeddings e as fotos com a chave de dados ativa de cada tenant
+func reseal(ctx context.Context, service *envelope.Service, repo *repositories.BiometricRepository, cfg *config.Config, tenantID *value_objects.UUID, onlyLegacy bool, logger *zap.Logger) {
+	records, err := service.Reseal(ctx, repo, tenantID, onlyLegacy)
+	if err != nil {
+		logger.Fatal("Failed to reseal face embeddings", zap.Error(err), zap.Int("resealed", records))
+	}
+	fmt.Printf("%d embeddings recifrados\n", records)
+
+	store, err := newBlobStore(cfg)
+	if err != nil {
+		logger.Fatal("Failed to configure photo storage", zap.Error(err))
+	}
+
+	signer, err := blobstore.NewURLSigner(cfg.Storage.PublicBaseURL, cfg.Storage.SigningSecret)
+	if err != nil {
+		logger.Fatal("Failed to configure photo storage", zap.Error(err))
+	}
+	photos := blobstore.NewEncryptedStore(store, service, signer)
+
+	resealed := 0
+	afterKey := ""
+	for {
+		keys, err := repo.ListPhotoKeys(ctx, tenantID, afterKey, constants.BiometricResealBatchSize)
+		if err != nil {
+			logger.Fatal("Failed to list photos", zap.Error(err))
+		}
+
+		for _, key := range keys {
+			written, err := photos.Reseal(ctx, key, onlyLegacy)
+			if err != nil {
+				// Fotos removidas do armazenamento não impedem as demais
+				logger.Warn("Failed to reseal photo", zap.String("key", key), zap.Error(err))
+				continue
+			}
+			if written {
+				resealed++
+			}
+		}
+
+		if len(keys) < constants.BiometricResealBatchSize {
+			break
+		}
+		afterKey = keys[len(keys)-1]
+	}
+	fmt.Printf("%d fotos recifradas\n", resealed)
+}
+
+// newBlobStore cria o armazenamento de fotos configurado, sem a camada de cifragem
+func newBlobStore(cfg *config.Config) (storage.BlobStore, error) {
+	if cfg.Storage.Backend == "s3" {
+		return blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:        cfg.Storage.S3Endpoint,
+			Region:          cfg.Storage.S3Region,
+			Bucket:          cfg.Storage.S3Bucket,
+			AccessKeyID:     cfg.Storage.S3AccessKeyID,
+			SecretAccessKey: cfg.Storage.S3SecretKey,
+			PathStyle:       cfg.Storage.S3PathStyle,
+		})
+	}
+
+	return blobstore.NewLocalStore(cfg.Storage.LocalPath, cfg.Storage.PublicBaseURL, cfg.Storage.SigningSecret)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "uso: biometric-keys rewrap | rotate -tenant <id> | seal")
+	os.Exit(2)
+}
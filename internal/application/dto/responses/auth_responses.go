@@ -34,15 +34,16 @@ type UserResponse struct {
 
 // TenantResponse representa os dados do tenant na resposta
 type TenantResponse struct {
-	ID           string    `json:"id"`
-	Name         string    `json:"name"`
-	Identity     string    `json:"identity,omitempty"`
-	IdentityType string    `json:"identity_type,omitempty"`
-	Email        string    `json:"email,omitempty"`
-	Address      string    `json:"address,omitempty"`
-	Active       bool      `json:"active"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID                  string    `json:"id"`
+	Name                string    `json:"name"`
+	Identity            string    `json:"identity,omitempty"`
+	IdentityType        string    `json:"identity_type,omitempty"`
+	Email               string    `json:"email,omitempty"`
+	Address             string    `json:"address,omitempty"`
+	Active              bool      `json:"active"`
+	RejectMockLocations bool      `json:"reject_mock_locations"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
 }
 
 // ErrorResponse representa uma resposta de erro
@@ -0,0 +1,100 @@
+package biometric
+
+import (
+	"context"
+	"encoding/binary"
+	stderrors "errors"
+	"fmt"
+	"math"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// ErrNotSealed indica dados gravados em texto claro, antes da cifragem envelope
+var ErrNotSealed = stderrors.New("data is not sealed")
+
+// Cipher protege os dados biométricos de cada tenant com cifragem envelope: uma chave de dados por tenant,
+// embrulhada pela chave mestra da configuração. Os embeddings só são abertos no caminho do reconhecimento.
+type Cipher interface {
+	// Seal cifra os dados com a chave de dados ativa do tenant; associatedData vincula o texto cifrado ao registro
+	Seal(ctx context.Context, tenantID value_objects.UUID, plaintext, associatedData []byte) ([]byte, error)
+
+	// Open decifra dados gravados por Seal; retorna ErrNotSealed quando os dados estão em texto claro
+	Open(ctx context.Context, tenantID value_objects.UUID, sealed, associatedData []byte) ([]byte, error)
+
+	// SearchVector transforma o embedding para o índice de busca protegido do tenant.
+	// A transformação é ortogonal e secreta: preserva a similaridade de cosseno, mas não revela o embedding original.
+	SearchVector(ctx context.Context, tenantID value_objects.UUID, embedding []float32) ([]float32, error)
+
+	// Digest calcula o HMAC do embedding com a chave de busca do tenant, usado para encontrar embeddings idênticos
+	Digest(ctx context.Context, tenantID value_objects.UUID, embedding []float32) ([]byte, error)
+}
+
+// DataKey representa uma chave de tenant embrulhada (cifrada) pela chave mestra
+type DataKey struct {
+	ID          value_objects.UUID
+	TenantID    value_objects.UUID
+	Purpose     string // data ou search
+	Version     int
+	MasterKeyID string // Chave mestra que embrulha esta chave
+	WrappedKey  []byte
+	CreatedAt   time.Time
+	RetiredAt   *time.Time // Chaves aposentadas não cifram dados novos, apenas decifram os antigos
+}
+
+// NewDataKey cria o registro de uma nova chave embrulhada
+func NewDataKey(tenantID value_objects.UUID, purpose string, version int, masterKeyID string, wrappedKey []byte) (*DataKey, error) {
+	if purpose != constants.BiometricKeyPurposeData && purpose != constants.BiometricKeyPurposeSearch {
+		return nil, fmt.Errorf("invalid biometric key purpose: %s", purpose)
+	}
+
+	if version < 1 {
+		return nil, fmt.Errorf("invalid biometric key version: %d", version)
+	}
+
+	return &DataKey{
+		ID:          value_objects.NewUUID(),
+		TenantID:    tenantID,
+		Purpose:     purpose,
+		Version:     version,
+		MasterKeyID: masterKeyID,
+		WrappedKey:  wrappedKey,
+		CreatedAt:   time.Now().UTC(),
+	}, nil
+}
+
+// IsActive verifica se a chave ainda cifra dados novos
+func (k *DataKey) IsActive() bool {
+	return k.RetiredAt == nil
+}
+
+// AssociatedData monta os dados associados que vinculam um embedding cifrado ao seu registro
+func AssociatedData(kind string, id value_objects.UUID) []byte {
+	return []byte(kind + "/" + id.String())
+}
+
+// EncodeEmbedding serializa o embedding em float32 little-endian para a cifragem
+func EncodeEmbedding(embedding []float32) []byte {
+	data := make([]byte, 4*len(embedding))
+	for i, value := range embedding {
+		binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(value))
+	}
+
+	return data
+}
+
+// DecodeEmbedding recupera o embedding serializado por EncodeEmbedding
+func DecodeEmbedding(data []byte) ([]float32, error) {
+	if len(data) != 4*constants.FaceEmbeddingDimensions {
+		return nil, fmt.Errorf("invalid embedding length: %d bytes", len(data))
+	}
+
+	embedding := make([]float32, len(data)/4)
+	for i := range embedding {
+		embedding[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+	}
+
+	return embedding, nil
+}
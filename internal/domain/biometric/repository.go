@@ -0,0 +1,58 @@
+package biometric
+
+import (
+	"context"
+	"time"
+
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Tipos de registros com embeddings cifrados (nomes das tabelas)
+const (
+	RecordEmployee     = "employees"
+	RecordFaceTemplate = "employee_face_templates"
+	RecordCheckin      = "checkin"
+)
+
+// RecordKinds lista os tipos de registros percorridos na recifragem
+var RecordKinds = []string{RecordEmployee, RecordFaceTemplate, RecordCheckin}
+
+// KeyRepository define as operações de persistência das chaves de tenant
+type KeyRepository interface {
+	// ListByTenant lista as chaves do tenant, das versões mais novas para as mais antigas
+	ListByTenant(ctx context.Context, tenantID value_objects.UUID) ([]*DataKey, error)
+
+	// ListAll lista as chaves de todos os tenants
+	ListAll(ctx context.Context) ([]*DataKey, error)
+
+	// Create grava uma nova chave; retorna AlreadyExists quando a versão já foi criada por outra instância
+	Create(ctx context.Context, key *DataKey) error
+
+	// UpdateWrapping grava a chave embrulhada novamente, por outra chave mestra
+	UpdateWrapping(ctx context.Context, key *DataKey) error
+
+	// Retire aposenta a chave: ela deixa de cifrar dados novos
+	Retire(ctx context.Context, id value_objects.UUID, at time.Time) error
+}
+
+// Record representa um embedding gravado em uma das tabelas com dados biométricos
+type Record struct {
+	Kind     string
+	ID       value_objects.UUID
+	TenantID value_objects.UUID
+	Sealed   []byte    // Embedding cifrado; vazio nos registros anteriores à cifragem
+	Legacy   []float32 // Embedding em texto claro, gravado antes da cifragem
+}
+
+// RecordRepository define a varredura dos dados biométricos usada na migração e na rotação de chaves
+type RecordRepository interface {
+	// ListRecords lista em ordem de ID os registros com embedding posteriores a afterID (nil começa do início).
+	// tenantID nil percorre todos os tenants; onlyLegacy restringe aos embeddings ainda em texto claro.
+	ListRecords(ctx context.Context, kind string, tenantID *value_objects.UUID, onlyLegacy bool, afterID *value_objects.UUID, limit int) ([]*Record, error)
+
+	// SaveRecord grava o embedding recifrado com o vetor de busca e o digest, apagando o texto claro
+	SaveRecord(ctx context.Context, record *Record, sealed []byte, searchVector []float32, digest []byte) error
+
+	// ListPhotoKeys lista em ordem as chaves de fotos do armazenamento posteriores a afterKey
+	ListPhotoKeys(ctx context.Context, tenantID *value_objects.UUID, afterKey string, limit int) ([]string, error)
+}
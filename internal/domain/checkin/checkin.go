@@ -19,11 +19,25 @@ type Checkin struct {
 	PartnerID         value_objects.UUID
 	Method            string // facial_recognition, qr_code, manual
 	Location          value_objects.Location
+	LocationFix       *value_objects.LocationFix // Precisão, altitude, horário, provedor e simulação da leitura de GPS
 	CheckinTime       time.Time
 	PhotoURL          string                 // Foto capturada no momento do check-in
+	PhotoKey          string                 // Chave da foto de evidência no armazenamento de arquivos
+	FaceEmbedding     []float32              // Embedding facial capturado no momento do check-in
+	SealedEmbedding   []byte                 // Embedding gravado, cifrado; aberto apenas na comparação facial do check-out
 	Notes             string                 // Observações do check-in
+	ClientID          *value_objects.UUID    // Identificador gerado no dispositivo (sincronização offline)
+	DeviceID          string                 // Dispositivo que registrou o check-in
 	IsValid           bool                   // Se o check-in é válido (dentro da cerca, horário correto, etc.)
 	ValidationDetails map[string]interface{} // Detalhes da validação (distância, similaridade facial, etc.)
+	VoidedAt          *time.Time             // Quando o check-in foi anulado (registro mantido para auditoria)
+	VoidedBy          *value_objects.UUID    // Quem anulou o check-in
+	VoidReason        string                 // Motivo da anulação
+	ReplacesID        *value_objects.UUID    // Check-in anulado que este registro substitui
+	ApprovalStatus    string                 // Aprovação do supervisor: pending, approved, rejected (vazio quando não exigida)
+	ReviewedAt        *time.Time             // Quando a aprovação foi decidida
+	ReviewedBy        *value_objects.UUID    // Quem aprovou ou rejeitou o check-in
+	ReviewComment     string                 // Comentário da decisão do supervisor
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 	CreatedBy         *value_objects.UUID
@@ -146,6 +160,90 @@ func (c *Checkin) MarkAsInvalid(validationDetails map[string]interface{}, update
 	c.UpdatedBy = &updatedBy
 }
 
+// MarkAsPendingApproval coloca o check-in na fila de aprovação do supervisor.
+// O check-in permanece inválido (fora das horas trabalhadas) até ser aprovado.
+func (c *Checkin) MarkAsPendingApproval(validationDetails map[string]interface{}, reason string, updatedBy value_objects.UUID) {
+	c.MarkAsInvalid(validationDetails, updatedBy)
+	c.ApprovalStatus = constants.ApprovalStatusPending
+	c.SetValidationDetail("approval_reason", reason)
+}
+
+// IsPendingApproval verifica se o check-in aguarda aprovação do supervisor
+func (c *Checkin) IsPendingApproval() bool {
+	return c.ApprovalStatus == constants.ApprovalStatusPending
+}
+
+// IsRejected verifica se o check-in foi rejeitado pelo supervisor
+func (c *Checkin) IsRejected() bool {
+	return c.ApprovalStatus == constants.ApprovalStatusRejected
+}
+
+// OccupiesSpot verifica se o check-in conta na ocupação do evento enquanto a sessão estiver aberta:
+// check-ins válidos ou aguardando aprovação que não foram anulados
+func (c *Checkin) OccupiesSpot() bool {
+	return !c.IsVoided() && (c.IsValid || c.IsPendingApproval())
+}
+
+// Approve aprova um check-in pendente, passando a contá-lo nas horas trabalhadas
+func (c *Checkin) Approve(comment string, reviewedBy value_objects.UUID) error {
+	comment = strings.TrimSpace(comment)
+	if err := c.validateReview(comment); err != nil {
+		return err
+	}
+
+	c.IsValid = true
+	c.review(constants.ApprovalStatusApproved, comment, reviewedBy)
+
+	return nil
+}
+
+// Reject rejeita um check-in pendente; o comentário com o motivo é obrigatório
+func (c *Checkin) Reject(comment string, reviewedBy value_objects.UUID) error {
+	comment = strings.TrimSpace(comment)
+	if err := c.validateReview(comment); err != nil {
+		return err
+	}
+
+	if comment == "" {
+		return errors.NewValidationError("ReviewComment", "é obrigatório para rejeitar o check-in")
+	}
+
+	c.IsValid = false
+	c.review(constants.ApprovalStatusRejected, comment, reviewedBy)
+
+	return nil
+}
+
+// validateReview verifica se o check-in pode receber a decisão do supervisor
+func (c *Checkin) validateReview(comment string) error {
+	if c.IsVoided() {
+		return errors.NewValidationError("Checkin", "check-in foi anulado").
+			WithContext("reason_code", constants.EligibilityCheckinVoided)
+	}
+
+	if !c.IsPendingApproval() {
+		return errors.NewValidationError("Checkin", "check-in não está aguardando aprovação").
+			WithContext("reason_code", constants.EligibilityCheckinNotPending)
+	}
+
+	if len(comment) > constants.MaxReviewCommentLength {
+		return errors.NewValidationError("ReviewComment", fmt.Sprintf("deve ter no máximo %d caracteres", constants.MaxReviewCommentLength))
+	}
+
+	return nil
+}
+
+// review registra a decisão do supervisor
+func (c *Checkin) review(status, comment string, reviewedBy value_objects.UUID) {
+	now := time.Now().UTC()
+	c.ApprovalStatus = status
+	c.ReviewedAt = &now
+	c.ReviewedBy = &reviewedBy
+	c.ReviewComment = comment
+	c.UpdatedAt = now
+	c.UpdatedBy = &reviewedBy
+}
+
 // AddNote adiciona uma observação ao check-in
 func (c *Checkin) AddNote(note string, updatedBy value_objects.UUID) error {
 	note = strings.TrimSpace(note)
@@ -169,6 +267,75 @@ func (c *Checkin) AddNote(note string, updatedBy value_objects.UUID) error {
 	return nil
 }
 
+// Void anula o check-in mantendo o registro original.
+// Check-ins anulados não contam em estatísticas nem em sessões de trabalho.
+func (c *Checkin) Void(reason string, voidedBy value_objects.UUID) error {
+	if c.IsVoided() {
+		return errors.NewValidationError("Checkin", "check-in já foi anulado").
+			WithContext("reason_code", constants.EligibilityCheckinVoided)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.NewValidationError("VoidReason", "é obrigatório")
+	}
+
+	if len(reason) > constants.MaxVoidReasonLength {
+		return errors.NewValidationError("VoidReason", fmt.Sprintf("deve ter no máximo %d caracteres", constants.MaxVoidReasonLength))
+	}
+
+	now := time.Now().UTC()
+	c.VoidedAt = &now
+	c.VoidedBy = &voidedBy
+	c.VoidReason = reason
+	c.UpdatedAt = now
+	c.UpdatedBy = &voidedBy
+
+	return nil
+}
+
+// CanAttachPhoto verifica se o check-in pode receber a foto de evidência.
+// A evidência é imutável: um check-in com foto não aceita outra.
+func (c *Checkin) CanAttachPhoto() error {
+	if c.IsVoided() {
+		return errors.NewValidationError("Checkin", "check-in anulado não aceita foto").
+			WithContext("reason_code", constants.EligibilityCheckinVoided)
+	}
+
+	if c.HasPhoto() {
+		return errors.NewAlreadyExistsError("CheckinPhoto", "checkin_id", c.ID.String())
+	}
+
+	return nil
+}
+
+// AttachPhoto associa a foto de evidência gravada no armazenamento de arquivos
+func (c *Checkin) AttachPhoto(photoKey string, updatedBy value_objects.UUID) error {
+	if err := c.CanAttachPhoto(); err != nil {
+		return err
+	}
+
+	if photoKey == "" || len(photoKey) > 500 {
+		return errors.NewValidationError("PhotoKey", "deve ter entre 1 e 500 caracteres")
+	}
+
+	c.PhotoKey = photoKey
+	c.UpdatedAt = time.Now()
+	c.UpdatedBy = &updatedBy
+
+	return nil
+}
+
+// IsVoided verifica se o check-in foi anulado
+func (c *Checkin) IsVoided() bool {
+	return c.VoidedAt != nil
+}
+
+// IsReplacement verifica se o check-in substitui um check-in anulado
+func (c *Checkin) IsReplacement() bool {
+	return c.ReplacesID != nil
+}
+
 // IsFacialRecognition verifica se o check-in foi feito por reconhecimento facial
 func (c *Checkin) IsFacialRecognition() bool {
 	return c.Method == constants.CheckMethodFacialRecognition
@@ -186,7 +353,7 @@ func (c *Checkin) IsManual() bool {
 
 // HasPhoto verifica se o check-in tem foto
 func (c *Checkin) HasPhoto() bool {
-	return c.PhotoURL != ""
+	return c.PhotoURL != "" || c.PhotoKey != ""
 }
 
 // GetValidationDetail obtém um detalhe específico da validação
@@ -265,14 +432,23 @@ func (c *Checkin) String() string {
 type CheckinStatus string
 
 const (
-	CheckinStatusPending   CheckinStatus = "pending"   // Aguardando validação
+	CheckinStatusPending   CheckinStatus = "pending"   // Aguardando validação ou aprovação do supervisor
 	CheckinStatusValid     CheckinStatus = "valid"     // Válido
 	CheckinStatusInvalid   CheckinStatus = "invalid"   // Inválido
 	CheckinStatusCancelled CheckinStatus = "cancelled" // Cancelado
+	CheckinStatusVoided    CheckinStatus = "voided"    // Anulado por erro do operador
 )
 
 // GetStatus retorna o status atual do check-in
 func (c *Checkin) GetStatus() CheckinStatus {
+	if c.IsVoided() {
+		return CheckinStatusVoided
+	}
+
+	if c.IsPendingApproval() {
+		return CheckinStatusPending
+	}
+
 	if c.IsValid {
 		return CheckinStatusValid
 	}
@@ -333,3 +509,32 @@ func (vr *ValidationResult) AddDetail(key string, value interface{}) *Validation
 	vr.Details[key] = value
 	return vr
 }
+
+// Merge combina outro resultado de validação a este.
+// O resultado só permanece válido se ambos forem válidos; o motivo da primeira falha é preservado.
+func (vr *ValidationResult) Merge(other *ValidationResult) *ValidationResult {
+	if other == nil {
+		return vr
+	}
+
+	if vr.IsValid && !other.IsValid {
+		vr.Reason = other.Reason
+	}
+	vr.IsValid = vr.IsValid && other.IsValid
+
+	for key, value := range other.Details {
+		vr.Details[key] = value
+	}
+
+	if other.DistanceFromEvent != nil {
+		vr.DistanceFromEvent = other.DistanceFromEvent
+	}
+	if other.FacialSimilarity != nil {
+		vr.FacialSimilarity = other.FacialSimilarity
+	}
+	if other.WithinBounds != nil {
+		vr.WithinBounds = other.WithinBounds
+	}
+
+	return vr
+}
@@ -11,12 +11,19 @@ import (
 
 // Repository define a interface para operações de persistência de check-ins
 type Repository interface {
-	// Create cria um novo check-in
+	// Create cria um novo check-in; retorna um erro ALREADY_EXISTS quando o funcionário já tem sessão aberta no evento,
+	// verificação que vale também para gravações simultâneas
 	Create(ctx context.Context, checkin *Checkin) error
 
-	// GetByID busca um check-in por ID
+	// GetByID busca um check-in por ID; o embedding facial permanece cifrado em SealedEmbedding
 	GetByID(ctx context.Context, id value_objects.UUID) (*Checkin, error)
 
+	// OpenFaceEmbedding decifra o embedding capturado no check-in, usado apenas na comparação facial do check-out
+	OpenFaceEmbedding(ctx context.Context, checkin *Checkin) ([]float32, error)
+
+	// GetByClientID busca um check-in pelo identificador gerado no dispositivo (sincronização offline)
+	GetByClientID(ctx context.Context, clientID value_objects.UUID) (*Checkin, error)
+
 	// Update atualiza um check-in existente
 	Update(ctx context.Context, checkin *Checkin) error
 
@@ -44,6 +51,18 @@ type Repository interface {
 	// ExistsByEmployeeAndEvent verifica se já existe check-in do funcionário no evento
 	ExistsByEmployeeAndEvent(ctx context.Context, employeeID, eventID value_objects.UUID) (bool, error)
 
+	// GetOpenByEmployeeAndEvent busca a sessão aberta (check-in válido ou aguardando aprovação, sem check-out) do funcionário no evento
+	GetOpenByEmployeeAndEvent(ctx context.Context, employeeID, eventID value_objects.UUID) (*Checkin, error)
+
+	// HasActiveCheckout verifica se o check-in possui check-out não anulado
+	HasActiveCheckout(ctx context.Context, checkinID value_objects.UUID) (bool, error)
+
+	// ExistsReplacement verifica se o check-in anulado já foi substituído por outro registro
+	ExistsReplacement(ctx context.Context, voidedID value_objects.UUID) (bool, error)
+
+	// ListStaleOpenSessions lista sessões abertas de eventos encerrados ou que excederam a duração máxima da sessão
+	ListStaleOpenSessions(ctx context.Context, now time.Time, limit int) ([]*Checkin, error)
+
 	// GetByDateRange busca check-ins em um período
 	GetByDateRange(ctx context.Context, tenantID value_objects.UUID, startDate, endDate time.Time, filters ListFilters) ([]*Checkin, int, error)
 
@@ -84,6 +103,10 @@ type ListFilters struct {
 	Method   *string
 	IsValid  *bool
 	HasPhoto *bool
+	Voided   *bool // nil inclui check-ins anulados na listagem
+
+	// Fila de aprovação
+	ApprovalStatus *string // pending, approved, rejected
 
 	// Filtros temporais
 	StartDate *time.Time
@@ -216,6 +239,16 @@ func (f *ListFilters) HasValidFilter() bool {
 	return f.IsValid != nil
 }
 
+// HasVoidedFilter verifica se há filtro por anulação
+func (f *ListFilters) HasVoidedFilter() bool {
+	return f.Voided != nil
+}
+
+// HasApprovalStatusFilter verifica se há filtro por status de aprovação
+func (f *ListFilters) HasApprovalStatusFilter() bool {
+	return f.ApprovalStatus != nil && *f.ApprovalStatus != ""
+}
+
 // HasPhotoFilter verifica se há filtro por foto
 func (f *ListFilters) HasPhotoFilter() bool {
 	return f.HasPhoto != nil
@@ -284,23 +317,41 @@ type CheckinStats struct {
 	CheckinsToday     int
 	CheckinsThisWeek  int
 	CheckinsThisMonth int
+	UniqueEmployees   int
+	OpenSessions      int // funcionários presentes: check-ins válidos ou pendentes sem check-out
 	AveragePerDay     float64
+	FirstCheckinTime  *time.Time
 	LastCheckinTime   *time.Time
 }
 
+// PartnerCheckinStats representa a presença de um parceiro em um evento
+type PartnerCheckinStats struct {
+	PartnerID       value_objects.UUID
+	TotalCheckins   int
+	ValidCheckins   int
+	InvalidCheckins int
+	PendingCheckins int
+	UniqueEmployees int
+	OpenSessions    int
+	TotalWorkHours  float64
+}
+
 // StatsRepository define operações para estatísticas de check-ins
 type StatsRepository interface {
 	// GetTenantStats obtém estatísticas de um tenant
 	GetTenantStats(ctx context.Context, tenantID value_objects.UUID) (*CheckinStats, error)
 
 	// GetEventStats obtém estatísticas de um evento
-	GetEventStats(ctx context.Context, eventID value_objects.UUID) (*CheckinStats, error)
+	GetEventStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*CheckinStats, error)
 
 	// GetEmployeeStats obtém estatísticas de um funcionário
-	GetEmployeeStats(ctx context.Context, employeeID value_objects.UUID) (*CheckinStats, error)
+	GetEmployeeStats(ctx context.Context, tenantID, employeeID value_objects.UUID) (*CheckinStats, error)
 
 	// GetPartnerStats obtém estatísticas de um parceiro
-	GetPartnerStats(ctx context.Context, partnerID value_objects.UUID) (*CheckinStats, error)
+	GetPartnerStats(ctx context.Context, tenantID, partnerID value_objects.UUID) (*CheckinStats, error)
+
+	// GetEventPartnerStats obtém as estatísticas de um evento agrupadas por parceiro
+	GetEventPartnerStats(ctx context.Context, tenantID, eventID value_objects.UUID) ([]*PartnerCheckinStats, error)
 
 	// GetDailyStats obtém estatísticas diárias
 	GetDailyStats(ctx context.Context, tenantID value_objects.UUID, date time.Time) (*CheckinStats, error)
@@ -310,4 +361,7 @@ type StatsRepository interface {
 
 	// GetMonthlyStats obtém estatísticas mensais
 	GetMonthlyStats(ctx context.Context, tenantID value_objects.UUID, year int, month time.Month) (*CheckinStats, error)
+
+	// InvalidateSessionStats descarta as estatísticas em cache afetadas por uma sessão anulada, revisada ou corrigida
+	InvalidateSessionStats(ctx context.Context, tenantID, eventID, employeeID, partnerID value_objects.UUID)
 }
@@ -2,12 +2,22 @@ package checkin
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"io"
 	"time"
 
+	"eventos-backend/internal/domain/employee"
+	"eventos-backend/internal/domain/event"
+	"eventos-backend/internal/domain/fraud"
+	"eventos-backend/internal/domain/occupancy"
+	"eventos-backend/internal/domain/partner"
+	"eventos-backend/internal/domain/qrcode"
 	"eventos-backend/internal/domain/shared/constants"
 	"eventos-backend/internal/domain/shared/errors"
 	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/domain/storage"
+	"eventos-backend/internal/domain/tenant"
 )
 
 // Service define a interface para serviços de domínio de check-ins
@@ -15,6 +25,9 @@ type Service interface {
 	// PerformCheckin realiza um check-in com validações completas
 	PerformCheckin(ctx context.Context, request CheckinRequest) (*Checkin, *ValidationResult, error)
 
+	// PerformWalkUpCheckin identifica o funcionário pelo rosto entre os participantes do evento e realiza o check-in
+	PerformWalkUpCheckin(ctx context.Context, request WalkUpRequest) (*Checkin, *ValidationResult, error)
+
 	// ValidateCheckin valida um check-in existente
 	ValidateCheckin(ctx context.Context, checkinID value_objects.UUID, validationResult *ValidationResult, validatedBy value_objects.UUID) error
 
@@ -30,23 +43,49 @@ type Service interface {
 	// GetEventCheckins busca check-ins de um evento
 	GetEventCheckins(ctx context.Context, eventID value_objects.UUID, filters ListFilters) ([]*Checkin, int, error)
 
-	// CanEmployeeCheckin verifica se funcionário pode fazer check-in no evento
-	CanEmployeeCheckin(ctx context.Context, employeeID, eventID value_objects.UUID) (bool, string, error)
+	// CanEmployeeCheckin verifica se funcionário pode fazer check-in no evento pelo parceiro informado.
+	// Funcionário, evento e parceiro devem pertencer ao tenant do usuário autenticado.
+	// Quando não puder, retorna o código do motivo (constants.Eligibility*).
+	CanEmployeeCheckin(ctx context.Context, tenantID, employeeID, eventID, partnerID value_objects.UUID) (bool, string, error)
 
 	// GetCheckinStats obtém estatísticas de check-ins
 	GetCheckinStats(ctx context.Context, tenantID value_objects.UUID) (*CheckinStats, error)
 
+	// GetEventCheckinStats obtém estatísticas de check-ins de um evento
+	GetEventCheckinStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*CheckinStats, error)
+
+	// GetEventPartnerStats obtém estatísticas de check-ins de um evento por parceiro
+	GetEventPartnerStats(ctx context.Context, tenantID, eventID value_objects.UUID) ([]*PartnerCheckinStats, error)
+
 	// AddCheckinNote adiciona observação a um check-in
 	AddCheckinNote(ctx context.Context, checkinID value_objects.UUID, note string, updatedBy value_objects.UUID) error
 
 	// GetRecentCheckins busca check-ins recentes
 	GetRecentCheckins(ctx context.Context, tenantID value_objects.UUID, limit int) ([]*Checkin, error)
 
+	// VoidCheckin anula um check-in registrado por engano, mantendo o registro original
+	VoidCheckin(ctx context.Context, checkinID, tenantID value_objects.UUID, reason string, voidedBy value_objects.UUID) (*Checkin, error)
+
+	// ListPendingCheckins lista a fila de check-ins aguardando aprovação do supervisor
+	ListPendingCheckins(ctx context.Context, tenantID value_objects.UUID, filters ListFilters) ([]*Checkin, int, error)
+
+	// ApproveCheckin aprova um check-in pendente
+	ApproveCheckin(ctx context.Context, checkinID, tenantID value_objects.UUID, comment string, reviewedBy value_objects.UUID) (*Checkin, error)
+
+	// RejectCheckin rejeita um check-in pendente
+	RejectCheckin(ctx context.Context, checkinID, tenantID value_objects.UUID, comment string, reviewedBy value_objects.UUID) (*Checkin, error)
+
+	// AttachPhoto grava a foto de evidência enviada e a associa ao check-in
+	AttachPhoto(ctx context.Context, checkinID, tenantID value_objects.UUID, contentType string, size int64, content io.Reader, updatedBy value_objects.UUID) (*Checkin, error)
+
 	// ValidateFacialRecognition valida check-in por reconhecimento facial
 	ValidateFacialRecognition(ctx context.Context, checkin *Checkin, faceEmbedding []float32) (*ValidationResult, error)
 
+	// ValidateQRCode valida e consome o QR Code lido no check-in
+	ValidateQRCode(ctx context.Context, checkin *Checkin, qrCodeData string) (*ValidationResult, error)
+
 	// ValidateGeolocation valida localização do check-in
-	ValidateGeolocation(ctx context.Context, checkin *Checkin, eventLocation value_objects.Location, eventFence []value_objects.Location) (*ValidationResult, error)
+	ValidateGeolocation(ctx context.Context, checkin *Checkin, evt *event.Event) (*ValidationResult, error)
 
 	// ValidateEventTiming valida horário do check-in em relação ao evento
 	ValidateEventTiming(ctx context.Context, checkin *Checkin, eventStartTime, eventEndTime time.Time) (*ValidationResult, error)
@@ -60,11 +99,26 @@ type CheckinRequest struct {
 	PartnerID     value_objects.UUID
 	Method        string
 	Location      value_objects.Location
+	LocationFix   *value_objects.LocationFix // Precisão, provedor e simulação da leitura de GPS
 	PhotoURL      string
 	Notes         string
 	FaceEmbedding []float32 // Para reconhecimento facial
 	QRCodeData    string    // Para check-in via QR Code
 	CreatedBy     value_objects.UUID
+	ReplacesID    *value_objects.UUID // Check-in anulado que este registro substitui
+
+	// Sincronização offline
+	ClientID   *value_objects.UUID // Identificador gerado no dispositivo
+	DeviceID   string              // Dispositivo que registrou o check-in
+	RecordedAt *time.Time          // Horário registrado no dispositivo
+}
+
+// checkinTime retorna o horário efetivo do check-in (horário do dispositivo quando informado)
+func (r *CheckinRequest) checkinTime() time.Time {
+	if r.RecordedAt != nil && !r.RecordedAt.IsZero() {
+		return r.RecordedAt.UTC()
+	}
+	return time.Now().UTC()
 }
 
 // Validate valida a requisição de check-in
@@ -105,7 +159,7 @@ func (r *CheckinRequest) Validate() error {
 			return errors.NewValidationError("FaceEmbedding", "é obrigatório para reconhecimento facial")
 		}
 
-		if len(r.FaceEmbedding) != 512 {
+		if len(r.FaceEmbedding) != constants.FaceEmbeddingDimensions {
 			return errors.NewValidationError("FaceEmbedding", "deve ter exatamente 512 dimensões")
 		}
 	}
@@ -119,17 +173,85 @@ func (r *CheckinRequest) Validate() error {
 	return nil
 }
 
+// WalkUpRequest representa um check-in em que o dispositivo envia apenas o rosto capturado e o evento
+type WalkUpRequest struct {
+	TenantID      value_objects.UUID
+	EventID       value_objects.UUID
+	FaceEmbedding []float32
+	Location      value_objects.Location
+	LocationFix   *value_objects.LocationFix
+	Notes         string
+	DeviceID      string
+	CreatedBy     value_objects.UUID
+}
+
+// Validate valida a requisição de check-in por identificação facial
+func (r *WalkUpRequest) Validate() error {
+	if r.TenantID.IsZero() {
+		return errors.NewValidationError("TenantID", "é obrigatório")
+	}
+
+	if r.EventID.IsZero() {
+		return errors.NewValidationError("EventID", "é obrigatório")
+	}
+
+	if r.CreatedBy.IsZero() {
+		return errors.NewValidationError("CreatedBy", "é obrigatório")
+	}
+
+	if len(r.FaceEmbedding) != constants.FaceEmbeddingDimensions {
+		return errors.NewValidationError("FaceEmbedding", "deve ter exatamente 512 dimensões")
+	}
+
+	return nil
+}
+
+// Config contém os parâmetros configuráveis das validações de check-in
+type Config struct {
+	FacialSimilarityThreshold float32 // Similaridade mínima para aceitar o reconhecimento facial
+	FacialApprovalThreshold   float32 // Similaridade abaixo da qual um reconhecimento aceito exige aprovação (0 desativa)
+	WalkUpSimilarityMargin    float32 // Vantagem mínima do funcionário identificado sobre o segundo candidato
+}
+
 // serviceImpl implementa a interface Service
 type serviceImpl struct {
-	repo      Repository
-	statsRepo StatsRepository
+	repo         Repository
+	statsRepo    StatsRepository
+	employeeRepo employee.Repository
+	eventRepo    event.Repository
+	partnerRepo  partner.Repository
+	tenantRepo   tenant.Repository
+	qrService    qrcode.Service
+	occupancy    occupancy.Service
+	fraud        fraud.Service
+	photos       storage.Service
+	config       Config
 }
 
-// NewService cria uma nova instância do serviço
-func NewService(repo Repository, statsRepo StatsRepository) Service {
+// NewService cria uma nova instância do serviço; sem occupancyService a capacidade dos eventos não é aplicada,
+// sem fraudService os check-ins não passam pela análise de fraude, sem tenantRepo localizações simuladas são aceitas
+// e sem photos fotos de evidência não podem ser anexadas
+func NewService(repo Repository, statsRepo StatsRepository, employeeRepo employee.Repository, eventRepo event.Repository, partnerRepo partner.Repository, tenantRepo tenant.Repository, qrService qrcode.Service, occupancyService occupancy.Service, fraudService fraud.Service, photos storage.Service, config Config) Service {
+	if config.FacialSimilarityThreshold <= 0 || config.FacialSimilarityThreshold > 1 {
+		config.FacialSimilarityThreshold = constants.DefaultFacialSimilarityThreshold
+	}
+
+	if config.WalkUpSimilarityMargin <= 0 || config.WalkUpSimilarityMargin > 1 {
+		config.WalkUpSimilarityMargin = constants.DefaultWalkUpSimilarityMargin
+	}
+
 	return &serviceImpl{
-		repo:      repo,
-		statsRepo: statsRepo,
+		repo:         repo,
+		statsRepo:    statsRepo,
+		employeeRepo: employeeRepo,
+		eventRepo:    eventRepo,
+		partnerRepo:  partnerRepo,
+		tenantRepo:   tenantRepo,
+		qrService:    qrService,
+		occupancy:    occupancyService,
+		fraud:        fraudService,
+		photos:       photos,
+		config:       config,
 	}
 }
 
@@ -140,24 +262,35 @@ func (s *serviceImpl) PerformCheckin(ctx context.Context, request CheckinRequest
 		return nil, nil, err
 	}
 
-	// Verificar se funcionário já fez check-in no evento
-	exists, err := s.repo.ExistsByEmployeeAndEvent(ctx, request.EmployeeID, request.EventID)
-	if err != nil {
-		return nil, nil, errors.NewInternalError("Erro ao verificar check-in existente", err)
+	// Substituição de check-in anulado
+	if request.ReplacesID != nil {
+		if err := s.validateReplacement(ctx, request); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	if exists {
-		return nil, nil, errors.NewAlreadyExistsError("Checkin", "employee_event", fmt.Sprintf("%s-%s", request.EmployeeID.String(), request.EventID.String()))
+	// Um novo check-in (reentrada ou novo turno) só é bloqueado enquanto houver sessão aberta no evento
+	openSession, err := s.repo.GetOpenByEmployeeAndEvent(ctx, request.EmployeeID, request.EventID)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, nil, errors.NewInternalError("Erro ao verificar sessão aberta", err)
 	}
 
-	// Verificar se funcionário pode fazer check-in
-	canCheckin, reason, err := s.CanEmployeeCheckin(ctx, request.EmployeeID, request.EventID)
+	if openSession != nil {
+		return nil, nil, errors.NewAlreadyExistsError("Checkin", "open_session", fmt.Sprintf("%s-%s", request.EmployeeID.String(), request.EventID.String())).
+			WithContext("reason_code", constants.EligibilitySessionAlreadyOpen).
+			WithContext("open_checkin_id", openSession.ID.String())
+	}
+
+	// Verificar se funcionário pode fazer check-in no horário em que foi registrado
+	checkinTime := request.checkinTime()
+	canCheckin, reasonCode, err := s.checkEligibility(ctx, request.TenantID, request.EmployeeID, request.EventID, request.PartnerID, checkinTime)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	if !canCheckin {
-		return nil, nil, errors.NewValidationError("Checkin", reason)
+		return nil, nil, errors.NewValidationError("Checkin", eligibilityMessages[reasonCode]).
+			WithContext("reason_code", reasonCode)
 	}
 
 	// Criar check-in
@@ -176,42 +309,315 @@ func (s *serviceImpl) PerformCheckin(ctx context.Context, request CheckinRequest
 		return nil, nil, err
 	}
 
-	// Salvar check-in
-	if err := s.repo.Create(ctx, checkin); err != nil {
-		return nil, nil, errors.NewInternalError("Erro ao criar check-in", err)
+	checkin.CheckinTime = checkinTime
+	checkin.ClientID = request.ClientID
+	checkin.LocationFix = request.LocationFix
+	checkin.DeviceID = request.DeviceID
+	checkin.ReplacesID = request.ReplacesID
+
+	// Guardar o embedding capturado para comparação no check-out
+	if len(request.FaceEmbedding) > 0 {
+		checkin.FaceEmbedding = request.FaceEmbedding
 	}
 
-	// Iniciar validação assíncrona (por enquanto, validação básica)
-	validationResult := s.performBasicValidation(checkin)
+	// Validar check-in de acordo com o método utilizado
+	validationResult, err := s.performValidation(ctx, checkin, request)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// Atualizar check-in com resultado da validação
+	// Aplicar resultado da validação; check-ins sem evidência suficiente aguardam aprovação
 	if validationResult.IsValid {
-		checkin.MarkAsValid(validationResult.Details, request.CreatedBy)
+		if reason, required := s.approvalReason(checkin, validationResult); required {
+			validationResult.Reason = "Check-in aguardando aprovação do supervisor"
+			checkin.MarkAsPendingApproval(validationResult.Details, reason, request.CreatedBy)
+		} else {
+			checkin.MarkAsValid(validationResult.Details, request.CreatedBy)
+		}
 	} else {
 		checkin.MarkAsInvalid(validationResult.Details, request.CreatedBy)
 	}
 
-	// Salvar check-in atualizado
-	if err := s.repo.Update(ctx, checkin); err != nil {
-		return nil, nil, errors.NewInternalError("Erro ao atualizar check-in", err)
+	// Reservar a vaga de quem passa a estar presente no evento
+	admitted, err := s.admit(ctx, checkin)
+	if err != nil {
+		s.releaseQRCode(ctx, validationResult)
+		return nil, nil, err
+	}
+
+	// Sinalizações de fraude não bloqueiam o check-in; ficam gravadas para revisão
+	fraudSubject, fraudFlags := s.analyzeFraud(ctx, checkin)
+
+	// Salvar check-in
+	if err := s.repo.Create(ctx, checkin); err != nil {
+		if admitted {
+			s.occupancy.Release(ctx, checkin.EventID, checkin.PartnerID)
+		}
+		s.releaseQRCode(ctx, validationResult)
+		// Outra requisição abriu a sessão entre a verificação acima e a gravação
+		var domainErr *errors.DomainError
+		if stderrors.As(err, &domainErr) && stderrors.Is(err, errors.ErrAlreadyExists) {
+			return nil, nil, domainErr.WithContext("reason_code", constants.EligibilitySessionAlreadyOpen)
+		}
+		return nil, nil, errors.NewInternalError("Erro ao criar check-in", err)
+	}
+
+	if len(fraudFlags) > 0 {
+		s.fraud.ReportFlags(ctx, fraudSubject, fraudFlags)
 	}
 
 	return checkin, validationResult, nil
 }
 
-// performBasicValidation realiza validação básica do check-in
-func (s *serviceImpl) performBasicValidation(checkin *Checkin) *ValidationResult {
-	// Por enquanto, validação simples - todos os check-ins são considerados válidos
-	// Em uma implementação completa, aqui seria feita a validação com:
-	// - Dados do evento (localização, horário)
-	// - Dados do funcionário (foto, embedding facial)
-	// - Regras de negócio específicas
+// PerformWalkUpCheckin identifica o funcionário pelo rosto entre os participantes do evento e realiza o check-in.
+// A identificação é recusada quando ninguém atinge o limite de similaridade ou quando dois candidatos ficam próximos demais.
+func (s *serviceImpl) PerformWalkUpCheckin(ctx context.Context, request WalkUpRequest) (*Checkin, *ValidationResult, error) {
+	if err := request.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	// Os dois mais parecidos são buscados sem limite mínimo: a margem é verificada contra o segundo candidato real,
+	// mesmo que ele fique abaixo do limite, e só então o limite é aplicado ao melhor
+	matches, err := s.employeeRepo.FindByFaceEmbeddingInEvent(ctx, request.TenantID, request.EventID, request.FaceEmbedding, -1, 2)
+	if err != nil {
+		return nil, nil, errors.NewInternalError("Erro ao identificar funcionário", err)
+	}
+
+	if len(matches) == 0 {
+		return nil, nil, errors.NewValidationError("FaceEmbedding", "nenhum funcionário do evento reconhecido").
+			WithContext("reason_code", constants.EligibilityFaceNotRecognized)
+	}
+
+	best := matches[0]
+	if len(matches) > 1 && best.Similarity-matches[1].Similarity < s.config.WalkUpSimilarityMargin {
+		return nil, nil, errors.NewValidationError("FaceEmbedding", "mais de um funcionário do evento corresponde ao rosto").
+			WithContext("reason_code", constants.EligibilityFaceAmbiguous)
+	}
+
+	if best.Similarity < s.config.FacialSimilarityThreshold {
+		return nil, nil, errors.NewValidationError("FaceEmbedding", "nenhum funcionário do evento reconhecido").
+			WithContext("reason_code", constants.EligibilityFaceNotRecognized)
+	}
+
+	return s.PerformCheckin(ctx, CheckinRequest{
+		TenantID:      request.TenantID,
+		EventID:       request.EventID,
+		EmployeeID:    best.Employee.ID,
+		PartnerID:     best.PartnerID,
+		Method:        constants.CheckMethodFacialRecognition,
+		Location:      request.Location,
+		LocationFix:   request.LocationFix,
+		Notes:         request.Notes,
+		FaceEmbedding: request.FaceEmbedding,
+		DeviceID:      request.DeviceID,
+		CreatedBy:     request.CreatedBy,
+	})
+}
+
+// analyzeFraud verifica os padrões de fraude do check-in e grava as sinalizações nos detalhes de validação.
+// Uma análise incompleta também é registrada, para que o check-in não pareça verificado.
+func (s *serviceImpl) analyzeFraud(ctx context.Context, checkin *Checkin) (fraud.Subject, []fraud.Flag) {
+	subject := fraud.Subject{
+		CheckinID:     checkin.ID,
+		TenantID:      checkin.TenantID,
+		EventID:       checkin.EventID,
+		EmployeeID:    checkin.EmployeeID,
+		DeviceID:      checkin.DeviceID,
+		Location:      checkin.Location,
+		At:            checkin.CheckinTime,
+		FaceEmbedding: checkin.FaceEmbedding,
+	}
+
+	if s.fraud == nil {
+		return subject, nil
+	}
+
+	flags, err := s.fraud.AnalyzeCheckin(ctx, subject)
+	if err != nil {
+		checkin.ValidationDetails[fraud.DetailsIncompleteKey] = true
+	}
+
+	if len(flags) > 0 {
+		checkin.ValidationDetails[fraud.DetailsFlagsKey] = flags
+	}
+
+	return subject, flags
+}
+
+// admit reserva uma vaga no evento para check-ins que contam na ocupação.
+// Retorna erro de validação com o código do motivo quando a capacidade do evento ou do parceiro foi atingida.
+func (s *serviceImpl) admit(ctx context.Context, checkin *Checkin) (bool, error) {
+	if s.occupancy == nil || !checkin.OccupiesSpot() {
+		return false, nil
+	}
+
+	allowed, reasonCode, err := s.occupancy.Admit(ctx, checkin.EventID, checkin.PartnerID)
+	if err != nil {
+		return false, err
+	}
+
+	if !allowed {
+		return false, errors.NewValidationError("Checkin", eligibilityMessages[reasonCode]).
+			WithContext("reason_code", reasonCode)
+	}
+
+	return true, nil
+}
+
+// validateReplacement verifica se o check-in substituído está anulado, ainda não foi substituído
+// e pertence ao mesmo funcionário e evento
+func (s *serviceImpl) validateReplacement(ctx context.Context, request CheckinRequest) error {
+	replaced, err := s.repo.GetByID(ctx, *request.ReplacesID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return errors.NewValidationError("ReplacesID", "check-in substituído não encontrado").
+				WithContext("reason_code", constants.EligibilityReplacementInvalid)
+		}
+		return errors.NewInternalError("Erro ao buscar check-in substituído", err)
+	}
+
+	if !replaced.TenantID.Equals(request.TenantID) ||
+		!replaced.EmployeeID.Equals(request.EmployeeID) ||
+		!replaced.EventID.Equals(request.EventID) {
+		return errors.NewValidationError("ReplacesID", "check-in substituído não pertence ao funcionário e evento informados").
+			WithContext("reason_code", constants.EligibilityReplacementInvalid)
+	}
+
+	if !replaced.IsVoided() {
+		return errors.NewValidationError("ReplacesID", "apenas check-ins anulados podem ser substituídos").
+			WithContext("reason_code", constants.EligibilityReplacementInvalid)
+	}
+
+	replacedAlready, err := s.repo.ExistsReplacement(ctx, replaced.ID)
+	if err != nil {
+		return errors.NewInternalError("Erro ao verificar substituição do check-in", err)
+	}
+
+	if replacedAlready {
+		return errors.NewAlreadyExistsError("Checkin", "replaces_id", replaced.ID.String()).
+			WithContext("reason_code", constants.EligibilityReplacementInvalid)
+	}
 
+	return nil
+}
+
+// performValidation executa as validações aplicáveis ao check-in
+func (s *serviceImpl) performValidation(ctx context.Context, checkin *Checkin, request CheckinRequest) (*ValidationResult, error) {
 	result := NewValidationResult(true, "Check-in realizado com sucesso")
-	result.AddDetail("validation_method", "basic")
+	result.AddDetail("validation_method", checkin.Method)
 	result.AddDetail("validation_timestamp", time.Now())
 
-	return result
+	if checkin.IsFacialRecognition() {
+		facialResult, err := s.ValidateFacialRecognition(ctx, checkin, request.FaceEmbedding)
+		if err != nil {
+			return nil, err
+		}
+		result.Merge(facialResult)
+	}
+
+	if checkin.LocationFix != nil {
+		fixResult, err := s.validateLocationFix(ctx, checkin)
+		if err != nil {
+			return nil, err
+		}
+		result.Merge(fixResult)
+	}
+
+	// A cerca é verificada mesmo sem localização: em evento com cerca, a ausência invalida o registro
+	evt, err := s.eventRepo.GetByID(ctx, checkin.EventID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Evento não encontrado", err)
+	}
+
+	geoResult, err := s.ValidateGeolocation(ctx, checkin, evt)
+	if err != nil {
+		return nil, err
+	}
+	result.Merge(geoResult)
+
+	// O QR Code é consumido por último: nenhuma outra validação pode falhar depois de registrado o uso
+	if checkin.IsQRCode() {
+		qrResult, err := s.ValidateQRCode(ctx, checkin, request.QRCodeData)
+		if err != nil {
+			return nil, err
+		}
+		result.Merge(qrResult)
+	}
+
+	return result, nil
+}
+
+// releaseQRCode devolve o uso do QR Code consumido na validação quando o check-in não chega a ser gravado
+func (s *serviceImpl) releaseQRCode(ctx context.Context, result *ValidationResult) {
+	qrCodeID, ok := result.Details["qr_code_id"].(string)
+	if !ok || s.qrService == nil {
+		return
+	}
+
+	if id, err := value_objects.ParseUUID(qrCodeID); err == nil {
+		s.qrService.ReleaseQRCode(ctx, id)
+	}
+}
+
+// validateLocationFix registra os metadados da leitura de GPS e aplica a política do tenant para localizações simuladas
+func (s *serviceImpl) validateLocationFix(ctx context.Context, checkin *Checkin) (*ValidationResult, error) {
+	fix := checkin.LocationFix
+
+	result := NewValidationResult(true, "Leitura de GPS registrada")
+	result.AddDetail("location_accuracy_meters", fix.Accuracy)
+	result.AddDetail("location_mock", fix.IsMock)
+	if fix.Provider != "" {
+		result.AddDetail("location_provider", fix.Provider)
+	}
+	if age, ok := fix.Age(checkin.CheckinTime); ok {
+		result.AddDetail("location_fix_age_seconds", age.Seconds())
+	}
+
+	if !fix.IsMock {
+		return result, nil
+	}
+
+	reject, err := s.rejectsMockLocation(ctx, checkin.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	result.AddDetail("mock_location_rejected", reject)
+	if reject {
+		result.IsValid = false
+		result.Reason = "Localização simulada não é aceita pelo tenant"
+	}
+
+	return result, nil
+}
+
+// rejectsMockLocation indica se o tenant rejeita localizações simuladas
+func (s *serviceImpl) rejectsMockLocation(ctx context.Context, tenantID value_objects.UUID) (bool, error) {
+	if s.tenantRepo == nil {
+		return false, nil
+	}
+
+	t, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return false, errors.NewInternalError("Erro ao buscar política de localização do tenant", err)
+	}
+
+	return t != nil && t.RejectMockLocations, nil
+}
+
+// approvalReason indica se o check-in precisa de aprovação do supervisor e o motivo
+func (s *serviceImpl) approvalReason(checkin *Checkin, result *ValidationResult) (string, bool) {
+	if checkin.IsManual() {
+		return constants.ApprovalReasonManual, true
+	}
+
+	threshold := s.config.FacialApprovalThreshold
+	if checkin.IsFacialRecognition() && threshold > 0 && result.FacialSimilarity != nil &&
+		*result.FacialSimilarity < float64(threshold) {
+		return constants.ApprovalReasonLowFacialConfidence, true
+	}
+
+	return "", false
 }
 
 // ValidateCheckin valida um check-in existente
@@ -286,15 +692,102 @@ func (s *serviceImpl) GetEventCheckins(ctx context.Context, eventID value_object
 	return checkins, total, nil
 }
 
+// eligibilityMessages descreve os códigos de inelegibilidade para o operador
+var eligibilityMessages = map[string]string{
+	constants.EligibilityEmployeeNotFound:     "funcionário não encontrado",
+	constants.EligibilityEmployeeInactive:     "funcionário inativo",
+	constants.EligibilityEventNotFound:        "evento não encontrado",
+	constants.EligibilityEventInactive:        "evento inativo",
+	constants.EligibilityEventNotStarted:      "evento ainda não começou",
+	constants.EligibilityEventFinished:        "evento já terminou",
+	constants.EligibilityTenantMismatch:       "funcionário, parceiro e evento pertencem a organizações diferentes",
+	constants.EligibilityPartnerNotFound:      "parceiro não encontrado",
+	constants.EligibilityPartnerInactive:      "parceiro inativo",
+	constants.EligibilityEmployeeNotInPartner: "funcionário não vinculado ao parceiro",
+	constants.EligibilityPartnerNotInEvent:    "parceiro não associado ao evento",
+	constants.EligibilityEventAtCapacity:      "evento atingiu a capacidade máxima",
+	constants.EligibilityPartnerAtCapacity:    "parceiro atingiu a capacidade máxima no evento",
+}
+
 // CanEmployeeCheckin verifica se funcionário pode fazer check-in no evento
-func (s *serviceImpl) CanEmployeeCheckin(ctx context.Context, employeeID, eventID value_objects.UUID) (bool, string, error) {
-	// TODO: Implementar validações completas:
-	// 1. Verificar se funcionário está ativo
-	// 2. Verificar se evento está ativo e em andamento
-	// 3. Verificar se funcionário está associado ao parceiro do evento
-	// 4. Verificar regras de negócio específicas
-
-	// Por enquanto, sempre permite check-in
+func (s *serviceImpl) CanEmployeeCheckin(ctx context.Context, tenantID, employeeID, eventID, partnerID value_objects.UUID) (bool, string, error) {
+	return s.checkEligibility(ctx, tenantID, employeeID, eventID, partnerID, time.Now().UTC())
+}
+
+// checkEligibility verifica a elegibilidade do check-in no instante informado.
+// O tenant é o do usuário autenticado: registros de outro tenant são recusados como TENANT_MISMATCH.
+func (s *serviceImpl) checkEligibility(ctx context.Context, tenantID, employeeID, eventID, partnerID value_objects.UUID, at time.Time) (bool, string, error) {
+	// 1. Funcionário deve existir e estar ativo
+	emp, err := s.employeeRepo.GetByID(ctx, employeeID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, constants.EligibilityEmployeeNotFound, nil
+		}
+		return false, "", errors.NewInternalError("Erro ao buscar funcionário", err)
+	}
+
+	if !emp.IsActive() {
+		return false, constants.EligibilityEmployeeInactive, nil
+	}
+
+	// 2. Evento deve existir, estar ativo e em andamento
+	evt, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, constants.EligibilityEventNotFound, nil
+		}
+		return false, "", errors.NewInternalError("Erro ao buscar evento", err)
+	}
+
+	if !evt.IsActive() {
+		return false, constants.EligibilityEventInactive, nil
+	}
+
+	if evt.IsUpcomingAt(at) {
+		return false, constants.EligibilityEventNotStarted, nil
+	}
+
+	if !evt.IsOngoingAt(at) {
+		return false, constants.EligibilityEventFinished, nil
+	}
+
+	// 3. Parceiro deve existir e estar ativo
+	ptn, err := s.partnerRepo.GetByID(ctx, partnerID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, constants.EligibilityPartnerNotFound, nil
+		}
+		return false, "", errors.NewInternalError("Erro ao buscar parceiro", err)
+	}
+
+	if !ptn.IsActive() {
+		return false, constants.EligibilityPartnerInactive, nil
+	}
+
+	if !evt.BelongsToTenant(tenantID) || !emp.BelongsToTenant(tenantID) || !ptn.BelongsToTenant(tenantID) {
+		return false, constants.EligibilityTenantMismatch, nil
+	}
+
+	// 4. Funcionário deve estar vinculado ao parceiro
+	linked, err := s.partnerRepo.IsEmployeeLinked(ctx, partnerID, employeeID)
+	if err != nil {
+		return false, "", errors.NewInternalError("Erro ao verificar vínculo do funcionário com o parceiro", err)
+	}
+
+	if !linked {
+		return false, constants.EligibilityEmployeeNotInPartner, nil
+	}
+
+	// 5. Parceiro deve estar associado ao evento
+	assigned, err := s.partnerRepo.IsAssignedToEvent(ctx, partnerID, eventID)
+	if err != nil {
+		return false, "", errors.NewInternalError("Erro ao verificar associação do parceiro ao evento", err)
+	}
+
+	if !assigned {
+		return false, constants.EligibilityPartnerNotInEvent, nil
+	}
+
 	return true, "", nil
 }
 
@@ -308,6 +801,26 @@ func (s *serviceImpl) GetCheckinStats(ctx context.Context, tenantID value_object
 	return stats, nil
 }
 
+// GetEventCheckinStats obtém estatísticas de check-ins de um evento
+func (s *serviceImpl) GetEventCheckinStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*CheckinStats, error) {
+	stats, err := s.statsRepo.GetEventStats(ctx, tenantID, eventID)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao obter estatísticas do evento", err)
+	}
+
+	return stats, nil
+}
+
+// GetEventPartnerStats obtém estatísticas de check-ins de um evento por parceiro
+func (s *serviceImpl) GetEventPartnerStats(ctx context.Context, tenantID, eventID value_objects.UUID) ([]*PartnerCheckinStats, error) {
+	stats, err := s.statsRepo.GetEventPartnerStats(ctx, tenantID, eventID)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao obter estatísticas por parceiro", err)
+	}
+
+	return stats, nil
+}
+
 // AddCheckinNote adiciona observação a um check-in
 func (s *serviceImpl) AddCheckinNote(ctx context.Context, checkinID value_objects.UUID, note string, updatedBy value_objects.UUID) error {
 	checkin, err := s.repo.GetByID(ctx, checkinID)
@@ -340,35 +853,241 @@ func (s *serviceImpl) GetRecentCheckins(ctx context.Context, tenantID value_obje
 	return checkins, nil
 }
 
+// VoidCheckin anula um check-in registrado por engano, mantendo o registro original.
+// Um check-in com check-out ativo só pode ser anulado após a anulação do check-out.
+func (s *serviceImpl) VoidCheckin(ctx context.Context, checkinID, tenantID value_objects.UUID, reason string, voidedBy value_objects.UUID) (*Checkin, error) {
+	checkin, err := s.repo.GetByID(ctx, checkinID)
+	if err != nil || !checkin.TenantID.Equals(tenantID) {
+		return nil, errors.NewNotFoundError("Checkin", checkinID.String())
+	}
+
+	hasCheckout, err := s.repo.HasActiveCheckout(ctx, checkinID)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao verificar check-out do check-in", err)
+	}
+
+	if hasCheckout {
+		return nil, errors.NewValidationError("Checkin", "anule o check-out antes de anular o check-in").
+			WithContext("reason_code", constants.EligibilityCheckinHasCheckout)
+	}
+
+	if err := checkin.Void(reason, voidedBy); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, checkin); err != nil {
+		return nil, errors.NewInternalError("Erro ao anular check-in", err)
+	}
+
+	s.invalidateOccupancy(ctx, checkin.EventID)
+	s.invalidateStats(ctx, checkin)
+
+	return checkin, nil
+}
+
+// ListPendingCheckins lista a fila de check-ins aguardando aprovação do supervisor
+func (s *serviceImpl) ListPendingCheckins(ctx context.Context, tenantID value_objects.UUID, filters ListFilters) ([]*Checkin, int, error) {
+	pending := constants.ApprovalStatusPending
+	voided := false
+	filters.TenantID = &tenantID
+	filters.ApprovalStatus = &pending
+	filters.Voided = &voided
+
+	return s.ListCheckins(ctx, filters)
+}
+
+// ApproveCheckin aprova um check-in pendente, incluindo-o nas horas trabalhadas
+func (s *serviceImpl) ApproveCheckin(ctx context.Context, checkinID, tenantID value_objects.UUID, comment string, reviewedBy value_objects.UUID) (*Checkin, error) {
+	return s.reviewCheckin(ctx, checkinID, tenantID, func(checkin *Checkin) error {
+		return checkin.Approve(comment, reviewedBy)
+	})
+}
+
+// RejectCheckin rejeita um check-in pendente, que permanece inválido
+func (s *serviceImpl) RejectCheckin(ctx context.Context, checkinID, tenantID value_objects.UUID, comment string, reviewedBy value_objects.UUID) (*Checkin, error) {
+	return s.reviewCheckin(ctx, checkinID, tenantID, func(checkin *Checkin) error {
+		return checkin.Reject(comment, reviewedBy)
+	})
+}
+
+// reviewCheckin aplica a decisão do supervisor a um check-in do tenant
+func (s *serviceImpl) reviewCheckin(ctx context.Context, checkinID, tenantID value_objects.UUID, decide func(*Checkin) error) (*Checkin, error) {
+	checkin, err := s.repo.GetByID(ctx, checkinID)
+	if err != nil || !checkin.TenantID.Equals(tenantID) {
+		return nil, errors.NewNotFoundError("Checkin", checkinID.String())
+	}
+
+	if err := decide(checkin); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, checkin); err != nil {
+		return nil, errors.NewInternalError("Erro ao registrar aprovação do check-in", err)
+	}
+
+	// Check-ins rejeitados deixam de contar na ocupação
+	if !checkin.OccupiesSpot() {
+		s.invalidateOccupancy(ctx, checkin.EventID)
+	}
+	s.invalidateStats(ctx, checkin)
+
+	return checkin, nil
+}
+
+// AttachPhoto grava a foto de evidência enviada e a associa ao check-in.
+// A foto gravada é descartada se o check-in não puder recebê-la.
+func (s *serviceImpl) AttachPhoto(ctx context.Context, checkinID, tenantID value_objects.UUID, contentType string, size int64, content io.Reader, updatedBy value_objects.UUID) (*Checkin, error) {
+	if s.photos == nil {
+		return nil, errors.NewInternalError("Armazenamento de fotos não configurado", nil)
+	}
+
+	checkin, err := s.repo.GetByID(ctx, checkinID)
+	if err != nil || !checkin.TenantID.Equals(tenantID) {
+		return nil, errors.NewNotFoundError("Checkin", checkinID.String())
+	}
+
+	// Verifica antes do upload para não gravar arquivos que serão descartados
+	if err := checkin.CanAttachPhoto(); err != nil {
+		return nil, err
+	}
+
+	object, err := s.photos.StorePhoto(ctx, storage.Upload{
+		TenantID:    tenantID,
+		Category:    constants.StorageCategoryCheckinPhoto,
+		OwnerID:     checkinID,
+		ContentType: contentType,
+		Size:        size,
+		Content:     content,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkin.AttachPhoto(object.Key, updatedBy); err != nil {
+		_ = s.photos.Delete(ctx, tenantID, object.Key)
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, checkin); err != nil {
+		_ = s.photos.Delete(ctx, tenantID, object.Key)
+		return nil, errors.NewInternalError("Erro ao anexar foto ao check-in", err)
+	}
+
+	return checkin, nil
+}
+
+// invalidateOccupancy reconstrói a ocupação do evento após anulações e rejeições, que são raras
+func (s *serviceImpl) invalidateOccupancy(ctx context.Context, eventID value_objects.UUID) {
+	if s.occupancy != nil {
+		s.occupancy.Invalidate(ctx, eventID)
+	}
+}
+
+// invalidateStats descarta as estatísticas em cache da sessão após anulações e revisões
+func (s *serviceImpl) invalidateStats(ctx context.Context, checkin *Checkin) {
+	if s.statsRepo != nil {
+		s.statsRepo.InvalidateSessionStats(ctx, checkin.TenantID, checkin.EventID, checkin.EmployeeID, checkin.PartnerID)
+	}
+}
+
 // ValidateFacialRecognition valida check-in por reconhecimento facial
 func (s *serviceImpl) ValidateFacialRecognition(ctx context.Context, checkin *Checkin, faceEmbedding []float32) (*ValidationResult, error) {
-	// TODO: Implementar validação de reconhecimento facial
-	// 1. Buscar embedding facial do funcionário
-	// 2. Calcular similaridade coseno
-	// 3. Verificar threshold de confiança
-	// 4. Retornar resultado da validação
+	if len(faceEmbedding) != constants.FaceEmbeddingDimensions {
+		return nil, errors.NewValidationError("FaceEmbedding", "deve ter exatamente 512 dimensões")
+	}
 
-	result := NewValidationResult(true, "Reconhecimento facial validado")
-	result.SetFacialSimilarity(0.95) // Simulado
-	result.AddDetail("confidence_level", "high")
+	emp, err := s.employeeRepo.GetByID(ctx, checkin.EmployeeID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Funcionário não encontrado", err)
+	}
+
+	// O embedding cadastrado só é decifrado para a comparação, e apenas com consentimento vigente
+	if emp.HasBiometricConsent() {
+		if err := employee.OpenBiometrics(ctx, s.employeeRepo, emp); err != nil {
+			return nil, err
+		}
+	}
+
+	threshold := s.config.FacialSimilarityThreshold
+
+	if !emp.CanPerformFacialRecognition() {
+		reason := "Funcionário sem embedding facial cadastrado"
+		if !emp.HasBiometricConsent() {
+			reason = "Funcionário sem consentimento biométrico vigente"
+			// Sem base legal, o embedding capturado não é guardado
+			checkin.FaceEmbedding = nil
+		}
+
+		result := NewValidationResult(false, reason)
+		result.AddDetail("biometric_consent", emp.HasBiometricConsent())
+		result.AddDetail("facial_threshold", float64(threshold))
+		result.AddDetail("confidence_level", "none")
+		return result, nil
+	}
+
+	matches, similarity := emp.CompareFaceEmbedding(faceEmbedding, threshold)
+
+	reason := "Reconhecimento facial validado"
+	if !matches {
+		reason = "Similaridade facial abaixo do limite de confiança"
+	}
+
+	result := NewValidationResult(matches, reason)
+	result.SetFacialSimilarity(float64(similarity))
+	result.AddDetail("facial_threshold", float64(threshold))
+	result.AddDetail("confidence_level", employee.ConfidenceLevel(similarity))
+
+	return result, nil
+}
+
+// ValidateQRCode valida e consome o QR Code lido no check-in
+func (s *serviceImpl) ValidateQRCode(ctx context.Context, checkin *Checkin, qrCodeData string) (*ValidationResult, error) {
+	qr, err := s.qrService.ConsumeQRCode(ctx, qrCodeData, checkin.EventID, constants.QRTypeCheckin, checkin.CheckinTime)
+	if err != nil {
+		return nil, err
+	}
+
+	result := NewValidationResult(true, "QR Code validado")
+	result.AddDetail("qr_code_id", qr.ID.String())
+	result.AddDetail("qr_code_valid_until", qr.ValidUntil)
 
 	return result, nil
 }
 
-// ValidateGeolocation valida localização do check-in
-func (s *serviceImpl) ValidateGeolocation(ctx context.Context, checkin *Checkin, eventLocation value_objects.Location, eventFence []value_objects.Location) (*ValidationResult, error) {
-	// TODO: Implementar validação geográfica
-	// 1. Calcular distância do check-in ao evento
-	// 2. Verificar se está dentro da cerca geográfica
-	// 3. Aplicar tolerâncias configuráveis
-	// 4. Retornar resultado da validação
+// ValidateGeolocation valida localização do check-in contra a cerca do evento
+func (s *serviceImpl) ValidateGeolocation(ctx context.Context, checkin *Checkin, evt *event.Event) (*ValidationResult, error) {
+	check := evt.CheckLocationWithAccuracy(checkin.Location, checkin.LocationFix)
+
+	reason := "Localização validada"
+	if !check.HasFence {
+		reason = "Evento sem cerca geográfica definida"
+	} else if check.Missing {
+		reason = "Localização não informada para evento com cerca geográfica"
+	} else if !check.WithinTolerance {
+		reason = "Localização fora da cerca do evento"
+	} else if !check.WithinFence {
+		reason = "Localização fora da cerca do evento, dentro da tolerância"
+	}
 
-	distance := checkin.Location.DistanceTo(eventLocation)
-	withinBounds := distance <= 100 // 100 metros de tolerância
+	// Distância fora da cerca (zero quando dentro do polígono)
+	distance := 0.0
+	if !check.WithinFence {
+		distance = check.DistanceToEdge
+	}
 
-	result := NewValidationResult(withinBounds, "Localização validada")
+	result := NewValidationResult(check.WithinTolerance, reason)
 	result.SetDistance(distance)
-	result.SetWithinBounds(withinBounds)
+	result.SetWithinBounds(check.WithinTolerance)
+	result.AddDetail("inside_event_fence", check.WithinFence)
+	result.AddDetail("fence_tolerance_meters", check.ToleranceMeters)
+	if check.AccuracyMeters > 0 {
+		result.AddDetail("fence_accuracy_credit_meters", check.AccuracyMeters)
+	}
+	if check.Missing {
+		result.AddDetail("location_missing", true)
+	} else if check.HasFence {
+		result.AddDetail("distance_to_fence_edge", check.DistanceToEdge)
+	}
 
 	return result, nil
 }
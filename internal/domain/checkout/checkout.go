@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"eventos-backend/internal/domain/event"
 	"eventos-backend/internal/domain/shared/constants"
 	"eventos-backend/internal/domain/shared/errors"
 	"eventos-backend/internal/domain/shared/value_objects"
@@ -18,14 +19,22 @@ type Checkout struct {
 	EmployeeID        value_objects.UUID
 	PartnerID         value_objects.UUID
 	CheckinID         value_objects.UUID // Referência ao check-in correspondente
-	Method            string             // facial_recognition, qr_code, manual
+	Method            string             // facial_recognition, qr_code, manual, system
 	Location          value_objects.Location
+	LocationFix       *value_objects.LocationFix // Precisão, altitude, horário, provedor e simulação da leitura de GPS
 	CheckoutTime      time.Time
 	PhotoURL          string                 // Foto capturada no momento do check-out
 	Notes             string                 // Observações do check-out
+	ClientID          *value_objects.UUID    // Identificador gerado no dispositivo (sincronização offline)
+	DeviceID          string                 // Dispositivo que registrou o check-out
 	WorkDuration      time.Duration          // Duração calculada entre check-in e check-out
 	IsValid           bool                   // Se o check-out é válido
+	AutoClosed        bool                   // Gerado pelo encerramento automático de sessão aberta
 	ValidationDetails map[string]interface{} // Detalhes da validação
+	VoidedAt          *time.Time             // Quando o check-out foi anulado (registro mantido para auditoria)
+	VoidedBy          *value_objects.UUID    // Quem anulou o check-out
+	VoidReason        string                 // Motivo da anulação
+	ReplacesID        *value_objects.UUID    // Check-out anulado que este registro substitui
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 	CreatedBy         *value_objects.UUID
@@ -62,6 +71,46 @@ func NewCheckout(tenantID, eventID, employeeID, partnerID, checkinID value_objec
 	return checkout, nil
 }
 
+// NewAutoClosedCheckout cria o check-out de sistema que encerra uma sessão esquecida aberta.
+// A explicação do encerramento fica registrada em ValidationDetails.
+func NewAutoClosedCheckout(tenantID, eventID, employeeID, partnerID, checkinID value_objects.UUID, checkinTime, checkoutTime time.Time, reason string) (*Checkout, error) {
+	now := time.Now().UTC()
+	checkout := &Checkout{
+		ID:           value_objects.NewUUID(),
+		TenantID:     tenantID,
+		EventID:      eventID,
+		EmployeeID:   employeeID,
+		PartnerID:    partnerID,
+		CheckinID:    checkinID,
+		Method:       constants.CheckMethodSystem,
+		CheckoutTime: checkoutTime,
+		IsValid:      true,
+		AutoClosed:   true,
+		ValidationDetails: map[string]interface{}{
+			"auto_closed":       true,
+			"auto_close_reason": reason,
+			"auto_closed_at":    now,
+			"explanation":       autoCloseExplanations[reason],
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := checkout.Validate(); err != nil {
+		return nil, err
+	}
+
+	checkout.CalculateWorkDuration(checkinTime)
+
+	return checkout, nil
+}
+
+// autoCloseExplanations descreve os motivos de encerramento automático para o supervisor
+var autoCloseExplanations = map[string]string{
+	event.AutoCloseReasonEventFinished:      "Sessão encerrada automaticamente no fim do evento: check-out não registrado",
+	event.AutoCloseReasonMaxSessionDuration: "Sessão encerrada automaticamente ao atingir a duração máxima configurada para o evento",
+}
+
 // Validate valida os dados do check-out
 func (c *Checkout) Validate() error {
 	if c.ID.IsZero() {
@@ -113,6 +162,7 @@ func (c *Checkout) validateMethod() error {
 		constants.CheckMethodFacialRecognition: true,
 		constants.CheckMethodQRCode:            true,
 		constants.CheckMethodManual:            true,
+		constants.CheckMethodSystem:            true,
 	}
 
 	if !validMethods[c.Method] {
@@ -144,6 +194,74 @@ func (c *Checkout) CalculateWorkDuration(checkinTime time.Time) {
 	c.SetValidationDetail("work_duration_minutes", c.WorkDuration.Minutes())
 }
 
+// CorrectCheckoutTime corrige o horário de um check-out (usado pelo supervisor em sessões encerradas automaticamente)
+func (c *Checkout) CorrectCheckoutTime(checkoutTime, checkinTime time.Time, reason string, correctedBy value_objects.UUID) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.NewValidationError("Reason", "é obrigatório")
+	}
+
+	if !checkoutTime.After(checkinTime) {
+		return errors.NewValidationError("CheckoutTime", "deve ser posterior ao check-in")
+	}
+
+	if checkoutTime.After(time.Now().UTC()) {
+		return errors.NewValidationError("CheckoutTime", "não pode estar no futuro")
+	}
+
+	c.SetValidationDetail("time_correction", map[string]interface{}{
+		"original_checkout_time":  c.CheckoutTime,
+		"corrected_checkout_time": checkoutTime,
+		"reason":                  reason,
+		"corrected_by":            correctedBy.String(),
+		"corrected_at":            time.Now().UTC(),
+	})
+
+	c.CheckoutTime = checkoutTime
+	c.CalculateWorkDuration(checkinTime)
+	c.UpdatedAt = time.Now()
+	c.UpdatedBy = &correctedBy
+
+	return nil
+}
+
+// Void anula o check-out mantendo o registro original; a sessão de trabalho volta a ficar aberta.
+// Check-outs anulados não contam em estatísticas nem em sessões de trabalho.
+func (c *Checkout) Void(reason string, voidedBy value_objects.UUID) error {
+	if c.IsVoided() {
+		return errors.NewValidationError("Checkout", "check-out já foi anulado").
+			WithContext("reason_code", constants.EligibilityCheckoutVoided)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.NewValidationError("VoidReason", "é obrigatório")
+	}
+
+	if len(reason) > constants.MaxVoidReasonLength {
+		return errors.NewValidationError("VoidReason", fmt.Sprintf("deve ter no máximo %d caracteres", constants.MaxVoidReasonLength))
+	}
+
+	now := time.Now().UTC()
+	c.VoidedAt = &now
+	c.VoidedBy = &voidedBy
+	c.VoidReason = reason
+	c.UpdatedAt = now
+	c.UpdatedBy = &voidedBy
+
+	return nil
+}
+
+// IsVoided verifica se o check-out foi anulado
+func (c *Checkout) IsVoided() bool {
+	return c.VoidedAt != nil
+}
+
+// IsReplacement verifica se o check-out substitui um check-out anulado
+func (c *Checkout) IsReplacement() bool {
+	return c.ReplacesID != nil
+}
+
 // MarkAsValid marca o check-out como válido
 func (c *Checkout) MarkAsValid(validationDetails map[string]interface{}, updatedBy value_objects.UUID) {
 	c.IsValid = true
@@ -303,10 +421,15 @@ const (
 	CheckoutStatusValid     CheckoutStatus = "valid"     // Válido
 	CheckoutStatusInvalid   CheckoutStatus = "invalid"   // Inválido
 	CheckoutStatusCancelled CheckoutStatus = "cancelled" // Cancelado
+	CheckoutStatusVoided    CheckoutStatus = "voided"    // Anulado por erro do operador
 )
 
 // GetStatus retorna o status atual do check-out
 func (c *Checkout) GetStatus() CheckoutStatus {
+	if c.IsVoided() {
+		return CheckoutStatusVoided
+	}
+
 	if c.IsValid {
 		return CheckoutStatusValid
 	}
@@ -378,7 +501,41 @@ func (vr *ValidationResult) AddDetail(key string, value interface{}) *Validation
 	return vr
 }
 
-// WorkSession representa uma sessão de trabalho (check-in + check-out)
+// Merge combina outro resultado de validação a este.
+// O resultado só permanece válido se ambos forem válidos; o motivo da primeira falha é preservado.
+func (vr *ValidationResult) Merge(other *ValidationResult) *ValidationResult {
+	if other == nil {
+		return vr
+	}
+
+	if vr.IsValid && !other.IsValid {
+		vr.Reason = other.Reason
+	}
+	vr.IsValid = vr.IsValid && other.IsValid
+
+	for key, value := range other.Details {
+		vr.Details[key] = value
+	}
+
+	if other.DistanceFromEvent != nil {
+		vr.DistanceFromEvent = other.DistanceFromEvent
+	}
+	if other.FacialSimilarity != nil {
+		vr.FacialSimilarity = other.FacialSimilarity
+	}
+	if other.WithinBounds != nil {
+		vr.WithinBounds = other.WithinBounds
+	}
+	if other.WorkDuration != nil {
+		vr.WorkDuration = other.WorkDuration
+	}
+
+	return vr
+}
+
+// WorkSession representa uma sessão de trabalho (check-in + check-out).
+// Um funcionário pode ter várias sessões no mesmo evento (reentradas e turnos);
+// enquanto não houver check-out a sessão está aberta e CheckoutID é zero.
 type WorkSession struct {
 	CheckinID    value_objects.UUID
 	CheckoutID   value_objects.UUID
@@ -410,6 +567,32 @@ func NewWorkSession(checkinID, checkoutID, employeeID, eventID, partnerID value_
 	}
 }
 
+// NewOpenWorkSession cria uma sessão de trabalho aberta (check-in sem check-out)
+func NewOpenWorkSession(checkinID, employeeID, eventID, partnerID value_objects.UUID, checkinTime time.Time) *WorkSession {
+	return &WorkSession{
+		CheckinID:   checkinID,
+		EmployeeID:  employeeID,
+		EventID:     eventID,
+		PartnerID:   partnerID,
+		CheckinTime: checkinTime,
+		IsComplete:  false,
+		IsValid:     true,
+	}
+}
+
+// IsOpen verifica se a sessão ainda não possui check-out
+func (ws *WorkSession) IsOpen() bool {
+	return !ws.IsComplete
+}
+
+// Status retorna o status da sessão (aberta ou fechada)
+func (ws *WorkSession) Status() string {
+	if ws.IsOpen() {
+		return constants.WorkSessionStatusOpen
+	}
+	return constants.WorkSessionStatusClosed
+}
+
 // GetDurationHours retorna a duração em horas
 func (ws *WorkSession) GetDurationHours() float64 {
 	return ws.Duration.Hours()
@@ -17,6 +17,9 @@ type Repository interface {
 	// GetByID busca um check-out por ID
 	GetByID(ctx context.Context, id value_objects.UUID) (*Checkout, error)
 
+	// GetByClientID busca um check-out pelo identificador gerado no dispositivo (sincronização offline)
+	GetByClientID(ctx context.Context, clientID value_objects.UUID) (*Checkout, error)
+
 	// Update atualiza um check-out existente
 	Update(ctx context.Context, checkout *Checkout) error
 
@@ -38,12 +41,15 @@ type Repository interface {
 	// GetByPartner busca check-outs de um parceiro
 	GetByPartner(ctx context.Context, partnerID value_objects.UUID, filters ListFilters) ([]*Checkout, int, error)
 
-	// GetByCheckin busca check-out por check-in
+	// GetByCheckin busca o check-out não anulado de um check-in
 	GetByCheckin(ctx context.Context, checkinID value_objects.UUID) (*Checkout, error)
 
-	// ExistsByCheckin verifica se já existe check-out para o check-in
+	// ExistsByCheckin verifica se já existe check-out não anulado para o check-in
 	ExistsByCheckin(ctx context.Context, checkinID value_objects.UUID) (bool, error)
 
+	// ExistsReplacement verifica se o check-out anulado já foi substituído por outro registro
+	ExistsReplacement(ctx context.Context, voidedID value_objects.UUID) (bool, error)
+
 	// GetByEmployeeAndEvent busca check-out específico de funcionário em evento
 	GetByEmployeeAndEvent(ctx context.Context, employeeID, eventID value_objects.UUID) (*Checkout, error)
 
@@ -78,10 +84,10 @@ type Repository interface {
 	GetWorkSessions(ctx context.Context, tenantID value_objects.UUID, filters WorkSessionFilters) ([]*WorkSession, int, error)
 
 	// GetEmployeeWorkSessions busca sessões de trabalho de um funcionário
-	GetEmployeeWorkSessions(ctx context.Context, employeeID value_objects.UUID, filters WorkSessionFilters) ([]*WorkSession, int, error)
+	GetEmployeeWorkSessions(ctx context.Context, tenantID, employeeID value_objects.UUID, filters WorkSessionFilters) ([]*WorkSession, int, error)
 
 	// GetEventWorkSessions busca sessões de trabalho de um evento
-	GetEventWorkSessions(ctx context.Context, eventID value_objects.UUID, filters WorkSessionFilters) ([]*WorkSession, int, error)
+	GetEventWorkSessions(ctx context.Context, tenantID, eventID value_objects.UUID, filters WorkSessionFilters) ([]*WorkSession, int, error)
 }
 
 // ListFilters define os filtros para listagem de check-outs
@@ -94,9 +100,11 @@ type ListFilters struct {
 	CheckinID  *value_objects.UUID
 
 	// Filtros específicos
-	Method   *string
-	IsValid  *bool
-	HasPhoto *bool
+	Method     *string
+	IsValid    *bool
+	HasPhoto   *bool
+	AutoClosed *bool // Check-outs gerados pelo encerramento automático de sessões
+	Voided     *bool // nil inclui check-outs anulados na listagem
 
 	// Filtros temporais
 	StartDate *time.Time
@@ -332,7 +340,7 @@ type CheckoutStats struct {
 	LastCheckoutTime   *time.Time
 }
 
-// WorkStats representa estatísticas de trabalho
+// WorkStats representa estatísticas de trabalho agregadas sobre todas as sessões
 type WorkStats struct {
 	TotalSessions      int
 	CompleteSessions   int
@@ -348,19 +356,64 @@ type WorkStats struct {
 	LongSessions       int
 }
 
+// NewWorkStats agrega as estatísticas de trabalho somando as horas de todas as sessões.
+// Sessões abertas contam como incompletas e não somam horas.
+func NewWorkStats(sessions []*WorkSession) *WorkStats {
+	stats := &WorkStats{TotalSessions: len(sessions)}
+
+	for _, session := range sessions {
+		if session.IsValid {
+			stats.ValidSessions++
+		} else {
+			stats.InvalidSessions++
+		}
+
+		if session.IsOpen() {
+			stats.IncompleteSessions++
+			continue
+		}
+
+		hours := session.GetDurationHours()
+		if stats.CompleteSessions == 0 || hours < stats.MinWorkHours {
+			stats.MinWorkHours = hours
+		}
+		if hours > stats.MaxWorkHours {
+			stats.MaxWorkHours = hours
+		}
+
+		stats.CompleteSessions++
+		stats.TotalWorkHours += hours
+
+		switch {
+		case session.IsShortSession():
+			stats.ShortSessions++
+		case session.IsLongSession():
+			stats.LongSessions++
+		default:
+			stats.NormalSessions++
+		}
+	}
+
+	if stats.CompleteSessions > 0 {
+		stats.AverageWorkHours = stats.TotalWorkHours / float64(stats.CompleteSessions)
+	}
+
+	return stats
+}
+
 // StatsRepository define operações para estatísticas de check-outs
 type StatsRepository interface {
 	// GetTenantStats obtém estatísticas de um tenant
 	GetTenantStats(ctx context.Context, tenantID value_objects.UUID) (*CheckoutStats, error)
 
 	// GetEventStats obtém estatísticas de um evento
-	GetEventStats(ctx context.Context, eventID value_objects.UUID) (*CheckoutStats, error)
+	GetEventStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*CheckoutStats, error)
 
 	// GetEmployeeStats obtém estatísticas de um funcionário
-	GetEmployeeStats(ctx context.Context, employeeID value_objects.UUID) (*CheckoutStats, error)
+	GetEmployeeStats(ctx context.Context, tenantID, employeeID value_objects.UUID) (*CheckoutStats, error)
 
 	// GetPartnerStats obtém estatísticas de um parceiro
-	GetPartnerStats(ctx context.Context, partnerID value_objects.UUID) (*CheckoutStats, error)
+	GetPartnerStats(ctx context.Context, tenantID, partnerID value_objects.UUID) (*CheckoutStats, error)
 
 	// GetDailyStats obtém estatísticas diárias
 	GetDailyStats(ctx context.Context, tenantID value_objects.UUID, date time.Time) (*CheckoutStats, error)
@@ -375,8 +428,11 @@ type StatsRepository interface {
 	GetWorkStats(ctx context.Context, tenantID value_objects.UUID) (*WorkStats, error)
 
 	// GetEmployeeWorkStats obtém estatísticas de trabalho de um funcionário
-	GetEmployeeWorkStats(ctx context.Context, employeeID value_objects.UUID) (*WorkStats, error)
+	GetEmployeeWorkStats(ctx context.Context, tenantID, employeeID value_objects.UUID) (*WorkStats, error)
 
 	// GetEventWorkStats obtém estatísticas de trabalho de um evento
-	GetEventWorkStats(ctx context.Context, eventID value_objects.UUID) (*WorkStats, error)
+	GetEventWorkStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*WorkStats, error)
+
+	// InvalidateSessionStats descarta as estatísticas em cache afetadas por uma sessão anulada, revisada ou corrigida
+	InvalidateSessionStats(ctx context.Context, tenantID, eventID, employeeID, partnerID value_objects.UUID)
 }
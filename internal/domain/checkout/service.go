@@ -4,9 +4,15 @@ import (
 	"context"
 	"time"
 
+	"eventos-backend/internal/domain/checkin"
+	"eventos-backend/internal/domain/employee"
+	"eventos-backend/internal/domain/event"
+	"eventos-backend/internal/domain/occupancy"
+	"eventos-backend/internal/domain/qrcode"
 	"eventos-backend/internal/domain/shared/constants"
 	"eventos-backend/internal/domain/shared/errors"
 	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/domain/tenant"
 )
 
 // Service define a interface para serviços de domínio de check-outs
@@ -29,8 +35,10 @@ type Service interface {
 	// GetEventCheckouts busca check-outs de um evento
 	GetEventCheckouts(ctx context.Context, eventID value_objects.UUID, filters ListFilters) ([]*Checkout, int, error)
 
-	// CanEmployeeCheckout verifica se funcionário pode fazer check-out
-	CanEmployeeCheckout(ctx context.Context, employeeID, eventID value_objects.UUID) (bool, string, error)
+	// CanEmployeeCheckout verifica se funcionário pode fazer check-out do check-in informado.
+	// Funcionário, evento e check-in devem pertencer ao tenant do usuário autenticado.
+	// Quando não puder, retorna o código do motivo (constants.Eligibility*).
+	CanEmployeeCheckout(ctx context.Context, tenantID, employeeID, eventID, partnerID, checkinID value_objects.UUID) (bool, string, error)
 
 	// GetCheckoutStats obtém estatísticas de check-outs
 	GetCheckoutStats(ctx context.Context, tenantID value_objects.UUID) (*CheckoutStats, error)
@@ -38,6 +46,12 @@ type Service interface {
 	// GetWorkStats obtém estatísticas de trabalho
 	GetWorkStats(ctx context.Context, tenantID value_objects.UUID) (*WorkStats, error)
 
+	// GetEventCheckoutStats obtém estatísticas de check-outs de um evento
+	GetEventCheckoutStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*CheckoutStats, error)
+
+	// GetEventWorkStats obtém estatísticas de trabalho de um evento
+	GetEventWorkStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*WorkStats, error)
+
 	// AddCheckoutNote adiciona observação a um check-out
 	AddCheckoutNote(ctx context.Context, checkoutID value_objects.UUID, note string, updatedBy value_objects.UUID) error
 
@@ -47,17 +61,32 @@ type Service interface {
 	// GetWorkSessions busca sessões de trabalho completas
 	GetWorkSessions(ctx context.Context, tenantID value_objects.UUID, filters WorkSessionFilters) ([]*WorkSession, int, error)
 
-	// GetEmployeeWorkSessions busca sessões de trabalho de um funcionário
-	GetEmployeeWorkSessions(ctx context.Context, employeeID value_objects.UUID, filters WorkSessionFilters) ([]*WorkSession, int, error)
+	// GetEmployeeWorkSessions busca sessões de trabalho de um funcionário do tenant
+	GetEmployeeWorkSessions(ctx context.Context, tenantID, employeeID value_objects.UUID, filters WorkSessionFilters) ([]*WorkSession, int, error)
 
 	// ValidateFacialRecognition valida check-out por reconhecimento facial
 	ValidateFacialRecognition(ctx context.Context, checkout *Checkout, faceEmbedding []float32) (*ValidationResult, error)
 
+	// ValidateQRCode valida e consome o QR Code lido no check-out
+	ValidateQRCode(ctx context.Context, checkout *Checkout, qrCodeData string) (*ValidationResult, error)
+
 	// ValidateGeolocation valida localização do check-out
-	ValidateGeolocation(ctx context.Context, checkout *Checkout, eventLocation value_objects.Location, eventFence []value_objects.Location) (*ValidationResult, error)
+	ValidateGeolocation(ctx context.Context, checkout *Checkout, evt *event.Event) (*ValidationResult, error)
 
 	// ValidateWorkDuration valida duração do trabalho
 	ValidateWorkDuration(ctx context.Context, checkout *Checkout, checkinTime time.Time) (*ValidationResult, error)
+
+	// AutoCloseStaleSessions encerra sessões abertas de eventos finalizados ou que excederam a duração máxima
+	AutoCloseStaleSessions(ctx context.Context, now time.Time, limit int) ([]*Checkout, error)
+
+	// ListAutoClosedCheckouts lista check-outs gerados pelo encerramento automático
+	ListAutoClosedCheckouts(ctx context.Context, tenantID value_objects.UUID, filters ListFilters) ([]*Checkout, int, error)
+
+	// CorrectCheckoutTime corrige o horário de um check-out encerrado automaticamente
+	CorrectCheckoutTime(ctx context.Context, checkoutID, tenantID value_objects.UUID, checkoutTime time.Time, reason string, correctedBy value_objects.UUID) (*Checkout, error)
+
+	// VoidCheckout anula um check-out registrado por engano, reabrindo a sessão de trabalho
+	VoidCheckout(ctx context.Context, checkoutID, tenantID value_objects.UUID, reason string, voidedBy value_objects.UUID) (*Checkout, error)
 }
 
 // CheckoutRequest representa uma requisição de check-out
@@ -69,11 +98,26 @@ type CheckoutRequest struct {
 	CheckinID     value_objects.UUID
 	Method        string
 	Location      value_objects.Location
+	LocationFix   *value_objects.LocationFix // Precisão, provedor e simulação da leitura de GPS
 	PhotoURL      string
 	Notes         string
 	FaceEmbedding []float32 // Para reconhecimento facial
 	QRCodeData    string    // Para check-out via QR Code
 	CreatedBy     value_objects.UUID
+	ReplacesID    *value_objects.UUID // Check-out anulado que este registro substitui
+
+	// Sincronização offline
+	ClientID   *value_objects.UUID // Identificador gerado no dispositivo
+	DeviceID   string              // Dispositivo que registrou o check-out
+	RecordedAt *time.Time          // Horário registrado no dispositivo
+}
+
+// checkoutTime retorna o horário efetivo do check-out (horário do dispositivo quando informado)
+func (r *CheckoutRequest) checkoutTime() time.Time {
+	if r.RecordedAt != nil && !r.RecordedAt.IsZero() {
+		return r.RecordedAt.UTC()
+	}
+	return time.Now().UTC()
 }
 
 // Validate valida a requisição de check-out
@@ -118,7 +162,7 @@ func (r *CheckoutRequest) Validate() error {
 			return errors.NewValidationError("FaceEmbedding", "é obrigatório para reconhecimento facial")
 		}
 
-		if len(r.FaceEmbedding) != 512 {
+		if len(r.FaceEmbedding) != constants.FaceEmbeddingDimensions {
 			return errors.NewValidationError("FaceEmbedding", "deve ter exatamente 512 dimensões")
 		}
 	}
@@ -132,17 +176,41 @@ func (r *CheckoutRequest) Validate() error {
 	return nil
 }
 
+// Config contém os parâmetros configuráveis das validações de check-out
+type Config struct {
+	FacialSimilarityThreshold float32 // Similaridade mínima para aceitar o reconhecimento facial
+}
+
 // serviceImpl implementa a interface Service
 type serviceImpl struct {
-	repo      Repository
-	statsRepo StatsRepository
+	repo         Repository
+	statsRepo    StatsRepository
+	checkinRepo  checkin.Repository
+	employeeRepo employee.Repository
+	eventRepo    event.Repository
+	tenantRepo   tenant.Repository
+	qrService    qrcode.Service
+	occupancy    occupancy.Service
+	config       Config
 }
 
-// NewService cria uma nova instância do serviço
-func NewService(repo Repository, statsRepo StatsRepository) Service {
+// NewService cria uma nova instância do serviço; occupancyService pode ser nil e,
+// sem tenantRepo, localizações simuladas são aceitas
+func NewService(repo Repository, statsRepo StatsRepository, checkinRepo checkin.Repository, employeeRepo employee.Repository, eventRepo event.Repository, tenantRepo tenant.Repository, qrService qrcode.Service, occupancyService occupancy.Service, config Config) Service {
+	if config.FacialSimilarityThreshold <= 0 || config.FacialSimilarityThreshold > 1 {
+		config.FacialSimilarityThreshold = constants.DefaultFacialSimilarityThreshold
+	}
+
 	return &serviceImpl{
-		repo:      repo,
-		statsRepo: statsRepo,
+		repo:         repo,
+		statsRepo:    statsRepo,
+		checkinRepo:  checkinRepo,
+		employeeRepo: employeeRepo,
+		eventRepo:    eventRepo,
+		tenantRepo:   tenantRepo,
+		qrService:    qrService,
+		occupancy:    occupancyService,
+		config:       config,
 	}
 }
 
@@ -153,6 +221,13 @@ func (s *serviceImpl) PerformCheckout(ctx context.Context, request CheckoutReque
 		return nil, nil, err
 	}
 
+	// Substituição de check-out anulado
+	if request.ReplacesID != nil {
+		if err := s.validateReplacement(ctx, request); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Verificar se já existe check-out para este check-in
 	exists, err := s.repo.ExistsByCheckin(ctx, request.CheckinID)
 	if err != nil {
@@ -164,13 +239,14 @@ func (s *serviceImpl) PerformCheckout(ctx context.Context, request CheckoutReque
 	}
 
 	// Verificar se funcionário pode fazer check-out
-	canCheckout, reason, err := s.CanEmployeeCheckout(ctx, request.EmployeeID, request.EventID)
+	canCheckout, reasonCode, err := s.CanEmployeeCheckout(ctx, request.TenantID, request.EmployeeID, request.EventID, request.PartnerID, request.CheckinID)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	if !canCheckout {
-		return nil, nil, errors.NewValidationError("Checkout", reason)
+		return nil, nil, errors.NewValidationError("Checkout", eligibilityMessages[reasonCode]).
+			WithContext("reason_code", reasonCode)
 	}
 
 	// Criar check-out
@@ -190,42 +266,194 @@ func (s *serviceImpl) PerformCheckout(ctx context.Context, request CheckoutReque
 		return nil, nil, err
 	}
 
-	// Salvar check-out
-	if err := s.repo.Create(ctx, checkout); err != nil {
-		return nil, nil, errors.NewInternalError("Erro ao criar check-out", err)
-	}
+	checkout.CheckoutTime = request.checkoutTime()
+	checkout.ClientID = request.ClientID
+	checkout.LocationFix = request.LocationFix
+	checkout.DeviceID = request.DeviceID
+	checkout.ReplacesID = request.ReplacesID
 
-	// Realizar validação (por enquanto, validação básica)
-	validationResult := s.performBasicValidation(checkout)
+	// Validar check-out de acordo com o método utilizado
+	validationResult, err := s.performValidation(ctx, checkout, request)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// Atualizar check-out com resultado da validação
+	// Aplicar resultado da validação
 	if validationResult.IsValid {
 		checkout.MarkAsValid(validationResult.Details, request.CreatedBy)
 	} else {
 		checkout.MarkAsInvalid(validationResult.Details, request.CreatedBy)
 	}
 
-	// Salvar check-out atualizado
-	if err := s.repo.Update(ctx, checkout); err != nil {
-		return nil, nil, errors.NewInternalError("Erro ao atualizar check-out", err)
+	// Salvar check-out
+	if err := s.repo.Create(ctx, checkout); err != nil {
+		s.releaseQRCode(ctx, validationResult)
+		return nil, nil, errors.NewInternalError("Erro ao criar check-out", err)
+	}
+
+	if ci, err := s.checkinRepo.GetByID(ctx, checkout.CheckinID); err == nil {
+		s.releaseSpot(ctx, ci)
 	}
 
 	return checkout, validationResult, nil
 }
 
-// performBasicValidation realiza validação básica do check-out
-func (s *serviceImpl) performBasicValidation(checkout *Checkout) *ValidationResult {
-	// Por enquanto, validação simples - todos os check-outs são considerados válidos
-	// Em uma implementação completa, aqui seria feita a validação com:
-	// - Dados do check-in correspondente
-	// - Duração do trabalho
-	// - Localização e regras de negócio
+// releaseSpot libera a vaga ocupada pela sessão encerrada; check-ins inválidos não ocupam vaga
+func (s *serviceImpl) releaseSpot(ctx context.Context, ci *checkin.Checkin) {
+	if s.occupancy != nil && ci.OccupiesSpot() {
+		s.occupancy.Release(ctx, ci.EventID, ci.PartnerID)
+	}
+}
+
+// validateReplacement verifica se o check-out substituído está anulado, ainda não foi substituído
+// e pertence ao mesmo check-in
+func (s *serviceImpl) validateReplacement(ctx context.Context, request CheckoutRequest) error {
+	replaced, err := s.repo.GetByID(ctx, *request.ReplacesID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return errors.NewValidationError("ReplacesID", "check-out substituído não encontrado").
+				WithContext("reason_code", constants.EligibilityReplacementInvalid)
+		}
+		return errors.NewInternalError("Erro ao buscar check-out substituído", err)
+	}
+
+	if !replaced.TenantID.Equals(request.TenantID) || !replaced.CheckinID.Equals(request.CheckinID) {
+		return errors.NewValidationError("ReplacesID", "check-out substituído não pertence ao check-in informado").
+			WithContext("reason_code", constants.EligibilityReplacementInvalid)
+	}
+
+	if !replaced.IsVoided() {
+		return errors.NewValidationError("ReplacesID", "apenas check-outs anulados podem ser substituídos").
+			WithContext("reason_code", constants.EligibilityReplacementInvalid)
+	}
+
+	replacedAlready, err := s.repo.ExistsReplacement(ctx, replaced.ID)
+	if err != nil {
+		return errors.NewInternalError("Erro ao verificar substituição do check-out", err)
+	}
+
+	if replacedAlready {
+		return errors.NewAlreadyExistsError("Checkout", "replaces_id", replaced.ID.String()).
+			WithContext("reason_code", constants.EligibilityReplacementInvalid)
+	}
+
+	return nil
+}
 
+// performValidation executa as validações aplicáveis ao check-out
+func (s *serviceImpl) performValidation(ctx context.Context, checkout *Checkout, request CheckoutRequest) (*ValidationResult, error) {
 	result := NewValidationResult(true, "Check-out realizado com sucesso")
-	result.AddDetail("validation_method", "basic")
+	result.AddDetail("validation_method", checkout.Method)
 	result.AddDetail("validation_timestamp", time.Now())
 
-	return result
+	// A duração é calculada a partir do check-in correspondente
+	checkinEntity, err := s.checkinRepo.GetByID(ctx, checkout.CheckinID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Checkin não encontrado", err)
+	}
+
+	durationResult, err := s.ValidateWorkDuration(ctx, checkout, checkinEntity.CheckinTime)
+	if err != nil {
+		return nil, err
+	}
+	result.Merge(durationResult)
+
+	if checkout.IsFacialRecognition() {
+		facialResult, err := s.ValidateFacialRecognition(ctx, checkout, request.FaceEmbedding)
+		if err != nil {
+			return nil, err
+		}
+		result.Merge(facialResult)
+	}
+
+	if checkout.LocationFix != nil {
+		fixResult, err := s.validateLocationFix(ctx, checkout)
+		if err != nil {
+			return nil, err
+		}
+		result.Merge(fixResult)
+	}
+
+	// A cerca é verificada mesmo sem localização: em evento com cerca, a ausência invalida o registro
+	evt, err := s.eventRepo.GetByID(ctx, checkout.EventID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Evento não encontrado", err)
+	}
+
+	geoResult, err := s.ValidateGeolocation(ctx, checkout, evt)
+	if err != nil {
+		return nil, err
+	}
+	result.Merge(geoResult)
+
+	// O QR Code é consumido por último: nenhuma outra validação pode falhar depois de registrado o uso
+	if checkout.IsQRCode() {
+		qrResult, err := s.ValidateQRCode(ctx, checkout, request.QRCodeData)
+		if err != nil {
+			return nil, err
+		}
+		result.Merge(qrResult)
+	}
+
+	return result, nil
+}
+
+// releaseQRCode devolve o uso do QR Code consumido na validação quando o check-out não chega a ser gravado
+func (s *serviceImpl) releaseQRCode(ctx context.Context, result *ValidationResult) {
+	qrCodeID, ok := result.Details["qr_code_id"].(string)
+	if !ok || s.qrService == nil {
+		return
+	}
+
+	if id, err := value_objects.ParseUUID(qrCodeID); err == nil {
+		s.qrService.ReleaseQRCode(ctx, id)
+	}
+}
+
+// validateLocationFix registra os metadados da leitura de GPS e aplica a política do tenant para localizações simuladas
+func (s *serviceImpl) validateLocationFix(ctx context.Context, checkout *Checkout) (*ValidationResult, error) {
+	fix := checkout.LocationFix
+
+	result := NewValidationResult(true, "Leitura de GPS registrada")
+	result.AddDetail("location_accuracy_meters", fix.Accuracy)
+	result.AddDetail("location_mock", fix.IsMock)
+	if fix.Provider != "" {
+		result.AddDetail("location_provider", fix.Provider)
+	}
+	if age, ok := fix.Age(checkout.CheckoutTime); ok {
+		result.AddDetail("location_fix_age_seconds", age.Seconds())
+	}
+
+	if !fix.IsMock {
+		return result, nil
+	}
+
+	reject, err := s.rejectsMockLocation(ctx, checkout.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	result.AddDetail("mock_location_rejected", reject)
+	if reject {
+		result.IsValid = false
+		result.Reason = "Localização simulada não é aceita pelo tenant"
+	}
+
+	return result, nil
+}
+
+// rejectsMockLocation indica se o tenant rejeita localizações simuladas
+func (s *serviceImpl) rejectsMockLocation(ctx context.Context, tenantID value_objects.UUID) (bool, error) {
+	if s.tenantRepo == nil {
+		return false, nil
+	}
+
+	t, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return false, errors.NewInternalError("Erro ao buscar política de localização do tenant", err)
+	}
+
+	return t != nil && t.RejectMockLocations, nil
 }
 
 // ValidateCheckout valida um check-out existente
@@ -300,14 +528,75 @@ func (s *serviceImpl) GetEventCheckouts(ctx context.Context, eventID value_objec
 	return checkouts, total, nil
 }
 
+// eligibilityMessages descreve os códigos de inelegibilidade para o operador
+var eligibilityMessages = map[string]string{
+	constants.EligibilityEmployeeNotFound: "funcionário não encontrado",
+	constants.EligibilityEmployeeInactive: "funcionário inativo",
+	constants.EligibilityEventNotFound:    "evento não encontrado",
+	constants.EligibilityEventInactive:    "evento inativo",
+	constants.EligibilityCheckinNotFound:  "check-in não encontrado",
+	constants.EligibilityCheckinMismatch:  "check-in não pertence ao funcionário, evento ou parceiro informado",
+	constants.EligibilityCheckinVoided:    "check-in foi anulado",
+	constants.EligibilityCheckinRejected:  "check-in foi rejeitado pelo supervisor",
+	constants.EligibilityTenantMismatch:   "funcionário, evento e check-in pertencem a organizações diferentes",
+}
+
 // CanEmployeeCheckout verifica se funcionário pode fazer check-out
-func (s *serviceImpl) CanEmployeeCheckout(ctx context.Context, employeeID, eventID value_objects.UUID) (bool, string, error) {
-	// TODO: Implementar validações completas:
-	// 1. Verificar se funcionário tem check-in ativo no evento
-	// 2. Verificar se não há check-out já realizado
-	// 3. Verificar regras de negócio específicas
+func (s *serviceImpl) CanEmployeeCheckout(ctx context.Context, tenantID, employeeID, eventID, partnerID, checkinID value_objects.UUID) (bool, string, error) {
+	// 1. Funcionário deve existir e estar ativo
+	emp, err := s.employeeRepo.GetByID(ctx, employeeID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, constants.EligibilityEmployeeNotFound, nil
+		}
+		return false, "", errors.NewInternalError("Erro ao buscar funcionário", err)
+	}
+
+	if !emp.IsActive() {
+		return false, constants.EligibilityEmployeeInactive, nil
+	}
+
+	// 2. Evento deve existir e estar ativo (o check-out é permitido após o término para encerrar a sessão)
+	evt, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, constants.EligibilityEventNotFound, nil
+		}
+		return false, "", errors.NewInternalError("Erro ao buscar evento", err)
+	}
+
+	if !evt.IsActive() {
+		return false, constants.EligibilityEventInactive, nil
+	}
+
+	// 3. Check-in deve existir e corresponder ao funcionário, evento e parceiro
+	checkinEntity, err := s.checkinRepo.GetByID(ctx, checkinID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, constants.EligibilityCheckinNotFound, nil
+		}
+		return false, "", errors.NewInternalError("Erro ao buscar check-in", err)
+	}
+
+	if !checkinEntity.EmployeeID.Equals(employeeID) ||
+		!checkinEntity.EventID.Equals(eventID) ||
+		!checkinEntity.PartnerID.Equals(partnerID) {
+		return false, constants.EligibilityCheckinMismatch, nil
+	}
+
+	if !checkinEntity.TenantID.Equals(tenantID) || !evt.BelongsToTenant(tenantID) || !emp.BelongsToTenant(tenantID) {
+		return false, constants.EligibilityTenantMismatch, nil
+	}
+
+	if checkinEntity.IsVoided() {
+		return false, constants.EligibilityCheckinVoided, nil
+	}
+
+	// Presença recusada pelo supervisor não abre sessão de trabalho
+	if checkinEntity.IsRejected() {
+		return false, constants.EligibilityCheckinRejected, nil
+	}
 
-	// Por enquanto, sempre permite check-out
 	return true, "", nil
 }
 
@@ -331,6 +620,26 @@ func (s *serviceImpl) GetWorkStats(ctx context.Context, tenantID value_objects.U
 	return stats, nil
 }
 
+// GetEventCheckoutStats obtém estatísticas de check-outs de um evento
+func (s *serviceImpl) GetEventCheckoutStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*CheckoutStats, error) {
+	stats, err := s.statsRepo.GetEventStats(ctx, tenantID, eventID)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao obter estatísticas de check-out do evento", err)
+	}
+
+	return stats, nil
+}
+
+// GetEventWorkStats obtém estatísticas de trabalho de um evento
+func (s *serviceImpl) GetEventWorkStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*WorkStats, error) {
+	stats, err := s.statsRepo.GetEventWorkStats(ctx, tenantID, eventID)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao obter estatísticas de trabalho do evento", err)
+	}
+
+	return stats, nil
+}
+
 // AddCheckoutNote adiciona observação a um check-out
 func (s *serviceImpl) AddCheckoutNote(ctx context.Context, checkoutID value_objects.UUID, note string, updatedBy value_objects.UUID) error {
 	checkout, err := s.repo.GetByID(ctx, checkoutID)
@@ -377,13 +686,13 @@ func (s *serviceImpl) GetWorkSessions(ctx context.Context, tenantID value_object
 	return sessions, total, nil
 }
 
-// GetEmployeeWorkSessions busca sessões de trabalho de um funcionário
-func (s *serviceImpl) GetEmployeeWorkSessions(ctx context.Context, employeeID value_objects.UUID, filters WorkSessionFilters) ([]*WorkSession, int, error) {
+// GetEmployeeWorkSessions busca sessões de trabalho de um funcionário do tenant
+func (s *serviceImpl) GetEmployeeWorkSessions(ctx context.Context, tenantID, employeeID value_objects.UUID, filters WorkSessionFilters) ([]*WorkSession, int, error) {
 	if err := filters.Validate(); err != nil {
 		return nil, 0, err
 	}
 
-	sessions, total, err := s.repo.GetEmployeeWorkSessions(ctx, employeeID, filters)
+	sessions, total, err := s.repo.GetEmployeeWorkSessions(ctx, tenantID, employeeID, filters)
 	if err != nil {
 		return nil, 0, errors.NewInternalError("Erro ao buscar sessões de trabalho do funcionário", err)
 	}
@@ -391,34 +700,254 @@ func (s *serviceImpl) GetEmployeeWorkSessions(ctx context.Context, employeeID va
 	return sessions, total, nil
 }
 
+// AutoCloseStaleSessions encerra sessões abertas de eventos finalizados ou que excederam a duração máxima.
+// Sessões fechadas concorrentemente por um check-out manual são ignoradas.
+func (s *serviceImpl) AutoCloseStaleSessions(ctx context.Context, now time.Time, limit int) ([]*Checkout, error) {
+	if limit <= 0 {
+		limit = constants.SessionAutoCloseBatchSize
+	}
+
+	staleCheckins, err := s.checkinRepo.ListStaleOpenSessions(ctx, now, limit)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao buscar sessões abertas", err)
+	}
+
+	events := make(map[value_objects.UUID]*event.Event)
+	closed := make([]*Checkout, 0, len(staleCheckins))
+
+	for _, ci := range staleCheckins {
+		evt, ok := events[ci.EventID]
+		if !ok {
+			evt, err = s.eventRepo.GetByID(ctx, ci.EventID)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return closed, errors.NewInternalError("Erro ao buscar evento", err)
+			}
+			events[ci.EventID] = evt
+		}
+
+		checkoutTime, reason := evt.AutoCloseTime(ci.CheckinTime)
+		if checkoutTime.After(now) {
+			continue
+		}
+
+		checkout, err := NewAutoClosedCheckout(ci.TenantID, ci.EventID, ci.EmployeeID, ci.PartnerID, ci.ID, ci.CheckinTime, checkoutTime, reason)
+		if err != nil {
+			return closed, err
+		}
+
+		if err := s.repo.Create(ctx, checkout); err != nil {
+			// O funcionário pode ter feito check-out entre a consulta e a criação
+			if exists, existsErr := s.repo.ExistsByCheckin(ctx, ci.ID); existsErr == nil && exists {
+				continue
+			}
+			return closed, errors.NewInternalError("Erro ao criar check-out automático", err)
+		}
+
+		s.releaseSpot(ctx, ci)
+		closed = append(closed, checkout)
+	}
+
+	return closed, nil
+}
+
+// ListAutoClosedCheckouts lista check-outs gerados pelo encerramento automático
+func (s *serviceImpl) ListAutoClosedCheckouts(ctx context.Context, tenantID value_objects.UUID, filters ListFilters) ([]*Checkout, int, error) {
+	autoClosed := true
+	filters.TenantID = &tenantID
+	filters.AutoClosed = &autoClosed
+
+	return s.ListCheckouts(ctx, filters)
+}
+
+// CorrectCheckoutTime corrige o horário de um check-out encerrado automaticamente
+func (s *serviceImpl) CorrectCheckoutTime(ctx context.Context, checkoutID, tenantID value_objects.UUID, checkoutTime time.Time, reason string, correctedBy value_objects.UUID) (*Checkout, error) {
+	checkout, err := s.repo.GetByID(ctx, checkoutID)
+	if err != nil || !checkout.TenantID.Equals(tenantID) {
+		return nil, errors.NewNotFoundError("Checkout", checkoutID.String())
+	}
+
+	if !checkout.AutoClosed {
+		return nil, errors.NewValidationError("CheckoutTime", "apenas check-outs encerrados automaticamente podem ter o horário corrigido")
+	}
+
+	if checkout.IsVoided() {
+		return nil, errors.NewValidationError("CheckoutTime", "check-out anulado não pode ser corrigido").
+			WithContext("reason_code", constants.EligibilityCheckoutVoided)
+	}
+
+	ci, err := s.checkinRepo.GetByID(ctx, checkout.CheckinID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Checkin não encontrado", err)
+	}
+
+	if err := checkout.CorrectCheckoutTime(checkoutTime, ci.CheckinTime, reason, correctedBy); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, checkout); err != nil {
+		return nil, errors.NewInternalError("Erro ao atualizar check-out", err)
+	}
+
+	s.invalidateStats(ctx, checkout)
+
+	return checkout, nil
+}
+
+// VoidCheckout anula um check-out registrado por engano, mantendo o registro original.
+// A sessão de trabalho volta a ficar aberta até um check-out substituto ser registrado.
+func (s *serviceImpl) VoidCheckout(ctx context.Context, checkoutID, tenantID value_objects.UUID, reason string, voidedBy value_objects.UUID) (*Checkout, error) {
+	checkout, err := s.repo.GetByID(ctx, checkoutID)
+	if err != nil || !checkout.TenantID.Equals(tenantID) {
+		return nil, errors.NewNotFoundError("Checkout", checkoutID.String())
+	}
+
+	if err := checkout.Void(reason, voidedBy); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, checkout); err != nil {
+		return nil, errors.NewInternalError("Erro ao anular check-out", err)
+	}
+
+	// A sessão reaberta volta a contar na ocupação
+	if s.occupancy != nil {
+		s.occupancy.Invalidate(ctx, checkout.EventID)
+	}
+	s.invalidateStats(ctx, checkout)
+
+	return checkout, nil
+}
+
+// invalidateStats descarta as estatísticas em cache da sessão após anulações e correções
+func (s *serviceImpl) invalidateStats(ctx context.Context, checkout *Checkout) {
+	if s.statsRepo != nil {
+		s.statsRepo.InvalidateSessionStats(ctx, checkout.TenantID, checkout.EventID, checkout.EmployeeID, checkout.PartnerID)
+	}
+}
+
 // ValidateFacialRecognition valida check-out por reconhecimento facial
 func (s *serviceImpl) ValidateFacialRecognition(ctx context.Context, checkout *Checkout, faceEmbedding []float32) (*ValidationResult, error) {
-	// TODO: Implementar validação de reconhecimento facial
-	// 1. Buscar embedding facial do funcionário
-	// 2. Calcular similaridade coseno
-	// 3. Verificar threshold de confiança
-	// 4. Comparar com check-in (mesma pessoa?)
+	if len(faceEmbedding) != constants.FaceEmbeddingDimensions {
+		return nil, errors.NewValidationError("FaceEmbedding", "deve ter exatamente 512 dimensões")
+	}
+
+	emp, err := s.employeeRepo.GetByID(ctx, checkout.EmployeeID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Funcionário não encontrado", err)
+	}
+
+	// O embedding cadastrado só é decifrado para a comparação, e apenas com consentimento vigente
+	if emp.HasBiometricConsent() {
+		if err := employee.OpenBiometrics(ctx, s.employeeRepo, emp); err != nil {
+			return nil, err
+		}
+	}
+
+	threshold := s.config.FacialSimilarityThreshold
+
+	if !emp.CanPerformFacialRecognition() {
+		reason := "Funcionário sem embedding facial cadastrado"
+		if !emp.HasBiometricConsent() {
+			reason = "Funcionário sem consentimento biométrico vigente"
+		}
+
+		result := NewValidationResult(false, reason)
+		result.AddDetail("biometric_consent", emp.HasBiometricConsent())
+		result.AddDetail("facial_threshold", float64(threshold))
+		result.AddDetail("confidence_level", "none")
+		return result, nil
+	}
+
+	matches, similarity := emp.CompareFaceEmbedding(faceEmbedding, threshold)
+
+	reason := "Reconhecimento facial validado"
+	if !matches {
+		reason = "Similaridade facial abaixo do limite de confiança"
+	}
+
+	result := NewValidationResult(matches, reason)
+	result.SetFacialSimilarity(float64(similarity))
+	result.AddDetail("facial_threshold", float64(threshold))
+	result.AddDetail("confidence_level", employee.ConfidenceLevel(similarity))
+
+	// Comparar com o rosto capturado no check-in (mesma pessoa?)
+	checkinEntity, err := s.checkinRepo.GetByID(ctx, checkout.CheckinID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Checkin não encontrado", err)
+	}
+
+	// O embedding do check-in é decifrado somente aqui, para esta comparação
+	checkinEmbedding, err := s.checkinRepo.OpenFaceEmbedding(ctx, checkinEntity)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao abrir embedding facial do check-in", err)
+	}
+
+	if len(checkinEmbedding) == constants.FaceEmbeddingDimensions {
+		checkinSimilarity := employee.CompareEmbeddings(checkinEmbedding, faceEmbedding)
+		result.AddDetail("checkin_facial_similarity", float64(checkinSimilarity))
 
-	result := NewValidationResult(true, "Reconhecimento facial validado")
-	result.SetFacialSimilarity(0.93) // Simulado
-	result.AddDetail("confidence_level", "high")
+		if checkinSimilarity < threshold {
+			result.IsValid = false
+			if matches {
+				result.Reason = "Rosto do check-out não corresponde ao capturado no check-in"
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// ValidateQRCode valida e consome o QR Code lido no check-out
+func (s *serviceImpl) ValidateQRCode(ctx context.Context, checkout *Checkout, qrCodeData string) (*ValidationResult, error) {
+	qr, err := s.qrService.ConsumeQRCode(ctx, qrCodeData, checkout.EventID, constants.QRTypeCheckout, checkout.CheckoutTime)
+	if err != nil {
+		return nil, err
+	}
+
+	result := NewValidationResult(true, "QR Code validado")
+	result.AddDetail("qr_code_id", qr.ID.String())
+	result.AddDetail("qr_code_valid_until", qr.ValidUntil)
 
 	return result, nil
 }
 
-// ValidateGeolocation valida localização do check-out
-func (s *serviceImpl) ValidateGeolocation(ctx context.Context, checkout *Checkout, eventLocation value_objects.Location, eventFence []value_objects.Location) (*ValidationResult, error) {
-	// TODO: Implementar validação geográfica
-	// 1. Calcular distância do check-out ao evento
-	// 2. Verificar se está dentro da cerca geográfica
-	// 3. Aplicar tolerâncias configuráveis
+// ValidateGeolocation valida localização do check-out contra a cerca do evento
+func (s *serviceImpl) ValidateGeolocation(ctx context.Context, checkout *Checkout, evt *event.Event) (*ValidationResult, error) {
+	check := evt.CheckLocationWithAccuracy(checkout.Location, checkout.LocationFix)
 
-	distance := checkout.Location.DistanceTo(eventLocation)
-	withinBounds := distance <= 100 // 100 metros de tolerância
+	reason := "Localização validada"
+	if !check.HasFence {
+		reason = "Evento sem cerca geográfica definida"
+	} else if check.Missing {
+		reason = "Localização não informada para evento com cerca geográfica"
+	} else if !check.WithinTolerance {
+		reason = "Localização fora da cerca do evento"
+	} else if !check.WithinFence {
+		reason = "Localização fora da cerca do evento, dentro da tolerância"
+	}
 
-	result := NewValidationResult(withinBounds, "Localização validada")
+	// Distância fora da cerca (zero quando dentro do polígono)
+	distance := 0.0
+	if !check.WithinFence {
+		distance = check.DistanceToEdge
+	}
+
+	result := NewValidationResult(check.WithinTolerance, reason)
 	result.SetDistance(distance)
-	result.SetWithinBounds(withinBounds)
+	result.SetWithinBounds(check.WithinTolerance)
+	result.AddDetail("inside_event_fence", check.WithinFence)
+	result.AddDetail("fence_tolerance_meters", check.ToleranceMeters)
+	if check.AccuracyMeters > 0 {
+		result.AddDetail("fence_accuracy_credit_meters", check.AccuracyMeters)
+	}
+	if check.Missing {
+		result.AddDetail("location_missing", true)
+	} else if check.HasFence {
+		result.AddDetail("distance_to_fence_edge", check.DistanceToEdge)
+	}
 
 	return result, nil
 }
@@ -0,0 +1,175 @@
+package datasubject
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Categorias de dados contadas nas evidências das solicitações
+const (
+	CategoryProfile        = "profile"
+	CategoryPhotos         = "photos"
+	CategoryFaceEmbeddings = "face_embeddings"
+	CategoryFaceTemplates  = "face_templates"
+	CategoryCheckins       = "checkins"
+	CategoryCheckouts      = "checkouts"
+	CategoryAuditEntries   = "audit_entries"
+	CategoryConsents       = "biometric_consents"
+)
+
+// Request representa uma solicitação de titular (LGPD, art. 18) registrada com sua situação e evidência de atendimento
+type Request struct {
+	ID            value_objects.UUID
+	TenantID      value_objects.UUID
+	SubjectType   string // employee, partner
+	SubjectID     value_objects.UUID
+	Type          string // export, erasure
+	Status        string // processing, completed, failed
+	Reason        string // Origem da solicitação informada pelo operador (canal, protocolo)
+	Evidence      *Evidence
+	FailureReason string
+	RequestedBy   value_objects.UUID
+	RequestedAt   time.Time
+	CompletedAt   *time.Time
+}
+
+// Evidence comprova o atendimento de uma solicitação
+type Evidence struct {
+	Records       map[string]int `json:"records"`                  // Registros exportados ou eliminados, por categoria
+	PhotosDeleted int            `json:"photos_deleted,omitempty"` // Arquivos removidos do armazenamento
+	ExportDigest  string         `json:"export_digest,omitempty"`  // SHA-256 do arquivo de exportação entregue
+}
+
+// NewRequest registra uma nova solicitação de titular, já em processamento
+func NewRequest(tenantID value_objects.UUID, subjectType string, subjectID value_objects.UUID, requestType, reason string, requestedBy value_objects.UUID) (*Request, error) {
+	if tenantID.IsZero() {
+		return nil, errors.NewValidationError("TenantID", "é obrigatório")
+	}
+
+	if !IsValidSubjectType(subjectType) {
+		return nil, errors.NewValidationError("SubjectType", "tipo de titular não reconhecido")
+	}
+
+	if subjectID.IsZero() {
+		return nil, errors.NewValidationError("SubjectID", "é obrigatório")
+	}
+
+	if requestType != constants.DataSubjectRequestExport && requestType != constants.DataSubjectRequestErasure {
+		return nil, errors.NewValidationError("Type", "tipo de solicitação não reconhecido")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if len(reason) > constants.DataSubjectMaxReasonLength {
+		return nil, errors.NewValidationError("Reason", "deve ter no máximo 500 caracteres")
+	}
+
+	return &Request{
+		ID:          value_objects.NewUUID(),
+		TenantID:    tenantID,
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		Type:        requestType,
+		Status:      constants.DataSubjectStatusProcessing,
+		Reason:      reason,
+		RequestedBy: requestedBy,
+		RequestedAt: time.Now().UTC(),
+	}, nil
+}
+
+// IsValidSubjectType verifica se o tipo de titular é válido
+func IsValidSubjectType(subjectType string) bool {
+	return subjectType == constants.DataSubjectEmployee || subjectType == constants.DataSubjectPartner
+}
+
+// Complete conclui a solicitação com a evidência de atendimento
+func (r *Request) Complete(evidence *Evidence) {
+	now := time.Now().UTC()
+	r.Status = constants.DataSubjectStatusCompleted
+	r.Evidence = evidence
+	r.FailureReason = ""
+	r.CompletedAt = &now
+}
+
+// Fail encerra a solicitação sem atendimento; a evidência parcial, quando houver, é mantida
+func (r *Request) Fail(reason string, evidence *Evidence) {
+	now := time.Now().UTC()
+	r.Status = constants.DataSubjectStatusFailed
+	r.Evidence = evidence
+	r.FailureReason = reason
+	r.CompletedAt = &now
+}
+
+// Export é a cópia legível por máquina dos dados do titular.
+// Os registros são exportados com todas as colunas gravadas; os embeddings são exportados decifrados.
+type Export struct {
+	FormatVersion  int               `json:"format_version"`
+	RequestID      string            `json:"request_id"`
+	TenantID       string            `json:"tenant_id"`
+	SubjectType    string            `json:"subject_type"`
+	SubjectID      string            `json:"subject_id"`
+	GeneratedAt    time.Time         `json:"generated_at"`
+	Profile        json.RawMessage   `json:"profile"`
+	Photos         []Photo           `json:"photos"`
+	FaceEmbeddings []FaceEmbedding   `json:"face_embeddings"`
+	Checkins       []json.RawMessage `json:"checkins"`
+	Checkouts      []json.RawMessage `json:"checkouts"`
+	Consents       []json.RawMessage `json:"biometric_consents"`
+	AuditEntries   []json.RawMessage `json:"audit_entries"`
+}
+
+// Photo representa uma foto do titular, entregue por URL de download de curta duração
+type Photo struct {
+	Key       string     `json:"key"`
+	Source    string     `json:"source"` // employees, employee_face_templates, checkin
+	RecordID  string     `json:"record_id"`
+	URL       string     `json:"url,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// FaceEmbedding representa um embedding facial do titular
+type FaceEmbedding struct {
+	Source     string     `json:"source"` // employees, employee_face_templates, checkin
+	RecordID   string     `json:"record_id"`
+	Vector     []float32  `json:"vector"`
+	CapturedAt *time.Time `json:"captured_at,omitempty"`
+}
+
+// Records conta os registros exportados por categoria
+func (e *Export) Records() map[string]int {
+	records := map[string]int{
+		CategoryPhotos:         len(e.Photos),
+		CategoryFaceEmbeddings: len(e.FaceEmbeddings),
+		CategoryCheckins:       len(e.Checkins),
+		CategoryCheckouts:      len(e.Checkouts),
+		CategoryConsents:       len(e.Consents),
+		CategoryAuditEntries:   len(e.AuditEntries),
+	}
+	if len(e.Profile) > 0 {
+		records[CategoryProfile] = 1
+	}
+
+	return records
+}
+
+// Digest calcula o SHA-256 do arquivo de exportação, guardado como evidência da entrega
+func (e *Export) Digest() (string, error) {
+	document, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(document)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Erasure resume os registros alterados na eliminação dos dados de um titular
+type Erasure struct {
+	Records map[string]int
+}
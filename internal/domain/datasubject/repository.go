@@ -0,0 +1,67 @@
+package datasubject
+
+import (
+	"context"
+
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Repository define a persistência do registro de solicitações e o acesso aos dados dos titulares
+type Repository interface {
+	// Create grava uma nova solicitação
+	Create(ctx context.Context, request *Request) error
+
+	// Update grava a situação e a evidência de uma solicitação
+	Update(ctx context.Context, request *Request) error
+
+	// GetByID busca uma solicitação do tenant; retorna NotFound quando não existe
+	GetByID(ctx context.Context, tenantID, id value_objects.UUID) (*Request, error)
+
+	// List lista as solicitações do tenant, das mais recentes para as mais antigas
+	List(ctx context.Context, tenantID value_objects.UUID, filters ListFilters) ([]*Request, int, error)
+
+	// Collect reúne todos os dados gravados sobre o titular; retorna NotFound quando o titular não existe no tenant.
+	// As URLs das fotos não são preenchidas.
+	Collect(ctx context.Context, tenantID value_objects.UUID, subjectType string, subjectID value_objects.UUID) (*Export, error)
+
+	// ListPhotoKeys lista as chaves das fotos do titular no armazenamento
+	ListPhotoKeys(ctx context.Context, tenantID value_objects.UUID, subjectType string, subjectID value_objects.UUID) ([]string, error)
+
+	// Erase elimina, em uma única transação, os dados pessoais e biométricos do titular e anonimiza seus
+	// registros de presença, que continuam contando nas estatísticas. Retorna NotFound quando o titular não existe.
+	Erase(ctx context.Context, tenantID value_objects.UUID, subjectType string, subjectID, erasedBy value_objects.UUID) (*Erasure, error)
+}
+
+// ListFilters define os filtros para listagem de solicitações
+type ListFilters struct {
+	SubjectType *string
+	SubjectID   *value_objects.UUID
+	Type        *string
+	Status      *string
+
+	// Paginação
+	Page     int
+	PageSize int
+}
+
+// Validate valida os filtros de listagem
+func (f *ListFilters) Validate() error {
+	if f.Page < 1 {
+		f.Page = 1
+	}
+
+	if f.PageSize < 1 {
+		f.PageSize = 20
+	}
+
+	if f.PageSize > 100 {
+		f.PageSize = 100
+	}
+
+	return nil
+}
+
+// GetOffset calcula o offset para paginação
+func (f *ListFilters) GetOffset() int {
+	return (f.Page - 1) * f.PageSize
+}
@@ -0,0 +1,175 @@
+package datasubject
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/domain/storage"
+
+	"go.uber.org/zap"
+)
+
+// Service define a interface para o atendimento das solicitações dos titulares de dados (LGPD).
+// Toda solicitação, atendida ou não, fica registrada com sua situação e evidência.
+type Service interface {
+	// Export gera a cópia legível por máquina de todos os dados do titular
+	Export(ctx context.Context, tenantID value_objects.UUID, subjectType string, subjectID value_objects.UUID, reason string, requestedBy value_objects.UUID) (*Request, *Export, error)
+
+	// Erase elimina os dados pessoais e biométricos do titular, anonimizando seus registros de presença
+	Erase(ctx context.Context, tenantID value_objects.UUID, subjectType string, subjectID value_objects.UUID, reason string, requestedBy value_objects.UUID) (*Request, error)
+
+	// GetRequest busca uma solicitação registrada do tenant
+	GetRequest(ctx context.Context, tenantID, id value_objects.UUID) (*Request, error)
+
+	// ListRequests lista as solicitações registradas do tenant
+	ListRequests(ctx context.Context, tenantID value_objects.UUID, filters ListFilters) ([]*Request, int, error)
+}
+
+// serviceImpl implementa a interface Service
+type serviceImpl struct {
+	repo   Repository
+	photos storage.Service
+	logger *zap.Logger
+}
+
+// NewService cria uma nova instância do serviço
+func NewService(repo Repository, photos storage.Service, logger *zap.Logger) Service {
+	return &serviceImpl{
+		repo:   repo,
+		photos: photos,
+		logger: logger,
+	}
+}
+
+// Export registra a solicitação, reúne os dados do titular e assina as URLs das fotos.
+// O SHA-256 do arquivo entregue fica na evidência da solicitação.
+func (s *serviceImpl) Export(ctx context.Context, tenantID value_objects.UUID, subjectType string, subjectID value_objects.UUID, reason string, requestedBy value_objects.UUID) (*Request, *Export, error) {
+	request, err := s.open(ctx, tenantID, subjectType, subjectID, constants.DataSubjectRequestExport, reason, requestedBy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	export, err := s.repo.Collect(ctx, tenantID, subjectType, subjectID)
+	if err != nil {
+		return nil, nil, s.fail(ctx, request, nil, err)
+	}
+
+	export.FormatVersion = constants.DataSubjectExportFormatVersion
+	export.RequestID = request.ID.String()
+	export.TenantID = tenantID.String()
+	export.SubjectType = subjectType
+	export.SubjectID = subjectID.String()
+	export.GeneratedAt = time.Now().UTC()
+
+	for i := range export.Photos {
+		signed, err := s.photos.SignedURL(tenantID, export.Photos[i].Key)
+		if err != nil {
+			return nil, nil, s.fail(ctx, request, nil, err)
+		}
+		export.Photos[i].URL = signed.URL
+		export.Photos[i].ExpiresAt = &signed.ExpiresAt
+	}
+
+	digest, err := export.Digest()
+	if err != nil {
+		return nil, nil, s.fail(ctx, request, nil, errors.NewInternalError("Erro ao gerar exportação", err))
+	}
+
+	request.Complete(&Evidence{Records: export.Records(), ExportDigest: digest})
+	if err := s.repo.Update(ctx, request); err != nil {
+		return nil, nil, errors.NewInternalError("Erro ao registrar solicitação", err)
+	}
+
+	return request, export, nil
+}
+
+// Erase remove as fotos do armazenamento antes de eliminar os dados gravados: se a remoção falhar,
+// o banco ainda referencia as fotos e a eliminação pode ser repetida.
+func (s *serviceImpl) Erase(ctx context.Context, tenantID value_objects.UUID, subjectType string, subjectID value_objects.UUID, reason string, requestedBy value_objects.UUID) (*Request, error) {
+	request, err := s.open(ctx, tenantID, subjectType, subjectID, constants.DataSubjectRequestErasure, reason, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.repo.ListPhotoKeys(ctx, tenantID, subjectType, subjectID)
+	if err != nil {
+		return nil, s.fail(ctx, request, nil, err)
+	}
+
+	evidence := &Evidence{Records: map[string]int{}}
+	for _, key := range keys {
+		if err := s.photos.Delete(ctx, tenantID, key); err != nil {
+			return nil, s.fail(ctx, request, evidence, err)
+		}
+		evidence.PhotosDeleted++
+	}
+
+	erasure, err := s.repo.Erase(ctx, tenantID, subjectType, subjectID, requestedBy)
+	if err != nil {
+		return nil, s.fail(ctx, request, evidence, err)
+	}
+	evidence.Records = erasure.Records
+
+	request.Complete(evidence)
+	if err := s.repo.Update(ctx, request); err != nil {
+		return nil, errors.NewInternalError("Erro ao registrar solicitação", err)
+	}
+
+	s.logger.Info("Data subject erased",
+		zap.String("request_id", request.ID.String()),
+		zap.String("subject_type", subjectType),
+		zap.String("subject_id", subjectID.String()),
+		zap.Int("photos_deleted", evidence.PhotosDeleted))
+
+	return request, nil
+}
+
+// GetRequest busca uma solicitação registrada do tenant
+func (s *serviceImpl) GetRequest(ctx context.Context, tenantID, id value_objects.UUID) (*Request, error) {
+	return s.repo.GetByID(ctx, tenantID, id)
+}
+
+// ListRequests lista as solicitações registradas do tenant
+func (s *serviceImpl) ListRequests(ctx context.Context, tenantID value_objects.UUID, filters ListFilters) ([]*Request, int, error) {
+	if err := filters.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	return s.repo.List(ctx, tenantID, filters)
+}
+
+// open valida e registra a solicitação antes de qualquer acesso aos dados do titular
+func (s *serviceImpl) open(ctx context.Context, tenantID value_objects.UUID, subjectType string, subjectID value_objects.UUID, requestType, reason string, requestedBy value_objects.UUID) (*Request, error) {
+	request, err := NewRequest(tenantID, subjectType, subjectID, requestType, reason, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, request); err != nil {
+		return nil, errors.NewInternalError("Erro ao registrar solicitação", err)
+	}
+
+	return request, nil
+}
+
+// fail registra a falha da solicitação e devolve o erro original
+func (s *serviceImpl) fail(ctx context.Context, request *Request, evidence *Evidence, cause error) error {
+	reason := cause.Error()
+	var domainErr *errors.DomainError
+	if stderrors.As(cause, &domainErr) {
+		reason = domainErr.Message
+	}
+
+	request.Fail(reason, evidence)
+	if err := s.repo.Update(ctx, request); err != nil {
+		s.logger.Error("Failed to record data subject request failure",
+			zap.String("request_id", request.ID.String()),
+			zap.Error(err))
+	}
+
+	return cause
+}
@@ -0,0 +1,214 @@
+package device
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Device representa um quiosque ou coletor portátil registrado para um tenant
+type Device struct {
+	ID                 value_objects.UUID
+	TenantID           value_objects.UUID
+	Name               string
+	Type               string              // kiosk, handheld
+	EventID            *value_objects.UUID // Evento ao qual o dispositivo está vinculado (nil = qualquer evento do tenant)
+	Gate               string              // Portão ou ponto de acesso onde o dispositivo opera
+	SecretHash         string              // Hash SHA-256 da credencial; a credencial é exibida apenas na emissão
+	EncryptedKey       []byte              // Credencial cifrada com a chave do servidor, usada para validar as assinaturas HMAC
+	CredentialIssuedAt *time.Time
+	AppVersion         string // Versão do aplicativo informada no último acesso
+	LastSeenAt         *time.Time
+	Active             bool
+	RevokedAt          *time.Time // Dispositivos revogados não podem mais enviar registros
+	RevokedBy          *value_objects.UUID
+	RevokeReason       string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	CreatedBy          *value_objects.UUID
+	UpdatedBy          *value_objects.UUID
+}
+
+// NewDevice cria um novo dispositivo, ainda sem credencial
+func NewDevice(tenantID value_objects.UUID, name, deviceType string, eventID *value_objects.UUID, gate string, createdBy value_objects.UUID) (*Device, error) {
+	if tenantID.IsZero() {
+		return nil, errors.NewValidationError("TenantID", "é obrigatório")
+	}
+
+	if !IsValidType(deviceType) {
+		return nil, errors.NewValidationError("Type", "tipo de dispositivo não reconhecido")
+	}
+
+	name, gate = strings.TrimSpace(name), strings.TrimSpace(gate)
+	if err := validateDeviceData(name, gate); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	return &Device{
+		ID:        value_objects.NewUUID(),
+		TenantID:  tenantID,
+		Name:      name,
+		Type:      deviceType,
+		EventID:   eventID,
+		Gate:      gate,
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: &createdBy,
+		UpdatedBy: &createdBy,
+	}, nil
+}
+
+// IsValidType verifica se o tipo de dispositivo é válido
+func IsValidType(deviceType string) bool {
+	return deviceType == constants.DeviceTypeKiosk || deviceType == constants.DeviceTypeHandheld
+}
+
+// Update atualiza o nome e o vínculo do dispositivo com evento e portão
+func (d *Device) Update(name string, eventID *value_objects.UUID, gate string, updatedBy value_objects.UUID) error {
+	if d.IsRevoked() {
+		return errors.NewValidationError("Device", "dispositivo revogado não pode ser alterado")
+	}
+
+	name, gate = strings.TrimSpace(name), strings.TrimSpace(gate)
+	if err := validateDeviceData(name, gate); err != nil {
+		return err
+	}
+
+	d.Name = name
+	d.EventID = eventID
+	d.Gate = gate
+	d.touch(updatedBy)
+
+	return nil
+}
+
+// IssueCredential gera uma nova credencial para o dispositivo, invalidando a anterior.
+// Retorna a credencial em texto claro; apenas o hash é armazenado.
+func (d *Device) IssueCredential(issuedBy value_objects.UUID) (string, error) {
+	if d.IsRevoked() {
+		return "", errors.NewValidationError("Device", "dispositivo revogado não pode receber credenciais")
+	}
+
+	raw := make([]byte, constants.DeviceSecretBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", errors.NewInternalError("Erro ao gerar credencial do dispositivo", err)
+	}
+
+	secret := base64.RawURLEncoding.EncodeToString(raw)
+	now := time.Now().UTC()
+
+	d.SecretHash = hashSecret(secret)
+	d.EncryptedKey = nil
+	d.CredentialIssuedAt = &now
+	d.touch(issuedBy)
+
+	return secret, nil
+}
+
+// VerifySecret verifica a credencial apresentada pelo dispositivo em tempo constante
+func (d *Device) VerifySecret(secret string) bool {
+	if d.SecretHash == "" || secret == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(d.SecretHash), []byte(hashSecret(secret))) == 1
+}
+
+// Revoke revoga o dispositivo; a revogação é definitiva
+func (d *Device) Revoke(reason string, revokedBy value_objects.UUID) error {
+	if d.IsRevoked() {
+		return errors.NewValidationError("Device", "dispositivo já foi revogado")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.NewValidationError("Reason", "motivo da revogação é obrigatório")
+	}
+
+	now := time.Now().UTC()
+	d.RevokedAt = &now
+	d.RevokedBy = &revokedBy
+	d.RevokeReason = reason
+	d.Active = false
+	d.touch(revokedBy)
+
+	return nil
+}
+
+// IsRevoked verifica se o dispositivo foi revogado
+func (d *Device) IsRevoked() bool {
+	return d.RevokedAt != nil
+}
+
+// CanSubmit verifica se o dispositivo pode enviar check-ins e check-outs
+func (d *Device) CanSubmit() bool {
+	return d.Active && !d.IsRevoked() && d.SecretHash != ""
+}
+
+// IsBoundTo verifica se o dispositivo pode registrar acessos no evento informado
+func (d *Device) IsBoundTo(eventID value_objects.UUID) bool {
+	return d.EventID == nil || d.EventID.Equals(eventID)
+}
+
+// NeedsSeenUpdate verifica se o último acesso deve ser gravado: a gravação é espaçada para não onerar cada requisição
+func (d *Device) NeedsSeenUpdate(appVersion string, at time.Time) bool {
+	if d.LastSeenAt == nil || (appVersion != "" && appVersion != d.AppVersion) {
+		return true
+	}
+
+	return at.Sub(*d.LastSeenAt) >= constants.DeviceLastSeenInterval*time.Second
+}
+
+// RecordSeen registra o último acesso do dispositivo e a versão do aplicativo
+func (d *Device) RecordSeen(appVersion string, at time.Time) {
+	at = at.UTC()
+	d.LastSeenAt = &at
+
+	appVersion = strings.TrimSpace(appVersion)
+	if appVersion != "" {
+		if len(appVersion) > constants.DeviceMaxAppVersionLength {
+			appVersion = appVersion[:constants.DeviceMaxAppVersionLength]
+		}
+		d.AppVersion = appVersion
+	}
+}
+
+// touch atualiza os dados de auditoria
+func (d *Device) touch(updatedBy value_objects.UUID) {
+	d.UpdatedAt = time.Now().UTC()
+	d.UpdatedBy = &updatedBy
+}
+
+// validateDeviceData valida os dados editáveis do dispositivo
+func validateDeviceData(name, gate string) error {
+	if name == "" {
+		return errors.NewValidationError("Name", "é obrigatório")
+	}
+
+	if len(name) > constants.DeviceMaxNameLength {
+		return errors.NewValidationError("Name", "deve ter no máximo 100 caracteres")
+	}
+
+	if len(gate) > constants.DeviceMaxGateLength {
+		return errors.NewValidationError("Gate", "deve ter no máximo 100 caracteres")
+	}
+
+	return nil
+}
+
+// hashSecret calcula o hash da credencial; a credencial tem entropia alta e dispensa hash lento
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
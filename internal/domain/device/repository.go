@@ -0,0 +1,63 @@
+package device
+
+import (
+	"context"
+	"time"
+
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Repository define as operações de persistência para dispositivos
+type Repository interface {
+	// Create cria um novo dispositivo
+	Create(ctx context.Context, device *Device) error
+
+	// GetByID busca um dispositivo pelo ID
+	GetByID(ctx context.Context, id value_objects.UUID) (*Device, error)
+
+	// GetByIDAndTenant busca um dispositivo pelo ID dentro de um tenant
+	GetByIDAndTenant(ctx context.Context, id, tenantID value_objects.UUID) (*Device, error)
+
+	// Update atualiza um dispositivo existente
+	Update(ctx context.Context, device *Device) error
+
+	// TouchLastSeen registra o último acesso do dispositivo sem alterar os demais dados
+	TouchLastSeen(ctx context.Context, id value_objects.UUID, appVersion string, at time.Time) error
+
+	// ListByTenant lista os dispositivos de um tenant com paginação e filtros
+	ListByTenant(ctx context.Context, tenantID value_objects.UUID, filters ListFilters) ([]*Device, int, error)
+}
+
+// ListFilters define os filtros para listagem de dispositivos
+type ListFilters struct {
+	EventID *value_objects.UUID
+	Type    *string
+	Active  *bool
+	Revoked *bool
+
+	// Paginação
+	Page     int
+	PageSize int
+}
+
+// Validate valida os filtros de listagem
+func (f *ListFilters) Validate() error {
+	if f.Page < 1 {
+		f.Page = 1
+	}
+
+	if f.PageSize < 1 {
+		f.PageSize = 20
+	}
+
+	if f.PageSize > 100 {
+		f.PageSize = 100
+	}
+
+	return nil
+}
+
+// GetOffset calcula o offset para paginação
+func (f *ListFilters) GetOffset() int {
+	return (f.Page - 1) * f.PageSize
+}
@@ -0,0 +1,300 @@
+package device
+
+import (
+	"context"
+	"time"
+
+	"eventos-backend/internal/domain/event"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Service define a interface para o registro de dispositivos
+type Service interface {
+	// RegisterDevice registra um novo dispositivo e emite sua credencial, retornada apenas nesta chamada
+	RegisterDevice(ctx context.Context, tenantID value_objects.UUID, name, deviceType string, eventID *value_objects.UUID, gate string, createdBy value_objects.UUID) (*Device, string, error)
+
+	// UpdateDevice atualiza o nome e o vínculo do dispositivo com evento e portão
+	UpdateDevice(ctx context.Context, tenantID, id value_objects.UUID, name string, eventID *value_objects.UUID, gate string, updatedBy value_objects.UUID) (*Device, error)
+
+	// GetDevice busca um dispositivo do tenant
+	GetDevice(ctx context.Context, tenantID, id value_objects.UUID) (*Device, error)
+
+	// ListDevices lista os dispositivos do tenant
+	ListDevices(ctx context.Context, tenantID value_objects.UUID, filters ListFilters) ([]*Device, int, error)
+
+	// RotateCredential emite uma nova credencial, invalidando a anterior imediatamente
+	RotateCredential(ctx context.Context, tenantID, id value_objects.UUID, issuedBy value_objects.UUID) (*Device, string, error)
+
+	// RevokeDevice revoga o dispositivo; seus envios passam a ser recusados imediatamente
+	RevokeDevice(ctx context.Context, tenantID, id value_objects.UUID, reason string, revokedBy value_objects.UUID) (*Device, error)
+
+	// Authenticate valida a credencial apresentada pelo dispositivo e registra o acesso
+	Authenticate(ctx context.Context, tenantID, id value_objects.UUID, secret, appVersion string) (*Device, error)
+
+	// AuthenticateSigned valida uma requisição assinada pelo dispositivo, sem usuário associado
+	AuthenticateSigned(ctx context.Context, req SignedRequest) (*Device, error)
+}
+
+// Config contém as configurações do serviço de dispositivos
+type Config struct {
+	SignatureMaxSkew time.Duration // Diferença máxima entre o relógio do dispositivo e o do servidor
+}
+
+// serviceImpl implementa a interface Service
+type serviceImpl struct {
+	repo      Repository
+	eventRepo event.Repository
+	cipher    KeyCipher
+	nonces    NonceStore
+	config    Config
+}
+
+// NewService cria uma nova instância do serviço
+func NewService(repo Repository, eventRepo event.Repository, cipher KeyCipher, nonces NonceStore, config Config) Service {
+	return &serviceImpl{
+		repo:      repo,
+		eventRepo: eventRepo,
+		cipher:    cipher,
+		nonces:    nonces,
+		config:    config,
+	}
+}
+
+// RegisterDevice registra um novo dispositivo
+func (s *serviceImpl) RegisterDevice(ctx context.Context, tenantID value_objects.UUID, name, deviceType string, eventID *value_objects.UUID, gate string, createdBy value_objects.UUID) (*Device, string, error) {
+	if err := s.validateEvent(ctx, tenantID, eventID); err != nil {
+		return nil, "", err
+	}
+
+	device, err := NewDevice(tenantID, name, deviceType, eventID, gate, createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret, err := s.issueCredential(device, createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.repo.Create(ctx, device); err != nil {
+		return nil, "", errors.NewInternalError("Erro ao registrar dispositivo", err)
+	}
+
+	return device, secret, nil
+}
+
+// UpdateDevice atualiza um dispositivo do tenant
+func (s *serviceImpl) UpdateDevice(ctx context.Context, tenantID, id value_objects.UUID, name string, eventID *value_objects.UUID, gate string, updatedBy value_objects.UUID) (*Device, error) {
+	device, err := s.GetDevice(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateEvent(ctx, tenantID, eventID); err != nil {
+		return nil, err
+	}
+
+	if err := device.Update(name, eventID, gate, updatedBy); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, device); err != nil {
+		return nil, errors.NewInternalError("Erro ao atualizar dispositivo", err)
+	}
+
+	return device, nil
+}
+
+// GetDevice busca um dispositivo do tenant
+func (s *serviceImpl) GetDevice(ctx context.Context, tenantID, id value_objects.UUID) (*Device, error) {
+	device, err := s.repo.GetByIDAndTenant(ctx, id, tenantID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("device", id.String())
+		}
+		return nil, errors.NewInternalError("Erro ao buscar dispositivo", err)
+	}
+
+	return device, nil
+}
+
+// ListDevices lista os dispositivos do tenant
+func (s *serviceImpl) ListDevices(ctx context.Context, tenantID value_objects.UUID, filters ListFilters) ([]*Device, int, error) {
+	if err := filters.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	devices, total, err := s.repo.ListByTenant(ctx, tenantID, filters)
+	if err != nil {
+		return nil, 0, errors.NewInternalError("Erro ao listar dispositivos", err)
+	}
+
+	return devices, total, nil
+}
+
+// RotateCredential emite uma nova credencial para o dispositivo
+func (s *serviceImpl) RotateCredential(ctx context.Context, tenantID, id value_objects.UUID, issuedBy value_objects.UUID) (*Device, string, error) {
+	device, err := s.GetDevice(ctx, tenantID, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret, err := s.issueCredential(device, issuedBy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.repo.Update(ctx, device); err != nil {
+		return nil, "", errors.NewInternalError("Erro ao emitir credencial do dispositivo", err)
+	}
+
+	return device, secret, nil
+}
+
+// RevokeDevice revoga um dispositivo do tenant
+func (s *serviceImpl) RevokeDevice(ctx context.Context, tenantID, id value_objects.UUID, reason string, revokedBy value_objects.UUID) (*Device, error) {
+	device, err := s.GetDevice(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := device.Revoke(reason, revokedBy); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, device); err != nil {
+		return nil, errors.NewInternalError("Erro ao revogar dispositivo", err)
+	}
+
+	return device, nil
+}
+
+// Authenticate valida a credencial do dispositivo.
+// O dispositivo é lido do banco a cada chamada para que revogações tenham efeito imediato.
+func (s *serviceImpl) Authenticate(ctx context.Context, tenantID, id value_objects.UUID, secret, appVersion string) (*Device, error) {
+	device, err := s.repo.GetByIDAndTenant(ctx, id, tenantID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NewUnauthorizedError("Dispositivo não registrado")
+		}
+		return nil, errors.NewInternalError("Erro ao buscar dispositivo", err)
+	}
+
+	if !device.VerifySecret(secret) {
+		return nil, errors.NewUnauthorizedError("Credencial do dispositivo inválida")
+	}
+
+	if !device.CanSubmit() {
+		return nil, errors.NewForbiddenError("device", "submit").
+			WithContext("revoked", device.IsRevoked())
+	}
+
+	s.recordSeen(ctx, device, appVersion)
+
+	return device, nil
+}
+
+// AuthenticateSigned valida a assinatura HMAC, o relógio e o nonce da requisição.
+// O dispositivo é lido do banco a cada chamada para que revogações tenham efeito imediato.
+func (s *serviceImpl) AuthenticateSigned(ctx context.Context, req SignedRequest) (*Device, error) {
+	now := time.Now().UTC()
+
+	skew := now.Sub(time.Unix(req.Timestamp, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > s.config.SignatureMaxSkew {
+		return nil, errors.NewUnauthorizedError("Horário da requisição fora da tolerância").
+			WithContext("reason_code", "CLOCK_SKEW").
+			WithContext("server_time", now.Unix())
+	}
+
+	if !IsValidNonce(req.Nonce) {
+		return nil, errors.NewUnauthorizedError("Nonce inválido").WithContext("reason_code", "INVALID_NONCE")
+	}
+
+	device, err := s.repo.GetByID(ctx, req.DeviceID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NewUnauthorizedError("Dispositivo não registrado").WithContext("reason_code", "UNKNOWN_DEVICE")
+		}
+		return nil, errors.NewInternalError("Erro ao buscar dispositivo", err)
+	}
+
+	if len(device.EncryptedKey) == 0 || s.cipher == nil {
+		return nil, errors.NewUnauthorizedError("Credencial do dispositivo deve ser reemitida").
+			WithContext("reason_code", "CREDENTIAL_NOT_SIGNABLE")
+	}
+
+	key, err := s.cipher.Decrypt(device.EncryptedKey)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao recuperar credencial do dispositivo", err)
+	}
+
+	if !VerifySignature(key, req) {
+		return nil, errors.NewUnauthorizedError("Assinatura inválida").WithContext("reason_code", "INVALID_SIGNATURE")
+	}
+
+	if !device.CanSubmit() {
+		return nil, errors.NewForbiddenError("device", "submit").
+			WithContext("revoked", device.IsRevoked())
+	}
+
+	// O nonce só é consumido após a assinatura ser validada, para que terceiros não esgotem nonces alheios
+	// A janela do nonce cobre a tolerância do relógio nos dois sentidos
+	fresh, err := s.nonces.Claim(ctx, device.ID, req.Nonce, 2*s.config.SignatureMaxSkew)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao registrar nonce", err)
+	}
+	if !fresh {
+		return nil, errors.NewUnauthorizedError("Requisição repetida").WithContext("reason_code", "REPLAYED_REQUEST")
+	}
+
+	s.recordSeen(ctx, device, req.AppVersion)
+
+	return device, nil
+}
+
+// issueCredential emite a credencial e guarda uma cópia cifrada para a validação das assinaturas
+func (s *serviceImpl) issueCredential(device *Device, issuedBy value_objects.UUID) (string, error) {
+	secret, err := device.IssueCredential(issuedBy)
+	if err != nil {
+		return "", err
+	}
+
+	if s.cipher != nil {
+		encrypted, err := s.cipher.Encrypt([]byte(secret))
+		if err != nil {
+			return "", errors.NewInternalError("Erro ao cifrar credencial do dispositivo", err)
+		}
+		device.EncryptedKey = encrypted
+	}
+
+	return secret, nil
+}
+
+// recordSeen registra o último acesso; falhas não impedem o envio
+func (s *serviceImpl) recordSeen(ctx context.Context, device *Device, appVersion string) {
+	now := time.Now().UTC()
+	if device.NeedsSeenUpdate(appVersion, now) {
+		device.RecordSeen(appVersion, now)
+		_ = s.repo.TouchLastSeen(ctx, device.ID, device.AppVersion, now)
+	}
+}
+
+// validateEvent verifica se o evento vinculado existe e pertence ao tenant
+func (s *serviceImpl) validateEvent(ctx context.Context, tenantID value_objects.UUID, eventID *value_objects.UUID) error {
+	if eventID == nil {
+		return nil
+	}
+
+	if _, err := s.eventRepo.GetByIDAndTenant(ctx, *eventID, tenantID); err != nil {
+		if errors.IsNotFound(err) {
+			return errors.NewValidationError("EventID", "evento não encontrado")
+		}
+		return errors.NewInternalError("Erro ao buscar evento", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package device
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// KeyCipher cifra a credencial do dispositivo para que o servidor possa validar assinaturas
+type KeyCipher interface {
+	// Encrypt cifra a credencial em texto claro
+	Encrypt(plaintext []byte) ([]byte, error)
+
+	// Decrypt recupera a credencial cifrada
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// NonceStore registra os nonces já usados para impedir a repetição de requisições
+type NonceStore interface {
+	// Claim reserva o nonce do dispositivo; retorna false se ele já foi usado dentro do TTL
+	Claim(ctx context.Context, deviceID value_objects.UUID, nonce string, ttl time.Duration) (bool, error)
+}
+
+// SignedRequest representa uma requisição assinada por um dispositivo
+type SignedRequest struct {
+	DeviceID   value_objects.UUID
+	Method     string
+	Path       string // Caminho com a query string, como enviado pelo dispositivo
+	BodyHash   string // SHA-256 do corpo em hexadecimal
+	Timestamp  int64  // Unix em segundos
+	Nonce      string
+	Signature  string // HMAC-SHA256 em hexadecimal
+	AppVersion string
+}
+
+// devicePermissions é o conjunto restrito de permissões concedido aos dispositivos
+var devicePermissions = map[string]map[string]bool{
+	constants.ModuleCheckins: {constants.PermissionWrite: true},
+}
+
+// HasPermission verifica se dispositivos podem executar a ação no módulo.
+// Dispositivos apenas registram check-ins e check-outs do evento ao qual estão vinculados.
+func HasPermission(module, action string) bool {
+	return devicePermissions[module][action]
+}
+
+// HashBody calcula o hash do corpo usado na assinatura
+func HashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// CanonicalString monta o texto assinado: método, caminho, hash do corpo, timestamp e nonce, um por linha
+func CanonicalString(method, path, bodyHash string, timestamp int64, nonce string) string {
+	return strings.Join([]string{
+		strings.ToUpper(method),
+		path,
+		strings.ToLower(bodyHash),
+		strconv.FormatInt(timestamp, 10),
+		nonce,
+	}, "\n")
+}
+
+// Sign calcula a assinatura HMAC-SHA256 do texto canônico com a credencial do dispositivo
+func Sign(key []byte, canonical string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(canonical))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature verifica a assinatura da requisição em tempo constante
+func VerifySignature(key []byte, req SignedRequest) bool {
+	expected := Sign(key, CanonicalString(req.Method, req.Path, req.BodyHash, req.Timestamp, req.Nonce))
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(req.Signature)))
+}
+
+// IsValidNonce verifica o formato do nonce
+func IsValidNonce(nonce string) bool {
+	if len(nonce) < constants.DeviceNonceMinLength || len(nonce) > constants.DeviceNonceMaxLength {
+		return false
+	}
+
+	for _, r := range nonce {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,224 @@
+package employee
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// ConsentText representa uma versão do termo de consentimento biométrico apresentado aos funcionários do tenant.
+// Versões publicadas não são alteradas; um novo texto gera uma nova versão.
+type ConsentText struct {
+	ID          value_objects.UUID
+	TenantID    value_objects.UUID
+	Version     int
+	Content     string
+	Digest      string // SHA-256 do texto, gravado em cada consentimento
+	PublishedAt time.Time
+	PublishedBy *value_objects.UUID
+}
+
+// NewConsentText cria uma nova versão do termo de consentimento biométrico
+func NewConsentText(tenantID value_objects.UUID, version int, content string, publishedBy value_objects.UUID) (*ConsentText, error) {
+	if tenantID.IsZero() {
+		return nil, errors.NewValidationError("tenant_id", "tenant is required")
+	}
+
+	if version < 1 {
+		return nil, errors.NewValidationError("version", "version must be positive")
+	}
+
+	content = strings.TrimSpace(content)
+	if content == "" || len(content) > constants.MaxConsentTextLength {
+		return nil, errors.NewValidationError("content", "consent text must be between 1 and 20000 characters")
+	}
+
+	sum := sha256.Sum256([]byte(content))
+
+	return &ConsentText{
+		ID:          value_objects.NewUUID(),
+		TenantID:    tenantID,
+		Version:     version,
+		Content:     content,
+		Digest:      hex.EncodeToString(sum[:]),
+		PublishedAt: time.Now().UTC(),
+		PublishedBy: &publishedBy,
+	}, nil
+}
+
+// BiometricConsent representa o consentimento do funcionário ao tratamento dos seus dados biométricos (LGPD, art. 11).
+// Os registros não são apagados: a revogação encerra o consentimento e o histórico comprova a base legal de cada período.
+type BiometricConsent struct {
+	ID                value_objects.UUID
+	TenantID          value_objects.UUID
+	EmployeeID        value_objects.UUID
+	TextVersion       int    // Versão do termo aceito
+	TextDigest        string // SHA-256 do termo aceito
+	Channel           string // kiosk, mobile_app, web, paper
+	GrantedAt         time.Time
+	GrantedBy         *value_objects.UUID
+	RevokedAt         *time.Time
+	RevokedBy         *value_objects.UUID
+	RevocationChannel string
+	RevocationReason  string
+}
+
+// BiometricPurge resume os dados biométricos eliminados com a revogação do consentimento
+type BiometricPurge struct {
+	FaceTemplates     int // Templates faciais removidos
+	CheckinEmbeddings int // Check-ins cujo embedding capturado foi apagado
+}
+
+// NewBiometricConsent registra o aceite do funcionário a uma versão do termo de consentimento
+func NewBiometricConsent(employee *Employee, text *ConsentText, channel string, grantedAt time.Time, grantedBy value_objects.UUID) (*BiometricConsent, error) {
+	if !text.TenantID.Equals(employee.TenantID) {
+		return nil, errors.NewValidationError("text_version", "consent text belongs to another tenant")
+	}
+
+	if !IsValidConsentChannel(channel) {
+		return nil, errors.NewValidationError("channel", "invalid consent channel")
+	}
+
+	now := time.Now().UTC()
+
+	if grantedAt.IsZero() {
+		grantedAt = now
+	}
+
+	if grantedAt.After(now.Add(time.Minute)) {
+		return nil, errors.NewValidationError("granted_at", "consent date cannot be in the future")
+	}
+
+	if grantedAt.Before(text.PublishedAt.Add(-time.Minute)) {
+		return nil, errors.NewValidationError("granted_at", "consent date cannot precede the consent text")
+	}
+
+	return &BiometricConsent{
+		ID:          value_objects.NewUUID(),
+		TenantID:    employee.TenantID,
+		EmployeeID:  employee.ID,
+		TextVersion: text.Version,
+		TextDigest:  text.Digest,
+		Channel:     channel,
+		GrantedAt:   grantedAt.UTC(),
+		GrantedBy:   &grantedBy,
+	}, nil
+}
+
+// IsActive verifica se o consentimento está vigente
+func (c *BiometricConsent) IsActive() bool {
+	return c.RevokedAt == nil
+}
+
+// revoke encerra o consentimento
+func (c *BiometricConsent) revoke(channel, reason string, revokedBy value_objects.UUID) {
+	now := time.Now().UTC()
+	c.RevokedAt = &now
+	c.RevokedBy = &revokedBy
+	c.RevocationChannel = channel
+	c.RevocationReason = reason
+}
+
+// IsValidConsentChannel verifica se o canal informado pode registrar consentimentos e revogações
+func IsValidConsentChannel(channel string) bool {
+	switch channel {
+	case constants.ConsentChannelKiosk, constants.ConsentChannelMobile, constants.ConsentChannelWeb, constants.ConsentChannelPaper:
+		return true
+	}
+
+	return false
+}
+
+// HasBiometricConsent verifica se o funcionário tem consentimento biométrico vigente
+func (e *Employee) HasBiometricConsent() bool {
+	return e.Consent != nil && e.Consent.IsActive()
+}
+
+// GrantBiometricConsent registra o consentimento como vigente, substituindo o aceite de um termo anterior
+func (e *Employee) GrantBiometricConsent(consent *BiometricConsent) error {
+	if !consent.EmployeeID.Equals(e.ID) {
+		return errors.NewValidationError("biometric_consent", "consent belongs to another employee")
+	}
+
+	if e.HasBiometricConsent() && e.Consent.TextVersion >= consent.TextVersion {
+		return errors.NewAlreadyExistsError("biometric consent", "text_version", consent.TextVersion)
+	}
+
+	e.Consent = consent
+
+	return nil
+}
+
+// RevokeBiometricConsent encerra o consentimento vigente e descarta os templates e o embedding facial do funcionário
+func (e *Employee) RevokeBiometricConsent(channel, reason string, revokedBy value_objects.UUID) (*BiometricConsent, error) {
+	if !e.HasBiometricConsent() {
+		return nil, errors.NewNotFoundError("biometric consent", e.ID.String())
+	}
+
+	if !IsValidConsentChannel(channel) {
+		return nil, errors.NewValidationError("channel", "invalid consent channel")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if len(reason) > constants.MaxConsentRevocationReasonLength {
+		return nil, errors.NewValidationError("reason", "revocation reason must be at most 500 characters")
+	}
+
+	consent := e.Consent
+	consent.revoke(channel, reason, revokedBy)
+
+	e.FaceTemplates = nil
+	e.FaceEmbedding = nil
+	e.SealedFaceEmbedding = nil
+	e.FaceEnrolled = false
+	e.UpdatedAt = time.Now().UTC()
+	e.UpdatedBy = &revokedBy
+
+	return consent, nil
+}
+
+// requireBiometricConsent recusa o tratamento biométrico de funcionários sem consentimento vigente
+func (e *Employee) requireBiometricConsent() error {
+	if e.HasBiometricConsent() {
+		return nil
+	}
+
+	return errors.NewValidationError("biometric_consent", "employee has no active biometric consent").
+		WithContext("reason_code", constants.FaceTemplateRejectNoConsent)
+}
+
+// ConsentCounts conta os funcionários por situação do consentimento biométrico
+type ConsentCounts struct {
+	Employees              int // Funcionários ativos vinculados
+	Consented              int // Com consentimento vigente
+	FaceEnrolled           int // Com cadastro facial
+	EnrolledWithoutConsent int // Cadastros faciais anteriores à exigência do consentimento, bloqueados para reconhecimento
+}
+
+// Rate calcula a fração dos funcionários com consentimento vigente
+func (c ConsentCounts) Rate() float64 {
+	if c.Employees == 0 {
+		return 0
+	}
+
+	return float64(c.Consented) / float64(c.Employees)
+}
+
+// ConsentCoverage representa a cobertura do consentimento biométrico entre os funcionários de um evento
+type ConsentCoverage struct {
+	EventID value_objects.UUID
+	ConsentCounts
+	Partners []*PartnerConsentCoverage
+}
+
+// PartnerConsentCoverage representa a cobertura do consentimento entre os funcionários de um parceiro do evento
+type PartnerConsentCoverage struct {
+	PartnerID   value_objects.UUID
+	PartnerName string
+	ConsentCounts
+}
@@ -1,6 +1,7 @@
 package employee
 
 import (
+	"fmt"
 	"time"
 
 	"eventos-backend/internal/domain/shared/constants"
@@ -16,8 +17,15 @@ type Employee struct {
 	Identity      string
 	IdentityType  string
 	DateOfBirth   *time.Time
-	PhotoURL      string
-	FaceEmbedding []float32 // Embedding facial para reconhecimento (512 dimensões)
+	PhotoURL      string    // URL externa da foto (cadastros anteriores ao armazenamento próprio)
+	PhotoKey      string    // Chave da foto no armazenamento de arquivos
+	FaceEmbedding []float32 // Embedding facial para reconhecimento (512 dimensões); espelha o template de maior qualidade
+	FaceEnrolled  bool      // Há embedding cadastrado
+	// Embedding gravado, cifrado; aberto por OpenBiometrics apenas no reconhecimento e no cadastro facial
+	SealedFaceEmbedding []byte
+	// Templates faciais cadastrados; carregados por OpenBiometrics
+	FaceTemplates []*FaceTemplate
+	Consent       *BiometricConsent // Consentimento biométrico vigente; carregado apenas na busca por ID
 	Phone         string
 	Email         string
 	Active        bool
@@ -83,31 +91,108 @@ func (e *Employee) UpdatePhoto(photoURL string, updatedBy value_objects.UUID) er
 	return nil
 }
 
-// UpdateFaceEmbedding atualiza o embedding facial do funcionário
+// SetPhotoKey associa a foto gravada no armazenamento de arquivos, substituindo a URL externa
+func (e *Employee) SetPhotoKey(photoKey string, updatedBy value_objects.UUID) error {
+	if photoKey == "" || len(photoKey) > 500 {
+		return errors.NewValidationError("photo_key", "photo key must be between 1 and 500 characters")
+	}
+
+	e.PhotoKey = photoKey
+	e.PhotoURL = ""
+	e.UpdatedAt = time.Now().UTC()
+	e.UpdatedBy = &updatedBy
+
+	return nil
+}
+
+// UpdateFaceEmbedding atualiza o embedding facial do funcionário; exige consentimento biométrico vigente
 func (e *Employee) UpdateFaceEmbedding(embedding []float32, updatedBy value_objects.UUID) error {
-	if len(embedding) != 512 {
-		return errors.NewValidationError("face_embedding", "face embedding must have exactly 512 dimensions")
+	if err := e.requireBiometricConsent(); err != nil {
+		return err
 	}
 
-	// Validar que todos os valores são números válidos
-	for i, val := range embedding {
-		if val != val { // Verificar NaN
-			return errors.NewValidationError("face_embedding", "face embedding contains invalid values")
-		}
-		if val < -1.0 || val > 1.0 {
-			return errors.NewValidationError("face_embedding", "face embedding values must be between -1.0 and 1.0")
-		}
-		// Limitar precisão para evitar problemas de armazenamento
-		embedding[i] = float32(int(val*10000)) / 10000
+	if err := validateEmbedding(embedding); err != nil {
+		return err
 	}
 
 	e.FaceEmbedding = embedding
+	e.FaceEnrolled = true
 	e.UpdatedAt = time.Now().UTC()
 	e.UpdatedBy = &updatedBy
 
 	return nil
 }
 
+// AddFaceTemplate adiciona um template facial respeitando o limite por funcionário; exige consentimento biométrico vigente
+func (e *Employee) AddFaceTemplate(template *FaceTemplate, maxTemplates int, updatedBy value_objects.UUID) error {
+	if !template.EmployeeID.Equals(e.ID) || !template.TenantID.Equals(e.TenantID) {
+		return errors.NewValidationError("face_template", "face template belongs to another employee")
+	}
+
+	if err := e.requireBiometricConsent(); err != nil {
+		return err
+	}
+
+	if len(e.FaceTemplates) >= maxTemplates {
+		return errors.NewValidationError("face_template", fmt.Sprintf("employee already has the maximum of %d face templates", maxTemplates)).
+			WithContext("reason_code", constants.FaceTemplateRejectLimitReached)
+	}
+
+	e.FaceTemplates = append(e.FaceTemplates, template)
+	e.syncFaceEmbedding(updatedBy)
+
+	return nil
+}
+
+// RemoveFaceTemplate remove um template facial; sem templates o embedding do funcionário é apagado
+func (e *Employee) RemoveFaceTemplate(templateID value_objects.UUID, updatedBy value_objects.UUID) (*FaceTemplate, error) {
+	for i, template := range e.FaceTemplates {
+		if template.ID.Equals(templateID) {
+			e.FaceTemplates = append(e.FaceTemplates[:i:i], e.FaceTemplates[i+1:]...)
+			e.syncFaceEmbedding(updatedBy)
+			return template, nil
+		}
+	}
+
+	return nil, errors.NewNotFoundError("face template", templateID.String())
+}
+
+// BestFaceTemplate retorna o template mais parecido com o embedding informado
+func (e *Employee) BestFaceTemplate(embedding []float32) (*FaceTemplate, float32) {
+	var best *FaceTemplate
+	var bestSimilarity float32
+
+	for _, template := range e.FaceTemplates {
+		similarity := template.Similarity(embedding)
+		if best == nil || similarity > bestSimilarity {
+			best = template
+			bestSimilarity = similarity
+		}
+	}
+
+	return best, bestSimilarity
+}
+
+// syncFaceEmbedding espelha em FaceEmbedding o template de maior qualidade (o mais recente em caso de empate)
+func (e *Employee) syncFaceEmbedding(updatedBy value_objects.UUID) {
+	var primary *FaceTemplate
+	for _, template := range e.FaceTemplates {
+		if primary == nil || template.Quality > primary.Quality ||
+			(template.Quality == primary.Quality && template.CapturedAt.After(primary.CapturedAt)) {
+			primary = template
+		}
+	}
+
+	e.FaceEmbedding = nil
+	if primary != nil {
+		e.FaceEmbedding = primary.Embedding
+	}
+	e.FaceEnrolled = primary != nil
+
+	e.UpdatedAt = time.Now().UTC()
+	e.UpdatedBy = &updatedBy
+}
+
 // Activate ativa o funcionário
 func (e *Employee) Activate(updatedBy value_objects.UUID) {
 	e.Active = true
@@ -134,7 +219,7 @@ func (e *Employee) BelongsToTenant(tenantID value_objects.UUID) bool {
 
 // HasPhoto verifica se o funcionário tem foto
 func (e *Employee) HasPhoto() bool {
-	return e.PhotoURL != ""
+	return e.PhotoURL != "" || e.PhotoKey != ""
 }
 
 // HasFaceEmbedding verifica se o funcionário tem embedding facial
@@ -164,9 +249,10 @@ func (e *Employee) IsMinor() bool {
 	return e.GetAge() < 18
 }
 
-// CanPerformFacialRecognition verifica se o funcionário pode usar reconhecimento facial
+// CanPerformFacialRecognition verifica se o funcionário pode usar reconhecimento facial:
+// ativo, com cadastro facial e com consentimento biométrico vigente
 func (e *Employee) CanPerformFacialRecognition() bool {
-	return e.IsActive() && e.HasFaceEmbedding()
+	return e.IsActive() && e.HasBiometricConsent() && e.HasFaceEmbedding()
 }
 
 // CompareFaceEmbedding compara o embedding facial com outro embedding
@@ -175,6 +261,12 @@ func (e *Employee) CompareFaceEmbedding(otherEmbedding []float32, threshold floa
 		return false, 0.0
 	}
 
+	// Com templates cadastrados vale o mais parecido; sem eles, o embedding único
+	if len(e.FaceTemplates) > 0 {
+		_, similarity := e.BestFaceTemplate(otherEmbedding)
+		return similarity >= threshold, similarity
+	}
+
 	// Calcular similaridade coseno
 	similarity := cosineSimilarity(e.FaceEmbedding, otherEmbedding)
 
@@ -283,6 +375,11 @@ func isValidIdentityType(identityType string) bool {
 	return false
 }
 
+// CompareEmbeddings calcula a similaridade coseno entre dois embeddings faciais
+func CompareEmbeddings(a, b []float32) float32 {
+	return cosineSimilarity(a, b)
+}
+
 // cosineSimilarity calcula a similaridade coseno entre dois vetores
 func cosineSimilarity(a, b []float32) float32 {
 	if len(a) != len(b) {
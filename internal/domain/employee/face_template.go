@@ -0,0 +1,126 @@
+package employee
+
+import (
+	"context"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// FaceTemplate representa um embedding facial cadastrado para o funcionário.
+// Cada funcionário pode ter vários templates; o reconhecimento usa o mais parecido.
+type FaceTemplate struct {
+	ID             value_objects.UUID
+	TenantID       value_objects.UUID
+	EmployeeID     value_objects.UUID
+	Embedding      []float32
+	Quality        float32 // Qualidade da captura informada pelo extrator (0 a 1)
+	ModelVersion   string  // Versão do modelo que gerou o embedding
+	SourcePhotoKey string  // Chave da foto de origem no armazenamento de arquivos
+	CapturedAt     time.Time
+	CreatedAt      time.Time
+	CreatedBy      *value_objects.UUID
+}
+
+// FaceTemplateMatch representa um template de outro funcionário parecido com o embedding pesquisado
+type FaceTemplateMatch struct {
+	EmployeeID value_objects.UUID
+	TemplateID *value_objects.UUID // nil quando o embedding é o cadastro único, anterior aos templates
+	Similarity float32
+}
+
+// NewFaceTemplate cria um novo template facial para o funcionário
+func NewFaceTemplate(tenantID, employeeID value_objects.UUID, embedding []float32, quality float32, modelVersion, sourcePhotoKey string, capturedAt time.Time, createdBy value_objects.UUID) (*FaceTemplate, error) {
+	if err := validateEmbedding(embedding); err != nil {
+		return nil, err
+	}
+
+	if quality != quality || quality < 0 || quality > 1 {
+		return nil, errors.NewValidationError("quality", "quality must be between 0 and 1")
+	}
+
+	if modelVersion == "" || len(modelVersion) > constants.MaxFaceTemplateModelVersionLength {
+		return nil, errors.NewValidationError("model_version", "model version must be between 1 and 50 characters")
+	}
+
+	if len(sourcePhotoKey) > 500 {
+		return nil, errors.NewValidationError("source_photo_key", "source photo key must be at most 500 characters")
+	}
+
+	now := time.Now().UTC()
+
+	if capturedAt.IsZero() {
+		capturedAt = now
+	}
+
+	if capturedAt.After(now.Add(time.Minute)) {
+		return nil, errors.NewValidationError("captured_at", "capture date cannot be in the future")
+	}
+
+	return &FaceTemplate{
+		ID:             value_objects.NewUUID(),
+		TenantID:       tenantID,
+		EmployeeID:     employeeID,
+		Embedding:      embedding,
+		Quality:        quality,
+		ModelVersion:   modelVersion,
+		SourcePhotoKey: sourcePhotoKey,
+		CapturedAt:     capturedAt.UTC(),
+		CreatedAt:      now,
+		CreatedBy:      &createdBy,
+	}, nil
+}
+
+// Similarity calcula a similaridade coseno entre o template e o embedding informado
+func (t *FaceTemplate) Similarity(embedding []float32) float32 {
+	if len(embedding) != constants.FaceEmbeddingDimensions {
+		return 0.0
+	}
+
+	return cosineSimilarity(t.Embedding, embedding)
+}
+
+// validateEmbedding valida as dimensões e os valores de um embedding facial,
+// limitando a precisão para evitar problemas de armazenamento
+func validateEmbedding(embedding []float32) error {
+	if len(embedding) != constants.FaceEmbeddingDimensions {
+		return errors.NewValidationError("face_embedding", "face embedding must have exactly 512 dimensions")
+	}
+
+	for i, val := range embedding {
+		if val != val { // Verificar NaN
+			return errors.NewValidationError("face_embedding", "face embedding contains invalid values")
+		}
+		if val < -1.0 || val > 1.0 {
+			return errors.NewValidationError("face_embedding", "face embedding values must be between -1.0 and 1.0")
+		}
+		embedding[i] = float32(int(val*10000)) / 10000
+	}
+
+	return nil
+}
+
+// OpenBiometrics decifra o embedding e os templates faciais do funcionário para a comparação ou o cadastro facial.
+// Nas demais leituras eles permanecem cifrados, e uma falha de chave não afeta o restante do cadastro.
+func OpenBiometrics(ctx context.Context, repo Repository, emp *Employee) error {
+	if !emp.FaceEnrolled {
+		return nil
+	}
+
+	templates, err := repo.OpenFaceTemplates(ctx, emp.ID)
+	if err != nil {
+		return err
+	}
+
+	embedding, err := repo.OpenFaceEmbedding(ctx, emp)
+	if err != nil {
+		return err
+	}
+
+	emp.FaceTemplates = templates
+	emp.FaceEmbedding = embedding
+
+	return nil
+}
@@ -11,12 +11,18 @@ type Repository interface {
 	// Create cria um novo funcionário
 	Create(ctx context.Context, employee *Employee) error
 
-	// GetByID busca um funcionário pelo ID
+	// GetByID busca um funcionário pelo ID; o embedding facial permanece cifrado em SealedFaceEmbedding
 	GetByID(ctx context.Context, id value_objects.UUID) (*Employee, error)
 
-	// GetByIDAndTenant busca um funcionário pelo ID dentro de um tenant
+	// GetByIDAndTenant busca um funcionário pelo ID dentro de um tenant; o embedding facial permanece cifrado
 	GetByIDAndTenant(ctx context.Context, id, tenantID value_objects.UUID) (*Employee, error)
 
+	// OpenFaceEmbedding decifra o embedding do funcionário, usado apenas no reconhecimento e no cadastro facial
+	OpenFaceEmbedding(ctx context.Context, employee *Employee) ([]float32, error)
+
+	// OpenFaceTemplates lista os templates do funcionário com os embeddings decifrados, apenas para o reconhecimento e o cadastro facial
+	OpenFaceTemplates(ctx context.Context, employeeID value_objects.UUID) ([]*FaceTemplate, error)
+
 	// GetByIdentity busca um funcionário pela identidade
 	GetByIdentity(ctx context.Context, identity string) (*Employee, error)
 
@@ -59,11 +65,47 @@ type Repository interface {
 	// ExistsByEmailInTenant verifica se existe um funcionário com o email no tenant
 	ExistsByEmailInTenant(ctx context.Context, email string, tenantID value_objects.UUID, excludeID *value_objects.UUID) (bool, error)
 
-	// FindByFaceEmbedding busca funcionários similares por embedding facial
+	// FindByFaceEmbedding busca funcionários similares por embedding facial, do mais para o menos parecido.
+	// O índice de busca é protegido por chave de cada tenant: tenantID é obrigatório.
 	FindByFaceEmbedding(ctx context.Context, embedding []float32, tenantID *value_objects.UUID, threshold float32, limit int) ([]*Employee, []float32, error)
 
+	// FindByFaceEmbeddingInEvent busca, entre os funcionários dos parceiros associados ao evento, os mais parecidos com o embedding.
+	// Um threshold negativo retorna os mais parecidos sem similaridade mínima.
+	FindByFaceEmbeddingInEvent(ctx context.Context, tenantID, eventID value_objects.UUID, embedding []float32, threshold float32, limit int) ([]*EventFaceMatch, error)
+
 	// GetEmployeesWithFaceEmbedding busca funcionários que têm embedding facial
 	GetEmployeesWithFaceEmbedding(ctx context.Context, tenantID *value_objects.UUID, filters ListFilters) ([]*Employee, int, error)
+
+	// CreateFaceTemplate grava um template facial do funcionário
+	CreateFaceTemplate(ctx context.Context, template *FaceTemplate) error
+
+	// ListFaceTemplates lista os templates faciais de um funcionário, do mais antigo ao mais recente
+	ListFaceTemplates(ctx context.Context, employeeID value_objects.UUID) ([]*FaceTemplate, error)
+
+	// DeleteFaceTemplate remove um template facial do funcionário
+	DeleteFaceTemplate(ctx context.Context, tenantID, employeeID, templateID value_objects.UUID) error
+
+	// FindSimilarFaceTemplates busca embeddings de outros funcionários ativos do tenant com similaridade mínima
+	FindSimilarFaceTemplates(ctx context.Context, tenantID value_objects.UUID, embedding []float32, threshold float32, excludeEmployeeID value_objects.UUID, limit int) ([]*FaceTemplateMatch, error)
+
+	// CreateConsentText grava uma nova versão do termo de consentimento biométrico do tenant
+	CreateConsentText(ctx context.Context, text *ConsentText) error
+
+	// GetLatestConsentText busca a versão mais recente do termo do tenant; retorna NotFound quando não há termo publicado
+	GetLatestConsentText(ctx context.Context, tenantID value_objects.UUID) (*ConsentText, error)
+
+	// CreateBiometricConsent grava o consentimento, encerrando como substituído o consentimento vigente anterior
+	CreateBiometricConsent(ctx context.Context, consent *BiometricConsent) error
+
+	// RevokeBiometricConsent grava a revogação e, na mesma transação, elimina os templates faciais,
+	// o embedding do cadastro e os embeddings capturados nos check-ins do funcionário
+	RevokeBiometricConsent(ctx context.Context, consent *BiometricConsent) (*BiometricPurge, error)
+
+	// ListBiometricConsents lista o histórico de consentimentos do funcionário, do mais recente ao mais antigo
+	ListBiometricConsents(ctx context.Context, tenantID, employeeID value_objects.UUID) ([]*BiometricConsent, error)
+
+	// GetEventConsentCoverage conta, por parceiro, os funcionários ativos do evento com e sem consentimento vigente
+	GetEventConsentCoverage(ctx context.Context, tenantID, eventID value_objects.UUID) (*ConsentCoverage, error)
 }
 
 // ListFilters define os filtros para listagem de funcionários
@@ -156,10 +198,22 @@ type FaceRecognitionResult struct {
 
 // GetConfidenceLevel retorna o nível de confiança baseado na similaridade
 func (r *FaceRecognitionResult) GetConfidenceLevel() string {
-	if r.Similarity >= 0.9 {
+	return ConfidenceLevel(r.Similarity)
+}
+
+// ConfidenceLevel classifica uma similaridade facial em "high", "medium" ou "low"
+func ConfidenceLevel(similarity float32) string {
+	if similarity >= 0.9 {
 		return "high"
-	} else if r.Similarity >= 0.75 {
+	} else if similarity >= 0.75 {
 		return "medium"
 	}
 	return "low"
 }
+
+// EventFaceMatch representa um funcionário do evento identificado por similaridade facial
+type EventFaceMatch struct {
+	Employee   *Employee
+	PartnerID  value_objects.UUID // Parceiro pelo qual o funcionário participa do evento
+	Similarity float32
+}
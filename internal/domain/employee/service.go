@@ -2,10 +2,14 @@ package employee
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"time"
 
+	"eventos-backend/internal/domain/shared/constants"
 	"eventos-backend/internal/domain/shared/errors"
 	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/domain/storage"
 
 	"go.uber.org/zap"
 )
@@ -21,6 +25,9 @@ type Service interface {
 	// UpdateEmployeePhoto atualiza a foto de um funcionário
 	UpdateEmployeePhoto(ctx context.Context, id value_objects.UUID, photoURL string, updatedBy value_objects.UUID) error
 
+	// UploadEmployeePhoto grava a foto enviada no armazenamento de arquivos e a associa ao funcionário
+	UploadEmployeePhoto(ctx context.Context, tenantID, id value_objects.UUID, contentType string, size int64, content io.Reader, updatedBy value_objects.UUID) (*Employee, error)
+
 	// UpdateEmployeeFaceEmbedding atualiza o embedding facial de um funcionário
 	UpdateEmployeeFaceEmbedding(ctx context.Context, id value_objects.UUID, embedding []float32, updatedBy value_objects.UUID) error
 
@@ -68,18 +75,84 @@ type Service interface {
 
 	// GetEmployeesWithFaceEmbedding busca funcionários que têm embedding facial
 	GetEmployeesWithFaceEmbedding(ctx context.Context, tenantID *value_objects.UUID, filters ListFilters) ([]*Employee, int, error)
+
+	// AddFaceTemplate cadastra um novo template facial para o funcionário
+	AddFaceTemplate(ctx context.Context, tenantID, employeeID value_objects.UUID, request FaceTemplateRequest, createdBy value_objects.UUID) (*FaceTemplate, error)
+
+	// ListFaceTemplates lista os templates faciais do funcionário
+	ListFaceTemplates(ctx context.Context, tenantID, employeeID value_objects.UUID) ([]*FaceTemplate, error)
+
+	// RemoveFaceTemplate remove um template facial do funcionário
+	RemoveFaceTemplate(ctx context.Context, tenantID, employeeID, templateID value_objects.UUID, removedBy value_objects.UUID) error
+
+	// PublishConsentText publica uma nova versão do termo de consentimento biométrico do tenant
+	PublishConsentText(ctx context.Context, tenantID value_objects.UUID, content string, publishedBy value_objects.UUID) (*ConsentText, error)
+
+	// GetConsentText busca a versão vigente do termo de consentimento biométrico do tenant
+	GetConsentText(ctx context.Context, tenantID value_objects.UUID) (*ConsentText, error)
+
+	// GrantBiometricConsent registra o consentimento do funcionário à versão vigente do termo
+	GrantBiometricConsent(ctx context.Context, tenantID, employeeID value_objects.UUID, request ConsentRequest, grantedBy value_objects.UUID) (*BiometricConsent, error)
+
+	// RevokeBiometricConsent revoga o consentimento do funcionário e elimina seus dados biométricos
+	RevokeBiometricConsent(ctx context.Context, tenantID, employeeID value_objects.UUID, channel, reason string, revokedBy value_objects.UUID) (*BiometricConsent, error)
+
+	// ListBiometricConsents lista o histórico de consentimentos do funcionário
+	ListBiometricConsents(ctx context.Context, tenantID, employeeID value_objects.UUID) ([]*BiometricConsent, error)
+
+	// GetEventConsentCoverage calcula a cobertura do consentimento biométrico entre os funcionários do evento
+	GetEventConsentCoverage(ctx context.Context, tenantID, eventID value_objects.UUID) (*ConsentCoverage, error)
+}
+
+// Config contém os parâmetros do cadastro de templates faciais
+type Config struct {
+	FaceDuplicateThreshold float32 // Similaridade com outro funcionário a partir da qual o template é recusado
+	MinFaceTemplateQuality float32 // Qualidade mínima aceita no cadastro
+	MaxFaceTemplates       int     // Templates por funcionário
+}
+
+// FaceTemplateRequest contém os dados de um template facial a cadastrar
+type FaceTemplateRequest struct {
+	Embedding      []float32
+	Quality        float32
+	ModelVersion   string
+	SourcePhotoKey string    // Vazio usa a foto de cadastro do funcionário
+	CapturedAt     time.Time // Zero usa o horário do cadastro
+}
+
+// ConsentRequest contém os dados do aceite do termo de consentimento biométrico
+type ConsentRequest struct {
+	TextVersion int       // Versão do termo apresentada ao funcionário; deve ser a vigente
+	Channel     string    // kiosk, mobile_app, web, paper
+	GrantedAt   time.Time // Zero usa o horário do registro; termos em papel informam a data da assinatura
 }
 
 // DomainService implementa os serviços de domínio para Employee
 type DomainService struct {
 	repository Repository
+	photos     storage.Service
+	config     Config
 	logger     *zap.Logger
 }
 
 // NewDomainService cria uma nova instância do serviço de domínio
-func NewDomainService(repository Repository, logger *zap.Logger) Service {
+func NewDomainService(repository Repository, photos storage.Service, config Config, logger *zap.Logger) Service {
+	if config.FaceDuplicateThreshold <= 0 || config.FaceDuplicateThreshold > 1 {
+		config.FaceDuplicateThreshold = constants.DefaultFacialSimilarityThreshold
+	}
+
+	if config.MinFaceTemplateQuality <= 0 || config.MinFaceTemplateQuality > 1 {
+		config.MinFaceTemplateQuality = constants.DefaultMinFaceTemplateQuality
+	}
+
+	if config.MaxFaceTemplates <= 0 {
+		config.MaxFaceTemplates = constants.DefaultMaxFaceTemplates
+	}
+
 	return &DomainService{
 		repository: repository,
+		photos:     photos,
+		config:     config,
 		logger:     logger,
 	}
 }
@@ -237,6 +310,70 @@ func (s *DomainService) UpdateEmployeePhoto(ctx context.Context, id value_object
 	return nil
 }
 
+// UploadEmployeePhoto grava a foto enviada no armazenamento de arquivos e a associa ao funcionário.
+// A foto anterior só é removida depois que a nova referência foi persistida.
+func (s *DomainService) UploadEmployeePhoto(ctx context.Context, tenantID, id value_objects.UUID, contentType string, size int64, content io.Reader, updatedBy value_objects.UUID) (*Employee, error) {
+	s.logger.Debug("Uploading employee photo",
+		zap.String("employee_id", id.String()),
+		zap.String("content_type", contentType),
+		zap.Int64("size", size),
+		zap.String("updated_by", updatedBy.String()),
+	)
+
+	employee, err := s.GetEmployeeByTenant(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := s.photos.StorePhoto(ctx, storage.Upload{
+		TenantID:    tenantID,
+		Category:    constants.StorageCategoryEmployeePhoto,
+		OwnerID:     employee.ID,
+		ContentType: contentType,
+		Size:        size,
+		Content:     content,
+	})
+	if err != nil {
+		s.logger.Warn("Employee photo rejected", zap.String("employee_id", id.String()), zap.Error(err))
+		return nil, err
+	}
+
+	previousKey := employee.PhotoKey
+
+	if err := employee.SetPhotoKey(object.Key, updatedBy); err != nil {
+		s.discardPhoto(ctx, tenantID, object.Key)
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, employee); err != nil {
+		s.logger.Error("Failed to persist employee photo", zap.Error(err))
+		s.discardPhoto(ctx, tenantID, object.Key)
+		return nil, errors.NewInternalError("failed to update employee photo", err)
+	}
+
+	if previousKey != "" {
+		s.discardPhoto(ctx, tenantID, previousKey)
+	}
+
+	s.logger.Info("Employee photo uploaded successfully",
+		zap.String("employee_id", id.String()),
+		zap.String("photo_key", object.Key),
+		zap.Int64("size", object.Size),
+	)
+
+	return employee, nil
+}
+
+// discardPhoto remove uma foto que deixou de ser referenciada; falhas só são registradas
+func (s *DomainService) discardPhoto(ctx context.Context, tenantID value_objects.UUID, key string) {
+	if err := s.photos.Delete(ctx, tenantID, key); err != nil {
+		s.logger.Warn("Failed to delete unreferenced employee photo",
+			zap.String("photo_key", key),
+			zap.Error(err),
+		)
+	}
+}
+
 // UpdateEmployeeFaceEmbedding atualiza o embedding facial de um funcionário
 func (s *DomainService) UpdateEmployeeFaceEmbedding(ctx context.Context, id value_objects.UUID, embedding []float32, updatedBy value_objects.UUID) error {
 	s.logger.Debug("Updating employee face embedding",
@@ -563,3 +700,267 @@ func (s *DomainService) GetEmployeesWithFaceEmbedding(ctx context.Context, tenan
 
 	return employees, total, nil
 }
+
+// AddFaceTemplate cadastra um novo template facial para o funcionário.
+// Templates de baixa qualidade, acima do limite ou parecidos demais com outro funcionário do tenant são recusados.
+func (s *DomainService) AddFaceTemplate(ctx context.Context, tenantID, employeeID value_objects.UUID, request FaceTemplateRequest, createdBy value_objects.UUID) (*FaceTemplate, error) {
+	s.logger.Debug("Adding employee face template",
+		zap.String("employee_id", employeeID.String()),
+		zap.Float32("quality", request.Quality),
+		zap.String("model_version", request.ModelVersion),
+		zap.String("created_by", createdBy.String()),
+	)
+
+	employee, err := s.GetEmployeeByTenant(ctx, employeeID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// O cadastro recalcula o embedding principal a partir dos templates decifrados
+	if err := OpenBiometrics(ctx, s.repository, employee); err != nil {
+		return nil, err
+	}
+
+	sourcePhotoKey := request.SourcePhotoKey
+	if sourcePhotoKey == "" {
+		sourcePhotoKey = employee.PhotoKey
+	} else if !storage.BelongsToTenant(sourcePhotoKey, tenantID) {
+		return nil, errors.NewValidationError("source_photo_key", "source photo does not belong to the tenant")
+	}
+
+	template, err := NewFaceTemplate(tenantID, employee.ID, request.Embedding, request.Quality, request.ModelVersion, sourcePhotoKey, request.CapturedAt, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if template.Quality < s.config.MinFaceTemplateQuality {
+		return nil, errors.NewValidationError("quality", fmt.Sprintf("face template quality must be at least %.2f", s.config.MinFaceTemplateQuality)).
+			WithContext("reason_code", constants.FaceTemplateRejectLowQuality)
+	}
+
+	// Um template parecido demais com outro funcionário tornaria o reconhecimento ambíguo
+	matches, err := s.repository.FindSimilarFaceTemplates(ctx, tenantID, template.Embedding, s.config.FaceDuplicateThreshold, employee.ID, 1)
+	if err != nil {
+		s.logger.Error("Failed to search similar face templates", zap.Error(err))
+		return nil, errors.NewInternalError("failed to validate face template uniqueness", err)
+	}
+	if len(matches) > 0 {
+		s.logger.Warn("Face template rejected: similar to another employee",
+			zap.String("employee_id", employee.ID.String()),
+			zap.String("matched_employee_id", matches[0].EmployeeID.String()),
+			zap.Float32("similarity", matches[0].Similarity),
+		)
+		return nil, errors.NewValidationError("face_embedding", "face template is too similar to another employee").
+			WithContext("reason_code", constants.FaceTemplateRejectSimilarToOther).
+			WithContext("matched_employee_id", matches[0].EmployeeID.String()).
+			WithContext("similarity", matches[0].Similarity)
+	}
+
+	if err := employee.AddFaceTemplate(template, s.config.MaxFaceTemplates, createdBy); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.CreateFaceTemplate(ctx, template); err != nil {
+		s.logger.Error("Failed to persist face template", zap.Error(err))
+		return nil, errors.NewInternalError("failed to create face template", err)
+	}
+
+	// O embedding do funcionário espelha o template de maior qualidade
+	if err := s.repository.Update(ctx, employee); err != nil {
+		s.logger.Error("Failed to persist employee face embedding", zap.Error(err))
+		if rollbackErr := s.repository.DeleteFaceTemplate(ctx, tenantID, employee.ID, template.ID); rollbackErr != nil {
+			s.logger.Error("Failed to roll back face template", zap.String("template_id", template.ID.String()), zap.Error(rollbackErr))
+		}
+		return nil, errors.NewInternalError("failed to create face template", err)
+	}
+
+	s.logger.Info("Employee face template added successfully",
+		zap.String("employee_id", employee.ID.String()),
+		zap.String("template_id", template.ID.String()),
+		zap.Int("templates", len(employee.FaceTemplates)),
+	)
+
+	return template, nil
+}
+
+// ListFaceTemplates lista os templates faciais do funcionário
+func (s *DomainService) ListFaceTemplates(ctx context.Context, tenantID, employeeID value_objects.UUID) ([]*FaceTemplate, error) {
+	employee, err := s.GetEmployeeByTenant(ctx, employeeID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A listagem expõe apenas os metadados; os embeddings permanecem cifrados
+	templates, err := s.repository.ListFaceTemplates(ctx, employee.ID)
+	if err != nil {
+		s.logger.Error("Failed to list face templates", zap.Error(err))
+		return nil, errors.NewInternalError("failed to list face templates", err)
+	}
+
+	return templates, nil
+}
+
+// RemoveFaceTemplate remove um template facial do funcionário
+func (s *DomainService) RemoveFaceTemplate(ctx context.Context, tenantID, employeeID, templateID value_objects.UUID, removedBy value_objects.UUID) error {
+	employee, err := s.GetEmployeeByTenant(ctx, employeeID, tenantID)
+	if err != nil {
+		return err
+	}
+
+	// O embedding principal passa a espelhar o melhor template restante, que precisa estar decifrado
+	if err := OpenBiometrics(ctx, s.repository, employee); err != nil {
+		return err
+	}
+
+	template, err := employee.RemoveFaceTemplate(templateID, removedBy)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.DeleteFaceTemplate(ctx, tenantID, employee.ID, template.ID); err != nil {
+		s.logger.Error("Failed to delete face template", zap.Error(err))
+		return errors.NewInternalError("failed to delete face template", err)
+	}
+
+	if err := s.repository.Update(ctx, employee); err != nil {
+		s.logger.Error("Failed to persist employee face embedding", zap.Error(err))
+		if rollbackErr := s.repository.CreateFaceTemplate(ctx, template); rollbackErr != nil {
+			s.logger.Error("Failed to restore face template", zap.String("template_id", template.ID.String()), zap.Error(rollbackErr))
+		}
+		return errors.NewInternalError("failed to delete face template", err)
+	}
+
+	s.logger.Info("Employee face template removed successfully",
+		zap.String("employee_id", employee.ID.String()),
+		zap.String("template_id", template.ID.String()),
+		zap.Int("templates", len(employee.FaceTemplates)),
+	)
+
+	return nil
+}
+
+// PublishConsentText publica uma nova versão do termo de consentimento biométrico.
+// Consentimentos a versões anteriores continuam vigentes até o funcionário aceitar o novo termo.
+func (s *DomainService) PublishConsentText(ctx context.Context, tenantID value_objects.UUID, content string, publishedBy value_objects.UUID) (*ConsentText, error) {
+	version := 1
+	current, err := s.repository.GetLatestConsentText(ctx, tenantID)
+	if err != nil && !errors.IsNotFound(err) {
+		s.logger.Error("Failed to get current consent text", zap.Error(err))
+		return nil, errors.NewInternalError("failed to publish consent text", err)
+	}
+	if current != nil {
+		version = current.Version + 1
+	}
+
+	text, err := NewConsentText(tenantID, version, content, publishedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.CreateConsentText(ctx, text); err != nil {
+		s.logger.Error("Failed to persist consent text", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Biometric consent text published",
+		zap.String("tenant_id", tenantID.String()),
+		zap.Int("version", text.Version),
+	)
+
+	return text, nil
+}
+
+// GetConsentText busca a versão vigente do termo de consentimento biométrico do tenant
+func (s *DomainService) GetConsentText(ctx context.Context, tenantID value_objects.UUID) (*ConsentText, error) {
+	return s.repository.GetLatestConsentText(ctx, tenantID)
+}
+
+// GrantBiometricConsent registra o consentimento do funcionário. O aceite só vale para a versão vigente do termo,
+// para que o texto gravado como evidência seja o que foi apresentado.
+func (s *DomainService) GrantBiometricConsent(ctx context.Context, tenantID, employeeID value_objects.UUID, request ConsentRequest, grantedBy value_objects.UUID) (*BiometricConsent, error) {
+	employee, err := s.GetEmployeeByTenant(ctx, employeeID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := s.repository.GetLatestConsentText(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.TextVersion != text.Version {
+		return nil, errors.NewValidationError("text_version", "consent must refer to the current consent text").
+			WithContext("current_version", text.Version)
+	}
+
+	consent, err := NewBiometricConsent(employee, text, request.Channel, request.GrantedAt, grantedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := employee.GrantBiometricConsent(consent); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.CreateBiometricConsent(ctx, consent); err != nil {
+		s.logger.Error("Failed to persist biometric consent", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Biometric consent granted",
+		zap.String("employee_id", employee.ID.String()),
+		zap.Int("text_version", consent.TextVersion),
+		zap.String("channel", consent.Channel),
+	)
+
+	return consent, nil
+}
+
+// RevokeBiometricConsent revoga o consentimento vigente; os templates e embeddings faciais do funcionário
+// são eliminados na mesma transação e o reconhecimento facial fica bloqueado até um novo consentimento
+func (s *DomainService) RevokeBiometricConsent(ctx context.Context, tenantID, employeeID value_objects.UUID, channel, reason string, revokedBy value_objects.UUID) (*BiometricConsent, error) {
+	employee, err := s.GetEmployeeByTenant(ctx, employeeID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	consent, err := employee.RevokeBiometricConsent(channel, reason, revokedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	purge, err := s.repository.RevokeBiometricConsent(ctx, consent)
+	if err != nil {
+		s.logger.Error("Failed to revoke biometric consent", zap.Error(err))
+		return nil, errors.NewInternalError("failed to revoke biometric consent", err)
+	}
+
+	s.logger.Info("Biometric consent revoked",
+		zap.String("employee_id", employee.ID.String()),
+		zap.String("channel", channel),
+		zap.Int("face_templates_purged", purge.FaceTemplates),
+		zap.Int("checkin_embeddings_purged", purge.CheckinEmbeddings),
+	)
+
+	return consent, nil
+}
+
+// ListBiometricConsents lista o histórico de consentimentos do funcionário
+func (s *DomainService) ListBiometricConsents(ctx context.Context, tenantID, employeeID value_objects.UUID) ([]*BiometricConsent, error) {
+	if _, err := s.GetEmployeeByTenant(ctx, employeeID, tenantID); err != nil {
+		return nil, err
+	}
+
+	return s.repository.ListBiometricConsents(ctx, tenantID, employeeID)
+}
+
+// GetEventConsentCoverage calcula a cobertura do consentimento biométrico entre os funcionários do evento
+func (s *DomainService) GetEventConsentCoverage(ctx context.Context, tenantID, eventID value_objects.UUID) (*ConsentCoverage, error) {
+	coverage, err := s.repository.GetEventConsentCoverage(ctx, tenantID, eventID)
+	if err != nil {
+		s.logger.Error("Failed to get event consent coverage", zap.Error(err), zap.String("event_id", eventID.String()))
+		return nil, errors.NewInternalError("failed to get consent coverage", err)
+	}
+
+	return coverage, nil
+}
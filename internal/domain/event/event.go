@@ -1,6 +1,7 @@
 package event
 
 import (
+	"math"
 	"time"
 
 	"eventos-backend/internal/domain/shared/errors"
@@ -9,18 +10,47 @@ import (
 
 // Event representa um evento no sistema
 type Event struct {
-	ID          value_objects.UUID
-	TenantID    value_objects.UUID
-	Name        string
-	Location    string
-	FenceEvent  []value_objects.Location // Polígono que define a área do evento
-	InitialDate time.Time
-	FinalDate   time.Time
-	Active      bool
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	CreatedBy   *value_objects.UUID
-	UpdatedBy   *value_objects.UUID
+	ID                   value_objects.UUID
+	TenantID             value_objects.UUID
+	Name                 string
+	Location             string
+	FenceEvent           []value_objects.Location // Polígono que define a área do evento
+	FenceToleranceMeters float64                  // Distância máxima (em metros) aceita fora da cerca
+	MaxSessionHours      float64                  // Duração máxima de uma sessão de trabalho (0 = sem limite)
+	MaxOccupancy         int                      // Número máximo de pessoas presentes ao mesmo tempo (0 = sem limite)
+	InitialDate          time.Time
+	FinalDate            time.Time
+	Active               bool
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	CreatedBy            *value_objects.UUID
+	UpdatedBy            *value_objects.UUID
+}
+
+// MaxFenceToleranceMeters é a tolerância máxima permitida fora da cerca do evento
+const MaxFenceToleranceMeters = 1000
+
+// MaxSessionHoursLimit é o maior limite configurável para a duração de uma sessão de trabalho
+const MaxSessionHoursLimit = 72
+
+// MaxOccupancyLimit é a maior capacidade configurável para um evento
+const MaxOccupancyLimit = 1000000
+
+// Motivos do encerramento automático de sessões abertas
+const (
+	AutoCloseReasonEventFinished      = "event_finished"
+	AutoCloseReasonMaxSessionDuration = "max_session_duration"
+)
+
+// FenceCheck representa o resultado da verificação de uma localização contra a cerca do evento
+type FenceCheck struct {
+	HasFence        bool    // Evento possui polígono válido
+	WithinFence     bool    // Localização está dentro do polígono
+	WithinTolerance bool    // Localização está dentro do polígono ou da tolerância
+	DistanceToEdge  float64 // Distância (em metros) até a borda mais próxima do polígono
+	ToleranceMeters float64 // Tolerância aplicada
+	AccuracyMeters  float64 // Precisão da leitura descontada da distância até a borda
+	Missing         bool    // Localização não informada para evento com cerca
 }
 
 // NewEvent cria uma nova instância de Event
@@ -85,20 +115,32 @@ func (e *Event) IsActive() bool {
 
 // IsOngoing verifica se o evento está em andamento (dentro do período)
 func (e *Event) IsOngoing() bool {
-	now := time.Now().UTC()
-	return e.Active && now.After(e.InitialDate) && now.Before(e.FinalDate)
+	return e.IsOngoingAt(time.Now().UTC())
+}
+
+// IsOngoingAt verifica se o evento estava em andamento no instante informado
+func (e *Event) IsOngoingAt(t time.Time) bool {
+	return e.Active && t.After(e.InitialDate) && t.Before(e.FinalDate)
 }
 
 // IsUpcoming verifica se o evento é futuro
 func (e *Event) IsUpcoming() bool {
-	now := time.Now().UTC()
-	return e.Active && now.Before(e.InitialDate)
+	return e.IsUpcomingAt(time.Now().UTC())
+}
+
+// IsUpcomingAt verifica se o evento ainda não havia começado no instante informado
+func (e *Event) IsUpcomingAt(t time.Time) bool {
+	return e.Active && t.Before(e.InitialDate)
 }
 
 // IsFinished verifica se o evento já terminou
 func (e *Event) IsFinished() bool {
-	now := time.Now().UTC()
-	return now.After(e.FinalDate)
+	return e.IsFinishedAt(time.Now().UTC())
+}
+
+// IsFinishedAt verifica se o evento já havia terminado no instante informado
+func (e *Event) IsFinishedAt(t time.Time) bool {
+	return t.After(e.FinalDate)
 }
 
 // GetDuration retorna a duração do evento
@@ -121,6 +163,102 @@ func (e *Event) IsLocationWithinFence(location value_objects.Location) bool {
 	return isPointInPolygon(location, e.FenceEvent)
 }
 
+// SetFenceTolerance define a tolerância (em metros) aceita fora da cerca do evento
+func (e *Event) SetFenceTolerance(meters float64, updatedBy value_objects.UUID) error {
+	if meters < 0 || meters > MaxFenceToleranceMeters {
+		return errors.NewValidationError("fence_tolerance_meters", "fence tolerance must be between 0 and 1000 meters")
+	}
+
+	e.FenceToleranceMeters = meters
+	e.UpdatedAt = time.Now().UTC()
+	e.UpdatedBy = &updatedBy
+
+	return nil
+}
+
+// SetMaxSessionHours define a duração máxima (em horas) de uma sessão de trabalho; 0 desativa o limite
+func (e *Event) SetMaxSessionHours(hours float64, updatedBy value_objects.UUID) error {
+	if hours < 0 || hours > MaxSessionHoursLimit {
+		return errors.NewValidationError("max_session_hours", "max session hours must be between 0 and 72")
+	}
+
+	e.MaxSessionHours = hours
+	e.UpdatedAt = time.Now().UTC()
+	e.UpdatedBy = &updatedBy
+
+	return nil
+}
+
+// SetMaxOccupancy define quantas pessoas podem estar presentes no evento ao mesmo tempo; 0 desativa o limite
+func (e *Event) SetMaxOccupancy(capacity int, updatedBy value_objects.UUID) error {
+	if capacity < 0 || capacity > MaxOccupancyLimit {
+		return errors.NewValidationError("max_occupancy", "max occupancy must be between 0 and 1000000")
+	}
+
+	e.MaxOccupancy = capacity
+	e.UpdatedAt = time.Now().UTC()
+	e.UpdatedBy = &updatedBy
+
+	return nil
+}
+
+// HasCapacityLimit verifica se o evento limita o número de pessoas presentes
+func (e *Event) HasCapacityLimit() bool {
+	return e.MaxOccupancy > 0
+}
+
+// AutoCloseTime retorna quando uma sessão aberta no horário informado deve ser encerrada automaticamente
+// e o motivo: o fim do evento ou, se ocorrer antes, o limite de duração da sessão
+func (e *Event) AutoCloseTime(checkinTime time.Time) (time.Time, string) {
+	closeTime, reason := e.FinalDate, AutoCloseReasonEventFinished
+
+	if e.MaxSessionHours > 0 {
+		limit := checkinTime.Add(time.Duration(e.MaxSessionHours * float64(time.Hour)))
+		if limit.Before(closeTime) {
+			closeTime, reason = limit, AutoCloseReasonMaxSessionDuration
+		}
+	}
+
+	// Check-ins registrados após o fim do evento são encerrados no próprio horário
+	if closeTime.Before(checkinTime) {
+		closeTime = checkinTime
+	}
+
+	return closeTime, reason
+}
+
+// CheckLocation verifica uma localização contra a cerca do evento, aplicando a tolerância configurada
+func (e *Event) CheckLocation(location value_objects.Location) FenceCheck {
+	return e.CheckLocationWithAccuracy(location, nil)
+}
+
+// CheckLocationWithAccuracy verifica uma localização considerando a precisão da leitura de GPS.
+// A localização só fica fora da tolerância quando todo o círculo de precisão está além dela.
+func (e *Event) CheckLocationWithAccuracy(location value_objects.Location, fix *value_objects.LocationFix) FenceCheck {
+	check := FenceCheck{ToleranceMeters: e.FenceToleranceMeters, AccuracyMeters: fix.AccuracyCredit()}
+
+	if len(e.FenceEvent) < 3 {
+		// Sem cerca definida, qualquer localização é aceita
+		check.WithinFence = true
+		check.WithinTolerance = true
+		return check
+	}
+
+	check.HasFence = true
+
+	// Sem localização não é possível comprovar a presença dentro da cerca
+	if location.IsZero() {
+		check.Missing = true
+		return check
+	}
+
+	check.WithinFence = isPointInPolygon(location, e.FenceEvent)
+	check.DistanceToEdge = distanceToPolygonEdge(location, e.FenceEvent)
+	check.WithinTolerance = check.WithinFence || check.DistanceToEdge <= e.FenceToleranceMeters+check.AccuracyMeters
+
+	return check
+}
+
 // CanCheckIn verifica se é possível fazer check-in no evento
 func (e *Event) CanCheckIn() error {
 	if !e.IsActive() {
@@ -231,3 +369,38 @@ func isPointInPolygon(point value_objects.Location, polygon []value_objects.Loca
 
 	return inside
 }
+
+// distanceToPolygonEdge calcula a distância em metros de um ponto até a aresta mais próxima do polígono.
+// Usa uma projeção equiretangular local centrada no ponto, adequada para as distâncias de uma cerca de evento.
+func distanceToPolygonEdge(point value_objects.Location, polygon []value_objects.Location) float64 {
+	const earthRadius = 6371000 // metros
+
+	cosLat := math.Cos(point.Latitude * math.Pi / 180)
+	project := func(l value_objects.Location) (float64, float64) {
+		x := (l.Longitude - point.Longitude) * math.Pi / 180 * earthRadius * cosLat
+		y := (l.Latitude - point.Latitude) * math.Pi / 180 * earthRadius
+		return x, y
+	}
+
+	minDistance := math.Inf(1)
+	j := len(polygon) - 1
+
+	for i := 0; i < len(polygon); i++ {
+		ax, ay := project(polygon[j])
+		bx, by := project(polygon[i])
+
+		// Distância da origem (o ponto) ao segmento AB
+		dx, dy := bx-ax, by-ay
+		t := 0.0
+		if lengthSq := dx*dx + dy*dy; lengthSq > 0 {
+			t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/lengthSq))
+		}
+
+		if distance := math.Hypot(ax+t*dx, ay+t*dy); distance < minDistance {
+			minDistance = distance
+		}
+		j = i
+	}
+
+	return minDistance
+}
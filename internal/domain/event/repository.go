@@ -44,6 +44,10 @@ type Repository interface {
 
 	// GetEventsInLocation busca eventos que contêm uma localização específica
 	GetEventsInLocation(ctx context.Context, location value_objects.Location, tenantID *value_objects.UUID) ([]*Event, error)
+
+	// CheckLocationInFence verifica no banco (PostGIS) se uma localização está na cerca do evento,
+	// com o mesmo resultado de Event.CheckLocationWithAccuracy
+	CheckLocationInFence(ctx context.Context, eventID value_objects.UUID, location value_objects.Location, fix *value_objects.LocationFix) (*FenceCheck, error)
 }
 
 // ListFilters define os filtros para listagem de eventos
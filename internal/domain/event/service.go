@@ -13,10 +13,10 @@ import (
 // Service define os serviços de domínio para Event
 type Service interface {
 	// CreateEvent cria um novo evento com validações de negócio
-	CreateEvent(ctx context.Context, tenantID value_objects.UUID, name, location string, fenceEvent []value_objects.Location, initialDate, finalDate time.Time, createdBy value_objects.UUID) (*Event, error)
+	CreateEvent(ctx context.Context, tenantID value_objects.UUID, name, location string, fenceEvent []value_objects.Location, fenceToleranceMeters, maxSessionHours float64, maxOccupancy int, initialDate, finalDate time.Time, createdBy value_objects.UUID) (*Event, error)
 
 	// UpdateEvent atualiza um evento existente
-	UpdateEvent(ctx context.Context, id value_objects.UUID, name, location string, fenceEvent []value_objects.Location, initialDate, finalDate time.Time, updatedBy value_objects.UUID) (*Event, error)
+	UpdateEvent(ctx context.Context, id value_objects.UUID, name, location string, fenceEvent []value_objects.Location, fenceToleranceMeters, maxSessionHours float64, maxOccupancy int, initialDate, finalDate time.Time, updatedBy value_objects.UUID) (*Event, error)
 
 	// GetEvent busca um evento pelo ID
 	GetEvent(ctx context.Context, id value_objects.UUID) (*Event, error)
@@ -73,7 +73,7 @@ func NewDomainService(repository Repository, logger *zap.Logger) Service {
 }
 
 // CreateEvent cria um novo evento com validações de negócio
-func (s *DomainService) CreateEvent(ctx context.Context, tenantID value_objects.UUID, name, location string, fenceEvent []value_objects.Location, initialDate, finalDate time.Time, createdBy value_objects.UUID) (*Event, error) {
+func (s *DomainService) CreateEvent(ctx context.Context, tenantID value_objects.UUID, name, location string, fenceEvent []value_objects.Location, fenceToleranceMeters, maxSessionHours float64, maxOccupancy int, initialDate, finalDate time.Time, createdBy value_objects.UUID) (*Event, error) {
 	s.logger.Debug("Creating new event",
 		zap.String("tenant_id", tenantID.String()),
 		zap.String("name", name),
@@ -100,6 +100,18 @@ func (s *DomainService) CreateEvent(ctx context.Context, tenantID value_objects.
 		return nil, err
 	}
 
+	if err := event.SetFenceTolerance(fenceToleranceMeters, createdBy); err != nil {
+		return nil, err
+	}
+
+	if err := event.SetMaxSessionHours(maxSessionHours, createdBy); err != nil {
+		return nil, err
+	}
+
+	if err := event.SetMaxOccupancy(maxOccupancy, createdBy); err != nil {
+		return nil, err
+	}
+
 	// Persistir no repositório
 	if err := s.repository.Create(ctx, event); err != nil {
 		s.logger.Error("Failed to persist event", zap.Error(err))
@@ -116,7 +128,7 @@ func (s *DomainService) CreateEvent(ctx context.Context, tenantID value_objects.
 }
 
 // UpdateEvent atualiza um evento existente
-func (s *DomainService) UpdateEvent(ctx context.Context, id value_objects.UUID, name, location string, fenceEvent []value_objects.Location, initialDate, finalDate time.Time, updatedBy value_objects.UUID) (*Event, error) {
+func (s *DomainService) UpdateEvent(ctx context.Context, id value_objects.UUID, name, location string, fenceEvent []value_objects.Location, fenceToleranceMeters, maxSessionHours float64, maxOccupancy int, initialDate, finalDate time.Time, updatedBy value_objects.UUID) (*Event, error) {
 	s.logger.Debug("Updating event",
 		zap.String("event_id", id.String()),
 		zap.String("name", name),
@@ -164,6 +176,18 @@ func (s *DomainService) UpdateEvent(ctx context.Context, id value_objects.UUID,
 		return nil, err
 	}
 
+	if err := event.SetFenceTolerance(fenceToleranceMeters, updatedBy); err != nil {
+		return nil, err
+	}
+
+	if err := event.SetMaxSessionHours(maxSessionHours, updatedBy); err != nil {
+		return nil, err
+	}
+
+	if err := event.SetMaxOccupancy(maxOccupancy, updatedBy); err != nil {
+		return nil, err
+	}
+
 	// Persistir alterações
 	if err := s.repository.Update(ctx, event); err != nil {
 		s.logger.Error("Failed to persist event update", zap.Error(err))
@@ -377,7 +401,7 @@ func (s *DomainService) ValidateEventForCheckIn(ctx context.Context, eventID val
 	}
 
 	// Verificar localização se fornecida
-	if location != nil && !event.IsLocationWithinFence(*location) {
+	if location != nil && !event.CheckLocation(*location).WithinTolerance {
 		return nil, errors.NewValidationError("location", "location is outside event fence")
 	}
 
@@ -397,7 +421,7 @@ func (s *DomainService) ValidateEventForCheckOut(ctx context.Context, eventID va
 	}
 
 	// Verificar localização se fornecida
-	if location != nil && !event.IsLocationWithinFence(*location) {
+	if location != nil && !event.CheckLocation(*location).WithinTolerance {
 		return nil, errors.NewValidationError("location", "location is outside event fence")
 	}
 
@@ -0,0 +1,187 @@
+package fraud
+
+import (
+	"fmt"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Chaves usadas em ValidationDetails do check-in
+const (
+	DetailsFlagsKey      = "fraud_flags"
+	DetailsReviewKey     = "fraud_review"
+	DetailsIncompleteKey = "fraud_analysis_incomplete"
+)
+
+// Flag representa um padrão de fraude detectado em um check-in
+type Flag struct {
+	Type       string                 `json:"type"` // impossible_travel, buddy_punching, duplicate_face_embedding
+	Message    string                 `json:"message"`
+	Evidence   map[string]interface{} `json:"evidence,omitempty"`
+	DetectedAt time.Time              `json:"detected_at"`
+}
+
+// Review representa a decisão de um revisor sobre um check-in sinalizado
+type Review struct {
+	Status     string    `json:"status"` // confirmed, dismissed
+	Comment    string    `json:"comment,omitempty"`
+	ReviewedBy string    `json:"reviewed_by"`
+	ReviewedAt time.Time `json:"reviewed_at"`
+}
+
+// Subject representa o check-in em análise, antes de ser gravado
+type Subject struct {
+	CheckinID     value_objects.UUID
+	TenantID      value_objects.UUID
+	EventID       value_objects.UUID
+	EmployeeID    value_objects.UUID
+	DeviceID      string
+	Location      value_objects.Location
+	At            time.Time
+	FaceEmbedding []float32
+}
+
+// Record representa um check-in ou check-out já gravado, usado como termo de comparação
+type Record struct {
+	ID         value_objects.UUID
+	Kind       string // checkin, checkout
+	EmployeeID value_objects.UUID
+	Location   value_objects.Location
+	At         time.Time
+}
+
+// FlaggedCheckin representa um check-in sinalizado na fila de revisão de fraude
+type FlaggedCheckin struct {
+	CheckinID    value_objects.UUID
+	TenantID     value_objects.UUID
+	EventID      value_objects.UUID
+	EmployeeID   value_objects.UUID
+	EmployeeName string
+	DeviceID     string
+	Method       string
+	CheckinTime  time.Time
+	Flags        []Flag
+	Review       *Review
+}
+
+// ReviewStatus retorna a situação da revisão; pending enquanto ninguém decidiu
+func (f *FlaggedCheckin) ReviewStatus() string {
+	if f.Review == nil {
+		return constants.FraudReviewPending
+	}
+
+	return f.Review.Status
+}
+
+// IsValidFlagType verifica se o tipo de sinalização é conhecido
+func IsValidFlagType(flagType string) bool {
+	switch flagType {
+	case constants.FraudFlagImpossibleTravel, constants.FraudFlagBuddyPunching, constants.FraudFlagDuplicateFaceEmbedding:
+		return true
+	}
+
+	return false
+}
+
+// DetectImpossibleTravel compara o check-in com o registro anterior do funcionário.
+// Sinaliza quando a velocidade implícita do deslocamento excede maxSpeed (km/h).
+// Registros sem localização e deslocamentos menores que a imprecisão do GPS são ignorados.
+func DetectImpossibleTravel(previous *Record, subject Subject, maxSpeed float64) *Flag {
+	if previous == nil || previous.Location.IsZero() || subject.Location.IsZero() {
+		return nil
+	}
+
+	distance := previous.Location.DistanceTo(subject.Location)
+	if distance < constants.FraudMinTravelDistance {
+		return nil
+	}
+
+	elapsed := subject.At.Sub(previous.At)
+	evidence := map[string]interface{}{
+		"previous_record_id":   previous.ID.String(),
+		"previous_record_type": previous.Kind,
+		"previous_record_time": previous.At,
+		"distance_meters":      distance,
+		"elapsed_seconds":      elapsed.Seconds(),
+		"max_speed_kmh":        maxSpeed,
+	}
+
+	// Registros simultâneos em lugares diferentes são impossíveis independentemente da distância
+	if elapsed <= 0 {
+		return &Flag{
+			Type:       constants.FraudFlagImpossibleTravel,
+			Message:    fmt.Sprintf("Registro a %.1f km do anterior sem intervalo de tempo", distance/1000),
+			Evidence:   evidence,
+			DetectedAt: time.Now().UTC(),
+		}
+	}
+
+	speed := (distance / 1000) / elapsed.Hours()
+	if speed <= maxSpeed {
+		return nil
+	}
+
+	evidence["speed_kmh"] = speed
+	return &Flag{
+		Type:       constants.FraudFlagImpossibleTravel,
+		Message:    fmt.Sprintf("Deslocamento de %.1f km em %s implica %.0f km/h", distance/1000, elapsed.Round(time.Second), speed),
+		Evidence:   evidence,
+		DetectedAt: time.Now().UTC(),
+	}
+}
+
+// DetectBuddyPunching sinaliza quando o dispositivo registrou threshold ou mais funcionários distintos na janela.
+// otherEmployees não inclui o funcionário do check-in em análise.
+func DetectBuddyPunching(subject Subject, otherEmployees int, window time.Duration, threshold int) *Flag {
+	if subject.DeviceID == "" || threshold <= 1 {
+		return nil
+	}
+
+	employees := otherEmployees + 1
+	if employees < threshold {
+		return nil
+	}
+
+	return &Flag{
+		Type:    constants.FraudFlagBuddyPunching,
+		Message: fmt.Sprintf("Dispositivo registrou %d funcionários distintos em %s", employees, window),
+		Evidence: map[string]interface{}{
+			"device_id":          subject.DeviceID,
+			"distinct_employees": employees,
+			"window_seconds":     window.Seconds(),
+			"threshold":          threshold,
+		},
+		DetectedAt: time.Now().UTC(),
+	}
+}
+
+// DetectDuplicateEmbedding sinaliza quando o embedding facial é idêntico ao de check-ins anteriores.
+// Capturas reais nunca produzem vetores idênticos; a repetição indica embedding reenviado.
+func DetectDuplicateEmbedding(matches []*Record) *Flag {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	checkinIDs := make([]string, len(matches))
+	employeeIDs := make([]string, 0, len(matches))
+	seen := make(map[value_objects.UUID]bool)
+	for i, match := range matches {
+		checkinIDs[i] = match.ID.String()
+		if !seen[match.EmployeeID] {
+			seen[match.EmployeeID] = true
+			employeeIDs = append(employeeIDs, match.EmployeeID.String())
+		}
+	}
+
+	return &Flag{
+		Type:    constants.FraudFlagDuplicateFaceEmbedding,
+		Message: fmt.Sprintf("Embedding facial idêntico a %d check-in(s) anterior(es)", len(matches)),
+		Evidence: map[string]interface{}{
+			"matching_checkin_ids":  checkinIDs,
+			"matching_employee_ids": employeeIDs,
+		},
+		DetectedAt: time.Now().UTC(),
+	}
+}
@@ -0,0 +1,68 @@
+package fraud
+
+import (
+	"context"
+	"time"
+
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Repository define as consultas da análise de fraude sobre os check-ins e check-outs gravados.
+// Check-ins anulados não entram nas comparações.
+type Repository interface {
+	// GetPreviousRecord retorna o último check-in ou check-out com localização do funcionário antes do instante.
+	// Retorna NotFound quando não há registro anterior.
+	GetPreviousRecord(ctx context.Context, tenantID, employeeID value_objects.UUID, before time.Time) (*Record, error)
+
+	// CountDeviceEmployees conta os funcionários distintos, exceto excludeEmployeeID, com check-in no dispositivo no intervalo
+	CountDeviceEmployees(ctx context.Context, tenantID value_objects.UUID, deviceID string, from, to time.Time, excludeEmployeeID value_objects.UUID) (int, error)
+
+	// FindIdenticalEmbeddings busca check-ins desde o instante com embedding facial idêntico
+	FindIdenticalEmbeddings(ctx context.Context, tenantID value_objects.UUID, embedding []float32, since time.Time, limit int) ([]*Record, error)
+
+	// ListFlagged lista os check-ins sinalizados do tenant com paginação e filtros
+	ListFlagged(ctx context.Context, tenantID value_objects.UUID, filters ListFilters) ([]*FlaggedCheckin, int, error)
+
+	// GetFlagged busca um check-in sinalizado do tenant; retorna NotFound quando não existe ou não foi sinalizado
+	GetFlagged(ctx context.Context, tenantID, checkinID value_objects.UUID) (*FlaggedCheckin, error)
+
+	// SaveReview grava a decisão do revisor nos detalhes de validação do check-in
+	SaveReview(ctx context.Context, tenantID, checkinID value_objects.UUID, review *Review) error
+}
+
+// ListFilters define os filtros para listagem de check-ins sinalizados
+type ListFilters struct {
+	EventID    *value_objects.UUID
+	EmployeeID *value_objects.UUID
+	DeviceID   *string
+	FlagType   *string
+	Status     *string // pending, confirmed, dismissed
+	From       *time.Time
+	To         *time.Time
+
+	// Paginação
+	Page     int
+	PageSize int
+}
+
+// Validate valida os filtros de listagem
+func (f *ListFilters) Validate() error {
+	if f.Page < 1 {
+		f.Page = 1
+	}
+
+	if f.PageSize < 1 {
+		f.PageSize = 20
+	}
+
+	if f.PageSize > 100 {
+		f.PageSize = 100
+	}
+
+	return nil
+}
+
+// GetOffset calcula o offset para paginação
+func (f *ListFilters) GetOffset() int {
+	return (f.Page - 1) * f.PageSize
+}
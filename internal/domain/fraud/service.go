@@ -0,0 +1,209 @@
+package fraud
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Service define a interface para a análise e revisão de fraudes nos check-ins
+type Service interface {
+	// AnalyzeCheckin verifica os padrões de fraude do check-in antes de ser gravado.
+	// Retorna as sinalizações encontradas; o erro indica que alguma verificação não pôde ser concluída.
+	AnalyzeCheckin(ctx context.Context, subject Subject) ([]Flag, error)
+
+	// ReportFlags emite o alerta das sinalizações de um check-in gravado
+	ReportFlags(ctx context.Context, subject Subject, flags []Flag)
+
+	// ListFlagged lista os check-ins sinalizados do tenant
+	ListFlagged(ctx context.Context, tenantID value_objects.UUID, filters ListFilters) ([]*FlaggedCheckin, int, error)
+
+	// GetFlagged busca um check-in sinalizado do tenant
+	GetFlagged(ctx context.Context, tenantID, checkinID value_objects.UUID) (*FlaggedCheckin, error)
+
+	// ReviewCheckin registra a decisão do revisor (confirmed ou dismissed) sobre um check-in sinalizado
+	ReviewCheckin(ctx context.Context, tenantID, checkinID value_objects.UUID, status, comment string, reviewedBy value_objects.UUID) (*FlaggedCheckin, error)
+}
+
+// Notifier emite os alertas de fraude; falhas de entrega são tratadas pela implementação
+type Notifier interface {
+	NotifyFraud(ctx context.Context, subject Subject, flags []Flag)
+}
+
+// Config contém os limites da detecção de fraude
+type Config struct {
+	MaxTravelSpeed    float64       // km/h acima dos quais o deslocamento é impossível
+	BuddyWindow       time.Duration // Janela observada no mesmo dispositivo, antes e depois do check-in
+	BuddyThreshold    int           // Funcionários distintos no dispositivo dentro da janela
+	EmbeddingLookback time.Duration // Período pesquisado em busca de embeddings idênticos
+}
+
+// serviceImpl implementa a interface Service
+type serviceImpl struct {
+	repo     Repository
+	notifier Notifier
+	config   Config
+}
+
+// NewService cria uma nova instância do serviço; sem notifier as sinalizações apenas são gravadas
+func NewService(repo Repository, notifier Notifier, config Config) Service {
+	if config.MaxTravelSpeed <= 0 {
+		config.MaxTravelSpeed = constants.DefaultFraudMaxTravelSpeed
+	}
+
+	if config.BuddyWindow <= 0 {
+		config.BuddyWindow = constants.DefaultFraudBuddyWindow * time.Second
+	}
+
+	if config.BuddyThreshold <= 1 {
+		config.BuddyThreshold = constants.DefaultFraudBuddyThreshold
+	}
+
+	if config.EmbeddingLookback <= 0 {
+		config.EmbeddingLookback = constants.DefaultFraudEmbeddingLookback * 24 * time.Hour
+	}
+
+	return &serviceImpl{
+		repo:     repo,
+		notifier: notifier,
+		config:   config,
+	}
+}
+
+// AnalyzeCheckin executa as três verificações; a falha de uma não impede as demais
+func (s *serviceImpl) AnalyzeCheckin(ctx context.Context, subject Subject) ([]Flag, error) {
+	var flags []Flag
+	var failures []error
+
+	if !subject.Location.IsZero() {
+		previous, err := s.repo.GetPreviousRecord(ctx, subject.TenantID, subject.EmployeeID, subject.At)
+		if err != nil && !errors.IsNotFound(err) {
+			failures = append(failures, fmt.Errorf("previous record: %w", err))
+		} else if flag := DetectImpossibleTravel(previous, subject, s.config.MaxTravelSpeed); flag != nil {
+			flags = append(flags, *flag)
+		}
+	}
+
+	if subject.DeviceID != "" {
+		others, err := s.repo.CountDeviceEmployees(ctx, subject.TenantID, subject.DeviceID,
+			subject.At.Add(-s.config.BuddyWindow), subject.At.Add(s.config.BuddyWindow), subject.EmployeeID)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("device employees: %w", err))
+		} else if flag := DetectBuddyPunching(subject, others, s.config.BuddyWindow, s.config.BuddyThreshold); flag != nil {
+			flags = append(flags, *flag)
+		}
+	}
+
+	if len(subject.FaceEmbedding) > 0 {
+		matches, err := s.repo.FindIdenticalEmbeddings(ctx, subject.TenantID, subject.FaceEmbedding,
+			subject.At.Add(-s.config.EmbeddingLookback), constants.FraudMaxEmbeddingMatches)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("identical embeddings: %w", err))
+		} else if flag := DetectDuplicateEmbedding(matches); flag != nil {
+			flags = append(flags, *flag)
+		}
+	}
+
+	if len(failures) > 0 {
+		return flags, errors.NewInternalError("Análise de fraude incompleta", stderrors.Join(failures...))
+	}
+
+	return flags, nil
+}
+
+// ReportFlags repassa as sinalizações ao notifier
+func (s *serviceImpl) ReportFlags(ctx context.Context, subject Subject, flags []Flag) {
+	if s.notifier == nil || len(flags) == 0 {
+		return
+	}
+
+	s.notifier.NotifyFraud(ctx, subject, flags)
+}
+
+// ListFlagged lista os check-ins sinalizados do tenant
+func (s *serviceImpl) ListFlagged(ctx context.Context, tenantID value_objects.UUID, filters ListFilters) ([]*FlaggedCheckin, int, error) {
+	if err := filters.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	if filters.FlagType != nil && !IsValidFlagType(*filters.FlagType) {
+		return nil, 0, errors.NewValidationError("FlagType", "tipo de sinalização inválido")
+	}
+
+	if filters.Status != nil && !isValidReviewFilter(*filters.Status) {
+		return nil, 0, errors.NewValidationError("Status", "deve ser pending, confirmed ou dismissed")
+	}
+
+	flagged, total, err := s.repo.ListFlagged(ctx, tenantID, filters)
+	if err != nil {
+		return nil, 0, errors.NewInternalError("Erro ao listar check-ins sinalizados", err)
+	}
+
+	return flagged, total, nil
+}
+
+// GetFlagged busca um check-in sinalizado do tenant
+func (s *serviceImpl) GetFlagged(ctx context.Context, tenantID, checkinID value_objects.UUID) (*FlaggedCheckin, error) {
+	flagged, err := s.repo.GetFlagged(ctx, tenantID, checkinID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("Check-in sinalizado", checkinID.String())
+		}
+		return nil, errors.NewInternalError("Erro ao buscar check-in sinalizado", err)
+	}
+
+	return flagged, nil
+}
+
+// ReviewCheckin registra a decisão do revisor; cada check-in sinalizado é revisado uma única vez
+func (s *serviceImpl) ReviewCheckin(ctx context.Context, tenantID, checkinID value_objects.UUID, status, comment string, reviewedBy value_objects.UUID) (*FlaggedCheckin, error) {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if status != constants.FraudReviewConfirmed && status != constants.FraudReviewDismissed {
+		return nil, errors.NewValidationError("Status", "deve ser confirmed ou dismissed")
+	}
+
+	comment = strings.TrimSpace(comment)
+	if len(comment) > constants.MaxFraudReviewCommentLength {
+		return nil, errors.NewValidationError("Comment", fmt.Sprintf("deve ter no máximo %d caracteres", constants.MaxFraudReviewCommentLength))
+	}
+
+	flagged, err := s.GetFlagged(ctx, tenantID, checkinID)
+	if err != nil {
+		return nil, err
+	}
+
+	if flagged.Review != nil {
+		return nil, errors.NewValidationError("Review", "check-in sinalizado já foi revisado").
+			WithContext("review_status", flagged.Review.Status)
+	}
+
+	review := &Review{
+		Status:     status,
+		Comment:    comment,
+		ReviewedBy: reviewedBy.String(),
+		ReviewedAt: time.Now().UTC(),
+	}
+
+	if err := s.repo.SaveReview(ctx, tenantID, checkinID, review); err != nil {
+		return nil, errors.NewInternalError("Erro ao registrar revisão de fraude", err)
+	}
+
+	flagged.Review = review
+	return flagged, nil
+}
+
+// isValidReviewFilter verifica a situação de revisão usada como filtro
+func isValidReviewFilter(status string) bool {
+	switch status {
+	case constants.FraudReviewPending, constants.FraudReviewConfirmed, constants.FraudReviewDismissed:
+		return true
+	}
+
+	return false
+}
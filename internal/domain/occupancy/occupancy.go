@@ -0,0 +1,76 @@
+package occupancy
+
+import (
+	"time"
+
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Headcount representa quantas pessoas estão presentes em um evento
+type Headcount struct {
+	EventID  value_objects.UUID
+	OnSite   int
+	Capacity int // 0 = sem limite
+	Partners []*PartnerHeadcount
+	At       time.Time
+}
+
+// PartnerHeadcount representa quantas pessoas de um parceiro estão presentes no evento
+type PartnerHeadcount struct {
+	PartnerID value_objects.UUID
+	OnSite    int
+	Capacity  int // 0 = sem limite
+}
+
+// RosterEntry representa um funcionário presente no evento (sessão de trabalho aberta)
+type RosterEntry struct {
+	CheckinID       value_objects.UUID
+	EmployeeID      value_objects.UUID
+	EmployeeName    string
+	PartnerID       value_objects.UUID
+	Method          string
+	CheckinTime     time.Time
+	PendingApproval bool
+}
+
+// Sample representa a ocupação do evento em um instante da série temporal
+type Sample struct {
+	At     time.Time
+	OnSite int
+}
+
+// SeriesFilters define o intervalo e a resolução da série de ocupação
+type SeriesFilters struct {
+	From     time.Time
+	To       time.Time
+	Interval time.Duration
+}
+
+// IsAtCapacity verifica se a ocupação atingiu a capacidade informada; capacidade 0 não tem limite
+func IsAtCapacity(onSite, capacity int) bool {
+	return capacity > 0 && onSite >= capacity
+}
+
+// AvailableSpots retorna quantas vagas restam; -1 quando não há limite
+func (h *Headcount) AvailableSpots() int {
+	if h.Capacity <= 0 {
+		return -1
+	}
+
+	if h.OnSite >= h.Capacity {
+		return 0
+	}
+
+	return h.Capacity - h.OnSite
+}
+
+// Partner retorna a ocupação de um parceiro, ou nil quando ninguém do parceiro está presente
+func (h *Headcount) Partner(partnerID value_objects.UUID) *PartnerHeadcount {
+	for _, partner := range h.Partners {
+		if partner.PartnerID.Equals(partnerID) {
+			return partner
+		}
+	}
+
+	return nil
+}
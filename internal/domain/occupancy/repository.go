@@ -0,0 +1,43 @@
+package occupancy
+
+import (
+	"context"
+
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Repository define as consultas de ocupação sobre as sessões de trabalho abertas.
+// Uma sessão está aberta quando o check-in é válido ou aguarda aprovação, não foi anulado e não tem check-out.
+type Repository interface {
+	// CountOnSite conta as pessoas presentes no evento, agrupadas por parceiro
+	CountOnSite(ctx context.Context, eventID value_objects.UUID) ([]*PartnerHeadcount, error)
+
+	// ListOnSite lista as pessoas presentes no evento, opcionalmente de um único parceiro
+	ListOnSite(ctx context.Context, eventID value_objects.UUID, partnerID *value_objects.UUID) ([]*RosterEntry, error)
+
+	// GetSeries calcula a ocupação do evento em cada instante da série
+	GetSeries(ctx context.Context, eventID value_objects.UUID, filters SeriesFilters) ([]*Sample, error)
+
+	// GetPartnerCapacities retorna a capacidade de cada parceiro associado ao evento que possui limite
+	GetPartnerCapacities(ctx context.Context, eventID value_objects.UUID) (map[value_objects.UUID]int, error)
+
+	// SetPartnerCapacity define a capacidade de um parceiro no evento (0 = sem limite).
+	// Retorna NotFound quando o parceiro não está associado ao evento.
+	SetPartnerCapacity(ctx context.Context, eventID, partnerID value_objects.UUID, capacity int) error
+}
+
+// Counter mantém contadores de ocupação de acesso rápido, reconstruídos a partir do Repository quando ausentes
+type Counter interface {
+	// Get retorna a ocupação do evento, no total e por parceiro; found é false quando os contadores precisam ser reconstruídos
+	Get(ctx context.Context, eventID value_objects.UUID) (total int64, byPartner map[value_objects.UUID]int64, found bool, err error)
+
+	// Add soma delta aos contadores do evento e do parceiro e retorna os novos valores.
+	// Quando os contadores do evento não existem nada é alterado e found é false.
+	Add(ctx context.Context, eventID, partnerID value_objects.UUID, delta int64) (eventCount, partnerCount int64, found bool, err error)
+
+	// Reset substitui os contadores do evento pela contagem informada
+	Reset(ctx context.Context, eventID value_objects.UUID, partners []*PartnerHeadcount) error
+
+	// Clear remove os contadores do evento
+	Clear(ctx context.Context, eventID value_objects.UUID) error
+}
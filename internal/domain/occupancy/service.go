@@ -0,0 +1,342 @@
+package occupancy
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"eventos-backend/internal/domain/event"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Service define a interface para o acompanhamento da ocupação dos eventos
+type Service interface {
+	// GetHeadcount retorna quantas pessoas estão presentes no evento, no total e por parceiro
+	GetHeadcount(ctx context.Context, tenantID, eventID value_objects.UUID) (*Headcount, error)
+
+	// GetRoster lista as pessoas presentes no evento, opcionalmente de um único parceiro
+	GetRoster(ctx context.Context, tenantID, eventID value_objects.UUID, partnerID *value_objects.UUID) ([]*RosterEntry, error)
+
+	// GetSeries retorna a ocupação do evento ao longo do tempo.
+	// Sem intervalo informado a série cobre do início do evento até agora (ou o fim do evento).
+	GetSeries(ctx context.Context, tenantID, eventID value_objects.UUID, filters SeriesFilters) ([]*Sample, error)
+
+	// SetPartnerCapacity define quantas pessoas do parceiro podem estar presentes no evento (0 = sem limite)
+	SetPartnerCapacity(ctx context.Context, tenantID, eventID, partnerID value_objects.UUID, capacity int) error
+
+	// Admit reserva uma vaga para uma nova sessão no evento.
+	// Retorna false e o código do motivo quando a capacidade do evento ou do parceiro foi atingida.
+	Admit(ctx context.Context, eventID, partnerID value_objects.UUID) (bool, string, error)
+
+	// Release libera a vaga de uma sessão encerrada ou de uma reserva não utilizada
+	Release(ctx context.Context, eventID, partnerID value_objects.UUID)
+
+	// Invalidate descarta os contadores do evento para que sejam reconstruídos a partir das sessões abertas
+	Invalidate(ctx context.Context, eventID value_objects.UUID)
+}
+
+// serviceImpl implementa a interface Service.
+// O contador é apenas um atalho: falhas e contadores ausentes recorrem à contagem no banco.
+type serviceImpl struct {
+	repo      Repository
+	counter   Counter
+	eventRepo event.Repository
+}
+
+// NewService cria uma nova instância do serviço; counter pode ser nil quando não há Redis
+func NewService(repo Repository, counter Counter, eventRepo event.Repository) Service {
+	return &serviceImpl{
+		repo:      repo,
+		counter:   counter,
+		eventRepo: eventRepo,
+	}
+}
+
+// GetHeadcount retorna a ocupação atual do evento
+func (s *serviceImpl) GetHeadcount(ctx context.Context, tenantID, eventID value_objects.UUID) (*Headcount, error) {
+	evt, err := s.getEvent(ctx, tenantID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	total, byPartner, err := s.currentCounts(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	capacities, err := s.repo.GetPartnerCapacities(ctx, eventID)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao buscar capacidade dos parceiros", err)
+	}
+
+	headcount := &Headcount{
+		EventID:  eventID,
+		OnSite:   int(total),
+		Capacity: evt.MaxOccupancy,
+		Partners: make([]*PartnerHeadcount, 0, len(byPartner)),
+		At:       time.Now().UTC(),
+	}
+
+	// Parceiros presentes e parceiros com limite, mesmo sem ninguém no local
+	for partnerID, onSite := range byPartner {
+		headcount.Partners = append(headcount.Partners, &PartnerHeadcount{
+			PartnerID: partnerID,
+			OnSite:    int(onSite),
+			Capacity:  capacities[partnerID],
+		})
+	}
+
+	for partnerID, capacity := range capacities {
+		if _, present := byPartner[partnerID]; !present {
+			headcount.Partners = append(headcount.Partners, &PartnerHeadcount{PartnerID: partnerID, Capacity: capacity})
+		}
+	}
+
+	sort.Slice(headcount.Partners, func(i, j int) bool {
+		if headcount.Partners[i].OnSite != headcount.Partners[j].OnSite {
+			return headcount.Partners[i].OnSite > headcount.Partners[j].OnSite
+		}
+		return headcount.Partners[i].PartnerID.String() < headcount.Partners[j].PartnerID.String()
+	})
+
+	return headcount, nil
+}
+
+// GetRoster lista as pessoas presentes no evento
+func (s *serviceImpl) GetRoster(ctx context.Context, tenantID, eventID value_objects.UUID, partnerID *value_objects.UUID) ([]*RosterEntry, error) {
+	if _, err := s.getEvent(ctx, tenantID, eventID); err != nil {
+		return nil, err
+	}
+
+	roster, err := s.repo.ListOnSite(ctx, eventID, partnerID)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao listar pessoas presentes", err)
+	}
+
+	return roster, nil
+}
+
+// GetSeries retorna a ocupação do evento ao longo do tempo
+func (s *serviceImpl) GetSeries(ctx context.Context, tenantID, eventID value_objects.UUID, filters SeriesFilters) ([]*Sample, error) {
+	evt, err := s.getEvent(ctx, tenantID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if filters.From.IsZero() {
+		filters.From = evt.InitialDate
+	}
+
+	if filters.To.IsZero() {
+		filters.To = time.Now().UTC()
+		if evt.FinalDate.Before(filters.To) {
+			filters.To = evt.FinalDate
+		}
+	}
+
+	if filters.Interval <= 0 {
+		filters.Interval = constants.OccupancyDefaultSeriesInterval * time.Second
+	}
+
+	if filters.Interval < constants.OccupancyMinSeriesInterval*time.Second {
+		return nil, errors.NewValidationError("interval", "intervalo mínimo da série é de 1 minuto")
+	}
+
+	if filters.To.Before(filters.From) {
+		return nil, errors.NewValidationError("to", "fim da série deve ser posterior ao início")
+	}
+
+	if int(filters.To.Sub(filters.From)/filters.Interval)+1 > constants.OccupancyMaxSeriesPoints {
+		return nil, errors.NewValidationError("interval", "intervalo gera amostras demais para o período; aumente o intervalo")
+	}
+
+	series, err := s.repo.GetSeries(ctx, eventID, filters)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao calcular a série de ocupação", err)
+	}
+
+	return series, nil
+}
+
+// SetPartnerCapacity define a capacidade de um parceiro no evento
+func (s *serviceImpl) SetPartnerCapacity(ctx context.Context, tenantID, eventID, partnerID value_objects.UUID, capacity int) error {
+	if _, err := s.getEvent(ctx, tenantID, eventID); err != nil {
+		return err
+	}
+
+	if capacity < 0 || capacity > event.MaxOccupancyLimit {
+		return errors.NewValidationError("capacity", "capacidade deve estar entre 0 e 1000000")
+	}
+
+	if err := s.repo.SetPartnerCapacity(ctx, eventID, partnerID, capacity); err != nil {
+		if errors.IsNotFound(err) {
+			return errors.NewValidationError("partner_id", "parceiro não associado ao evento").
+				WithContext("reason_code", constants.EligibilityPartnerNotInEvent)
+		}
+		return errors.NewInternalError("Erro ao definir capacidade do parceiro", err)
+	}
+
+	return nil
+}
+
+// Admit reserva uma vaga para uma nova sessão no evento
+func (s *serviceImpl) Admit(ctx context.Context, eventID, partnerID value_objects.UUID) (bool, string, error) {
+	evt, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, constants.EligibilityEventNotFound, nil
+		}
+		return false, "", errors.NewInternalError("Erro ao buscar evento", err)
+	}
+
+	capacities, err := s.repo.GetPartnerCapacities(ctx, eventID)
+	if err != nil {
+		return false, "", errors.NewInternalError("Erro ao buscar capacidade dos parceiros", err)
+	}
+
+	eventCount, partnerCount, reserved, err := s.reserve(ctx, eventID, partnerID)
+	if err != nil {
+		return false, "", err
+	}
+
+	// A contagem já inclui a nova sessão: a capacidade é excedida quando passa do limite
+	reasonCode := ""
+	switch {
+	case evt.HasCapacityLimit() && eventCount > int64(evt.MaxOccupancy):
+		reasonCode = constants.EligibilityEventAtCapacity
+	case capacities[partnerID] > 0 && partnerCount > int64(capacities[partnerID]):
+		reasonCode = constants.EligibilityPartnerAtCapacity
+	}
+
+	if reasonCode != "" {
+		if reserved {
+			s.Release(ctx, eventID, partnerID)
+		}
+		return false, reasonCode, nil
+	}
+
+	return true, "", nil
+}
+
+// Release libera a vaga de uma sessão; contadores ausentes são reconstruídos na próxima leitura
+func (s *serviceImpl) Release(ctx context.Context, eventID, partnerID value_objects.UUID) {
+	if s.counter == nil {
+		return
+	}
+
+	eventCount, partnerCount, found, err := s.counter.Add(ctx, eventID, partnerID, -1)
+	if err != nil || !found {
+		return
+	}
+
+	// Contadores negativos indicam divergência com as sessões abertas
+	if eventCount < 0 || partnerCount < 0 {
+		s.Invalidate(ctx, eventID)
+	}
+}
+
+// Invalidate descarta os contadores do evento
+func (s *serviceImpl) Invalidate(ctx context.Context, eventID value_objects.UUID) {
+	if s.counter == nil {
+		return
+	}
+
+	_ = s.counter.Clear(ctx, eventID)
+}
+
+// reserve soma uma sessão à ocupação do evento e do parceiro e retorna os novos valores.
+// Sem contador disponível a ocupação é calculada no banco e nada fica reservado.
+func (s *serviceImpl) reserve(ctx context.Context, eventID, partnerID value_objects.UUID) (int64, int64, bool, error) {
+	if s.counter != nil {
+		eventCount, partnerCount, found, err := s.counter.Add(ctx, eventID, partnerID, 1)
+		if err == nil && !found {
+			// Contadores expirados: reconstruir a partir das sessões abertas e tentar novamente
+			if err = s.seed(ctx, eventID); err == nil {
+				eventCount, partnerCount, found, err = s.counter.Add(ctx, eventID, partnerID, 1)
+			}
+		}
+
+		if err == nil && found {
+			return eventCount, partnerCount, true, nil
+		}
+	}
+
+	total, byPartner, err := s.countOnSite(ctx, eventID)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	return total + 1, byPartner[partnerID] + 1, false, nil
+}
+
+// currentCounts retorna a ocupação atual a partir do contador ou, na ausência, do banco
+func (s *serviceImpl) currentCounts(ctx context.Context, eventID value_objects.UUID) (int64, map[value_objects.UUID]int64, error) {
+	if s.counter != nil {
+		total, byPartner, found, err := s.counter.Get(ctx, eventID)
+		if err == nil && found {
+			return total, byPartner, nil
+		}
+	}
+
+	total, byPartner, err := s.countOnSite(ctx, eventID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if s.counter != nil {
+		_ = s.counter.Reset(ctx, eventID, toHeadcounts(byPartner))
+	}
+
+	return total, byPartner, nil
+}
+
+// seed reconstrói os contadores do evento a partir das sessões abertas
+func (s *serviceImpl) seed(ctx context.Context, eventID value_objects.UUID) error {
+	_, byPartner, err := s.countOnSite(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	return s.counter.Reset(ctx, eventID, toHeadcounts(byPartner))
+}
+
+// countOnSite conta as sessões abertas do evento no banco
+func (s *serviceImpl) countOnSite(ctx context.Context, eventID value_objects.UUID) (int64, map[value_objects.UUID]int64, error) {
+	partners, err := s.repo.CountOnSite(ctx, eventID)
+	if err != nil {
+		return 0, nil, errors.NewInternalError("Erro ao contar pessoas presentes", err)
+	}
+
+	var total int64
+	byPartner := make(map[value_objects.UUID]int64, len(partners))
+	for _, partner := range partners {
+		byPartner[partner.PartnerID] = int64(partner.OnSite)
+		total += int64(partner.OnSite)
+	}
+
+	return total, byPartner, nil
+}
+
+// getEvent busca o evento garantindo que pertence ao tenant
+func (s *serviceImpl) getEvent(ctx context.Context, tenantID, eventID value_objects.UUID) (*event.Event, error) {
+	evt, err := s.eventRepo.GetByIDAndTenant(ctx, eventID, tenantID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("event", eventID.String())
+		}
+		return nil, errors.NewInternalError("Erro ao buscar evento", err)
+	}
+
+	return evt, nil
+}
+
+// toHeadcounts converte a contagem por parceiro para a forma aceita pelo contador
+func toHeadcounts(byPartner map[value_objects.UUID]int64) []*PartnerHeadcount {
+	partners := make([]*PartnerHeadcount, 0, len(byPartner))
+	for partnerID, onSite := range byPartner {
+		partners = append(partners, &PartnerHeadcount{PartnerID: partnerID, OnSite: int(onSite)})
+	}
+	return partners
+}
@@ -0,0 +1,150 @@
+package offlinesync
+
+import (
+	"time"
+
+	"eventos-backend/internal/domain/device"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Record representa um check-in ou check-out registrado offline por um dispositivo de portaria
+type Record struct {
+	ClientID   value_objects.UUID // Identificador gerado no dispositivo (chave de idempotência)
+	Type       string             // checkin, checkout
+	RecordedAt time.Time          // Horário registrado no dispositivo
+	EventID    value_objects.UUID
+	EmployeeID value_objects.UUID
+	PartnerID  value_objects.UUID
+
+	// Referência ao check-in de um check-out: ID do servidor ou identificador gerado no dispositivo
+	CheckinID       *value_objects.UUID
+	CheckinClientID *value_objects.UUID
+
+	Method        string
+	Location      value_objects.Location
+	LocationFix   *value_objects.LocationFix
+	PhotoURL      string
+	Notes         string
+	FaceEmbedding []float32
+	QRCodeData    string
+}
+
+// Validate valida os dados mínimos do registro
+func (r *Record) Validate() error {
+	if r.ClientID.IsZero() {
+		return errors.NewValidationError("ClientID", "é obrigatório")
+	}
+
+	if r.Type != constants.SyncRecordTypeCheckin && r.Type != constants.SyncRecordTypeCheckout {
+		return errors.NewValidationError("Type", "tipo de registro não reconhecido")
+	}
+
+	if r.RecordedAt.IsZero() {
+		return errors.NewValidationError("RecordedAt", "é obrigatório")
+	}
+
+	if r.IsCheckout() && r.CheckinID == nil && r.CheckinClientID == nil {
+		return errors.NewValidationError("CheckinID", "é obrigatório para check-out (ID do servidor ou do dispositivo)")
+	}
+
+	return nil
+}
+
+// IsCheckin verifica se o registro é um check-in
+func (r *Record) IsCheckin() bool {
+	return r.Type == constants.SyncRecordTypeCheckin
+}
+
+// HasQRCode verifica se o registro contém a leitura de um QR Code
+func (r *Record) HasQRCode() bool {
+	return r.Method == constants.CheckMethodQRCode || r.QRCodeData != ""
+}
+
+// IsCheckout verifica se o registro é um check-out
+func (r *Record) IsCheckout() bool {
+	return r.Type == constants.SyncRecordTypeCheckout
+}
+
+// BatchRequest representa um lote de registros offline enviado por um dispositivo
+type BatchRequest struct {
+	TenantID    value_objects.UUID
+	Device      *device.Device // Dispositivo autenticado pela credencial; é o único identificador de origem aceito
+	Records     []Record
+	SubmittedBy value_objects.UUID
+}
+
+// Validate valida o lote
+func (r *BatchRequest) Validate() error {
+	if r.TenantID.IsZero() {
+		return errors.NewValidationError("TenantID", "é obrigatório")
+	}
+
+	// Um identificador informado pelo cliente não é verificável; o lote exige um dispositivo registrado
+	if r.Device == nil {
+		return errors.NewValidationError("Device", "lote offline exige dispositivo autenticado")
+	}
+
+	if len(r.Records) == 0 {
+		return errors.NewValidationError("Records", "deve conter ao menos um registro")
+	}
+
+	if len(r.Records) > constants.OfflineSyncMaxBatchSize {
+		return errors.NewValidationError("Records", "excede o tamanho máximo do lote")
+	}
+
+	if r.SubmittedBy.IsZero() {
+		return errors.NewValidationError("SubmittedBy", "é obrigatório")
+	}
+
+	return nil
+}
+
+// IsDeviceBoundTo verifica se o dispositivo autenticado pode registrar acessos no evento
+func (r *BatchRequest) IsDeviceBoundTo(eventID value_objects.UUID) bool {
+	return r.Device != nil && r.Device.IsBoundTo(eventID)
+}
+
+// DeviceID retorna o identificador do dispositivo autenticado, gravado nos check-ins e check-outs do lote
+func (r *BatchRequest) DeviceID() string {
+	if r.Device == nil {
+		return ""
+	}
+	return r.Device.ID.String()
+}
+
+// ItemResult representa o resultado da aplicação de um registro
+type ItemResult struct {
+	ClientID   value_objects.UUID
+	Type       string
+	Status     string              // accepted, duplicate, rejected
+	ResourceID *value_objects.UUID // Check-in/check-out criado ou já existente
+	IsValid    *bool               // Resultado das validações quando aceito
+	ReasonCode string              // Código do motivo quando rejeitado
+	Message    string
+}
+
+// BatchResult representa o resultado da sincronização de um lote
+type BatchResult struct {
+	DeviceID    string
+	Results     []ItemResult // Na mesma ordem dos registros enviados
+	Accepted    int
+	Duplicates  int
+	Rejected    int
+	ProcessedAt time.Time
+}
+
+// add registra o resultado de um item e atualiza os totalizadores
+func (b *BatchResult) add(index int, result ItemResult) {
+	b.Results[index] = result
+
+	switch result.Status {
+	case constants.SyncStatusAccepted:
+		b.Accepted++
+	case constants.SyncStatusDuplicate:
+		b.Duplicates++
+	case constants.SyncStatusRejected:
+		b.Rejected++
+	}
+}
@@ -0,0 +1,316 @@
+package offlinesync
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"eventos-backend/internal/domain/checkin"
+	"eventos-backend/internal/domain/checkout"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Service define a interface para a sincronização de registros offline dos dispositivos
+type Service interface {
+	// SyncBatch aplica um lote de check-ins e check-outs registrados offline.
+	// Os registros são aplicados em ordem cronológica e o reenvio de um mesmo ClientID é idempotente.
+	SyncBatch(ctx context.Context, request BatchRequest) (*BatchResult, error)
+}
+
+// serviceImpl implementa a interface Service
+type serviceImpl struct {
+	checkinService  checkin.Service
+	checkoutService checkout.Service
+	checkinRepo     checkin.Repository
+	checkoutRepo    checkout.Repository
+}
+
+// NewService cria uma nova instância do serviço
+func NewService(checkinService checkin.Service, checkoutService checkout.Service, checkinRepo checkin.Repository, checkoutRepo checkout.Repository) Service {
+	return &serviceImpl{
+		checkinService:  checkinService,
+		checkoutService: checkoutService,
+		checkinRepo:     checkinRepo,
+		checkoutRepo:    checkoutRepo,
+	}
+}
+
+// SyncBatch aplica um lote de registros offline.
+// Erros de infraestrutura interrompem o lote: como o reenvio é idempotente, o dispositivo pode reenviá-lo inteiro.
+func (s *serviceImpl) SyncBatch(ctx context.Context, request BatchRequest) (*BatchResult, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	result := &BatchResult{
+		DeviceID:    request.DeviceID(),
+		Results:     make([]ItemResult, len(request.Records)),
+		ProcessedAt: now,
+	}
+
+	// ClientIDs já processados neste lote
+	seen := make(map[value_objects.UUID]int)
+
+	for _, index := range chronologicalOrder(request.Records) {
+		record := request.Records[index]
+
+		if first, ok := seen[record.ClientID]; ok && !record.ClientID.IsZero() {
+			result.add(index, duplicateOf(record, result.Results[first]))
+			continue
+		}
+		seen[record.ClientID] = index
+
+		item, err := s.applyRecord(ctx, request, record, now)
+		if err != nil {
+			return nil, err
+		}
+		result.add(index, *item)
+	}
+
+	return result, nil
+}
+
+// applyRecord valida e aplica um registro individual
+func (s *serviceImpl) applyRecord(ctx context.Context, request BatchRequest, record Record, now time.Time) (*ItemResult, error) {
+	if err := record.Validate(); err != nil {
+		return rejected(record, constants.SyncRejectInvalidRecord, err.Error()), nil
+	}
+
+	if record.RecordedAt.After(now.Add(constants.OfflineSyncMaxClockSkew * time.Second)) {
+		return rejected(record, constants.SyncRejectFutureTimestamp, "horário do dispositivo está no futuro"), nil
+	}
+
+	if record.RecordedAt.Before(now.Add(-constants.OfflineSyncMaxRecordAge * time.Second)) {
+		return rejected(record, constants.SyncRejectStaleRecord, "registro offline excede a idade máxima permitida"), nil
+	}
+
+	if record.IsCheckin() {
+		return s.applyCheckin(ctx, request, record)
+	}
+
+	return s.applyCheckout(ctx, request, record)
+}
+
+// applyCheckin aplica um check-in offline
+func (s *serviceImpl) applyCheckin(ctx context.Context, request BatchRequest, record Record) (*ItemResult, error) {
+	existing, err := s.checkinRepo.GetByClientID(ctx, record.ClientID)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.NewInternalError("Erro ao verificar check-in sincronizado", err)
+	}
+
+	if existing != nil {
+		if !existing.TenantID.Equals(request.TenantID) {
+			return rejected(record, constants.SyncRejectAlreadyExists, "identificador do registro já utilizado"), nil
+		}
+		return duplicate(record, existing.ID, existing.IsValid), nil
+	}
+
+	if !request.IsDeviceBoundTo(record.EventID) {
+		return rejected(record, constants.SyncRejectDeviceNotBound, "dispositivo não está vinculado a este evento"), nil
+	}
+
+	clientID := record.ClientID
+	recordedAt := record.RecordedAt
+	created, validation, err := s.checkinService.PerformCheckin(ctx, checkin.CheckinRequest{
+		TenantID:      request.TenantID,
+		EventID:       record.EventID,
+		EmployeeID:    record.EmployeeID,
+		PartnerID:     record.PartnerID,
+		Method:        record.Method,
+		Location:      record.Location,
+		LocationFix:   record.LocationFix,
+		PhotoURL:      record.PhotoURL,
+		Notes:         record.Notes,
+		FaceEmbedding: record.FaceEmbedding,
+		QRCodeData:    record.QRCodeData,
+		CreatedBy:     request.SubmittedBy,
+		ClientID:      &clientID,
+		DeviceID:      request.DeviceID(),
+		RecordedAt:    &recordedAt,
+	})
+	if err != nil {
+		return rejectedByError(record, err)
+	}
+
+	return accepted(record, created.ID, created.IsValid, validation.Reason), nil
+}
+
+// applyCheckout aplica um check-out offline
+func (s *serviceImpl) applyCheckout(ctx context.Context, request BatchRequest, record Record) (*ItemResult, error) {
+	existing, err := s.checkoutRepo.GetByClientID(ctx, record.ClientID)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.NewInternalError("Erro ao verificar check-out sincronizado", err)
+	}
+
+	if existing != nil {
+		if !existing.TenantID.Equals(request.TenantID) {
+			return rejected(record, constants.SyncRejectAlreadyExists, "identificador do registro já utilizado"), nil
+		}
+		return duplicate(record, existing.ID, existing.IsValid), nil
+	}
+
+	// Resolver o check-in correspondente (pode ter sido registrado offline no mesmo lote)
+	checkinEntity, err := s.resolveCheckin(ctx, record)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return rejected(record, constants.EligibilityCheckinNotFound, "check-in correspondente não encontrado"), nil
+		}
+		return nil, errors.NewInternalError("Erro ao buscar check-in do check-out", err)
+	}
+
+	if !checkinEntity.TenantID.Equals(request.TenantID) {
+		return rejected(record, constants.EligibilityCheckinNotFound, "check-in correspondente não encontrado"), nil
+	}
+
+	// O evento do check-out é o do check-in, mesmo quando o dispositivo não o informa
+	if !request.IsDeviceBoundTo(checkinEntity.EventID) {
+		return rejected(record, constants.SyncRejectDeviceNotBound, "dispositivo não está vinculado a este evento"), nil
+	}
+
+	// Completar os dados omitidos pelo dispositivo a partir do check-in
+	eventID, employeeID, partnerID := record.EventID, record.EmployeeID, record.PartnerID
+	if eventID.IsZero() {
+		eventID = checkinEntity.EventID
+	}
+	if employeeID.IsZero() {
+		employeeID = checkinEntity.EmployeeID
+	}
+	if partnerID.IsZero() {
+		partnerID = checkinEntity.PartnerID
+	}
+
+	clientID := record.ClientID
+	recordedAt := record.RecordedAt
+	created, validation, err := s.checkoutService.PerformCheckout(ctx, checkout.CheckoutRequest{
+		TenantID:      request.TenantID,
+		EventID:       eventID,
+		EmployeeID:    employeeID,
+		PartnerID:     partnerID,
+		CheckinID:     checkinEntity.ID,
+		Method:        record.Method,
+		Location:      record.Location,
+		LocationFix:   record.LocationFix,
+		PhotoURL:      record.PhotoURL,
+		Notes:         record.Notes,
+		FaceEmbedding: record.FaceEmbedding,
+		QRCodeData:    record.QRCodeData,
+		CreatedBy:     request.SubmittedBy,
+		ClientID:      &clientID,
+		DeviceID:      request.DeviceID(),
+		RecordedAt:    &recordedAt,
+	})
+	if err != nil {
+		return rejectedByError(record, err)
+	}
+
+	return accepted(record, created.ID, created.IsValid, validation.Reason), nil
+}
+
+// resolveCheckin busca o check-in referenciado pelo check-out
+func (s *serviceImpl) resolveCheckin(ctx context.Context, record Record) (*checkin.Checkin, error) {
+	if record.CheckinID != nil {
+		return s.checkinRepo.GetByID(ctx, *record.CheckinID)
+	}
+
+	return s.checkinRepo.GetByClientID(ctx, *record.CheckinClientID)
+}
+
+// chronologicalOrder retorna os índices dos registros ordenados pelo horário do dispositivo.
+// Em caso de empate, check-ins são aplicados antes de check-outs.
+func chronologicalOrder(records []Record) []int {
+	order := make([]int, len(records))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		ra, rb := records[order[a]], records[order[b]]
+		if !ra.RecordedAt.Equal(rb.RecordedAt) {
+			return ra.RecordedAt.Before(rb.RecordedAt)
+		}
+		return ra.IsCheckin() && !rb.IsCheckin()
+	})
+
+	return order
+}
+
+// rejectedByError converte erros de negócio em rejeição do registro; demais erros interrompem o lote
+func rejectedByError(record Record, err error) (*ItemResult, error) {
+	domainErr, ok := err.(*errors.DomainError)
+	if !ok {
+		return nil, err
+	}
+
+	switch domainErr.Type {
+	case "VALIDATION_ERROR":
+		return rejected(record, reasonCodeOf(domainErr, constants.SyncRejectValidation), domainErr.Message), nil
+	case "ALREADY_EXISTS":
+		return rejected(record, reasonCodeOf(domainErr, constants.SyncRejectAlreadyExists), domainErr.Message), nil
+	case "NOT_FOUND":
+		return rejected(record, constants.SyncRejectNotFound, domainErr.Message), nil
+	default:
+		return nil, err
+	}
+}
+
+// reasonCodeOf retorna o reason_code informado pelo domínio ou o código padrão
+func reasonCodeOf(domainErr *errors.DomainError, fallback string) string {
+	if code, ok := domainErr.Context["reason_code"].(string); ok && code != "" {
+		return code
+	}
+	return fallback
+}
+
+// accepted cria o resultado de um registro aplicado
+func accepted(record Record, resourceID value_objects.UUID, isValid bool, message string) *ItemResult {
+	return &ItemResult{
+		ClientID:   record.ClientID,
+		Type:       record.Type,
+		Status:     constants.SyncStatusAccepted,
+		ResourceID: &resourceID,
+		IsValid:    &isValid,
+		Message:    message,
+	}
+}
+
+// duplicate cria o resultado de um registro já sincronizado anteriormente
+func duplicate(record Record, resourceID value_objects.UUID, isValid bool) *ItemResult {
+	return &ItemResult{
+		ClientID:   record.ClientID,
+		Type:       record.Type,
+		Status:     constants.SyncStatusDuplicate,
+		ResourceID: &resourceID,
+		IsValid:    &isValid,
+		Message:    "registro já sincronizado",
+	}
+}
+
+// duplicateOf cria o resultado de um registro repetido dentro do mesmo lote
+func duplicateOf(record Record, first ItemResult) ItemResult {
+	if first.Status == constants.SyncStatusRejected {
+		return *rejected(record, first.ReasonCode, first.Message)
+	}
+
+	return ItemResult{
+		ClientID:   record.ClientID,
+		Type:       record.Type,
+		Status:     constants.SyncStatusDuplicate,
+		ResourceID: first.ResourceID,
+		IsValid:    first.IsValid,
+		Message:    "registro repetido no lote",
+	}
+}
+
+// rejected cria o resultado de um registro rejeitado
+func rejected(record Record, reasonCode, message string) *ItemResult {
+	return &ItemResult{
+		ClientID:   record.ClientID,
+		Type:       record.Type,
+		Status:     constants.SyncStatusRejected,
+		ReasonCode: reasonCode,
+		Message:    message,
+	}
+}
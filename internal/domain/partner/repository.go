@@ -58,6 +58,12 @@ type Repository interface {
 
 	// GetPartnersWithEmployees busca parceiros que têm funcionários
 	GetPartnersWithEmployees(ctx context.Context, tenantID value_objects.UUID, filters ListFilters) ([]*Partner, int, error)
+
+	// IsEmployeeLinked verifica se o funcionário está vinculado ao parceiro (partner_employee)
+	IsEmployeeLinked(ctx context.Context, partnerID, employeeID value_objects.UUID) (bool, error)
+
+	// IsAssignedToEvent verifica se o parceiro está associado ao evento (event_partner)
+	IsAssignedToEvent(ctx context.Context, partnerID, eventID value_objects.UUID) (bool, error)
 }
 
 // ListFilters define os filtros para listagem de parceiros
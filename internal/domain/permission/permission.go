@@ -148,6 +148,9 @@ func (p *Permission) validateModule() error {
 		constants.ModuleAudit:     true,
 		constants.ModuleQRCode:    true,
 		constants.ModuleFacial:    true,
+		constants.ModuleDevices:   true,
+		constants.ModuleFraud:     true,
+		constants.ModulePrivacy:   true,
 	}
 
 	if !validModules[p.Module] {
@@ -173,10 +176,12 @@ func (p *Permission) validateAction() error {
 
 	// Validar se é uma ação conhecida
 	validActions := map[string]bool{
-		constants.PermissionRead:   true,
-		constants.PermissionWrite:  true,
-		constants.PermissionDelete: true,
-		constants.PermissionAdmin:  true,
+		constants.PermissionRead:    true,
+		constants.PermissionWrite:   true,
+		constants.PermissionDelete:  true,
+		constants.PermissionAdmin:   true,
+		constants.PermissionApprove: true,
+		constants.PermissionVoid:    true,
 	}
 
 	if !validActions[p.Action] {
@@ -354,9 +359,11 @@ func GetSystemPermissions() []*Permission {
 	// Permissões de check-ins
 	checkinsRead, _ := NewSystemPermission(constants.ModuleCheckins, constants.PermissionRead, "", "Visualizar Check-ins", "Visualizar check-ins e relatórios")
 	checkinsWrite, _ := NewSystemPermission(constants.ModuleCheckins, constants.PermissionWrite, "", "Realizar Check-ins", "Realizar check-ins e check-outs")
+	checkinsApprove, _ := NewSystemPermission(constants.ModuleCheckins, constants.PermissionApprove, "", "Aprovar Check-ins", "Aprovar ou rejeitar check-ins manuais e de baixa confiança")
+	checkinsVoid, _ := NewSystemPermission(constants.ModuleCheckins, constants.PermissionVoid, "", "Anular Check-ins", "Anular check-ins e check-outs registrados")
 	checkinsAdmin, _ := NewSystemPermission(constants.ModuleCheckins, constants.PermissionAdmin, "", "Administrar Check-ins", "Administração completa de check-ins")
 
-	permissions = append(permissions, checkinsRead, checkinsWrite, checkinsAdmin)
+	permissions = append(permissions, checkinsRead, checkinsWrite, checkinsApprove, checkinsVoid, checkinsAdmin)
 
 	// Permissões de relatórios
 	reportsRead, _ := NewSystemPermission(constants.ModuleReports, constants.PermissionRead, "", "Visualizar Relatórios", "Visualizar relatórios e estatísticas")
@@ -371,5 +378,23 @@ func GetSystemPermissions() []*Permission {
 
 	permissions = append(permissions, auditRead, auditAdmin)
 
+	// Permissões de dispositivos
+	devicesRead, _ := NewSystemPermission(constants.ModuleDevices, constants.PermissionRead, "", "Visualizar Dispositivos", "Visualizar quiosques e coletores registrados")
+	devicesAdmin, _ := NewSystemPermission(constants.ModuleDevices, constants.PermissionAdmin, "", "Administrar Dispositivos", "Registrar dispositivos, emitir credenciais e revogar dispositivos")
+
+	permissions = append(permissions, devicesRead, devicesAdmin)
+
+	// Permissões de análise de fraude
+	fraudRead, _ := NewSystemPermission(constants.ModuleFraud, constants.PermissionRead, "", "Visualizar Sinalizações de Fraude", "Visualizar check-ins sinalizados pela análise de fraude")
+	fraudAdmin, _ := NewSystemPermission(constants.ModuleFraud, constants.PermissionAdmin, "", "Revisar Sinalizações de Fraude", "Confirmar ou descartar sinalizações de fraude")
+
+	permissions = append(permissions, fraudRead, fraudAdmin)
+
+	// Permissões de privacidade (LGPD)
+	privacyRead, _ := NewSystemPermission(constants.ModulePrivacy, constants.PermissionRead, "", "Visualizar Solicitações de Titulares", "Visualizar o registro das solicitações de acesso e eliminação de dados")
+	privacyAdmin, _ := NewSystemPermission(constants.ModulePrivacy, constants.PermissionAdmin, "", "Atender Solicitações de Titulares", "Exportar e eliminar os dados pessoais de funcionários e parceiros")
+
+	permissions = append(permissions, privacyRead, privacyAdmin)
+
 	return permissions
 }
@@ -57,6 +57,10 @@ type Repository interface {
 
 	// GetActions retorna todas as ações disponíveis para um módulo
 	GetActions(ctx context.Context, tenantID value_objects.UUID, module string) ([]string, error)
+
+	// UserHasPermission verifica se algum papel ativo do usuário concede a ação no módulo
+	// (a ação admin do módulo concede todas as demais)
+	UserHasPermission(ctx context.Context, userID value_objects.UUID, module, action string) (bool, error)
 }
 
 // ListFilters define os filtros para listagem de permissões
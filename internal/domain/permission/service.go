@@ -52,6 +52,9 @@ type Service interface {
 	// ValidatePermissionAccess valida se uma permissão permite acesso a um recurso
 	ValidatePermissionAccess(ctx context.Context, permissionID value_objects.UUID, module, action, resource string) (bool, error)
 
+	// UserHasPermission verifica se o usuário possui a permissão por meio de seus papéis
+	UserHasPermission(ctx context.Context, userID value_objects.UUID, module, action string) (bool, error)
+
 	// GetAvailableModules retorna os módulos disponíveis para um tenant
 	GetAvailableModules(ctx context.Context, tenantID value_objects.UUID) ([]string, error)
 
@@ -305,6 +308,16 @@ func (s *serviceImpl) ValidatePermissionAccess(ctx context.Context, permissionID
 	return permission.MatchesPattern(module, action, resource), nil
 }
 
+// UserHasPermission verifica se o usuário possui a permissão por meio de seus papéis
+func (s *serviceImpl) UserHasPermission(ctx context.Context, userID value_objects.UUID, module, action string) (bool, error) {
+	allowed, err := s.repo.UserHasPermission(ctx, userID, module, action)
+	if err != nil {
+		return false, errors.NewInternalError("Erro ao verificar permissão do usuário", err)
+	}
+
+	return allowed, nil
+}
+
 // GetAvailableModules retorna os módulos disponíveis para um tenant
 func (s *serviceImpl) GetAvailableModules(ctx context.Context, tenantID value_objects.UUID) ([]string, error) {
 	modules, err := s.repo.GetModules(ctx, tenantID)
@@ -0,0 +1,169 @@
+package qrcode
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// tokenVersion identifica o formato do token assinado
+const tokenVersion = "v1"
+
+// QRCode representa um QR Code rotativo de um evento (tabela event_qr_code)
+type QRCode struct {
+	ID         value_objects.UUID
+	EventID    value_objects.UUID
+	Type       string // checkin, checkout
+	Token      string // Token assinado exibido no QR Code
+	ValidFrom  time.Time
+	ValidUntil time.Time
+	UsageCount int // Quantidade de vezes que o token foi utilizado
+	MaxUsage   int // Quantidade máxima de usos permitidos
+	CreatedAt  time.Time
+	CreatedBy  *value_objects.UUID
+}
+
+// TokenClaims representa os dados contidos em um token de QR Code
+type TokenClaims struct {
+	QRCodeID   value_objects.UUID
+	EventID    value_objects.UUID
+	Type       string
+	ValidUntil time.Time
+}
+
+// NewQRCode cria um novo QR Code para o evento, assinando o token com o segredo informado
+func NewQRCode(eventID value_objects.UUID, qrType string, validity time.Duration, maxUsage int, secret []byte, createdBy value_objects.UUID) (*QRCode, error) {
+	if eventID.IsZero() {
+		return nil, errors.NewValidationError("EventID", "é obrigatório")
+	}
+
+	if !IsValidType(qrType) {
+		return nil, errors.NewValidationError("Type", "tipo de QR Code não reconhecido")
+	}
+
+	if validity <= 0 {
+		return nil, errors.NewValidationError("Validity", "deve ser maior que zero")
+	}
+
+	if maxUsage < 1 {
+		maxUsage = constants.QRCodeMaxUsage
+	}
+
+	if len(secret) == 0 {
+		return nil, errors.NewValidationError("Secret", "é obrigatório para assinar o QR Code")
+	}
+
+	now := time.Now().UTC()
+
+	qr := &QRCode{
+		ID:         value_objects.NewUUID(),
+		EventID:    eventID,
+		Type:       qrType,
+		ValidFrom:  now,
+		ValidUntil: now.Add(validity),
+		MaxUsage:   maxUsage,
+		CreatedAt:  now,
+		CreatedBy:  &createdBy,
+	}
+	qr.Token = qr.sign(secret)
+
+	return qr, nil
+}
+
+// IsValidType verifica se o tipo de QR Code é válido
+func IsValidType(qrType string) bool {
+	return qrType == constants.QRTypeCheckin || qrType == constants.QRTypeCheckout
+}
+
+// IsValidAt verifica se o QR Code está dentro do período de validade no instante informado
+func (q *QRCode) IsValidAt(t time.Time) bool {
+	return !t.Before(q.ValidFrom) && t.Before(q.ValidUntil)
+}
+
+// IsExhausted verifica se o QR Code já atingiu o número máximo de usos
+func (q *QRCode) IsExhausted() bool {
+	return q.UsageCount >= q.MaxUsage
+}
+
+// BelongsToEvent verifica se o QR Code pertence ao evento informado
+func (q *QRCode) BelongsToEvent(eventID value_objects.UUID) bool {
+	return q.EventID.Equals(eventID)
+}
+
+// sign gera o token assinado com HMAC-SHA256 no formato payload.assinatura (base64url)
+func (q *QRCode) sign(secret []byte) string {
+	payload := strings.Join([]string{
+		tokenVersion,
+		q.ID.String(),
+		q.EventID.String(),
+		q.Type,
+		strconv.FormatInt(q.ValidUntil.Unix(), 10),
+	}, "|")
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encodedPayload + "." + base64.RawURLEncoding.EncodeToString(signature(encodedPayload, secret))
+}
+
+// ParseToken verifica a assinatura do token e extrai seus dados
+func ParseToken(token string, secret []byte) (*TokenClaims, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("formato de token inválido")
+	}
+
+	providedSignature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("assinatura do token inválida")
+	}
+
+	if !hmac.Equal(providedSignature, signature(parts[0], secret)) {
+		return nil, fmt.Errorf("assinatura do token inválida")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("conteúdo do token inválido")
+	}
+
+	fields := strings.Split(string(payload), "|")
+	if len(fields) != 5 || fields[0] != tokenVersion {
+		return nil, fmt.Errorf("versão do token não suportada")
+	}
+
+	qrCodeID, err := value_objects.ParseUUID(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("ID do QR Code inválido: %w", err)
+	}
+
+	eventID, err := value_objects.ParseUUID(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("ID do evento inválido: %w", err)
+	}
+
+	validUntil, err := strconv.ParseInt(fields[4], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("validade do token inválida: %w", err)
+	}
+
+	return &TokenClaims{
+		QRCodeID:   qrCodeID,
+		EventID:    eventID,
+		Type:       fields[3],
+		ValidUntil: time.Unix(validUntil, 0).UTC(),
+	}, nil
+}
+
+// signature calcula o HMAC-SHA256 do conteúdo
+func signature(content string, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(content))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,27 @@
+package qrcode
+
+import (
+	"context"
+	"time"
+
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Repository define as operações de persistência para QR Codes de eventos
+type Repository interface {
+	// Create cria um novo QR Code
+	Create(ctx context.Context, qr *QRCode) error
+
+	// GetByID busca um QR Code pelo ID
+	GetByID(ctx context.Context, id value_objects.UUID) (*QRCode, error)
+
+	// GetLatest busca o QR Code mais recente do evento ainda válido e com usos disponíveis
+	GetLatest(ctx context.Context, eventID value_objects.UUID, qrType string, at time.Time) (*QRCode, error)
+
+	// RegisterUsage incrementa o uso do QR Code de forma atômica.
+	// Retorna false quando o QR Code expirou ou já atingiu o número máximo de usos.
+	RegisterUsage(ctx context.Context, id value_objects.UUID, at time.Time) (bool, error)
+
+	// ReleaseUsage devolve um uso registrado cujo check-in ou check-out não foi gravado
+	ReleaseUsage(ctx context.Context, id value_objects.UUID) error
+}
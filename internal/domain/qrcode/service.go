@@ -0,0 +1,196 @@
+package qrcode
+
+import (
+	"context"
+	"time"
+
+	"eventos-backend/internal/domain/event"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Service define a interface para serviços de domínio de QR Codes
+type Service interface {
+	// GetCurrentQRCode retorna o QR Code vigente do evento, emitindo um novo a cada intervalo de rotação
+	GetCurrentQRCode(ctx context.Context, tenantID, eventID value_objects.UUID, qrType string, requestedBy value_objects.UUID) (*QRCode, error)
+
+	// IssueQRCode emite um novo QR Code para o evento
+	IssueQRCode(ctx context.Context, tenantID, eventID value_objects.UUID, qrType string, createdBy value_objects.UUID) (*QRCode, error)
+
+	// ConsumeQRCode valida um token lido no instante informado e registra seu uso.
+	// O instante da leitura só é considerado se recebido em até OfflineSyncMaxQRCodeAge; depois disso vale o horário do servidor.
+	ConsumeQRCode(ctx context.Context, token string, eventID value_objects.UUID, qrType string, scannedAt time.Time) (*QRCode, error)
+
+	// ReleaseQRCode devolve o uso de um QR Code consumido por um registro que não chegou a ser gravado
+	ReleaseQRCode(ctx context.Context, qrCodeID value_objects.UUID)
+}
+
+// Config contém os parâmetros de emissão dos QR Codes
+type Config struct {
+	Secret           string        // Segredo usado na assinatura HMAC dos tokens
+	RotationInterval time.Duration // Intervalo para emissão de um novo QR Code no quiosque
+	Validity         time.Duration // Tempo de validade de cada QR Code
+	MaxUsage         int           // Número máximo de usos de cada QR Code
+}
+
+// serviceImpl implementa a interface Service
+type serviceImpl struct {
+	repo      Repository
+	eventRepo event.Repository
+	config    Config
+}
+
+// NewService cria uma nova instância do serviço
+func NewService(repo Repository, eventRepo event.Repository, config Config) Service {
+	if config.Validity <= 0 {
+		config.Validity = constants.QRCodeValidityDuration * time.Second
+	}
+
+	if config.RotationInterval <= 0 || config.RotationInterval > config.Validity {
+		config.RotationInterval = config.Validity
+	}
+
+	if config.MaxUsage < 1 {
+		config.MaxUsage = constants.QRCodeMaxUsage
+	}
+
+	return &serviceImpl{
+		repo:      repo,
+		eventRepo: eventRepo,
+		config:    config,
+	}
+}
+
+// GetCurrentQRCode retorna o QR Code vigente do evento
+func (s *serviceImpl) GetCurrentQRCode(ctx context.Context, tenantID, eventID value_objects.UUID, qrType string, requestedBy value_objects.UUID) (*QRCode, error) {
+	if err := s.validateEvent(ctx, tenantID, eventID); err != nil {
+		return nil, err
+	}
+
+	if !IsValidType(qrType) {
+		return nil, errors.NewValidationError("Type", "tipo de QR Code não reconhecido")
+	}
+
+	now := time.Now().UTC()
+
+	current, err := s.repo.GetLatest(ctx, eventID, qrType, now)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.NewInternalError("Erro ao buscar QR Code vigente", err)
+	}
+
+	// Reutilizar o QR Code enquanto estiver dentro do intervalo de rotação
+	if current != nil && now.Sub(current.ValidFrom) < s.config.RotationInterval {
+		return current, nil
+	}
+
+	return s.issue(ctx, eventID, qrType, requestedBy)
+}
+
+// IssueQRCode emite um novo QR Code para o evento
+func (s *serviceImpl) IssueQRCode(ctx context.Context, tenantID, eventID value_objects.UUID, qrType string, createdBy value_objects.UUID) (*QRCode, error) {
+	if err := s.validateEvent(ctx, tenantID, eventID); err != nil {
+		return nil, err
+	}
+
+	return s.issue(ctx, eventID, qrType, createdBy)
+}
+
+// ConsumeQRCode valida um token lido e registra seu uso.
+// A validade é verificada no instante da leitura, que pode ser anterior ao envio em dispositivos offline.
+// O horário da leitura é informado pelo dispositivo: para que um horário antigo não reabra um token vencido,
+// ele só é aceito se a leitura chegar ao servidor em até OfflineSyncMaxQRCodeAge.
+func (s *serviceImpl) ConsumeQRCode(ctx context.Context, token string, eventID value_objects.UUID, qrType string, scannedAt time.Time) (*QRCode, error) {
+	claims, err := ParseToken(token, []byte(s.config.Secret))
+	if err != nil {
+		return nil, rejectionError(constants.QRCodeRejectInvalid, "QR Code inválido")
+	}
+
+	if !claims.EventID.Equals(eventID) {
+		return nil, rejectionError(constants.QRCodeRejectWrongEvent, "QR Code pertence a outro evento")
+	}
+
+	if claims.Type != qrType {
+		return nil, rejectionError(constants.QRCodeRejectWrongType, "QR Code não é válido para esta operação")
+	}
+
+	now := time.Now().UTC()
+	if !scannedAt.IsZero() && now.Sub(scannedAt) <= constants.OfflineSyncMaxQRCodeAge*time.Second {
+		now = scannedAt.UTC()
+	}
+
+	if !now.Before(claims.ValidUntil) {
+		return nil, rejectionError(constants.QRCodeRejectExpired, "QR Code expirado")
+	}
+
+	qr, err := s.repo.GetByID(ctx, claims.QRCodeID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, rejectionError(constants.QRCodeRejectInvalid, "QR Code inválido")
+		}
+		return nil, errors.NewInternalError("Erro ao buscar QR Code", err)
+	}
+
+	// O token precisa ser exatamente o emitido (evita reaproveitar IDs com segredo antigo)
+	if qr.Token != token || !qr.BelongsToEvent(eventID) {
+		return nil, rejectionError(constants.QRCodeRejectInvalid, "QR Code inválido")
+	}
+
+	if !qr.IsValidAt(now) {
+		return nil, rejectionError(constants.QRCodeRejectExpired, "QR Code expirado")
+	}
+
+	registered, err := s.repo.RegisterUsage(ctx, qr.ID, now)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao registrar uso do QR Code", err)
+	}
+
+	if !registered {
+		return nil, rejectionError(constants.QRCodeRejectAlreadyUsed, "QR Code já utilizado")
+	}
+
+	qr.UsageCount++
+
+	return qr, nil
+}
+
+// ReleaseQRCode devolve o uso do QR Code; em caso de falha o token permanece consumido
+func (s *serviceImpl) ReleaseQRCode(ctx context.Context, qrCodeID value_objects.UUID) {
+	_ = s.repo.ReleaseUsage(ctx, qrCodeID)
+}
+
+// issue cria e persiste um novo QR Code
+func (s *serviceImpl) issue(ctx context.Context, eventID value_objects.UUID, qrType string, createdBy value_objects.UUID) (*QRCode, error) {
+	qr, err := NewQRCode(eventID, qrType, s.config.Validity, s.config.MaxUsage, []byte(s.config.Secret), createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, qr); err != nil {
+		return nil, errors.NewInternalError("Erro ao criar QR Code", err)
+	}
+
+	return qr, nil
+}
+
+// validateEvent verifica se o evento existe no tenant e está ativo
+func (s *serviceImpl) validateEvent(ctx context.Context, tenantID, eventID value_objects.UUID) error {
+	evt, err := s.eventRepo.GetByIDAndTenant(ctx, eventID, tenantID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return errors.NewNotFoundError("event", eventID.String())
+		}
+		return errors.NewInternalError("Erro ao buscar evento", err)
+	}
+
+	if !evt.IsActive() || evt.IsFinished() {
+		return errors.NewValidationError("event", "evento não está disponível para emissão de QR Code")
+	}
+
+	return nil
+}
+
+// rejectionError cria um erro de validação com o código de rejeição do QR Code
+func rejectionError(code, message string) error {
+	return errors.NewValidationError("QRCodeData", message).WithContext("reason_code", code)
+}
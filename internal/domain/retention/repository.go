@@ -0,0 +1,40 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Repository define a persistência das políticas de retenção e a eliminação dos dados vencidos.
+// As operações de eliminação recebem o limite do prazo calculado pela política (Policy.Cutoff).
+type Repository interface {
+	// ListPolicies lista as políticas de retenção do tenant
+	ListPolicies(ctx context.Context, tenantID value_objects.UUID) ([]*Policy, error)
+
+	// SavePolicy cria ou substitui a política da classe de dados
+	SavePolicy(ctx context.Context, policy *Policy) error
+
+	// DeletePolicy remove a política da classe de dados; retorna NotFound quando não existe
+	DeletePolicy(ctx context.Context, tenantID value_objects.UUID, dataClass string) error
+
+	// ListTenantIDs lista os tenants com ao menos uma política de retenção
+	ListTenantIDs(ctx context.Context) ([]value_objects.UUID, error)
+
+	// Count conta os registros da classe vencidos no limite informado e, para fotos, os arquivos a remover
+	Count(ctx context.Context, tenantID value_objects.UUID, dataClass string, cutoff time.Time) (records int, photos int, err error)
+
+	// ListExpiredPhotos lista um lote de presenças com foto de evidência vencida
+	ListExpiredPhotos(ctx context.Context, tenantID value_objects.UUID, cutoff time.Time, limit int) ([]ExpiredPhoto, error)
+
+	// ClearPhotos apaga as referências às fotos já removidas do armazenamento
+	ClearPhotos(ctx context.Context, tenantID value_objects.UUID, photos []ExpiredPhoto) (int, error)
+
+	// PurgeBatch elimina um lote de registros vencidos de biometria, localização ou logs.
+	// Retorna quantos registros foram eliminados; menos que o limite indica que não restam registros vencidos.
+	PurgeBatch(ctx context.Context, tenantID value_objects.UUID, dataClass string, cutoff time.Time, limit int) (int, error)
+
+	// RecordPurge registra a eliminação em audit_log
+	RecordPurge(ctx context.Context, report *Report, triggeredBy *value_objects.UUID) error
+}
@@ -0,0 +1,110 @@
+package retention
+
+import (
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// DataClasses lista as classes de dados com prazo de retenção configurável, na ordem em que são eliminadas
+var DataClasses = []string{
+	constants.RetentionClassBiometrics,
+	constants.RetentionClassPhotos,
+	constants.RetentionClassLocations,
+	constants.RetentionClassLogs,
+}
+
+// Policy representa o prazo de retenção de uma classe de dados do tenant, contado a partir do fim de cada evento.
+// Classes sem política não são eliminadas.
+type Policy struct {
+	TenantID  value_objects.UUID
+	DataClass string
+	Days      int // Dias após o fim do evento
+	UpdatedAt time.Time
+	UpdatedBy *value_objects.UUID
+}
+
+// NewPolicy cria uma política de retenção validada
+func NewPolicy(tenantID value_objects.UUID, dataClass string, days int, updatedBy value_objects.UUID) (*Policy, error) {
+	if tenantID.IsZero() {
+		return nil, errors.NewValidationError("tenant_id", "tenant is required")
+	}
+
+	if !IsValidDataClass(dataClass) {
+		return nil, errors.NewValidationError("data_class", "invalid data class").
+			WithContext("data_classes", DataClasses)
+	}
+
+	if days < constants.MinRetentionDays || days > constants.MaxRetentionDays {
+		return nil, errors.NewValidationError("retention_days", "retention must be between 1 and 3650 days")
+	}
+
+	return &Policy{
+		TenantID:  tenantID,
+		DataClass: dataClass,
+		Days:      days,
+		UpdatedAt: time.Now().UTC(),
+		UpdatedBy: &updatedBy,
+	}, nil
+}
+
+// Retention devolve o prazo de retenção como duração
+func (p *Policy) Retention() time.Duration {
+	return time.Duration(p.Days) * 24 * time.Hour
+}
+
+// Cutoff devolve o limite do prazo: os dados de eventos encerrados antes dele estão vencidos.
+// Entradas de audit_log não pertencem a um evento e vencem pela data de gravação.
+func (p *Policy) Cutoff(now time.Time) time.Time {
+	return now.UTC().Add(-p.Retention())
+}
+
+// IsValidDataClass verifica se a classe de dados aceita política de retenção
+func IsValidDataClass(dataClass string) bool {
+	for _, c := range DataClasses {
+		if c == dataClass {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExpiredPhoto representa uma foto de evidência vencida; Key vazia indica foto externa, apenas referenciada por URL
+type ExpiredPhoto struct {
+	Source   string // checkin, checkout
+	RecordID value_objects.UUID
+	Key      string
+}
+
+// ClassResult resume a eliminação de uma classe de dados
+type ClassResult struct {
+	DataClass     string    `json:"data_class"`
+	RetentionDays int       `json:"retention_days"`
+	Cutoff        time.Time `json:"cutoff"`
+	Records       int       `json:"records"`          // Registros eliminados ou, na simulação, a eliminar
+	Photos        int       `json:"photos,omitempty"` // Arquivos removidos do armazenamento ou, na simulação, a remover
+}
+
+// Report representa uma execução da eliminação por retenção, ou a sua simulação.
+// Presenças nunca são apagadas: as sessões de trabalho continuam com horários, evento e parceiro.
+type Report struct {
+	ID          string         `json:"id"` // record_id da entrada em audit_log
+	TenantID    string         `json:"tenant_id"`
+	DryRun      bool           `json:"dry_run"`
+	StartedAt   time.Time      `json:"started_at"`
+	CompletedAt time.Time      `json:"completed_at"`
+	Classes     []*ClassResult `json:"classes"`
+}
+
+// Total soma os registros eliminados, ou a eliminar, de todas as classes
+func (r *Report) Total() int {
+	total := 0
+	for _, c := range r.Classes {
+		total += c.Records
+	}
+
+	return total
+}
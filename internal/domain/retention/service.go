@@ -0,0 +1,233 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/domain/storage"
+
+	"go.uber.org/zap"
+)
+
+// Service define a interface para as políticas de retenção e a eliminação dos dados vencidos
+type Service interface {
+	// ListPolicies lista as políticas de retenção do tenant
+	ListPolicies(ctx context.Context, tenantID value_objects.UUID) ([]*Policy, error)
+
+	// SetPolicy define o prazo de retenção, em dias após o fim do evento, de uma classe de dados
+	SetPolicy(ctx context.Context, tenantID value_objects.UUID, dataClass string, days int, updatedBy value_objects.UUID) (*Policy, error)
+
+	// RemovePolicy remove a política da classe de dados, que deixa de ser eliminada
+	RemovePolicy(ctx context.Context, tenantID value_objects.UUID, dataClass string) error
+
+	// Preview simula a eliminação e conta o que seria removido, sem alterar dados
+	Preview(ctx context.Context, tenantID value_objects.UUID, now time.Time) (*Report, error)
+
+	// Purge elimina, em lotes, os dados vencidos do tenant. Sem triggeredBy a execução é atribuída ao sistema.
+	Purge(ctx context.Context, tenantID value_objects.UUID, now time.Time, batchSize int, triggeredBy *value_objects.UUID) (*Report, error)
+
+	// ListTenants lista os tenants com ao menos uma política de retenção
+	ListTenants(ctx context.Context) ([]value_objects.UUID, error)
+}
+
+// serviceImpl implementa a interface Service
+type serviceImpl struct {
+	repo   Repository
+	photos storage.Service
+	logger *zap.Logger
+}
+
+// NewService cria uma nova instância do serviço
+func NewService(repo Repository, photos storage.Service, logger *zap.Logger) Service {
+	return &serviceImpl{
+		repo:   repo,
+		photos: photos,
+		logger: logger,
+	}
+}
+
+// ListPolicies lista as políticas de retenção do tenant
+func (s *serviceImpl) ListPolicies(ctx context.Context, tenantID value_objects.UUID) ([]*Policy, error) {
+	return s.repo.ListPolicies(ctx, tenantID)
+}
+
+// SetPolicy define o prazo de retenção de uma classe de dados; a próxima execução já aplica o novo prazo
+func (s *serviceImpl) SetPolicy(ctx context.Context, tenantID value_objects.UUID, dataClass string, days int, updatedBy value_objects.UUID) (*Policy, error) {
+	policy, err := NewPolicy(tenantID, dataClass, days, updatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SavePolicy(ctx, policy); err != nil {
+		return nil, errors.NewInternalError("Erro ao salvar política de retenção", err)
+	}
+
+	s.logger.Info("Retention policy saved",
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("data_class", dataClass),
+		zap.Int("retention_days", days))
+
+	return policy, nil
+}
+
+// RemovePolicy remove a política da classe de dados
+func (s *serviceImpl) RemovePolicy(ctx context.Context, tenantID value_objects.UUID, dataClass string) error {
+	if !IsValidDataClass(dataClass) {
+		return errors.NewValidationError("data_class", "invalid data class").
+			WithContext("data_classes", DataClasses)
+	}
+
+	return s.repo.DeletePolicy(ctx, tenantID, dataClass)
+}
+
+// Preview conta, por classe, os registros e as fotos que a próxima execução eliminaria
+func (s *serviceImpl) Preview(ctx context.Context, tenantID value_objects.UUID, now time.Time) (*Report, error) {
+	policies, err := s.repo.ListPolicies(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao buscar políticas de retenção", err)
+	}
+
+	report := newReport(tenantID, true, now)
+	for _, policy := range ordered(policies) {
+		result := report.add(policy, now)
+		if result.Records, result.Photos, err = s.repo.Count(ctx, tenantID, policy.DataClass, result.Cutoff); err != nil {
+			return nil, errors.NewInternalError("Erro ao simular eliminação", err)
+		}
+	}
+	report.CompletedAt = time.Now().UTC()
+
+	return report, nil
+}
+
+// Purge elimina as classes com política em lotes, cada lote em sua própria transação.
+// A execução é registrada em audit_log sempre que algo é eliminado, inclusive quando interrompida por erro;
+// nesse caso o relatório parcial é devolvido com o erro e a próxima execução continua de onde parou.
+func (s *serviceImpl) Purge(ctx context.Context, tenantID value_objects.UUID, now time.Time, batchSize int, triggeredBy *value_objects.UUID) (*Report, error) {
+	policies, err := s.repo.ListPolicies(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao buscar políticas de retenção", err)
+	}
+
+	if batchSize <= 0 {
+		batchSize = constants.RetentionPurgeBatchSize
+	}
+
+	report := newReport(tenantID, false, now)
+	var purgeErr error
+	for _, policy := range ordered(policies) {
+		result := report.add(policy, now)
+		if purgeErr = s.purgeClass(ctx, tenantID, result, batchSize); purgeErr != nil {
+			break
+		}
+	}
+	report.CompletedAt = time.Now().UTC()
+
+	if report.Total() > 0 {
+		if err := s.repo.RecordPurge(ctx, report, triggeredBy); err != nil {
+			s.logger.Error("Failed to record retention purge", zap.String("tenant_id", tenantID.String()), zap.Error(err))
+			if purgeErr == nil {
+				purgeErr = errors.NewInternalError("Erro ao registrar eliminação", err)
+			}
+		}
+
+		s.logger.Info("Expired data purged",
+			zap.String("tenant_id", tenantID.String()),
+			zap.Int("records", report.Total()))
+	}
+
+	return report, purgeErr
+}
+
+// ListTenants lista os tenants com ao menos uma política de retenção
+func (s *serviceImpl) ListTenants(ctx context.Context) ([]value_objects.UUID, error) {
+	return s.repo.ListTenantIDs(ctx)
+}
+
+// purgeClass elimina os lotes vencidos de uma classe até restar um lote incompleto
+func (s *serviceImpl) purgeClass(ctx context.Context, tenantID value_objects.UUID, result *ClassResult, batchSize int) error {
+	for ctx.Err() == nil {
+		if result.DataClass != constants.RetentionClassPhotos {
+			purged, err := s.repo.PurgeBatch(ctx, tenantID, result.DataClass, result.Cutoff, batchSize)
+			if err != nil {
+				return errors.NewInternalError("Erro ao eliminar dados vencidos", err)
+			}
+			result.Records += purged
+
+			if purged < batchSize {
+				return nil
+			}
+			continue
+		}
+
+		photos, err := s.repo.ListExpiredPhotos(ctx, tenantID, result.Cutoff, batchSize)
+		if err != nil {
+			return errors.NewInternalError("Erro ao buscar fotos vencidas", err)
+		}
+
+		// Os arquivos são removidos antes das referências: se a remoção falhar, a próxima execução tenta de novo
+		for _, photo := range photos {
+			if photo.Key == "" {
+				continue
+			}
+			if err := s.photos.Delete(ctx, tenantID, photo.Key); err != nil {
+				return err
+			}
+			result.Photos++
+		}
+
+		cleared, err := s.repo.ClearPhotos(ctx, tenantID, photos)
+		if err != nil {
+			return errors.NewInternalError("Erro ao eliminar fotos vencidas", err)
+		}
+		result.Records += cleared
+
+		if len(photos) < batchSize {
+			return nil
+		}
+	}
+
+	return ctx.Err()
+}
+
+// newReport cria o relatório de uma execução
+func newReport(tenantID value_objects.UUID, dryRun bool, now time.Time) *Report {
+	return &Report{
+		ID:        value_objects.NewUUID().String(),
+		TenantID:  tenantID.String(),
+		DryRun:    dryRun,
+		StartedAt: now.UTC(),
+		Classes:   []*ClassResult{},
+	}
+}
+
+// add inclui no relatório o resultado da classe da política
+func (r *Report) add(policy *Policy, now time.Time) *ClassResult {
+	result := &ClassResult{
+		DataClass:     policy.DataClass,
+		RetentionDays: policy.Days,
+		Cutoff:        policy.Cutoff(now),
+	}
+	r.Classes = append(r.Classes, result)
+
+	return result
+}
+
+// ordered devolve as políticas na ordem de eliminação das classes
+func ordered(policies []*Policy) []*Policy {
+	byClass := make(map[string]*Policy, len(policies))
+	for _, policy := range policies {
+		byClass[policy.DataClass] = policy
+	}
+
+	result := make([]*Policy, 0, len(policies))
+	for _, dataClass := range DataClasses {
+		if policy, ok := byClass[dataClass]; ok {
+			result = append(result, policy)
+		}
+	}
+
+	return result
+}
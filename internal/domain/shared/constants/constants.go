@@ -20,6 +20,19 @@ const (
 	CheckMethodFacialRecognition = "facial_recognition"
 	CheckMethodQRCode            = "qr_code"
 	CheckMethodManual            = "manual"
+	CheckMethodSystem            = "system" // check-out gerado automaticamente pelo sistema
+)
+
+// Status de sessões de trabalho (check-in + check-out)
+const (
+	WorkSessionStatusOpen   = "open"
+	WorkSessionStatusClosed = "closed"
+)
+
+// Encerramento automático de sessões abertas
+const (
+	SessionAutoCloseBatchSize       = 200 // Sessões encerradas por execução do job
+	DefaultSessionAutoCloseInterval = 300 // 5 minutos entre execuções do job
 )
 
 // Tipos de QR Code
@@ -66,14 +79,19 @@ const (
 	ModuleAudit     = "audit"
 	ModuleQRCode    = "qr_code"
 	ModuleFacial    = "facial"
+	ModuleDevices   = "devices"
+	ModuleFraud     = "fraud"
+	ModulePrivacy   = "privacy"
 )
 
 // Permissões básicas
 const (
-	PermissionRead   = "read"
-	PermissionWrite  = "write"
-	PermissionDelete = "delete"
-	PermissionAdmin  = "admin"
+	PermissionRead    = "read"
+	PermissionWrite   = "write"
+	PermissionDelete  = "delete"
+	PermissionAdmin   = "admin"
+	PermissionApprove = "approve"
+	PermissionVoid    = "void"
 )
 
 // Roles padrão do sistema
@@ -103,3 +121,270 @@ const (
 	QRCodeValidityDuration = 60 // segundos
 	QRCodeMaxUsage         = 1  // número máximo de usos
 )
+
+// Configurações de reconhecimento facial
+const (
+	FaceEmbeddingDimensions          = 512  // dimensões do embedding facial
+	DefaultFacialSimilarityThreshold = 0.75 // similaridade mínima padrão para aceitar o reconhecimento
+	DefaultWalkUpSimilarityMargin    = 0.05 // vantagem mínima do melhor candidato sobre o segundo na identificação 1:N
+	FaceSearchCandidateFactor        = 10   // embeddings lidos do índice vetorial por resultado pedido
+	FaceSearchMinEfSearch            = 40   // lista mínima de candidatos percorrida no índice HNSW
+	FaceSearchMaxEfSearch            = 1000 // lista máxima de candidatos aceita pelo pgvector
+)
+
+// Configurações dos templates faciais de cadastro
+const (
+	DefaultMaxFaceTemplates           = 5   // templates faciais por funcionário
+	DefaultMinFaceTemplateQuality     = 0.6 // qualidade mínima (0 a 1) aceita no cadastro de um template
+	MaxFaceTemplateModelVersionLength = 50  // caracteres
+)
+
+// Códigos de rejeição do cadastro de templates faciais
+const (
+	FaceTemplateRejectLowQuality     = "LOW_QUALITY"
+	FaceTemplateRejectLimitReached   = "TEMPLATE_LIMIT_REACHED"
+	FaceTemplateRejectSimilarToOther = "SIMILAR_TO_OTHER_EMPLOYEE"
+	FaceTemplateRejectNoConsent      = "BIOMETRIC_CONSENT_REQUIRED"
+)
+
+// Códigos de inelegibilidade para check-in/check-out
+const (
+	EligibilityEmployeeNotFound     = "EMPLOYEE_NOT_FOUND"
+	EligibilityEmployeeInactive     = "EMPLOYEE_INACTIVE"
+	EligibilityEventNotFound        = "EVENT_NOT_FOUND"
+	EligibilityEventInactive        = "EVENT_INACTIVE"
+	EligibilityEventNotStarted      = "EVENT_NOT_STARTED"
+	EligibilityEventFinished        = "EVENT_FINISHED"
+	EligibilityTenantMismatch       = "TENANT_MISMATCH"
+	EligibilityPartnerNotFound      = "PARTNER_NOT_FOUND"
+	EligibilityPartnerInactive      = "PARTNER_INACTIVE"
+	EligibilityEmployeeNotInPartner = "EMPLOYEE_NOT_LINKED_TO_PARTNER"
+	EligibilityPartnerNotInEvent    = "PARTNER_NOT_ASSIGNED_TO_EVENT"
+	EligibilityCheckinNotFound      = "CHECKIN_NOT_FOUND"
+	EligibilityCheckinMismatch      = "CHECKIN_MISMATCH"
+	EligibilitySessionAlreadyOpen   = "SESSION_ALREADY_OPEN"
+	EligibilityCheckinVoided        = "CHECKIN_VOIDED"
+	EligibilityCheckinRejected      = "CHECKIN_REJECTED"
+	EligibilityCheckoutVoided       = "CHECKOUT_VOIDED"
+	EligibilityCheckinHasCheckout   = "CHECKIN_HAS_CHECKOUT"
+	EligibilityReplacementInvalid   = "REPLACEMENT_INVALID"
+	EligibilityCheckinNotPending    = "CHECKIN_NOT_PENDING_APPROVAL"
+	EligibilityEventAtCapacity      = "EVENT_AT_CAPACITY"
+	EligibilityPartnerAtCapacity    = "PARTNER_AT_CAPACITY"
+	EligibilityFaceNotRecognized    = "FACE_NOT_RECOGNIZED"
+	EligibilityFaceAmbiguous        = "FACE_AMBIGUOUS"
+)
+
+// Anulação de check-ins e check-outs
+const (
+	MaxVoidReasonLength = 500 // caracteres
+)
+
+// Status de aprovação de check-ins sem evidência suficiente (manuais ou com baixa confiança facial)
+const (
+	ApprovalStatusPending  = "pending"
+	ApprovalStatusApproved = "approved"
+	ApprovalStatusRejected = "rejected"
+)
+
+// Motivos para exigir aprovação do supervisor
+const (
+	ApprovalReasonManual              = "manual_checkin"
+	ApprovalReasonLowFacialConfidence = "low_facial_confidence"
+)
+
+// Fila de aprovação
+const (
+	MaxReviewCommentLength = 500 // caracteres
+)
+
+// Configurações de ocupação dos eventos
+const (
+	OccupancyCounterTTL            = 600  // segundos até os contadores serem reconstruídos a partir das sessões abertas
+	OccupancyDefaultSeriesInterval = 900  // segundos entre as amostras da série de ocupação
+	OccupancyMinSeriesInterval     = 60   // segundos
+	OccupancyMaxSeriesPoints       = 1000 // número máximo de amostras por série
+)
+
+// Configurações do feed de check-ins em tempo real
+const (
+	FeedHeartbeatInterval = 15   // segundos entre os heartbeats das conexões abertas
+	FeedSubscriberBuffer  = 64   // notificações pendentes por conexão; excedentes são descartadas
+	FeedReconnectDelay    = 3000 // milissegundos sugeridos ao cliente antes de reconectar
+)
+
+// Tipos de dispositivo
+const (
+	DeviceTypeKiosk    = "kiosk"
+	DeviceTypeHandheld = "handheld"
+)
+
+// Configurações do registro de dispositivos
+const (
+	DeviceSecretBytes         = 32      // bytes aleatórios da credencial do dispositivo
+	DeviceLastSeenInterval    = 60      // segundos entre atualizações do último acesso do dispositivo
+	DeviceMaxNameLength       = 100     // caracteres
+	DeviceMaxGateLength       = 100     // caracteres
+	DeviceMaxAppVersionLength = 50      // caracteres
+	DeviceNonceMinLength      = 16      // caracteres do nonce das requisições assinadas
+	DeviceNonceMaxLength      = 64      // caracteres do nonce das requisições assinadas
+	DeviceMaxSignedBodySize   = 5 << 20 // bytes do corpo de uma requisição assinada
+)
+
+// Padrões de fraude sinalizados nos check-ins
+const (
+	FraudFlagImpossibleTravel       = "impossible_travel"        // deslocamento incompatível desde o registro anterior do funcionário
+	FraudFlagBuddyPunching          = "buddy_punching"           // muitos funcionários distintos no mesmo dispositivo em segundos
+	FraudFlagDuplicateFaceEmbedding = "duplicate_face_embedding" // embedding facial idêntico a um já registrado
+)
+
+// Decisão da revisão de um check-in sinalizado
+const (
+	FraudReviewPending   = "pending"
+	FraudReviewConfirmed = "confirmed"
+	FraudReviewDismissed = "dismissed"
+)
+
+// Configurações da detecção de fraude
+const (
+	DefaultFraudMaxTravelSpeed    = 900  // km/h; acima disso o deslocamento é considerado impossível
+	FraudMinTravelDistance        = 1000 // metros; distâncias menores são ignoradas (imprecisão do GPS)
+	DefaultFraudBuddyWindow       = 30   // segundos observados no mesmo dispositivo
+	DefaultFraudBuddyThreshold    = 5    // funcionários distintos no dispositivo dentro da janela
+	DefaultFraudEmbeddingLookback = 90   // dias pesquisados em busca de embeddings idênticos
+	FraudMaxEmbeddingMatches      = 10   // check-ins idênticos citados na evidência
+	MaxFraudReviewCommentLength   = 500  // caracteres
+)
+
+// Códigos de rejeição de QR Code
+const (
+	QRCodeRejectInvalid     = "QR_CODE_INVALID"
+	QRCodeRejectExpired     = "QR_CODE_EXPIRED"
+	QRCodeRejectAlreadyUsed = "QR_CODE_ALREADY_USED"
+	QRCodeRejectWrongEvent  = "QR_CODE_WRONG_EVENT"
+	QRCodeRejectWrongType   = "QR_CODE_WRONG_TYPE"
+)
+
+// Configurações de sincronização offline
+const (
+	OfflineSyncMaxBatchSize = 500    // número máximo de registros por lote
+	OfflineSyncMaxClockSkew = 300    // segundos tolerados para horários do dispositivo no futuro
+	OfflineSyncMaxRecordAge = 604800 // segundos (7 dias) de idade máxima de um registro offline
+	OfflineSyncMaxQRCodeAge = 300    // segundos entre a leitura offline de um QR Code e o envio ao servidor
+)
+
+// Tipos de registro da sincronização offline
+const (
+	SyncRecordTypeCheckin  = "checkin"
+	SyncRecordTypeCheckout = "checkout"
+)
+
+// Resultado de cada registro da sincronização offline
+const (
+	SyncStatusAccepted  = "accepted"
+	SyncStatusDuplicate = "duplicate"
+	SyncStatusRejected  = "rejected"
+)
+
+// Códigos de rejeição da sincronização offline
+const (
+	SyncRejectInvalidRecord   = "INVALID_RECORD"
+	SyncRejectFutureTimestamp = "DEVICE_TIMESTAMP_IN_FUTURE"
+	SyncRejectStaleRecord     = "RECORD_TOO_OLD"
+	SyncRejectAlreadyExists   = "ALREADY_EXISTS"
+	SyncRejectNotFound        = "NOT_FOUND"
+	SyncRejectValidation      = "VALIDATION_ERROR"
+	SyncRejectDeviceNotBound  = "DEVICE_NOT_BOUND_TO_EVENT"
+)
+
+// Categorias de arquivos do armazenamento binário
+const (
+	StorageCategoryEmployeePhoto = "employee-photos" // foto de cadastro do funcionário
+	StorageCategoryCheckinPhoto  = "checkin-photos"  // foto de evidência capturada no check-in
+)
+
+// Configurações do armazenamento de fotos
+const (
+	DefaultMaxPhotoSize   = 5 << 20 // bytes
+	DefaultSignedURLTTL   = 300     // segundos de validade das URLs assinadas de download
+	MaxSignedURLTTL       = 3600    // segundos
+	StorageMultipartField = "photo" // campo do formulário multipart com o arquivo
+)
+
+// Códigos de rejeição de arquivos enviados
+const (
+	StorageRejectEmpty           = "EMPTY_FILE"
+	StorageRejectTooLarge        = "FILE_TOO_LARGE"
+	StorageRejectUnsupportedType = "UNSUPPORTED_CONTENT_TYPE"
+	StorageRejectContentMismatch = "CONTENT_TYPE_MISMATCH"
+)
+
+// Cifragem envelope dos dados biométricos
+const (
+	BiometricKeyPurposeData     = "data"   // chave AES-256-GCM que cifra embeddings e fotos
+	BiometricKeyPurposeSearch   = "search" // chave do índice de busca protegido e dos digests de embeddings
+	BiometricKeyBytes           = 32       // bytes das chaves de dados dos tenants
+	BiometricSearchReflections  = 8        // reflexões de Householder da transformação do índice de busca
+	DefaultBiometricKeyCacheTTL = 300      // segundos em que as chaves abertas ficam em memória
+	BiometricResealBatchSize    = 200      // registros recifrados por lote pelo comando de rotação
+)
+
+// Titulares de dados pessoais (LGPD)
+const (
+	DataSubjectEmployee = "employee"
+	DataSubjectPartner  = "partner"
+)
+
+// Solicitações dos titulares de dados
+const (
+	DataSubjectRequestExport  = "export"  // cópia legível por máquina de todos os dados do titular
+	DataSubjectRequestErasure = "erasure" // eliminação dos dados pessoais e biométricos
+)
+
+// Situações das solicitações dos titulares de dados
+const (
+	DataSubjectStatusProcessing = "processing"
+	DataSubjectStatusCompleted  = "completed"
+	DataSubjectStatusFailed     = "failed"
+)
+
+// Configurações das solicitações dos titulares de dados
+const (
+	DataSubjectAnonymizedName      = "Titular anonimizado" // nome gravado no lugar do nome do titular eliminado
+	DataSubjectMaxReasonLength     = 500                   // caracteres
+	DataSubjectExportFormatVersion = 1                     // versão do formato do arquivo de exportação
+)
+
+// Canais de coleta e revogação do consentimento biométrico
+const (
+	ConsentChannelKiosk  = "kiosk"      // aceite no quiosque de check-in
+	ConsentChannelMobile = "mobile_app" // aceite no aplicativo do funcionário
+	ConsentChannelWeb    = "web"        // registro pelo painel administrativo
+	ConsentChannelPaper  = "paper"      // termo assinado em papel e digitalizado
+	ConsentChannelSystem = "system"     // encerramento automático (novo termo aceito, eliminação de dados)
+)
+
+// Configurações do consentimento biométrico
+const (
+	MaxConsentTextLength              = 20000        // caracteres do termo de consentimento
+	MaxConsentRevocationReasonLength  = 500          // caracteres
+	ConsentRevocationReasonSuperseded = "superseded" // consentimento substituído pelo aceite de um novo termo
+	ConsentRevocationReasonErasure    = "erasure"    // consentimento encerrado pela eliminação dos dados do titular
+)
+
+// Classes de dados com prazo de retenção configurável por tenant
+const (
+	RetentionClassBiometrics = "biometrics"        // embeddings faciais capturados nos check-ins
+	RetentionClassPhotos     = "photos"            // fotos de evidência dos check-ins e check-outs
+	RetentionClassLocations  = "checkin_locations" // coordenadas e metadados de GPS dos check-ins e check-outs
+	RetentionClassLogs       = "logs"              // registros de event_log e audit_log
+)
+
+// Configurações da retenção de dados
+const (
+	MinRetentionDays              = 1                // prazo mínimo após o fim do evento
+	MaxRetentionDays              = 3650             // 10 anos
+	RetentionPurgeBatchSize       = 500              // registros eliminados por lote
+	DefaultRetentionPurgeInterval = 3600             // 1 hora entre execuções do job
+	RetentionAuditTable           = "data_retention" // table_name das eliminações registradas em audit_log
+)
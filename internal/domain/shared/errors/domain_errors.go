@@ -107,3 +107,12 @@ func NewInternalError(message string, cause error) *DomainError {
 		cause,
 	)
 }
+
+// IsNotFound verifica se o erro representa um recurso não encontrado
+func IsNotFound(err error) bool {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) && domainErr.Type == "NOT_FOUND" {
+		return true
+	}
+	return errors.Is(err, ErrNotFound)
+}
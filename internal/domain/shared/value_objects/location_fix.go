@@ -0,0 +1,87 @@
+package value_objects
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Provedores de localização informados pelo dispositivo
+const (
+	LocationProviderGPS     = "gps"
+	LocationProviderNetwork = "network"
+	LocationProviderFused   = "fused"
+	LocationProviderPassive = "passive"
+)
+
+// MaxLocationAccuracyCredit limita (em metros) quanto da precisão informada é descontado na verificação da cerca.
+// Sem o limite, uma estimativa por antena de celular com raio de quilômetros passaria em qualquer cerca.
+const MaxLocationAccuracyCredit = 100.0
+
+// LocationFix representa os metadados da leitura de GPS que acompanham uma Location
+type LocationFix struct {
+	Accuracy float64    // Raio de precisão em metros (0 quando desconhecido)
+	Altitude *float64   // Altitude em metros
+	FixTime  *time.Time // Momento em que o dispositivo obteve a posição
+	Provider string     // gps, network, fused, passive (vazio quando desconhecido)
+	IsMock   bool       // Posição simulada (mock location) reportada pelo sistema operacional
+}
+
+// NewLocationFix cria os metadados de uma leitura de GPS
+func NewLocationFix(accuracy float64, altitude *float64, fixTime *time.Time, provider string, isMock bool) (*LocationFix, error) {
+	if math.IsNaN(accuracy) || accuracy < 0 {
+		return nil, fmt.Errorf("invalid accuracy: %f (must be zero or positive)", accuracy)
+	}
+
+	if altitude != nil && (math.IsNaN(*altitude) || math.IsInf(*altitude, 0)) {
+		return nil, fmt.Errorf("invalid altitude: %f", *altitude)
+	}
+
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if !isValidLocationProvider(provider) {
+		return nil, fmt.Errorf("invalid location provider: %s", provider)
+	}
+
+	fix := &LocationFix{
+		Accuracy: accuracy,
+		Altitude: altitude,
+		Provider: provider,
+		IsMock:   isMock,
+	}
+
+	if fixTime != nil && !fixTime.IsZero() {
+		t := fixTime.UTC()
+		fix.FixTime = &t
+	}
+
+	return fix, nil
+}
+
+// AccuracyCredit retorna a precisão descontada na verificação da cerca, limitada a MaxLocationAccuracyCredit
+func (f *LocationFix) AccuracyCredit() float64 {
+	if f == nil {
+		return 0
+	}
+
+	return math.Min(f.Accuracy, MaxLocationAccuracyCredit)
+}
+
+// Age retorna há quanto tempo a posição foi obtida em relação a at; false quando o horário é desconhecido
+func (f *LocationFix) Age(at time.Time) (time.Duration, bool) {
+	if f == nil || f.FixTime == nil {
+		return 0, false
+	}
+
+	return at.Sub(*f.FixTime), true
+}
+
+// isValidLocationProvider verifica se o provedor de localização é conhecido
+func isValidLocationProvider(provider string) bool {
+	switch provider {
+	case "", LocationProviderGPS, LocationProviderNetwork, LocationProviderFused, LocationProviderPassive:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Service define a interface para o armazenamento de fotos
+type Service interface {
+	// StorePhoto valida e grava uma foto no espaço de arquivos do tenant
+	StorePhoto(ctx context.Context, upload Upload) (*Object, error)
+
+	// SignedURL gera uma URL de download de curta duração para um arquivo do tenant
+	SignedURL(tenantID value_objects.UUID, key string) (*SignedURL, error)
+
+	// Delete remove um arquivo do tenant
+	Delete(ctx context.Context, tenantID value_objects.UUID, key string) error
+}
+
+// Config contém os parâmetros configuráveis do armazenamento de fotos
+type Config struct {
+	MaxPhotoSize int64         // Tamanho máximo de uma foto em bytes
+	SignedURLTTL time.Duration // Validade das URLs assinadas de download
+}
+
+// serviceImpl implementa a interface Service
+type serviceImpl struct {
+	store  BlobStore
+	config Config
+}
+
+// NewService cria uma nova instância do serviço
+func NewService(store BlobStore, config Config) Service {
+	if config.MaxPhotoSize <= 0 {
+		config.MaxPhotoSize = constants.DefaultMaxPhotoSize
+	}
+
+	if config.SignedURLTTL <= 0 || config.SignedURLTTL > constants.MaxSignedURLTTL*time.Second {
+		config.SignedURLTTL = constants.DefaultSignedURLTTL * time.Second
+	}
+
+	return &serviceImpl{
+		store:  store,
+		config: config,
+	}
+}
+
+// StorePhoto valida e grava uma foto no espaço de arquivos do tenant.
+// O tipo declarado precisa ser suportado e coincidir com a assinatura do conteúdo.
+func (s *serviceImpl) StorePhoto(ctx context.Context, upload Upload) (*Object, error) {
+	if upload.TenantID.IsZero() {
+		return nil, errors.NewValidationError("TenantID", "é obrigatório")
+	}
+
+	if upload.OwnerID.IsZero() {
+		return nil, errors.NewValidationError("OwnerID", "é obrigatório")
+	}
+
+	if !IsValidCategory(upload.Category) {
+		return nil, errors.NewValidationError("Category", "categoria de arquivo não reconhecida")
+	}
+
+	contentType, _, err := mime.ParseMediaType(upload.ContentType)
+	if err != nil || !IsSupportedPhotoType(contentType) {
+		return nil, errors.NewValidationError("ContentType", "tipo de arquivo não suportado; use JPEG, PNG ou WebP").
+			WithContext("reason_code", constants.StorageRejectUnsupportedType)
+	}
+
+	if upload.Size > s.config.MaxPhotoSize {
+		return nil, s.tooLargeError()
+	}
+
+	// Lê no máximo um byte além do limite para detectar arquivos maiores que o declarado
+	data, err := io.ReadAll(io.LimitReader(upload.Content, s.config.MaxPhotoSize+1))
+	if err != nil {
+		return nil, errors.NewValidationError("Content", "falha ao ler o arquivo enviado")
+	}
+
+	if len(data) == 0 {
+		return nil, errors.NewValidationError("Content", "arquivo vazio").
+			WithContext("reason_code", constants.StorageRejectEmpty)
+	}
+
+	if int64(len(data)) > s.config.MaxPhotoSize {
+		return nil, s.tooLargeError()
+	}
+
+	if detected := DetectPhotoType(data); detected != contentType {
+		return nil, errors.NewValidationError("Content", "conteúdo do arquivo não corresponde ao tipo declarado").
+			WithContext("reason_code", constants.StorageRejectContentMismatch).
+			WithContext("declared_type", contentType)
+	}
+
+	object := &Object{
+		Key:         ObjectKey(upload.TenantID, upload.Category, upload.OwnerID, contentType),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+	}
+
+	if err := s.store.Put(ctx, object.Key, object.ContentType, object.Size, bytes.NewReader(data)); err != nil {
+		return nil, errors.NewInternalError("Erro ao gravar arquivo", err)
+	}
+
+	return object, nil
+}
+
+// SignedURL gera uma URL de download de curta duração para um arquivo do tenant.
+// Chaves de outro tenant são tratadas como inexistentes.
+func (s *serviceImpl) SignedURL(tenantID value_objects.UUID, key string) (*SignedURL, error) {
+	if !BelongsToTenant(key, tenantID) {
+		return nil, errors.NewNotFoundError("File", key)
+	}
+
+	expiresAt := time.Now().UTC().Add(s.config.SignedURLTTL)
+	url, err := s.store.SignedURL(key, s.config.SignedURLTTL)
+	if err != nil {
+		return nil, errors.NewInternalError("Erro ao assinar URL de download", err)
+	}
+
+	return &SignedURL{URL: url, ExpiresAt: expiresAt}, nil
+}
+
+// Delete remove um arquivo do tenant
+func (s *serviceImpl) Delete(ctx context.Context, tenantID value_objects.UUID, key string) error {
+	if !BelongsToTenant(key, tenantID) {
+		return errors.NewNotFoundError("File", key)
+	}
+
+	if err := s.store.Delete(ctx, key); err != nil {
+		return errors.NewInternalError("Erro ao remover arquivo", err)
+	}
+
+	return nil
+}
+
+// tooLargeError monta o erro de arquivo acima do limite
+func (s *serviceImpl) tooLargeError() error {
+	return errors.NewValidationError("Size", fmt.Sprintf("arquivo excede o limite de %d bytes", s.config.MaxPhotoSize)).
+		WithContext("reason_code", constants.StorageRejectTooLarge).
+		WithContext("max_size", s.config.MaxPhotoSize)
+}
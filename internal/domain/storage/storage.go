@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// Tipos de conteúdo aceitos para fotos
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeWebP = "image/webp"
+)
+
+// Object representa um arquivo gravado no armazenamento
+type Object struct {
+	Key         string
+	ContentType string
+	Size        int64
+}
+
+// BlobStore define o armazenamento binário dos arquivos.
+// Implementações: sistema de arquivos local e bucket S3 compatível.
+type BlobStore interface {
+	// Put grava o conteúdo na chave informada, substituindo um objeto existente
+	Put(ctx context.Context, key, contentType string, size int64, content io.Reader) error
+
+	// Get abre o conteúdo de um objeto; retorna errors.ErrNotFound quando não existe
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+
+	// Delete remove um objeto; remover um objeto inexistente não é erro
+	Delete(ctx context.Context, key string) error
+
+	// SignedURL gera uma URL de download válida por ttl, sem acesso à rede
+	SignedURL(key string, ttl time.Duration) (string, error)
+}
+
+// Upload representa um arquivo enviado pelo cliente
+type Upload struct {
+	TenantID    value_objects.UUID
+	Category    string             // employee-photos, checkin-photos
+	OwnerID     value_objects.UUID // Funcionário ou check-in dono do arquivo
+	ContentType string             // Tipo declarado pelo cliente
+	Size        int64              // Tamanho declarado pelo cliente
+	Content     io.Reader
+}
+
+// SignedURL representa uma URL de download de curta duração
+type SignedURL struct {
+	URL       string
+	ExpiresAt time.Time
+}
+
+// ObjectKey monta a chave de um arquivo: tenants/{tenant}/{categoria}/{dono}/{id}{extensão}.
+// O prefixo do tenant isola os arquivos de cada organização.
+func ObjectKey(tenantID value_objects.UUID, category string, ownerID value_objects.UUID, contentType string) string {
+	return fmt.Sprintf("%s%s/%s/%s%s", tenantPrefix(tenantID), category, ownerID.String(), value_objects.NewUUID().String(), extensionFor(contentType))
+}
+
+// BelongsToTenant verifica se a chave está no espaço de arquivos do tenant
+func BelongsToTenant(key string, tenantID value_objects.UUID) bool {
+	return strings.HasPrefix(key, tenantPrefix(tenantID)) && ValidateKey(key) == nil
+}
+
+// TenantFromKey extrai o tenant dono da chave a partir do prefixo tenants/{tenant}/
+func TenantFromKey(key string) (value_objects.UUID, error) {
+	rest, ok := strings.CutPrefix(key, "tenants/")
+	if !ok {
+		return value_objects.UUID{}, fmt.Errorf("object key outside tenant space: %q", key)
+	}
+
+	tenant, _, _ := strings.Cut(rest, "/")
+	tenantID, err := value_objects.ParseUUID(tenant)
+	if err != nil {
+		return value_objects.UUID{}, fmt.Errorf("object key outside tenant space: %q", key)
+	}
+
+	return tenantID, nil
+}
+
+// ValidateKey rejeita chaves vazias, absolutas ou que escapem do diretório base
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid object key: %q", key)
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("invalid object key: %q", key)
+		}
+	}
+
+	return nil
+}
+
+// IsValidCategory verifica se a categoria de arquivo é conhecida
+func IsValidCategory(category string) bool {
+	switch category {
+	case constants.StorageCategoryEmployeePhoto, constants.StorageCategoryCheckinPhoto:
+		return true
+	}
+
+	return false
+}
+
+// IsSupportedPhotoType verifica se o tipo de conteúdo é aceito para fotos
+func IsSupportedPhotoType(contentType string) bool {
+	switch contentType {
+	case ContentTypeJPEG, ContentTypePNG, ContentTypeWebP:
+		return true
+	}
+
+	return false
+}
+
+// DetectPhotoType identifica o tipo da imagem pela assinatura dos primeiros bytes; vazio quando não reconhecida
+func DetectPhotoType(header []byte) string {
+	switch {
+	case bytes.HasPrefix(header, []byte{0xFF, 0xD8, 0xFF}):
+		return ContentTypeJPEG
+	case bytes.HasPrefix(header, []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}):
+		return ContentTypePNG
+	case len(header) >= 12 && bytes.Equal(header[:4], []byte("RIFF")) && bytes.Equal(header[8:12], []byte("WEBP")):
+		return ContentTypeWebP
+	}
+
+	return ""
+}
+
+// tenantPrefix retorna o prefixo das chaves de um tenant
+func tenantPrefix(tenantID value_objects.UUID) string {
+	return "tenants/" + tenantID.String() + "/"
+}
+
+// extensionFor retorna a extensão de arquivo do tipo de conteúdo
+func extensionFor(contentType string) string {
+	switch contentType {
+	case ContentTypeJPEG:
+		return ".jpg"
+	case ContentTypePNG:
+		return ".png"
+	case ContentTypeWebP:
+		return ".webp"
+	}
+
+	return ""
+}
@@ -26,6 +26,9 @@ type Service interface {
 	// DeactivateTenant desativa um tenant
 	DeactivateTenant(ctx context.Context, id value_objects.UUID, updatedBy value_objects.UUID) error
 
+	// SetMockLocationPolicy define se o tenant rejeita localizações simuladas
+	SetMockLocationPolicy(ctx context.Context, id value_objects.UUID, reject bool, updatedBy value_objects.UUID) (*Tenant, error)
+
 	// ListTenants lista tenants com filtros
 	ListTenants(ctx context.Context, filters ListFilters) ([]*Tenant, int, error)
 
@@ -227,6 +230,28 @@ func (s *DomainService) DeactivateTenant(ctx context.Context, id value_objects.U
 	return nil
 }
 
+// SetMockLocationPolicy define se o tenant rejeita localizações simuladas
+func (s *DomainService) SetMockLocationPolicy(ctx context.Context, id value_objects.UUID, reject bool, updatedBy value_objects.UUID) (*Tenant, error) {
+	tenant, err := s.GetTenant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant.SetMockLocationPolicy(reject, updatedBy)
+
+	if err := s.repository.Update(ctx, tenant); err != nil {
+		s.logger.Error("Failed to update mock location policy", zap.Error(err))
+		return nil, errors.NewInternalError("failed to update mock location policy", err)
+	}
+
+	s.logger.Info("Tenant mock location policy updated",
+		zap.String("tenant_id", id.String()),
+		zap.Bool("reject_mock_locations", reject),
+	)
+
+	return tenant, nil
+}
+
 // ListTenants lista tenants com filtros
 func (s *DomainService) ListTenants(ctx context.Context, filters ListFilters) ([]*Tenant, int, error) {
 	if err := filters.Validate(); err != nil {
@@ -10,18 +10,19 @@ import (
 
 // Tenant representa uma organização no sistema multi-tenant
 type Tenant struct {
-	ID           value_objects.UUID
-	ConfigID     *value_objects.UUID
-	Name         string
-	Identity     string
-	IdentityType string
-	Email        string
-	Address      string
-	Active       bool
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	CreatedBy    *value_objects.UUID
-	UpdatedBy    *value_objects.UUID
+	ID                  value_objects.UUID
+	ConfigID            *value_objects.UUID
+	Name                string
+	Identity            string
+	IdentityType        string
+	Email               string
+	Address             string
+	Active              bool
+	RejectMockLocations bool // Invalida check-ins e check-outs com localização simulada (quando falso, a simulação só é registrada)
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	CreatedBy           *value_objects.UUID
+	UpdatedBy           *value_objects.UUID
 }
 
 // NewTenant cria uma nova instância de Tenant
@@ -85,6 +86,13 @@ func (t *Tenant) SetConfig(configID value_objects.UUID, updatedBy value_objects.
 	t.UpdatedBy = &updatedBy
 }
 
+// SetMockLocationPolicy define se localizações simuladas são rejeitadas nos check-ins e check-outs
+func (t *Tenant) SetMockLocationPolicy(reject bool, updatedBy value_objects.UUID) {
+	t.RejectMockLocations = reject
+	t.UpdatedAt = time.Now().UTC()
+	t.UpdatedBy = &updatedBy
+}
+
 // IsActive verifica se o tenant está ativo
 func (t *Tenant) IsActive() bool {
 	return t.Active
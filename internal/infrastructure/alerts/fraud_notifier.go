@@ -0,0 +1,75 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"eventos-backend/internal/domain/fraud"
+	"eventos-backend/internal/infrastructure/messaging/rabbitmq"
+
+	"go.uber.org/zap"
+)
+
+// SystemEventPublisher publica eventos de sistema; *rabbitmq.Publisher implementa esta interface
+type SystemEventPublisher interface {
+	PublishSystemEvent(ctx context.Context, eventType string, payload rabbitmq.SystemEventPayload) error
+}
+
+// FraudNotifier implementa fraud.Notifier emitindo mensagens system.warning.
+// Sem publisher (RabbitMQ indisponível) as sinalizações são apenas registradas no log.
+type FraudNotifier struct {
+	publisher SystemEventPublisher
+	logger    *zap.Logger
+}
+
+// NewFraudNotifier cria uma nova instância do notificador de fraude; publisher pode ser nil
+func NewFraudNotifier(publisher SystemEventPublisher, logger *zap.Logger) *FraudNotifier {
+	return &FraudNotifier{
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+// NotifyFraud emite um alerta com todas as sinalizações do check-in
+func (n *FraudNotifier) NotifyFraud(ctx context.Context, subject fraud.Subject, flags []fraud.Flag) {
+	flagTypes := make([]string, len(flags))
+	for i, flag := range flags {
+		flagTypes[i] = flag.Type
+	}
+
+	n.logger.Warn("Checkin flagged by fraud analysis",
+		zap.String("checkin_id", subject.CheckinID.String()),
+		zap.String("tenant_id", subject.TenantID.String()),
+		zap.String("employee_id", subject.EmployeeID.String()),
+		zap.String("device_id", subject.DeviceID),
+		zap.Strings("flags", flagTypes),
+	)
+
+	if n.publisher == nil {
+		return
+	}
+
+	payload := rabbitmq.SystemEventPayload{
+		Level:     "warning",
+		Message:   fmt.Sprintf("Check-in sinalizado pela análise de fraude: %s", strings.Join(flagTypes, ", ")),
+		Component: "fraud",
+		Context: map[string]interface{}{
+			"tenant_id":   subject.TenantID.String(),
+			"event_id":    subject.EventID.String(),
+			"checkin_id":  subject.CheckinID.String(),
+			"employee_id": subject.EmployeeID.String(),
+			"device_id":   subject.DeviceID,
+			"flags":       flags,
+		},
+		Timestamp: time.Now().UTC(),
+	}
+
+	if err := n.publisher.PublishSystemEvent(ctx, rabbitmq.MessageTypeSystemWarning, payload); err != nil {
+		n.logger.Error("Failed to publish fraud warning",
+			zap.Error(err),
+			zap.String("checkin_id", subject.CheckinID.String()),
+		)
+	}
+}
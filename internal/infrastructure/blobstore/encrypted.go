@@ -0,0 +1,123 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+
+	"eventos-backend/internal/domain/biometric"
+	"eventos-backend/internal/domain/storage"
+)
+
+// EncryptedStore implementa storage.BlobStore cifrando as fotos com a chave de dados do tenant dono da chave.
+// Como o armazenamento guarda apenas texto cifrado, os downloads são sempre servidos e decifrados pela API.
+type EncryptedStore struct {
+	*URLSigner
+	store  storage.BlobStore
+	cipher biometric.Cipher
+}
+
+// NewEncryptedStore cria uma nova instância do armazenamento cifrado sobre o armazenamento informado
+func NewEncryptedStore(store storage.BlobStore, cipher biometric.Cipher, signer *URLSigner) *EncryptedStore {
+	return &EncryptedStore{
+		URLSigner: signer,
+		store:     store,
+		cipher:    cipher,
+	}
+}
+
+// Put cifra o conteúdo antes de gravá-lo; a chave do objeto é o dado associado da cifragem
+func (s *EncryptedStore) Put(ctx context.Context, key, contentType string, size int64, content io.Reader) error {
+	plaintext, err := io.ReadAll(content)
+	if err != nil {
+		return fmt.Errorf("failed to read object: %w", err)
+	}
+
+	sealed, err := s.seal(ctx, key, plaintext)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Put(ctx, key, contentType, int64(len(sealed)), bytes.NewReader(sealed))
+}
+
+// Get decifra o objeto; arquivos gravados antes da cifragem são devolvidos como estão
+func (s *EncryptedStore) Get(ctx context.Context, key string) (io.ReadCloser, *storage.Object, error) {
+	plaintext, object, _, err := s.open(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	object.Size = int64(len(plaintext))
+	return io.NopCloser(bytes.NewReader(plaintext)), object, nil
+}
+
+// Delete remove o objeto
+func (s *EncryptedStore) Delete(ctx context.Context, key string) error {
+	return s.store.Delete(ctx, key)
+}
+
+// Reseal recifra o objeto com a chave de dados ativa do tenant. onlyLegacy limita aos arquivos em texto claro;
+// retorna se o objeto foi regravado.
+func (s *EncryptedStore) Reseal(ctx context.Context, key string, onlyLegacy bool) (bool, error) {
+	plaintext, object, sealed, err := s.open(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	if sealed && onlyLegacy {
+		return false, nil
+	}
+
+	if err := s.Put(ctx, key, object.ContentType, int64(len(plaintext)), bytes.NewReader(plaintext)); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// seal cifra o conteúdo com a chave do tenant dono do objeto
+func (s *EncryptedStore) seal(ctx context.Context, key string, plaintext []byte) ([]byte, error) {
+	tenantID, err := storage.TenantFromKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := s.cipher.Seal(ctx, tenantID, plaintext, []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal object: %w", err)
+	}
+
+	return sealed, nil
+}
+
+// open lê e decifra o objeto, indicando se ele estava cifrado
+func (s *EncryptedStore) open(ctx context.Context, key string) ([]byte, *storage.Object, bool, error) {
+	tenantID, err := storage.TenantFromKey(key)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	content, object, err := s.store.Get(ctx, key)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	defer content.Close()
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to read object: %w", err)
+	}
+
+	plaintext, err := s.cipher.Open(ctx, tenantID, data, []byte(key))
+	if err != nil {
+		if stderrors.Is(err, biometric.ErrNotSealed) {
+			return data, object, false, nil
+		}
+		return nil, nil, false, fmt.Errorf("failed to open object: %w", err)
+	}
+
+	return plaintext, object, true, nil
+}
@@ -0,0 +1,126 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/storage"
+)
+
+// LocalStore implementa storage.BlobStore no sistema de arquivos local.
+// Os downloads são servidos pela própria API em baseURL, com URLs assinadas por HMAC-SHA256.
+type LocalStore struct {
+	*URLSigner
+	root string
+}
+
+// NewLocalStore cria uma nova instância do armazenamento local
+func NewLocalStore(root, baseURL, secret string) (*LocalStore, error) {
+	if root == "" {
+		return nil, fmt.Errorf("local storage path must be set")
+	}
+
+	signer, err := NewURLSigner(baseURL, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create local storage directory: %w", err)
+	}
+
+	return &LocalStore{
+		URLSigner: signer,
+		root:      root,
+	}, nil
+}
+
+// Put grava o conteúdo em um arquivo temporário e o move para o destino, evitando leituras de arquivos incompletos
+func (s *LocalStore) Put(ctx context.Context, key, contentType string, size int64, content io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store object: %w", err)
+	}
+
+	return nil
+}
+
+// Get abre o arquivo; o tipo de conteúdo é deduzido pela extensão da chave
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, *storage.Object, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("object not found: %w", errors.ErrNotFound)
+		}
+		return nil, nil, fmt.Errorf("failed to open object: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to stat object: %w", err)
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return file, &storage.Object{Key: key, ContentType: contentType, Size: info.Size()}, nil
+}
+
+// Delete remove o arquivo; arquivos inexistentes são ignorados
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+
+	return nil
+}
+
+// path converte a chave no caminho do arquivo dentro do diretório base
+func (s *LocalStore) path(key string) (string, error) {
+	if err := storage.ValidateKey(key); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,88 @@
+package blobstore
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"eventos-backend/internal/domain/storage"
+)
+
+// URLSigner gera e valida as URLs de download servidas pela própria API, assinadas por HMAC-SHA256
+type URLSigner struct {
+	baseURL string
+	secret  []byte
+	now     func() time.Time
+}
+
+// NewURLSigner cria uma nova instância do assinador de URLs
+func NewURLSigner(baseURL, secret string) (*URLSigner, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("storage signing secret must be set")
+	}
+
+	return &URLSigner{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		secret:  []byte(secret),
+		now:     time.Now,
+	}, nil
+}
+
+// SignedURL gera a URL de download servida pela API: {baseURL}/{key}?expires={unix}&signature={hmac}
+func (s *URLSigner) SignedURL(key string, ttl time.Duration) (string, error) {
+	if err := storage.ValidateKey(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(s.now().Add(ttl).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(key, expires))
+
+	return s.baseURL + "/" + escapeKey(key) + "?" + query.Encode(), nil
+}
+
+// VerifySignature valida a assinatura e a expiração de uma URL gerada por SignedURL
+func (s *URLSigner) VerifySignature(key, expires, signature string) error {
+	if err := storage.ValidateKey(key); err != nil {
+		return err
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid expiration: %s", expires)
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return fmt.Errorf("invalid signature")
+	}
+
+	if s.now().Unix() > expiresAt {
+		return fmt.Errorf("signed url expired")
+	}
+
+	return nil
+}
+
+// sign calcula a assinatura HMAC-SHA256 da chave e da expiração
+func (s *URLSigner) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// escapeKey codifica cada segmento da chave para uso no caminho da URL
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
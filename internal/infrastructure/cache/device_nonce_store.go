@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// DeviceNonceStore implementa device.NonceStore no Redis, compartilhado entre as instâncias da API
+type DeviceNonceStore struct {
+	store      Cache
+	keyBuilder KeyBuilder
+}
+
+// NewDeviceNonceStore cria uma nova instância do registro de nonces
+func NewDeviceNonceStore(store Cache, keyBuilder KeyBuilder) *DeviceNonceStore {
+	return &DeviceNonceStore{
+		store:      store,
+		keyBuilder: keyBuilder,
+	}
+}
+
+// Claim reserva o nonce do dispositivo de forma atômica
+func (s *DeviceNonceStore) Claim(ctx context.Context, deviceID value_objects.UUID, nonce string, ttl time.Duration) (bool, error) {
+	return s.store.SetNX(ctx, s.keyBuilder.BuildKey("device", deviceID.String(), "nonce", nonce), 1, ttl)
+}
+
+// MemoryNonceStore implementa device.NonceStore em memória, para execução sem Redis (instância única)
+type MemoryNonceStore struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+	sweepAt time.Time
+}
+
+// NewMemoryNonceStore cria uma nova instância do registro de nonces em memória
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{
+		entries: make(map[string]time.Time),
+	}
+}
+
+// Claim reserva o nonce do dispositivo; entradas expiradas são descartadas periodicamente
+func (s *MemoryNonceStore) Claim(ctx context.Context, deviceID value_objects.UUID, nonce string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.After(s.sweepAt) {
+		for key, expiresAt := range s.entries {
+			if now.After(expiresAt) {
+				delete(s.entries, key)
+			}
+		}
+		s.sweepAt = now.Add(ttl)
+	}
+
+	key := deviceID.String() + ":" + nonce
+	if expiresAt, exists := s.entries[key]; exists && now.Before(expiresAt) {
+		return false, nil
+	}
+
+	s.entries[key] = now.Add(ttl)
+	return true, nil
+}
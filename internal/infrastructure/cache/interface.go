@@ -40,6 +40,12 @@ type Cache interface {
 	// Keys retorna todas as chaves que correspondem a um padrão
 	Keys(ctx context.Context, pattern string) ([]string, error)
 
+	// HashGetAll retorna todos os campos de um hash; vazio quando a chave não existe
+	HashGetAll(ctx context.Context, key string) (map[string]string, error)
+
+	// RunScript executa um script Lua de forma atômica no servidor
+	RunScript(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+
 	// Ping testa a conexão com o cache
 	Ping(ctx context.Context) error
 
@@ -81,4 +87,7 @@ type KeyBuilder interface {
 
 	// BuildKeyWithExpiration constrói uma chave de cache com expiração customizada
 	BuildKeyWithExpiration(ttl time.Duration, parts ...string) (string, time.Duration)
+
+	// StatsKey constrói chaves relacionadas a estatísticas
+	StatsKey(tenantID, statsType string, suffix ...string) string
 }
@@ -239,6 +239,75 @@ func (cs *CacheService) InvalidateEntity(ctx context.Context, cacheName, tenantI
 	return nil
 }
 
+// CacheStats armazena estatísticas no cache sob a chave StatsKey(tenantID, statsType, suffix...)
+func (cs *CacheService) CacheStats(ctx context.Context, cacheName, tenantID, statsType string, stats interface{}, ttl time.Duration, suffix ...string) error {
+	cache := cs.manager.GetCache(cacheName)
+	if cache == nil {
+		return fmt.Errorf("cache '%s' not found", cacheName)
+	}
+
+	key := cs.keyBuilder.StatsKey(tenantID, statsType, suffix...)
+	if ttl <= 0 {
+		ttl = cs.defaultTTL
+	}
+
+	if err := cache.Set(ctx, key, stats, ttl); err != nil {
+		cs.logger.Error("Failed to cache stats",
+			zap.String("cache", cacheName),
+			zap.String("key", key),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	cs.logger.Debug("Stats cached successfully",
+		zap.String("cache", cacheName),
+		zap.String("key", key),
+		zap.Duration("ttl", ttl),
+	)
+	return nil
+}
+
+// GetStats recupera estatísticas do cache
+func (cs *CacheService) GetStats(ctx context.Context, cacheName, tenantID, statsType string, dest interface{}, suffix ...string) error {
+	cache := cs.manager.GetCache(cacheName)
+	if cache == nil {
+		return fmt.Errorf("cache '%s' not found", cacheName)
+	}
+
+	key := cs.keyBuilder.StatsKey(tenantID, statsType, suffix...)
+	if err := cache.Get(ctx, key, dest); err != nil {
+		cs.logger.Debug("Cache miss for stats",
+			zap.String("cache", cacheName),
+			zap.String("key", key),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// InvalidateStats remove do cache as estatísticas sob a chave StatsKey(tenantID, statsType, suffix...)
+func (cs *CacheService) InvalidateStats(ctx context.Context, cacheName, tenantID, statsType string, suffix ...string) error {
+	cache := cs.manager.GetCache(cacheName)
+	if cache == nil {
+		return fmt.Errorf("cache '%s' not found", cacheName)
+	}
+
+	key := cs.keyBuilder.StatsKey(tenantID, statsType, suffix...)
+	if err := cache.Delete(ctx, key); err != nil {
+		cs.logger.Error("Failed to invalidate stats cache",
+			zap.String("cache", cacheName),
+			zap.String("key", key),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
 // InvalidatePattern remove todas as chaves que correspondem a um padrão
 func (cs *CacheService) InvalidatePattern(ctx context.Context, cacheName, pattern string) error {
 	cache := cs.manager.GetCache(cacheName)
@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"eventos-backend/internal/domain/occupancy"
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// OccupancyCounter implementa occupancy.Counter no Redis. A ocupação de cada evento fica em um hash, com o total
+// e um campo por parceiro, alterado por scripts Lua atômicos. O hash expira após o TTL e é reconstruído a partir
+// das sessões abertas, corrigindo eventuais divergências com o banco.
+type OccupancyCounter struct {
+	store      Cache
+	keyBuilder KeyBuilder
+	ttl        time.Duration
+}
+
+// occupancyTotalField é o campo do hash com o total do evento; os demais campos são IDs de parceiros
+const occupancyTotalField = "total"
+
+// occupancyAddScript soma ARGV[1] ao total e ao parceiro ARGV[2] somente se o hash existir;
+// retorna vazio quando os contadores precisam ser reconstruídos
+const occupancyAddScript = `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return {}
+end
+local total = redis.call('HINCRBY', KEYS[1], 'total', ARGV[1])
+local partner = redis.call('HINCRBY', KEYS[1], ARGV[2], ARGV[1])
+return {total, partner}`
+
+// occupancyResetScript substitui o hash pelos campos em ARGV[2..] e define o TTL de ARGV[1] milissegundos
+const occupancyResetScript = `
+redis.call('DEL', KEYS[1])
+redis.call('HSET', KEYS[1], unpack(ARGV, 2))
+redis.call('PEXPIRE', KEYS[1], ARGV[1])
+return 1`
+
+// NewOccupancyCounter cria uma nova instância do contador de ocupação
+func NewOccupancyCounter(store Cache, keyBuilder KeyBuilder, ttl time.Duration) *OccupancyCounter {
+	if ttl <= 0 {
+		ttl = 10 * time.Minute
+	}
+
+	return &OccupancyCounter{
+		store:      store,
+		keyBuilder: keyBuilder,
+		ttl:        ttl,
+	}
+}
+
+// Get retorna a ocupação do evento, no total e por parceiro
+func (c *OccupancyCounter) Get(ctx context.Context, eventID value_objects.UUID) (int64, map[value_objects.UUID]int64, bool, error) {
+	fields, err := c.store.HashGetAll(ctx, c.eventKey(eventID))
+	if err != nil || len(fields) == 0 {
+		return 0, nil, false, err
+	}
+
+	var total int64
+	byPartner := make(map[value_objects.UUID]int64, len(fields)-1)
+	for field, value := range fields {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, nil, false, fmt.Errorf("invalid occupancy counter %q: %w", field, err)
+		}
+
+		if field == occupancyTotalField {
+			total = count
+			continue
+		}
+
+		partnerID, err := value_objects.ParseUUID(field)
+		if err != nil {
+			continue
+		}
+
+		if count > 0 {
+			byPartner[partnerID] = count
+		}
+	}
+
+	return total, byPartner, true, nil
+}
+
+// Add soma delta aos contadores do evento e do parceiro, de forma atômica e somente se os contadores existirem
+func (c *OccupancyCounter) Add(ctx context.Context, eventID, partnerID value_objects.UUID, delta int64) (int64, int64, bool, error) {
+	result, err := c.store.RunScript(ctx, occupancyAddScript, []string{c.eventKey(eventID)}, delta, partnerID.String())
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	counts, ok := result.([]interface{})
+	if !ok || len(counts) != 2 {
+		return 0, 0, false, nil
+	}
+
+	eventCount, eventOK := counts[0].(int64)
+	partnerCount, partnerOK := counts[1].(int64)
+	if !eventOK || !partnerOK {
+		return 0, 0, false, fmt.Errorf("unexpected occupancy counter result: %v", counts)
+	}
+
+	return eventCount, partnerCount, true, nil
+}
+
+// Reset substitui, de forma atômica, os contadores do evento pela contagem informada
+func (c *OccupancyCounter) Reset(ctx context.Context, eventID value_objects.UUID, partners []*occupancy.PartnerHeadcount) error {
+	var total int64
+	args := []interface{}{c.ttl.Milliseconds(), occupancyTotalField, 0}
+	for _, partner := range partners {
+		if partner.OnSite <= 0 {
+			continue
+		}
+
+		args = append(args, partner.PartnerID.String(), partner.OnSite)
+		total += int64(partner.OnSite)
+	}
+	args[2] = total
+
+	_, err := c.store.RunScript(ctx, occupancyResetScript, []string{c.eventKey(eventID)}, args...)
+	return err
+}
+
+// Clear remove os contadores do evento
+func (c *OccupancyCounter) Clear(ctx context.Context, eventID value_objects.UUID) error {
+	return c.store.Delete(ctx, c.eventKey(eventID))
+}
+
+// eventKey retorna a chave do hash de ocupação do evento
+func (c *OccupancyCounter) eventKey(eventID value_objects.UUID) string {
+	return c.keyBuilder.BuildKey("occupancy", "event", eventID.String(), "headcount")
+}
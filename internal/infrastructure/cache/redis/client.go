@@ -297,3 +297,31 @@ func (c *Client) Keys(ctx context.Context, pattern string) ([]string, error) {
 	)
 	return keys, nil
 }
+
+// HashGetAll retorna todos os campos de um hash; vazio quando a chave não existe
+func (c *Client) HashGetAll(ctx context.Context, key string) (map[string]string, error) {
+	fields, err := c.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		c.logger.Error("Failed to get cache hash",
+			zap.String("key", key),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to get cache hash: %w", err)
+	}
+
+	return fields, nil
+}
+
+// RunScript executa um script Lua de forma atômica; o script é enviado por EVALSHA e reenviado quando o servidor não o conhece
+func (c *Client) RunScript(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	result, err := redis.NewScript(script).Run(ctx, c.client, keys, args...).Result()
+	if err != nil && err != redis.Nil {
+		c.logger.Error("Failed to run cache script",
+			zap.Strings("keys", keys),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to run cache script: %w", err)
+	}
+
+	return result, nil
+}
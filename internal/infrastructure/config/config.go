@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,6 +15,13 @@ type Config struct {
 	RabbitMQ RabbitMQConfig
 	JWT      JWTConfig
 	Logging  LoggingConfig
+	Facial   FacialConfig
+	QRCode   QRCodeConfig
+	Jobs     JobsConfig
+	Devices  DevicesConfig
+	Fraud    FraudConfig
+	Storage  StorageConfig
+	Biometry BiometryConfig
 }
 
 type ServerConfig struct {
@@ -65,6 +73,63 @@ type LoggingConfig struct {
 	Output string
 }
 
+type FacialConfig struct {
+	SimilarityThreshold float64
+	ApprovalThreshold   float64 // Abaixo deste valor o check-in facial vai para aprovação (0 desativa)
+	MinTemplateQuality  float64 // Qualidade mínima de um template facial no cadastro
+	MaxTemplates        int     // Templates faciais por funcionário
+	WalkUpMargin        float64 // Vantagem mínima do melhor candidato sobre o segundo no check-in walk-up
+}
+
+type QRCodeConfig struct {
+	Secret           string
+	RotationInterval time.Duration
+	Validity         time.Duration
+}
+
+type JobsConfig struct {
+	SessionAutoCloseEnabled   bool
+	SessionAutoCloseInterval  time.Duration
+	SessionAutoCloseBatchSize int
+	RetentionPurgeEnabled     bool
+	RetentionPurgeInterval    time.Duration
+	RetentionPurgeBatchSize   int
+}
+
+type DevicesConfig struct {
+	AuthRequired     bool          // Exige credencial de dispositivo registrado para enviar check-ins e check-outs
+	KeySecret        string        // Segredo do servidor que cifra as credenciais usadas nas assinaturas
+	SignatureMaxSkew time.Duration // Tolerância do relógio dos dispositivos nas requisições assinadas
+}
+
+type FraudConfig struct {
+	MaxTravelSpeed    float64       // km/h acima dos quais o deslocamento entre registros é impossível
+	BuddyWindow       time.Duration // Janela observada no mesmo dispositivo
+	BuddyThreshold    int           // Funcionários distintos no dispositivo dentro da janela
+	EmbeddingLookback time.Duration // Período pesquisado em busca de embeddings faciais idênticos
+}
+
+type StorageConfig struct {
+	Backend       string // local ou s3
+	LocalPath     string // Diretório base do armazenamento local
+	PublicBaseURL string // URL pública da rota de download; as fotos cifradas são sempre servidas pela API
+	SigningSecret string // Segredo das URLs de download assinadas pela API
+	S3Endpoint    string // Endpoint do bucket S3 compatível (AWS, MinIO, R2)
+	S3Region      string
+	S3Bucket      string
+	S3AccessKeyID string
+	S3SecretKey   string
+	S3PathStyle   bool          // Endereçamento {endpoint}/{bucket}, exigido pelo MinIO
+	MaxPhotoSize  int64         // Tamanho máximo de uma foto em bytes
+	SignedURLTTL  time.Duration // Validade das URLs de download
+}
+
+type BiometryConfig struct {
+	MasterKeys      string        // Chaves mestras no formato id:segredo, separadas por vírgula
+	ActiveMasterKey string        // Chave mestra que embrulha as chaves novas dos tenants
+	KeyCacheTTL     time.Duration // Tempo que as chaves abertas dos tenants ficam em memória
+}
+
 func Load() (*Config, error) {
 	config := &Config{
 		Server: ServerConfig{
@@ -110,6 +175,56 @@ func Load() (*Config, error) {
 			Format: getEnv("LOG_FORMAT", "json"),
 			Output: getEnv("LOG_OUTPUT", "stdout"),
 		},
+		Facial: FacialConfig{
+			SimilarityThreshold: getEnvAsFloat("FACIAL_SIMILARITY_THRESHOLD", 0.75),
+			ApprovalThreshold:   getEnvAsFloat("FACIAL_APPROVAL_THRESHOLD", 0),
+			MinTemplateQuality:  getEnvAsFloat("FACIAL_MIN_TEMPLATE_QUALITY", 0.6),
+			MaxTemplates:        getEnvAsInt("FACIAL_MAX_TEMPLATES", 5),
+			WalkUpMargin:        getEnvAsFloat("FACIAL_WALK_UP_MARGIN", 0.05),
+		},
+		QRCode: QRCodeConfig{
+			Secret:           getEnv("QR_CODE_SECRET", "your-super-secret-qr-code-key-change-in-production"),
+			RotationInterval: getEnvAsDuration("QR_CODE_ROTATION_INTERVAL", 30*time.Second),
+			Validity:         getEnvAsDuration("QR_CODE_VALIDITY", 60*time.Second),
+		},
+		Jobs: JobsConfig{
+			SessionAutoCloseEnabled:   getEnvAsBool("SESSION_AUTO_CLOSE_ENABLED", true),
+			SessionAutoCloseInterval:  getEnvAsDuration("SESSION_AUTO_CLOSE_INTERVAL", 5*time.Minute),
+			SessionAutoCloseBatchSize: getEnvAsInt("SESSION_AUTO_CLOSE_BATCH_SIZE", 200),
+			RetentionPurgeEnabled:     getEnvAsBool("RETENTION_PURGE_ENABLED", true),
+			RetentionPurgeInterval:    getEnvAsDuration("RETENTION_PURGE_INTERVAL", time.Hour),
+			RetentionPurgeBatchSize:   getEnvAsInt("RETENTION_PURGE_BATCH_SIZE", 500),
+		},
+		Devices: DevicesConfig{
+			AuthRequired:     getEnvAsBool("DEVICE_AUTH_REQUIRED", false),
+			KeySecret:        getEnv("DEVICE_KEY_SECRET", "your-super-secret-device-key-change-in-production"),
+			SignatureMaxSkew: getEnvAsDuration("DEVICE_SIGNATURE_MAX_SKEW", 5*time.Minute),
+		},
+		Fraud: FraudConfig{
+			MaxTravelSpeed:    getEnvAsFloat("FRAUD_MAX_TRAVEL_SPEED", 900),
+			BuddyWindow:       getEnvAsDuration("FRAUD_BUDDY_WINDOW", 30*time.Second),
+			BuddyThreshold:    getEnvAsInt("FRAUD_BUDDY_THRESHOLD", 5),
+			EmbeddingLookback: getEnvAsDuration("FRAUD_EMBEDDING_LOOKBACK", 90*24*time.Hour),
+		},
+		Storage: StorageConfig{
+			Backend:       getEnv("STORAGE_BACKEND", "local"),
+			LocalPath:     getEnv("STORAGE_LOCAL_PATH", "./data/storage"),
+			PublicBaseURL: getEnv("STORAGE_PUBLIC_BASE_URL", "http://localhost:8080/api/v1/files"),
+			SigningSecret: getEnv("STORAGE_SIGNING_SECRET", "your-super-secret-storage-key-change-in-production"),
+			S3Endpoint:    getEnv("STORAGE_S3_ENDPOINT", "https://s3.amazonaws.com"),
+			S3Region:      getEnv("STORAGE_S3_REGION", "us-east-1"),
+			S3Bucket:      getEnv("STORAGE_S3_BUCKET", ""),
+			S3AccessKeyID: getEnv("STORAGE_S3_ACCESS_KEY_ID", ""),
+			S3SecretKey:   getEnv("STORAGE_S3_SECRET_ACCESS_KEY", ""),
+			S3PathStyle:   getEnvAsBool("STORAGE_S3_PATH_STYLE", false),
+			MaxPhotoSize:  int64(getEnvAsInt("STORAGE_MAX_PHOTO_SIZE", 5<<20)),
+			SignedURLTTL:  getEnvAsDuration("STORAGE_SIGNED_URL_TTL", 5*time.Minute),
+		},
+		Biometry: BiometryConfig{
+			MasterKeys:      getEnv("BIOMETRIC_MASTER_KEYS", "v1:your-super-secret-biometric-key-change-in-production"),
+			ActiveMasterKey: getEnv("BIOMETRIC_ACTIVE_MASTER_KEY", "v1"),
+			KeyCacheTTL:     getEnvAsDuration("BIOMETRIC_KEY_CACHE_TTL", 5*time.Minute),
+		},
 	}
 
 	if err := config.Validate(); err != nil {
@@ -136,6 +251,26 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("database user is required")
 	}
 
+	if c.Facial.SimilarityThreshold <= 0 || c.Facial.SimilarityThreshold > 1 {
+		return fmt.Errorf("invalid facial similarity threshold: %f", c.Facial.SimilarityThreshold)
+	}
+
+	if c.Facial.ApprovalThreshold < 0 || c.Facial.ApprovalThreshold > 1 {
+		return fmt.Errorf("invalid facial approval threshold: %f", c.Facial.ApprovalThreshold)
+	}
+
+	if c.Facial.MinTemplateQuality <= 0 || c.Facial.MinTemplateQuality > 1 {
+		return fmt.Errorf("invalid facial min template quality: %f", c.Facial.MinTemplateQuality)
+	}
+
+	if c.Facial.MaxTemplates <= 0 {
+		return fmt.Errorf("facial max templates must be positive")
+	}
+
+	if c.Facial.WalkUpMargin <= 0 || c.Facial.WalkUpMargin > 1 {
+		return fmt.Errorf("invalid facial walk-up margin: %f", c.Facial.WalkUpMargin)
+	}
+
 	if c.JWT.Secret == "" {
 		return fmt.Errorf("JWT secret must be set")
 	}
@@ -146,6 +281,75 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("JWT secret must be changed from default in production")
 	}
 
+	if c.QRCode.Secret == "" {
+		return fmt.Errorf("QR code secret must be set")
+	}
+
+	if env == "production" && c.QRCode.Secret == "your-super-secret-qr-code-key-change-in-production" {
+		return fmt.Errorf("QR code secret must be changed from default in production")
+	}
+
+	if c.Devices.KeySecret == "" {
+		return fmt.Errorf("device key secret must be set")
+	}
+
+	if env == "production" && c.Devices.KeySecret == "your-super-secret-device-key-change-in-production" {
+		return fmt.Errorf("device key secret must be changed from default in production")
+	}
+
+	if c.Devices.SignatureMaxSkew <= 0 {
+		return fmt.Errorf("invalid device signature max skew: %s", c.Devices.SignatureMaxSkew)
+	}
+
+	if c.Fraud.MaxTravelSpeed <= 0 {
+		return fmt.Errorf("invalid fraud max travel speed: %f", c.Fraud.MaxTravelSpeed)
+	}
+
+	if c.Fraud.BuddyWindow <= 0 || c.Fraud.BuddyThreshold < 2 {
+		return fmt.Errorf("invalid fraud buddy punching settings: window %s, threshold %d", c.Fraud.BuddyWindow, c.Fraud.BuddyThreshold)
+	}
+
+	if c.Storage.SigningSecret == "" {
+		return fmt.Errorf("storage signing secret must be set")
+	}
+
+	if env == "production" && c.Storage.SigningSecret == "your-super-secret-storage-key-change-in-production" {
+		return fmt.Errorf("storage signing secret must be changed from default in production")
+	}
+
+	switch c.Storage.Backend {
+	case "local":
+		if c.Storage.LocalPath == "" {
+			return fmt.Errorf("local storage path must be set")
+		}
+	case "s3":
+		if c.Storage.S3Bucket == "" || c.Storage.S3AccessKeyID == "" || c.Storage.S3SecretKey == "" {
+			return fmt.Errorf("s3 storage bucket and credentials must be set")
+		}
+	default:
+		return fmt.Errorf("invalid storage backend: %s", c.Storage.Backend)
+	}
+
+	if c.Storage.MaxPhotoSize <= 0 {
+		return fmt.Errorf("invalid storage max photo size: %d", c.Storage.MaxPhotoSize)
+	}
+
+	if c.Storage.SignedURLTTL <= 0 || c.Storage.SignedURLTTL > time.Hour {
+		return fmt.Errorf("invalid storage signed URL TTL: %s (must be at most 1h)", c.Storage.SignedURLTTL)
+	}
+
+	if c.Biometry.MasterKeys == "" || c.Biometry.ActiveMasterKey == "" {
+		return fmt.Errorf("biometric master keys must be set")
+	}
+
+	if env == "production" && strings.Contains(c.Biometry.MasterKeys, "your-super-secret-biometric-key-change-in-production") {
+		return fmt.Errorf("biometric master key must be changed from default in production")
+	}
+
+	if c.Biometry.KeyCacheTTL <= 0 {
+		return fmt.Errorf("invalid biometric key cache TTL: %s", c.Biometry.KeyCacheTTL)
+	}
+
 	return nil
 }
 
@@ -173,3 +377,21 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,93 @@
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+// Keyring guarda as chaves mestras que embrulham as chaves dos tenants.
+// A chave ativa embrulha as chaves novas; as demais continuam abrindo as antigas até o reembrulho.
+type Keyring struct {
+	keys   map[string]cipher.AEAD
+	active string
+}
+
+// ParseKeyring lê as chaves mestras no formato id:segredo, separadas por vírgula.
+// Cada chave AES-256 é derivada do segredo por SHA-256, como as credenciais de dispositivos.
+func ParseKeyring(spec, activeID string) (*Keyring, error) {
+	keyring := &Keyring{keys: make(map[string]cipher.AEAD), active: activeID}
+
+	for _, entry := range strings.Split(spec, ",") {
+		id, secret, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || id == "" || secret == "" {
+			return nil, fmt.Errorf("invalid master key entry: expected id:secret")
+		}
+
+		if _, exists := keyring.keys[id]; exists {
+			return nil, fmt.Errorf("duplicate master key id: %s", id)
+		}
+
+		aead, err := newAEAD(sha256.Sum256([]byte(secret)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create master key %s: %w", id, err)
+		}
+		keyring.keys[id] = aead
+	}
+
+	if _, ok := keyring.keys[activeID]; !ok {
+		return nil, fmt.Errorf("active master key %q is not configured", activeID)
+	}
+
+	return keyring, nil
+}
+
+// ActiveKeyID retorna o identificador da chave mestra que embrulha as chaves novas
+func (k *Keyring) ActiveKeyID() string {
+	return k.active
+}
+
+// Wrap embrulha uma chave de tenant com a chave mestra ativa; associatedData vincula o resultado ao registro da chave
+func (k *Keyring) Wrap(key, associatedData []byte) (string, []byte, error) {
+	aead := k.keys[k.active]
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return k.active, aead.Seal(nonce, nonce, key, associatedData), nil
+}
+
+// Unwrap abre uma chave de tenant embrulhada pela chave mestra informada
+func (k *Keyring) Unwrap(masterKeyID string, wrapped, associatedData []byte) ([]byte, error) {
+	aead, ok := k.keys[masterKeyID]
+	if !ok {
+		return nil, fmt.Errorf("master key %q is not configured", masterKeyID)
+	}
+
+	size := aead.NonceSize()
+	if len(wrapped) < size {
+		return nil, fmt.Errorf("wrapped key too short")
+	}
+
+	key, err := aead.Open(nil, wrapped[:size], wrapped[size:], associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap key with master key %q: %w", masterKeyID, err)
+	}
+
+	return key, nil
+}
+
+// newAEAD cria o cifrador AES-256-GCM da chave
+func newAEAD(key [32]byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
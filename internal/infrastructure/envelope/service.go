@@ -0,0 +1,423 @@
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	stderrors "errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"eventos-backend/internal/domain/biometric"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"go.uber.org/zap"
+)
+
+// sealedMagic identifica os dados cifrados; o formato é magic, versão da chave de dados (uint32), nonce e texto cifrado
+var sealedMagic = []byte("EVB1")
+
+// Service implementa biometric.Cipher com cifragem envelope: cada tenant tem chaves AES-256 próprias,
+// gravadas embrulhadas pela chave mestra e mantidas abertas em memória por um tempo limitado
+type Service struct {
+	repo    biometric.KeyRepository
+	keyring *Keyring
+	ttl     time.Duration
+	logger  *zap.Logger
+	now     func() time.Time
+
+	mu      sync.Mutex
+	tenants map[value_objects.UUID]*tenantKeys
+}
+
+// tenantKeys guarda as chaves abertas de um tenant
+type tenantKeys struct {
+	data      map[int]cipher.AEAD // Por versão, incluindo as aposentadas
+	active    int
+	digestKey []byte
+	transform *searchTransform
+	loadedAt  time.Time
+}
+
+// NewService cria uma nova instância do serviço de cifragem
+func NewService(repo biometric.KeyRepository, keyring *Keyring, ttl time.Duration, logger *zap.Logger) *Service {
+	if ttl <= 0 {
+		ttl = constants.DefaultBiometricKeyCacheTTL * time.Second
+	}
+
+	return &Service{
+		repo:    repo,
+		keyring: keyring,
+		ttl:     ttl,
+		logger:  logger,
+		now:     time.Now,
+		tenants: make(map[value_objects.UUID]*tenantKeys),
+	}
+}
+
+// Seal cifra os dados com a chave de dados ativa do tenant
+func (s *Service) Seal(ctx context.Context, tenantID value_objects.UUID, plaintext, associatedData []byte) ([]byte, error) {
+	keys, err := s.keysFor(ctx, tenantID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	aead := keys.data[keys.active]
+
+	header := make([]byte, len(sealedMagic)+4, len(sealedMagic)+4+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	copy(header, sealedMagic)
+	binary.BigEndian.PutUint32(header[len(sealedMagic):], uint32(keys.active))
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return aead.Seal(append(header, nonce...), nonce, plaintext, associatedData), nil
+}
+
+// Open decifra dados gravados por Seal. Uma versão de chave desconhecida recarrega as chaves do tenant,
+// pois outra instância pode ter feito a rotação.
+func (s *Service) Open(ctx context.Context, tenantID value_objects.UUID, sealed, associatedData []byte) ([]byte, error) {
+	if !bytes.HasPrefix(sealed, sealedMagic) {
+		return nil, biometric.ErrNotSealed
+	}
+
+	if len(sealed) < len(sealedMagic)+4 {
+		return nil, fmt.Errorf("sealed data too short")
+	}
+	version := int(binary.BigEndian.Uint32(sealed[len(sealedMagic):]))
+	body := sealed[len(sealedMagic)+4:]
+
+	keys, err := s.keysFor(ctx, tenantID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, ok := keys.data[version]
+	if !ok {
+		if keys, err = s.keysFor(ctx, tenantID, true); err != nil {
+			return nil, err
+		}
+		if aead, ok = keys.data[version]; !ok {
+			return nil, fmt.Errorf("biometric data key version %d not found for tenant %s", version, tenantID)
+		}
+	}
+
+	if len(body) < aead.NonceSize() {
+		return nil, fmt.Errorf("sealed data too short")
+	}
+
+	plaintext, err := aead.Open(nil, body[:aead.NonceSize()], body[aead.NonceSize():], associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open biometric data: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// SearchVector transforma o embedding para o índice de busca protegido do tenant
+func (s *Service) SearchVector(ctx context.Context, tenantID value_objects.UUID, embedding []float32) ([]float32, error) {
+	keys, err := s.keysFor(ctx, tenantID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return keys.transform.apply(embedding)
+}
+
+// Digest calcula o HMAC-SHA256 do embedding com a chave de busca do tenant
+func (s *Service) Digest(ctx context.Context, tenantID value_objects.UUID, embedding []float32) ([]byte, error) {
+	keys, err := s.keysFor(ctx, tenantID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, keys.digestKey)
+	mac.Write(biometric.EncodeEmbedding(embedding))
+	return mac.Sum(nil), nil
+}
+
+// RotateDataKey cria uma nova versão da chave de dados do tenant e aposenta a anterior.
+// Os dados antigos continuam legíveis; Reseal os recifra com a nova versão.
+func (s *Service) RotateDataKey(ctx context.Context, tenantID value_objects.UUID) (*biometric.DataKey, error) {
+	if _, err := s.keysFor(ctx, tenantID, true); err != nil {
+		return nil, err
+	}
+
+	stored, err := s.repo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.createKey(ctx, tenantID, constants.BiometricKeyPurposeData, nextVersion(stored, constants.BiometricKeyPurposeData))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, previous := range stored {
+		if previous.Purpose == constants.BiometricKeyPurposeData && previous.IsActive() {
+			if err := s.repo.Retire(ctx, previous.ID, key.CreatedAt); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	s.logger.Info("Biometric data key rotated",
+		zap.String("tenant_id", tenantID.String()),
+		zap.Int("version", key.Version))
+
+	_, err = s.keysFor(ctx, tenantID, true)
+	return key, err
+}
+
+// Rewrap embrulha com a chave mestra ativa as chaves de todos os tenants que ainda usam outra chave mestra.
+// Depois dele, as chaves mestras antigas podem ser removidas da configuração.
+func (s *Service) Rewrap(ctx context.Context) (int, error) {
+	stored, err := s.repo.ListAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rewrapped := 0
+	for _, key := range stored {
+		if key.MasterKeyID == s.keyring.ActiveKeyID() {
+			continue
+		}
+
+		plaintext, err := s.keyring.Unwrap(key.MasterKeyID, key.WrappedKey, wrapAssociatedData(key))
+		if err != nil {
+			return rewrapped, err
+		}
+
+		if key.MasterKeyID, key.WrappedKey, err = s.keyring.Wrap(plaintext, wrapAssociatedData(key)); err != nil {
+			return rewrapped, err
+		}
+
+		if err := s.repo.UpdateWrapping(ctx, key); err != nil {
+			return rewrapped, err
+		}
+		rewrapped++
+	}
+
+	s.logger.Info("Biometric keys rewrapped",
+		zap.String("master_key_id", s.keyring.ActiveKeyID()),
+		zap.Int("keys", rewrapped))
+
+	return rewrapped, nil
+}
+
+// Reseal recifra com a chave de dados ativa os embeddings gravados, incluindo os ainda em texto claro,
+// e atualiza os vetores do índice de busca e os digests. onlyLegacy limita aos registros em texto claro.
+func (s *Service) Reseal(ctx context.Context, records biometric.RecordRepository, tenantID *value_objects.UUID, onlyLegacy bool) (int, error) {
+	resealed := 0
+
+	for _, kind := range biometric.RecordKinds {
+		var afterID *value_objects.UUID
+		for {
+			batch, err := records.ListRecords(ctx, kind, tenantID, onlyLegacy, afterID, constants.BiometricResealBatchSize)
+			if err != nil {
+				return resealed, err
+			}
+
+			for _, record := range batch {
+				if err := s.resealRecord(ctx, records, record); err != nil {
+					return resealed, fmt.Errorf("failed to reseal %s %s: %w", kind, record.ID, err)
+				}
+				resealed++
+			}
+
+			if len(batch) < constants.BiometricResealBatchSize {
+				break
+			}
+			afterID = &batch[len(batch)-1].ID
+		}
+	}
+
+	return resealed, nil
+}
+
+// resealRecord recifra um registro com a chave ativa e recalcula o vetor de busca e o digest
+func (s *Service) resealRecord(ctx context.Context, records biometric.RecordRepository, record *biometric.Record) error {
+	associatedData := biometric.AssociatedData(record.Kind, record.ID)
+
+	embedding := record.Legacy
+	if len(record.Sealed) > 0 {
+		plaintext, err := s.Open(ctx, record.TenantID, record.Sealed, associatedData)
+		if err != nil {
+			return err
+		}
+
+		if embedding, err = biometric.DecodeEmbedding(plaintext); err != nil {
+			return err
+		}
+	}
+
+	sealed, err := s.Seal(ctx, record.TenantID, biometric.EncodeEmbedding(embedding), associatedData)
+	if err != nil {
+		return err
+	}
+
+	vector, err := s.SearchVector(ctx, record.TenantID, embedding)
+	if err != nil {
+		return err
+	}
+
+	digest, err := s.Digest(ctx, record.TenantID, embedding)
+	if err != nil {
+		return err
+	}
+
+	return records.SaveRecord(ctx, record, sealed, vector, digest)
+}
+
+// keysFor retorna as chaves abertas do tenant, recarregando-as quando expiradas ou quando reload é pedido
+func (s *Service) keysFor(ctx context.Context, tenantID value_objects.UUID, reload bool) (*tenantKeys, error) {
+	s.mu.Lock()
+	keys, ok := s.tenants[tenantID]
+	s.mu.Unlock()
+
+	if ok && !reload && s.now().Sub(keys.loadedAt) < s.ttl {
+		return keys, nil
+	}
+
+	keys, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load biometric keys for tenant %s: %w", tenantID, err)
+	}
+
+	s.mu.Lock()
+	s.tenants[tenantID] = keys
+	s.mu.Unlock()
+
+	return keys, nil
+}
+
+// load lê e abre as chaves do tenant, criando as que faltam no primeiro uso
+func (s *Service) load(ctx context.Context, tenantID value_objects.UUID) (*tenantKeys, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		stored, err := s.repo.ListByTenant(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		keys, missing, err := s.unwrap(stored)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(missing) == 0 {
+			return keys, nil
+		}
+
+		for _, purpose := range missing {
+			if _, err := s.createKey(ctx, tenantID, purpose, nextVersion(stored, purpose)); err != nil && !stderrors.Is(err, errors.ErrAlreadyExists) {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("biometric keys not available")
+}
+
+// unwrap abre as chaves gravadas do tenant e indica os propósitos sem chave utilizável
+func (s *Service) unwrap(stored []*biometric.DataKey) (*tenantKeys, []string, error) {
+	keys := &tenantKeys{data: make(map[int]cipher.AEAD), loadedAt: s.now()}
+	searchVersion := 0
+
+	for _, key := range stored {
+		plaintext, err := s.keyring.Unwrap(key.MasterKeyID, key.WrappedKey, wrapAssociatedData(key))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(plaintext) != constants.BiometricKeyBytes {
+			return nil, nil, fmt.Errorf("invalid biometric key length: %d bytes", len(plaintext))
+		}
+
+		switch key.Purpose {
+		case constants.BiometricKeyPurposeData:
+			aead, err := newAEAD([32]byte(plaintext))
+			if err != nil {
+				return nil, nil, err
+			}
+			keys.data[key.Version] = aead
+
+			if key.IsActive() && key.Version > keys.active {
+				keys.active = key.Version
+			}
+		case constants.BiometricKeyPurposeSearch:
+			if key.Version > searchVersion {
+				searchVersion = key.Version
+				keys.digestKey = derive(plaintext, "face-embedding-digest")
+				keys.transform = newSearchTransform([32]byte(derive(plaintext, "face-search-transform")),
+					constants.FaceEmbeddingDimensions, constants.BiometricSearchReflections)
+			}
+		}
+	}
+
+	var missing []string
+	if keys.active == 0 {
+		missing = append(missing, constants.BiometricKeyPurposeData)
+	}
+	if searchVersion == 0 {
+		missing = append(missing, constants.BiometricKeyPurposeSearch)
+	}
+
+	return keys, missing, nil
+}
+
+// createKey sorteia, embrulha e grava uma nova chave do tenant
+func (s *Service) createKey(ctx context.Context, tenantID value_objects.UUID, purpose string, version int) (*biometric.DataKey, error) {
+	plaintext := make([]byte, constants.BiometricKeyBytes)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, fmt.Errorf("failed to generate biometric key: %w", err)
+	}
+
+	key, err := biometric.NewDataKey(tenantID, purpose, version, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.MasterKeyID, key.WrappedKey, err = s.keyring.Wrap(plaintext, wrapAssociatedData(key)); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// nextVersion calcula a próxima versão de chave do propósito
+func nextVersion(stored []*biometric.DataKey, purpose string) int {
+	version := 0
+	for _, key := range stored {
+		if key.Purpose == purpose && key.Version > version {
+			version = key.Version
+		}
+	}
+
+	return version + 1
+}
+
+// wrapAssociatedData vincula a chave embrulhada ao tenant, ao propósito e à versão
+func wrapAssociatedData(key *biometric.DataKey) []byte {
+	return []byte(key.TenantID.String() + "/" + key.Purpose + "/" + strconv.Itoa(key.Version))
+}
+
+// derive calcula uma subchave independente para cada uso da chave de busca
+func derive(key []byte, label string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(label))
+	return mac.Sum(nil)
+}
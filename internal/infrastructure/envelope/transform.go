@@ -0,0 +1,80 @@
+package envelope
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// searchTransform é a transformação ortogonal secreta do índice de busca de um tenant: permutação com troca
+// de sinais seguida de reflexões de Householder, todas sorteadas a partir da chave de busca.
+// Transformações ortogonais preservam o produto interno e, portanto, a similaridade de cosseno do pgvector.
+type searchTransform struct {
+	permutation []int
+	signs       []float64
+	reflections [][]float64 // Vetores unitários v das reflexões H = I - 2vvᵀ
+}
+
+// newSearchTransform sorteia a transformação de forma determinística a partir da semente
+func newSearchTransform(seed [32]byte, dimensions, reflections int) *searchTransform {
+	rng := rand.New(rand.NewChaCha8(seed))
+
+	transform := &searchTransform{
+		permutation: rng.Perm(dimensions),
+		signs:       make([]float64, dimensions),
+		reflections: make([][]float64, reflections),
+	}
+
+	for i := range transform.signs {
+		transform.signs[i] = 1
+		if rng.IntN(2) == 0 {
+			transform.signs[i] = -1
+		}
+	}
+
+	for r := range transform.reflections {
+		v := make([]float64, dimensions)
+		var norm float64
+		for i := range v {
+			v[i] = rng.NormFloat64()
+			norm += v[i] * v[i]
+		}
+
+		norm = math.Sqrt(norm)
+		for i := range v {
+			v[i] /= norm
+		}
+		transform.reflections[r] = v
+	}
+
+	return transform
+}
+
+// apply transforma o embedding para o espaço do índice de busca
+func (t *searchTransform) apply(embedding []float32) ([]float32, error) {
+	if len(embedding) != len(t.permutation) {
+		return nil, fmt.Errorf("invalid embedding dimensions: %d", len(embedding))
+	}
+
+	x := make([]float64, len(embedding))
+	for i, j := range t.permutation {
+		x[i] = t.signs[i] * float64(embedding[j])
+	}
+
+	for _, v := range t.reflections {
+		var dot float64
+		for i := range x {
+			dot += v[i] * x[i]
+		}
+		for i := range x {
+			x[i] -= 2 * dot * v[i]
+		}
+	}
+
+	result := make([]float32, len(x))
+	for i, value := range x {
+		result[i] = float32(value)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"eventos-backend/internal/domain/retention"
+	"eventos-backend/internal/domain/shared/constants"
+
+	"go.uber.org/zap"
+)
+
+// RetentionPurgeJob elimina periodicamente os dados vencidos pelas políticas de retenção dos tenants
+type RetentionPurgeJob struct {
+	retentionService retention.Service
+	interval         time.Duration
+	batchSize        int
+	logger           *zap.Logger
+}
+
+// NewRetentionPurgeJob cria uma nova instância do job
+func NewRetentionPurgeJob(retentionService retention.Service, interval time.Duration, batchSize int, logger *zap.Logger) *RetentionPurgeJob {
+	if interval <= 0 {
+		interval = time.Duration(constants.DefaultRetentionPurgeInterval) * time.Second
+	}
+
+	if batchSize <= 0 {
+		batchSize = constants.RetentionPurgeBatchSize
+	}
+
+	return &RetentionPurgeJob{
+		retentionService: retentionService,
+		interval:         interval,
+		batchSize:        batchSize,
+		logger:           logger,
+	}
+}
+
+// Start executa o job no intervalo configurado até o contexto ser cancelado
+func (j *RetentionPurgeJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.logger.Info("Retention purge job started", zap.Duration("interval", j.interval))
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			j.logger.Info("Retention purge job stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce aplica as políticas de cada tenant. A falha de um tenant não interrompe os demais;
+// o que ficou pendente é eliminado na próxima execução.
+func (j *RetentionPurgeJob) RunOnce(ctx context.Context) int {
+	tenantIDs, err := j.retentionService.ListTenants(ctx)
+	if err != nil {
+		j.logger.Error("Failed to list tenants with retention policies", zap.Error(err))
+		return 0
+	}
+
+	total := 0
+	for _, tenantID := range tenantIDs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		report, err := j.retentionService.Purge(ctx, tenantID, time.Now().UTC(), j.batchSize, nil)
+		if report != nil {
+			total += report.Total()
+		}
+
+		if err != nil {
+			j.logger.Error("Failed to purge expired data",
+				zap.String("tenant_id", tenantID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	if total > 0 {
+		j.logger.Info("Expired data purged", zap.Int("records", total), zap.Int("tenants", len(tenantIDs)))
+	}
+
+	return total
+}
@@ -0,0 +1,121 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"eventos-backend/internal/domain/checkout"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/infrastructure/messaging/rabbitmq"
+	"eventos-backend/internal/infrastructure/realtime"
+
+	"go.uber.org/zap"
+)
+
+// SessionAutoCloseJob encerra periodicamente sessões de trabalho esquecidas abertas
+type SessionAutoCloseJob struct {
+	checkoutService checkout.Service
+	publisher       realtime.Publisher
+	interval        time.Duration
+	batchSize       int
+	logger          *zap.Logger
+}
+
+// NewSessionAutoCloseJob cria uma nova instância do job.
+// Sem publisher configurado os check-outs são criados sem publicar eventos.
+func NewSessionAutoCloseJob(checkoutService checkout.Service, publisher realtime.Publisher, interval time.Duration, batchSize int, logger *zap.Logger) *SessionAutoCloseJob {
+	if interval <= 0 {
+		interval = time.Duration(constants.DefaultSessionAutoCloseInterval) * time.Second
+	}
+
+	if batchSize <= 0 {
+		batchSize = constants.SessionAutoCloseBatchSize
+	}
+
+	return &SessionAutoCloseJob{
+		checkoutService: checkoutService,
+		publisher:       publisher,
+		interval:        interval,
+		batchSize:       batchSize,
+		logger:          logger,
+	}
+}
+
+// Start executa o job no intervalo configurado até o contexto ser cancelado
+func (j *SessionAutoCloseJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.logger.Info("Session auto-close job started", zap.Duration("interval", j.interval))
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			j.logger.Info("Session auto-close job stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce encerra as sessões vencidas e publica work_session.completed para cada uma.
+// Lotes cheios são processados em sequência até não restarem sessões vencidas.
+func (j *SessionAutoCloseJob) RunOnce(ctx context.Context) int {
+	total := 0
+
+	for ctx.Err() == nil {
+		closed, err := j.checkoutService.AutoCloseStaleSessions(ctx, time.Now().UTC(), j.batchSize)
+		for _, c := range closed {
+			j.publish(ctx, c)
+		}
+		total += len(closed)
+
+		if err != nil {
+			j.logger.Error("Failed to auto-close stale sessions", zap.Error(err))
+			break
+		}
+
+		if len(closed) < j.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		j.logger.Info("Stale sessions auto-closed", zap.Int("count", total))
+	}
+
+	return total
+}
+
+// publish notifica o encerramento da sessão de trabalho
+func (j *SessionAutoCloseJob) publish(ctx context.Context, c *checkout.Checkout) {
+	if j.publisher == nil {
+		return
+	}
+
+	reason, _ := c.GetValidationDetail("auto_close_reason")
+	reasonStr, _ := reason.(string)
+
+	payload := rabbitmq.WorkSessionEventPayload{
+		CheckinID:       c.CheckinID.String(),
+		CheckoutID:      c.ID.String(),
+		TenantID:        c.TenantID.String(),
+		EventID:         c.EventID.String(),
+		EmployeeID:      c.EmployeeID.String(),
+		PartnerID:       c.PartnerID.String(),
+		CheckinTime:     c.CheckoutTime.Add(-c.WorkDuration),
+		CheckoutTime:    c.CheckoutTime,
+		WorkDuration:    c.WorkDuration,
+		AutoClosed:      c.AutoClosed,
+		AutoCloseReason: reasonStr,
+	}
+
+	if err := j.publisher.PublishWorkSessionEvent(ctx, rabbitmq.MessageTypeWorkSessionCompleted, payload); err != nil {
+		j.logger.Warn("Failed to publish work session completed event",
+			zap.String("checkout_id", c.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
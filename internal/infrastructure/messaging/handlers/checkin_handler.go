@@ -217,7 +217,7 @@ func (h *CheckinEventHandler) invalidateRelatedCaches(ctx context.Context, paylo
 		// Cache de listas de check-ins do evento
 		defaultKeyBuilder.BuildKeyWithTenant(payload.TenantID, "list", "checkin", "event", payload.EventID) + "*",
 
-		// Cache de estatísticas
+		// Cache de estatísticas, incluindo as do evento, do funcionário e do parceiro
 		defaultKeyBuilder.StatsKey(payload.TenantID, "checkin") + "*",
 
 		// Cache de check-ins recentes
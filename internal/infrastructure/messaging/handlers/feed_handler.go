@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"eventos-backend/internal/infrastructure/messaging/rabbitmq"
+	"eventos-backend/internal/infrastructure/realtime"
+
+	"go.uber.org/zap"
+)
+
+// FeedEventHandler repassa os eventos de check-in e check-out às conexões do feed desta instância
+type FeedEventHandler struct {
+	hub    *realtime.Hub
+	logger *zap.Logger
+}
+
+// NewFeedEventHandler cria uma nova instância do handler
+func NewFeedEventHandler(hub *realtime.Hub, logger *zap.Logger) *FeedEventHandler {
+	return &FeedEventHandler{
+		hub:    hub,
+		logger: logger,
+	}
+}
+
+// Handle converte a mensagem em notificação e a entrega ao hub
+func (h *FeedEventHandler) Handle(ctx context.Context, message *rabbitmq.Message) error {
+	if !h.CanHandle(message.Type) {
+		return fmt.Errorf("unsupported message type: %s", message.Type)
+	}
+
+	notification, err := realtime.NewNotification(message)
+	if err != nil {
+		// Mensagem malformada: reprocessar não resolve
+		h.logger.Warn("Discarding feed message",
+			zap.String("message_id", message.ID),
+			zap.String("message_type", message.Type),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	h.hub.Broadcast(notification)
+	return nil
+}
+
+// CanHandle verifica se o handler pode processar o tipo de mensagem
+func (h *FeedEventHandler) CanHandle(messageType string) bool {
+	return realtime.IsFeedMessageType(messageType)
+}
+
+// GetName retorna o nome do handler
+func (h *FeedEventHandler) GetName() string {
+	return "FeedEventHandler"
+}
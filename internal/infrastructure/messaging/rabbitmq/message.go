@@ -227,6 +227,11 @@ type CheckinEventPayload struct {
 	Method      string    `json:"method"`
 	IsValid     bool      `json:"is_valid"`
 	CheckinTime time.Time `json:"checkin_time"`
+
+	// Decisão do supervisor (check-ins da fila de aprovação)
+	ApprovalStatus string `json:"approval_status,omitempty"`
+	ReviewedBy     string `json:"reviewed_by,omitempty"`
+	ReviewComment  string `json:"review_comment,omitempty"`
 }
 
 // CheckoutEventPayload payload para eventos de check-out
@@ -243,6 +248,21 @@ type CheckoutEventPayload struct {
 	WorkDuration time.Duration `json:"work_duration"`
 }
 
+// WorkSessionEventPayload payload para eventos de sessões de trabalho
+type WorkSessionEventPayload struct {
+	CheckinID       string        `json:"checkin_id"`
+	CheckoutID      string        `json:"checkout_id"`
+	TenantID        string        `json:"tenant_id"`
+	EventID         string        `json:"event_id"`
+	EmployeeID      string        `json:"employee_id"`
+	PartnerID       string        `json:"partner_id"`
+	CheckinTime     time.Time     `json:"checkin_time"`
+	CheckoutTime    time.Time     `json:"checkout_time"`
+	WorkDuration    time.Duration `json:"work_duration"`
+	AutoClosed      bool          `json:"auto_closed"`
+	AutoCloseReason string        `json:"auto_close_reason,omitempty"`
+}
+
 // SystemEventPayload payload para eventos de sistema
 type SystemEventPayload struct {
 	Level     string                 `json:"level"` // error, warning, info
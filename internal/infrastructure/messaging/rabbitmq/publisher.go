@@ -145,6 +145,14 @@ func (p *Publisher) PublishCheckoutEvent(ctx context.Context, eventType string,
 	return p.PublishToDefault(ctx, "checkout.events", message)
 }
 
+// PublishWorkSessionEvent publica eventos relacionados a sessões de trabalho
+func (p *Publisher) PublishWorkSessionEvent(ctx context.Context, eventType string, payload WorkSessionEventPayload) error {
+	message := NewMessage(eventType, payload)
+	message.SetTenantID(payload.TenantID)
+
+	return p.PublishToDefault(ctx, "work_session.events", message)
+}
+
 // PublishSystemEvent publica eventos de sistema
 func (p *Publisher) PublishSystemEvent(ctx context.Context, eventType string, payload SystemEventPayload) error {
 	message := NewMessage(eventType, payload)
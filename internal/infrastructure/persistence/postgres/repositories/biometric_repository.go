@@ -0,0 +1,289 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"eventos-backend/internal/domain/biometric"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// BiometricRepository implementa biometric.KeyRepository e biometric.RecordRepository usando PostgreSQL
+type BiometricRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewBiometricRepository cria uma nova instância do repositório de chaves e dados biométricos
+func NewBiometricRepository(db *sqlx.DB, logger *zap.Logger) *BiometricRepository {
+	return &BiometricRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// biometricKeyColumns lista as colunas lidas da tabela biometric_keys
+const biometricKeyColumns = `id, tenant_id, purpose, version, master_key_id, wrapped_key, created_at, retired_at`
+
+// biometricKeyRow representa uma linha de chave de tenant no banco de dados
+type biometricKeyRow struct {
+	ID          string       `db:"id"`
+	TenantID    string       `db:"tenant_id"`
+	Purpose     string       `db:"purpose"`
+	Version     int          `db:"version"`
+	MasterKeyID string       `db:"master_key_id"`
+	WrappedKey  []byte       `db:"wrapped_key"`
+	CreatedAt   time.Time    `db:"created_at"`
+	RetiredAt   sql.NullTime `db:"retired_at"`
+}
+
+// toEntity converte biometricKeyRow para entidade DataKey
+func (r *biometricKeyRow) toEntity() (*biometric.DataKey, error) {
+	id, err := value_objects.ParseUUID(r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid biometric key ID: %w", err)
+	}
+
+	tenantID, err := value_objects.ParseUUID(r.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant ID: %w", err)
+	}
+
+	key := &biometric.DataKey{
+		ID:          id,
+		TenantID:    tenantID,
+		Purpose:     r.Purpose,
+		Version:     r.Version,
+		MasterKeyID: r.MasterKeyID,
+		WrappedKey:  r.WrappedKey,
+		CreatedAt:   r.CreatedAt,
+	}
+
+	if r.RetiredAt.Valid {
+		key.RetiredAt = &r.RetiredAt.Time
+	}
+
+	return key, nil
+}
+
+// ListByTenant lista as chaves do tenant, das versões mais novas para as mais antigas
+func (repo *BiometricRepository) ListByTenant(ctx context.Context, tenantID value_objects.UUID) ([]*biometric.DataKey, error) {
+	query := `SELECT ` + biometricKeyColumns + ` FROM biometric_keys WHERE tenant_id = $1 ORDER BY purpose, version DESC`
+
+	return repo.selectKeys(ctx, query, tenantID.String())
+}
+
+// ListAll lista as chaves de todos os tenants
+func (repo *BiometricRepository) ListAll(ctx context.Context) ([]*biometric.DataKey, error) {
+	query := `SELECT ` + biometricKeyColumns + ` FROM biometric_keys ORDER BY tenant_id, purpose, version`
+
+	return repo.selectKeys(ctx, query)
+}
+
+// selectKeys executa uma consulta de chaves
+func (repo *BiometricRepository) selectKeys(ctx context.Context, query string, args ...interface{}) ([]*biometric.DataKey, error) {
+	var rows []biometricKeyRow
+	if err := repo.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		repo.logger.Error("Failed to list biometric keys", zap.Error(err))
+		return nil, fmt.Errorf("failed to list biometric keys: %w", err)
+	}
+
+	keys := make([]*biometric.DataKey, 0, len(rows))
+	for i := range rows {
+		key, err := rows[i].toEntity()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// Create grava uma nova chave; a versão já criada por outra instância retorna AlreadyExists
+func (repo *BiometricRepository) Create(ctx context.Context, key *biometric.DataKey) error {
+	query := `
+		INSERT INTO biometric_keys (id, tenant_id, purpose, version, master_key_id, wrapped_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (tenant_id, purpose, version) DO NOTHING`
+
+	result, err := repo.db.ExecContext(ctx, query,
+		key.ID.String(), key.TenantID.String(), key.Purpose, key.Version, key.MasterKeyID, key.WrappedKey, key.CreatedAt)
+	if err != nil {
+		repo.logger.Error("Failed to create biometric key", zap.Error(err), zap.String("tenant_id", key.TenantID.String()))
+		return fmt.Errorf("failed to create biometric key: %w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return errors.NewAlreadyExistsError("biometric key", "version", key.Version)
+	}
+
+	repo.logger.Info("Biometric key created",
+		zap.String("tenant_id", key.TenantID.String()),
+		zap.String("purpose", key.Purpose),
+		zap.Int("version", key.Version))
+	return nil
+}
+
+// UpdateWrapping grava a chave embrulhada novamente, por outra chave mestra
+func (repo *BiometricRepository) UpdateWrapping(ctx context.Context, key *biometric.DataKey) error {
+	query := `UPDATE biometric_keys SET master_key_id = $2, wrapped_key = $3 WHERE id = $1`
+
+	if _, err := repo.db.ExecContext(ctx, query, key.ID.String(), key.MasterKeyID, key.WrappedKey); err != nil {
+		repo.logger.Error("Failed to rewrap biometric key", zap.Error(err), zap.String("key_id", key.ID.String()))
+		return fmt.Errorf("failed to rewrap biometric key: %w", err)
+	}
+
+	return nil
+}
+
+// Retire aposenta a chave: ela deixa de cifrar dados novos
+func (repo *BiometricRepository) Retire(ctx context.Context, id value_objects.UUID, at time.Time) error {
+	query := `UPDATE biometric_keys SET retired_at = $2 WHERE id = $1 AND retired_at IS NULL`
+
+	if _, err := repo.db.ExecContext(ctx, query, id.String(), at); err != nil {
+		repo.logger.Error("Failed to retire biometric key", zap.Error(err), zap.String("key_id", id.String()))
+		return fmt.Errorf("failed to retire biometric key: %w", err)
+	}
+
+	return nil
+}
+
+// biometricRecordColumns indica, por tipo de registro, a tabela e as colunas do embedding
+var biometricRecordColumns = map[string]struct {
+	table, id, tenant, sealed, legacy string
+}{
+	biometric.RecordEmployee:     {"employees", "id", "tenant_id", "face_embedding_sealed", "face_embedding"},
+	biometric.RecordFaceTemplate: {"employee_face_templates", "id", "tenant_id", "embedding_sealed", "embedding"},
+	biometric.RecordCheckin:      {"checkin", "id_checkin", "id_tenant", "face_embedding_sealed", "face_embedding"},
+}
+
+// biometricRecordRow representa um embedding lido na varredura
+type biometricRecordRow struct {
+	ID       string          `db:"id"`
+	TenantID string          `db:"tenant_id"`
+	Sealed   []byte          `db:"sealed"`
+	Legacy   pq.Float32Array `db:"legacy"`
+}
+
+// ListRecords lista em ordem de ID os registros com embedding posteriores a afterID
+func (repo *BiometricRepository) ListRecords(ctx context.Context, kind string, tenantID *value_objects.UUID, onlyLegacy bool, afterID *value_objects.UUID, limit int) ([]*biometric.Record, error) {
+	columns, ok := biometricRecordColumns[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown biometric record kind: %s", kind)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %[2]s AS id, %[3]s AS tenant_id, %[4]s AS sealed, %[5]s AS legacy
+		FROM %[1]s
+		WHERE (%[4]s IS NOT NULL OR array_length(%[5]s, 1) > 0)
+		  AND ($1::boolean = false OR %[4]s IS NULL)
+		  AND ($2::uuid IS NULL OR %[3]s = $2::uuid)
+		  AND ($3::uuid IS NULL OR %[2]s > $3::uuid)
+		ORDER BY %[2]s
+		LIMIT $4`, columns.table, columns.id, columns.tenant, columns.sealed, columns.legacy)
+
+	var rows []biometricRecordRow
+	if err := repo.db.SelectContext(ctx, &rows, query, onlyLegacy, toNullUUID(tenantID), toNullUUID(afterID), limit); err != nil {
+		repo.logger.Error("Failed to list biometric records", zap.Error(err), zap.String("kind", kind))
+		return nil, fmt.Errorf("failed to list biometric records: %w", err)
+	}
+
+	records := make([]*biometric.Record, 0, len(rows))
+	for _, row := range rows {
+		id, err := value_objects.ParseUUID(row.ID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid record ID: %w", err)
+		}
+
+		tenant, err := value_objects.ParseUUID(row.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tenant ID: %w", err)
+		}
+
+		records = append(records, &biometric.Record{
+			Kind:     kind,
+			ID:       id,
+			TenantID: tenant,
+			Sealed:   row.Sealed,
+			Legacy:   []float32(row.Legacy),
+		})
+	}
+
+	return records, nil
+}
+
+// SaveRecord grava o embedding recifrado e apaga o texto claro. A gravação só acontece se o registro não mudou
+// desde a leitura; check-ins guardam o digest e os demais registros o vetor do índice de busca.
+func (repo *BiometricRepository) SaveRecord(ctx context.Context, record *biometric.Record, sealed []byte, searchVector []float32, digest []byte) error {
+	columns, ok := biometricRecordColumns[record.Kind]
+	if !ok {
+		return fmt.Errorf("unknown biometric record kind: %s", record.Kind)
+	}
+
+	index, value := "face_embedding_vector = $3::real[]::vector(512)", interface{}(pq.Float32Array(searchVector))
+	switch record.Kind {
+	case biometric.RecordFaceTemplate:
+		index = "embedding_vector = $3::real[]::vector(512)"
+	case biometric.RecordCheckin:
+		index, value = "face_embedding_digest = $3", digest
+	}
+
+	var previous, legacy interface{}
+	if len(record.Sealed) > 0 {
+		previous = record.Sealed
+	}
+	if len(record.Legacy) > 0 {
+		legacy = pq.Float32Array(record.Legacy)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %[1]s SET %[4]s = $2, %[6]s, %[5]s = NULL
+		WHERE %[2]s = $1
+		  AND %[4]s IS NOT DISTINCT FROM $4
+		  AND %[5]s IS NOT DISTINCT FROM $5::real[]`,
+		columns.table, columns.id, columns.tenant, columns.sealed, columns.legacy, index)
+
+	if _, err := repo.db.ExecContext(ctx, query, record.ID.String(), sealed, value, previous, legacy); err != nil {
+		repo.logger.Error("Failed to save biometric record",
+			zap.Error(err),
+			zap.String("kind", record.Kind),
+			zap.String("id", record.ID.String()))
+		return fmt.Errorf("failed to save biometric record: %w", err)
+	}
+
+	return nil
+}
+
+// ListPhotoKeys lista em ordem as chaves de fotos do armazenamento posteriores a afterKey
+func (repo *BiometricRepository) ListPhotoKeys(ctx context.Context, tenantID *value_objects.UUID, afterKey string, limit int) ([]string, error) {
+	query := `
+		SELECT key FROM (
+			SELECT photo_key AS key FROM employees
+			WHERE photo_key IS NOT NULL AND ($1::uuid IS NULL OR tenant_id = $1::uuid)
+			UNION
+			SELECT source_photo_key FROM employee_face_templates
+			WHERE source_photo_key IS NOT NULL AND ($1::uuid IS NULL OR tenant_id = $1::uuid)
+			UNION
+			SELECT photo_key FROM checkin
+			WHERE photo_key IS NOT NULL AND ($1::uuid IS NULL OR id_tenant = $1::uuid)
+		) photo_keys
+		WHERE key > $2
+		ORDER BY key
+		LIMIT $3`
+
+	var keys []string
+	if err := repo.db.SelectContext(ctx, &keys, query, toNullUUID(tenantID), afterKey, limit); err != nil {
+		repo.logger.Error("Failed to list photo keys", zap.Error(err))
+		return nil, fmt.Errorf("failed to list photo keys: %w", err)
+	}
+
+	return keys, nil
+}
@@ -8,23 +8,28 @@ import (
 	"strings"
 	"time"
 
+	"eventos-backend/internal/domain/biometric"
 	"eventos-backend/internal/domain/checkin"
+	"eventos-backend/internal/domain/shared/errors"
 	"eventos-backend/internal/domain/shared/value_objects"
 
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 )
 
-// CheckinRepository implementa a interface de repositório para Checkin
+// CheckinRepository implementa a interface de repositório para Checkin.
+// O embedding capturado é gravado cifrado e nunca é aberto nas leituras; o digest permite achar embeddings idênticos.
 type CheckinRepository struct {
 	db     *sqlx.DB
+	cipher biometric.Cipher
 	logger *zap.Logger
 }
 
 // NewCheckinRepository cria uma nova instância do repositório de checkin
-func NewCheckinRepository(db *sqlx.DB, logger *zap.Logger) checkin.Repository {
+func NewCheckinRepository(db *sqlx.DB, cipher biometric.Cipher, logger *zap.Logger) checkin.Repository {
 	return &CheckinRepository{
 		db:     db,
+		cipher: cipher,
 		logger: logger,
 	}
 }
@@ -41,13 +46,29 @@ type checkinRow struct {
 	Longitude         float64        `db:"longitude"`
 	CheckinTime       time.Time      `db:"checkin_time"`
 	PhotoURL          sql.NullString `db:"photo_url"`
+	PhotoKey          sql.NullString `db:"photo_key"`
+	SealedEmbedding   []byte         `db:"face_embedding_sealed"`
+	EmbeddingDigest   []byte         `db:"face_embedding_digest"`
 	Notes             sql.NullString `db:"notes"`
+	ClientID          sql.NullString `db:"client_id"`
+	DeviceID          sql.NullString `db:"device_id"`
 	IsValid           bool           `db:"is_valid"`
 	ValidationDetails sql.NullString `db:"validation_details"`
+	VoidedAt          sql.NullTime   `db:"voided_at"`
+	VoidedBy          sql.NullString `db:"voided_by"`
+	VoidReason        sql.NullString `db:"void_reason"`
+	ReplacesID        sql.NullString `db:"replaces_id"`
+	ApprovalStatus    sql.NullString `db:"approval_status"`
+	ReviewedAt        sql.NullTime   `db:"reviewed_at"`
+	ReviewedBy        sql.NullString `db:"reviewed_by"`
+	ReviewComment     sql.NullString `db:"review_comment"`
 	CreatedAt         time.Time      `db:"created_at"`
 	UpdatedAt         time.Time      `db:"updated_at"`
 	CreatedBy         sql.NullString `db:"created_by"`
 	UpdatedBy         sql.NullString `db:"updated_by"`
+
+	// Metadados da leitura de GPS
+	locationFixRow
 }
 
 // toEntity converte uma linha do banco para entidade de domínio
@@ -101,11 +122,37 @@ func (r *checkinRow) toEntity() (*checkin.Checkin, error) {
 		checkinEntity.PhotoURL = r.PhotoURL.String
 	}
 
+	// PhotoKey
+	if r.PhotoKey.Valid {
+		checkinEntity.PhotoKey = r.PhotoKey.String
+	}
+
+	// Embedding facial cifrado
+	if len(r.SealedEmbedding) > 0 {
+		checkinEntity.SealedEmbedding = r.SealedEmbedding
+	}
+
 	// Notes
 	if r.Notes.Valid {
 		checkinEntity.Notes = r.Notes.String
 	}
 
+	// ClientID
+	if r.ClientID.Valid {
+		clientID, err := value_objects.ParseUUID(r.ClientID.String)
+		if err == nil {
+			checkinEntity.ClientID = &clientID
+		}
+	}
+
+	// DeviceID
+	if r.DeviceID.Valid {
+		checkinEntity.DeviceID = r.DeviceID.String
+	}
+
+	// Metadados da leitura de GPS
+	checkinEntity.LocationFix = r.toLocationFix()
+
 	// ValidationDetails
 	if r.ValidationDetails.Valid && r.ValidationDetails.String != "" {
 		var details map[string]interface{}
@@ -118,6 +165,52 @@ func (r *checkinRow) toEntity() (*checkin.Checkin, error) {
 		checkinEntity.ValidationDetails = make(map[string]interface{})
 	}
 
+	// Anulação
+	if r.VoidedAt.Valid {
+		voidedAt := r.VoidedAt.Time
+		checkinEntity.VoidedAt = &voidedAt
+	}
+
+	if r.VoidedBy.Valid {
+		voidedBy, err := value_objects.ParseUUID(r.VoidedBy.String)
+		if err == nil {
+			checkinEntity.VoidedBy = &voidedBy
+		}
+	}
+
+	if r.VoidReason.Valid {
+		checkinEntity.VoidReason = r.VoidReason.String
+	}
+
+	// ReplacesID
+	if r.ReplacesID.Valid {
+		replacesID, err := value_objects.ParseUUID(r.ReplacesID.String)
+		if err == nil {
+			checkinEntity.ReplacesID = &replacesID
+		}
+	}
+
+	// Aprovação do supervisor
+	if r.ApprovalStatus.Valid {
+		checkinEntity.ApprovalStatus = r.ApprovalStatus.String
+	}
+
+	if r.ReviewedAt.Valid {
+		reviewedAt := r.ReviewedAt.Time
+		checkinEntity.ReviewedAt = &reviewedAt
+	}
+
+	if r.ReviewedBy.Valid {
+		reviewedBy, err := value_objects.ParseUUID(r.ReviewedBy.String)
+		if err == nil {
+			checkinEntity.ReviewedBy = &reviewedBy
+		}
+	}
+
+	if r.ReviewComment.Valid {
+		checkinEntity.ReviewComment = r.ReviewComment.String
+	}
+
 	// CreatedBy
 	if r.CreatedBy.Valid {
 		createdBy, err := value_objects.ParseUUID(r.CreatedBy.String)
@@ -159,11 +252,29 @@ func (repo *CheckinRepository) fromEntity(c *checkin.Checkin) *checkinRow {
 		row.PhotoURL = sql.NullString{String: c.PhotoURL, Valid: true}
 	}
 
+	// PhotoKey
+	if c.PhotoKey != "" {
+		row.PhotoKey = sql.NullString{String: c.PhotoKey, Valid: true}
+	}
+
 	// Notes
 	if c.Notes != "" {
 		row.Notes = sql.NullString{String: c.Notes, Valid: true}
 	}
 
+	// ClientID
+	if c.ClientID != nil {
+		row.ClientID = sql.NullString{String: c.ClientID.String(), Valid: true}
+	}
+
+	// DeviceID
+	if c.DeviceID != "" {
+		row.DeviceID = sql.NullString{String: c.DeviceID, Valid: true}
+	}
+
+	// Metadados da leitura de GPS
+	row.locationFixRow = newLocationFixRow(c.LocationFix)
+
 	// ValidationDetails
 	if len(c.ValidationDetails) > 0 {
 		if detailsJSON, err := json.Marshal(c.ValidationDetails); err == nil {
@@ -171,6 +282,41 @@ func (repo *CheckinRepository) fromEntity(c *checkin.Checkin) *checkinRow {
 		}
 	}
 
+	// Anulação
+	if c.VoidedAt != nil {
+		row.VoidedAt = sql.NullTime{Time: *c.VoidedAt, Valid: true}
+	}
+
+	if c.VoidedBy != nil {
+		row.VoidedBy = sql.NullString{String: c.VoidedBy.String(), Valid: true}
+	}
+
+	if c.VoidReason != "" {
+		row.VoidReason = sql.NullString{String: c.VoidReason, Valid: true}
+	}
+
+	// ReplacesID
+	if c.ReplacesID != nil {
+		row.ReplacesID = sql.NullString{String: c.ReplacesID.String(), Valid: true}
+	}
+
+	// Aprovação do supervisor
+	if c.ApprovalStatus != "" {
+		row.ApprovalStatus = sql.NullString{String: c.ApprovalStatus, Valid: true}
+	}
+
+	if c.ReviewedAt != nil {
+		row.ReviewedAt = sql.NullTime{Time: *c.ReviewedAt, Valid: true}
+	}
+
+	if c.ReviewedBy != nil {
+		row.ReviewedBy = sql.NullString{String: c.ReviewedBy.String(), Valid: true}
+	}
+
+	if c.ReviewComment != "" {
+		row.ReviewComment = sql.NullString{String: c.ReviewComment, Valid: true}
+	}
+
 	// CreatedBy
 	if c.CreatedBy != nil {
 		row.CreatedBy = sql.NullString{String: c.CreatedBy.String(), Valid: true}
@@ -184,45 +330,101 @@ func (repo *CheckinRepository) fromEntity(c *checkin.Checkin) *checkinRow {
 	return row
 }
 
-// Create cria um novo checkin
+// openSessionCondition identifica check-ins (alias c) que mantêm uma sessão de trabalho aberta
+const openSessionCondition = `(c.is_valid = true OR c.approval_status = 'pending') AND c.voided_at IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM checkout co WHERE co.id_checkin = c.id_checkin AND co.voided_at IS NULL)`
+
+// Create cria um novo checkin. A gravação é serializada por funcionário e evento com um advisory lock da transação,
+// e a sessão aberta é verificada de novo sob o lock: requisições simultâneas não abrem duas sessões.
 func (repo *CheckinRepository) Create(ctx context.Context, c *checkin.Checkin) error {
 	row := repo.fromEntity(c)
+	if err := repo.sealFaceEmbedding(ctx, c, row); err != nil {
+		return err
+	}
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create checkin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2))`, row.EmployeeID, row.EventID); err != nil {
+		repo.logger.Error("Failed to lock employee session", zap.Error(err), zap.String("checkin_id", c.ID.String()))
+		return fmt.Errorf("failed to create checkin: %w", err)
+	}
+
+	var openID string
+	openQuery := `SELECT c.id_checkin FROM checkin c WHERE c.id_employee = $1 AND c.id_event = $2 AND ` + openSessionCondition + ` LIMIT 1`
+	err = tx.GetContext(ctx, &openID, openQuery, row.EmployeeID, row.EventID)
+	if err == nil {
+		return errors.NewAlreadyExistsError("Checkin", "open_session", fmt.Sprintf("%s-%s", row.EmployeeID, row.EventID)).
+			WithContext("open_checkin_id", openID)
+	}
+	if err != sql.ErrNoRows {
+		repo.logger.Error("Failed to check open session", zap.Error(err), zap.String("checkin_id", c.ID.String()))
+		return fmt.Errorf("failed to create checkin: %w", err)
+	}
 
 	query := `
 		INSERT INTO checkin (
 			id_checkin, id_tenant, id_event, id_employee, id_partner,
-			method, latitude, longitude, checkin_time, photo_url, notes,
-			is_valid, validation_details, created_at, updated_at, created_by, updated_by
+			method, latitude, longitude, checkin_time, photo_url, photo_key, face_embedding_sealed, face_embedding_digest, notes, client_id, device_id,
+			location_accuracy, location_altitude, location_fix_time, location_provider, location_mock,
+			is_valid, validation_details, voided_at, voided_by, void_reason, replaces_id, approval_status, reviewed_at, reviewed_by, review_comment, created_at, updated_at, created_by, updated_by
 		) VALUES (
 			:id_checkin, :id_tenant, :id_event, :id_employee, :id_partner,
-			:method, :latitude, :longitude, :checkin_time, :photo_url, :notes,
-			:is_valid, :validation_details, :created_at, :updated_at, :created_by, :updated_by
+			:method, :latitude, :longitude, :checkin_time, :photo_url, :photo_key, :face_embedding_sealed, :face_embedding_digest, :notes, :client_id, :device_id,
+			:location_accuracy, :location_altitude, :location_fix_time, :location_provider, :location_mock,
+			:is_valid, :validation_details, :voided_at, :voided_by, :void_reason, :replaces_id, :approval_status, :reviewed_at, :reviewed_by, :review_comment, :created_at, :updated_at, :created_by, :updated_by
 		)`
 
-	_, err := repo.db.NamedExecContext(ctx, query, row)
-	if err != nil {
+	if _, err := tx.NamedExecContext(ctx, query, row); err != nil {
 		repo.logger.Error("Failed to create checkin", zap.Error(err), zap.String("checkin_id", c.ID.String()))
 		return fmt.Errorf("failed to create checkin: %w", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to create checkin: %w", err)
+	}
+
 	repo.logger.Info("Checkin created successfully", zap.String("checkin_id", c.ID.String()))
 	return nil
 }
 
-// GetByID busca um checkin por ID
+// sealFaceEmbedding preenche na linha o embedding cifrado do check-in e o seu digest
+func (repo *CheckinRepository) sealFaceEmbedding(ctx context.Context, c *checkin.Checkin, row *checkinRow) error {
+	if len(c.FaceEmbedding) == 0 {
+		return nil
+	}
+
+	var err error
+	row.SealedEmbedding, err = repo.cipher.Seal(ctx, c.TenantID, biometric.EncodeEmbedding(c.FaceEmbedding), biometric.AssociatedData(biometric.RecordCheckin, c.ID))
+	if err == nil {
+		row.EmbeddingDigest, err = repo.cipher.Digest(ctx, c.TenantID, c.FaceEmbedding)
+	}
+	if err != nil {
+		repo.logger.Error("Failed to seal checkin face embedding", zap.Error(err), zap.String("checkin_id", c.ID.String()))
+		return fmt.Errorf("failed to seal checkin face embedding: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID busca um checkin por ID. É a única leitura que traz o embedding, ainda cifrado.
 func (repo *CheckinRepository) GetByID(ctx context.Context, id value_objects.UUID) (*checkin.Checkin, error) {
 	var row checkinRow
 	query := `
 		SELECT id_checkin, id_tenant, id_event, id_employee, id_partner,
-			   method, latitude, longitude, checkin_time, photo_url, notes,
-			   is_valid, validation_details, created_at, updated_at, created_by, updated_by
+			   method, latitude, longitude, checkin_time, photo_url, photo_key, face_embedding_sealed, notes, client_id, device_id,
+			   location_accuracy, location_altitude, location_fix_time, location_provider, location_mock,
+			   is_valid, validation_details, voided_at, voided_by, void_reason, replaces_id, approval_status, reviewed_at, reviewed_by, review_comment, created_at, updated_at, created_by, updated_by
 		FROM checkin 
 		WHERE id_checkin = $1`
 
 	err := repo.db.GetContext(ctx, &row, query, id.String())
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("checkin not found")
+			return nil, fmt.Errorf("checkin not found: %w", errors.ErrNotFound)
 		}
 		repo.logger.Error("Failed to get checkin by ID", zap.Error(err), zap.String("checkin_id", id.String()))
 		return nil, fmt.Errorf("failed to get checkin: %w", err)
@@ -231,6 +433,50 @@ func (repo *CheckinRepository) GetByID(ctx context.Context, id value_objects.UUI
 	return row.toEntity()
 }
 
+// OpenFaceEmbedding decifra o embedding gravado no check-in
+func (repo *CheckinRepository) OpenFaceEmbedding(ctx context.Context, c *checkin.Checkin) ([]float32, error) {
+	if len(c.SealedEmbedding) == 0 {
+		return nil, nil
+	}
+
+	plaintext, err := repo.cipher.Open(ctx, c.TenantID, c.SealedEmbedding, biometric.AssociatedData(biometric.RecordCheckin, c.ID))
+	if err != nil {
+		repo.logger.Error("Failed to open checkin face embedding", zap.Error(err), zap.String("checkin_id", c.ID.String()))
+		return nil, fmt.Errorf("failed to open checkin face embedding: %w", err)
+	}
+
+	embedding, err := biometric.DecodeEmbedding(plaintext)
+	if err != nil {
+		repo.logger.Error("Failed to decode checkin face embedding", zap.Error(err), zap.String("checkin_id", c.ID.String()))
+		return nil, fmt.Errorf("failed to decode checkin face embedding: %w", err)
+	}
+
+	return embedding, nil
+}
+
+// GetByClientID busca um checkin pelo identificador gerado no dispositivo
+func (repo *CheckinRepository) GetByClientID(ctx context.Context, clientID value_objects.UUID) (*checkin.Checkin, error) {
+	var row checkinRow
+	query := `
+		SELECT id_checkin, id_tenant, id_event, id_employee, id_partner,
+			   method, latitude, longitude, checkin_time, photo_url, photo_key, notes, client_id, device_id,
+			   location_accuracy, location_altitude, location_fix_time, location_provider, location_mock,
+			   is_valid, validation_details, voided_at, voided_by, void_reason, replaces_id, approval_status, reviewed_at, reviewed_by, review_comment, created_at, updated_at, created_by, updated_by
+		FROM checkin 
+		WHERE client_id = $1`
+
+	err := repo.db.GetContext(ctx, &row, query, clientID.String())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("checkin not found: %w", errors.ErrNotFound)
+		}
+		repo.logger.Error("Failed to get checkin by client ID", zap.Error(err), zap.String("client_id", clientID.String()))
+		return nil, fmt.Errorf("failed to get checkin: %w", err)
+	}
+
+	return row.toEntity()
+}
+
 // Update atualiza um checkin existente
 func (repo *CheckinRepository) Update(ctx context.Context, c *checkin.Checkin) error {
 	row := repo.fromEntity(c)
@@ -238,9 +484,17 @@ func (repo *CheckinRepository) Update(ctx context.Context, c *checkin.Checkin) e
 	query := `
 		UPDATE checkin SET
 			photo_url = :photo_url,
+			photo_key = :photo_key,
 			notes = :notes,
 			is_valid = :is_valid,
 			validation_details = :validation_details,
+			voided_at = :voided_at,
+			voided_by = :voided_by,
+			void_reason = :void_reason,
+			approval_status = :approval_status,
+			reviewed_at = :reviewed_at,
+			reviewed_by = :reviewed_by,
+			review_comment = :review_comment,
 			updated_at = :updated_at,
 			updated_by = :updated_by
 		WHERE id_checkin = :id_checkin`
@@ -257,7 +511,7 @@ func (repo *CheckinRepository) Update(ctx context.Context, c *checkin.Checkin) e
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("checkin not found")
+		return fmt.Errorf("checkin not found: %w", errors.ErrNotFound)
 	}
 
 	repo.logger.Info("Checkin updated successfully", zap.String("checkin_id", c.ID.String()))
@@ -285,7 +539,7 @@ func (repo *CheckinRepository) Delete(ctx context.Context, id value_objects.UUID
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("checkin not found")
+		return fmt.Errorf("checkin not found: %w", errors.ErrNotFound)
 	}
 
 	repo.logger.Info("Checkin deleted successfully", zap.String("checkin_id", id.String()))
@@ -340,11 +594,25 @@ func (repo *CheckinRepository) List(ctx context.Context, filters checkin.ListFil
 		args = append(args, *filters.IsValid)
 	}
 
+	if filters.HasVoidedFilter() {
+		if *filters.Voided {
+			conditions = append(conditions, "c.voided_at IS NOT NULL")
+		} else {
+			conditions = append(conditions, "c.voided_at IS NULL")
+		}
+	}
+
+	if filters.HasApprovalStatusFilter() {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("c.approval_status = $%d", argCount))
+		args = append(args, *filters.ApprovalStatus)
+	}
+
 	if filters.HasPhotoFilter() {
 		if *filters.HasPhoto {
-			conditions = append(conditions, "c.photo_url IS NOT NULL AND c.photo_url != ''")
+			conditions = append(conditions, "((c.photo_url IS NOT NULL AND c.photo_url != '') OR (c.photo_key IS NOT NULL AND c.photo_key != ''))")
 		} else {
-			conditions = append(conditions, "(c.photo_url IS NULL OR c.photo_url = '')")
+			conditions = append(conditions, "(c.photo_url IS NULL OR c.photo_url = '') AND (c.photo_key IS NULL OR c.photo_key = '')")
 		}
 	}
 
@@ -385,8 +653,9 @@ func (repo *CheckinRepository) List(ctx context.Context, filters checkin.ListFil
 	// Query para buscar dados com paginação
 	selectQuery := `
 		SELECT c.id_checkin, c.id_tenant, c.id_event, c.id_employee, c.id_partner,
-			   c.method, c.latitude, c.longitude, c.checkin_time, c.photo_url, c.notes,
-			   c.is_valid, c.validation_details, c.created_at, c.updated_at, c.created_by, c.updated_by ` + baseQuery
+			   c.method, c.latitude, c.longitude, c.checkin_time, c.photo_url, c.photo_key, c.notes, c.client_id, c.device_id,
+			   c.location_accuracy, c.location_altitude, c.location_fix_time, c.location_provider, c.location_mock,
+			   c.is_valid, c.validation_details, c.voided_at, c.voided_by, c.void_reason, c.replaces_id, c.approval_status, c.reviewed_at, c.reviewed_by, c.review_comment, c.created_at, c.updated_at, c.created_by, c.updated_by ` + baseQuery
 
 	// Adicionar ordenação
 	orderDirection := "ASC"
@@ -448,17 +717,18 @@ func (repo *CheckinRepository) GetByEmployeeAndEvent(ctx context.Context, employ
 	var row checkinRow
 	query := `
 		SELECT id_checkin, id_tenant, id_event, id_employee, id_partner,
-			   method, latitude, longitude, checkin_time, photo_url, notes,
-			   is_valid, validation_details, created_at, updated_at, created_by, updated_by
+			   method, latitude, longitude, checkin_time, photo_url, photo_key, notes, client_id, device_id,
+			   location_accuracy, location_altitude, location_fix_time, location_provider, location_mock,
+			   is_valid, validation_details, voided_at, voided_by, void_reason, replaces_id, approval_status, reviewed_at, reviewed_by, review_comment, created_at, updated_at, created_by, updated_by
 		FROM checkin 
-		WHERE id_employee = $1 AND id_event = $2
+		WHERE id_employee = $1 AND id_event = $2 AND voided_at IS NULL
 		ORDER BY checkin_time DESC
 		LIMIT 1`
 
 	err := repo.db.GetContext(ctx, &row, query, employeeID.String(), eventID.String())
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("checkin not found")
+			return nil, fmt.Errorf("checkin not found: %w", errors.ErrNotFound)
 		}
 		repo.logger.Error("Failed to get checkin by employee and event", zap.Error(err))
 		return nil, fmt.Errorf("failed to get checkin: %w", err)
@@ -473,7 +743,7 @@ func (repo *CheckinRepository) ExistsByEmployeeAndEvent(ctx context.Context, emp
 	query := `
 		SELECT COUNT(*)
 		FROM checkin 
-		WHERE id_employee = $1 AND id_event = $2`
+		WHERE id_employee = $1 AND id_event = $2 AND voided_at IS NULL`
 
 	err := repo.db.GetContext(ctx, &count, query, employeeID.String(), eventID.String())
 	if err != nil {
@@ -484,6 +754,99 @@ func (repo *CheckinRepository) ExistsByEmployeeAndEvent(ctx context.Context, emp
 	return count > 0, nil
 }
 
+// HasActiveCheckout verifica se o checkin possui checkout não anulado
+func (repo *CheckinRepository) HasActiveCheckout(ctx context.Context, checkinID value_objects.UUID) (bool, error) {
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM checkout
+			WHERE id_checkin = $1 AND voided_at IS NULL
+		)`
+
+	err := repo.db.GetContext(ctx, &exists, query, checkinID.String())
+	if err != nil {
+		repo.logger.Error("Failed to check active checkout", zap.Error(err), zap.String("checkin_id", checkinID.String()))
+		return false, fmt.Errorf("failed to check active checkout: %w", err)
+	}
+
+	return exists, nil
+}
+
+// ExistsReplacement verifica se o checkin anulado já foi substituído
+func (repo *CheckinRepository) ExistsReplacement(ctx context.Context, voidedID value_objects.UUID) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM checkin WHERE replaces_id = $1)`
+
+	err := repo.db.GetContext(ctx, &exists, query, voidedID.String())
+	if err != nil {
+		repo.logger.Error("Failed to check checkin replacement", zap.Error(err), zap.String("checkin_id", voidedID.String()))
+		return false, fmt.Errorf("failed to check checkin replacement: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetOpenByEmployeeAndEvent busca a sessão aberta (checkin válido ou aguardando aprovação, sem checkout) do funcionário no evento
+func (repo *CheckinRepository) GetOpenByEmployeeAndEvent(ctx context.Context, employeeID, eventID value_objects.UUID) (*checkin.Checkin, error) {
+	var row checkinRow
+	query := `
+		SELECT id_checkin, id_tenant, id_event, id_employee, id_partner,
+			   method, latitude, longitude, checkin_time, photo_url, photo_key, notes, client_id, device_id,
+			   location_accuracy, location_altitude, location_fix_time, location_provider, location_mock,
+			   is_valid, validation_details, voided_at, voided_by, void_reason, replaces_id, approval_status, reviewed_at, reviewed_by, review_comment, created_at, updated_at, created_by, updated_by
+		FROM checkin c
+		WHERE c.id_employee = $1 AND c.id_event = $2 AND ` + openSessionCondition + `
+		ORDER BY c.checkin_time DESC
+		LIMIT 1`
+
+	err := repo.db.GetContext(ctx, &row, query, employeeID.String(), eventID.String())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("open checkin not found: %w", errors.ErrNotFound)
+		}
+		repo.logger.Error("Failed to get open checkin by employee and event", zap.Error(err))
+		return nil, fmt.Errorf("failed to get open checkin: %w", err)
+	}
+
+	return row.toEntity()
+}
+
+// ListStaleOpenSessions lista sessões abertas de eventos encerrados ou que excederam a duração máxima da sessão
+func (repo *CheckinRepository) ListStaleOpenSessions(ctx context.Context, now time.Time, limit int) ([]*checkin.Checkin, error) {
+	query := `
+		SELECT c.id_checkin, c.id_tenant, c.id_event, c.id_employee, c.id_partner,
+			   c.method, c.latitude, c.longitude, c.checkin_time, c.photo_url, c.photo_key, c.notes, c.client_id, c.device_id,
+			   c.location_accuracy, c.location_altitude, c.location_fix_time, c.location_provider, c.location_mock,
+			   c.is_valid, c.validation_details, c.voided_at, c.voided_by, c.void_reason, c.replaces_id, c.approval_status, c.reviewed_at, c.reviewed_by, c.review_comment, c.created_at, c.updated_at, c.created_by, c.updated_by
+		FROM checkin c
+		JOIN events e ON e.id = c.id_event
+		WHERE (c.is_valid = true OR c.approval_status = 'pending') AND c.voided_at IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM checkout co WHERE co.id_checkin = c.id_checkin AND co.voided_at IS NULL)
+		  AND (e.final_date <= $1
+			   OR (e.max_session_hours > 0 AND c.checkin_time + e.max_session_hours * INTERVAL '1 hour' <= $1))
+		ORDER BY c.checkin_time ASC
+		LIMIT $2`
+
+	var rows []checkinRow
+	err := repo.db.SelectContext(ctx, &rows, query, now, limit)
+	if err != nil {
+		repo.logger.Error("Failed to list stale open checkins", zap.Error(err))
+		return nil, fmt.Errorf("failed to list stale open checkins: %w", err)
+	}
+
+	checkins := make([]*checkin.Checkin, len(rows))
+	for i, row := range rows {
+		c, err := row.toEntity()
+		if err != nil {
+			repo.logger.Error("Failed to convert checkin row to entity", zap.Error(err))
+			return nil, fmt.Errorf("failed to convert checkin: %w", err)
+		}
+		checkins[i] = c
+	}
+
+	return checkins, nil
+}
+
 // GetByDateRange busca checkins em um período
 func (repo *CheckinRepository) GetByDateRange(ctx context.Context, tenantID value_objects.UUID, startDate, endDate time.Time, filters checkin.ListFilters) ([]*checkin.Checkin, int, error) {
 	filters.TenantID = &tenantID
@@ -503,7 +866,9 @@ func (repo *CheckinRepository) GetByMethod(ctx context.Context, tenantID value_o
 func (repo *CheckinRepository) GetValidCheckins(ctx context.Context, tenantID value_objects.UUID, filters checkin.ListFilters) ([]*checkin.Checkin, int, error) {
 	filters.TenantID = &tenantID
 	valid := true
+	voided := false
 	filters.IsValid = &valid
+	filters.Voided = &voided
 	return repo.List(ctx, filters)
 }
 
@@ -521,7 +886,7 @@ func (repo *CheckinRepository) CountByTenant(ctx context.Context, tenantID value
 	query := `
 		SELECT COUNT(*)
 		FROM checkin 
-		WHERE id_tenant = $1`
+		WHERE id_tenant = $1 AND voided_at IS NULL`
 
 	err := repo.db.GetContext(ctx, &count, query, tenantID.String())
 	if err != nil {
@@ -538,7 +903,7 @@ func (repo *CheckinRepository) CountByEvent(ctx context.Context, eventID value_o
 	query := `
 		SELECT COUNT(*)
 		FROM checkin 
-		WHERE id_event = $1`
+		WHERE id_event = $1 AND voided_at IS NULL`
 
 	err := repo.db.GetContext(ctx, &count, query, eventID.String())
 	if err != nil {
@@ -555,7 +920,7 @@ func (repo *CheckinRepository) CountByEmployee(ctx context.Context, employeeID v
 	query := `
 		SELECT COUNT(*)
 		FROM checkin 
-		WHERE id_employee = $1`
+		WHERE id_employee = $1 AND voided_at IS NULL`
 
 	err := repo.db.GetContext(ctx, &count, query, employeeID.String())
 	if err != nil {
@@ -570,8 +935,9 @@ func (repo *CheckinRepository) CountByEmployee(ctx context.Context, employeeID v
 func (repo *CheckinRepository) GetRecentCheckins(ctx context.Context, tenantID value_objects.UUID, limit int) ([]*checkin.Checkin, error) {
 	query := `
 		SELECT id_checkin, id_tenant, id_event, id_employee, id_partner,
-			   method, latitude, longitude, checkin_time, photo_url, notes,
-			   is_valid, validation_details, created_at, updated_at, created_by, updated_by
+			   method, latitude, longitude, checkin_time, photo_url, photo_key, notes, client_id, device_id,
+			   location_accuracy, location_altitude, location_fix_time, location_provider, location_mock,
+			   is_valid, validation_details, voided_at, voided_by, void_reason, replaces_id, approval_status, reviewed_at, reviewed_by, review_comment, created_at, updated_at, created_by, updated_by
 		FROM checkin 
 		WHERE id_tenant = $1 AND checkin_time >= NOW() - INTERVAL '24 hours'
 		ORDER BY checkin_time DESC
@@ -655,8 +1021,9 @@ func (repo *CheckinRepository) GetCheckinsByLocation(ctx context.Context, tenant
 	// Query para buscar dados com paginação
 	selectQuery := `
 		SELECT c.id_checkin, c.id_tenant, c.id_event, c.id_employee, c.id_partner,
-			   c.method, c.latitude, c.longitude, c.checkin_time, c.photo_url, c.notes,
-			   c.is_valid, c.validation_details, c.created_at, c.updated_at, c.created_by, c.updated_by,
+			   c.method, c.latitude, c.longitude, c.checkin_time, c.photo_url, c.photo_key, c.notes, c.client_id, c.device_id,
+			   c.location_accuracy, c.location_altitude, c.location_fix_time, c.location_provider, c.location_mock,
+			   c.is_valid, c.validation_details, c.voided_at, c.voided_by, c.void_reason, c.replaces_id, c.approval_status, c.reviewed_at, c.reviewed_by, c.review_comment, c.created_at, c.updated_at, c.created_by, c.updated_by,
 			   ST_Distance(
 				   ST_GeogFromText('POINT(' || c.longitude || ' ' || c.latitude || ')'),
 				   ST_GeogFromText('POINT($3 $2)')
@@ -0,0 +1,384 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"eventos-backend/internal/domain/checkin"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/infrastructure/cache"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const (
+	// statsCacheName é o cache utilizado para estatísticas agregadas
+	statsCacheName = "default"
+
+	// statsCacheTTL mantém as estatísticas por pouco tempo; o consumer de check-ins e InvalidateSessionStats invalidam as do tenant
+	statsCacheTTL = constants.CacheTTLShort * time.Second
+)
+
+// sessionStatsTypes são as estatísticas que mudam quando uma sessão é anulada, revisada ou corrigida
+var sessionStatsTypes = []string{"checkin", "checkout", "work"}
+
+// CheckinStatsRepository implementa checkin.StatsRepository com agregações no Postgres
+type CheckinStatsRepository struct {
+	db           *sqlx.DB
+	cacheService *cache.CacheService
+	logger       *zap.Logger
+}
+
+// NewCheckinStatsRepository cria uma nova instância do repositório de estatísticas de check-in.
+// Sem cacheService as estatísticas são sempre calculadas no banco.
+func NewCheckinStatsRepository(db *sqlx.DB, cacheService *cache.CacheService, logger *zap.Logger) checkin.StatsRepository {
+	return &CheckinStatsRepository{
+		db:           db,
+		cacheService: cacheService,
+		logger:       logger,
+	}
+}
+
+// checkinStatsRow representa o resultado da agregação de check-ins
+type checkinStatsRow struct {
+	TotalCheckins     int          `db:"total_checkins"`
+	ValidCheckins     int          `db:"valid_checkins"`
+	InvalidCheckins   int          `db:"invalid_checkins"`
+	PendingCheckins   int          `db:"pending_checkins"`
+	FacialCheckins    int          `db:"facial_checkins"`
+	QRCodeCheckins    int          `db:"qr_code_checkins"`
+	ManualCheckins    int          `db:"manual_checkins"`
+	CheckinsToday     int          `db:"checkins_today"`
+	CheckinsThisWeek  int          `db:"checkins_this_week"`
+	CheckinsThisMonth int          `db:"checkins_this_month"`
+	UniqueEmployees   int          `db:"unique_employees"`
+	OpenSessions      int          `db:"open_sessions"`
+	FirstCheckinTime  sql.NullTime `db:"first_checkin_time"`
+	LastCheckinTime   sql.NullTime `db:"last_checkin_time"`
+}
+
+// toEntity converte o resultado da agregação para estatísticas de domínio.
+// A média diária considera o período informado ou, sem período, os dias entre o primeiro e o último check-in.
+func (r *checkinStatsRow) toEntity(period *statsPeriod) *checkin.CheckinStats {
+	stats := &checkin.CheckinStats{
+		TotalCheckins:     r.TotalCheckins,
+		ValidCheckins:     r.ValidCheckins,
+		InvalidCheckins:   r.InvalidCheckins,
+		PendingCheckins:   r.PendingCheckins,
+		FacialCheckins:    r.FacialCheckins,
+		QRCodeCheckins:    r.QRCodeCheckins,
+		ManualCheckins:    r.ManualCheckins,
+		CheckinsToday:     r.CheckinsToday,
+		CheckinsThisWeek:  r.CheckinsThisWeek,
+		CheckinsThisMonth: r.CheckinsThisMonth,
+		UniqueEmployees:   r.UniqueEmployees,
+		OpenSessions:      r.OpenSessions,
+	}
+
+	if r.FirstCheckinTime.Valid {
+		firstCheckin := r.FirstCheckinTime.Time
+		stats.FirstCheckinTime = &firstCheckin
+	}
+
+	if r.LastCheckinTime.Valid {
+		lastCheckin := r.LastCheckinTime.Time
+		stats.LastCheckinTime = &lastCheckin
+	}
+
+	switch {
+	case period != nil:
+		stats.AveragePerDay = float64(r.TotalCheckins) / period.days()
+	case r.FirstCheckinTime.Valid && r.LastCheckinTime.Valid:
+		stats.AveragePerDay = float64(r.TotalCheckins) / daysBetween(r.FirstCheckinTime.Time, r.LastCheckinTime.Time)
+	}
+
+	return stats
+}
+
+// GetTenantStats obtém estatísticas de um tenant
+func (repo *CheckinStatsRepository) GetTenantStats(ctx context.Context, tenantID value_objects.UUID) (*checkin.CheckinStats, error) {
+	return repo.cachedStats(ctx, tenantID.String(), []string{"tenant"}, "c.id_tenant = $1", tenantID, nil)
+}
+
+// GetEventStats obtém estatísticas de um evento
+func (repo *CheckinStatsRepository) GetEventStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*checkin.CheckinStats, error) {
+	return repo.cachedStats(ctx, tenantID.String(), []string{"event", eventID.String()}, "c.id_event = $1", eventID, nil)
+}
+
+// GetEmployeeStats obtém estatísticas de um funcionário
+func (repo *CheckinStatsRepository) GetEmployeeStats(ctx context.Context, tenantID, employeeID value_objects.UUID) (*checkin.CheckinStats, error) {
+	return repo.cachedStats(ctx, tenantID.String(), []string{"employee", employeeID.String()}, "c.id_employee = $1", employeeID, nil)
+}
+
+// GetPartnerStats obtém estatísticas de um parceiro
+func (repo *CheckinStatsRepository) GetPartnerStats(ctx context.Context, tenantID, partnerID value_objects.UUID) (*checkin.CheckinStats, error) {
+	return repo.cachedStats(ctx, tenantID.String(), []string{"partner", partnerID.String()}, "c.id_partner = $1", partnerID, nil)
+}
+
+// GetDailyStats obtém estatísticas diárias
+func (repo *CheckinStatsRepository) GetDailyStats(ctx context.Context, tenantID value_objects.UUID, date time.Time) (*checkin.CheckinStats, error) {
+	period := dailyPeriod(date)
+	return repo.cachedStats(ctx, tenantID.String(), period.cacheSuffix("daily"), "c.id_tenant = $1", tenantID, period)
+}
+
+// GetWeeklyStats obtém estatísticas semanais
+func (repo *CheckinStatsRepository) GetWeeklyStats(ctx context.Context, tenantID value_objects.UUID, startDate time.Time) (*checkin.CheckinStats, error) {
+	period := weeklyPeriod(startDate)
+	return repo.cachedStats(ctx, tenantID.String(), period.cacheSuffix("weekly"), "c.id_tenant = $1", tenantID, period)
+}
+
+// GetMonthlyStats obtém estatísticas mensais
+func (repo *CheckinStatsRepository) GetMonthlyStats(ctx context.Context, tenantID value_objects.UUID, year int, month time.Month) (*checkin.CheckinStats, error) {
+	period := monthlyPeriod(year, month)
+	return repo.cachedStats(ctx, tenantID.String(), period.cacheSuffix("monthly"), "c.id_tenant = $1", tenantID, period)
+}
+
+// cachedStats busca as estatísticas no cache e, na ausência, agrega no banco e armazena o resultado
+func (repo *CheckinStatsRepository) cachedStats(ctx context.Context, tenantID string, keySuffix []string, scope string, scopeID value_objects.UUID, period *statsPeriod) (*checkin.CheckinStats, error) {
+	if repo.cacheService != nil {
+		var cached checkin.CheckinStats
+		if err := repo.cacheService.GetStats(ctx, statsCacheName, tenantID, "checkin", &cached, keySuffix...); err == nil {
+			return &cached, nil
+		}
+	}
+
+	stats, err := repo.aggregate(ctx, scope, scopeID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.cacheService != nil {
+		if err := repo.cacheService.CacheStats(ctx, statsCacheName, tenantID, "checkin", stats, statsCacheTTL, keySuffix...); err != nil {
+			repo.logger.Warn("Failed to cache checkin stats", zap.Strings("key", keySuffix), zap.Error(err))
+		}
+	}
+
+	return stats, nil
+}
+
+// aggregate calcula as estatísticas em uma única consulta.
+// Check-ins anulados são ignorados; pendentes de aprovação não contam como válidos nem inválidos.
+func (repo *CheckinStatsRepository) aggregate(ctx context.Context, scope string, scopeID value_objects.UUID, period *statsPeriod) (*checkin.CheckinStats, error) {
+	today, week, month := periodStarts(time.Now().UTC())
+
+	query := `
+		SELECT COUNT(*) AS total_checkins,
+			   COUNT(*) FILTER (WHERE c.is_valid) AS valid_checkins,
+			   COUNT(*) FILTER (WHERE NOT c.is_valid AND c.approval_status IS DISTINCT FROM 'pending') AS invalid_checkins,
+			   COUNT(*) FILTER (WHERE c.approval_status = 'pending') AS pending_checkins,
+			   COUNT(*) FILTER (WHERE c.method = $2) AS facial_checkins,
+			   COUNT(*) FILTER (WHERE c.method = $3) AS qr_code_checkins,
+			   COUNT(*) FILTER (WHERE c.method = $4) AS manual_checkins,
+			   COUNT(*) FILTER (WHERE c.checkin_time >= $5) AS checkins_today,
+			   COUNT(*) FILTER (WHERE c.checkin_time >= $6) AS checkins_this_week,
+			   COUNT(*) FILTER (WHERE c.checkin_time >= $7) AS checkins_this_month,
+			   COUNT(DISTINCT c.id_employee) AS unique_employees,
+			   COUNT(*) FILTER (WHERE (c.is_valid OR c.approval_status = 'pending')
+			       AND NOT EXISTS (SELECT 1 FROM checkout co WHERE co.id_checkin = c.id_checkin AND co.voided_at IS NULL)) AS open_sessions,
+			   MIN(c.checkin_time) AS first_checkin_time,
+			   MAX(c.checkin_time) AS last_checkin_time
+		FROM checkin c
+		WHERE c.voided_at IS NULL AND ` + scope
+
+	args := []interface{}{
+		scopeID.String(),
+		constants.CheckMethodFacialRecognition,
+		constants.CheckMethodQRCode,
+		constants.CheckMethodManual,
+		today, week, month,
+	}
+
+	if period != nil {
+		query += " AND c.checkin_time >= $8 AND c.checkin_time < $9"
+		args = append(args, period.start, period.end)
+	}
+
+	var row checkinStatsRow
+	if err := repo.db.GetContext(ctx, &row, query, args...); err != nil {
+		repo.logger.Error("Failed to aggregate checkin stats", zap.Error(err), zap.String("scope", scope))
+		return nil, fmt.Errorf("failed to aggregate checkin stats: %w", err)
+	}
+
+	return row.toEntity(period), nil
+}
+
+// partnerStatsRow representa a agregação de check-ins de um parceiro
+type partnerStatsRow struct {
+	PartnerID        string `db:"id_partner"`
+	TotalCheckins    int    `db:"total_checkins"`
+	ValidCheckins    int    `db:"valid_checkins"`
+	InvalidCheckins  int    `db:"invalid_checkins"`
+	PendingCheckins  int    `db:"pending_checkins"`
+	UniqueEmployees  int    `db:"unique_employees"`
+	OpenSessions     int    `db:"open_sessions"`
+	TotalWorkSeconds int64  `db:"total_work_seconds"`
+}
+
+// toEntity converte o resultado da agregação para estatísticas de domínio
+func (r *partnerStatsRow) toEntity() (*checkin.PartnerCheckinStats, error) {
+	partnerID, err := value_objects.ParseUUID(r.PartnerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid partner ID: %w", err)
+	}
+
+	return &checkin.PartnerCheckinStats{
+		PartnerID:       partnerID,
+		TotalCheckins:   r.TotalCheckins,
+		ValidCheckins:   r.ValidCheckins,
+		InvalidCheckins: r.InvalidCheckins,
+		PendingCheckins: r.PendingCheckins,
+		UniqueEmployees: r.UniqueEmployees,
+		OpenSessions:    r.OpenSessions,
+		TotalWorkHours:  float64(r.TotalWorkSeconds) / 3600,
+	}, nil
+}
+
+// GetEventPartnerStats obtém as estatísticas de um evento agrupadas por parceiro.
+// As horas consideram apenas sessões encerradas cujo check-in não aguarda aprovação.
+func (repo *CheckinStatsRepository) GetEventPartnerStats(ctx context.Context, tenantID, eventID value_objects.UUID) ([]*checkin.PartnerCheckinStats, error) {
+	rows, err := repo.cachedPartnerRows(ctx, tenantID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]*checkin.PartnerCheckinStats, 0, len(rows))
+	for i := range rows {
+		partnerStats, err := rows[i].toEntity()
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, partnerStats)
+	}
+
+	return stats, nil
+}
+
+// cachedPartnerRows busca a agregação por parceiro no cache e, na ausência, consulta o banco.
+// As linhas são armazenadas no lugar das entidades porque value_objects.UUID não é serializável em JSON.
+func (repo *CheckinStatsRepository) cachedPartnerRows(ctx context.Context, tenantID, eventID value_objects.UUID) ([]partnerStatsRow, error) {
+	keySuffix := []string{"event", eventID.String(), "partners"}
+
+	if repo.cacheService != nil {
+		var cached []partnerStatsRow
+		if err := repo.cacheService.GetStats(ctx, statsCacheName, tenantID.String(), "checkin", &cached, keySuffix...); err == nil {
+			return cached, nil
+		}
+	}
+
+	query := `
+		SELECT c.id_partner,
+			   COUNT(*) AS total_checkins,
+			   COUNT(*) FILTER (WHERE c.is_valid) AS valid_checkins,
+			   COUNT(*) FILTER (WHERE NOT c.is_valid AND c.approval_status IS DISTINCT FROM 'pending') AS invalid_checkins,
+			   COUNT(*) FILTER (WHERE c.approval_status = 'pending') AS pending_checkins,
+			   COUNT(DISTINCT c.id_employee) AS unique_employees,
+			   COUNT(*) FILTER (WHERE (c.is_valid OR c.approval_status = 'pending') AND co.id_checkout IS NULL) AS open_sessions,
+			   COALESCE(SUM(co.work_duration_seconds) FILTER (WHERE COALESCE(c.approval_status, '') NOT IN ('pending', 'rejected')), 0) AS total_work_seconds
+		FROM checkin c
+		LEFT JOIN checkout co ON co.id_checkin = c.id_checkin AND co.voided_at IS NULL
+		WHERE c.voided_at IS NULL AND c.id_event = $1
+		GROUP BY c.id_partner
+		ORDER BY total_checkins DESC`
+
+	var rows []partnerStatsRow
+	if err := repo.db.SelectContext(ctx, &rows, query, eventID.String()); err != nil {
+		repo.logger.Error("Failed to aggregate partner stats", zap.Error(err), zap.String("event_id", eventID.String()))
+		return nil, fmt.Errorf("failed to aggregate partner stats: %w", err)
+	}
+
+	if repo.cacheService != nil {
+		if err := repo.cacheService.CacheStats(ctx, statsCacheName, tenantID.String(), "checkin", rows, statsCacheTTL, keySuffix...); err != nil {
+			repo.logger.Warn("Failed to cache partner stats", zap.Strings("key", keySuffix), zap.Error(err))
+		}
+	}
+
+	return rows, nil
+}
+
+// InvalidateSessionStats descarta as estatísticas em cache do tenant, do evento, do funcionário e do parceiro da sessão
+func (repo *CheckinStatsRepository) InvalidateSessionStats(ctx context.Context, tenantID, eventID, employeeID, partnerID value_objects.UUID) {
+	invalidateSessionStats(ctx, repo.cacheService, repo.logger, tenantID, eventID, employeeID, partnerID)
+}
+
+// invalidateSessionStats remove as chaves de estatísticas de check-in, check-out e trabalho afetadas por uma sessão.
+// As estatísticas diárias, semanais e mensais expiram pelo TTL curto. Falhas apenas são registradas.
+func invalidateSessionStats(ctx context.Context, cacheService *cache.CacheService, logger *zap.Logger, tenantID, eventID, employeeID, partnerID value_objects.UUID) {
+	if cacheService == nil {
+		return
+	}
+
+	suffixes := [][]string{
+		{"tenant"},
+		{"event", eventID.String()},
+		{"event", eventID.String(), "partners"},
+		{"employee", employeeID.String()},
+		{"partner", partnerID.String()},
+	}
+
+	for _, statsType := range sessionStatsTypes {
+		for _, suffix := range suffixes {
+			if err := cacheService.InvalidateStats(ctx, statsCacheName, tenantID.String(), statsType, suffix...); err != nil {
+				logger.Warn("Failed to invalidate session stats", zap.String("type", statsType), zap.Strings("key", suffix), zap.Error(err))
+			}
+		}
+	}
+}
+
+// statsPeriod representa o intervalo [start, end) das estatísticas diárias, semanais e mensais
+type statsPeriod struct {
+	start time.Time
+	end   time.Time
+}
+
+// dailyPeriod retorna o dia (UTC) que contém a data
+func dailyPeriod(date time.Time) *statsPeriod {
+	start := truncateToDay(date)
+	return &statsPeriod{start: start, end: start.AddDate(0, 0, 1)}
+}
+
+// weeklyPeriod retorna os sete dias a partir da data inicial
+func weeklyPeriod(startDate time.Time) *statsPeriod {
+	start := truncateToDay(startDate)
+	return &statsPeriod{start: start, end: start.AddDate(0, 0, 7)}
+}
+
+// monthlyPeriod retorna o mês (UTC) informado
+func monthlyPeriod(year int, month time.Month) *statsPeriod {
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return &statsPeriod{start: start, end: start.AddDate(0, 1, 0)}
+}
+
+// days retorna a quantidade de dias do período
+func (p *statsPeriod) days() float64 {
+	return math.Max(1, p.end.Sub(p.start).Hours()/24)
+}
+
+// cacheSuffix retorna o sufixo da chave de cache do período
+func (p *statsPeriod) cacheSuffix(kind string) []string {
+	return []string{kind, p.start.Format("2006-01-02")}
+}
+
+// periodStarts retorna o início do dia, da semana (segunda-feira) e do mês correntes
+func periodStarts(now time.Time) (time.Time, time.Time, time.Time) {
+	today := truncateToDay(now)
+	weekday := (int(today.Weekday()) + 6) % 7 // segunda-feira = 0
+	week := today.AddDate(0, 0, -weekday)
+	month := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return today, week, month
+}
+
+// truncateToDay retorna a meia-noite (UTC) da data
+func truncateToDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween retorna a quantidade de dias corridos entre duas datas, incluindo ambas
+func daysBetween(first, last time.Time) float64 {
+	return truncateToDay(last).Sub(truncateToDay(first)).Hours()/24 + 1
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"eventos-backend/internal/domain/checkout"
+	"eventos-backend/internal/domain/shared/errors"
 	"eventos-backend/internal/domain/shared/value_objects"
 
 	"github.com/jmoiron/sqlx"
@@ -43,28 +44,38 @@ type checkoutRow struct {
 	CheckoutTime      time.Time      `db:"checkout_time"`
 	PhotoURL          sql.NullString `db:"photo_url"`
 	Notes             sql.NullString `db:"notes"`
+	ClientID          sql.NullString `db:"client_id"`
+	DeviceID          sql.NullString `db:"device_id"`
 	WorkDurationSecs  int64          `db:"work_duration_seconds"`
 	IsValid           bool           `db:"is_valid"`
+	AutoClosed        bool           `db:"auto_closed"`
 	ValidationDetails sql.NullString `db:"validation_details"`
+	VoidedAt          sql.NullTime   `db:"voided_at"`
+	VoidedBy          sql.NullString `db:"voided_by"`
+	VoidReason        sql.NullString `db:"void_reason"`
+	ReplacesID        sql.NullString `db:"replaces_id"`
 	CreatedAt         time.Time      `db:"created_at"`
 	UpdatedAt         time.Time      `db:"updated_at"`
 	CreatedBy         sql.NullString `db:"created_by"`
 	UpdatedBy         sql.NullString `db:"updated_by"`
+
+	// Metadados da leitura de GPS
+	locationFixRow
 }
 
-// workSessionRow representa uma sessão de trabalho completa (checkin + checkout)
+// workSessionRow representa uma sessão de trabalho (checkin + checkout opcional)
 type workSessionRow struct {
-	CheckinID        string    `db:"checkin_id"`
-	CheckoutID       string    `db:"checkout_id"`
-	TenantID         string    `db:"id_tenant"`
-	EventID          string    `db:"id_event"`
-	EmployeeID       string    `db:"id_employee"`
-	PartnerID        string    `db:"id_partner"`
-	CheckinTime      time.Time `db:"checkin_time"`
-	CheckoutTime     time.Time `db:"checkout_time"`
-	WorkDurationSecs int64     `db:"work_duration_seconds"`
-	IsValid          bool      `db:"is_valid"`
-	IsComplete       bool      `db:"is_complete"`
+	CheckinID        string         `db:"checkin_id"`
+	CheckoutID       sql.NullString `db:"checkout_id"`
+	TenantID         string         `db:"id_tenant"`
+	EventID          string         `db:"id_event"`
+	EmployeeID       string         `db:"id_employee"`
+	PartnerID        string         `db:"id_partner"`
+	CheckinTime      time.Time      `db:"checkin_time"`
+	CheckoutTime     sql.NullTime   `db:"checkout_time"`
+	WorkDurationSecs sql.NullInt64  `db:"work_duration_seconds"`
+	IsValid          bool           `db:"is_valid"`
+	IsComplete       bool           `db:"is_complete"`
 }
 
 // toEntity converte uma linha do banco para entidade de domínio
@@ -116,6 +127,7 @@ func (r *checkoutRow) toEntity() (*checkout.Checkout, error) {
 		CheckoutTime: r.CheckoutTime,
 		WorkDuration: time.Duration(r.WorkDurationSecs) * time.Second,
 		IsValid:      r.IsValid,
+		AutoClosed:   r.AutoClosed,
 		CreatedAt:    r.CreatedAt,
 		UpdatedAt:    r.UpdatedAt,
 	}
@@ -130,6 +142,22 @@ func (r *checkoutRow) toEntity() (*checkout.Checkout, error) {
 		checkoutEntity.Notes = r.Notes.String
 	}
 
+	// ClientID
+	if r.ClientID.Valid {
+		clientID, err := value_objects.ParseUUID(r.ClientID.String)
+		if err == nil {
+			checkoutEntity.ClientID = &clientID
+		}
+	}
+
+	// DeviceID
+	if r.DeviceID.Valid {
+		checkoutEntity.DeviceID = r.DeviceID.String
+	}
+
+	// Metadados da leitura de GPS
+	checkoutEntity.LocationFix = r.toLocationFix()
+
 	// ValidationDetails
 	if r.ValidationDetails.Valid && r.ValidationDetails.String != "" {
 		var details map[string]interface{}
@@ -142,6 +170,31 @@ func (r *checkoutRow) toEntity() (*checkout.Checkout, error) {
 		checkoutEntity.ValidationDetails = make(map[string]interface{})
 	}
 
+	// Anulação
+	if r.VoidedAt.Valid {
+		voidedAt := r.VoidedAt.Time
+		checkoutEntity.VoidedAt = &voidedAt
+	}
+
+	if r.VoidedBy.Valid {
+		voidedBy, err := value_objects.ParseUUID(r.VoidedBy.String)
+		if err == nil {
+			checkoutEntity.VoidedBy = &voidedBy
+		}
+	}
+
+	if r.VoidReason.Valid {
+		checkoutEntity.VoidReason = r.VoidReason.String
+	}
+
+	// ReplacesID
+	if r.ReplacesID.Valid {
+		replacesID, err := value_objects.ParseUUID(r.ReplacesID.String)
+		if err == nil {
+			checkoutEntity.ReplacesID = &replacesID
+		}
+	}
+
 	// CreatedBy
 	if r.CreatedBy.Valid {
 		createdBy, err := value_objects.ParseUUID(r.CreatedBy.String)
@@ -168,11 +221,6 @@ func (r *workSessionRow) toWorkSessionEntity() (*checkout.WorkSession, error) {
 		return nil, fmt.Errorf("invalid checkin ID: %w", err)
 	}
 
-	checkoutID, err := value_objects.ParseUUID(r.CheckoutID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid checkout ID: %w", err)
-	}
-
 	eventID, err := value_objects.ParseUUID(r.EventID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid event ID: %w", err)
@@ -188,18 +236,34 @@ func (r *workSessionRow) toWorkSessionEntity() (*checkout.WorkSession, error) {
 		return nil, fmt.Errorf("invalid partner ID: %w", err)
 	}
 
-	return &checkout.WorkSession{
-		CheckinID:    checkinID,
-		CheckoutID:   checkoutID,
-		EventID:      eventID,
-		EmployeeID:   employeeID,
-		PartnerID:    partnerID,
-		CheckinTime:  r.CheckinTime,
-		CheckoutTime: r.CheckoutTime,
-		Duration:     time.Duration(r.WorkDurationSecs) * time.Second,
-		IsValid:      r.IsValid,
-		IsComplete:   r.IsComplete,
-	}, nil
+	session := &checkout.WorkSession{
+		CheckinID:   checkinID,
+		EventID:     eventID,
+		EmployeeID:  employeeID,
+		PartnerID:   partnerID,
+		CheckinTime: r.CheckinTime,
+		IsValid:     r.IsValid,
+		IsComplete:  r.IsComplete,
+	}
+
+	// Sessões abertas não possuem checkout
+	if r.CheckoutID.Valid {
+		checkoutID, err := value_objects.ParseUUID(r.CheckoutID.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkout ID: %w", err)
+		}
+		session.CheckoutID = checkoutID
+	}
+
+	if r.CheckoutTime.Valid {
+		session.CheckoutTime = r.CheckoutTime.Time
+	}
+
+	if r.WorkDurationSecs.Valid {
+		session.Duration = time.Duration(r.WorkDurationSecs.Int64) * time.Second
+	}
+
+	return session, nil
 }
 
 // fromEntity converte uma entidade de domínio para linha do banco
@@ -217,6 +281,7 @@ func (repo *CheckoutRepository) fromEntity(c *checkout.Checkout) *checkoutRow {
 		CheckoutTime:     c.CheckoutTime,
 		WorkDurationSecs: int64(c.WorkDuration.Seconds()),
 		IsValid:          c.IsValid,
+		AutoClosed:       c.AutoClosed,
 		CreatedAt:        c.CreatedAt,
 		UpdatedAt:        c.UpdatedAt,
 	}
@@ -231,6 +296,19 @@ func (repo *CheckoutRepository) fromEntity(c *checkout.Checkout) *checkoutRow {
 		row.Notes = sql.NullString{String: c.Notes, Valid: true}
 	}
 
+	// ClientID
+	if c.ClientID != nil {
+		row.ClientID = sql.NullString{String: c.ClientID.String(), Valid: true}
+	}
+
+	// DeviceID
+	if c.DeviceID != "" {
+		row.DeviceID = sql.NullString{String: c.DeviceID, Valid: true}
+	}
+
+	// Metadados da leitura de GPS
+	row.locationFixRow = newLocationFixRow(c.LocationFix)
+
 	// ValidationDetails
 	if len(c.ValidationDetails) > 0 {
 		if detailsJSON, err := json.Marshal(c.ValidationDetails); err == nil {
@@ -238,6 +316,24 @@ func (repo *CheckoutRepository) fromEntity(c *checkout.Checkout) *checkoutRow {
 		}
 	}
 
+	// Anulação
+	if c.VoidedAt != nil {
+		row.VoidedAt = sql.NullTime{Time: *c.VoidedAt, Valid: true}
+	}
+
+	if c.VoidedBy != nil {
+		row.VoidedBy = sql.NullString{String: c.VoidedBy.String(), Valid: true}
+	}
+
+	if c.VoidReason != "" {
+		row.VoidReason = sql.NullString{String: c.VoidReason, Valid: true}
+	}
+
+	// ReplacesID
+	if c.ReplacesID != nil {
+		row.ReplacesID = sql.NullString{String: c.ReplacesID.String(), Valid: true}
+	}
+
 	// CreatedBy
 	if c.CreatedBy != nil {
 		row.CreatedBy = sql.NullString{String: c.CreatedBy.String(), Valid: true}
@@ -258,13 +354,17 @@ func (repo *CheckoutRepository) Create(ctx context.Context, c *checkout.Checkout
 	query := `
 		INSERT INTO checkout (
 			id_checkout, id_tenant, id_event, id_employee, id_partner, id_checkin,
-			method, latitude, longitude, checkout_time, photo_url, notes,
-			work_duration_seconds, is_valid, validation_details,
+			method, latitude, longitude, checkout_time, photo_url, notes, client_id, device_id,
+			location_accuracy, location_altitude, location_fix_time, location_provider, location_mock,
+			work_duration_seconds, is_valid, auto_closed, validation_details,
+			voided_at, voided_by, void_reason, replaces_id,
 			created_at, updated_at, created_by, updated_by
 		) VALUES (
 			:id_checkout, :id_tenant, :id_event, :id_employee, :id_partner, :id_checkin,
-			:method, :latitude, :longitude, :checkout_time, :photo_url, :notes,
-			:work_duration_seconds, :is_valid, :validation_details,
+			:method, :latitude, :longitude, :checkout_time, :photo_url, :notes, :client_id, :device_id,
+			:location_accuracy, :location_altitude, :location_fix_time, :location_provider, :location_mock,
+			:work_duration_seconds, :is_valid, :auto_closed, :validation_details,
+			:voided_at, :voided_by, :void_reason, :replaces_id,
 			:created_at, :updated_at, :created_by, :updated_by
 		)`
 
@@ -283,8 +383,10 @@ func (repo *CheckoutRepository) GetByID(ctx context.Context, id value_objects.UU
 	var row checkoutRow
 	query := `
 		SELECT id_checkout, id_tenant, id_event, id_employee, id_partner, id_checkin,
-			   method, latitude, longitude, checkout_time, photo_url, notes,
-			   work_duration_seconds, is_valid, validation_details,
+			   method, latitude, longitude, checkout_time, photo_url, notes, client_id, device_id,
+			   location_accuracy, location_altitude, location_fix_time, location_provider, location_mock,
+			   work_duration_seconds, is_valid, auto_closed, validation_details,
+			   voided_at, voided_by, void_reason, replaces_id,
 			   created_at, updated_at, created_by, updated_by
 		FROM checkout 
 		WHERE id_checkout = $1`
@@ -292,7 +394,7 @@ func (repo *CheckoutRepository) GetByID(ctx context.Context, id value_objects.UU
 	err := repo.db.GetContext(ctx, &row, query, id.String())
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("checkout not found")
+			return nil, fmt.Errorf("checkout not found: %w", errors.ErrNotFound)
 		}
 		repo.logger.Error("Failed to get checkout by ID", zap.Error(err), zap.String("checkout_id", id.String()))
 		return nil, fmt.Errorf("failed to get checkout: %w", err)
@@ -309,9 +411,13 @@ func (repo *CheckoutRepository) Update(ctx context.Context, c *checkout.Checkout
 		UPDATE checkout SET
 			photo_url = :photo_url,
 			notes = :notes,
+			checkout_time = :checkout_time,
 			work_duration_seconds = :work_duration_seconds,
 			is_valid = :is_valid,
 			validation_details = :validation_details,
+			voided_at = :voided_at,
+			voided_by = :voided_by,
+			void_reason = :void_reason,
 			updated_at = :updated_at,
 			updated_by = :updated_by
 		WHERE id_checkout = :id_checkout`
@@ -328,7 +434,7 @@ func (repo *CheckoutRepository) Update(ctx context.Context, c *checkout.Checkout
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("checkout not found")
+		return fmt.Errorf("checkout not found: %w", errors.ErrNotFound)
 	}
 
 	repo.logger.Info("Checkout updated successfully", zap.String("checkout_id", c.ID.String()))
@@ -356,7 +462,7 @@ func (repo *CheckoutRepository) Delete(ctx context.Context, id value_objects.UUI
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("checkout not found")
+		return fmt.Errorf("checkout not found: %w", errors.ErrNotFound)
 	}
 
 	repo.logger.Info("Checkout deleted successfully", zap.String("checkout_id", id.String()))
@@ -417,6 +523,20 @@ func (repo *CheckoutRepository) List(ctx context.Context, filters checkout.ListF
 		args = append(args, *filters.IsValid)
 	}
 
+	if filters.AutoClosed != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("c.auto_closed = $%d", argCount))
+		args = append(args, *filters.AutoClosed)
+	}
+
+	if filters.Voided != nil {
+		if *filters.Voided {
+			conditions = append(conditions, "c.voided_at IS NOT NULL")
+		} else {
+			conditions = append(conditions, "c.voided_at IS NULL")
+		}
+	}
+
 	if filters.HasPhoto != nil {
 		if *filters.HasPhoto {
 			conditions = append(conditions, "c.photo_url IS NOT NULL AND c.photo_url != ''")
@@ -475,8 +595,10 @@ func (repo *CheckoutRepository) List(ctx context.Context, filters checkout.ListF
 	// Query para buscar dados com paginação
 	selectQuery := `
 		SELECT c.id_checkout, c.id_tenant, c.id_event, c.id_employee, c.id_partner, c.id_checkin,
-			   c.method, c.latitude, c.longitude, c.checkout_time, c.photo_url, c.notes,
-			   c.work_duration_seconds, c.is_valid, c.validation_details,
+			   c.method, c.latitude, c.longitude, c.checkout_time, c.photo_url, c.notes, c.client_id, c.device_id,
+			   c.location_accuracy, c.location_altitude, c.location_fix_time, c.location_provider, c.location_mock,
+			   c.work_duration_seconds, c.is_valid, c.auto_closed, c.validation_details,
+			   c.voided_at, c.voided_by, c.void_reason, c.replaces_id,
 			   c.created_at, c.updated_at, c.created_by, c.updated_by ` + baseQuery
 
 	// Adicionar ordenação
@@ -539,16 +661,18 @@ func (repo *CheckoutRepository) GetByCheckin(ctx context.Context, checkinID valu
 	var row checkoutRow
 	query := `
 		SELECT id_checkout, id_tenant, id_event, id_employee, id_partner, id_checkin,
-			   method, latitude, longitude, checkout_time, photo_url, notes,
-			   work_duration_seconds, is_valid, validation_details,
+			   method, latitude, longitude, checkout_time, photo_url, notes, client_id, device_id,
+			   location_accuracy, location_altitude, location_fix_time, location_provider, location_mock,
+			   work_duration_seconds, is_valid, auto_closed, validation_details,
+			   voided_at, voided_by, void_reason, replaces_id,
 			   created_at, updated_at, created_by, updated_by
 		FROM checkout 
-		WHERE id_checkin = $1`
+		WHERE id_checkin = $1 AND voided_at IS NULL`
 
 	err := repo.db.GetContext(ctx, &row, query, checkinID.String())
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("checkout not found")
+			return nil, fmt.Errorf("checkout not found: %w", errors.ErrNotFound)
 		}
 		repo.logger.Error("Failed to get checkout by checkin", zap.Error(err))
 		return nil, fmt.Errorf("failed to get checkout: %w", err)
@@ -557,13 +681,38 @@ func (repo *CheckoutRepository) GetByCheckin(ctx context.Context, checkinID valu
 	return row.toEntity()
 }
 
+// GetByClientID busca checkout pelo identificador gerado no dispositivo
+func (repo *CheckoutRepository) GetByClientID(ctx context.Context, clientID value_objects.UUID) (*checkout.Checkout, error) {
+	var row checkoutRow
+	query := `
+		SELECT id_checkout, id_tenant, id_event, id_employee, id_partner, id_checkin,
+			   method, latitude, longitude, checkout_time, photo_url, notes, client_id, device_id,
+			   location_accuracy, location_altitude, location_fix_time, location_provider, location_mock,
+			   work_duration_seconds, is_valid, auto_closed, validation_details,
+			   voided_at, voided_by, void_reason, replaces_id,
+			   created_at, updated_at, created_by, updated_by
+		FROM checkout 
+		WHERE client_id = $1`
+
+	err := repo.db.GetContext(ctx, &row, query, clientID.String())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("checkout not found: %w", errors.ErrNotFound)
+		}
+		repo.logger.Error("Failed to get checkout by client ID", zap.Error(err), zap.String("client_id", clientID.String()))
+		return nil, fmt.Errorf("failed to get checkout: %w", err)
+	}
+
+	return row.toEntity()
+}
+
 // ExistsByCheckin verifica se já existe checkout para o checkin
 func (repo *CheckoutRepository) ExistsByCheckin(ctx context.Context, checkinID value_objects.UUID) (bool, error) {
 	var count int
 	query := `
 		SELECT COUNT(*)
 		FROM checkout 
-		WHERE id_checkin = $1`
+		WHERE id_checkin = $1 AND voided_at IS NULL`
 
 	err := repo.db.GetContext(ctx, &count, query, checkinID.String())
 	if err != nil {
@@ -574,23 +723,39 @@ func (repo *CheckoutRepository) ExistsByCheckin(ctx context.Context, checkinID v
 	return count > 0, nil
 }
 
+// ExistsReplacement verifica se o checkout anulado já foi substituído
+func (repo *CheckoutRepository) ExistsReplacement(ctx context.Context, voidedID value_objects.UUID) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM checkout WHERE replaces_id = $1)`
+
+	err := repo.db.GetContext(ctx, &exists, query, voidedID.String())
+	if err != nil {
+		repo.logger.Error("Failed to check checkout replacement", zap.Error(err), zap.String("checkout_id", voidedID.String()))
+		return false, fmt.Errorf("failed to check checkout replacement: %w", err)
+	}
+
+	return exists, nil
+}
+
 // GetByEmployeeAndEvent busca checkout específico de funcionário em evento
 func (repo *CheckoutRepository) GetByEmployeeAndEvent(ctx context.Context, employeeID, eventID value_objects.UUID) (*checkout.Checkout, error) {
 	var row checkoutRow
 	query := `
 		SELECT id_checkout, id_tenant, id_event, id_employee, id_partner, id_checkin,
-			   method, latitude, longitude, checkout_time, photo_url, notes,
-			   work_duration_seconds, is_valid, validation_details,
+			   method, latitude, longitude, checkout_time, photo_url, notes, client_id, device_id,
+			   location_accuracy, location_altitude, location_fix_time, location_provider, location_mock,
+			   work_duration_seconds, is_valid, auto_closed, validation_details,
+			   voided_at, voided_by, void_reason, replaces_id,
 			   created_at, updated_at, created_by, updated_by
 		FROM checkout 
-		WHERE id_employee = $1 AND id_event = $2
+		WHERE id_employee = $1 AND id_event = $2 AND voided_at IS NULL
 		ORDER BY checkout_time DESC
 		LIMIT 1`
 
 	err := repo.db.GetContext(ctx, &row, query, employeeID.String(), eventID.String())
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("checkout not found")
+			return nil, fmt.Errorf("checkout not found: %w", errors.ErrNotFound)
 		}
 		repo.logger.Error("Failed to get checkout by employee and event", zap.Error(err))
 		return nil, fmt.Errorf("failed to get checkout: %w", err)
@@ -599,13 +764,20 @@ func (repo *CheckoutRepository) GetByEmployeeAndEvent(ctx context.Context, emplo
 	return row.toEntity()
 }
 
-// GetWorkSessions busca sessões de trabalho completas (checkin + checkout)
+// GetWorkSessions busca sessões de trabalho (checkin + checkout).
+// Cada checkin é uma sessão: um funcionário pode ter várias sessões no mesmo evento.
 func (repo *CheckoutRepository) GetWorkSessions(ctx context.Context, tenantID value_objects.UUID, filters checkout.WorkSessionFilters) ([]*checkout.WorkSession, int, error) {
+	return repo.listWorkSessions(ctx, tenantID, filters)
+}
+
+// listWorkSessions lista sessões de trabalho do tenant; funcionário e evento restringem pelos filtros.
+// Check-ins aguardando aprovação ou rejeitados pelo supervisor não entram nas horas trabalhadas.
+func (repo *CheckoutRepository) listWorkSessions(ctx context.Context, tenantID value_objects.UUID, filters checkout.WorkSessionFilters) ([]*checkout.WorkSession, int, error) {
 	// Construir query base
 	baseQuery := `
 		FROM checkin ci
-		LEFT JOIN checkout co ON ci.id_checkin = co.id_checkin
-		WHERE ci.id_tenant = $1`
+		LEFT JOIN checkout co ON ci.id_checkin = co.id_checkin AND co.voided_at IS NULL
+		WHERE ci.voided_at IS NULL AND COALESCE(ci.approval_status, '') NOT IN ('pending', 'rejected') AND ci.id_tenant = $1`
 
 	args := []interface{}{tenantID.String()}
 	argCount := 1
@@ -757,7 +929,9 @@ func (repo *CheckoutRepository) GetByMethod(ctx context.Context, tenantID value_
 func (repo *CheckoutRepository) GetValidCheckouts(ctx context.Context, tenantID value_objects.UUID, filters checkout.ListFilters) ([]*checkout.Checkout, int, error) {
 	filters.TenantID = &tenantID
 	valid := true
+	voided := false
 	filters.IsValid = &valid
+	filters.Voided = &voided
 	return repo.List(ctx, filters)
 }
 
@@ -809,6 +983,20 @@ func (repo *CheckoutRepository) GetCheckoutsByLocation(ctx context.Context, tena
 		args = append(args, *filters.IsValid)
 	}
 
+	if filters.AutoClosed != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("c.auto_closed = $%d", argCount))
+		args = append(args, *filters.AutoClosed)
+	}
+
+	if filters.Voided != nil {
+		if *filters.Voided {
+			conditions = append(conditions, "c.voided_at IS NOT NULL")
+		} else {
+			conditions = append(conditions, "c.voided_at IS NULL")
+		}
+	}
+
 	// Adicionar condições adicionais
 	if len(conditions) > 0 {
 		baseQuery += " AND " + strings.Join(conditions, " AND ")
@@ -826,8 +1014,10 @@ func (repo *CheckoutRepository) GetCheckoutsByLocation(ctx context.Context, tena
 	// Query para buscar dados com paginação
 	selectQuery := `
 		SELECT c.id_checkout, c.id_tenant, c.id_event, c.id_employee, c.id_partner, c.id_checkin,
-			   c.method, c.latitude, c.longitude, c.checkout_time, c.photo_url, c.notes,
-			   c.work_duration_seconds, c.is_valid, c.validation_details,
+			   c.method, c.latitude, c.longitude, c.checkout_time, c.photo_url, c.notes, c.client_id, c.device_id,
+			   c.location_accuracy, c.location_altitude, c.location_fix_time, c.location_provider, c.location_mock,
+			   c.work_duration_seconds, c.is_valid, c.auto_closed, c.validation_details,
+			   c.voided_at, c.voided_by, c.void_reason, c.replaces_id,
 			   c.created_at, c.updated_at, c.created_by, c.updated_by,
 			   ST_Distance(
 				   ST_GeogFromText('POINT(' || c.longitude || ' ' || c.latitude || ')'),
@@ -861,28 +1051,26 @@ func (repo *CheckoutRepository) GetCheckoutsByLocation(ctx context.Context, tena
 	return checkouts, total, nil
 }
 
-// GetEmployeeWorkSessions busca sessões de trabalho de um funcionário
-func (repo *CheckoutRepository) GetEmployeeWorkSessions(ctx context.Context, employeeID value_objects.UUID, filters checkout.WorkSessionFilters) ([]*checkout.WorkSession, int, error) {
+// GetEmployeeWorkSessions busca sessões de trabalho de um funcionário do tenant
+func (repo *CheckoutRepository) GetEmployeeWorkSessions(ctx context.Context, tenantID, employeeID value_objects.UUID, filters checkout.WorkSessionFilters) ([]*checkout.WorkSession, int, error) {
 	filters.EmployeeID = &employeeID
-	// Usar um tenant vazio já que não temos como obter o tenantID aqui
-	// Em uma implementação real, seria necessário buscar o tenantID do funcionário primeiro
-	return nil, 0, fmt.Errorf("method not implemented - requires tenant information")
+	return repo.listWorkSessions(ctx, tenantID, filters)
 }
 
-// GetEventWorkSessions busca sessões de trabalho de um evento
-func (repo *CheckoutRepository) GetEventWorkSessions(ctx context.Context, eventID value_objects.UUID, filters checkout.WorkSessionFilters) ([]*checkout.WorkSession, int, error) {
+// GetEventWorkSessions busca sessões de trabalho de um evento do tenant
+func (repo *CheckoutRepository) GetEventWorkSessions(ctx context.Context, tenantID, eventID value_objects.UUID, filters checkout.WorkSessionFilters) ([]*checkout.WorkSession, int, error) {
 	filters.EventID = &eventID
-	// Usar um tenant vazio já que não temos como obter o tenantID aqui
-	// Em uma implementação real, seria necessário buscar o tenantID do evento primeiro
-	return nil, 0, fmt.Errorf("method not implemented - requires tenant information")
+	return repo.listWorkSessions(ctx, tenantID, filters)
 }
 
 // GetRecentCheckouts busca checkouts recentes (últimas 24h)
 func (repo *CheckoutRepository) GetRecentCheckouts(ctx context.Context, tenantID value_objects.UUID, limit int) ([]*checkout.Checkout, error) {
 	query := `
 		SELECT id_checkout, id_tenant, id_event, id_employee, id_partner, id_checkin,
-			   method, latitude, longitude, checkout_time, photo_url, notes,
-			   work_duration_seconds, is_valid, validation_details,
+			   method, latitude, longitude, checkout_time, photo_url, notes, client_id, device_id,
+			   location_accuracy, location_altitude, location_fix_time, location_provider, location_mock,
+			   work_duration_seconds, is_valid, auto_closed, validation_details,
+			   voided_at, voided_by, void_reason, replaces_id,
 			   created_at, updated_at, created_by, updated_by
 		FROM checkout 
 		WHERE id_tenant = $1 AND checkout_time >= NOW() - INTERVAL '24 hours'
@@ -919,7 +1107,7 @@ func (repo *CheckoutRepository) CountByTenant(ctx context.Context, tenantID valu
 	query := `
 		SELECT COUNT(*)
 		FROM checkout 
-		WHERE id_tenant = $1`
+		WHERE id_tenant = $1 AND voided_at IS NULL`
 
 	err := repo.db.GetContext(ctx, &count, query, tenantID.String())
 	if err != nil {
@@ -936,7 +1124,7 @@ func (repo *CheckoutRepository) CountByEvent(ctx context.Context, eventID value_
 	query := `
 		SELECT COUNT(*)
 		FROM checkout 
-		WHERE id_event = $1`
+		WHERE id_event = $1 AND voided_at IS NULL`
 
 	err := repo.db.GetContext(ctx, &count, query, eventID.String())
 	if err != nil {
@@ -953,7 +1141,7 @@ func (repo *CheckoutRepository) CountByEmployee(ctx context.Context, employeeID
 	query := `
 		SELECT COUNT(*)
 		FROM checkout 
-		WHERE id_employee = $1`
+		WHERE id_employee = $1 AND voided_at IS NULL`
 
 	err := repo.db.GetContext(ctx, &count, query, employeeID.String())
 	if err != nil {
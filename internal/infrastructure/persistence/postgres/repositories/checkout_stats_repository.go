@@ -0,0 +1,299 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"eventos-backend/internal/domain/checkout"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/infrastructure/cache"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const (
+	shortSessionSeconds = 3600      // sessões com menos de 1 hora
+	longSessionSeconds  = 12 * 3600 // sessões com mais de 12 horas
+)
+
+// CheckoutStatsRepository implementa checkout.StatsRepository com agregações no Postgres
+type CheckoutStatsRepository struct {
+	db           *sqlx.DB
+	cacheService *cache.CacheService
+	logger       *zap.Logger
+}
+
+// NewCheckoutStatsRepository cria uma nova instância do repositório de estatísticas de check-out.
+// Sem cacheService as estatísticas são sempre calculadas no banco.
+func NewCheckoutStatsRepository(db *sqlx.DB, cacheService *cache.CacheService, logger *zap.Logger) checkout.StatsRepository {
+	return &CheckoutStatsRepository{
+		db:           db,
+		cacheService: cacheService,
+		logger:       logger,
+	}
+}
+
+// checkoutStatsRow representa o resultado da agregação de check-outs
+type checkoutStatsRow struct {
+	TotalCheckouts     int          `db:"total_checkouts"`
+	ValidCheckouts     int          `db:"valid_checkouts"`
+	InvalidCheckouts   int          `db:"invalid_checkouts"`
+	PendingCheckouts   int          `db:"pending_checkouts"`
+	FacialCheckouts    int          `db:"facial_checkouts"`
+	QRCodeCheckouts    int          `db:"qr_code_checkouts"`
+	ManualCheckouts    int          `db:"manual_checkouts"`
+	CheckoutsToday     int          `db:"checkouts_today"`
+	CheckoutsThisWeek  int          `db:"checkouts_this_week"`
+	CheckoutsThisMonth int          `db:"checkouts_this_month"`
+	CountedSessions    int          `db:"counted_sessions"`
+	TotalWorkSeconds   int64        `db:"total_work_seconds"`
+	ShortSessions      int          `db:"short_sessions"`
+	LongSessions       int          `db:"long_sessions"`
+	LastCheckoutTime   sql.NullTime `db:"last_checkout_time"`
+}
+
+// toEntity converte o resultado da agregação para estatísticas de domínio
+func (r *checkoutStatsRow) toEntity() *checkout.CheckoutStats {
+	stats := &checkout.CheckoutStats{
+		TotalCheckouts:     r.TotalCheckouts,
+		ValidCheckouts:     r.ValidCheckouts,
+		InvalidCheckouts:   r.InvalidCheckouts,
+		PendingCheckouts:   r.PendingCheckouts,
+		FacialCheckouts:    r.FacialCheckouts,
+		QRCodeCheckouts:    r.QRCodeCheckouts,
+		ManualCheckouts:    r.ManualCheckouts,
+		CheckoutsToday:     r.CheckoutsToday,
+		CheckoutsThisWeek:  r.CheckoutsThisWeek,
+		CheckoutsThisMonth: r.CheckoutsThisMonth,
+		TotalWorkHours:     float64(r.TotalWorkSeconds) / 3600,
+		ShortSessions:      r.ShortSessions,
+		LongSessions:       r.LongSessions,
+	}
+
+	if r.CountedSessions > 0 {
+		stats.AverageWorkHours = stats.TotalWorkHours / float64(r.CountedSessions)
+	}
+
+	if r.LastCheckoutTime.Valid {
+		lastCheckout := r.LastCheckoutTime.Time
+		stats.LastCheckoutTime = &lastCheckout
+	}
+
+	return stats
+}
+
+// workStatsRow representa o resultado da agregação de sessões de trabalho
+type workStatsRow struct {
+	TotalSessions    int   `db:"total_sessions"`
+	CompleteSessions int   `db:"complete_sessions"`
+	ValidSessions    int   `db:"valid_sessions"`
+	TotalWorkSeconds int64 `db:"total_work_seconds"`
+	MinWorkSeconds   int64 `db:"min_work_seconds"`
+	MaxWorkSeconds   int64 `db:"max_work_seconds"`
+	ShortSessions    int   `db:"short_sessions"`
+	LongSessions     int   `db:"long_sessions"`
+}
+
+// toEntity converte o resultado da agregação para estatísticas de trabalho
+func (r *workStatsRow) toEntity() *checkout.WorkStats {
+	stats := &checkout.WorkStats{
+		TotalSessions:      r.TotalSessions,
+		CompleteSessions:   r.CompleteSessions,
+		IncompleteSessions: r.TotalSessions - r.CompleteSessions,
+		ValidSessions:      r.ValidSessions,
+		InvalidSessions:    r.TotalSessions - r.ValidSessions,
+		TotalWorkHours:     float64(r.TotalWorkSeconds) / 3600,
+		MinWorkHours:       float64(r.MinWorkSeconds) / 3600,
+		MaxWorkHours:       float64(r.MaxWorkSeconds) / 3600,
+		ShortSessions:      r.ShortSessions,
+		NormalSessions:     r.CompleteSessions - r.ShortSessions - r.LongSessions,
+		LongSessions:       r.LongSessions,
+	}
+
+	if r.CompleteSessions > 0 {
+		stats.AverageWorkHours = stats.TotalWorkHours / float64(r.CompleteSessions)
+	}
+
+	return stats
+}
+
+// GetTenantStats obtém estatísticas de um tenant
+func (repo *CheckoutStatsRepository) GetTenantStats(ctx context.Context, tenantID value_objects.UUID) (*checkout.CheckoutStats, error) {
+	return repo.cachedStats(ctx, tenantID.String(), []string{"tenant"}, "co.id_tenant = $1", tenantID, nil)
+}
+
+// GetEventStats obtém estatísticas de um evento
+func (repo *CheckoutStatsRepository) GetEventStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*checkout.CheckoutStats, error) {
+	return repo.cachedStats(ctx, tenantID.String(), []string{"event", eventID.String()}, "co.id_event = $1", eventID, nil)
+}
+
+// GetEmployeeStats obtém estatísticas de um funcionário
+func (repo *CheckoutStatsRepository) GetEmployeeStats(ctx context.Context, tenantID, employeeID value_objects.UUID) (*checkout.CheckoutStats, error) {
+	return repo.cachedStats(ctx, tenantID.String(), []string{"employee", employeeID.String()}, "co.id_employee = $1", employeeID, nil)
+}
+
+// GetPartnerStats obtém estatísticas de um parceiro
+func (repo *CheckoutStatsRepository) GetPartnerStats(ctx context.Context, tenantID, partnerID value_objects.UUID) (*checkout.CheckoutStats, error) {
+	return repo.cachedStats(ctx, tenantID.String(), []string{"partner", partnerID.String()}, "co.id_partner = $1", partnerID, nil)
+}
+
+// GetDailyStats obtém estatísticas diárias
+func (repo *CheckoutStatsRepository) GetDailyStats(ctx context.Context, tenantID value_objects.UUID, date time.Time) (*checkout.CheckoutStats, error) {
+	period := dailyPeriod(date)
+	return repo.cachedStats(ctx, tenantID.String(), period.cacheSuffix("daily"), "co.id_tenant = $1", tenantID, period)
+}
+
+// GetWeeklyStats obtém estatísticas semanais
+func (repo *CheckoutStatsRepository) GetWeeklyStats(ctx context.Context, tenantID value_objects.UUID, startDate time.Time) (*checkout.CheckoutStats, error) {
+	period := weeklyPeriod(startDate)
+	return repo.cachedStats(ctx, tenantID.String(), period.cacheSuffix("weekly"), "co.id_tenant = $1", tenantID, period)
+}
+
+// GetMonthlyStats obtém estatísticas mensais
+func (repo *CheckoutStatsRepository) GetMonthlyStats(ctx context.Context, tenantID value_objects.UUID, year int, month time.Month) (*checkout.CheckoutStats, error) {
+	period := monthlyPeriod(year, month)
+	return repo.cachedStats(ctx, tenantID.String(), period.cacheSuffix("monthly"), "co.id_tenant = $1", tenantID, period)
+}
+
+// GetWorkStats obtém estatísticas de trabalho
+func (repo *CheckoutStatsRepository) GetWorkStats(ctx context.Context, tenantID value_objects.UUID) (*checkout.WorkStats, error) {
+	return repo.cachedWorkStats(ctx, tenantID.String(), []string{"tenant"}, "ci.id_tenant = $1", tenantID)
+}
+
+// GetEmployeeWorkStats obtém estatísticas de trabalho de um funcionário
+func (repo *CheckoutStatsRepository) GetEmployeeWorkStats(ctx context.Context, tenantID, employeeID value_objects.UUID) (*checkout.WorkStats, error) {
+	return repo.cachedWorkStats(ctx, tenantID.String(), []string{"employee", employeeID.String()}, "ci.id_employee = $1", employeeID)
+}
+
+// GetEventWorkStats obtém estatísticas de trabalho de um evento
+func (repo *CheckoutStatsRepository) GetEventWorkStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*checkout.WorkStats, error) {
+	return repo.cachedWorkStats(ctx, tenantID.String(), []string{"event", eventID.String()}, "ci.id_event = $1", eventID)
+}
+
+// InvalidateSessionStats descarta as estatísticas em cache do tenant, do evento, do funcionário e do parceiro da sessão
+func (repo *CheckoutStatsRepository) InvalidateSessionStats(ctx context.Context, tenantID, eventID, employeeID, partnerID value_objects.UUID) {
+	invalidateSessionStats(ctx, repo.cacheService, repo.logger, tenantID, eventID, employeeID, partnerID)
+}
+
+// cachedStats busca as estatísticas no cache e, na ausência, agrega no banco e armazena o resultado
+func (repo *CheckoutStatsRepository) cachedStats(ctx context.Context, tenantID string, keySuffix []string, scope string, scopeID value_objects.UUID, period *statsPeriod) (*checkout.CheckoutStats, error) {
+	if repo.cacheService != nil {
+		var cached checkout.CheckoutStats
+		if err := repo.cacheService.GetStats(ctx, statsCacheName, tenantID, "checkout", &cached, keySuffix...); err == nil {
+			return &cached, nil
+		}
+	}
+
+	stats, err := repo.aggregate(ctx, scope, scopeID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.cacheService != nil {
+		if err := repo.cacheService.CacheStats(ctx, statsCacheName, tenantID, "checkout", stats, statsCacheTTL, keySuffix...); err != nil {
+			repo.logger.Warn("Failed to cache checkout stats", zap.Strings("key", keySuffix), zap.Error(err))
+		}
+	}
+
+	return stats, nil
+}
+
+// cachedWorkStats busca as estatísticas de trabalho no cache e, na ausência, agrega no banco
+func (repo *CheckoutStatsRepository) cachedWorkStats(ctx context.Context, tenantID string, keySuffix []string, scope string, scopeID value_objects.UUID) (*checkout.WorkStats, error) {
+	if repo.cacheService != nil {
+		var cached checkout.WorkStats
+		if err := repo.cacheService.GetStats(ctx, statsCacheName, tenantID, "work", &cached, keySuffix...); err == nil {
+			return &cached, nil
+		}
+	}
+
+	stats, err := repo.aggregateWork(ctx, scope, scopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.cacheService != nil {
+		if err := repo.cacheService.CacheStats(ctx, statsCacheName, tenantID, "work", stats, statsCacheTTL, keySuffix...); err != nil {
+			repo.logger.Warn("Failed to cache work stats", zap.Strings("key", keySuffix), zap.Error(err))
+		}
+	}
+
+	return stats, nil
+}
+
+// aggregate calcula as estatísticas de check-out em uma única consulta.
+// Check-outs anulados são ignorados e sessões cujo check-in aguarda aprovação ou foi rejeitado não somam horas.
+func (repo *CheckoutStatsRepository) aggregate(ctx context.Context, scope string, scopeID value_objects.UUID, period *statsPeriod) (*checkout.CheckoutStats, error) {
+	today, week, month := periodStarts(time.Now().UTC())
+
+	query := `
+		SELECT COUNT(*) AS total_checkouts,
+			   COUNT(*) FILTER (WHERE co.is_valid) AS valid_checkouts,
+			   COUNT(*) FILTER (WHERE NOT co.is_valid) AS invalid_checkouts,
+			   COUNT(*) FILTER (WHERE ci.approval_status = 'pending') AS pending_checkouts,
+			   COUNT(*) FILTER (WHERE co.method = $2) AS facial_checkouts,
+			   COUNT(*) FILTER (WHERE co.method = $3) AS qr_code_checkouts,
+			   COUNT(*) FILTER (WHERE co.method = $4) AS manual_checkouts,
+			   COUNT(*) FILTER (WHERE co.checkout_time >= $5) AS checkouts_today,
+			   COUNT(*) FILTER (WHERE co.checkout_time >= $6) AS checkouts_this_week,
+			   COUNT(*) FILTER (WHERE co.checkout_time >= $7) AS checkouts_this_month,
+			   COUNT(*) FILTER (WHERE COALESCE(ci.approval_status, '') NOT IN ('pending', 'rejected')) AS counted_sessions,
+			   COALESCE(SUM(co.work_duration_seconds) FILTER (WHERE COALESCE(ci.approval_status, '') NOT IN ('pending', 'rejected')), 0) AS total_work_seconds,
+			   COUNT(*) FILTER (WHERE COALESCE(ci.approval_status, '') NOT IN ('pending', 'rejected') AND co.work_duration_seconds < $8) AS short_sessions,
+			   COUNT(*) FILTER (WHERE COALESCE(ci.approval_status, '') NOT IN ('pending', 'rejected') AND co.work_duration_seconds > $9) AS long_sessions,
+			   MAX(co.checkout_time) AS last_checkout_time
+		FROM checkout co
+		JOIN checkin ci ON ci.id_checkin = co.id_checkin
+		WHERE co.voided_at IS NULL AND ` + scope
+
+	args := []interface{}{
+		scopeID.String(),
+		constants.CheckMethodFacialRecognition,
+		constants.CheckMethodQRCode,
+		constants.CheckMethodManual,
+		today, week, month,
+		shortSessionSeconds, longSessionSeconds,
+	}
+
+	if period != nil {
+		query += " AND co.checkout_time >= $10 AND co.checkout_time < $11"
+		args = append(args, period.start, period.end)
+	}
+
+	var row checkoutStatsRow
+	if err := repo.db.GetContext(ctx, &row, query, args...); err != nil {
+		repo.logger.Error("Failed to aggregate checkout stats", zap.Error(err), zap.String("scope", scope))
+		return nil, fmt.Errorf("failed to aggregate checkout stats: %w", err)
+	}
+
+	return row.toEntity(), nil
+}
+
+// aggregateWork calcula as estatísticas de trabalho com a mesma regra das sessões listadas:
+// check-ins anulados, aguardando aprovação ou rejeitados ficam de fora e sessões abertas não somam horas
+func (repo *CheckoutStatsRepository) aggregateWork(ctx context.Context, scope string, scopeID value_objects.UUID) (*checkout.WorkStats, error) {
+	query := `
+		SELECT COUNT(*) AS total_sessions,
+			   COUNT(co.id_checkout) AS complete_sessions,
+			   COUNT(*) FILTER (WHERE ci.is_valid AND COALESCE(co.is_valid, true)) AS valid_sessions,
+			   COALESCE(SUM(co.work_duration_seconds), 0) AS total_work_seconds,
+			   COALESCE(MIN(co.work_duration_seconds), 0) AS min_work_seconds,
+			   COALESCE(MAX(co.work_duration_seconds), 0) AS max_work_seconds,
+			   COUNT(*) FILTER (WHERE co.work_duration_seconds < $2) AS short_sessions,
+			   COUNT(*) FILTER (WHERE co.work_duration_seconds > $3) AS long_sessions
+		FROM checkin ci
+		LEFT JOIN checkout co ON ci.id_checkin = co.id_checkin AND co.voided_at IS NULL
+		WHERE ci.voided_at IS NULL AND COALESCE(ci.approval_status, '') NOT IN ('pending', 'rejected') AND ` + scope
+
+	var row workStatsRow
+	if err := repo.db.GetContext(ctx, &row, query, scopeID.String(), shortSessionSeconds, longSessionSeconds); err != nil {
+		repo.logger.Error("Failed to aggregate work stats", zap.Error(err), zap.String("scope", scope))
+		return nil, fmt.Errorf("failed to aggregate work stats: %w", err)
+	}
+
+	return row.toEntity(), nil
+}
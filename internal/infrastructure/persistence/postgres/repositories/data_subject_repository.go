@@ -0,0 +1,556 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"eventos-backend/internal/domain/biometric"
+	"eventos-backend/internal/domain/datasubject"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// DataSubjectRepository implementa datasubject.Repository usando PostgreSQL.
+// A exportação é, além do reconhecimento facial, o único caminho que abre os embeddings cifrados.
+type DataSubjectRepository struct {
+	db     *sqlx.DB
+	cipher biometric.Cipher
+	logger *zap.Logger
+}
+
+// NewDataSubjectRepository cria uma nova instância do repositório de solicitações de titulares
+func NewDataSubjectRepository(db *sqlx.DB, cipher biometric.Cipher, logger *zap.Logger) datasubject.Repository {
+	return &DataSubjectRepository{
+		db:     db,
+		cipher: cipher,
+		logger: logger,
+	}
+}
+
+// dataSubjectRequestColumns lista as colunas lidas da tabela data_subject_requests
+const dataSubjectRequestColumns = `id, tenant_id, subject_type, subject_id, request_type, status, reason, evidence,
+	failure_reason, requested_by, requested_at, completed_at`
+
+// dataSubjectRequestRow representa uma linha de solicitação de titular no banco de dados
+type dataSubjectRequestRow struct {
+	ID            string         `db:"id"`
+	TenantID      string         `db:"tenant_id"`
+	SubjectType   string         `db:"subject_type"`
+	SubjectID     string         `db:"subject_id"`
+	RequestType   string         `db:"request_type"`
+	Status        string         `db:"status"`
+	Reason        sql.NullString `db:"reason"`
+	Evidence      sql.NullString `db:"evidence"`
+	FailureReason sql.NullString `db:"failure_reason"`
+	RequestedBy   string         `db:"requested_by"`
+	RequestedAt   time.Time      `db:"requested_at"`
+	CompletedAt   sql.NullTime   `db:"completed_at"`
+}
+
+// toEntity converte dataSubjectRequestRow para entidade Request
+func (r *dataSubjectRequestRow) toEntity() (*datasubject.Request, error) {
+	id, err := value_objects.ParseUUID(r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request ID: %w", err)
+	}
+
+	tenantID, err := value_objects.ParseUUID(r.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant ID: %w", err)
+	}
+
+	subjectID, err := value_objects.ParseUUID(r.SubjectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subject ID: %w", err)
+	}
+
+	requestedBy, err := value_objects.ParseUUID(r.RequestedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid requested by ID: %w", err)
+	}
+
+	request := &datasubject.Request{
+		ID:            id,
+		TenantID:      tenantID,
+		SubjectType:   r.SubjectType,
+		SubjectID:     subjectID,
+		Type:          r.RequestType,
+		Status:        r.Status,
+		Reason:        r.Reason.String,
+		FailureReason: r.FailureReason.String,
+		RequestedBy:   requestedBy,
+		RequestedAt:   r.RequestedAt,
+	}
+
+	if r.Evidence.Valid && r.Evidence.String != "" {
+		var evidence datasubject.Evidence
+		if err := json.Unmarshal([]byte(r.Evidence.String), &evidence); err != nil {
+			return nil, fmt.Errorf("invalid request evidence: %w", err)
+		}
+		request.Evidence = &evidence
+	}
+
+	if r.CompletedAt.Valid {
+		request.CompletedAt = &r.CompletedAt.Time
+	}
+
+	return request, nil
+}
+
+// Create grava uma nova solicitação
+func (repo *DataSubjectRepository) Create(ctx context.Context, request *datasubject.Request) error {
+	evidence, err := encodeEvidence(request.Evidence)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO data_subject_requests (
+			id, tenant_id, subject_type, subject_id, request_type, status, reason, evidence,
+			failure_reason, requested_by, requested_at, completed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err = repo.db.ExecContext(ctx, query,
+		request.ID.String(), request.TenantID.String(), request.SubjectType, request.SubjectID.String(),
+		request.Type, request.Status, nullString(request.Reason), evidence,
+		nullString(request.FailureReason), request.RequestedBy.String(), request.RequestedAt, request.CompletedAt)
+	if err != nil {
+		repo.logger.Error("Failed to create data subject request", zap.Error(err), zap.String("request_id", request.ID.String()))
+		return fmt.Errorf("failed to create data subject request: %w", err)
+	}
+
+	return nil
+}
+
+// Update grava a situação e a evidência de uma solicitação
+func (repo *DataSubjectRepository) Update(ctx context.Context, request *datasubject.Request) error {
+	evidence, err := encodeEvidence(request.Evidence)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE data_subject_requests
+		SET status = $3, evidence = $4, failure_reason = $5, completed_at = $6
+		WHERE id = $1 AND tenant_id = $2`
+
+	result, err := repo.db.ExecContext(ctx, query,
+		request.ID.String(), request.TenantID.String(), request.Status, evidence,
+		nullString(request.FailureReason), request.CompletedAt)
+	if err != nil {
+		repo.logger.Error("Failed to update data subject request", zap.Error(err), zap.String("request_id", request.ID.String()))
+		return fmt.Errorf("failed to update data subject request: %w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return fmt.Errorf("data subject request not found: %w", errors.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetByID busca uma solicitação do tenant
+func (repo *DataSubjectRepository) GetByID(ctx context.Context, tenantID, id value_objects.UUID) (*datasubject.Request, error) {
+	query := `SELECT ` + dataSubjectRequestColumns + ` FROM data_subject_requests WHERE id = $1 AND tenant_id = $2`
+
+	var row dataSubjectRequestRow
+	if err := repo.db.GetContext(ctx, &row, query, id.String(), tenantID.String()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("Data subject request", id.String())
+		}
+		repo.logger.Error("Failed to get data subject request", zap.Error(err), zap.String("request_id", id.String()))
+		return nil, fmt.Errorf("failed to get data subject request: %w", err)
+	}
+
+	return row.toEntity()
+}
+
+// List lista as solicitações do tenant, das mais recentes para as mais antigas
+func (repo *DataSubjectRepository) List(ctx context.Context, tenantID value_objects.UUID, filters datasubject.ListFilters) ([]*datasubject.Request, int, error) {
+	if err := filters.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	conditions := []string{"tenant_id = $1"}
+	args := []interface{}{tenantID.String()}
+	argIndex := 2
+
+	if filters.SubjectType != nil {
+		conditions = append(conditions, fmt.Sprintf("subject_type = $%d", argIndex))
+		args = append(args, *filters.SubjectType)
+		argIndex++
+	}
+
+	if filters.SubjectID != nil {
+		conditions = append(conditions, fmt.Sprintf("subject_id = $%d", argIndex))
+		args = append(args, filters.SubjectID.String())
+		argIndex++
+	}
+
+	if filters.Type != nil {
+		conditions = append(conditions, fmt.Sprintf("request_type = $%d", argIndex))
+		args = append(args, *filters.Type)
+		argIndex++
+	}
+
+	if filters.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filters.Status)
+		argIndex++
+	}
+
+	whereClause := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := repo.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM data_subject_requests"+whereClause, args...); err != nil {
+		repo.logger.Error("Failed to count data subject requests", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to count data subject requests: %w", err)
+	}
+
+	dataQuery := `SELECT ` + dataSubjectRequestColumns + ` FROM data_subject_requests` + whereClause +
+		fmt.Sprintf(" ORDER BY requested_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filters.PageSize, filters.GetOffset())
+
+	var rows []dataSubjectRequestRow
+	if err := repo.db.SelectContext(ctx, &rows, dataQuery, args...); err != nil {
+		repo.logger.Error("Failed to list data subject requests", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to list data subject requests: %w", err)
+	}
+
+	requests := make([]*datasubject.Request, 0, len(rows))
+	for i := range rows {
+		request, err := rows[i].toEntity()
+		if err != nil {
+			repo.logger.Warn("Failed to convert data subject request row", zap.Error(err), zap.String("request_id", rows[i].ID))
+			continue
+		}
+		requests = append(requests, request)
+	}
+
+	return requests, total, nil
+}
+
+// subjectBiometricRow representa um registro do titular com embedding ou foto
+type subjectBiometricRow struct {
+	ID         string          `db:"id"`
+	Sealed     []byte          `db:"sealed"`
+	Legacy     pq.Float32Array `db:"legacy"`
+	PhotoKey   sql.NullString  `db:"photo_key"`
+	CapturedAt sql.NullTime    `db:"captured_at"`
+}
+
+// employeeBiometricQueries lê, por tipo de registro, os embeddings e as fotos de um funcionário ($1 tenant, $2 funcionário)
+var employeeBiometricQueries = []struct {
+	kind  string
+	query string
+}{
+	{biometric.RecordEmployee, `
+		SELECT id, face_embedding_sealed AS sealed, face_embedding AS legacy, photo_key, NULL::timestamp AS captured_at
+		FROM employees WHERE tenant_id = $1 AND id = $2`},
+	{biometric.RecordFaceTemplate, `
+		SELECT id, embedding_sealed AS sealed, embedding AS legacy, source_photo_key AS photo_key, captured_at
+		FROM employee_face_templates WHERE tenant_id = $1 AND employee_id = $2 ORDER BY captured_at`},
+	{biometric.RecordCheckin, `
+		SELECT id_checkin AS id, face_embedding_sealed AS sealed, face_embedding AS legacy, photo_key, checkin_time AS captured_at
+		FROM checkin WHERE id_tenant = $1 AND id_employee = $2 ORDER BY checkin_time`},
+}
+
+// subjectRecordIDs devolve a subconsulta com os IDs dos registros do titular referenciados na auditoria ($1 tenant, $2 titular)
+func subjectRecordIDs(subjectType string) string {
+	if subjectType == constants.DataSubjectEmployee {
+		return `SELECT $2::uuid
+			UNION ALL SELECT id_checkin FROM checkin WHERE id_tenant = $1 AND id_employee = $2
+			UNION ALL SELECT id_checkout FROM checkout WHERE id_tenant = $1 AND id_employee = $2`
+	}
+
+	return `SELECT $2::uuid`
+}
+
+// Collect reúne os dados do titular em uma transação somente leitura, para que a exportação seja consistente
+func (repo *DataSubjectRepository) Collect(ctx context.Context, tenantID value_objects.UUID, subjectType string, subjectID value_objects.UUID) (*datasubject.Export, error) {
+	tx, err := repo.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true, Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin export transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	args := []interface{}{tenantID.String(), subjectID.String()}
+	export := &datasubject.Export{
+		Photos:         []datasubject.Photo{},
+		FaceEmbeddings: []datasubject.FaceEmbedding{},
+		Checkins:       []json.RawMessage{},
+		Checkouts:      []json.RawMessage{},
+		Consents:       []json.RawMessage{},
+	}
+
+	// Perfil sem as colunas de embedding, exportados decifrados, e sem a senha
+	profileQuery := `SELECT to_jsonb(e) - ARRAY['face_embedding', 'face_embedding_sealed', 'face_embedding_vector']
+		FROM employees e WHERE e.tenant_id = $1 AND e.id = $2`
+	if subjectType == constants.DataSubjectPartner {
+		profileQuery = `SELECT to_jsonb(p) - 'password_hash' FROM partners p WHERE p.tenant_id = $1 AND p.id = $2`
+	}
+
+	var profile []byte
+	if err := tx.GetContext(ctx, &profile, profileQuery, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("Data subject", subjectID.String())
+		}
+		repo.logger.Error("Failed to collect data subject profile", zap.Error(err), zap.String("subject_id", subjectID.String()))
+		return nil, fmt.Errorf("failed to collect data subject profile: %w", err)
+	}
+	export.Profile = profile
+
+	if subjectType == constants.DataSubjectEmployee {
+		for _, source := range employeeBiometricQueries {
+			if err := repo.collectBiometrics(ctx, tx, tenantID, source.kind, source.query, args, export); err != nil {
+				return nil, err
+			}
+		}
+
+		if export.Checkins, err = selectJSON(ctx, tx, `
+			SELECT to_jsonb(c) - ARRAY['face_embedding', 'face_embedding_sealed', 'face_embedding_digest']
+			FROM checkin c WHERE c.id_tenant = $1 AND c.id_employee = $2 ORDER BY c.checkin_time`, args...); err != nil {
+			return nil, err
+		}
+
+		if export.Checkouts, err = selectJSON(ctx, tx, `
+			SELECT to_jsonb(c) FROM checkout c WHERE c.id_tenant = $1 AND c.id_employee = $2 ORDER BY c.checkout_time`, args...); err != nil {
+			return nil, err
+		}
+
+		if export.Consents, err = selectJSON(ctx, tx, `
+			SELECT to_jsonb(bc) FROM employee_biometric_consents bc
+			WHERE bc.tenant_id = $1 AND bc.employee_id = $2 ORDER BY bc.granted_at`, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	if export.AuditEntries, err = selectJSON(ctx, tx, `
+		SELECT to_jsonb(a) FROM audit_log a
+		WHERE a.id_tenant = $1 AND a.record_id IN (`+subjectRecordIDs(subjectType)+`)
+		ORDER BY a.created_at`, args...); err != nil {
+		return nil, err
+	}
+
+	return export, tx.Commit()
+}
+
+// collectBiometrics adiciona à exportação as fotos e os embeddings decifrados de um tipo de registro
+func (repo *DataSubjectRepository) collectBiometrics(ctx context.Context, tx *sqlx.Tx, tenantID value_objects.UUID, kind, query string, args []interface{}, export *datasubject.Export) error {
+	var rows []subjectBiometricRow
+	if err := tx.SelectContext(ctx, &rows, query, args...); err != nil {
+		repo.logger.Error("Failed to collect data subject biometrics", zap.Error(err), zap.String("kind", kind))
+		return fmt.Errorf("failed to collect %s biometrics: %w", kind, err)
+	}
+
+	for _, row := range rows {
+		var capturedAt *time.Time
+		if row.CapturedAt.Valid {
+			capturedAt = &row.CapturedAt.Time
+		}
+
+		if row.PhotoKey.Valid && row.PhotoKey.String != "" {
+			export.Photos = append(export.Photos, datasubject.Photo{Key: row.PhotoKey.String, Source: kind, RecordID: row.ID})
+		}
+
+		vector := []float32(row.Legacy)
+		if len(row.Sealed) > 0 {
+			id, err := value_objects.ParseUUID(row.ID)
+			if err != nil {
+				return fmt.Errorf("invalid %s ID: %w", kind, err)
+			}
+
+			plaintext, err := repo.cipher.Open(ctx, tenantID, row.Sealed, biometric.AssociatedData(kind, id))
+			if err == nil {
+				vector, err = biometric.DecodeEmbedding(plaintext)
+			}
+			if err != nil {
+				repo.logger.Error("Failed to open data subject embedding", zap.Error(err), zap.String("kind", kind), zap.String("id", row.ID))
+				return fmt.Errorf("failed to open %s embedding: %w", kind, err)
+			}
+		}
+
+		if len(vector) > 0 {
+			export.FaceEmbeddings = append(export.FaceEmbeddings, datasubject.FaceEmbedding{
+				Source:     kind,
+				RecordID:   row.ID,
+				Vector:     vector,
+				CapturedAt: capturedAt,
+			})
+		}
+	}
+
+	return nil
+}
+
+// ListPhotoKeys lista as chaves das fotos do titular; contatos de parceiros não têm fotos
+func (repo *DataSubjectRepository) ListPhotoKeys(ctx context.Context, tenantID value_objects.UUID, subjectType string, subjectID value_objects.UUID) ([]string, error) {
+	if subjectType != constants.DataSubjectEmployee {
+		return nil, nil
+	}
+
+	query := `
+		SELECT photo_key FROM employees WHERE tenant_id = $1 AND id = $2 AND photo_key IS NOT NULL
+		UNION
+		SELECT source_photo_key FROM employee_face_templates WHERE tenant_id = $1 AND employee_id = $2 AND source_photo_key IS NOT NULL
+		UNION
+		SELECT photo_key FROM checkin WHERE id_tenant = $1 AND id_employee = $2 AND photo_key IS NOT NULL`
+
+	var keys []string
+	if err := repo.db.SelectContext(ctx, &keys, query, tenantID.String(), subjectID.String()); err != nil {
+		repo.logger.Error("Failed to list data subject photos", zap.Error(err), zap.String("subject_id", subjectID.String()))
+		return nil, fmt.Errorf("failed to list data subject photos: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Erase anonimiza o cadastro, remove os templates faciais, encerra o consentimento biométrico vigente e apaga das presenças
+// as fotos, os embeddings e as observações.
+// Horários, locais e vínculos com evento e parceiro são mantidos para as estatísticas; a auditoria perde os valores gravados.
+func (repo *DataSubjectRepository) Erase(ctx context.Context, tenantID value_objects.UUID, subjectType string, subjectID, erasedBy value_objects.UUID) (*datasubject.Erasure, error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin erasure transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	args := []interface{}{tenantID.String(), subjectID.String()}
+	erasure := &datasubject.Erasure{Records: map[string]int{}}
+
+	profileStatement := `
+		UPDATE employees
+		SET full_name = $3, identity = '', date_of_birth = NULL, photo_url = NULL, photo_key = NULL,
+		    face_embedding = NULL, face_embedding_sealed = NULL, face_embedding_vector = NULL,
+		    phone = '', email = '', active = false, updated_at = $4, updated_by = $5
+		WHERE tenant_id = $1 AND id = $2`
+	if subjectType == constants.DataSubjectPartner {
+		profileStatement = `
+			UPDATE partners
+			SET name = $3, email = '', email2 = NULL, phone = '', phone2 = NULL, identity = '', location = '',
+			    password_hash = '', last_login = NULL, failed_login_attempts = 0, locked_until = NULL,
+			    active = false, updated_at = $4, updated_by = $5
+			WHERE tenant_id = $1 AND id = $2`
+	}
+
+	profiles, err := execCount(ctx, tx, profileStatement, append(args, constants.DataSubjectAnonymizedName, now, erasedBy.String())...)
+	if err != nil {
+		return nil, repo.erasureError(err, subjectID)
+	}
+	if profiles == 0 {
+		return nil, errors.NewNotFoundError("Data subject", subjectID.String())
+	}
+	erasure.Records[datasubject.CategoryProfile] = profiles
+
+	// A auditoria é anonimizada antes das presenças, enquanto a subconsulta ainda encontra os registros do titular
+	if erasure.Records[datasubject.CategoryAuditEntries], err = execCount(ctx, tx, `
+		UPDATE audit_log SET old_values = NULL, new_values = NULL
+		WHERE id_tenant = $1 AND record_id IN (`+subjectRecordIDs(subjectType)+`)
+		  AND (old_values IS NOT NULL OR new_values IS NOT NULL)`, args...); err != nil {
+		return nil, repo.erasureError(err, subjectID)
+	}
+
+	if subjectType == constants.DataSubjectEmployee {
+		statements := []struct {
+			category  string
+			statement string
+		}{
+			{datasubject.CategoryFaceTemplates, `DELETE FROM employee_face_templates WHERE tenant_id = $1 AND employee_id = $2`},
+			{datasubject.CategoryCheckins, `
+				UPDATE checkin
+				SET photo_url = NULL, photo_key = NULL, face_embedding = NULL, face_embedding_sealed = NULL,
+				    face_embedding_digest = NULL, notes = NULL, updated_at = $3
+				WHERE id_tenant = $1 AND id_employee = $2`},
+			{datasubject.CategoryCheckouts, `
+				UPDATE checkout SET photo_url = NULL, notes = NULL, updated_at = $3
+				WHERE id_tenant = $1 AND id_employee = $2`},
+		}
+
+		for _, s := range statements {
+			statementArgs := args
+			if strings.Contains(s.statement, "$3") {
+				statementArgs = append(args, now)
+			}
+
+			if erasure.Records[s.category], err = execCount(ctx, tx, s.statement, statementArgs...); err != nil {
+				return nil, repo.erasureError(err, subjectID)
+			}
+		}
+
+		// O consentimento vigente é encerrado; o histórico comprova a base legal do tratamento já realizado
+		if erasure.Records[datasubject.CategoryConsents], err = execCount(ctx, tx, `
+			UPDATE employee_biometric_consents
+			SET revoked_at = $3, revoked_by = $4, revocation_channel = $5, revocation_reason = $6
+			WHERE tenant_id = $1 AND employee_id = $2 AND revoked_at IS NULL`,
+			append(args, now, erasedBy.String(), constants.ConsentChannelSystem, constants.ConsentRevocationReasonErasure)...); err != nil {
+			return nil, repo.erasureError(err, subjectID)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, repo.erasureError(err, subjectID)
+	}
+
+	return erasure, nil
+}
+
+// erasureError registra a falha da eliminação; a transação é desfeita
+func (repo *DataSubjectRepository) erasureError(err error, subjectID value_objects.UUID) error {
+	repo.logger.Error("Failed to erase data subject", zap.Error(err), zap.String("subject_id", subjectID.String()))
+	return fmt.Errorf("failed to erase data subject: %w", err)
+}
+
+// selectJSON executa uma consulta que devolve um documento JSON por linha
+func selectJSON(ctx context.Context, tx *sqlx.Tx, query string, args ...interface{}) ([]json.RawMessage, error) {
+	var rows [][]byte
+	if err := tx.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to collect data subject records: %w", err)
+	}
+
+	documents := make([]json.RawMessage, len(rows))
+	for i, row := range rows {
+		documents[i] = row
+	}
+
+	return documents, nil
+}
+
+// execCount executa um comando e retorna o número de linhas afetadas
+func execCount(ctx context.Context, tx *sqlx.Tx, statement string, args ...interface{}) (int, error) {
+	result, err := tx.ExecContext(ctx, statement, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	return int(affected), err
+}
+
+// encodeEvidence converte a evidência para a coluna JSONB
+func encodeEvidence(evidence *datasubject.Evidence) (sql.NullString, error) {
+	if evidence == nil {
+		return sql.NullString{}, nil
+	}
+
+	data, err := json.Marshal(evidence)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode request evidence: %w", err)
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// nullString converte texto vazio em NULL
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
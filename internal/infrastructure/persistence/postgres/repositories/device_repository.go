@@ -0,0 +1,346 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"eventos-backend/internal/domain/device"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// DeviceRepository implementa a interface device.Repository usando PostgreSQL
+type DeviceRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewDeviceRepository cria uma nova instância do repositório de dispositivos
+func NewDeviceRepository(db *sqlx.DB, logger *zap.Logger) device.Repository {
+	return &DeviceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// deviceColumns lista as colunas lidas da tabela device
+const deviceColumns = `id_device, id_tenant, device_name, device_type, id_event, gate,
+	secret_hash, encrypted_key, credential_issued_at, app_version, last_seen_at, active,
+	revoked_at, revoked_by, revoke_reason, created_at, updated_at, created_by, updated_by`
+
+// deviceRow representa uma linha de dispositivo no banco de dados
+type deviceRow struct {
+	ID                 string         `db:"id_device"`
+	TenantID           string         `db:"id_tenant"`
+	Name               string         `db:"device_name"`
+	Type               string         `db:"device_type"`
+	EventID            sql.NullString `db:"id_event"`
+	Gate               sql.NullString `db:"gate"`
+	SecretHash         sql.NullString `db:"secret_hash"`
+	EncryptedKey       []byte         `db:"encrypted_key"`
+	CredentialIssuedAt sql.NullTime   `db:"credential_issued_at"`
+	AppVersion         sql.NullString `db:"app_version"`
+	LastSeenAt         sql.NullTime   `db:"last_seen_at"`
+	Active             bool           `db:"active"`
+	RevokedAt          sql.NullTime   `db:"revoked_at"`
+	RevokedBy          sql.NullString `db:"revoked_by"`
+	RevokeReason       sql.NullString `db:"revoke_reason"`
+	CreatedAt          time.Time      `db:"created_at"`
+	UpdatedAt          time.Time      `db:"updated_at"`
+	CreatedBy          sql.NullString `db:"created_by"`
+	UpdatedBy          sql.NullString `db:"updated_by"`
+}
+
+// toEntity converte deviceRow para entidade Device
+func (r *deviceRow) toEntity() (*device.Device, error) {
+	id, err := value_objects.ParseUUID(r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid device ID: %w", err)
+	}
+
+	tenantID, err := value_objects.ParseUUID(r.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant ID: %w", err)
+	}
+
+	d := &device.Device{
+		ID:           id,
+		TenantID:     tenantID,
+		Name:         r.Name,
+		Type:         r.Type,
+		Gate:         r.Gate.String,
+		SecretHash:   r.SecretHash.String,
+		EncryptedKey: r.EncryptedKey,
+		AppVersion:   r.AppVersion.String,
+		Active:       r.Active,
+		RevokeReason: r.RevokeReason.String,
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    r.UpdatedAt,
+		EventID:      parseNullUUID(r.EventID),
+		RevokedBy:    parseNullUUID(r.RevokedBy),
+		CreatedBy:    parseNullUUID(r.CreatedBy),
+		UpdatedBy:    parseNullUUID(r.UpdatedBy),
+	}
+
+	if r.CredentialIssuedAt.Valid {
+		d.CredentialIssuedAt = &r.CredentialIssuedAt.Time
+	}
+
+	if r.LastSeenAt.Valid {
+		d.LastSeenAt = &r.LastSeenAt.Time
+	}
+
+	if r.RevokedAt.Valid {
+		d.RevokedAt = &r.RevokedAt.Time
+	}
+
+	return d, nil
+}
+
+// fromEntity converte entidade Device para deviceRow
+func (repo *DeviceRepository) fromEntity(d *device.Device) *deviceRow {
+	row := &deviceRow{
+		ID:           d.ID.String(),
+		TenantID:     d.TenantID.String(),
+		Name:         d.Name,
+		Type:         d.Type,
+		Gate:         sql.NullString{String: d.Gate, Valid: d.Gate != ""},
+		SecretHash:   sql.NullString{String: d.SecretHash, Valid: d.SecretHash != ""},
+		EncryptedKey: d.EncryptedKey,
+		AppVersion:   sql.NullString{String: d.AppVersion, Valid: d.AppVersion != ""},
+		Active:       d.Active,
+		RevokeReason: sql.NullString{String: d.RevokeReason, Valid: d.RevokeReason != ""},
+		CreatedAt:    d.CreatedAt,
+		UpdatedAt:    d.UpdatedAt,
+		EventID:      toNullUUID(d.EventID),
+		RevokedBy:    toNullUUID(d.RevokedBy),
+		CreatedBy:    toNullUUID(d.CreatedBy),
+		UpdatedBy:    toNullUUID(d.UpdatedBy),
+	}
+
+	if d.CredentialIssuedAt != nil {
+		row.CredentialIssuedAt = sql.NullTime{Time: *d.CredentialIssuedAt, Valid: true}
+	}
+
+	if d.LastSeenAt != nil {
+		row.LastSeenAt = sql.NullTime{Time: *d.LastSeenAt, Valid: true}
+	}
+
+	if d.RevokedAt != nil {
+		row.RevokedAt = sql.NullTime{Time: *d.RevokedAt, Valid: true}
+	}
+
+	return row
+}
+
+// Create cria um novo dispositivo
+func (repo *DeviceRepository) Create(ctx context.Context, d *device.Device) error {
+	query := `
+		INSERT INTO device (
+			id_device, id_tenant, device_name, device_type, id_event, gate,
+			secret_hash, encrypted_key, credential_issued_at, app_version, last_seen_at, active,
+			revoked_at, revoked_by, revoke_reason, created_at, updated_at, created_by, updated_by
+		) VALUES (
+			:id_device, :id_tenant, :device_name, :device_type, :id_event, :gate,
+			:secret_hash, :encrypted_key, :credential_issued_at, :app_version, :last_seen_at, :active,
+			:revoked_at, :revoked_by, :revoke_reason, :created_at, :updated_at, :created_by, :updated_by
+		)`
+
+	if _, err := repo.db.NamedExecContext(ctx, query, repo.fromEntity(d)); err != nil {
+		repo.logger.Error("Failed to create device", zap.Error(err), zap.String("device_id", d.ID.String()))
+		return fmt.Errorf("failed to create device: %w", err)
+	}
+
+	repo.logger.Info("Device created successfully",
+		zap.String("device_id", d.ID.String()),
+		zap.String("tenant_id", d.TenantID.String()),
+		zap.String("type", d.Type))
+	return nil
+}
+
+// GetByID busca um dispositivo pelo ID
+func (repo *DeviceRepository) GetByID(ctx context.Context, id value_objects.UUID) (*device.Device, error) {
+	var row deviceRow
+
+	query := `SELECT ` + deviceColumns + ` FROM device WHERE id_device = $1`
+
+	if err := repo.db.GetContext(ctx, &row, query, id.String()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("device not found: %w", errors.ErrNotFound)
+		}
+		repo.logger.Error("Failed to get device by ID", zap.Error(err), zap.String("device_id", id.String()))
+		return nil, fmt.Errorf("failed to get device: %w", err)
+	}
+
+	return row.toEntity()
+}
+
+// GetByIDAndTenant busca um dispositivo pelo ID dentro de um tenant
+func (repo *DeviceRepository) GetByIDAndTenant(ctx context.Context, id, tenantID value_objects.UUID) (*device.Device, error) {
+	var row deviceRow
+
+	query := `SELECT ` + deviceColumns + ` FROM device WHERE id_device = $1 AND id_tenant = $2`
+
+	if err := repo.db.GetContext(ctx, &row, query, id.String(), tenantID.String()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("device not found: %w", errors.ErrNotFound)
+		}
+		repo.logger.Error("Failed to get device by ID and tenant",
+			zap.Error(err),
+			zap.String("device_id", id.String()),
+			zap.String("tenant_id", tenantID.String()))
+		return nil, fmt.Errorf("failed to get device: %w", err)
+	}
+
+	return row.toEntity()
+}
+
+// Update atualiza um dispositivo existente
+func (repo *DeviceRepository) Update(ctx context.Context, d *device.Device) error {
+	query := `
+		UPDATE device SET
+			device_name = :device_name,
+			id_event = :id_event,
+			gate = :gate,
+			secret_hash = :secret_hash,
+			encrypted_key = :encrypted_key,
+			credential_issued_at = :credential_issued_at,
+			app_version = :app_version,
+			last_seen_at = :last_seen_at,
+			active = :active,
+			revoked_at = :revoked_at,
+			revoked_by = :revoked_by,
+			revoke_reason = :revoke_reason,
+			updated_at = :updated_at,
+			updated_by = :updated_by
+		WHERE id_device = :id_device AND id_tenant = :id_tenant`
+
+	result, err := repo.db.NamedExecContext(ctx, query, repo.fromEntity(d))
+	if err != nil {
+		repo.logger.Error("Failed to update device", zap.Error(err), zap.String("device_id", d.ID.String()))
+		return fmt.Errorf("failed to update device: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("device not found: %w", errors.ErrNotFound)
+	}
+
+	return nil
+}
+
+// TouchLastSeen registra o último acesso do dispositivo
+func (repo *DeviceRepository) TouchLastSeen(ctx context.Context, id value_objects.UUID, appVersion string, at time.Time) error {
+	query := `
+		UPDATE device SET
+			last_seen_at = $2,
+			app_version = COALESCE(NULLIF($3, ''), app_version)
+		WHERE id_device = $1`
+
+	if _, err := repo.db.ExecContext(ctx, query, id.String(), at, appVersion); err != nil {
+		repo.logger.Warn("Failed to record device last seen", zap.Error(err), zap.String("device_id", id.String()))
+		return fmt.Errorf("failed to record device last seen: %w", err)
+	}
+
+	return nil
+}
+
+// ListByTenant lista os dispositivos de um tenant com paginação e filtros
+func (repo *DeviceRepository) ListByTenant(ctx context.Context, tenantID value_objects.UUID, filters device.ListFilters) ([]*device.Device, int, error) {
+	if err := filters.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	conditions := []string{"id_tenant = $1"}
+	args := []interface{}{tenantID.String()}
+	argIndex := 2
+
+	if filters.EventID != nil {
+		conditions = append(conditions, fmt.Sprintf("id_event = $%d", argIndex))
+		args = append(args, filters.EventID.String())
+		argIndex++
+	}
+
+	if filters.Type != nil {
+		conditions = append(conditions, fmt.Sprintf("device_type = $%d", argIndex))
+		args = append(args, *filters.Type)
+		argIndex++
+	}
+
+	if filters.Active != nil {
+		conditions = append(conditions, fmt.Sprintf("active = $%d", argIndex))
+		args = append(args, *filters.Active)
+		argIndex++
+	}
+
+	if filters.Revoked != nil {
+		if *filters.Revoked {
+			conditions = append(conditions, "revoked_at IS NOT NULL")
+		} else {
+			conditions = append(conditions, "revoked_at IS NULL")
+		}
+	}
+
+	whereClause := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := repo.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM device"+whereClause, args...); err != nil {
+		repo.logger.Error("Failed to count devices", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to count devices: %w", err)
+	}
+
+	dataQuery := `SELECT ` + deviceColumns + ` FROM device` + whereClause +
+		fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filters.PageSize, filters.GetOffset())
+
+	var rows []deviceRow
+	if err := repo.db.SelectContext(ctx, &rows, dataQuery, args...); err != nil {
+		repo.logger.Error("Failed to list devices", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	devices := make([]*device.Device, 0, len(rows))
+	for _, row := range rows {
+		d, err := row.toEntity()
+		if err != nil {
+			repo.logger.Warn("Failed to convert device row", zap.Error(err), zap.String("device_id", row.ID))
+			continue
+		}
+		devices = append(devices, d)
+	}
+
+	return devices, total, nil
+}
+
+// parseNullUUID converte uma coluna UUID anulável; valores inválidos são tratados como nulos
+func parseNullUUID(value sql.NullString) *value_objects.UUID {
+	if !value.Valid {
+		return nil
+	}
+
+	id, err := value_objects.ParseUUID(value.String)
+	if err != nil {
+		return nil
+	}
+
+	return &id
+}
+
+// toNullUUID converte um UUID opcional para coluna anulável
+func toNullUUID(id *value_objects.UUID) sql.NullString {
+	if id == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: id.String(), Valid: true}
+}
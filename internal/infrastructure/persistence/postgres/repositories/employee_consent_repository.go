@@ -0,0 +1,374 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"eventos-backend/internal/domain/employee"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"go.uber.org/zap"
+)
+
+// activeConsentCondition restringe o reconhecimento facial aos funcionários (e) com consentimento biométrico vigente
+const activeConsentCondition = `EXISTS (
+			SELECT 1 FROM employee_biometric_consents bc
+			WHERE bc.employee_id = e.id AND bc.revoked_at IS NULL)`
+
+// consentTextRow representa uma linha de termo de consentimento no banco de dados
+type consentTextRow struct {
+	ID          string         `db:"id"`
+	TenantID    string         `db:"tenant_id"`
+	Version     int            `db:"version"`
+	Content     string         `db:"content"`
+	Digest      string         `db:"digest"`
+	PublishedAt time.Time      `db:"published_at"`
+	PublishedBy sql.NullString `db:"published_by"`
+}
+
+// toEntity converte consentTextRow para entidade ConsentText
+func (r *consentTextRow) toEntity() (*employee.ConsentText, error) {
+	id, err := value_objects.ParseUUID(r.ID)
+	if err != nil {
+		return nil, errors.NewDomainError("INVALID_ID", "invalid consent text ID", err)
+	}
+
+	tenantID, err := value_objects.ParseUUID(r.TenantID)
+	if err != nil {
+		return nil, errors.NewDomainError("INVALID_TENANT_ID", "invalid tenant ID", err)
+	}
+
+	return &employee.ConsentText{
+		ID:          id,
+		TenantID:    tenantID,
+		Version:     r.Version,
+		Content:     r.Content,
+		Digest:      r.Digest,
+		PublishedAt: r.PublishedAt,
+		PublishedBy: parseNullUUID(r.PublishedBy),
+	}, nil
+}
+
+// biometricConsentRow representa uma linha de consentimento biométrico no banco de dados
+type biometricConsentRow struct {
+	ID                string         `db:"id"`
+	TenantID          string         `db:"tenant_id"`
+	EmployeeID        string         `db:"employee_id"`
+	TextVersion       int            `db:"text_version"`
+	TextDigest        string         `db:"text_digest"`
+	Channel           string         `db:"channel"`
+	GrantedAt         time.Time      `db:"granted_at"`
+	GrantedBy         sql.NullString `db:"granted_by"`
+	RevokedAt         sql.NullTime   `db:"revoked_at"`
+	RevokedBy         sql.NullString `db:"revoked_by"`
+	RevocationChannel sql.NullString `db:"revocation_channel"`
+	RevocationReason  sql.NullString `db:"revocation_reason"`
+}
+
+// biometricConsentColumns lista as colunas lidas da tabela employee_biometric_consents
+const biometricConsentColumns = `id, tenant_id, employee_id, text_version, text_digest, channel, granted_at, granted_by,
+	revoked_at, revoked_by, revocation_channel, revocation_reason`
+
+// toEntity converte biometricConsentRow para entidade BiometricConsent
+func (r *biometricConsentRow) toEntity() (*employee.BiometricConsent, error) {
+	id, err := value_objects.ParseUUID(r.ID)
+	if err != nil {
+		return nil, errors.NewDomainError("INVALID_ID", "invalid biometric consent ID", err)
+	}
+
+	tenantID, err := value_objects.ParseUUID(r.TenantID)
+	if err != nil {
+		return nil, errors.NewDomainError("INVALID_TENANT_ID", "invalid tenant ID", err)
+	}
+
+	employeeID, err := value_objects.ParseUUID(r.EmployeeID)
+	if err != nil {
+		return nil, errors.NewDomainError("INVALID_EMPLOYEE_ID", "invalid employee ID", err)
+	}
+
+	consent := &employee.BiometricConsent{
+		ID:                id,
+		TenantID:          tenantID,
+		EmployeeID:        employeeID,
+		TextVersion:       r.TextVersion,
+		TextDigest:        r.TextDigest,
+		Channel:           r.Channel,
+		GrantedAt:         r.GrantedAt,
+		GrantedBy:         parseNullUUID(r.GrantedBy),
+		RevokedBy:         parseNullUUID(r.RevokedBy),
+		RevocationChannel: r.RevocationChannel.String,
+		RevocationReason:  r.RevocationReason.String,
+	}
+
+	if r.RevokedAt.Valid {
+		consent.RevokedAt = &r.RevokedAt.Time
+	}
+
+	return consent, nil
+}
+
+// CreateConsentText grava uma nova versão do termo de consentimento biométrico do tenant
+func (repo *EmployeeRepository) CreateConsentText(ctx context.Context, text *employee.ConsentText) error {
+	query := `
+		INSERT INTO biometric_consent_texts (id, tenant_id, version, content, digest, published_at, published_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := repo.db.ExecContext(ctx, query,
+		text.ID.String(), text.TenantID.String(), text.Version, text.Content, text.Digest, text.PublishedAt, toNullUUID(text.PublishedBy))
+	if err != nil {
+		repo.logger.Error("Failed to create consent text", zap.Error(err), zap.String("tenant_id", text.TenantID.String()))
+		return errors.NewInternalError("failed to create consent text", err)
+	}
+
+	return nil
+}
+
+// GetLatestConsentText busca a versão mais recente do termo do tenant
+func (repo *EmployeeRepository) GetLatestConsentText(ctx context.Context, tenantID value_objects.UUID) (*employee.ConsentText, error) {
+	query := `
+		SELECT id, tenant_id, version, content, digest, published_at, published_by
+		FROM biometric_consent_texts
+		WHERE tenant_id = $1
+		ORDER BY version DESC
+		LIMIT 1`
+
+	var row consentTextRow
+	if err := repo.db.GetContext(ctx, &row, query, tenantID.String()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("consent text", tenantID.String())
+		}
+		repo.logger.Error("Failed to get consent text", zap.Error(err), zap.String("tenant_id", tenantID.String()))
+		return nil, errors.NewInternalError("failed to get consent text", err)
+	}
+
+	return row.toEntity()
+}
+
+// CreateBiometricConsent grava o consentimento; o consentimento vigente anterior do funcionário é encerrado como substituído
+func (repo *EmployeeRepository) CreateBiometricConsent(ctx context.Context, consent *employee.BiometricConsent) error {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.NewInternalError("failed to create biometric consent", err)
+	}
+	defer tx.Rollback()
+
+	supersede := `
+		UPDATE employee_biometric_consents
+		SET revoked_at = $3, revoked_by = $4, revocation_channel = $5, revocation_reason = $6
+		WHERE tenant_id = $1 AND employee_id = $2 AND revoked_at IS NULL`
+
+	if _, err := tx.ExecContext(ctx, supersede,
+		consent.TenantID.String(), consent.EmployeeID.String(), consent.GrantedAt, toNullUUID(consent.GrantedBy),
+		constants.ConsentChannelSystem, constants.ConsentRevocationReasonSuperseded); err != nil {
+		repo.logger.Error("Failed to supersede biometric consent", zap.Error(err), zap.String("employee_id", consent.EmployeeID.String()))
+		return errors.NewInternalError("failed to create biometric consent", err)
+	}
+
+	insert := `
+		INSERT INTO employee_biometric_consents (
+			id, tenant_id, employee_id, text_version, text_digest, channel, granted_at, granted_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	if _, err := tx.ExecContext(ctx, insert,
+		consent.ID.String(), consent.TenantID.String(), consent.EmployeeID.String(), consent.TextVersion, consent.TextDigest,
+		consent.Channel, consent.GrantedAt, toNullUUID(consent.GrantedBy)); err != nil {
+		repo.logger.Error("Failed to create biometric consent", zap.Error(err), zap.String("employee_id", consent.EmployeeID.String()))
+		return errors.NewInternalError("failed to create biometric consent", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewInternalError("failed to create biometric consent", err)
+	}
+
+	return nil
+}
+
+// RevokeBiometricConsent grava a revogação e elimina, na mesma transação, os dados biométricos do funcionário
+func (repo *EmployeeRepository) RevokeBiometricConsent(ctx context.Context, consent *employee.BiometricConsent) (*employee.BiometricPurge, error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	tenantID, employeeID := consent.TenantID.String(), consent.EmployeeID.String()
+
+	revoke := `
+		UPDATE employee_biometric_consents
+		SET revoked_at = $3, revoked_by = $4, revocation_channel = $5, revocation_reason = $6
+		WHERE id = $1 AND tenant_id = $2 AND revoked_at IS NULL`
+
+	result, err := tx.ExecContext(ctx, revoke,
+		consent.ID.String(), tenantID, consent.RevokedAt, toNullUUID(consent.RevokedBy),
+		consent.RevocationChannel, nullString(consent.RevocationReason))
+	if err != nil {
+		return nil, err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return nil, errors.NewNotFoundError("biometric consent", consent.ID.String())
+	}
+
+	purge := &employee.BiometricPurge{}
+
+	if purge.FaceTemplates, err = execCount(ctx, tx,
+		`DELETE FROM employee_face_templates WHERE tenant_id = $1 AND employee_id = $2`, tenantID, employeeID); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE employees
+		SET face_embedding = NULL, face_embedding_sealed = NULL, face_embedding_vector = NULL,
+		    updated_at = $3, updated_by = $4
+		WHERE tenant_id = $1 AND id = $2`, tenantID, employeeID, consent.RevokedAt, toNullUUID(consent.RevokedBy)); err != nil {
+		return nil, err
+	}
+
+	if purge.CheckinEmbeddings, err = execCount(ctx, tx, `
+		UPDATE checkin
+		SET face_embedding = NULL, face_embedding_sealed = NULL, face_embedding_digest = NULL, updated_at = $3
+		WHERE id_tenant = $1 AND id_employee = $2
+		  AND (face_embedding IS NOT NULL OR face_embedding_sealed IS NOT NULL OR face_embedding_digest IS NOT NULL)`,
+		tenantID, employeeID, consent.RevokedAt); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return purge, nil
+}
+
+// ListBiometricConsents lista o histórico de consentimentos do funcionário, do mais recente ao mais antigo
+func (repo *EmployeeRepository) ListBiometricConsents(ctx context.Context, tenantID, employeeID value_objects.UUID) ([]*employee.BiometricConsent, error) {
+	query := `SELECT ` + biometricConsentColumns + `
+		FROM employee_biometric_consents
+		WHERE tenant_id = $1 AND employee_id = $2
+		ORDER BY granted_at DESC`
+
+	var rows []biometricConsentRow
+	if err := repo.db.SelectContext(ctx, &rows, query, tenantID.String(), employeeID.String()); err != nil {
+		repo.logger.Error("Failed to list biometric consents", zap.Error(err), zap.String("employee_id", employeeID.String()))
+		return nil, errors.NewInternalError("failed to list biometric consents", err)
+	}
+
+	consents := make([]*employee.BiometricConsent, 0, len(rows))
+	for i := range rows {
+		consent, err := rows[i].toEntity()
+		if err != nil {
+			repo.logger.Warn("Failed to convert biometric consent row", zap.Error(err), zap.String("consent_id", rows[i].ID))
+			continue
+		}
+		consents = append(consents, consent)
+	}
+
+	return consents, nil
+}
+
+// getActiveConsent busca o consentimento vigente do funcionário; nil quando não há
+func (repo *EmployeeRepository) getActiveConsent(ctx context.Context, employeeID value_objects.UUID) (*employee.BiometricConsent, error) {
+	query := `SELECT ` + biometricConsentColumns + `
+		FROM employee_biometric_consents
+		WHERE employee_id = $1 AND revoked_at IS NULL`
+
+	var row biometricConsentRow
+	if err := repo.db.GetContext(ctx, &row, query, employeeID.String()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		repo.logger.Error("Failed to get biometric consent", zap.Error(err), zap.String("employee_id", employeeID.String()))
+		return nil, errors.NewInternalError("failed to get biometric consent", err)
+	}
+
+	return row.toEntity()
+}
+
+// eventConsentEmployees lista cada vínculo de funcionário ativo com parceiro do evento ($1 tenant, $2 evento)
+const eventConsentEmployees = `
+	WITH event_employees AS (
+		SELECT p.id AS partner_id, p.name AS partner_name, e.id AS employee_id,
+		       (e.face_embedding_sealed IS NOT NULL OR e.face_embedding IS NOT NULL) AS face_enrolled,
+		       ` + activeConsentCondition + ` AS consented
+		FROM event_partner ep
+		JOIN partners p ON p.id = ep.id_partner AND p.tenant_id = $1
+		JOIN partner_employee pe ON pe.id_partner = p.id
+		JOIN employees e ON e.id = pe.id_employee AND e.tenant_id = $1 AND e.active = true
+		WHERE ep.id_event = $2
+	)`
+
+// consentCountsRow representa as contagens de consentimento de um grupo de funcionários
+type consentCountsRow struct {
+	PartnerID              sql.NullString `db:"partner_id"`
+	PartnerName            sql.NullString `db:"partner_name"`
+	Employees              int            `db:"employees"`
+	Consented              int            `db:"consented"`
+	FaceEnrolled           int            `db:"face_enrolled"`
+	EnrolledWithoutConsent int            `db:"enrolled_without_consent"`
+}
+
+// counts converte a linha para as contagens do domínio
+func (r *consentCountsRow) counts() employee.ConsentCounts {
+	return employee.ConsentCounts{
+		Employees:              r.Employees,
+		Consented:              r.Consented,
+		FaceEnrolled:           r.FaceEnrolled,
+		EnrolledWithoutConsent: r.EnrolledWithoutConsent,
+	}
+}
+
+// GetEventConsentCoverage conta, por parceiro, os funcionários ativos do evento com e sem consentimento vigente.
+// No total do evento, quem participa por mais de um parceiro é contado uma vez.
+func (repo *EmployeeRepository) GetEventConsentCoverage(ctx context.Context, tenantID, eventID value_objects.UUID) (*employee.ConsentCoverage, error) {
+	totalQuery := eventConsentEmployees + `
+		SELECT NULL AS partner_id, NULL AS partner_name,
+		       COUNT(DISTINCT employee_id) AS employees,
+		       COUNT(DISTINCT employee_id) FILTER (WHERE consented) AS consented,
+		       COUNT(DISTINCT employee_id) FILTER (WHERE face_enrolled) AS face_enrolled,
+		       COUNT(DISTINCT employee_id) FILTER (WHERE face_enrolled AND NOT consented) AS enrolled_without_consent
+		FROM event_employees`
+
+	var total consentCountsRow
+	if err := repo.db.GetContext(ctx, &total, totalQuery, tenantID.String(), eventID.String()); err != nil {
+		return nil, err
+	}
+
+	partnerQuery := eventConsentEmployees + `
+		SELECT partner_id, partner_name,
+		       COUNT(*) AS employees,
+		       COUNT(*) FILTER (WHERE consented) AS consented,
+		       COUNT(*) FILTER (WHERE face_enrolled) AS face_enrolled,
+		       COUNT(*) FILTER (WHERE face_enrolled AND NOT consented) AS enrolled_without_consent
+		FROM event_employees
+		GROUP BY partner_id, partner_name
+		ORDER BY partner_name`
+
+	var rows []consentCountsRow
+	if err := repo.db.SelectContext(ctx, &rows, partnerQuery, tenantID.String(), eventID.String()); err != nil {
+		return nil, err
+	}
+
+	coverage := &employee.ConsentCoverage{
+		EventID:       eventID,
+		ConsentCounts: total.counts(),
+		Partners:      make([]*employee.PartnerConsentCoverage, 0, len(rows)),
+	}
+
+	for i := range rows {
+		partnerID, err := value_objects.ParseUUID(rows[i].PartnerID.String)
+		if err != nil {
+			repo.logger.Warn("Failed to convert consent coverage row", zap.Error(err), zap.String("partner_id", rows[i].PartnerID.String))
+			continue
+		}
+
+		coverage.Partners = append(coverage.Partners, &employee.PartnerConsentCoverage{
+			PartnerID:     partnerID,
+			PartnerName:   rows[i].PartnerName.String,
+			ConsentCounts: rows[i].counts(),
+		})
+	}
+
+	return coverage, nil
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"eventos-backend/internal/domain/biometric"
 	"eventos-backend/internal/domain/employee"
+	"eventos-backend/internal/domain/shared/constants"
 	"eventos-backend/internal/domain/shared/errors"
 	"eventos-backend/internal/domain/shared/value_objects"
 
@@ -16,16 +20,25 @@ import (
 	"go.uber.org/zap"
 )
 
-// EmployeeRepository implementa a interface employee.Repository usando PostgreSQL
+// EmployeeRepository implementa a interface employee.Repository usando PostgreSQL.
+// Os embeddings faciais são gravados cifrados e só são abertos por OpenFaceEmbedding e OpenFaceTemplates,
+// no reconhecimento e no cadastro facial; o índice vetorial recebe apenas os vetores protegidos do tenant.
 type EmployeeRepository struct {
 	db     *sqlx.DB
+	cipher biometric.Cipher
 	logger *zap.Logger
+
+	// Suporte do pgvector instalado à varredura iterativa, verificado na primeira busca facial
+	vectorMu       sync.Mutex
+	vectorChecked  bool
+	iterativeScans bool
 }
 
 // NewEmployeeRepository cria uma nova instância do repositório de funcionários
-func NewEmployeeRepository(db *sqlx.DB, logger *zap.Logger) employee.Repository {
+func NewEmployeeRepository(db *sqlx.DB, cipher biometric.Cipher, logger *zap.Logger) employee.Repository {
 	return &EmployeeRepository{
 		db:     db,
+		cipher: cipher,
 		logger: logger,
 	}
 }
@@ -39,7 +52,10 @@ type employeeRow struct {
 	IdentityType  string          `db:"identity_type"`
 	DateOfBirth   sql.NullTime    `db:"date_of_birth"`
 	PhotoURL      sql.NullString  `db:"photo_url"`
-	FaceEmbedding pq.Float32Array `db:"face_embedding"`
+	PhotoKey      sql.NullString  `db:"photo_key"`
+	FaceEmbedding pq.Float32Array `db:"face_embedding"` // Texto claro anterior à cifragem
+	Sealed        []byte          `db:"face_embedding_sealed"`
+	SearchVector  pq.Float32Array `db:"face_embedding_vector"`
 	Phone         string          `db:"phone"`
 	Email         string          `db:"email"`
 	Active        bool            `db:"active"`
@@ -82,10 +98,13 @@ func (r *employeeRow) toEntity() (*employee.Employee, error) {
 		emp.PhotoURL = r.PhotoURL.String
 	}
 
-	if len(r.FaceEmbedding) > 0 {
-		emp.FaceEmbedding = []float32(r.FaceEmbedding)
+	if r.PhotoKey.Valid {
+		emp.PhotoKey = r.PhotoKey.String
 	}
 
+	emp.FaceEnrolled = len(r.Sealed) > 0 || len(r.FaceEmbedding) > 0
+	emp.SealedFaceEmbedding = r.Sealed
+
 	if r.CreatedBy.Valid {
 		createdBy, err := value_objects.ParseUUID(r.CreatedBy.String)
 		if err == nil {
@@ -126,8 +145,8 @@ func (repo *EmployeeRepository) fromEntity(emp *employee.Employee) *employeeRow
 		row.PhotoURL = sql.NullString{String: emp.PhotoURL, Valid: true}
 	}
 
-	if len(emp.FaceEmbedding) > 0 {
-		row.FaceEmbedding = pq.Float32Array(emp.FaceEmbedding)
+	if emp.PhotoKey != "" {
+		row.PhotoKey = sql.NullString{String: emp.PhotoKey, Valid: true}
 	}
 
 	if emp.CreatedBy != nil {
@@ -144,15 +163,19 @@ func (repo *EmployeeRepository) fromEntity(emp *employee.Employee) *employeeRow
 // Create cria um novo funcionário
 func (repo *EmployeeRepository) Create(ctx context.Context, emp *employee.Employee) error {
 	row := repo.fromEntity(emp)
+	if err := repo.sealFaceEmbedding(ctx, emp, row); err != nil {
+		return err
+	}
 
 	query := `
 		INSERT INTO employees (
 			id, tenant_id, full_name, identity, identity_type,
-			date_of_birth, photo_url, face_embedding, phone, email,
+			date_of_birth, photo_url, photo_key, face_embedding_sealed, face_embedding_vector, phone, email,
 			active, created_at, updated_at, created_by, updated_by
 		) VALUES (
 			:id, :tenant_id, :full_name, :identity, :identity_type,
-			:date_of_birth, :photo_url, :face_embedding, :phone, :email,
+			:date_of_birth, :photo_url, :photo_key, :face_embedding_sealed,
+			CAST(CAST(:face_embedding_vector AS real[]) AS vector(512)), :phone, :email,
 			:active, :created_at, :updated_at, :created_by, :updated_by
 		)`
 
@@ -172,7 +195,7 @@ func (repo *EmployeeRepository) GetByID(ctx context.Context, id value_objects.UU
 
 	query := `
 		SELECT id, tenant_id, full_name, identity, identity_type,
-			   date_of_birth, photo_url, face_embedding, phone, email,
+			   date_of_birth, photo_url, photo_key, face_embedding, face_embedding_sealed, phone, email,
 			   active, created_at, updated_at, created_by, updated_by
 		FROM employees 
 		WHERE id = $1 AND active = true`
@@ -186,7 +209,7 @@ func (repo *EmployeeRepository) GetByID(ctx context.Context, id value_objects.UU
 		return nil, errors.NewInternalError("failed to get employee", err)
 	}
 
-	return row.toEntity()
+	return repo.withConsent(ctx, &row)
 }
 
 // GetByIDAndTenant busca um funcionário pelo ID dentro de um tenant
@@ -195,7 +218,7 @@ func (repo *EmployeeRepository) GetByIDAndTenant(ctx context.Context, id, tenant
 
 	query := `
 		SELECT id, tenant_id, full_name, identity, identity_type,
-			   date_of_birth, photo_url, face_embedding, phone, email,
+			   date_of_birth, photo_url, photo_key, face_embedding, face_embedding_sealed, phone, email,
 			   active, created_at, updated_at, created_by, updated_by
 		FROM employees 
 		WHERE id = $1 AND tenant_id = $2 AND active = true`
@@ -212,7 +235,105 @@ func (repo *EmployeeRepository) GetByIDAndTenant(ctx context.Context, id, tenant
 		return nil, errors.NewInternalError("failed to get employee", err)
 	}
 
-	return row.toEntity()
+	return repo.withConsent(ctx, &row)
+}
+
+// withConsent converte a linha e carrega o consentimento biométrico vigente; o embedding facial permanece cifrado
+func (repo *EmployeeRepository) withConsent(ctx context.Context, row *employeeRow) (*employee.Employee, error) {
+	emp, err := row.toEntity()
+	if err != nil {
+		return nil, err
+	}
+
+	emp.Consent, err = repo.getActiveConsent(ctx, emp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return emp, nil
+}
+
+// OpenFaceEmbedding decifra o embedding do funcionário; cadastros anteriores à cifragem são lidos em texto claro
+func (repo *EmployeeRepository) OpenFaceEmbedding(ctx context.Context, emp *employee.Employee) ([]float32, error) {
+	if len(emp.SealedFaceEmbedding) > 0 {
+		return repo.openEmbedding(ctx, emp.TenantID, biometric.RecordEmployee, emp.ID, emp.SealedFaceEmbedding, nil)
+	}
+
+	var legacy pq.Float32Array
+	query := `SELECT face_embedding FROM employees WHERE id = $1 AND face_embedding IS NOT NULL`
+	if err := repo.db.GetContext(ctx, &legacy, query, emp.ID.String()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		repo.logger.Error("Failed to get legacy face embedding", zap.Error(err), zap.String("employee_id", emp.ID.String()))
+		return nil, errors.NewInternalError("failed to open face embedding", err)
+	}
+
+	return legacy, nil
+}
+
+// OpenFaceTemplates lista os templates faciais do funcionário com os embeddings decifrados
+func (repo *EmployeeRepository) OpenFaceTemplates(ctx context.Context, employeeID value_objects.UUID) ([]*employee.FaceTemplate, error) {
+	return repo.listFaceTemplates(ctx, employeeID, true)
+}
+
+// openEmbedding decifra o embedding de um registro; registros anteriores à cifragem usam o texto claro
+func (repo *EmployeeRepository) openEmbedding(ctx context.Context, tenantID value_objects.UUID, kind string, id value_objects.UUID, sealed []byte, legacy []float32) ([]float32, error) {
+	if len(sealed) == 0 {
+		return legacy, nil
+	}
+
+	plaintext, err := repo.cipher.Open(ctx, tenantID, sealed, biometric.AssociatedData(kind, id))
+	if err != nil {
+		repo.logger.Error("Failed to open face embedding", zap.Error(err), zap.String("kind", kind), zap.String("id", id.String()))
+		return nil, errors.NewInternalError("failed to open face embedding", err)
+	}
+
+	embedding, err := biometric.DecodeEmbedding(plaintext)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to open face embedding", err)
+	}
+
+	return embedding, nil
+}
+
+// sealEmbedding cifra o embedding de um registro e calcula o vetor do índice de busca protegido
+func (repo *EmployeeRepository) sealEmbedding(ctx context.Context, tenantID value_objects.UUID, kind string, id value_objects.UUID, embedding []float32) ([]byte, pq.Float32Array, error) {
+	sealed, err := repo.cipher.Seal(ctx, tenantID, biometric.EncodeEmbedding(embedding), biometric.AssociatedData(kind, id))
+	if err != nil {
+		repo.logger.Error("Failed to seal face embedding", zap.Error(err), zap.String("kind", kind), zap.String("id", id.String()))
+		return nil, nil, errors.NewInternalError("failed to seal face embedding", err)
+	}
+
+	vector, err := repo.cipher.SearchVector(ctx, tenantID, embedding)
+	if err != nil {
+		repo.logger.Error("Failed to protect face embedding", zap.Error(err), zap.String("kind", kind), zap.String("id", id.String()))
+		return nil, nil, errors.NewInternalError("failed to seal face embedding", err)
+	}
+
+	return sealed, pq.Float32Array(vector), nil
+}
+
+// sealFaceEmbedding preenche na linha o embedding cifrado do funcionário e o vetor de busca
+func (repo *EmployeeRepository) sealFaceEmbedding(ctx context.Context, emp *employee.Employee, row *employeeRow) error {
+	if len(emp.FaceEmbedding) == 0 {
+		return nil
+	}
+
+	var err error
+	row.Sealed, row.SearchVector, err = repo.sealEmbedding(ctx, emp.TenantID, biometric.RecordEmployee, emp.ID, emp.FaceEmbedding)
+	return err
+}
+
+// searchVector transforma o embedding pesquisado para o índice de busca protegido do tenant
+func (repo *EmployeeRepository) searchVector(ctx context.Context, tenantID value_objects.UUID, embedding []float32) (pq.Float32Array, error) {
+	vector, err := repo.cipher.SearchVector(ctx, tenantID, embedding)
+	if err != nil {
+		repo.logger.Error("Failed to protect searched face embedding", zap.Error(err), zap.String("tenant_id", tenantID.String()))
+		return nil, errors.NewInternalError("failed to search face embedding", err)
+	}
+
+	return pq.Float32Array(vector), nil
 }
 
 // GetByIdentity busca um funcionário pela identidade
@@ -221,7 +342,7 @@ func (repo *EmployeeRepository) GetByIdentity(ctx context.Context, identity stri
 
 	query := `
 		SELECT id, tenant_id, full_name, identity, identity_type,
-			   date_of_birth, photo_url, face_embedding, phone, email,
+			   date_of_birth, photo_url, photo_key, face_embedding, face_embedding_sealed, phone, email,
 			   active, created_at, updated_at, created_by, updated_by
 		FROM employees 
 		WHERE identity = $1 AND active = true`
@@ -244,7 +365,7 @@ func (repo *EmployeeRepository) GetByIdentityAndTenant(ctx context.Context, iden
 
 	query := `
 		SELECT id, tenant_id, full_name, identity, identity_type,
-			   date_of_birth, photo_url, face_embedding, phone, email,
+			   date_of_birth, photo_url, photo_key, face_embedding, face_embedding_sealed, phone, email,
 			   active, created_at, updated_at, created_by, updated_by
 		FROM employees 
 		WHERE identity = $1 AND tenant_id = $2 AND active = true`
@@ -270,7 +391,7 @@ func (repo *EmployeeRepository) GetByEmail(ctx context.Context, email string) (*
 
 	query := `
 		SELECT id, tenant_id, full_name, identity, identity_type,
-			   date_of_birth, photo_url, face_embedding, phone, email,
+			   date_of_birth, photo_url, photo_key, face_embedding, face_embedding_sealed, phone, email,
 			   active, created_at, updated_at, created_by, updated_by
 		FROM employees 
 		WHERE email = $1 AND active = true`
@@ -293,7 +414,7 @@ func (repo *EmployeeRepository) GetByEmailAndTenant(ctx context.Context, email s
 
 	query := `
 		SELECT id, tenant_id, full_name, identity, identity_type,
-			   date_of_birth, photo_url, face_embedding, phone, email,
+			   date_of_birth, photo_url, photo_key, face_embedding, face_embedding_sealed, phone, email,
 			   active, created_at, updated_at, created_by, updated_by
 		FROM employees 
 		WHERE email = $1 AND tenant_id = $2 AND active = true`
@@ -316,6 +437,18 @@ func (repo *EmployeeRepository) GetByEmailAndTenant(ctx context.Context, email s
 // Update atualiza um funcionário existente
 func (repo *EmployeeRepository) Update(ctx context.Context, emp *employee.Employee) error {
 	row := repo.fromEntity(emp)
+	if err := repo.sealFaceEmbedding(ctx, emp, row); err != nil {
+		return err
+	}
+
+	// Funcionários carregados sem abrir o embedding (listagens) mantêm o cadastro facial gravado
+	faceColumns := `
+			face_embedding = NULL,
+			face_embedding_sealed = :face_embedding_sealed,
+			face_embedding_vector = CAST(CAST(:face_embedding_vector AS real[]) AS vector(512)),`
+	if emp.FaceEnrolled && len(emp.FaceEmbedding) == 0 {
+		faceColumns = ""
+	}
 
 	query := `
 		UPDATE employees SET
@@ -324,7 +457,7 @@ func (repo *EmployeeRepository) Update(ctx context.Context, emp *employee.Employ
 			identity_type = :identity_type,
 			date_of_birth = :date_of_birth,
 			photo_url = :photo_url,
-			face_embedding = :face_embedding,
+			photo_key = :photo_key,` + faceColumns + `
 			phone = :phone,
 			email = :email,
 			updated_at = :updated_at,
@@ -431,17 +564,17 @@ func (repo *EmployeeRepository) List(ctx context.Context, filters employee.ListF
 
 	if filters.HasPhoto != nil {
 		if *filters.HasPhoto {
-			conditions = append(conditions, "photo_url IS NOT NULL AND photo_url != ''")
+			conditions = append(conditions, "((photo_url IS NOT NULL AND photo_url != '') OR (photo_key IS NOT NULL AND photo_key != ''))")
 		} else {
-			conditions = append(conditions, "(photo_url IS NULL OR photo_url = '')")
+			conditions = append(conditions, "(photo_url IS NULL OR photo_url = '') AND (photo_key IS NULL OR photo_key = '')")
 		}
 	}
 
 	if filters.HasFaceEmbedding != nil {
 		if *filters.HasFaceEmbedding {
-			conditions = append(conditions, "face_embedding IS NOT NULL AND array_length(face_embedding, 1) > 0")
+			conditions = append(conditions, "(face_embedding_sealed IS NOT NULL OR array_length(face_embedding, 1) > 0)")
 		} else {
-			conditions = append(conditions, "(face_embedding IS NULL OR array_length(face_embedding, 1) = 0)")
+			conditions = append(conditions, "face_embedding_sealed IS NULL AND (face_embedding IS NULL OR array_length(face_embedding, 1) = 0)")
 		}
 	}
 
@@ -476,7 +609,7 @@ func (repo *EmployeeRepository) List(ctx context.Context, filters employee.ListF
 
 	dataQuery := `
 		SELECT id, tenant_id, full_name, identity, identity_type,
-			   date_of_birth, photo_url, face_embedding, phone, email,
+			   date_of_birth, photo_url, photo_key, face_embedding, face_embedding_sealed, phone, email,
 			   active, created_at, updated_at, created_by, updated_by ` +
 		baseQuery + whereClause + " " + orderClause + " " + limitClause
 
@@ -601,11 +734,238 @@ func (repo *EmployeeRepository) ExistsByEmailInTenant(ctx context.Context, email
 	return count > 0, nil
 }
 
-// FindByFaceEmbedding busca funcionários similares por embedding facial
+// faceHitsQuery lê do índice vetorial (pgvector, distância de cosseno) os vetores protegidos mais próximos de $1:
+// os templates faciais e, para quem ainda não tem templates, o embedding único do cadastro.
+// $2 limita os candidatos de cada índice; o filtro recebe as colunas de tenant (%[1]s) e de funcionário (%[2]s).
+func faceHitsQuery(filter string) string {
+	return `
+		SELECT * FROM (
+			SELECT t.employee_id, t.id AS template_id, t.embedding_vector <=> $1::real[]::vector(512) AS distance
+			FROM employee_face_templates t
+			WHERE ` + fmt.Sprintf(filter, "t.tenant_id", "t.employee_id") + `
+			ORDER BY t.embedding_vector <=> $1::real[]::vector(512)
+			LIMIT $2
+		) template_hits
+		UNION ALL
+		SELECT * FROM (
+			SELECT e.id, NULL::uuid, e.face_embedding_vector <=> $1::real[]::vector(512)
+			FROM employees e
+			WHERE e.face_embedding_vector IS NOT NULL AND e.active = true
+			  AND NOT EXISTS (SELECT 1 FROM employee_face_templates ft WHERE ft.employee_id = e.id)
+			  AND ` + fmt.Sprintf(filter, "e.tenant_id", "e.id") + `
+			ORDER BY e.face_embedding_vector <=> $1::real[]::vector(512)
+			LIMIT $2
+		) legacy_hits`
+}
+
+// selectFaceMatches executa uma busca vetorial em transação somente leitura. A lista de candidatos do índice HNSW
+// acompanha o número pedido e a varredura iterativa (pgvector 0.8+) continua a busca quando os filtros descartam candidatos;
+// em versões anteriores ela é omitida e a busca fica limitada à lista de candidatos.
+func (repo *EmployeeRepository) selectFaceMatches(ctx context.Context, dest interface{}, candidates int, query string, args ...interface{}) error {
+	tx, err := repo.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	efSearch := min(max(candidates, constants.FaceSearchMinEfSearch), constants.FaceSearchMaxEfSearch)
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL hnsw.ef_search = %d", efSearch)); err != nil {
+		return err
+	}
+
+	if repo.supportsIterativeScan(ctx) {
+		if _, err := tx.ExecContext(ctx, "SET LOCAL hnsw.iterative_scan = relaxed_order"); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.SelectContext(ctx, dest, query, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// supportsIterativeScan informa se o pgvector instalado aceita hnsw.iterative_scan (0.8 ou superior).
+// A versão é consultada uma única vez; uma falha na consulta é tentada de novo na próxima busca.
+func (repo *EmployeeRepository) supportsIterativeScan(ctx context.Context) bool {
+	repo.vectorMu.Lock()
+	defer repo.vectorMu.Unlock()
+
+	if repo.vectorChecked {
+		return repo.iterativeScans
+	}
+
+	var version string
+	if err := repo.db.GetContext(ctx, &version, `SELECT extversion FROM pg_extension WHERE extname = 'vector'`); err != nil {
+		repo.logger.Warn("Failed to get pgvector version", zap.Error(err))
+		return false
+	}
+
+	repo.vectorChecked = true
+	repo.iterativeScans = vectorVersionAtLeast(version, 0, 8)
+	if !repo.iterativeScans {
+		repo.logger.Warn("pgvector older than 0.8: face search runs without iterative index scans",
+			zap.String("pgvector_version", version))
+	}
+
+	return repo.iterativeScans
+}
+
+// vectorVersionAtLeast compara a versão de extensão (major.minor.patch) com a mínima informada
+func vectorVersionAtLeast(version string, major, minor int) bool {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+
+	gotMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	gotMinor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+
+	return gotMajor > major || (gotMajor == major && gotMinor >= minor)
+}
+
+// faceSearchCandidates calcula quantos embeddings ler de cada índice para devolver limit funcionários
+func faceSearchCandidates(limit int) int {
+	if limit < 1 {
+		limit = 1
+	}
+
+	return limit * constants.FaceSearchCandidateFactor
+}
+
+// faceMatchRow representa um funcionário encontrado por similaridade facial
+type faceMatchRow struct {
+	employeeRow
+	Similarity float32        `db:"similarity"`
+	PartnerID  sql.NullString `db:"partner_id"`
+}
+
+// FindByFaceEmbedding busca funcionários similares por embedding facial; vale o template mais parecido de cada um.
+// O índice de busca é protegido por uma chave de cada tenant, por isso o tenant é obrigatório.
+// Funcionários sem consentimento biométrico vigente não são reconhecidos.
 func (repo *EmployeeRepository) FindByFaceEmbedding(ctx context.Context, embedding []float32, tenantID *value_objects.UUID, threshold float32, limit int) ([]*employee.Employee, []float32, error) {
-	// TODO: Implementar busca por similaridade facial usando PostGIS ou extensão de vetores
-	// Por enquanto, retorna lista vazia
-	return []*employee.Employee{}, []float32{}, nil
+	if tenantID == nil {
+		return nil, nil, errors.NewValidationError("tenant_id", "tenant is required for face search")
+	}
+
+	vector, err := repo.searchVector(ctx, *tenantID, embedding)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := `
+		WITH hits AS (` + faceHitsQuery("%[1]s = $3") + `
+		), best AS (
+			SELECT employee_id, 1 - MIN(distance) AS similarity
+			FROM hits
+			GROUP BY employee_id
+		)
+		SELECT e.id, e.tenant_id, e.full_name, e.identity, e.identity_type,
+			   e.date_of_birth, e.photo_url, e.photo_key, e.face_embedding, e.face_embedding_sealed, e.phone, e.email,
+			   e.active, e.created_at, e.updated_at, e.created_by, e.updated_by,
+			   b.similarity
+		FROM best b
+		JOIN employees e ON e.id = b.employee_id
+		WHERE e.active = true AND b.similarity >= $4 AND ` + activeConsentCondition + `
+		ORDER BY b.similarity DESC
+		LIMIT $5`
+
+	candidates := faceSearchCandidates(limit)
+
+	var rows []faceMatchRow
+	err = repo.selectFaceMatches(ctx, &rows, candidates, query, vector, candidates, tenantID.String(), threshold, limit)
+	if err != nil {
+		repo.logger.Error("Failed to find employees by face embedding", zap.Error(err))
+		return nil, nil, errors.NewInternalError("failed to find employees by face embedding", err)
+	}
+
+	employees := make([]*employee.Employee, 0, len(rows))
+	similarities := make([]float32, 0, len(rows))
+	for _, row := range rows {
+		emp, err := row.toEntity()
+		if err != nil {
+			repo.logger.Warn("Failed to convert employee row", zap.Error(err), zap.String("employee_id", row.ID))
+			continue
+		}
+		employees = append(employees, emp)
+		similarities = append(similarities, row.Similarity)
+	}
+
+	return employees, similarities, nil
+}
+
+// FindByFaceEmbeddingInEvent busca, entre os funcionários dos parceiros associados ao evento, os mais parecidos com o embedding.
+// Quando o funcionário participa por mais de um parceiro, prevalece o parceiro ativo vinculado há mais tempo.
+// Funcionários sem consentimento biométrico vigente não são identificados.
+func (repo *EmployeeRepository) FindByFaceEmbeddingInEvent(ctx context.Context, tenantID, eventID value_objects.UUID, embedding []float32, threshold float32, limit int) ([]*employee.EventFaceMatch, error) {
+	vector, err := repo.searchVector(ctx, tenantID, embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		WITH hits AS (` + faceHitsQuery(`%[1]s = $3 AND EXISTS (
+				SELECT 1 FROM partner_employee pe
+				JOIN event_partner ep ON ep.id_partner = pe.id_partner
+				WHERE pe.id_employee = %[2]s AND ep.id_event = $4)`) + `
+		), best AS (
+			SELECT employee_id, 1 - MIN(distance) AS similarity
+			FROM hits
+			GROUP BY employee_id
+		)
+		SELECT e.id, e.tenant_id, e.full_name, e.identity, e.identity_type,
+			   e.date_of_birth, e.photo_url, e.photo_key, e.face_embedding, e.face_embedding_sealed, e.phone, e.email,
+			   e.active, e.created_at, e.updated_at, e.created_by, e.updated_by,
+			   b.similarity,
+			   (SELECT pe.id_partner
+				FROM partner_employee pe
+				JOIN event_partner ep ON ep.id_partner = pe.id_partner AND ep.id_event = $4
+				JOIN partners p ON p.id = pe.id_partner
+				WHERE pe.id_employee = e.id
+				ORDER BY p.active DESC, pe.assigned_at
+				LIMIT 1) AS partner_id
+		FROM best b
+		JOIN employees e ON e.id = b.employee_id
+		WHERE e.active = true AND b.similarity >= $5 AND ` + activeConsentCondition + `
+		ORDER BY b.similarity DESC
+		LIMIT $6`
+
+	candidates := faceSearchCandidates(limit)
+
+	var rows []faceMatchRow
+	err = repo.selectFaceMatches(ctx, &rows, candidates, query, vector, candidates, tenantID.String(), eventID.String(), threshold, limit)
+	if err != nil {
+		repo.logger.Error("Failed to find event employees by face embedding",
+			zap.Error(err),
+			zap.String("event_id", eventID.String()))
+		return nil, errors.NewInternalError("failed to find employees by face embedding", err)
+	}
+
+	matches := make([]*employee.EventFaceMatch, 0, len(rows))
+	for _, row := range rows {
+		emp, err := row.toEntity()
+		if err != nil {
+			repo.logger.Warn("Failed to convert employee row", zap.Error(err), zap.String("employee_id", row.ID))
+			continue
+		}
+
+		partnerID, err := value_objects.ParseUUID(row.PartnerID.String)
+		if err != nil {
+			repo.logger.Warn("Face match without event partner", zap.String("employee_id", row.ID))
+			continue
+		}
+
+		matches = append(matches, &employee.EventFaceMatch{Employee: emp, PartnerID: partnerID, Similarity: row.Similarity})
+	}
+
+	return matches, nil
 }
 
 // GetEmployeesWithFaceEmbedding busca funcionários que têm embedding facial
@@ -617,3 +977,219 @@ func (repo *EmployeeRepository) GetEmployeesWithFaceEmbedding(ctx context.Contex
 	}
 	return repo.List(ctx, filters)
 }
+
+// faceTemplateRow representa uma linha de template facial no banco de dados
+type faceTemplateRow struct {
+	ID             string          `db:"id"`
+	TenantID       string          `db:"tenant_id"`
+	EmployeeID     string          `db:"employee_id"`
+	Embedding      pq.Float32Array `db:"embedding"` // Texto claro anterior à cifragem
+	Sealed         []byte          `db:"embedding_sealed"`
+	SearchVector   pq.Float32Array `db:"embedding_vector"`
+	Quality        float32         `db:"quality"`
+	ModelVersion   string          `db:"model_version"`
+	SourcePhotoKey sql.NullString  `db:"source_photo_key"`
+	CapturedAt     time.Time       `db:"captured_at"`
+	CreatedAt      time.Time       `db:"created_at"`
+	CreatedBy      sql.NullString  `db:"created_by"`
+}
+
+// toEntity converte faceTemplateRow para entidade FaceTemplate
+func (r *faceTemplateRow) toEntity() (*employee.FaceTemplate, error) {
+	id, err := value_objects.ParseUUID(r.ID)
+	if err != nil {
+		return nil, errors.NewDomainError("INVALID_ID", "invalid face template ID", err)
+	}
+
+	tenantID, err := value_objects.ParseUUID(r.TenantID)
+	if err != nil {
+		return nil, errors.NewDomainError("INVALID_TENANT_ID", "invalid tenant ID", err)
+	}
+
+	employeeID, err := value_objects.ParseUUID(r.EmployeeID)
+	if err != nil {
+		return nil, errors.NewDomainError("INVALID_EMPLOYEE_ID", "invalid employee ID", err)
+	}
+
+	template := &employee.FaceTemplate{
+		ID:           id,
+		TenantID:     tenantID,
+		EmployeeID:   employeeID,
+		Quality:      r.Quality,
+		ModelVersion: r.ModelVersion,
+		CapturedAt:   r.CapturedAt,
+		CreatedAt:    r.CreatedAt,
+	}
+
+	if r.SourcePhotoKey.Valid {
+		template.SourcePhotoKey = r.SourcePhotoKey.String
+	}
+
+	if r.CreatedBy.Valid {
+		createdBy, err := value_objects.ParseUUID(r.CreatedBy.String)
+		if err == nil {
+			template.CreatedBy = &createdBy
+		}
+	}
+
+	return template, nil
+}
+
+// CreateFaceTemplate grava um template facial do funcionário
+func (repo *EmployeeRepository) CreateFaceTemplate(ctx context.Context, template *employee.FaceTemplate) error {
+	row := faceTemplateRow{
+		ID:           template.ID.String(),
+		TenantID:     template.TenantID.String(),
+		EmployeeID:   template.EmployeeID.String(),
+		Quality:      template.Quality,
+		ModelVersion: template.ModelVersion,
+		CapturedAt:   template.CapturedAt,
+		CreatedAt:    template.CreatedAt,
+	}
+
+	if template.SourcePhotoKey != "" {
+		row.SourcePhotoKey = sql.NullString{String: template.SourcePhotoKey, Valid: true}
+	}
+
+	if template.CreatedBy != nil {
+		row.CreatedBy = sql.NullString{String: template.CreatedBy.String(), Valid: true}
+	}
+
+	var err error
+	row.Sealed, row.SearchVector, err = repo.sealEmbedding(ctx, template.TenantID, biometric.RecordFaceTemplate, template.ID, template.Embedding)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO employee_face_templates (
+			id, tenant_id, employee_id, embedding_sealed, embedding_vector, quality, model_version,
+			source_photo_key, captured_at, created_at, created_by
+		) VALUES (
+			:id, :tenant_id, :employee_id, :embedding_sealed,
+			CAST(CAST(:embedding_vector AS real[]) AS vector(512)), :quality, :model_version,
+			:source_photo_key, :captured_at, :created_at, :created_by
+		)`
+
+	if _, err := repo.db.NamedExecContext(ctx, query, row); err != nil {
+		repo.logger.Error("Failed to create face template", zap.Error(err), zap.String("template_id", row.ID))
+		return errors.NewInternalError("failed to create face template", err)
+	}
+
+	return nil
+}
+
+// ListFaceTemplates lista os templates faciais de um funcionário, do mais antigo ao mais recente, sem abrir os embeddings
+func (repo *EmployeeRepository) ListFaceTemplates(ctx context.Context, employeeID value_objects.UUID) ([]*employee.FaceTemplate, error) {
+	return repo.listFaceTemplates(ctx, employeeID, false)
+}
+
+// listFaceTemplates lista os templates faciais de um funcionário; open decifra os embeddings
+func (repo *EmployeeRepository) listFaceTemplates(ctx context.Context, employeeID value_objects.UUID, open bool) ([]*employee.FaceTemplate, error) {
+	query := `
+		SELECT id, tenant_id, employee_id, embedding, embedding_sealed, quality, model_version,
+			   source_photo_key, captured_at, created_at, created_by
+		FROM employee_face_templates
+		WHERE employee_id = $1
+		ORDER BY created_at`
+
+	var rows []faceTemplateRow
+	if err := repo.db.SelectContext(ctx, &rows, query, employeeID.String()); err != nil {
+		repo.logger.Error("Failed to list face templates", zap.Error(err), zap.String("employee_id", employeeID.String()))
+		return nil, errors.NewInternalError("failed to list face templates", err)
+	}
+
+	templates := make([]*employee.FaceTemplate, 0, len(rows))
+	for _, row := range rows {
+		template, err := row.toEntity()
+		if err != nil {
+			repo.logger.Warn("Failed to convert face template row", zap.Error(err), zap.String("template_id", row.ID))
+			continue
+		}
+
+		if open {
+			template.Embedding, err = repo.openEmbedding(ctx, template.TenantID, biometric.RecordFaceTemplate, template.ID, row.Sealed, row.Embedding)
+			if err != nil {
+				return nil, err
+			}
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+// DeleteFaceTemplate remove um template facial do funcionário
+func (repo *EmployeeRepository) DeleteFaceTemplate(ctx context.Context, tenantID, employeeID, templateID value_objects.UUID) error {
+	query := `DELETE FROM employee_face_templates WHERE id = $1 AND employee_id = $2 AND tenant_id = $3`
+
+	result, err := repo.db.ExecContext(ctx, query, templateID.String(), employeeID.String(), tenantID.String())
+	if err != nil {
+		repo.logger.Error("Failed to delete face template", zap.Error(err), zap.String("template_id", templateID.String()))
+		return errors.NewInternalError("failed to delete face template", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repo.logger.Error("Failed to get rows affected", zap.Error(err))
+		return errors.NewInternalError("failed to delete face template", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewDomainError("NOT_FOUND", "face template not found", nil)
+	}
+
+	return nil
+}
+
+// faceTemplateMatchRow representa um embedding de outro funcionário encontrado por similaridade
+type faceTemplateMatchRow struct {
+	EmployeeID string         `db:"employee_id"`
+	TemplateID sql.NullString `db:"template_id"`
+	Similarity float32        `db:"similarity"`
+}
+
+// FindSimilarFaceTemplates busca embeddings de outros funcionários ativos do tenant com similaridade mínima
+func (repo *EmployeeRepository) FindSimilarFaceTemplates(ctx context.Context, tenantID value_objects.UUID, embedding []float32, threshold float32, excludeEmployeeID value_objects.UUID, limit int) ([]*employee.FaceTemplateMatch, error) {
+	vector, err := repo.searchVector(ctx, tenantID, embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		WITH hits AS (` + faceHitsQuery("%[1]s = $3 AND %[2]s != $4") + `
+		)
+		SELECT h.employee_id, h.template_id, 1 - h.distance AS similarity
+		FROM hits h
+		JOIN employees e ON e.id = h.employee_id
+		WHERE e.active = true AND 1 - h.distance >= $5
+		ORDER BY h.distance
+		LIMIT $6`
+
+	candidates := faceSearchCandidates(limit)
+
+	var rows []faceTemplateMatchRow
+	err = repo.selectFaceMatches(ctx, &rows, candidates, query, vector, candidates, tenantID.String(), excludeEmployeeID.String(), threshold, limit)
+	if err != nil {
+		repo.logger.Error("Failed to find similar face templates", zap.Error(err))
+		return nil, errors.NewInternalError("failed to find similar face templates", err)
+	}
+
+	matches := make([]*employee.FaceTemplateMatch, 0, len(rows))
+	for _, row := range rows {
+		employeeID, err := value_objects.ParseUUID(row.EmployeeID)
+		if err != nil {
+			continue
+		}
+
+		match := &employee.FaceTemplateMatch{EmployeeID: employeeID, Similarity: row.Similarity}
+		if row.TemplateID.Valid {
+			if templateID, err := value_objects.ParseUUID(row.TemplateID.String); err == nil {
+				match.TemplateID = &templateID
+			}
+		}
+		matches = append(matches, match)
+	}
+
+	return matches, nil
+}
@@ -33,18 +33,21 @@ func NewEventRepository(db *sqlx.DB, logger *zap.Logger) event.Repository {
 
 // eventRow representa uma linha de evento no banco de dados
 type eventRow struct {
-	ID          string         `db:"id"`
-	TenantID    string         `db:"tenant_id"`
-	Name        string         `db:"name"`
-	Location    string         `db:"location"`
-	FenceEvent  pq.StringArray `db:"fence_event"` // Array de coordenadas como strings
-	InitialDate time.Time      `db:"initial_date"`
-	FinalDate   time.Time      `db:"final_date"`
-	Active      bool           `db:"active"`
-	CreatedAt   time.Time      `db:"created_at"`
-	UpdatedAt   time.Time      `db:"updated_at"`
-	CreatedBy   sql.NullString `db:"created_by"`
-	UpdatedBy   sql.NullString `db:"updated_by"`
+	ID                   string         `db:"id"`
+	TenantID             string         `db:"tenant_id"`
+	Name                 string         `db:"name"`
+	Location             string         `db:"location"`
+	FenceEvent           pq.StringArray `db:"fence_event"` // Array de coordenadas como strings
+	FenceToleranceMeters float64        `db:"fence_tolerance_meters"`
+	MaxSessionHours      float64        `db:"max_session_hours"`
+	MaxOccupancy         int            `db:"max_occupancy"`
+	InitialDate          time.Time      `db:"initial_date"`
+	FinalDate            time.Time      `db:"final_date"`
+	Active               bool           `db:"active"`
+	CreatedAt            time.Time      `db:"created_at"`
+	UpdatedAt            time.Time      `db:"updated_at"`
+	CreatedBy            sql.NullString `db:"created_by"`
+	UpdatedBy            sql.NullString `db:"updated_by"`
 }
 
 // toEntity converte eventRow para entidade Event
@@ -87,16 +90,19 @@ func (r *eventRow) toEntity() (*event.Event, error) {
 	}
 
 	evt := &event.Event{
-		ID:          id,
-		TenantID:    tenantID,
-		Name:        r.Name,
-		Location:    r.Location,
-		FenceEvent:  fenceEvent,
-		InitialDate: r.InitialDate,
-		FinalDate:   r.FinalDate,
-		Active:      r.Active,
-		CreatedAt:   r.CreatedAt,
-		UpdatedAt:   r.UpdatedAt,
+		ID:                   id,
+		TenantID:             tenantID,
+		Name:                 r.Name,
+		Location:             r.Location,
+		FenceEvent:           fenceEvent,
+		FenceToleranceMeters: r.FenceToleranceMeters,
+		MaxSessionHours:      r.MaxSessionHours,
+		MaxOccupancy:         r.MaxOccupancy,
+		InitialDate:          r.InitialDate,
+		FinalDate:            r.FinalDate,
+		Active:               r.Active,
+		CreatedAt:            r.CreatedAt,
+		UpdatedAt:            r.UpdatedAt,
 	}
 
 	if r.CreatedBy.Valid {
@@ -119,15 +125,18 @@ func (r *eventRow) toEntity() (*event.Event, error) {
 // fromEntity converte entidade Event para eventRow
 func (repo *EventRepository) fromEntity(evt *event.Event) *eventRow {
 	row := &eventRow{
-		ID:          evt.ID.String(),
-		TenantID:    evt.TenantID.String(),
-		Name:        evt.Name,
-		Location:    evt.Location,
-		InitialDate: evt.InitialDate,
-		FinalDate:   evt.FinalDate,
-		Active:      evt.Active,
-		CreatedAt:   evt.CreatedAt,
-		UpdatedAt:   evt.UpdatedAt,
+		ID:                   evt.ID.String(),
+		TenantID:             evt.TenantID.String(),
+		Name:                 evt.Name,
+		Location:             evt.Location,
+		FenceToleranceMeters: evt.FenceToleranceMeters,
+		MaxSessionHours:      evt.MaxSessionHours,
+		MaxOccupancy:         evt.MaxOccupancy,
+		InitialDate:          evt.InitialDate,
+		FinalDate:            evt.FinalDate,
+		Active:               evt.Active,
+		CreatedAt:            evt.CreatedAt,
+		UpdatedAt:            evt.UpdatedAt,
 	}
 
 	// Converter FenceEvent para array de strings
@@ -153,11 +162,11 @@ func (repo *EventRepository) Create(ctx context.Context, evt *event.Event) error
 
 	query := `
 		INSERT INTO events (
-			id, tenant_id, name, location, fence_event, 
+			id, tenant_id, name, location, fence_event, fence_tolerance_meters, max_session_hours, max_occupancy,
 			initial_date, final_date, active, created_at, 
 			updated_at, created_by, updated_by
 		) VALUES (
-			:id, :tenant_id, :name, :location, :fence_event,
+			:id, :tenant_id, :name, :location, :fence_event, :fence_tolerance_meters, :max_session_hours, :max_occupancy,
 			:initial_date, :final_date, :active, :created_at,
 			:updated_at, :created_by, :updated_by
 		)`
@@ -177,7 +186,7 @@ func (repo *EventRepository) GetByID(ctx context.Context, id value_objects.UUID)
 	var row eventRow
 
 	query := `
-		SELECT id, tenant_id, name, location, fence_event, 
+		SELECT id, tenant_id, name, location, fence_event, fence_tolerance_meters, max_session_hours, max_occupancy,
 			   initial_date, final_date, active, created_at, 
 			   updated_at, created_by, updated_by
 		FROM events 
@@ -200,7 +209,7 @@ func (repo *EventRepository) GetByIDAndTenant(ctx context.Context, id, tenantID
 	var row eventRow
 
 	query := `
-		SELECT id, tenant_id, name, location, fence_event, 
+		SELECT id, tenant_id, name, location, fence_event, fence_tolerance_meters, max_session_hours, max_occupancy,
 			   initial_date, final_date, active, created_at, 
 			   updated_at, created_by, updated_by
 		FROM events 
@@ -230,6 +239,9 @@ func (repo *EventRepository) Update(ctx context.Context, evt *event.Event) error
 			name = :name,
 			location = :location,
 			fence_event = :fence_event,
+			fence_tolerance_meters = :fence_tolerance_meters,
+			max_session_hours = :max_session_hours,
+			max_occupancy = :max_occupancy,
 			initial_date = :initial_date,
 			final_date = :final_date,
 			updated_at = :updated_at,
@@ -377,7 +389,7 @@ func (repo *EventRepository) List(ctx context.Context, filters event.ListFilters
 	limitClause := fmt.Sprintf("LIMIT %d OFFSET %d", filters.PageSize, filters.GetOffset())
 
 	dataQuery := `
-		SELECT id, tenant_id, name, location, fence_event, 
+		SELECT id, tenant_id, name, location, fence_event, fence_tolerance_meters, max_session_hours, max_occupancy,
 			   initial_date, final_date, active, created_at, 
 			   updated_at, created_by, updated_by ` +
 		baseQuery + whereClause + " " + orderClause + " " + limitClause
@@ -463,7 +475,7 @@ func (repo *EventRepository) ExistsByNameInTenant(ctx context.Context, name stri
 func (repo *EventRepository) GetEventsInLocation(ctx context.Context, location value_objects.Location, tenantID *value_objects.UUID) ([]*event.Event, error) {
 	// Esta implementação é simplificada - em produção usaria PostGIS para queries geoespaciais
 	query := `
-		SELECT id, tenant_id, name, location, fence_event, 
+		SELECT id, tenant_id, name, location, fence_event, fence_tolerance_meters, max_session_hours, max_occupancy,
 			   initial_date, final_date, active, created_at, 
 			   updated_at, created_by, updated_by
 		FROM events 
@@ -490,13 +502,82 @@ func (repo *EventRepository) GetEventsInLocation(ctx context.Context, location v
 			continue
 		}
 
-		// Verificar se a localização está dentro do fence do evento
-		if len(evt.FenceEvent) > 0 {
-			// Implementação simplificada - em produção usaria PostGIS
-			// Por enquanto, adiciona todos os eventos para não quebrar
+		// Verificar se a localização está dentro do fence do evento (considerando a tolerância)
+		if len(evt.FenceEvent) > 0 && evt.CheckLocation(location).WithinTolerance {
 			eventsInLocation = append(eventsInLocation, evt)
 		}
 	}
 
 	return eventsInLocation, nil
 }
+
+// fenceGeographySQL monta o polígono PostGIS da cerca a partir do array "lat,lng" armazenado em fence_event
+const fenceGeographySQL = `
+	SELECT ST_MakePolygon(
+			   CASE WHEN ST_IsClosed(ring) THEN ring ELSE ST_AddPoint(ring, ST_StartPoint(ring)) END
+		   )::geography AS polygon,
+		   fence_tolerance_meters
+	FROM (
+		SELECT ST_MakeLine(ARRAY(
+				   SELECT ST_SetSRID(ST_MakePoint(split_part(p.coord, ',', 2)::float8, split_part(p.coord, ',', 1)::float8), 4326)
+				   FROM unnest(e.fence_event) WITH ORDINALITY AS p(coord, ord)
+				   ORDER BY p.ord
+			   )) AS ring,
+			   e.fence_tolerance_meters
+		FROM events e
+		WHERE e.id = $1 AND array_length(e.fence_event, 1) >= 3
+	) fence`
+
+// CheckLocationInFence verifica no PostGIS se uma localização está na cerca do evento (ST_Covers/ST_DWithin).
+// A precisão da leitura amplia a tolerância como em Event.CheckLocationWithAccuracy.
+func (repo *EventRepository) CheckLocationInFence(ctx context.Context, eventID value_objects.UUID, location value_objects.Location, fix *value_objects.LocationFix) (*event.FenceCheck, error) {
+	var result struct {
+		WithinFence     bool    `db:"within_fence"`
+		WithinTolerance bool    `db:"within_tolerance"`
+		DistanceToEdge  float64 `db:"distance_to_edge"`
+		ToleranceMeters float64 `db:"fence_tolerance_meters"`
+	}
+
+	accuracy := fix.AccuracyCredit()
+
+	query := `
+		WITH fence AS (` + fenceGeographySQL + `
+		), point AS (
+			SELECT ST_GeogFromText('SRID=4326;' || $2) AS geog
+		)
+		SELECT ST_Covers(fence.polygon, point.geog) AS within_fence,
+			   ST_DWithin(fence.polygon, point.geog, fence.fence_tolerance_meters + $3) AS within_tolerance,
+			   ST_Distance(ST_ExteriorRing(fence.polygon::geometry)::geography, point.geog) AS distance_to_edge,
+			   fence.fence_tolerance_meters
+		FROM fence, point`
+
+	err := repo.db.GetContext(ctx, &result, query, eventID.String(), location.String(), accuracy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// Evento sem cerca definida: qualquer localização é aceita
+			return &event.FenceCheck{WithinFence: true, WithinTolerance: true, AccuracyMeters: accuracy}, nil
+		}
+		repo.logger.Error("Failed to check location in event fence",
+			zap.Error(err),
+			zap.String("event_id", eventID.String()))
+		return nil, errors.NewInternalError("failed to check location in event fence", err)
+	}
+
+	check := &event.FenceCheck{
+		HasFence:        true,
+		ToleranceMeters: result.ToleranceMeters,
+		AccuracyMeters:  accuracy,
+	}
+
+	// Sem localização não é possível comprovar a presença dentro da cerca
+	if location.IsZero() {
+		check.Missing = true
+		return check, nil
+	}
+
+	check.WithinFence = result.WithinFence
+	check.WithinTolerance = result.WithinTolerance
+	check.DistanceToEdge = result.DistanceToEdge
+
+	return check, nil
+}
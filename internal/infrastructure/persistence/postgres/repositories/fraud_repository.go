@@ -0,0 +1,363 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"eventos-backend/internal/domain/biometric"
+	"eventos-backend/internal/domain/fraud"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// FraudRepository implementa as consultas da análise de fraude sobre check-ins e check-outs
+type FraudRepository struct {
+	db     *sqlx.DB
+	cipher biometric.Cipher
+	logger *zap.Logger
+}
+
+// NewFraudRepository cria uma nova instância do repositório de fraude
+func NewFraudRepository(db *sqlx.DB, cipher biometric.Cipher, logger *zap.Logger) fraud.Repository {
+	return &FraudRepository{
+		db:     db,
+		cipher: cipher,
+		logger: logger,
+	}
+}
+
+// fraudRecordRow representa um check-in ou check-out usado nas comparações
+type fraudRecordRow struct {
+	ID         string    `db:"id"`
+	Kind       string    `db:"kind"`
+	EmployeeID string    `db:"id_employee"`
+	Latitude   float64   `db:"latitude"`
+	Longitude  float64   `db:"longitude"`
+	At         time.Time `db:"at"`
+}
+
+// toEntity converte uma linha do banco para o registro de comparação
+func (r *fraudRecordRow) toEntity() (*fraud.Record, error) {
+	id, err := value_objects.ParseUUID(r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid record ID: %w", err)
+	}
+
+	employeeID, err := value_objects.ParseUUID(r.EmployeeID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid employee ID: %w", err)
+	}
+
+	location, err := value_objects.NewLocation(r.Latitude, r.Longitude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location: %w", err)
+	}
+
+	return &fraud.Record{
+		ID:         id,
+		Kind:       r.Kind,
+		EmployeeID: employeeID,
+		Location:   location,
+		At:         r.At,
+	}, nil
+}
+
+// flaggedCheckinRow representa um check-in sinalizado
+type flaggedCheckinRow struct {
+	ID                string         `db:"id_checkin"`
+	TenantID          string         `db:"id_tenant"`
+	EventID           string         `db:"id_event"`
+	EmployeeID        string         `db:"id_employee"`
+	EmployeeName      string         `db:"full_name"`
+	DeviceID          sql.NullString `db:"device_id"`
+	Method            string         `db:"method"`
+	CheckinTime       time.Time      `db:"checkin_time"`
+	ValidationDetails sql.NullString `db:"validation_details"`
+}
+
+// toEntity converte uma linha do banco para o check-in sinalizado
+func (r *flaggedCheckinRow) toEntity() (*fraud.FlaggedCheckin, error) {
+	id, err := value_objects.ParseUUID(r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkin ID: %w", err)
+	}
+
+	tenantID, err := value_objects.ParseUUID(r.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant ID: %w", err)
+	}
+
+	eventID, err := value_objects.ParseUUID(r.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event ID: %w", err)
+	}
+
+	employeeID, err := value_objects.ParseUUID(r.EmployeeID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid employee ID: %w", err)
+	}
+
+	flagged := &fraud.FlaggedCheckin{
+		CheckinID:    id,
+		TenantID:     tenantID,
+		EventID:      eventID,
+		EmployeeID:   employeeID,
+		EmployeeName: r.EmployeeName,
+		DeviceID:     r.DeviceID.String,
+		Method:       r.Method,
+		CheckinTime:  r.CheckinTime,
+	}
+
+	if r.ValidationDetails.Valid && r.ValidationDetails.String != "" {
+		var details struct {
+			Flags  []fraud.Flag  `json:"fraud_flags"`
+			Review *fraud.Review `json:"fraud_review"`
+		}
+		if err := json.Unmarshal([]byte(r.ValidationDetails.String), &details); err != nil {
+			return nil, fmt.Errorf("invalid validation details: %w", err)
+		}
+		flagged.Flags = details.Flags
+		flagged.Review = details.Review
+	}
+
+	return flagged, nil
+}
+
+// flaggedCheckinColumns são as colunas lidas na fila de revisão de fraude
+const flaggedCheckinColumns = `c.id_checkin, c.id_tenant, c.id_event, c.id_employee, COALESCE(e.full_name, '') AS full_name,
+	c.device_id, c.method, c.checkin_time, c.validation_details`
+
+// GetPreviousRecord busca o último check-in ou check-out não anulado e com localização do funcionário
+func (repo *FraudRepository) GetPreviousRecord(ctx context.Context, tenantID, employeeID value_objects.UUID, before time.Time) (*fraud.Record, error) {
+	query := `
+		SELECT id, kind, id_employee, latitude, longitude, at FROM (
+			SELECT id_checkin AS id, 'checkin' AS kind, id_employee, latitude, longitude, checkin_time AS at
+			FROM checkin
+			WHERE id_tenant = $1 AND id_employee = $2 AND checkin_time <= $3 AND voided_at IS NULL
+			  AND NOT (latitude = 0 AND longitude = 0)
+			UNION ALL
+			SELECT id_checkout AS id, 'checkout' AS kind, id_employee, latitude, longitude, checkout_time AS at
+			FROM checkout
+			WHERE id_tenant = $1 AND id_employee = $2 AND checkout_time <= $3 AND voided_at IS NULL
+			  AND NOT (latitude = 0 AND longitude = 0)
+		) records
+		ORDER BY at DESC
+		LIMIT 1`
+
+	var row fraudRecordRow
+	if err := repo.db.GetContext(ctx, &row, query, tenantID.String(), employeeID.String(), before); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("previous record not found: %w", errors.ErrNotFound)
+		}
+		repo.logger.Error("Failed to get previous record", zap.Error(err), zap.String("employee_id", employeeID.String()))
+		return nil, fmt.Errorf("failed to get previous record: %w", err)
+	}
+
+	return row.toEntity()
+}
+
+// CountDeviceEmployees conta os funcionários distintos com check-in não anulado no dispositivo
+func (repo *FraudRepository) CountDeviceEmployees(ctx context.Context, tenantID value_objects.UUID, deviceID string, from, to time.Time, excludeEmployeeID value_objects.UUID) (int, error) {
+	query := `
+		SELECT COUNT(DISTINCT id_employee)
+		FROM checkin
+		WHERE id_tenant = $1 AND device_id = $2 AND checkin_time BETWEEN $3 AND $4
+		  AND id_employee <> $5 AND voided_at IS NULL`
+
+	var count int
+	if err := repo.db.GetContext(ctx, &count, query, tenantID.String(), deviceID, from, to, excludeEmployeeID.String()); err != nil {
+		repo.logger.Error("Failed to count device employees", zap.Error(err), zap.String("device_id", deviceID))
+		return 0, fmt.Errorf("failed to count device employees: %w", err)
+	}
+
+	return count, nil
+}
+
+// FindIdenticalEmbeddings busca check-ins não anulados com o mesmo vetor facial, dos mais recentes para os mais antigos.
+// Os embeddings cifrados são comparados pelo digest; os anteriores à cifragem, pelo texto claro.
+func (repo *FraudRepository) FindIdenticalEmbeddings(ctx context.Context, tenantID value_objects.UUID, embedding []float32, since time.Time, limit int) ([]*fraud.Record, error) {
+	digest, err := repo.cipher.Digest(ctx, tenantID, embedding)
+	if err != nil {
+		repo.logger.Error("Failed to digest face embedding", zap.Error(err), zap.String("tenant_id", tenantID.String()))
+		return nil, fmt.Errorf("failed to find identical embeddings: %w", err)
+	}
+
+	query := `
+		SELECT id_checkin AS id, 'checkin' AS kind, id_employee, latitude, longitude, checkin_time AS at
+		FROM checkin
+		WHERE id_tenant = $1 AND checkin_time >= $2 AND voided_at IS NULL
+		  AND (face_embedding_digest = $3 OR (face_embedding IS NOT NULL AND face_embedding = $4::real[]))
+		ORDER BY checkin_time DESC
+		LIMIT $5`
+
+	var rows []fraudRecordRow
+	if err := repo.db.SelectContext(ctx, &rows, query, tenantID.String(), since, digest, pq.Float32Array(embedding), limit); err != nil {
+		repo.logger.Error("Failed to find identical embeddings", zap.Error(err), zap.String("tenant_id", tenantID.String()))
+		return nil, fmt.Errorf("failed to find identical embeddings: %w", err)
+	}
+
+	records := make([]*fraud.Record, 0, len(rows))
+	for i := range rows {
+		record, err := rows[i].toEntity()
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// ListFlagged lista os check-ins com sinalizações de fraude, dos mais recentes para os mais antigos
+func (repo *FraudRepository) ListFlagged(ctx context.Context, tenantID value_objects.UUID, filters fraud.ListFilters) ([]*fraud.FlaggedCheckin, int, error) {
+	if err := filters.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	conditions := []string{"c.id_tenant = $1", "c.validation_details::jsonb -> 'fraud_flags' IS NOT NULL"}
+	args := []interface{}{tenantID.String()}
+	argIndex := 2
+
+	if filters.EventID != nil {
+		conditions = append(conditions, fmt.Sprintf("c.id_event = $%d", argIndex))
+		args = append(args, filters.EventID.String())
+		argIndex++
+	}
+
+	if filters.EmployeeID != nil {
+		conditions = append(conditions, fmt.Sprintf("c.id_employee = $%d", argIndex))
+		args = append(args, filters.EmployeeID.String())
+		argIndex++
+	}
+
+	if filters.DeviceID != nil {
+		conditions = append(conditions, fmt.Sprintf("c.device_id = $%d", argIndex))
+		args = append(args, *filters.DeviceID)
+		argIndex++
+	}
+
+	if filters.FlagType != nil {
+		flagFilter, err := json.Marshal([]map[string]string{{"type": *filters.FlagType}})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to build flag filter: %w", err)
+		}
+		conditions = append(conditions, fmt.Sprintf("c.validation_details::jsonb -> 'fraud_flags' @> $%d::jsonb", argIndex))
+		args = append(args, string(flagFilter))
+		argIndex++
+	}
+
+	if filters.Status != nil {
+		if *filters.Status == constants.FraudReviewPending {
+			conditions = append(conditions, "c.validation_details::jsonb -> 'fraud_review' IS NULL")
+		} else {
+			conditions = append(conditions, fmt.Sprintf("c.validation_details::jsonb -> 'fraud_review' ->> 'status' = $%d", argIndex))
+			args = append(args, *filters.Status)
+			argIndex++
+		}
+	}
+
+	if filters.From != nil {
+		conditions = append(conditions, fmt.Sprintf("c.checkin_time >= $%d", argIndex))
+		args = append(args, *filters.From)
+		argIndex++
+	}
+
+	if filters.To != nil {
+		conditions = append(conditions, fmt.Sprintf("c.checkin_time <= $%d", argIndex))
+		args = append(args, *filters.To)
+		argIndex++
+	}
+
+	whereClause := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := repo.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM checkin c"+whereClause, args...); err != nil {
+		repo.logger.Error("Failed to count flagged checkins", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to count flagged checkins: %w", err)
+	}
+
+	dataQuery := `SELECT ` + flaggedCheckinColumns + ` FROM checkin c LEFT JOIN employees e ON e.id = c.id_employee` + whereClause +
+		fmt.Sprintf(" ORDER BY c.checkin_time DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filters.PageSize, filters.GetOffset())
+
+	var rows []flaggedCheckinRow
+	if err := repo.db.SelectContext(ctx, &rows, dataQuery, args...); err != nil {
+		repo.logger.Error("Failed to list flagged checkins", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to list flagged checkins: %w", err)
+	}
+
+	flagged := make([]*fraud.FlaggedCheckin, 0, len(rows))
+	for _, row := range rows {
+		entry, err := row.toEntity()
+		if err != nil {
+			repo.logger.Warn("Failed to convert flagged checkin row", zap.Error(err), zap.String("checkin_id", row.ID))
+			continue
+		}
+		flagged = append(flagged, entry)
+	}
+
+	return flagged, total, nil
+}
+
+// GetFlagged busca um check-in sinalizado do tenant
+func (repo *FraudRepository) GetFlagged(ctx context.Context, tenantID, checkinID value_objects.UUID) (*fraud.FlaggedCheckin, error) {
+	query := `SELECT ` + flaggedCheckinColumns + `
+		FROM checkin c
+		LEFT JOIN employees e ON e.id = c.id_employee
+		WHERE c.id_checkin = $1 AND c.id_tenant = $2 AND c.validation_details::jsonb -> 'fraud_flags' IS NOT NULL`
+
+	var row flaggedCheckinRow
+	if err := repo.db.GetContext(ctx, &row, query, checkinID.String(), tenantID.String()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("flagged checkin not found: %w", errors.ErrNotFound)
+		}
+		repo.logger.Error("Failed to get flagged checkin", zap.Error(err), zap.String("checkin_id", checkinID.String()))
+		return nil, fmt.Errorf("failed to get flagged checkin: %w", err)
+	}
+
+	return row.toEntity()
+}
+
+// SaveReview grava a decisão em validation_details sem alterar as demais chaves
+func (repo *FraudRepository) SaveReview(ctx context.Context, tenantID, checkinID value_objects.UUID, review *fraud.Review) error {
+	reviewJSON, err := json.Marshal(review)
+	if err != nil {
+		return fmt.Errorf("failed to encode fraud review: %w", err)
+	}
+
+	query := `
+		UPDATE checkin
+		SET validation_details = jsonb_set(validation_details::jsonb, '{fraud_review}', $3::jsonb),
+			updated_at = $4
+		WHERE id_checkin = $1 AND id_tenant = $2 AND validation_details::jsonb -> 'fraud_flags' IS NOT NULL`
+
+	result, err := repo.db.ExecContext(ctx, query, checkinID.String(), tenantID.String(), string(reviewJSON), time.Now())
+	if err != nil {
+		repo.logger.Error("Failed to save fraud review", zap.Error(err), zap.String("checkin_id", checkinID.String()))
+		return fmt.Errorf("failed to save fraud review: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("flagged checkin not found: %w", errors.ErrNotFound)
+	}
+
+	repo.logger.Info("Fraud review saved",
+		zap.String("checkin_id", checkinID.String()),
+		zap.String("status", review.Status),
+	)
+	return nil
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"eventos-backend/internal/domain/shared/value_objects"
+)
+
+// locationFixRow representa as colunas com os metadados da leitura de GPS, comuns a checkin e checkout
+type locationFixRow struct {
+	LocationAccuracy sql.NullFloat64 `db:"location_accuracy"`
+	LocationAltitude sql.NullFloat64 `db:"location_altitude"`
+	LocationFixTime  sql.NullTime    `db:"location_fix_time"`
+	LocationProvider sql.NullString  `db:"location_provider"`
+	LocationMock     bool            `db:"location_mock"`
+}
+
+// newLocationFixRow converte os metadados da leitura de GPS para as colunas do banco
+func newLocationFixRow(fix *value_objects.LocationFix) locationFixRow {
+	if fix == nil {
+		return locationFixRow{}
+	}
+
+	row := locationFixRow{
+		LocationAccuracy: sql.NullFloat64{Float64: fix.Accuracy, Valid: true},
+		LocationMock:     fix.IsMock,
+	}
+
+	if fix.Altitude != nil {
+		row.LocationAltitude = sql.NullFloat64{Float64: *fix.Altitude, Valid: true}
+	}
+
+	if fix.FixTime != nil {
+		row.LocationFixTime = sql.NullTime{Time: *fix.FixTime, Valid: true}
+	}
+
+	if fix.Provider != "" {
+		row.LocationProvider = sql.NullString{String: fix.Provider, Valid: true}
+	}
+
+	return row
+}
+
+// toLocationFix converte as colunas para os metadados da leitura de GPS; nil para registros sem leitura informada
+func (r locationFixRow) toLocationFix() *value_objects.LocationFix {
+	if !r.LocationAccuracy.Valid && !r.LocationAltitude.Valid && !r.LocationFixTime.Valid &&
+		!r.LocationProvider.Valid && !r.LocationMock {
+		return nil
+	}
+
+	fix := &value_objects.LocationFix{
+		Accuracy: r.LocationAccuracy.Float64,
+		Provider: r.LocationProvider.String,
+		IsMock:   r.LocationMock,
+	}
+
+	if r.LocationAltitude.Valid {
+		altitude := r.LocationAltitude.Float64
+		fix.Altitude = &altitude
+	}
+
+	if r.LocationFixTime.Valid {
+		fixTime := r.LocationFixTime.Time
+		fix.FixTime = &fixTime
+	}
+
+	return fix
+}
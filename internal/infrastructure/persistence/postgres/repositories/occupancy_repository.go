@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eventos-backend/internal/domain/occupancy"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// OccupancyRepository implementa a interface occupancy.Repository usando PostgreSQL
+type OccupancyRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewOccupancyRepository cria uma nova instância do repositório de ocupação
+func NewOccupancyRepository(db *sqlx.DB, logger *zap.Logger) occupancy.Repository {
+	return &OccupancyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// partnerHeadcountRow representa a contagem de pessoas presentes de um parceiro
+type partnerHeadcountRow struct {
+	PartnerID string `db:"id_partner"`
+	OnSite    int    `db:"on_site"`
+}
+
+// rosterRow representa uma pessoa presente no evento
+type rosterRow struct {
+	CheckinID      string    `db:"id_checkin"`
+	EmployeeID     string    `db:"id_employee"`
+	EmployeeName   string    `db:"full_name"`
+	PartnerID      string    `db:"id_partner"`
+	Method         string    `db:"method"`
+	CheckinTime    time.Time `db:"checkin_time"`
+	ApprovalStatus *string   `db:"approval_status"`
+}
+
+// toEntity converte rosterRow para a entrada de domínio
+func (r *rosterRow) toEntity() (*occupancy.RosterEntry, error) {
+	checkinID, err := value_objects.ParseUUID(r.CheckinID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkin ID: %w", err)
+	}
+
+	employeeID, err := value_objects.ParseUUID(r.EmployeeID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid employee ID: %w", err)
+	}
+
+	partnerID, err := value_objects.ParseUUID(r.PartnerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid partner ID: %w", err)
+	}
+
+	return &occupancy.RosterEntry{
+		CheckinID:       checkinID,
+		EmployeeID:      employeeID,
+		EmployeeName:    r.EmployeeName,
+		PartnerID:       partnerID,
+		Method:          r.Method,
+		CheckinTime:     r.CheckinTime,
+		PendingApproval: r.ApprovalStatus != nil && *r.ApprovalStatus == "pending",
+	}, nil
+}
+
+// CountOnSite conta as sessões abertas do evento por parceiro
+func (repo *OccupancyRepository) CountOnSite(ctx context.Context, eventID value_objects.UUID) ([]*occupancy.PartnerHeadcount, error) {
+	query := `
+		SELECT c.id_partner, COUNT(*) AS on_site
+		FROM checkin c
+		WHERE c.id_event = $1 AND (c.is_valid = true OR c.approval_status = 'pending') AND c.voided_at IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM checkout co WHERE co.id_checkin = c.id_checkin AND co.voided_at IS NULL)
+		GROUP BY c.id_partner`
+
+	var rows []partnerHeadcountRow
+	if err := repo.db.SelectContext(ctx, &rows, query, eventID.String()); err != nil {
+		repo.logger.Error("Failed to count on-site employees", zap.Error(err), zap.String("event_id", eventID.String()))
+		return nil, fmt.Errorf("failed to count on-site employees: %w", err)
+	}
+
+	headcounts := make([]*occupancy.PartnerHeadcount, 0, len(rows))
+	for _, row := range rows {
+		partnerID, err := value_objects.ParseUUID(row.PartnerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid partner ID: %w", err)
+		}
+		headcounts = append(headcounts, &occupancy.PartnerHeadcount{PartnerID: partnerID, OnSite: row.OnSite})
+	}
+
+	return headcounts, nil
+}
+
+// ListOnSite lista as sessões abertas do evento, das mais recentes para as mais antigas
+func (repo *OccupancyRepository) ListOnSite(ctx context.Context, eventID value_objects.UUID, partnerID *value_objects.UUID) ([]*occupancy.RosterEntry, error) {
+	query := `
+		SELECT c.id_checkin, c.id_employee, COALESCE(e.full_name, '') AS full_name, c.id_partner,
+			   c.method, c.checkin_time, c.approval_status
+		FROM checkin c
+		LEFT JOIN employees e ON e.id = c.id_employee
+		WHERE c.id_event = $1 AND (c.is_valid = true OR c.approval_status = 'pending') AND c.voided_at IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM checkout co WHERE co.id_checkin = c.id_checkin AND co.voided_at IS NULL)`
+
+	args := []interface{}{eventID.String()}
+	if partnerID != nil && !partnerID.IsZero() {
+		query += " AND c.id_partner = $2"
+		args = append(args, partnerID.String())
+	}
+
+	query += " ORDER BY c.checkin_time DESC"
+
+	var rows []rosterRow
+	if err := repo.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		repo.logger.Error("Failed to list on-site employees", zap.Error(err), zap.String("event_id", eventID.String()))
+		return nil, fmt.Errorf("failed to list on-site employees: %w", err)
+	}
+
+	roster := make([]*occupancy.RosterEntry, 0, len(rows))
+	for i := range rows {
+		entry, err := rows[i].toEntity()
+		if err != nil {
+			return nil, err
+		}
+		roster = append(roster, entry)
+	}
+
+	return roster, nil
+}
+
+// GetSeries calcula quantas sessões estavam abertas em cada instante da série.
+// Considera o estado atual dos registros: check-ins anulados ou rejeitados não entram na série.
+func (repo *OccupancyRepository) GetSeries(ctx context.Context, eventID value_objects.UUID, filters occupancy.SeriesFilters) ([]*occupancy.Sample, error) {
+	query := `
+		WITH sessions AS (
+			SELECT c.checkin_time AS started_at, co.checkout_time AS ended_at
+			FROM checkin c
+			LEFT JOIN checkout co ON co.id_checkin = c.id_checkin AND co.voided_at IS NULL
+			WHERE c.id_event = $1 AND (c.is_valid = true OR c.approval_status = 'pending') AND c.voided_at IS NULL
+			  AND c.checkin_time <= $3
+		)
+		SELECT s.at, COUNT(sessions.started_at) AS on_site
+		FROM generate_series($2::timestamp, $3::timestamp, $4 * INTERVAL '1 second') AS s(at)
+		LEFT JOIN sessions ON sessions.started_at <= s.at AND (sessions.ended_at IS NULL OR sessions.ended_at > s.at)
+		GROUP BY s.at
+		ORDER BY s.at`
+
+	var rows []struct {
+		At     time.Time `db:"at"`
+		OnSite int       `db:"on_site"`
+	}
+
+	err := repo.db.SelectContext(ctx, &rows, query, eventID.String(), filters.From.UTC(), filters.To.UTC(), int64(filters.Interval/time.Second))
+	if err != nil {
+		repo.logger.Error("Failed to compute occupancy series", zap.Error(err), zap.String("event_id", eventID.String()))
+		return nil, fmt.Errorf("failed to compute occupancy series: %w", err)
+	}
+
+	series := make([]*occupancy.Sample, 0, len(rows))
+	for _, row := range rows {
+		series = append(series, &occupancy.Sample{At: row.At, OnSite: row.OnSite})
+	}
+
+	return series, nil
+}
+
+// GetPartnerCapacities retorna a capacidade dos parceiros do evento que possuem limite
+func (repo *OccupancyRepository) GetPartnerCapacities(ctx context.Context, eventID value_objects.UUID) (map[value_objects.UUID]int, error) {
+	query := `SELECT id_partner, max_occupancy FROM event_partner WHERE id_event = $1 AND max_occupancy > 0`
+
+	var rows []struct {
+		PartnerID    string `db:"id_partner"`
+		MaxOccupancy int    `db:"max_occupancy"`
+	}
+	if err := repo.db.SelectContext(ctx, &rows, query, eventID.String()); err != nil {
+		repo.logger.Error("Failed to get partner capacities", zap.Error(err), zap.String("event_id", eventID.String()))
+		return nil, fmt.Errorf("failed to get partner capacities: %w", err)
+	}
+
+	capacities := make(map[value_objects.UUID]int, len(rows))
+	for _, row := range rows {
+		partnerID, err := value_objects.ParseUUID(row.PartnerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid partner ID: %w", err)
+		}
+		capacities[partnerID] = row.MaxOccupancy
+	}
+
+	return capacities, nil
+}
+
+// SetPartnerCapacity define a capacidade de um parceiro associado ao evento
+func (repo *OccupancyRepository) SetPartnerCapacity(ctx context.Context, eventID, partnerID value_objects.UUID, capacity int) error {
+	query := `UPDATE event_partner SET max_occupancy = $3 WHERE id_event = $1 AND id_partner = $2`
+
+	result, err := repo.db.ExecContext(ctx, query, eventID.String(), partnerID.String(), capacity)
+	if err != nil {
+		repo.logger.Error("Failed to set partner capacity",
+			zap.Error(err),
+			zap.String("event_id", eventID.String()),
+			zap.String("partner_id", partnerID.String()),
+		)
+		return fmt.Errorf("failed to set partner capacity: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("event partner not found: %w", errors.ErrNotFound)
+	}
+
+	return nil
+}
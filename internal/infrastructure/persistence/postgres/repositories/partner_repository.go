@@ -614,3 +614,37 @@ func (repo *PartnerRepository) GetPartnersWithEmployees(ctx context.Context, ten
 	// Por enquanto, retorna lista vazia
 	return []*partner.Partner{}, 0, nil
 }
+
+// IsEmployeeLinked verifica se o funcionário está vinculado ao parceiro
+func (repo *PartnerRepository) IsEmployeeLinked(ctx context.Context, partnerID, employeeID value_objects.UUID) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM partner_employee WHERE id_partner = $1 AND id_employee = $2)`
+
+	var linked bool
+	err := repo.db.GetContext(ctx, &linked, query, partnerID.String(), employeeID.String())
+	if err != nil {
+		repo.logger.Error("Failed to check partner employee link",
+			zap.Error(err),
+			zap.String("partner_id", partnerID.String()),
+			zap.String("employee_id", employeeID.String()))
+		return false, errors.NewInternalError("failed to check partner employee link", err)
+	}
+
+	return linked, nil
+}
+
+// IsAssignedToEvent verifica se o parceiro está associado ao evento
+func (repo *PartnerRepository) IsAssignedToEvent(ctx context.Context, partnerID, eventID value_objects.UUID) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM event_partner WHERE id_partner = $1 AND id_event = $2)`
+
+	var assigned bool
+	err := repo.db.GetContext(ctx, &assigned, query, partnerID.String(), eventID.String())
+	if err != nil {
+		repo.logger.Error("Failed to check event partner assignment",
+			zap.Error(err),
+			zap.String("partner_id", partnerID.String()),
+			zap.String("event_id", eventID.String()))
+		return false, errors.NewInternalError("failed to check event partner assignment", err)
+	}
+
+	return assigned, nil
+}
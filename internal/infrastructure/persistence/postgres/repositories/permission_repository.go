@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"eventos-backend/internal/domain/permission"
+	"eventos-backend/internal/domain/shared/constants"
 	"eventos-backend/internal/domain/shared/value_objects"
 
 	"github.com/jmoiron/sqlx"
@@ -615,3 +616,25 @@ func (repo *PermissionRepository) GetActions(ctx context.Context, tenantID value
 
 	return actions, nil
 }
+
+// UserHasPermission verifica se algum papel ativo do usuário concede a ação no módulo
+func (repo *PermissionRepository) UserHasPermission(ctx context.Context, userID value_objects.UUID, module, action string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM user_role ur
+			JOIN role r ON r.id_role = ur.id_role AND r.active = true
+			JOIN role_permission rp ON rp.id_role = ur.id_role
+			JOIN permission p ON p.id_permission = rp.id_permission AND p.active = true
+			WHERE ur.id_user = $1 AND p.module = $2 AND p.action IN ($3, $4)
+		)`
+
+	var allowed bool
+	err := repo.db.GetContext(ctx, &allowed, query, userID.String(), strings.ToLower(module), strings.ToLower(action), constants.PermissionAdmin)
+	if err != nil {
+		repo.logger.Error("Failed to check user permission", zap.Error(err), zap.String("user_id", userID.String()))
+		return false, fmt.Errorf("failed to check user permission: %w", err)
+	}
+
+	return allowed, nil
+}
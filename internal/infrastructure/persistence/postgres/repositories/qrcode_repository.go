@@ -0,0 +1,210 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"eventos-backend/internal/domain/qrcode"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// QRCodeRepository implementa a interface qrcode.Repository usando PostgreSQL
+type QRCodeRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewQRCodeRepository cria uma nova instância do repositório de QR Codes
+func NewQRCodeRepository(db *sqlx.DB, logger *zap.Logger) qrcode.Repository {
+	return &QRCodeRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// qrCodeRow representa uma linha da tabela event_qr_code no banco
+type qrCodeRow struct {
+	ID         string         `db:"id_qr_code"`
+	EventID    string         `db:"id_event"`
+	Type       string         `db:"qr_type"`
+	Token      string         `db:"qr_token"`
+	ValidFrom  time.Time      `db:"valid_from"`
+	ValidUntil time.Time      `db:"valid_until"`
+	UsageCount int            `db:"usage_count"`
+	MaxUsage   int            `db:"max_usage"`
+	CreatedAt  time.Time      `db:"created_at"`
+	CreatedBy  sql.NullString `db:"created_by"`
+}
+
+// toEntity converte uma linha do banco para entidade de domínio
+func (r *qrCodeRow) toEntity() (*qrcode.QRCode, error) {
+	id, err := value_objects.ParseUUID(r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid QR code ID: %w", err)
+	}
+
+	eventID, err := value_objects.ParseUUID(r.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event ID: %w", err)
+	}
+
+	qr := &qrcode.QRCode{
+		ID:         id,
+		EventID:    eventID,
+		Type:       r.Type,
+		Token:      r.Token,
+		ValidFrom:  r.ValidFrom.UTC(),
+		ValidUntil: r.ValidUntil.UTC(),
+		UsageCount: r.UsageCount,
+		MaxUsage:   r.MaxUsage,
+		CreatedAt:  r.CreatedAt,
+	}
+
+	if r.CreatedBy.Valid {
+		createdBy, err := value_objects.ParseUUID(r.CreatedBy.String)
+		if err == nil {
+			qr.CreatedBy = &createdBy
+		}
+	}
+
+	return qr, nil
+}
+
+// fromEntity converte uma entidade de domínio para linha do banco
+func (repo *QRCodeRepository) fromEntity(qr *qrcode.QRCode) *qrCodeRow {
+	row := &qrCodeRow{
+		ID:         qr.ID.String(),
+		EventID:    qr.EventID.String(),
+		Type:       qr.Type,
+		Token:      qr.Token,
+		ValidFrom:  qr.ValidFrom,
+		ValidUntil: qr.ValidUntil,
+		UsageCount: qr.UsageCount,
+		MaxUsage:   qr.MaxUsage,
+		CreatedAt:  qr.CreatedAt,
+	}
+
+	if qr.CreatedBy != nil {
+		row.CreatedBy = sql.NullString{String: qr.CreatedBy.String(), Valid: true}
+	}
+
+	return row
+}
+
+// Create cria um novo QR Code
+func (repo *QRCodeRepository) Create(ctx context.Context, qr *qrcode.QRCode) error {
+	row := repo.fromEntity(qr)
+
+	query := `
+		INSERT INTO event_qr_code (
+			id_qr_code, id_event, qr_type, qr_token, valid_from, valid_until,
+			usage_count, max_usage, created_at, created_by
+		) VALUES (
+			:id_qr_code, :id_event, :qr_type, :qr_token, :valid_from, :valid_until,
+			:usage_count, :max_usage, :created_at, :created_by
+		)`
+
+	_, err := repo.db.NamedExecContext(ctx, query, row)
+	if err != nil {
+		repo.logger.Error("Failed to create QR code", zap.Error(err), zap.String("qr_code_id", qr.ID.String()))
+		return fmt.Errorf("failed to create QR code: %w", err)
+	}
+
+	repo.logger.Debug("QR code created successfully",
+		zap.String("qr_code_id", qr.ID.String()),
+		zap.String("event_id", qr.EventID.String()),
+		zap.String("qr_type", qr.Type))
+	return nil
+}
+
+// GetByID busca um QR Code pelo ID
+func (repo *QRCodeRepository) GetByID(ctx context.Context, id value_objects.UUID) (*qrcode.QRCode, error) {
+	var row qrCodeRow
+	query := `
+		SELECT id_qr_code, id_event, qr_type, qr_token, valid_from, valid_until,
+			   usage_count, max_usage, created_at, created_by
+		FROM event_qr_code
+		WHERE id_qr_code = $1`
+
+	err := repo.db.GetContext(ctx, &row, query, id.String())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewDomainError("NOT_FOUND", "QR code not found", nil)
+		}
+		repo.logger.Error("Failed to get QR code by ID", zap.Error(err), zap.String("qr_code_id", id.String()))
+		return nil, fmt.Errorf("failed to get QR code: %w", err)
+	}
+
+	return row.toEntity()
+}
+
+// GetLatest busca o QR Code mais recente do evento ainda válido e com usos disponíveis
+func (repo *QRCodeRepository) GetLatest(ctx context.Context, eventID value_objects.UUID, qrType string, at time.Time) (*qrcode.QRCode, error) {
+	var row qrCodeRow
+	query := `
+		SELECT id_qr_code, id_event, qr_type, qr_token, valid_from, valid_until,
+			   usage_count, max_usage, created_at, created_by
+		FROM event_qr_code
+		WHERE id_event = $1 AND qr_type = $2
+		  AND valid_from <= $3 AND valid_until > $3
+		  AND usage_count < max_usage
+		ORDER BY valid_from DESC
+		LIMIT 1`
+
+	err := repo.db.GetContext(ctx, &row, query, eventID.String(), qrType, at)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewDomainError("NOT_FOUND", "QR code not found", nil)
+		}
+		repo.logger.Error("Failed to get latest QR code",
+			zap.Error(err),
+			zap.String("event_id", eventID.String()),
+			zap.String("qr_type", qrType))
+		return nil, fmt.Errorf("failed to get latest QR code: %w", err)
+	}
+
+	return row.toEntity()
+}
+
+// RegisterUsage incrementa o uso do QR Code de forma atômica
+func (repo *QRCodeRepository) RegisterUsage(ctx context.Context, id value_objects.UUID, at time.Time) (bool, error) {
+	query := `
+		UPDATE event_qr_code SET
+			usage_count = usage_count + 1,
+			last_used_at = $2
+		WHERE id_qr_code = $1 AND usage_count < max_usage AND valid_until > $2`
+
+	result, err := repo.db.ExecContext(ctx, query, id.String(), at)
+	if err != nil {
+		repo.logger.Error("Failed to register QR code usage", zap.Error(err), zap.String("qr_code_id", id.String()))
+		return false, fmt.Errorf("failed to register QR code usage: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ReleaseUsage devolve um uso registrado do QR Code
+func (repo *QRCodeRepository) ReleaseUsage(ctx context.Context, id value_objects.UUID) error {
+	query := `
+		UPDATE event_qr_code SET
+			usage_count = usage_count - 1
+		WHERE id_qr_code = $1 AND usage_count > 0`
+
+	if _, err := repo.db.ExecContext(ctx, query, id.String()); err != nil {
+		repo.logger.Error("Failed to release QR code usage", zap.Error(err), zap.String("qr_code_id", id.String()))
+		return fmt.Errorf("failed to release QR code usage: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,347 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"eventos-backend/internal/domain/retention"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// RetentionRepository implementa retention.Repository usando PostgreSQL.
+// Presenças nunca são apagadas: biometria, fotos e localização são limpas das linhas de checkin e checkout,
+// que continuam formando as sessões de trabalho. Apenas event_log e audit_log têm linhas removidas.
+type RetentionRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+// NewRetentionRepository cria uma nova instância do repositório de retenção
+func NewRetentionRepository(db *sqlx.DB, logger *zap.Logger) retention.Repository {
+	return &RetentionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// retentionTarget descreve uma tabela eliminada por uma classe de dados
+type retentionTarget struct {
+	table   string // tabela alterada, com o alias t nas condições
+	id      string // chave primária
+	expired string // FROM/WHERE dos registros vencidos e ainda não eliminados ($1 tenant, $2 limite do prazo)
+	set     string // colunas limpas; vazio remove a linha
+}
+
+// eventEnded restringe a tabela t aos registros de eventos do tenant encerrados antes do limite do prazo
+const eventEnded = `JOIN events e ON e.id = t.id_event WHERE t.id_tenant = $1 AND e.final_date < $2`
+
+// locationColumns limpa as coordenadas e os metadados de GPS; (0, 0) é tratado como presença sem localização.
+// location_mock é mantido: é o resultado da validação da presença, não a localização.
+const locationColumns = `latitude = 0, longitude = 0, location_accuracy = NULL, location_altitude = NULL,
+	location_fix_time = NULL, location_provider = NULL`
+
+// locationPending seleciona presenças com coordenadas ou metadados de GPS ainda gravados
+const locationPending = `(NOT (t.latitude = 0 AND t.longitude = 0) OR t.location_accuracy IS NOT NULL
+	OR t.location_altitude IS NOT NULL OR t.location_fix_time IS NOT NULL OR t.location_provider IS NOT NULL)`
+
+// retentionTargets lista as tabelas eliminadas por classe de dados
+var retentionTargets = map[string][]retentionTarget{
+	constants.RetentionClassBiometrics: {
+		{"checkin", "id_checkin", `FROM checkin t ` + eventEnded + `
+			AND (t.face_embedding IS NOT NULL OR t.face_embedding_sealed IS NOT NULL OR t.face_embedding_digest IS NOT NULL)`,
+			`face_embedding = NULL, face_embedding_sealed = NULL, face_embedding_digest = NULL`},
+	},
+	constants.RetentionClassPhotos: {
+		{"checkin", "id_checkin", `FROM checkin t ` + eventEnded + ` AND (t.photo_key IS NOT NULL OR t.photo_url IS NOT NULL)`,
+			`photo_url = NULL, photo_key = NULL`},
+		{"checkout", "id_checkout", `FROM checkout t ` + eventEnded + ` AND t.photo_url IS NOT NULL`,
+			`photo_url = NULL`},
+	},
+	constants.RetentionClassLocations: {
+		{"checkin", "id_checkin", `FROM checkin t ` + eventEnded + ` AND ` + locationPending, locationColumns},
+		{"checkout", "id_checkout", `FROM checkout t ` + eventEnded + ` AND ` + locationPending, locationColumns},
+	},
+	constants.RetentionClassLogs: {
+		{"event_log", "id_event_log", `FROM event_log t ` + eventEnded, ""},
+		// Entradas de auditoria não pertencem a um evento; as eliminações registradas são mantidas como evidência
+		{"audit_log", "id_audit_log", `FROM audit_log t WHERE t.id_tenant = $1 AND t.created_at < $2
+			AND t.table_name <> '` + constants.RetentionAuditTable + `'`, ""},
+	},
+}
+
+// retentionPolicyRow representa uma linha de política de retenção no banco de dados
+type retentionPolicyRow struct {
+	TenantID      string         `db:"tenant_id"`
+	DataClass     string         `db:"data_class"`
+	RetentionDays int            `db:"retention_days"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+	UpdatedBy     sql.NullString `db:"updated_by"`
+}
+
+// toEntity converte retentionPolicyRow para entidade Policy
+func (r *retentionPolicyRow) toEntity() (*retention.Policy, error) {
+	tenantID, err := value_objects.ParseUUID(r.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant ID: %w", err)
+	}
+
+	return &retention.Policy{
+		TenantID:  tenantID,
+		DataClass: r.DataClass,
+		Days:      r.RetentionDays,
+		UpdatedAt: r.UpdatedAt,
+		UpdatedBy: parseNullUUID(r.UpdatedBy),
+	}, nil
+}
+
+// ListPolicies lista as políticas de retenção do tenant
+func (repo *RetentionRepository) ListPolicies(ctx context.Context, tenantID value_objects.UUID) ([]*retention.Policy, error) {
+	query := `
+		SELECT tenant_id, data_class, retention_days, updated_at, updated_by
+		FROM data_retention_policies
+		WHERE tenant_id = $1
+		ORDER BY data_class`
+
+	var rows []retentionPolicyRow
+	if err := repo.db.SelectContext(ctx, &rows, query, tenantID.String()); err != nil {
+		repo.logger.Error("Failed to list retention policies", zap.Error(err), zap.String("tenant_id", tenantID.String()))
+		return nil, fmt.Errorf("failed to list retention policies: %w", err)
+	}
+
+	policies := make([]*retention.Policy, 0, len(rows))
+	for i := range rows {
+		policy, err := rows[i].toEntity()
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+// SavePolicy cria ou substitui a política da classe de dados
+func (repo *RetentionRepository) SavePolicy(ctx context.Context, policy *retention.Policy) error {
+	query := `
+		INSERT INTO data_retention_policies (tenant_id, data_class, retention_days, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, data_class) DO UPDATE
+		SET retention_days = EXCLUDED.retention_days, updated_at = EXCLUDED.updated_at, updated_by = EXCLUDED.updated_by`
+
+	_, err := repo.db.ExecContext(ctx, query,
+		policy.TenantID.String(), policy.DataClass, policy.Days, policy.UpdatedAt, toNullUUID(policy.UpdatedBy))
+	if err != nil {
+		repo.logger.Error("Failed to save retention policy", zap.Error(err), zap.String("data_class", policy.DataClass))
+		return fmt.Errorf("failed to save retention policy: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePolicy remove a política da classe de dados
+func (repo *RetentionRepository) DeletePolicy(ctx context.Context, tenantID value_objects.UUID, dataClass string) error {
+	result, err := repo.db.ExecContext(ctx,
+		`DELETE FROM data_retention_policies WHERE tenant_id = $1 AND data_class = $2`, tenantID.String(), dataClass)
+	if err != nil {
+		repo.logger.Error("Failed to delete retention policy", zap.Error(err), zap.String("data_class", dataClass))
+		return fmt.Errorf("failed to delete retention policy: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete retention policy: %w", err)
+	}
+
+	if affected == 0 {
+		return errors.NewNotFoundError("Retention policy", dataClass)
+	}
+
+	return nil
+}
+
+// ListTenantIDs lista os tenants com ao menos uma política de retenção
+func (repo *RetentionRepository) ListTenantIDs(ctx context.Context) ([]value_objects.UUID, error) {
+	var ids []string
+	if err := repo.db.SelectContext(ctx, &ids, `SELECT DISTINCT tenant_id FROM data_retention_policies`); err != nil {
+		repo.logger.Error("Failed to list retention tenants", zap.Error(err))
+		return nil, fmt.Errorf("failed to list retention tenants: %w", err)
+	}
+
+	tenantIDs := make([]value_objects.UUID, 0, len(ids))
+	for _, id := range ids {
+		tenantID, err := value_objects.ParseUUID(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tenant ID: %w", err)
+		}
+		tenantIDs = append(tenantIDs, tenantID)
+	}
+
+	return tenantIDs, nil
+}
+
+// Count conta os registros vencidos da classe e, para fotos, os arquivos guardados no armazenamento
+func (repo *RetentionRepository) Count(ctx context.Context, tenantID value_objects.UUID, dataClass string, cutoff time.Time) (int, int, error) {
+	records := 0
+	for _, target := range retentionTargets[dataClass] {
+		var count int
+		if err := repo.db.GetContext(ctx, &count, `SELECT COUNT(*) `+target.expired, tenantID.String(), cutoff); err != nil {
+			repo.logger.Error("Failed to count expired records", zap.Error(err), zap.String("table", target.table))
+			return 0, 0, fmt.Errorf("failed to count expired %s records: %w", target.table, err)
+		}
+		records += count
+	}
+
+	photos := 0
+	if dataClass == constants.RetentionClassPhotos {
+		query := `SELECT COUNT(*) FROM checkin t ` + eventEnded + ` AND t.photo_key IS NOT NULL`
+		if err := repo.db.GetContext(ctx, &photos, query, tenantID.String(), cutoff); err != nil {
+			repo.logger.Error("Failed to count expired photos", zap.Error(err))
+			return 0, 0, fmt.Errorf("failed to count expired photos: %w", err)
+		}
+	}
+
+	return records, photos, nil
+}
+
+// expiredPhotoRow representa uma presença com foto vencida
+type expiredPhotoRow struct {
+	Source   string `db:"source"`
+	RecordID string `db:"record_id"`
+	Key      string `db:"photo_key"`
+}
+
+// ListExpiredPhotos lista um lote de check-ins e check-outs com foto vencida; check-outs guardam apenas a URL
+func (repo *RetentionRepository) ListExpiredPhotos(ctx context.Context, tenantID value_objects.UUID, cutoff time.Time, limit int) ([]retention.ExpiredPhoto, error) {
+	targets := retentionTargets[constants.RetentionClassPhotos]
+	query := `
+		SELECT 'checkin' AS source, t.id_checkin AS record_id, COALESCE(t.photo_key, '') AS photo_key ` + targets[0].expired + `
+		UNION ALL
+		SELECT 'checkout' AS source, t.id_checkout AS record_id, '' AS photo_key ` + targets[1].expired + `
+		LIMIT $3`
+
+	var rows []expiredPhotoRow
+	if err := repo.db.SelectContext(ctx, &rows, query, tenantID.String(), cutoff, limit); err != nil {
+		repo.logger.Error("Failed to list expired photos", zap.Error(err), zap.String("tenant_id", tenantID.String()))
+		return nil, fmt.Errorf("failed to list expired photos: %w", err)
+	}
+
+	photos := make([]retention.ExpiredPhoto, 0, len(rows))
+	for _, row := range rows {
+		recordID, err := value_objects.ParseUUID(row.RecordID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s ID: %w", row.Source, err)
+		}
+		photos = append(photos, retention.ExpiredPhoto{Source: row.Source, RecordID: recordID, Key: row.Key})
+	}
+
+	return photos, nil
+}
+
+// ClearPhotos apaga as referências às fotos das presenças informadas
+func (repo *RetentionRepository) ClearPhotos(ctx context.Context, tenantID value_objects.UUID, photos []retention.ExpiredPhoto) (int, error) {
+	ids := map[string][]string{}
+	for _, photo := range photos {
+		ids[photo.Source] = append(ids[photo.Source], photo.RecordID.String())
+	}
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin photo purge transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	cleared := 0
+	for _, target := range retentionTargets[constants.RetentionClassPhotos] {
+		if len(ids[target.table]) == 0 {
+			continue
+		}
+
+		statement := `UPDATE ` + target.table + ` SET ` + target.set + ` WHERE id_tenant = $1 AND ` + target.id + ` = ANY($2)`
+		count, err := execCount(ctx, tx, statement, tenantID.String(), pq.Array(ids[target.table]))
+		if err != nil {
+			repo.logger.Error("Failed to clear expired photos", zap.Error(err), zap.String("table", target.table))
+			return 0, fmt.Errorf("failed to clear expired %s photos: %w", target.table, err)
+		}
+		cleared += count
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit photo purge: %w", err)
+	}
+
+	return cleared, nil
+}
+
+// PurgeBatch elimina um lote de cada tabela da classe em uma única transação.
+// Linhas bloqueadas por outra transação ficam para o próximo lote.
+func (repo *RetentionRepository) PurgeBatch(ctx context.Context, tenantID value_objects.UUID, dataClass string, cutoff time.Time, limit int) (int, error) {
+	if dataClass == constants.RetentionClassPhotos {
+		return 0, errors.NewValidationError("data_class", "photos are purged through ListExpiredPhotos and ClearPhotos")
+	}
+
+	targets, ok := retentionTargets[dataClass]
+	if !ok {
+		return 0, errors.NewValidationError("data_class", "invalid data class")
+	}
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin retention purge transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	purged := 0
+	for _, target := range targets {
+		batch := `SELECT t.` + target.id + ` ` + target.expired + ` LIMIT $3 FOR UPDATE OF t SKIP LOCKED`
+
+		statement := `DELETE FROM ` + target.table + ` WHERE ` + target.id + ` IN (` + batch + `)`
+		if target.set != "" {
+			statement = `UPDATE ` + target.table + ` SET ` + target.set + ` WHERE ` + target.id + ` IN (` + batch + `)`
+		}
+
+		count, err := execCount(ctx, tx, statement, tenantID.String(), cutoff, limit)
+		if err != nil {
+			repo.logger.Error("Failed to purge expired records", zap.Error(err),
+				zap.String("data_class", dataClass), zap.String("table", target.table))
+			return 0, fmt.Errorf("failed to purge expired %s records: %w", target.table, err)
+		}
+		purged += count
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit retention purge: %w", err)
+	}
+
+	return purged, nil
+}
+
+// RecordPurge registra a eliminação em audit_log, com o relatório da execução em new_values
+func (repo *RetentionRepository) RecordPurge(ctx context.Context, report *retention.Report, triggeredBy *value_objects.UUID) error {
+	values, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode retention report: %w", err)
+	}
+
+	query := `
+		INSERT INTO audit_log (id_tenant, table_name, record_id, action, new_values, user_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err = repo.db.ExecContext(ctx, query,
+		report.TenantID, constants.RetentionAuditTable, report.ID, constants.ActionDelete,
+		string(values), toNullUUID(triggeredBy), report.CompletedAt)
+	if err != nil {
+		repo.logger.Error("Failed to record retention purge", zap.Error(err), zap.String("report_id", report.ID))
+		return fmt.Errorf("failed to record retention purge: %w", err)
+	}
+
+	return nil
+}
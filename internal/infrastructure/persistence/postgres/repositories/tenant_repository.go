@@ -30,18 +30,19 @@ func NewTenantRepository(db *sqlx.DB, logger *zap.Logger) tenant.Repository {
 
 // tenantRow representa uma linha da tabela tenant no banco
 type tenantRow struct {
-	ID           string         `db:"id_tenant"`
-	ConfigID     sql.NullString `db:"id_config_tenant"`
-	Name         string         `db:"name"`
-	Identity     sql.NullString `db:"identity"`
-	IdentityType sql.NullString `db:"type_identity"`
-	Email        sql.NullString `db:"email"`
-	Address      sql.NullString `db:"address"`
-	Active       bool           `db:"active"`
-	CreatedAt    time.Time      `db:"created_at"`
-	UpdatedAt    time.Time      `db:"updated_at"`
-	CreatedBy    sql.NullString `db:"created_by"`
-	UpdatedBy    sql.NullString `db:"updated_by"`
+	ID                  string         `db:"id_tenant"`
+	ConfigID            sql.NullString `db:"id_config_tenant"`
+	Name                string         `db:"name"`
+	Identity            sql.NullString `db:"identity"`
+	IdentityType        sql.NullString `db:"type_identity"`
+	Email               sql.NullString `db:"email"`
+	Address             sql.NullString `db:"address"`
+	Active              bool           `db:"active"`
+	RejectMockLocations bool           `db:"reject_mock_locations"`
+	CreatedAt           time.Time      `db:"created_at"`
+	UpdatedAt           time.Time      `db:"updated_at"`
+	CreatedBy           sql.NullString `db:"created_by"`
+	UpdatedBy           sql.NullString `db:"updated_by"`
 }
 
 // toEntity converte uma linha do banco para entidade de domínio
@@ -52,11 +53,12 @@ func (r *tenantRow) toEntity() (*tenant.Tenant, error) {
 	}
 
 	t := &tenant.Tenant{
-		ID:        id,
-		Name:      r.Name,
-		Active:    r.Active,
-		CreatedAt: r.CreatedAt,
-		UpdatedAt: r.UpdatedAt,
+		ID:                  id,
+		Name:                r.Name,
+		Active:              r.Active,
+		RejectMockLocations: r.RejectMockLocations,
+		CreatedAt:           r.CreatedAt,
+		UpdatedAt:           r.UpdatedAt,
 	}
 
 	// Campos opcionais
@@ -106,11 +108,12 @@ func (r *tenantRow) toEntity() (*tenant.Tenant, error) {
 // fromEntity converte uma entidade de domínio para linha do banco
 func (repo *TenantRepository) fromEntity(t *tenant.Tenant) *tenantRow {
 	row := &tenantRow{
-		ID:        t.ID.String(),
-		Name:      t.Name,
-		Active:    t.Active,
-		CreatedAt: t.CreatedAt,
-		UpdatedAt: t.UpdatedAt,
+		ID:                  t.ID.String(),
+		Name:                t.Name,
+		Active:              t.Active,
+		RejectMockLocations: t.RejectMockLocations,
+		CreatedAt:           t.CreatedAt,
+		UpdatedAt:           t.UpdatedAt,
 	}
 
 	// Campos opcionais
@@ -150,10 +153,10 @@ func (repo *TenantRepository) Create(ctx context.Context, t *tenant.Tenant) erro
 	query := `
 		INSERT INTO tenant (
 			id_tenant, id_config_tenant, name, identity, type_identity, 
-			email, address, active, created_at, updated_at, created_by, updated_by
+			email, address, active, reject_mock_locations, created_at, updated_at, created_by, updated_by
 		) VALUES (
 			:id_tenant, :id_config_tenant, :name, :identity, :type_identity,
-			:email, :address, :active, :created_at, :updated_at, :created_by, :updated_by
+			:email, :address, :active, :reject_mock_locations, :created_at, :updated_at, :created_by, :updated_by
 		)`
 
 	row := repo.fromEntity(t)
@@ -171,7 +174,7 @@ func (repo *TenantRepository) Create(ctx context.Context, t *tenant.Tenant) erro
 func (repo *TenantRepository) GetByID(ctx context.Context, id value_objects.UUID) (*tenant.Tenant, error) {
 	query := `
 		SELECT id_tenant, id_config_tenant, name, identity, type_identity,
-		       email, address, active, created_at, updated_at, created_by, updated_by
+		       email, address, active, reject_mock_locations, created_at, updated_at, created_by, updated_by
 		FROM tenant 
 		WHERE id_tenant = $1`
 
@@ -192,7 +195,7 @@ func (repo *TenantRepository) GetByID(ctx context.Context, id value_objects.UUID
 func (repo *TenantRepository) GetByIdentity(ctx context.Context, identity string) (*tenant.Tenant, error) {
 	query := `
 		SELECT id_tenant, id_config_tenant, name, identity, type_identity,
-		       email, address, active, created_at, updated_at, created_by, updated_by
+		       email, address, active, reject_mock_locations, created_at, updated_at, created_by, updated_by
 		FROM tenant 
 		WHERE identity = $1`
 
@@ -213,7 +216,7 @@ func (repo *TenantRepository) GetByIdentity(ctx context.Context, identity string
 func (repo *TenantRepository) GetByEmail(ctx context.Context, email string) (*tenant.Tenant, error) {
 	query := `
 		SELECT id_tenant, id_config_tenant, name, identity, type_identity,
-		       email, address, active, created_at, updated_at, created_by, updated_by
+		       email, address, active, reject_mock_locations, created_at, updated_at, created_by, updated_by
 		FROM tenant 
 		WHERE email = $1`
 
@@ -241,6 +244,7 @@ func (repo *TenantRepository) Update(ctx context.Context, t *tenant.Tenant) erro
 			email = :email,
 			address = :address,
 			active = :active,
+			reject_mock_locations = :reject_mock_locations,
 			updated_at = :updated_at,
 			updated_by = :updated_by
 		WHERE id_tenant = :id_tenant`
@@ -297,7 +301,7 @@ func (repo *TenantRepository) List(ctx context.Context, filters tenant.ListFilte
 	// Construir query base
 	baseQuery := `
 		SELECT id_tenant, id_config_tenant, name, identity, type_identity,
-		       email, address, active, created_at, updated_at, created_by, updated_by
+		       email, address, active, reject_mock_locations, created_at, updated_at, created_by, updated_by
 		FROM tenant`
 
 	countQuery := "SELECT COUNT(*) FROM tenant"
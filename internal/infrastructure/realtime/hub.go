@@ -0,0 +1,125 @@
+package realtime
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"eventos-backend/internal/domain/shared/constants"
+
+	"go.uber.org/zap"
+)
+
+// Hub distribui as notificações do feed às conexões abertas nesta instância.
+// Entre instâncias a distribuição é feita pelo RabbitMQ: cada instância consome uma fila própria e repassa ao seu hub.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	logger      *zap.Logger
+}
+
+// Subscription representa uma conexão inscrita no feed de um tenant
+type Subscription struct {
+	tenantID      string
+	eventID       string
+	types         map[string]bool
+	notifications chan *Notification
+	dropped       atomic.Int64
+}
+
+// NewHub cria uma nova instância do hub
+func NewHub(logger *zap.Logger) *Hub {
+	return &Hub{
+		subscribers: make(map[*Subscription]struct{}),
+		logger:      logger,
+	}
+}
+
+// Subscribe inscreve uma conexão no feed do tenant.
+// eventID vazio recebe todos os eventos do tenant; types vazio recebe todos os tipos de notificação.
+func (h *Hub) Subscribe(tenantID, eventID string, types []string) *Subscription {
+	sub := &Subscription{
+		tenantID:      tenantID,
+		eventID:       eventID,
+		notifications: make(chan *Notification, constants.FeedSubscriberBuffer),
+	}
+
+	if len(types) > 0 {
+		sub.types = make(map[string]bool, len(types))
+		for _, t := range types {
+			sub.types[t] = true
+		}
+	}
+
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+
+	return sub
+}
+
+// Unsubscribe remove a inscrição e fecha o canal de notificações
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(h.subscribers, sub)
+	close(sub.notifications)
+}
+
+// Broadcast entrega a notificação às inscrições do tenant e do evento.
+// Conexões lentas não bloqueiam as demais: quando o buffer está cheio a notificação é descartada para aquela conexão.
+func (h *Hub) Broadcast(notification *Notification) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subscribers {
+		if !sub.matches(notification) {
+			continue
+		}
+
+		select {
+		case sub.notifications <- notification:
+		default:
+			if sub.dropped.Add(1) == 1 {
+				h.logger.Warn("Feed subscriber is not keeping up, dropping notifications",
+					zap.String("tenant_id", sub.tenantID),
+					zap.String("event_id", sub.eventID),
+				)
+			}
+		}
+	}
+}
+
+// SubscriberCount retorna o número de conexões inscritas nesta instância
+func (h *Hub) SubscriberCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers)
+}
+
+// Notifications retorna o canal de notificações da inscrição; é fechado no Unsubscribe
+func (s *Subscription) Notifications() <-chan *Notification {
+	return s.notifications
+}
+
+// Dropped retorna quantas notificações foram descartadas por falta de espaço no buffer
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// matches verifica se a notificação pertence ao escopo da inscrição
+func (s *Subscription) matches(notification *Notification) bool {
+	if notification.TenantID != s.tenantID {
+		return false
+	}
+
+	if s.eventID != "" && notification.EventID != s.eventID {
+		return false
+	}
+
+	return s.types == nil || s.types[notification.Type]
+}
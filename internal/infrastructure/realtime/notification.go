@@ -0,0 +1,74 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"eventos-backend/internal/infrastructure/messaging/rabbitmq"
+)
+
+// MessageTypes lista os tipos de mensagem entregues no feed em tempo real
+var MessageTypes = []string{
+	rabbitmq.MessageTypeCheckinPerformed,
+	rabbitmq.MessageTypeCheckinValidated,
+	rabbitmq.MessageTypeCheckinInvalid,
+	rabbitmq.MessageTypeCheckoutPerformed,
+	rabbitmq.MessageTypeCheckoutValidated,
+	rabbitmq.MessageTypeCheckoutInvalid,
+	rabbitmq.MessageTypeWorkSessionCompleted,
+}
+
+// IsFeedMessageType verifica se o tipo de mensagem é entregue no feed
+func IsFeedMessageType(messageType string) bool {
+	for _, t := range MessageTypes {
+		if t == messageType {
+			return true
+		}
+	}
+	return false
+}
+
+// Notification representa uma notificação enviada aos clientes conectados ao feed
+type Notification struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	TenantID  string          `json:"tenant_id"`
+	EventID   string          `json:"event_id"`
+	Timestamp time.Time       `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// NewNotification converte uma mensagem de check-in, check-out ou sessão de trabalho em notificação.
+// O corpo pode ser o payload original (barramento local) ou o mapa decodificado do RabbitMQ.
+func NewNotification(message *rabbitmq.Message) (*Notification, error) {
+	data, err := json.Marshal(message.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message body: %w", err)
+	}
+
+	var scope struct {
+		TenantID string `json:"tenant_id"`
+		EventID  string `json:"event_id"`
+	}
+	if err := json.Unmarshal(data, &scope); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal message scope: %w", err)
+	}
+
+	if scope.TenantID == "" {
+		scope.TenantID, _ = message.GetTenantID()
+	}
+
+	if scope.TenantID == "" || scope.EventID == "" {
+		return nil, fmt.Errorf("message %s has no tenant or event", message.ID)
+	}
+
+	return &Notification{
+		ID:        message.ID,
+		Type:      message.Type,
+		TenantID:  scope.TenantID,
+		EventID:   scope.EventID,
+		Timestamp: message.Timestamp,
+		Data:      data,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package realtime
+
+import (
+	"context"
+
+	"eventos-backend/internal/infrastructure/messaging/rabbitmq"
+)
+
+// Publisher publica os eventos de check-in, check-out e sessões de trabalho.
+// *rabbitmq.Publisher implementa esta interface.
+type Publisher interface {
+	PublishCheckinEvent(ctx context.Context, eventType string, payload rabbitmq.CheckinEventPayload) error
+	PublishCheckoutEvent(ctx context.Context, eventType string, payload rabbitmq.CheckoutEventPayload) error
+	PublishWorkSessionEvent(ctx context.Context, eventType string, payload rabbitmq.WorkSessionEventPayload) error
+}
+
+// SelectPublisher retorna o publisher do RabbitMQ quando disponível, que alcança todas as instâncias,
+// ou o barramento local do hub. Retorna nil quando nenhum dos dois está configurado.
+func SelectPublisher(publisher *rabbitmq.Publisher, hub *Hub) Publisher {
+	if publisher != nil {
+		return publisher
+	}
+
+	if hub != nil {
+		return NewLocalPublisher(hub)
+	}
+
+	return nil
+}
+
+// LocalPublisher entrega os eventos diretamente ao hub desta instância.
+// Usado quando o RabbitMQ está indisponível: apenas as conexões desta instância recebem as notificações.
+type LocalPublisher struct {
+	hub *Hub
+}
+
+// NewLocalPublisher cria uma nova instância do publisher local
+func NewLocalPublisher(hub *Hub) *LocalPublisher {
+	return &LocalPublisher{hub: hub}
+}
+
+// PublishCheckinEvent publica eventos relacionados a check-ins
+func (p *LocalPublisher) PublishCheckinEvent(ctx context.Context, eventType string, payload rabbitmq.CheckinEventPayload) error {
+	return p.publish(rabbitmq.NewMessage(eventType, payload).SetTenantID(payload.TenantID))
+}
+
+// PublishCheckoutEvent publica eventos relacionados a check-outs
+func (p *LocalPublisher) PublishCheckoutEvent(ctx context.Context, eventType string, payload rabbitmq.CheckoutEventPayload) error {
+	return p.publish(rabbitmq.NewMessage(eventType, payload).SetTenantID(payload.TenantID))
+}
+
+// PublishWorkSessionEvent publica eventos relacionados a sessões de trabalho
+func (p *LocalPublisher) PublishWorkSessionEvent(ctx context.Context, eventType string, payload rabbitmq.WorkSessionEventPayload) error {
+	return p.publish(rabbitmq.NewMessage(eventType, payload).SetTenantID(payload.TenantID))
+}
+
+// publish converte a mensagem em notificação e entrega ao hub
+func (p *LocalPublisher) publish(message *rabbitmq.Message) error {
+	if !IsFeedMessageType(message.Type) {
+		return nil
+	}
+
+	notification, err := NewNotification(message)
+	if err != nil {
+		return err
+	}
+
+	p.hub.Broadcast(notification)
+	return nil
+}
@@ -7,7 +7,11 @@ import (
 	"eventos-backend/internal/domain/checkin"
 	"eventos-backend/internal/domain/shared/errors"
 	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/domain/storage"
 	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
+	"eventos-backend/internal/infrastructure/messaging/rabbitmq"
+	"eventos-backend/internal/infrastructure/realtime"
+	"eventos-backend/internal/interfaces/http/middleware"
 	httpResponses "eventos-backend/internal/interfaces/http/responses"
 
 	"github.com/gin-gonic/gin"
@@ -17,13 +21,19 @@ import (
 // CheckinHandler gerencia as operações de check-in
 type CheckinHandler struct {
 	checkinService checkin.Service
+	publisher      realtime.Publisher
+	photos         storage.Service
 	logger         *zap.Logger
 }
 
-// NewCheckinHandler cria uma nova instância do handler de check-in
-func NewCheckinHandler(checkinService checkin.Service, logger *zap.Logger) *CheckinHandler {
+// NewCheckinHandler cria uma nova instância do handler de check-in.
+// Sem publisher os check-ins e as decisões de aprovação não são publicados;
+// sem photos as fotos de evidência não recebem URL de download nas respostas.
+func NewCheckinHandler(checkinService checkin.Service, publisher realtime.Publisher, photos storage.Service, logger *zap.Logger) *CheckinHandler {
 	return &CheckinHandler{
 		checkinService: checkinService,
+		publisher:      publisher,
+		photos:         photos,
 		logger:         logger,
 	}
 }
@@ -40,6 +50,77 @@ type PerformCheckinRequest struct {
 	Notes         string    `json:"notes"`
 	FaceEmbedding []float32 `json:"face_embedding"`
 	QRCodeData    string    `json:"qr_code_data"`
+	LocationFixRequest
+
+	// Substituição de check-in anulado
+	ReplacesCheckinID string `json:"replaces_checkin_id"`
+}
+
+// WalkUpCheckinRequest representa um check-in em que o funcionário é identificado pelo rosto
+type WalkUpCheckinRequest struct {
+	EventID       string    `json:"event_id" binding:"required"`
+	FaceEmbedding []float32 `json:"face_embedding" binding:"required"`
+	Latitude      float64   `json:"latitude" binding:"required"`
+	Longitude     float64   `json:"longitude" binding:"required"`
+	Notes         string    `json:"notes"`
+	LocationFixRequest
+}
+
+// LocationFixRequest representa os metadados opcionais da leitura de GPS enviados junto com a localização
+type LocationFixRequest struct {
+	Accuracy         *float64   `json:"accuracy" binding:"omitempty,min=0"` // Raio de precisão em metros
+	Altitude         *float64   `json:"altitude"`                           // Altitude em metros
+	FixTime          *time.Time `json:"fix_time"`                           // Momento em que a posição foi obtida
+	LocationProvider string     `json:"location_provider" binding:"omitempty,oneof=gps network fused passive"`
+	IsMockLocation   bool       `json:"is_mock_location"` // Posição simulada reportada pelo sistema operacional
+}
+
+// toLocationFix converte os metadados da leitura de GPS; nil quando o dispositivo não informou nenhum
+func (r LocationFixRequest) toLocationFix() (*value_objects.LocationFix, error) {
+	if r.Accuracy == nil && r.Altitude == nil && r.FixTime == nil && r.LocationProvider == "" && !r.IsMockLocation {
+		return nil, nil
+	}
+
+	accuracy := 0.0
+	if r.Accuracy != nil {
+		accuracy = *r.Accuracy
+	}
+
+	return value_objects.NewLocationFix(accuracy, r.Altitude, r.FixTime, r.LocationProvider, r.IsMockLocation)
+}
+
+// LocationFixResponse representa os metadados da leitura de GPS na resposta
+type LocationFixResponse struct {
+	Accuracy         float64    `json:"accuracy"`
+	Altitude         *float64   `json:"altitude,omitempty"`
+	FixTime          *time.Time `json:"fix_time,omitempty"`
+	LocationProvider string     `json:"location_provider,omitempty"`
+	IsMockLocation   bool       `json:"is_mock_location"`
+}
+
+// toLocationFixResponse converte os metadados da leitura de GPS para a resposta
+func toLocationFixResponse(fix *value_objects.LocationFix) *LocationFixResponse {
+	if fix == nil {
+		return nil
+	}
+
+	return &LocationFixResponse{
+		Accuracy:         fix.Accuracy,
+		Altitude:         fix.Altitude,
+		FixTime:          fix.FixTime,
+		LocationProvider: fix.Provider,
+		IsMockLocation:   fix.IsMock,
+	}
+}
+
+// VoidRequest representa a anulação de um check-in ou check-out
+type VoidRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// ReviewCheckinRequest representa a decisão do supervisor sobre um check-in pendente
+type ReviewCheckinRequest struct {
+	Comment string `json:"comment" binding:"max=500"`
 }
 
 // CheckinResponse representa a resposta de um check-in
@@ -51,12 +132,23 @@ type CheckinResponse struct {
 	PartnerID         string                 `json:"partner_id"`
 	Method            string                 `json:"method"`
 	Location          LocationResponse       `json:"location"`
+	LocationFix       *LocationFixResponse   `json:"location_fix,omitempty"`
 	CheckinTime       time.Time              `json:"checkin_time"`
 	PhotoURL          string                 `json:"photo_url,omitempty"`
+	PhotoURLExpiresAt *time.Time             `json:"photo_url_expires_at,omitempty"` // Validade da URL assinada da foto de evidência
 	Notes             string                 `json:"notes,omitempty"`
+	DeviceID          string                 `json:"device_id,omitempty"`
 	IsValid           bool                   `json:"is_valid"`
 	ValidationDetails map[string]interface{} `json:"validation_details,omitempty"`
 	Status            string                 `json:"status"`
+	VoidedAt          *time.Time             `json:"voided_at,omitempty"`
+	VoidedBy          *string                `json:"voided_by,omitempty"`
+	VoidReason        string                 `json:"void_reason,omitempty"`
+	ReplacesCheckinID *string                `json:"replaces_checkin_id,omitempty"`
+	ApprovalStatus    string                 `json:"approval_status,omitempty"`
+	ReviewedAt        *time.Time             `json:"reviewed_at,omitempty"`
+	ReviewedBy        *string                `json:"reviewed_by,omitempty"`
+	ReviewComment     string                 `json:"review_comment,omitempty"`
 	CreatedAt         time.Time              `json:"created_at"`
 	UpdatedAt         time.Time              `json:"updated_at"`
 	CreatedBy         *string                `json:"created_by,omitempty"`
@@ -108,7 +200,7 @@ func (h *CheckinHandler) PerformCheckin(c *gin.Context) {
 	}
 
 	// Obter informações do usuário autenticado
-	userClaims, exists := c.Get("user")
+	userClaims, exists := c.Get("claims")
 	if !exists {
 		h.logger.Error("User claims not found in context")
 		httpResponses.Unauthorized(c, "Authentication required")
@@ -166,6 +258,36 @@ func (h *CheckinHandler) PerformCheckin(c *gin.Context) {
 		return
 	}
 
+	locationFix, err := req.toLocationFix()
+	if err != nil {
+		h.logger.Warn("Invalid location fix", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid location fix", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	// Dispositivo autenticado que registra o check-in
+	var deviceID string
+	if d, ok := middleware.GetDevice(c); ok {
+		if !d.IsBoundTo(eventID) {
+			h.logger.Warn("Device not bound to event", zap.String("device_id", d.ID.String()), zap.String("event_id", eventID.String()))
+			httpResponses.Forbidden(c, "Device is not bound to this event")
+			return
+		}
+		deviceID = d.ID.String()
+	}
+
+	// Check-in anulado substituído por este registro
+	var replacesID *value_objects.UUID
+	if req.ReplacesCheckinID != "" {
+		id, err := value_objects.ParseUUID(req.ReplacesCheckinID)
+		if err != nil {
+			h.logger.Warn("Invalid replaces checkin ID", zap.String("replaces_checkin_id", req.ReplacesCheckinID))
+			httpResponses.BadRequest(c, "Invalid replaces checkin ID", nil)
+			return
+		}
+		replacesID = &id
+	}
+
 	// Criar requisição de check-in
 	checkinRequest := checkin.CheckinRequest{
 		TenantID:      tenantID,
@@ -174,11 +296,14 @@ func (h *CheckinHandler) PerformCheckin(c *gin.Context) {
 		PartnerID:     partnerID,
 		Method:        req.Method,
 		Location:      location,
+		LocationFix:   locationFix,
 		PhotoURL:      req.PhotoURL,
 		Notes:         req.Notes,
 		FaceEmbedding: req.FaceEmbedding,
 		QRCodeData:    req.QRCodeData,
+		DeviceID:      deviceID,
 		CreatedBy:     userID,
+		ReplacesID:    replacesID,
 	}
 
 	// Realizar check-in
@@ -199,12 +324,125 @@ func (h *CheckinHandler) PerformCheckin(c *gin.Context) {
 		zap.Bool("is_valid", validationResult.IsValid),
 	)
 
+	// Tentativas inválidas também são registradas e notificadas
+	eventType := rabbitmq.MessageTypeCheckinPerformed
+	if !checkinResult.IsValid && !checkinResult.IsPendingApproval() {
+		eventType = rabbitmq.MessageTypeCheckinInvalid
+	}
+	h.publishCheckin(c, eventType, checkinResult)
+
 	httpResponses.Created(c, map[string]interface{}{
 		"checkin":    response,
 		"validation": validationResponse,
 	}, "Check-in realizado com sucesso")
 }
 
+// PerformWalkUpCheckin identifica o funcionário pelo rosto entre os participantes do evento e realiza o check-in
+func (h *CheckinHandler) PerformWalkUpCheckin(c *gin.Context) {
+	var req WalkUpCheckinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid walk-up checkin request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	// Obter informações do usuário autenticado
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		h.logger.Error("User claims not found in context")
+		httpResponses.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid tenant ID")
+		return
+	}
+
+	userID, err := value_objects.ParseUUID(claims.UserID)
+	if err != nil {
+		h.logger.Error("Invalid user ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid user ID")
+		return
+	}
+
+	eventID, err := value_objects.ParseUUID(req.EventID)
+	if err != nil {
+		h.logger.Warn("Invalid event ID", zap.String("event_id", req.EventID))
+		httpResponses.BadRequest(c, "Invalid event ID", nil)
+		return
+	}
+
+	location, err := value_objects.NewLocation(req.Latitude, req.Longitude)
+	if err != nil {
+		h.logger.Warn("Invalid location", zap.Float64("latitude", req.Latitude), zap.Float64("longitude", req.Longitude))
+		httpResponses.BadRequest(c, "Invalid location coordinates", nil)
+		return
+	}
+
+	locationFix, err := req.toLocationFix()
+	if err != nil {
+		h.logger.Warn("Invalid location fix", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid location fix", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	// Dispositivo autenticado que registra o check-in
+	var deviceID string
+	if d, ok := middleware.GetDevice(c); ok {
+		if !d.IsBoundTo(eventID) {
+			h.logger.Warn("Device not bound to event", zap.String("device_id", d.ID.String()), zap.String("event_id", eventID.String()))
+			httpResponses.Forbidden(c, "Device is not bound to this event")
+			return
+		}
+		deviceID = d.ID.String()
+	}
+
+	checkinResult, validationResult, err := h.checkinService.PerformWalkUpCheckin(c.Request.Context(), checkin.WalkUpRequest{
+		TenantID:      tenantID,
+		EventID:       eventID,
+		FaceEmbedding: req.FaceEmbedding,
+		Location:      location,
+		LocationFix:   locationFix,
+		Notes:         req.Notes,
+		DeviceID:      deviceID,
+		CreatedBy:     userID,
+	})
+	if err != nil {
+		h.handleServiceError(c, err, "perform walk-up checkin")
+		return
+	}
+
+	h.logger.Info("Walk-up checkin performed successfully",
+		zap.String("checkin_id", checkinResult.ID.String()),
+		zap.String("employee_id", checkinResult.EmployeeID.String()),
+		zap.String("event_id", eventID.String()),
+		zap.Bool("is_valid", validationResult.IsValid),
+	)
+
+	eventType := rabbitmq.MessageTypeCheckinPerformed
+	if !checkinResult.IsValid && !checkinResult.IsPendingApproval() {
+		eventType = rabbitmq.MessageTypeCheckinInvalid
+	}
+	h.publishCheckin(c, eventType, checkinResult)
+
+	httpResponses.Created(c, map[string]interface{}{
+		"checkin":    h.toCheckinResponse(checkinResult),
+		"validation": h.toValidationResultResponse(validationResult),
+	}, "Check-in realizado com sucesso")
+}
+
 // GetByID busca um check-in por ID
 func (h *CheckinHandler) GetByID(c *gin.Context) {
 	idParam := c.Param("id")
@@ -304,6 +542,13 @@ func (h *CheckinHandler) List(c *gin.Context) {
 		}
 	}
 
+	// Parse do filtro de anulados
+	if voidedStr := c.Query("voided"); voidedStr != "" {
+		if voided, err := strconv.ParseBool(voidedStr); err == nil {
+			filters.Voided = &voided
+		}
+	}
+
 	// Parse do filtro de foto
 	if hasPhotoStr := c.Query("has_photo"); hasPhotoStr != "" {
 		if hasPhoto, err := strconv.ParseBool(hasPhotoStr); err == nil {
@@ -553,6 +798,308 @@ func (h *CheckinHandler) AddNote(c *gin.Context) {
 	httpResponses.Success(c, nil, "Observação adicionada com sucesso")
 }
 
+// Void anula um check-in registrado por engano
+func (h *CheckinHandler) Void(c *gin.Context) {
+	idParam := c.Param("id")
+	checkinID, err := value_objects.ParseUUID(idParam)
+	if err != nil {
+		h.logger.Warn("Invalid checkin ID", zap.String("id", idParam))
+		httpResponses.BadRequest(c, "Invalid checkin ID", nil)
+		return
+	}
+
+	var req VoidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid void checkin request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid tenant ID")
+		return
+	}
+
+	userID, err := value_objects.ParseUUID(claims.UserID)
+	if err != nil {
+		h.logger.Error("Invalid user ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid user ID")
+		return
+	}
+
+	voided, err := h.checkinService.VoidCheckin(c.Request.Context(), checkinID, tenantID, req.Reason, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "void checkin")
+		return
+	}
+
+	h.logger.Info("Checkin voided",
+		zap.String("checkin_id", checkinID.String()),
+		zap.String("voided_by", userID.String()),
+	)
+	httpResponses.Success(c, h.toCheckinResponse(voided), "Check-in anulado com sucesso")
+}
+
+// AttachPhoto anexa a foto de evidência ao check-in (multipart/form-data, campo "photo").
+// A foto é gravada uma única vez; um segundo envio é rejeitado.
+func (h *CheckinHandler) AttachPhoto(c *gin.Context) {
+	idParam := c.Param("id")
+	checkinID, err := value_objects.ParseUUID(idParam)
+	if err != nil {
+		h.logger.Warn("Invalid checkin ID", zap.String("id", idParam))
+		httpResponses.BadRequest(c, "Invalid checkin ID", nil)
+		return
+	}
+
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid tenant ID")
+		return
+	}
+
+	userID, err := value_objects.ParseUUID(claims.UserID)
+	if err != nil {
+		h.logger.Error("Invalid user ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid user ID")
+		return
+	}
+
+	file, header, ok := openPhotoUpload(c, h.logger)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	updated, err := h.checkinService.AttachPhoto(c.Request.Context(), checkinID, tenantID, header.Header.Get("Content-Type"), header.Size, file, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "attach checkin photo")
+		return
+	}
+
+	h.logger.Info("Checkin photo attached",
+		zap.String("checkin_id", checkinID.String()),
+		zap.String("photo_key", updated.PhotoKey),
+	)
+	httpResponses.Success(c, h.toCheckinResponse(updated), "Foto anexada ao check-in")
+}
+
+// ListPending lista os check-ins aguardando aprovação do supervisor
+func (h *CheckinHandler) ListPending(c *gin.Context) {
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid tenant ID")
+		return
+	}
+
+	filters := checkin.ListFilters{
+		Page:     1,
+		PageSize: 20,
+		OrderBy:  "checkin_time",
+	}
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			filters.Page = page
+		}
+	}
+
+	if pageSizeStr := c.Query("page_size"); pageSizeStr != "" {
+		if pageSize, err := strconv.Atoi(pageSizeStr); err == nil && pageSize > 0 && pageSize <= 100 {
+			filters.PageSize = pageSize
+		}
+	}
+
+	if eventIDStr := c.Query("event_id"); eventIDStr != "" {
+		if eventID, err := value_objects.ParseUUID(eventIDStr); err == nil {
+			filters.EventID = &eventID
+		}
+	}
+
+	if method := c.Query("method"); method != "" {
+		filters.Method = &method
+	}
+
+	checkins, total, err := h.checkinService.ListPendingCheckins(c.Request.Context(), tenantID, filters)
+	if err != nil {
+		h.handleServiceError(c, err, "list pending checkins")
+		return
+	}
+
+	checkinResponses := make([]CheckinResponse, len(checkins))
+	for i, checkin := range checkins {
+		checkinResponses[i] = h.toCheckinResponse(checkin)
+	}
+
+	totalPages := (total + filters.PageSize - 1) / filters.PageSize
+	response := CheckinListResponse{
+		Checkins: checkinResponses,
+		Pagination: httpResponses.Pagination{
+			Page:       filters.Page,
+			PageSize:   filters.PageSize,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}
+
+	httpResponses.Success(c, response, "Check-ins pendentes recuperados com sucesso")
+}
+
+// Approve aprova um check-in pendente
+func (h *CheckinHandler) Approve(c *gin.Context) {
+	h.review(c, true)
+}
+
+// Reject rejeita um check-in pendente
+func (h *CheckinHandler) Reject(c *gin.Context) {
+	h.review(c, false)
+}
+
+// review aplica a decisão do supervisor e publica checkin.validated ou checkin.invalid
+func (h *CheckinHandler) review(c *gin.Context, approve bool) {
+	idParam := c.Param("id")
+	checkinID, err := value_objects.ParseUUID(idParam)
+	if err != nil {
+		h.logger.Warn("Invalid checkin ID", zap.String("id", idParam))
+		httpResponses.BadRequest(c, "Invalid checkin ID", nil)
+		return
+	}
+
+	var req ReviewCheckinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid review checkin request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid tenant ID")
+		return
+	}
+
+	userID, err := value_objects.ParseUUID(claims.UserID)
+	if err != nil {
+		h.logger.Error("Invalid user ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid user ID")
+		return
+	}
+
+	var reviewed *checkin.Checkin
+	var eventType, message string
+	if approve {
+		reviewed, err = h.checkinService.ApproveCheckin(c.Request.Context(), checkinID, tenantID, req.Comment, userID)
+		eventType, message = rabbitmq.MessageTypeCheckinValidated, "Check-in aprovado com sucesso"
+	} else {
+		reviewed, err = h.checkinService.RejectCheckin(c.Request.Context(), checkinID, tenantID, req.Comment, userID)
+		eventType, message = rabbitmq.MessageTypeCheckinInvalid, "Check-in rejeitado com sucesso"
+	}
+	if err != nil {
+		h.handleServiceError(c, err, "review checkin")
+		return
+	}
+
+	h.publishCheckin(c, eventType, reviewed)
+
+	h.logger.Info("Checkin reviewed",
+		zap.String("checkin_id", checkinID.String()),
+		zap.String("approval_status", reviewed.ApprovalStatus),
+		zap.String("reviewed_by", userID.String()),
+	)
+	httpResponses.Success(c, h.toCheckinResponse(reviewed), message)
+}
+
+// publishCheckin publica o check-in ou a decisão do supervisor; falhas de publicação não desfazem a operação
+func (h *CheckinHandler) publishCheckin(c *gin.Context, eventType string, record *checkin.Checkin) {
+	if h.publisher == nil {
+		return
+	}
+
+	payload := rabbitmq.CheckinEventPayload{
+		CheckinID:      record.ID.String(),
+		TenantID:       record.TenantID.String(),
+		EventID:        record.EventID.String(),
+		EmployeeID:     record.EmployeeID.String(),
+		PartnerID:      record.PartnerID.String(),
+		Method:         record.Method,
+		IsValid:        record.IsValid,
+		CheckinTime:    record.CheckinTime,
+		ApprovalStatus: record.ApprovalStatus,
+		ReviewComment:  record.ReviewComment,
+	}
+	if record.ReviewedBy != nil {
+		payload.ReviewedBy = record.ReviewedBy.String()
+	}
+
+	if err := h.publisher.PublishCheckinEvent(c.Request.Context(), eventType, payload); err != nil {
+		h.logger.Warn("Failed to publish checkin event",
+			zap.String("checkin_id", record.ID.String()),
+			zap.String("event_type", eventType),
+			zap.Error(err),
+		)
+	}
+}
+
 // GetStats obtém estatísticas de check-ins
 func (h *CheckinHandler) GetStats(c *gin.Context) {
 	// Obter informações do usuário autenticado
@@ -666,9 +1213,11 @@ func (h *CheckinHandler) toCheckinResponse(c *checkin.Checkin) CheckinResponse {
 			Latitude:  c.Location.Latitude,
 			Longitude: c.Location.Longitude,
 		},
+		LocationFix:       toLocationFixResponse(c.LocationFix),
 		CheckinTime:       c.CheckinTime,
 		PhotoURL:          c.PhotoURL,
 		Notes:             c.Notes,
+		DeviceID:          c.DeviceID,
 		IsValid:           c.IsValid,
 		ValidationDetails: c.ValidationDetails,
 		Status:            h.getCheckinStatus(c),
@@ -676,6 +1225,11 @@ func (h *CheckinHandler) toCheckinResponse(c *checkin.Checkin) CheckinResponse {
 		UpdatedAt:         c.UpdatedAt,
 	}
 
+	// Foto de evidência armazenada é entregue por URL assinada de curta duração
+	if c.PhotoKey != "" {
+		response.PhotoURL, response.PhotoURLExpiresAt = signPhotoURL(h.photos, c.TenantID, c.PhotoKey, h.logger)
+	}
+
 	// Adicionar CreatedBy se existir
 	if c.CreatedBy != nil {
 		createdBy := c.CreatedBy.String()
@@ -688,6 +1242,32 @@ func (h *CheckinHandler) toCheckinResponse(c *checkin.Checkin) CheckinResponse {
 		response.UpdatedBy = &updatedBy
 	}
 
+	// Adicionar dados da anulação se existirem
+	if c.IsVoided() {
+		response.VoidedAt = c.VoidedAt
+		response.VoidReason = c.VoidReason
+		if c.VoidedBy != nil {
+			voidedBy := c.VoidedBy.String()
+			response.VoidedBy = &voidedBy
+		}
+	}
+
+	if c.ReplacesID != nil {
+		replacesID := c.ReplacesID.String()
+		response.ReplacesCheckinID = &replacesID
+	}
+
+	// Adicionar dados da aprovação se existirem
+	if c.ApprovalStatus != "" {
+		response.ApprovalStatus = c.ApprovalStatus
+		response.ReviewedAt = c.ReviewedAt
+		response.ReviewComment = c.ReviewComment
+		if c.ReviewedBy != nil {
+			reviewedBy := c.ReviewedBy.String()
+			response.ReviewedBy = &reviewedBy
+		}
+	}
+
 	return response
 }
 
@@ -706,6 +1286,14 @@ func (h *CheckinHandler) toValidationResultResponse(vr *checkin.ValidationResult
 
 // getCheckinStatus determina o status do check-in
 func (h *CheckinHandler) getCheckinStatus(c *checkin.Checkin) string {
+	if c.IsVoided() {
+		return "voided"
+	}
+
+	if c.IsPendingApproval() {
+		return "pending"
+	}
+
 	if c.IsValid {
 		return "valid"
 	}
@@ -725,13 +1313,13 @@ func (h *CheckinHandler) handleServiceError(c *gin.Context, err error, operation
 
 	if domainErr, ok := err.(*errors.DomainError); ok {
 		switch domainErr.Type {
-		case "ValidationError":
+		case "ValidationError", "VALIDATION_ERROR":
 			httpResponses.BadRequest(c, domainErr.Message, domainErr.Context)
-		case "AlreadyExistsError":
+		case "AlreadyExistsError", "ALREADY_EXISTS":
 			httpResponses.Conflict(c, domainErr.Message, domainErr.Context)
 		case "NotFoundError", "NOT_FOUND":
 			httpResponses.NotFound(c, domainErr.Message)
-		case "ForbiddenError":
+		case "ForbiddenError", "FORBIDDEN":
 			httpResponses.Forbidden(c, domainErr.Message)
 		default:
 			httpResponses.InternalServerError(c, "Failed to "+operation)
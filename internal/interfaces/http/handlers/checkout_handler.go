@@ -5,9 +5,13 @@ import (
 	"time"
 
 	"eventos-backend/internal/domain/checkout"
+	"eventos-backend/internal/domain/shared/constants"
 	"eventos-backend/internal/domain/shared/errors"
 	"eventos-backend/internal/domain/shared/value_objects"
 	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
+	"eventos-backend/internal/infrastructure/messaging/rabbitmq"
+	"eventos-backend/internal/infrastructure/realtime"
+	"eventos-backend/internal/interfaces/http/middleware"
 	httpResponses "eventos-backend/internal/interfaces/http/responses"
 
 	"github.com/gin-gonic/gin"
@@ -17,13 +21,16 @@ import (
 // CheckoutHandler gerencia as operações de check-out
 type CheckoutHandler struct {
 	checkoutService checkout.Service
+	publisher       realtime.Publisher
 	logger          *zap.Logger
 }
 
-// NewCheckoutHandler cria uma nova instância do handler de check-out
-func NewCheckoutHandler(checkoutService checkout.Service, logger *zap.Logger) *CheckoutHandler {
+// NewCheckoutHandler cria uma nova instância do handler de check-out.
+// Sem publisher os check-outs não são publicados.
+func NewCheckoutHandler(checkoutService checkout.Service, publisher realtime.Publisher, logger *zap.Logger) *CheckoutHandler {
 	return &CheckoutHandler{
 		checkoutService: checkoutService,
+		publisher:       publisher,
 		logger:          logger,
 	}
 }
@@ -41,30 +48,47 @@ type PerformCheckoutRequest struct {
 	Notes         string    `json:"notes"`
 	FaceEmbedding []float32 `json:"face_embedding"`
 	QRCodeData    string    `json:"qr_code_data"`
+	LocationFixRequest
+
+	// Substituição de check-out anulado
+	ReplacesCheckoutID string `json:"replaces_checkout_id"`
 }
 
 // CheckoutResponse representa a resposta de um check-out
 type CheckoutResponse struct {
-	ID                string                 `json:"id"`
-	TenantID          string                 `json:"tenant_id"`
-	EventID           string                 `json:"event_id"`
-	EmployeeID        string                 `json:"employee_id"`
-	PartnerID         string                 `json:"partner_id"`
-	CheckinID         string                 `json:"checkin_id"`
-	Method            string                 `json:"method"`
-	Location          LocationResponse       `json:"location"`
-	CheckoutTime      time.Time              `json:"checkout_time"`
-	PhotoURL          string                 `json:"photo_url,omitempty"`
-	Notes             string                 `json:"notes,omitempty"`
-	WorkDuration      string                 `json:"work_duration"` // Formato "2h30m"
-	WorkDurationHours float64                `json:"work_duration_hours"`
-	IsValid           bool                   `json:"is_valid"`
-	ValidationDetails map[string]interface{} `json:"validation_details,omitempty"`
-	Status            string                 `json:"status"`
-	CreatedAt         time.Time              `json:"created_at"`
-	UpdatedAt         time.Time              `json:"updated_at"`
-	CreatedBy         *string                `json:"created_by,omitempty"`
-	UpdatedBy         *string                `json:"updated_by,omitempty"`
+	ID                 string                 `json:"id"`
+	TenantID           string                 `json:"tenant_id"`
+	EventID            string                 `json:"event_id"`
+	EmployeeID         string                 `json:"employee_id"`
+	PartnerID          string                 `json:"partner_id"`
+	CheckinID          string                 `json:"checkin_id"`
+	Method             string                 `json:"method"`
+	Location           LocationResponse       `json:"location"`
+	LocationFix        *LocationFixResponse   `json:"location_fix,omitempty"`
+	CheckoutTime       time.Time              `json:"checkout_time"`
+	PhotoURL           string                 `json:"photo_url,omitempty"`
+	Notes              string                 `json:"notes,omitempty"`
+	DeviceID           string                 `json:"device_id,omitempty"`
+	WorkDuration       string                 `json:"work_duration"` // Formato "2h30m"
+	WorkDurationHours  float64                `json:"work_duration_hours"`
+	IsValid            bool                   `json:"is_valid"`
+	ValidationDetails  map[string]interface{} `json:"validation_details,omitempty"`
+	Status             string                 `json:"status"`
+	AutoClosed         bool                   `json:"auto_closed"`
+	VoidedAt           *time.Time             `json:"voided_at,omitempty"`
+	VoidedBy           *string                `json:"voided_by,omitempty"`
+	VoidReason         string                 `json:"void_reason,omitempty"`
+	ReplacesCheckoutID *string                `json:"replaces_checkout_id,omitempty"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+	CreatedBy          *string                `json:"created_by,omitempty"`
+	UpdatedBy          *string                `json:"updated_by,omitempty"`
+}
+
+// CorrectCheckoutTimeRequest representa a correção do horário de um check-out encerrado automaticamente
+type CorrectCheckoutTimeRequest struct {
+	CheckoutTime time.Time `json:"checkout_time" binding:"required"`
+	Reason       string    `json:"reason" binding:"required,max=500"`
 }
 
 // CheckoutValidationResult representa o resultado de validação do check-out
@@ -87,20 +111,21 @@ type CheckoutListResponse struct {
 
 // WorkSessionResponse representa uma sessão de trabalho
 type WorkSessionResponse struct {
-	CheckinID         string    `json:"checkin_id"`
-	CheckoutID        string    `json:"checkout_id"`
-	EmployeeID        string    `json:"employee_id"`
-	EventID           string    `json:"event_id"`
-	PartnerID         string    `json:"partner_id"`
-	CheckinTime       time.Time `json:"checkin_time"`
-	CheckoutTime      time.Time `json:"checkout_time"`
-	Duration          string    `json:"duration"` // Formato "2h30m"
-	DurationHours     float64   `json:"duration_hours"`
-	DurationMinutes   float64   `json:"duration_minutes"`
-	IsComplete        bool      `json:"is_complete"`
-	IsValid           bool      `json:"is_valid"`
-	IsShortSession    bool      `json:"is_short_session"`
-	IsOvertimeSession bool      `json:"is_overtime_session"`
+	CheckinID         string     `json:"checkin_id"`
+	CheckoutID        string     `json:"checkout_id,omitempty"`
+	EmployeeID        string     `json:"employee_id"`
+	EventID           string     `json:"event_id"`
+	PartnerID         string     `json:"partner_id"`
+	Status            string     `json:"status"` // open ou closed
+	CheckinTime       time.Time  `json:"checkin_time"`
+	CheckoutTime      *time.Time `json:"checkout_time,omitempty"`
+	Duration          string     `json:"duration"` // Formato "2h30m"
+	DurationHours     float64    `json:"duration_hours"`
+	DurationMinutes   float64    `json:"duration_minutes"`
+	IsComplete        bool       `json:"is_complete"`
+	IsValid           bool       `json:"is_valid"`
+	IsShortSession    bool       `json:"is_short_session"`
+	IsOvertimeSession bool       `json:"is_overtime_session"`
 }
 
 // WorkSessionListResponse representa a resposta de listagem de sessões de trabalho
@@ -163,7 +188,7 @@ func (h *CheckoutHandler) PerformCheckout(c *gin.Context) {
 	}
 
 	// Obter informações do usuário autenticado
-	userClaims, exists := c.Get("user")
+	userClaims, exists := c.Get("claims")
 	if !exists {
 		h.logger.Error("User claims not found in context")
 		httpResponses.Unauthorized(c, "Authentication required")
@@ -228,6 +253,36 @@ func (h *CheckoutHandler) PerformCheckout(c *gin.Context) {
 		return
 	}
 
+	locationFix, err := req.toLocationFix()
+	if err != nil {
+		h.logger.Warn("Invalid location fix", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid location fix", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	// Dispositivo autenticado que registra o check-out
+	var deviceID string
+	if d, ok := middleware.GetDevice(c); ok {
+		if !d.IsBoundTo(eventID) {
+			h.logger.Warn("Device not bound to event", zap.String("device_id", d.ID.String()), zap.String("event_id", eventID.String()))
+			httpResponses.Forbidden(c, "Device is not bound to this event")
+			return
+		}
+		deviceID = d.ID.String()
+	}
+
+	// Check-out anulado substituído por este registro
+	var replacesID *value_objects.UUID
+	if req.ReplacesCheckoutID != "" {
+		id, err := value_objects.ParseUUID(req.ReplacesCheckoutID)
+		if err != nil {
+			h.logger.Warn("Invalid replaces checkout ID", zap.String("replaces_checkout_id", req.ReplacesCheckoutID))
+			httpResponses.BadRequest(c, "Invalid replaces checkout ID", nil)
+			return
+		}
+		replacesID = &id
+	}
+
 	// Criar requisição de check-out
 	checkoutRequest := checkout.CheckoutRequest{
 		TenantID:      tenantID,
@@ -237,11 +292,14 @@ func (h *CheckoutHandler) PerformCheckout(c *gin.Context) {
 		CheckinID:     checkinID,
 		Method:        req.Method,
 		Location:      location,
+		LocationFix:   locationFix,
 		PhotoURL:      req.PhotoURL,
 		Notes:         req.Notes,
 		FaceEmbedding: req.FaceEmbedding,
 		QRCodeData:    req.QRCodeData,
+		DeviceID:      deviceID,
 		CreatedBy:     userID,
+		ReplacesID:    replacesID,
 	}
 
 	// Realizar check-out
@@ -264,12 +322,46 @@ func (h *CheckoutHandler) PerformCheckout(c *gin.Context) {
 		zap.Duration("work_duration", checkoutResult.WorkDuration),
 	)
 
+	eventType := rabbitmq.MessageTypeCheckoutPerformed
+	if !checkoutResult.IsValid {
+		eventType = rabbitmq.MessageTypeCheckoutInvalid
+	}
+	h.publishCheckout(c, eventType, checkoutResult)
+
 	httpResponses.Created(c, map[string]interface{}{
 		"checkout":   response,
 		"validation": validationResponse,
 	}, "Check-out realizado com sucesso")
 }
 
+// publishCheckout publica o check-out realizado; falhas de publicação não desfazem a operação
+func (h *CheckoutHandler) publishCheckout(c *gin.Context, eventType string, record *checkout.Checkout) {
+	if h.publisher == nil {
+		return
+	}
+
+	payload := rabbitmq.CheckoutEventPayload{
+		CheckoutID:   record.ID.String(),
+		CheckinID:    record.CheckinID.String(),
+		TenantID:     record.TenantID.String(),
+		EventID:      record.EventID.String(),
+		EmployeeID:   record.EmployeeID.String(),
+		PartnerID:    record.PartnerID.String(),
+		Method:       record.Method,
+		IsValid:      record.IsValid,
+		CheckoutTime: record.CheckoutTime,
+		WorkDuration: record.WorkDuration,
+	}
+
+	if err := h.publisher.PublishCheckoutEvent(c.Request.Context(), eventType, payload); err != nil {
+		h.logger.Warn("Failed to publish checkout event",
+			zap.String("checkout_id", record.ID.String()),
+			zap.String("event_type", eventType),
+			zap.Error(err),
+		)
+	}
+}
+
 // GetByID busca um check-out por ID
 func (h *CheckoutHandler) GetByID(c *gin.Context) {
 	idParam := c.Param("id")
@@ -376,6 +468,13 @@ func (h *CheckoutHandler) List(c *gin.Context) {
 		}
 	}
 
+	// Parse do filtro de anulados
+	if voidedStr := c.Query("voided"); voidedStr != "" {
+		if voided, err := strconv.ParseBool(voidedStr); err == nil {
+			filters.Voided = &voided
+		}
+	}
+
 	// Parse do filtro de foto
 	if hasPhotoStr := c.Query("has_photo"); hasPhotoStr != "" {
 		if hasPhoto, err := strconv.ParseBool(hasPhotoStr); err == nil {
@@ -680,6 +779,16 @@ func (h *CheckoutHandler) GetWorkSessions(c *gin.Context) {
 		}
 	}
 
+	// Parse do filtro de status da sessão (open ou closed)
+	switch c.Query("status") {
+	case constants.WorkSessionStatusOpen:
+		isComplete := false
+		filters.IsComplete = &isComplete
+	case constants.WorkSessionStatusClosed:
+		isComplete := true
+		filters.IsComplete = &isComplete
+	}
+
 	// Parse da ordenação
 	if orderBy := c.Query("order_by"); orderBy != "" {
 		filters.OrderBy = orderBy
@@ -727,6 +836,28 @@ func (h *CheckoutHandler) GetWorkSessions(c *gin.Context) {
 
 // GetEmployeeWorkSessions busca sessões de trabalho de um funcionário
 func (h *CheckoutHandler) GetEmployeeWorkSessions(c *gin.Context) {
+	// Obter informações do usuário autenticado
+	userClaims, exists := c.Get("user")
+	if !exists {
+		h.logger.Error("User claims not found in context")
+		httpResponses.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid tenant ID")
+		return
+	}
+
 	employeeIDStr := c.Param("employee_id")
 	employeeID, err := value_objects.ParseUUID(employeeIDStr)
 	if err != nil {
@@ -754,7 +885,7 @@ func (h *CheckoutHandler) GetEmployeeWorkSessions(c *gin.Context) {
 		}
 	}
 
-	workSessions, total, err := h.checkoutService.GetEmployeeWorkSessions(c.Request.Context(), employeeID, filters)
+	workSessions, total, err := h.checkoutService.GetEmployeeWorkSessions(c.Request.Context(), tenantID, employeeID, filters)
 	if err != nil {
 		h.handleServiceError(c, err, "get employee work sessions")
 		return
@@ -841,6 +972,199 @@ func (h *CheckoutHandler) AddNote(c *gin.Context) {
 	httpResponses.Success(c, nil, "Observação adicionada com sucesso")
 }
 
+// ListAutoClosed lista check-outs gerados pelo encerramento automático de sessões
+func (h *CheckoutHandler) ListAutoClosed(c *gin.Context) {
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid tenant ID")
+		return
+	}
+
+	filters := checkout.ListFilters{
+		Page:      1,
+		PageSize:  20,
+		OrderBy:   "checkout_time",
+		OrderDesc: true,
+	}
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			filters.Page = page
+		}
+	}
+
+	if pageSizeStr := c.Query("page_size"); pageSizeStr != "" {
+		if pageSize, err := strconv.Atoi(pageSizeStr); err == nil && pageSize > 0 && pageSize <= 100 {
+			filters.PageSize = pageSize
+		}
+	}
+
+	if eventIDStr := c.Query("event_id"); eventIDStr != "" {
+		eventID, err := value_objects.ParseUUID(eventIDStr)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid event ID", nil)
+			return
+		}
+		filters.EventID = &eventID
+	}
+
+	checkouts, total, err := h.checkoutService.ListAutoClosedCheckouts(c.Request.Context(), tenantID, filters)
+	if err != nil {
+		h.handleServiceError(c, err, "list auto-closed checkouts")
+		return
+	}
+
+	checkoutResponses := make([]CheckoutResponse, len(checkouts))
+	for i, checkout := range checkouts {
+		checkoutResponses[i] = h.toCheckoutResponse(checkout)
+	}
+
+	totalPages := (total + filters.PageSize - 1) / filters.PageSize
+	response := CheckoutListResponse{
+		Checkouts: checkoutResponses,
+		Pagination: httpResponses.Pagination{
+			Page:       filters.Page,
+			PageSize:   filters.PageSize,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}
+
+	httpResponses.Success(c, response, "Check-outs encerrados automaticamente recuperados com sucesso")
+}
+
+// CorrectTime corrige o horário de um check-out encerrado automaticamente
+func (h *CheckoutHandler) CorrectTime(c *gin.Context) {
+	idParam := c.Param("id")
+	checkoutID, err := value_objects.ParseUUID(idParam)
+	if err != nil {
+		h.logger.Warn("Invalid checkout ID", zap.String("id", idParam))
+		httpResponses.BadRequest(c, "Invalid checkout ID", nil)
+		return
+	}
+
+	var req CorrectCheckoutTimeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid correct checkout time request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid tenant ID")
+		return
+	}
+
+	userID, err := value_objects.ParseUUID(claims.UserID)
+	if err != nil {
+		h.logger.Error("Invalid user ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid user ID")
+		return
+	}
+
+	corrected, err := h.checkoutService.CorrectCheckoutTime(c.Request.Context(), checkoutID, tenantID, req.CheckoutTime.UTC(), req.Reason, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "correct checkout time")
+		return
+	}
+
+	h.logger.Info("Checkout time corrected",
+		zap.String("checkout_id", checkoutID.String()),
+		zap.String("corrected_by", userID.String()),
+	)
+	httpResponses.Success(c, h.toCheckoutResponse(corrected), "Horário do check-out corrigido com sucesso")
+}
+
+// Void anula um check-out registrado por engano
+func (h *CheckoutHandler) Void(c *gin.Context) {
+	idParam := c.Param("id")
+	checkoutID, err := value_objects.ParseUUID(idParam)
+	if err != nil {
+		h.logger.Warn("Invalid checkout ID", zap.String("id", idParam))
+		httpResponses.BadRequest(c, "Invalid checkout ID", nil)
+		return
+	}
+
+	var req VoidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid void checkout request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid tenant ID")
+		return
+	}
+
+	userID, err := value_objects.ParseUUID(claims.UserID)
+	if err != nil {
+		h.logger.Error("Invalid user ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid user ID")
+		return
+	}
+
+	voided, err := h.checkoutService.VoidCheckout(c.Request.Context(), checkoutID, tenantID, req.Reason, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "void checkout")
+		return
+	}
+
+	h.logger.Info("Checkout voided",
+		zap.String("checkout_id", checkoutID.String()),
+		zap.String("voided_by", userID.String()),
+	)
+	httpResponses.Success(c, h.toCheckoutResponse(voided), "Check-out anulado com sucesso")
+}
+
 // GetStats obtém estatísticas de check-outs
 func (h *CheckoutHandler) GetStats(c *gin.Context) {
 	// Obter informações do usuário autenticado
@@ -1014,14 +1338,17 @@ func (h *CheckoutHandler) toCheckoutResponse(c *checkout.Checkout) CheckoutRespo
 			Latitude:  c.Location.Latitude,
 			Longitude: c.Location.Longitude,
 		},
+		LocationFix:       toLocationFixResponse(c.LocationFix),
 		CheckoutTime:      c.CheckoutTime,
 		PhotoURL:          c.PhotoURL,
 		Notes:             c.Notes,
+		DeviceID:          c.DeviceID,
 		WorkDuration:      c.WorkDuration.String(),
 		WorkDurationHours: c.WorkDuration.Hours(),
 		IsValid:           c.IsValid,
 		ValidationDetails: c.ValidationDetails,
 		Status:            h.getCheckoutStatus(c),
+		AutoClosed:        c.AutoClosed,
 		CreatedAt:         c.CreatedAt,
 		UpdatedAt:         c.UpdatedAt,
 	}
@@ -1038,6 +1365,21 @@ func (h *CheckoutHandler) toCheckoutResponse(c *checkout.Checkout) CheckoutRespo
 		response.UpdatedBy = &updatedBy
 	}
 
+	// Adicionar dados da anulação se existirem
+	if c.IsVoided() {
+		response.VoidedAt = c.VoidedAt
+		response.VoidReason = c.VoidReason
+		if c.VoidedBy != nil {
+			voidedBy := c.VoidedBy.String()
+			response.VoidedBy = &voidedBy
+		}
+	}
+
+	if c.ReplacesID != nil {
+		replacesID := c.ReplacesID.String()
+		response.ReplacesCheckoutID = &replacesID
+	}
+
 	return response
 }
 
@@ -1057,26 +1399,38 @@ func (h *CheckoutHandler) toCheckoutValidationResultResponse(vr *checkout.Valida
 
 // toWorkSessionResponse converte uma WorkSession para WorkSessionResponse
 func (h *CheckoutHandler) toWorkSessionResponse(ws *checkout.WorkSession) WorkSessionResponse {
-	return WorkSessionResponse{
+	response := WorkSessionResponse{
 		CheckinID:         ws.CheckinID.String(),
-		CheckoutID:        ws.CheckoutID.String(),
 		EmployeeID:        ws.EmployeeID.String(),
 		EventID:           ws.EventID.String(),
 		PartnerID:         ws.PartnerID.String(),
+		Status:            ws.Status(),
 		CheckinTime:       ws.CheckinTime,
-		CheckoutTime:      ws.CheckoutTime,
 		Duration:          ws.Duration.String(),
 		DurationHours:     ws.GetDurationHours(),
 		DurationMinutes:   ws.GetDurationMinutes(),
 		IsComplete:        ws.IsComplete,
 		IsValid:           ws.IsValid,
-		IsShortSession:    ws.IsShortSession(),
+		IsShortSession:    ws.IsComplete && ws.IsShortSession(),
 		IsOvertimeSession: ws.IsLongSession(),
 	}
+
+	// Sessões abertas ainda não possuem check-out
+	if !ws.IsOpen() {
+		checkoutTime := ws.CheckoutTime
+		response.CheckoutID = ws.CheckoutID.String()
+		response.CheckoutTime = &checkoutTime
+	}
+
+	return response
 }
 
 // getCheckoutStatus determina o status do check-out
 func (h *CheckoutHandler) getCheckoutStatus(c *checkout.Checkout) string {
+	if c.IsVoided() {
+		return "voided"
+	}
+
 	if c.IsValid {
 		return "valid"
 	}
@@ -1096,13 +1450,13 @@ func (h *CheckoutHandler) handleServiceError(c *gin.Context, err error, operatio
 
 	if domainErr, ok := err.(*errors.DomainError); ok {
 		switch domainErr.Type {
-		case "ValidationError":
+		case "ValidationError", "VALIDATION_ERROR":
 			httpResponses.BadRequest(c, domainErr.Message, domainErr.Context)
-		case "AlreadyExistsError":
+		case "AlreadyExistsError", "ALREADY_EXISTS":
 			httpResponses.Conflict(c, domainErr.Message, domainErr.Context)
 		case "NotFoundError", "NOT_FOUND":
 			httpResponses.NotFound(c, domainErr.Message)
-		case "ForbiddenError":
+		case "ForbiddenError", "FORBIDDEN":
 			httpResponses.Forbidden(c, domainErr.Message)
 		default:
 			httpResponses.InternalServerError(c, "Failed to "+operation)
@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"eventos-backend/internal/domain/datasubject"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
+	httpResponses "eventos-backend/internal/interfaces/http/responses"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DataSubjectHandler gerencia as solicitações dos titulares de dados (LGPD): exportação e eliminação
+type DataSubjectHandler struct {
+	dataSubjectService datasubject.Service
+	logger             *zap.Logger
+}
+
+// NewDataSubjectHandler cria uma nova instância do handler de solicitações de titulares
+func NewDataSubjectHandler(dataSubjectService datasubject.Service, logger *zap.Logger) *DataSubjectHandler {
+	return &DataSubjectHandler{
+		dataSubjectService: dataSubjectService,
+		logger:             logger,
+	}
+}
+
+// DataSubjectRequest representa o pedido de exportação ou eliminação dos dados de um titular
+type DataSubjectRequest struct {
+	SubjectType string `json:"subject_type" binding:"required,oneof=employee partner"`
+	SubjectID   string `json:"subject_id" binding:"required"`
+	Reason      string `json:"reason" binding:"max=500"`
+}
+
+// DataSubjectRequestResponse representa uma solicitação de titular registrada
+type DataSubjectRequestResponse struct {
+	ID            string                `json:"id"`
+	SubjectType   string                `json:"subject_type"`
+	SubjectID     string                `json:"subject_id"`
+	Type          string                `json:"type"`
+	Status        string                `json:"status"`
+	Reason        string                `json:"reason,omitempty"`
+	Evidence      *datasubject.Evidence `json:"evidence,omitempty"`
+	FailureReason string                `json:"failure_reason,omitempty"`
+	RequestedBy   string                `json:"requested_by"`
+	RequestedAt   time.Time             `json:"requested_at"`
+	CompletedAt   *time.Time            `json:"completed_at,omitempty"`
+}
+
+// DataSubjectExportResponse representa a solicitação atendida com o arquivo de exportação
+type DataSubjectExportResponse struct {
+	Request DataSubjectRequestResponse `json:"request"`
+	Export  *datasubject.Export        `json:"export"`
+}
+
+// DataSubjectRequestListResponse representa a resposta de listagem de solicitações
+type DataSubjectRequestListResponse struct {
+	Requests   []DataSubjectRequestResponse `json:"requests"`
+	Pagination httpResponses.Pagination     `json:"pagination"`
+}
+
+// Export gera a cópia dos dados do titular; as fotos são entregues por URLs de download de curta duração
+func (h *DataSubjectHandler) Export(c *gin.Context) {
+	req, subjectID, ok := h.bindRequest(c)
+	if !ok {
+		return
+	}
+
+	tenantID, userID, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	request, export, err := h.dataSubjectService.Export(c.Request.Context(), tenantID, req.SubjectType, subjectID, req.Reason, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "export data subject")
+		return
+	}
+
+	h.logger.Info("Data subject exported",
+		zap.String("request_id", request.ID.String()),
+		zap.String("subject_type", req.SubjectType),
+		zap.String("subject_id", subjectID.String()),
+		zap.String("requested_by", userID.String()),
+	)
+
+	httpResponses.Success(c, DataSubjectExportResponse{
+		Request: h.toRequestResponse(request),
+		Export:  export,
+	}, "Dados do titular exportados com sucesso")
+}
+
+// Erase elimina os dados pessoais e biométricos do titular; a operação não pode ser desfeita
+func (h *DataSubjectHandler) Erase(c *gin.Context) {
+	req, subjectID, ok := h.bindRequest(c)
+	if !ok {
+		return
+	}
+
+	tenantID, userID, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	request, err := h.dataSubjectService.Erase(c.Request.Context(), tenantID, req.SubjectType, subjectID, req.Reason, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "erase data subject")
+		return
+	}
+
+	httpResponses.Success(c, h.toRequestResponse(request), "Dados do titular eliminados com sucesso")
+}
+
+// List lista as solicitações de titulares do tenant.
+// Filtros: subject_type (employee, partner), subject_id, type (export, erasure), status (processing, completed, failed).
+func (h *DataSubjectHandler) List(c *gin.Context) {
+	tenantID, _, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	filters := datasubject.ListFilters{}
+	filters.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filters.PageSize, _ = strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	if subjectType := c.Query("subject_type"); subjectType != "" {
+		filters.SubjectType = &subjectType
+	}
+
+	if subjectIDStr := c.Query("subject_id"); subjectIDStr != "" {
+		subjectID, err := value_objects.ParseUUID(subjectIDStr)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid subject ID format", nil)
+			return
+		}
+		filters.SubjectID = &subjectID
+	}
+
+	if requestType := c.Query("type"); requestType != "" {
+		filters.Type = &requestType
+	}
+
+	if status := c.Query("status"); status != "" {
+		filters.Status = &status
+	}
+
+	requests, total, err := h.dataSubjectService.ListRequests(c.Request.Context(), tenantID, filters)
+	if err != nil {
+		h.handleServiceError(c, err, "list data subject requests")
+		return
+	}
+
+	response := DataSubjectRequestListResponse{
+		Requests:   make([]DataSubjectRequestResponse, len(requests)),
+		Pagination: httpResponses.CalculatePagination(filters.Page, filters.PageSize, total),
+	}
+	for i, request := range requests {
+		response.Requests[i] = h.toRequestResponse(request)
+	}
+
+	httpResponses.Success(c, response, "Solicitações recuperadas com sucesso")
+}
+
+// GetByID busca uma solicitação de titular com sua evidência de atendimento
+func (h *DataSubjectHandler) GetByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := value_objects.ParseUUID(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid data subject request ID", zap.String("id", idStr))
+		httpResponses.BadRequest(c, "Invalid request ID format", nil)
+		return
+	}
+
+	tenantID, _, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	request, err := h.dataSubjectService.GetRequest(c.Request.Context(), tenantID, id)
+	if err != nil {
+		h.handleServiceError(c, err, "get data subject request")
+		return
+	}
+
+	httpResponses.Success(c, h.toRequestResponse(request), "Solicitação recuperada com sucesso")
+}
+
+// bindRequest lê e valida o corpo da solicitação
+func (h *DataSubjectHandler) bindRequest(c *gin.Context) (DataSubjectRequest, value_objects.UUID, bool) {
+	var req DataSubjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid data subject request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return req, value_objects.UUID{}, false
+	}
+
+	subjectID, err := value_objects.ParseUUID(req.SubjectID)
+	if err != nil {
+		httpResponses.BadRequest(c, "Invalid subject ID format", nil)
+		return req, value_objects.UUID{}, false
+	}
+
+	return req, subjectID, true
+}
+
+// getAuthContext resolve o tenant e o usuário autenticado
+func (h *DataSubjectHandler) getAuthContext(c *gin.Context) (value_objects.UUID, value_objects.UUID, bool) {
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	userID, err := value_objects.ParseUUID(claims.UserID)
+	if err != nil {
+		h.logger.Error("Invalid user ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	return tenantID, userID, true
+}
+
+// toRequestResponse converte a solicitação para a resposta
+func (h *DataSubjectHandler) toRequestResponse(r *datasubject.Request) DataSubjectRequestResponse {
+	return DataSubjectRequestResponse{
+		ID:            r.ID.String(),
+		SubjectType:   r.SubjectType,
+		SubjectID:     r.SubjectID.String(),
+		Type:          r.Type,
+		Status:        r.Status,
+		Reason:        r.Reason,
+		Evidence:      r.Evidence,
+		FailureReason: r.FailureReason,
+		RequestedBy:   r.RequestedBy.String(),
+		RequestedAt:   r.RequestedAt,
+		CompletedAt:   r.CompletedAt,
+	}
+}
+
+// handleServiceError trata erros do serviço de solicitações de titulares
+func (h *DataSubjectHandler) handleServiceError(c *gin.Context, err error, operation string) {
+	h.logger.Error("Data subject service error", zap.Error(err), zap.String("operation", operation))
+
+	if domainErr, ok := err.(*errors.DomainError); ok {
+		switch domainErr.Type {
+		case "ValidationError", "VALIDATION_ERROR":
+			httpResponses.BadRequest(c, domainErr.Message, domainErr.Context)
+		case "NotFoundError", "NOT_FOUND":
+			httpResponses.NotFound(c, domainErr.Message)
+		default:
+			httpResponses.InternalServerError(c, "Failed to "+operation)
+		}
+	} else {
+		httpResponses.InternalServerError(c, "Failed to "+operation)
+	}
+}
@@ -0,0 +1,415 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"eventos-backend/internal/domain/device"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
+	"eventos-backend/internal/interfaces/http/middleware"
+	httpResponses "eventos-backend/internal/interfaces/http/responses"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DeviceHandler gerencia o registro de quiosques e coletores portáteis
+type DeviceHandler struct {
+	deviceService device.Service
+	logger        *zap.Logger
+}
+
+// NewDeviceHandler cria uma nova instância do handler de dispositivos
+func NewDeviceHandler(deviceService device.Service, logger *zap.Logger) *DeviceHandler {
+	return &DeviceHandler{
+		deviceService: deviceService,
+		logger:        logger,
+	}
+}
+
+// RegisterDeviceRequest representa o registro de um dispositivo
+type RegisterDeviceRequest struct {
+	Name    string `json:"name" binding:"required,max=100"`
+	Type    string `json:"type" binding:"required,oneof=kiosk handheld"`
+	EventID string `json:"event_id"`
+	Gate    string `json:"gate" binding:"max=100"`
+}
+
+// UpdateDeviceRequest representa a atualização de um dispositivo
+type UpdateDeviceRequest struct {
+	Name    string `json:"name" binding:"required,max=100"`
+	EventID string `json:"event_id"`
+	Gate    string `json:"gate" binding:"max=100"`
+}
+
+// RevokeDeviceRequest representa a revogação de um dispositivo
+type RevokeDeviceRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// DeviceResponse representa a resposta de um dispositivo
+type DeviceResponse struct {
+	ID                 string     `json:"id"`
+	TenantID           string     `json:"tenant_id"`
+	Name               string     `json:"name"`
+	Type               string     `json:"type"`
+	EventID            *string    `json:"event_id,omitempty"`
+	Gate               string     `json:"gate,omitempty"`
+	CredentialIssuedAt *time.Time `json:"credential_issued_at,omitempty"`
+	AppVersion         string     `json:"app_version,omitempty"`
+	LastSeenAt         *time.Time `json:"last_seen_at,omitempty"`
+	Active             bool       `json:"active"`
+	RevokedAt          *time.Time `json:"revoked_at,omitempty"`
+	RevokeReason       string     `json:"revoke_reason,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// DeviceCredentialResponse representa um dispositivo com a credencial recém-emitida
+type DeviceCredentialResponse struct {
+	Device DeviceResponse `json:"device"`
+	Secret string         `json:"secret"` // Exibida apenas uma vez; enviar no header X-Device-Key
+}
+
+// DeviceListResponse representa a resposta de listagem de dispositivos
+type DeviceListResponse struct {
+	Devices    []DeviceResponse         `json:"devices"`
+	Pagination httpResponses.Pagination `json:"pagination"`
+}
+
+// Register registra um novo dispositivo e retorna sua credencial
+func (h *DeviceHandler) Register(c *gin.Context) {
+	var req RegisterDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid register device request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	tenantID, userID, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	eventID, ok := h.parseOptionalEventID(c, req.EventID)
+	if !ok {
+		return
+	}
+
+	d, secret, err := h.deviceService.RegisterDevice(c.Request.Context(), tenantID, req.Name, req.Type, eventID, req.Gate, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "register device")
+		return
+	}
+
+	h.logger.Info("Device registered",
+		zap.String("device_id", d.ID.String()),
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("type", d.Type),
+	)
+
+	httpResponses.Created(c, DeviceCredentialResponse{
+		Device: h.toDeviceResponse(d),
+		Secret: secret,
+	}, "Dispositivo registrado com sucesso")
+}
+
+// GetByID busca um dispositivo do tenant
+func (h *DeviceHandler) GetByID(c *gin.Context) {
+	deviceID, ok := h.parseDeviceID(c)
+	if !ok {
+		return
+	}
+
+	tenantID, _, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	d, err := h.deviceService.GetDevice(c.Request.Context(), tenantID, deviceID)
+	if err != nil {
+		h.handleServiceError(c, err, "get device")
+		return
+	}
+
+	httpResponses.Success(c, h.toDeviceResponse(d), "Dispositivo recuperado com sucesso")
+}
+
+// List lista os dispositivos do tenant
+func (h *DeviceHandler) List(c *gin.Context) {
+	tenantID, _, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	filters := device.ListFilters{}
+	filters.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filters.PageSize, _ = strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	if eventIDStr := c.Query("event_id"); eventIDStr != "" {
+		eventID, err := value_objects.ParseUUID(eventIDStr)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid event ID format", nil)
+			return
+		}
+		filters.EventID = &eventID
+	}
+
+	if deviceType := c.Query("type"); deviceType != "" {
+		filters.Type = &deviceType
+	}
+
+	if activeStr := c.Query("active"); activeStr != "" {
+		active, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid active filter", nil)
+			return
+		}
+		filters.Active = &active
+	}
+
+	if revokedStr := c.Query("revoked"); revokedStr != "" {
+		revoked, err := strconv.ParseBool(revokedStr)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid revoked filter", nil)
+			return
+		}
+		filters.Revoked = &revoked
+	}
+
+	devices, total, err := h.deviceService.ListDevices(c.Request.Context(), tenantID, filters)
+	if err != nil {
+		h.handleServiceError(c, err, "list devices")
+		return
+	}
+
+	response := DeviceListResponse{
+		Devices:    make([]DeviceResponse, len(devices)),
+		Pagination: httpResponses.CalculatePagination(filters.Page, filters.PageSize, total),
+	}
+	for i, d := range devices {
+		response.Devices[i] = h.toDeviceResponse(d)
+	}
+
+	httpResponses.Success(c, response, "Dispositivos recuperados com sucesso")
+}
+
+// Update atualiza o nome e o vínculo do dispositivo
+func (h *DeviceHandler) Update(c *gin.Context) {
+	deviceID, ok := h.parseDeviceID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid update device request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	tenantID, userID, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	eventID, ok := h.parseOptionalEventID(c, req.EventID)
+	if !ok {
+		return
+	}
+
+	d, err := h.deviceService.UpdateDevice(c.Request.Context(), tenantID, deviceID, req.Name, eventID, req.Gate, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "update device")
+		return
+	}
+
+	httpResponses.Success(c, h.toDeviceResponse(d), "Dispositivo atualizado com sucesso")
+}
+
+// RotateCredential emite uma nova credencial; a anterior deixa de valer imediatamente
+func (h *DeviceHandler) RotateCredential(c *gin.Context) {
+	deviceID, ok := h.parseDeviceID(c)
+	if !ok {
+		return
+	}
+
+	tenantID, userID, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	d, secret, err := h.deviceService.RotateCredential(c.Request.Context(), tenantID, deviceID, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "issue device credential")
+		return
+	}
+
+	h.logger.Info("Device credential issued",
+		zap.String("device_id", d.ID.String()),
+		zap.String("issued_by", userID.String()),
+	)
+
+	httpResponses.Success(c, DeviceCredentialResponse{
+		Device: h.toDeviceResponse(d),
+		Secret: secret,
+	}, "Credencial do dispositivo emitida com sucesso")
+}
+
+// Revoke revoga o dispositivo; seus envios passam a ser recusados imediatamente
+func (h *DeviceHandler) Revoke(c *gin.Context) {
+	deviceID, ok := h.parseDeviceID(c)
+	if !ok {
+		return
+	}
+
+	var req RevokeDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid revoke device request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	tenantID, userID, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	d, err := h.deviceService.RevokeDevice(c.Request.Context(), tenantID, deviceID, req.Reason, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "revoke device")
+		return
+	}
+
+	h.logger.Info("Device revoked",
+		zap.String("device_id", d.ID.String()),
+		zap.String("revoked_by", userID.String()),
+	)
+
+	httpResponses.Success(c, h.toDeviceResponse(d), "Dispositivo revogado com sucesso")
+}
+
+// Heartbeat confirma a credencial do dispositivo e registra o último acesso
+func (h *DeviceHandler) Heartbeat(c *gin.Context) {
+	d, ok := middleware.GetDevice(c)
+	if !ok {
+		httpResponses.Unauthorized(c, "Device credentials required")
+		return
+	}
+
+	httpResponses.Success(c, h.toDeviceResponse(d), "Dispositivo ativo")
+}
+
+// parseDeviceID lê o ID do dispositivo da rota
+func (h *DeviceHandler) parseDeviceID(c *gin.Context) (value_objects.UUID, bool) {
+	idStr := c.Param("id")
+	deviceID, err := value_objects.ParseUUID(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid device ID", zap.String("id", idStr))
+		httpResponses.BadRequest(c, "Invalid device ID format", nil)
+		return value_objects.UUID{}, false
+	}
+
+	return deviceID, true
+}
+
+// parseOptionalEventID lê o evento opcional ao qual o dispositivo é vinculado
+func (h *DeviceHandler) parseOptionalEventID(c *gin.Context, eventIDStr string) (*value_objects.UUID, bool) {
+	if eventIDStr == "" {
+		return nil, true
+	}
+
+	eventID, err := value_objects.ParseUUID(eventIDStr)
+	if err != nil {
+		httpResponses.BadRequest(c, "Invalid event ID format", nil)
+		return nil, false
+	}
+
+	return &eventID, true
+}
+
+// getAuthContext resolve o tenant e o usuário autenticado
+func (h *DeviceHandler) getAuthContext(c *gin.Context) (value_objects.UUID, value_objects.UUID, bool) {
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	userID, err := value_objects.ParseUUID(claims.UserID)
+	if err != nil {
+		h.logger.Error("Invalid user ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	return tenantID, userID, true
+}
+
+// toDeviceResponse converte a entidade para a resposta; o hash da credencial nunca é exposto
+func (h *DeviceHandler) toDeviceResponse(d *device.Device) DeviceResponse {
+	response := DeviceResponse{
+		ID:                 d.ID.String(),
+		TenantID:           d.TenantID.String(),
+		Name:               d.Name,
+		Type:               d.Type,
+		Gate:               d.Gate,
+		CredentialIssuedAt: d.CredentialIssuedAt,
+		AppVersion:         d.AppVersion,
+		LastSeenAt:         d.LastSeenAt,
+		Active:             d.Active,
+		RevokedAt:          d.RevokedAt,
+		RevokeReason:       d.RevokeReason,
+		CreatedAt:          d.CreatedAt,
+		UpdatedAt:          d.UpdatedAt,
+	}
+
+	if d.EventID != nil {
+		eventID := d.EventID.String()
+		response.EventID = &eventID
+	}
+
+	return response
+}
+
+// handleServiceError trata erros do serviço de dispositivos
+func (h *DeviceHandler) handleServiceError(c *gin.Context, err error, operation string) {
+	h.logger.Error("Device service error", zap.Error(err), zap.String("operation", operation))
+
+	if domainErr, ok := err.(*errors.DomainError); ok {
+		switch domainErr.Type {
+		case "ValidationError", "VALIDATION_ERROR":
+			httpResponses.BadRequest(c, domainErr.Message, domainErr.Context)
+		case "NotFoundError", "NOT_FOUND":
+			httpResponses.NotFound(c, domainErr.Message)
+		case "ForbiddenError", "FORBIDDEN":
+			httpResponses.Forbidden(c, domainErr.Message)
+		default:
+			httpResponses.InternalServerError(c, "Failed to "+operation)
+		}
+	} else {
+		httpResponses.InternalServerError(c, "Failed to "+operation)
+	}
+}
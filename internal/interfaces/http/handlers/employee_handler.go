@@ -7,6 +7,7 @@ import (
 	"eventos-backend/internal/domain/employee"
 	"eventos-backend/internal/domain/shared/errors"
 	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/domain/storage"
 	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
 	httpResponses "eventos-backend/internal/interfaces/http/responses"
 
@@ -17,13 +18,16 @@ import (
 // EmployeeHandler gerencia as operações de funcionário
 type EmployeeHandler struct {
 	employeeService employee.Service
+	photos          storage.Service
 	logger          *zap.Logger
 }
 
-// NewEmployeeHandler cria uma nova instância do handler de funcionário
-func NewEmployeeHandler(employeeService employee.Service, logger *zap.Logger) *EmployeeHandler {
+// NewEmployeeHandler cria uma nova instância do handler de funcionário.
+// Sem photos as fotos armazenadas não recebem URL de download nas respostas.
+func NewEmployeeHandler(employeeService employee.Service, photos storage.Service, logger *zap.Logger) *EmployeeHandler {
 	return &EmployeeHandler{
 		employeeService: employeeService,
+		photos:          photos,
 		logger:          logger,
 	}
 }
@@ -48,11 +52,6 @@ type UpdateEmployeeRequest struct {
 	Email        string `json:"email" binding:"required,email"`
 }
 
-// UploadPhotoRequest representa uma requisição de upload de foto
-type UploadPhotoRequest struct {
-	PhotoURL string `json:"photo_url" binding:"required"`
-}
-
 // FaceRecognitionRequest representa uma requisição de reconhecimento facial
 type FaceRecognitionRequest struct {
 	FaceEmbedding []float32 `json:"face_embedding" binding:"required"`
@@ -68,7 +67,9 @@ type EmployeeResponse struct {
 	IdentityType     string  `json:"identity_type"`
 	DateOfBirth      *string `json:"date_of_birth,omitempty"`
 	PhotoURL         string  `json:"photo_url,omitempty"`
+	PhotoURLExpires  *string `json:"photo_url_expires_at,omitempty"` // Validade da URL assinada da foto armazenada
 	HasFaceEmbedding bool    `json:"has_face_embedding"`
+	HasConsent       bool    `json:"has_biometric_consent"`
 	Phone            string  `json:"phone"`
 	Email            string  `json:"email"`
 	Active           bool    `json:"active"`
@@ -78,6 +79,94 @@ type EmployeeResponse struct {
 	UpdatedBy        *string `json:"updated_by,omitempty"`
 }
 
+// FaceTemplateRequest representa uma requisição de cadastro de template facial
+type FaceTemplateRequest struct {
+	FaceEmbedding  []float32 `json:"face_embedding" binding:"required"`
+	Quality        float32   `json:"quality" binding:"required"`
+	ModelVersion   string    `json:"model_version" binding:"required"`
+	SourcePhotoKey string    `json:"source_photo_key,omitempty"` // Vazio usa a foto de cadastro do funcionário
+	CapturedAt     string    `json:"captured_at,omitempty"`      // Format: RFC3339
+}
+
+// FaceTemplateResponse representa um template facial; o embedding não é devolvido
+type FaceTemplateResponse struct {
+	ID                    string  `json:"id"`
+	EmployeeID            string  `json:"employee_id"`
+	Quality               float32 `json:"quality"`
+	ModelVersion          string  `json:"model_version"`
+	SourcePhotoURL        string  `json:"source_photo_url,omitempty"`
+	SourcePhotoURLExpires *string `json:"source_photo_url_expires_at,omitempty"`
+	CapturedAt            string  `json:"captured_at"`
+	CreatedAt             string  `json:"created_at"`
+	CreatedBy             *string `json:"created_by,omitempty"`
+}
+
+// ConsentTextRequest representa a publicação de uma nova versão do termo de consentimento biométrico
+type ConsentTextRequest struct {
+	Content string `json:"content" binding:"required,max=20000"`
+}
+
+// ConsentTextResponse representa uma versão do termo de consentimento biométrico
+type ConsentTextResponse struct {
+	Version     int     `json:"version"`
+	Content     string  `json:"content"`
+	Digest      string  `json:"digest"`
+	PublishedAt string  `json:"published_at"`
+	PublishedBy *string `json:"published_by,omitempty"`
+}
+
+// GrantConsentRequest representa o aceite do termo de consentimento biométrico pelo funcionário
+type GrantConsentRequest struct {
+	TextVersion int    `json:"text_version" binding:"required,min=1"`
+	Channel     string `json:"channel" binding:"required,oneof=kiosk mobile_app web paper"`
+	GrantedAt   string `json:"granted_at,omitempty"` // Format: RFC3339; vazio usa o horário do registro
+}
+
+// RevokeConsentRequest representa a revogação do consentimento biométrico
+type RevokeConsentRequest struct {
+	Channel string `json:"channel" binding:"required,oneof=kiosk mobile_app web paper"`
+	Reason  string `json:"reason,omitempty" binding:"max=500"`
+}
+
+// BiometricConsentResponse representa um consentimento biométrico do funcionário
+type BiometricConsentResponse struct {
+	ID                string  `json:"id"`
+	EmployeeID        string  `json:"employee_id"`
+	TextVersion       int     `json:"text_version"`
+	TextDigest        string  `json:"text_digest"`
+	Channel           string  `json:"channel"`
+	Active            bool    `json:"active"`
+	GrantedAt         string  `json:"granted_at"`
+	GrantedBy         *string `json:"granted_by,omitempty"`
+	RevokedAt         *string `json:"revoked_at,omitempty"`
+	RevokedBy         *string `json:"revoked_by,omitempty"`
+	RevocationChannel string  `json:"revocation_channel,omitempty"`
+	RevocationReason  string  `json:"revocation_reason,omitempty"`
+}
+
+// ConsentCountsResponse representa a contagem de funcionários por situação do consentimento
+type ConsentCountsResponse struct {
+	Employees              int     `json:"employees"`
+	Consented              int     `json:"consented"`
+	FaceEnrolled           int     `json:"face_enrolled"`
+	EnrolledWithoutConsent int     `json:"enrolled_without_consent"`
+	Rate                   float64 `json:"rate"`
+}
+
+// PartnerConsentCoverageResponse representa a cobertura do consentimento entre os funcionários de um parceiro
+type PartnerConsentCoverageResponse struct {
+	PartnerID   string `json:"partner_id"`
+	PartnerName string `json:"partner_name"`
+	ConsentCountsResponse
+}
+
+// ConsentCoverageResponse representa a cobertura do consentimento biométrico no evento
+type ConsentCoverageResponse struct {
+	EventID string `json:"event_id"`
+	ConsentCountsResponse
+	Partners []PartnerConsentCoverageResponse `json:"partners"`
+}
+
 // EmployeeListResponse representa a resposta de listagem de funcionários
 type EmployeeListResponse struct {
 	Employees  []EmployeeResponse       `json:"employees"`
@@ -347,7 +436,7 @@ func (h *EmployeeHandler) List(c *gin.Context) {
 	httpResponses.Success(c, response, "Employees retrieved successfully")
 }
 
-// UploadPhoto faz upload da foto de um funcionário
+// UploadPhoto faz upload da foto de um funcionário (multipart/form-data, campo "photo")
 func (h *EmployeeHandler) UploadPhoto(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := value_objects.ParseUUID(idStr)
@@ -357,15 +446,6 @@ func (h *EmployeeHandler) UploadPhoto(c *gin.Context) {
 		return
 	}
 
-	var req UploadPhotoRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn("Invalid upload photo request", zap.Error(err))
-		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
-			"validation_errors": err.Error(),
-		})
-		return
-	}
-
 	// Obter dados do usuário autenticado
 	userClaims, exists := c.Get("claims")
 	if !exists {
@@ -381,14 +461,27 @@ func (h *EmployeeHandler) UploadPhoto(c *gin.Context) {
 		return
 	}
 
-	err = h.employeeService.UpdateEmployeePhoto(c.Request.Context(), id, req.PhotoURL, userID)
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return
+	}
+
+	file, header, ok := openPhotoUpload(c, h.logger)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	emp, err := h.employeeService.UploadEmployeePhoto(c.Request.Context(), tenantID, id, header.Header.Get("Content-Type"), header.Size, file, userID)
 	if err != nil {
 		h.handleServiceError(c, err, "upload employee photo")
 		return
 	}
 
 	h.logger.Info("Employee photo uploaded successfully", zap.String("employee_id", id.String()))
-	httpResponses.Success(c, nil, "Photo uploaded successfully")
+	httpResponses.Success(c, h.convertToEmployeeResponse(emp), "Photo uploaded successfully")
 }
 
 // UpdateFaceEmbedding atualiza o embedding facial de um funcionário
@@ -510,6 +603,343 @@ func (h *EmployeeHandler) RecognizeFace(c *gin.Context) {
 	httpResponses.Success(c, response, "Face recognition completed successfully")
 }
 
+// AddFaceTemplate cadastra um template facial para o funcionário
+func (h *EmployeeHandler) AddFaceTemplate(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := value_objects.ParseUUID(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid employee ID", zap.String("id", idStr))
+		httpResponses.BadRequest(c, "Invalid employee ID format", nil)
+		return
+	}
+
+	var req FaceTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid face template request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	var capturedAt time.Time
+	if req.CapturedAt != "" {
+		capturedAt, err = time.Parse(time.RFC3339, req.CapturedAt)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid captured_at format. Use RFC3339", nil)
+			return
+		}
+	}
+
+	tenantID, userID, ok := h.authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	template, err := h.employeeService.AddFaceTemplate(c.Request.Context(), tenantID, id, employee.FaceTemplateRequest{
+		Embedding:      req.FaceEmbedding,
+		Quality:        req.Quality,
+		ModelVersion:   req.ModelVersion,
+		SourcePhotoKey: req.SourcePhotoKey,
+		CapturedAt:     capturedAt,
+	}, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "add face template")
+		return
+	}
+
+	h.logger.Info("Face template added successfully",
+		zap.String("employee_id", id.String()),
+		zap.String("template_id", template.ID.String()))
+	httpResponses.Created(c, h.convertToFaceTemplateResponse(template), "Face template added successfully")
+}
+
+// ListFaceTemplates lista os templates faciais do funcionário
+func (h *EmployeeHandler) ListFaceTemplates(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := value_objects.ParseUUID(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid employee ID", zap.String("id", idStr))
+		httpResponses.BadRequest(c, "Invalid employee ID format", nil)
+		return
+	}
+
+	tenantID, _, ok := h.authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	templates, err := h.employeeService.ListFaceTemplates(c.Request.Context(), tenantID, id)
+	if err != nil {
+		h.handleServiceError(c, err, "list face templates")
+		return
+	}
+
+	response := make([]FaceTemplateResponse, len(templates))
+	for i, template := range templates {
+		response[i] = h.convertToFaceTemplateResponse(template)
+	}
+
+	httpResponses.Success(c, response, "Face templates retrieved successfully")
+}
+
+// RemoveFaceTemplate remove um template facial do funcionário
+func (h *EmployeeHandler) RemoveFaceTemplate(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := value_objects.ParseUUID(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid employee ID", zap.String("id", idStr))
+		httpResponses.BadRequest(c, "Invalid employee ID format", nil)
+		return
+	}
+
+	templateIDStr := c.Param("templateId")
+	templateID, err := value_objects.ParseUUID(templateIDStr)
+	if err != nil {
+		h.logger.Warn("Invalid face template ID", zap.String("template_id", templateIDStr))
+		httpResponses.BadRequest(c, "Invalid face template ID format", nil)
+		return
+	}
+
+	tenantID, userID, ok := h.authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.employeeService.RemoveFaceTemplate(c.Request.Context(), tenantID, id, templateID, userID); err != nil {
+		h.handleServiceError(c, err, "remove face template")
+		return
+	}
+
+	h.logger.Info("Face template removed successfully",
+		zap.String("employee_id", id.String()),
+		zap.String("template_id", templateID.String()))
+	httpResponses.Success(c, nil, "Face template removed successfully")
+}
+
+// GetConsentText busca a versão vigente do termo de consentimento biométrico do tenant
+func (h *EmployeeHandler) GetConsentText(c *gin.Context) {
+	tenantID, _, ok := h.authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	text, err := h.employeeService.GetConsentText(c.Request.Context(), tenantID)
+	if err != nil {
+		h.handleServiceError(c, err, "get consent text")
+		return
+	}
+
+	httpResponses.Success(c, h.convertToConsentTextResponse(text), "Consent text retrieved successfully")
+}
+
+// PublishConsentText publica uma nova versão do termo; os funcionários precisam aceitá-la para renovar o consentimento
+func (h *EmployeeHandler) PublishConsentText(c *gin.Context) {
+	var req ConsentTextRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid consent text request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	tenantID, userID, ok := h.authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	text, err := h.employeeService.PublishConsentText(c.Request.Context(), tenantID, req.Content, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "publish consent text")
+		return
+	}
+
+	h.logger.Info("Consent text published successfully",
+		zap.String("tenant_id", tenantID.String()),
+		zap.Int("version", text.Version))
+	httpResponses.Created(c, h.convertToConsentTextResponse(text), "Consent text published successfully")
+}
+
+// GrantBiometricConsent registra o consentimento biométrico do funcionário
+func (h *EmployeeHandler) GrantBiometricConsent(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := value_objects.ParseUUID(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid employee ID", zap.String("id", idStr))
+		httpResponses.BadRequest(c, "Invalid employee ID format", nil)
+		return
+	}
+
+	var req GrantConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid biometric consent request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	var grantedAt time.Time
+	if req.GrantedAt != "" {
+		grantedAt, err = time.Parse(time.RFC3339, req.GrantedAt)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid granted_at format. Use RFC3339", nil)
+			return
+		}
+	}
+
+	tenantID, userID, ok := h.authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	consent, err := h.employeeService.GrantBiometricConsent(c.Request.Context(), tenantID, id, employee.ConsentRequest{
+		TextVersion: req.TextVersion,
+		Channel:     req.Channel,
+		GrantedAt:   grantedAt,
+	}, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "grant biometric consent")
+		return
+	}
+
+	h.logger.Info("Biometric consent granted successfully",
+		zap.String("employee_id", id.String()),
+		zap.Int("text_version", consent.TextVersion),
+		zap.String("channel", consent.Channel))
+	httpResponses.Created(c, h.convertToBiometricConsentResponse(consent), "Biometric consent granted successfully")
+}
+
+// RevokeBiometricConsent revoga o consentimento biométrico; os templates e embeddings faciais do funcionário são eliminados
+func (h *EmployeeHandler) RevokeBiometricConsent(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := value_objects.ParseUUID(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid employee ID", zap.String("id", idStr))
+		httpResponses.BadRequest(c, "Invalid employee ID format", nil)
+		return
+	}
+
+	var req RevokeConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid biometric consent revocation request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	tenantID, userID, ok := h.authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	consent, err := h.employeeService.RevokeBiometricConsent(c.Request.Context(), tenantID, id, req.Channel, req.Reason, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "revoke biometric consent")
+		return
+	}
+
+	h.logger.Info("Biometric consent revoked successfully",
+		zap.String("employee_id", id.String()),
+		zap.String("consent_id", consent.ID.String()),
+		zap.String("channel", req.Channel))
+	httpResponses.Success(c, h.convertToBiometricConsentResponse(consent), "Biometric consent revoked successfully")
+}
+
+// ListBiometricConsents lista o histórico de consentimentos biométricos do funcionário
+func (h *EmployeeHandler) ListBiometricConsents(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := value_objects.ParseUUID(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid employee ID", zap.String("id", idStr))
+		httpResponses.BadRequest(c, "Invalid employee ID format", nil)
+		return
+	}
+
+	tenantID, _, ok := h.authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	consents, err := h.employeeService.ListBiometricConsents(c.Request.Context(), tenantID, id)
+	if err != nil {
+		h.handleServiceError(c, err, "list biometric consents")
+		return
+	}
+
+	response := make([]BiometricConsentResponse, len(consents))
+	for i, consent := range consents {
+		response[i] = h.convertToBiometricConsentResponse(consent)
+	}
+
+	httpResponses.Success(c, response, "Biometric consents retrieved successfully")
+}
+
+// GetEventConsentCoverage calcula a cobertura do consentimento biométrico entre os funcionários do evento, por parceiro
+func (h *EmployeeHandler) GetEventConsentCoverage(c *gin.Context) {
+	idStr := c.Param("id")
+	eventID, err := value_objects.ParseUUID(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid event ID", zap.String("id", idStr))
+		httpResponses.BadRequest(c, "Invalid event ID format", nil)
+		return
+	}
+
+	tenantID, _, ok := h.authenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	coverage, err := h.employeeService.GetEventConsentCoverage(c.Request.Context(), tenantID, eventID)
+	if err != nil {
+		h.handleServiceError(c, err, "get event consent coverage")
+		return
+	}
+
+	response := ConsentCoverageResponse{
+		EventID:               coverage.EventID.String(),
+		ConsentCountsResponse: convertToConsentCountsResponse(coverage.ConsentCounts),
+		Partners:              make([]PartnerConsentCoverageResponse, len(coverage.Partners)),
+	}
+	for i, partner := range coverage.Partners {
+		response.Partners[i] = PartnerConsentCoverageResponse{
+			PartnerID:             partner.PartnerID.String(),
+			PartnerName:           partner.PartnerName,
+			ConsentCountsResponse: convertToConsentCountsResponse(partner.ConsentCounts),
+		}
+	}
+
+	httpResponses.Success(c, response, "Consent coverage retrieved successfully")
+}
+
+// authenticatedUser obtém o tenant e o usuário autenticados; responde com erro quando ausentes ou inválidos
+func (h *EmployeeHandler) authenticatedUser(c *gin.Context) (value_objects.UUID, value_objects.UUID, bool) {
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	claims := userClaims.(*jwtService.Claims)
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	userID, err := value_objects.ParseUUID(claims.UserID)
+	if err != nil {
+		h.logger.Error("Invalid user ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	return tenantID, userID, true
+}
+
 // buildListFilters constrói os filtros de listagem a partir dos query parameters
 func (h *EmployeeHandler) buildListFilters(c *gin.Context) employee.ListFilters {
 	filters := employee.ListFilters{
@@ -597,7 +1027,8 @@ func (h *EmployeeHandler) convertToEmployeeResponse(emp *employee.Employee) Empl
 		Identity:         emp.Identity,
 		IdentityType:     emp.IdentityType,
 		PhotoURL:         emp.PhotoURL,
-		HasFaceEmbedding: len(emp.FaceEmbedding) > 0,
+		HasFaceEmbedding: emp.FaceEnrolled || len(emp.FaceEmbedding) > 0,
+		HasConsent:       emp.HasBiometricConsent(),
 		Phone:            emp.Phone,
 		Email:            emp.Email,
 		Active:           emp.Active,
@@ -610,6 +1041,16 @@ func (h *EmployeeHandler) convertToEmployeeResponse(emp *employee.Employee) Empl
 		response.DateOfBirth = &dateOfBirth
 	}
 
+	// Fotos armazenadas são entregues por URL assinada de curta duração
+	if emp.PhotoKey != "" {
+		url, expiresAt := signPhotoURL(h.photos, emp.TenantID, emp.PhotoKey, h.logger)
+		response.PhotoURL = url
+		if expiresAt != nil {
+			expires := expiresAt.Format(time.RFC3339)
+			response.PhotoURLExpires = &expires
+		}
+	}
+
 	if emp.CreatedBy != nil {
 		createdBy := emp.CreatedBy.String()
 		response.CreatedBy = &createdBy
@@ -623,6 +1064,92 @@ func (h *EmployeeHandler) convertToEmployeeResponse(emp *employee.Employee) Empl
 	return response
 }
 
+// convertToFaceTemplateResponse converte FaceTemplate para FaceTemplateResponse
+func (h *EmployeeHandler) convertToFaceTemplateResponse(template *employee.FaceTemplate) FaceTemplateResponse {
+	response := FaceTemplateResponse{
+		ID:           template.ID.String(),
+		EmployeeID:   template.EmployeeID.String(),
+		Quality:      template.Quality,
+		ModelVersion: template.ModelVersion,
+		CapturedAt:   template.CapturedAt.Format(time.RFC3339),
+		CreatedAt:    template.CreatedAt.Format(time.RFC3339),
+	}
+
+	url, expiresAt := signPhotoURL(h.photos, template.TenantID, template.SourcePhotoKey, h.logger)
+	response.SourcePhotoURL = url
+	if expiresAt != nil {
+		expires := expiresAt.Format(time.RFC3339)
+		response.SourcePhotoURLExpires = &expires
+	}
+
+	if template.CreatedBy != nil {
+		createdBy := template.CreatedBy.String()
+		response.CreatedBy = &createdBy
+	}
+
+	return response
+}
+
+// convertToConsentTextResponse converte ConsentText para ConsentTextResponse
+func (h *EmployeeHandler) convertToConsentTextResponse(text *employee.ConsentText) ConsentTextResponse {
+	response := ConsentTextResponse{
+		Version:     text.Version,
+		Content:     text.Content,
+		Digest:      text.Digest,
+		PublishedAt: text.PublishedAt.Format(time.RFC3339),
+	}
+
+	if text.PublishedBy != nil {
+		publishedBy := text.PublishedBy.String()
+		response.PublishedBy = &publishedBy
+	}
+
+	return response
+}
+
+// convertToBiometricConsentResponse converte BiometricConsent para BiometricConsentResponse
+func (h *EmployeeHandler) convertToBiometricConsentResponse(consent *employee.BiometricConsent) BiometricConsentResponse {
+	response := BiometricConsentResponse{
+		ID:                consent.ID.String(),
+		EmployeeID:        consent.EmployeeID.String(),
+		TextVersion:       consent.TextVersion,
+		TextDigest:        consent.TextDigest,
+		Channel:           consent.Channel,
+		Active:            consent.IsActive(),
+		GrantedAt:         consent.GrantedAt.Format(time.RFC3339),
+		RevocationChannel: consent.RevocationChannel,
+		RevocationReason:  consent.RevocationReason,
+	}
+
+	if consent.GrantedBy != nil {
+		grantedBy := consent.GrantedBy.String()
+		response.GrantedBy = &grantedBy
+	}
+
+	if consent.RevokedAt != nil {
+		revokedAt := consent.RevokedAt.Format(time.RFC3339)
+		response.RevokedAt = &revokedAt
+	}
+
+	if consent.RevokedBy != nil {
+		revokedBy := consent.RevokedBy.String()
+		response.RevokedBy = &revokedBy
+	}
+
+	return response
+}
+
+// convertToConsentCountsResponse converte ConsentCounts para ConsentCountsResponse
+func convertToConsentCountsResponse(counts employee.ConsentCounts) ConsentCountsResponse {
+	return ConsentCountsResponse{
+		Employees:              counts.Employees,
+		Consented:              counts.Consented,
+		FaceEnrolled:           counts.FaceEnrolled,
+		EnrolledWithoutConsent: counts.EnrolledWithoutConsent,
+		Rate:                   counts.Rate(),
+	}
+}
+
 // getConfidenceLevel determina o nível de confiança baseado na similaridade
 func (h *EmployeeHandler) getConfidenceLevel(similarity float64) string {
 	if similarity >= 0.95 {
@@ -644,7 +1171,7 @@ func (h *EmployeeHandler) handleServiceError(c *gin.Context, err error, operatio
 		case "NOT_FOUND":
 			h.logger.Warn("Resource not found in "+operation, zap.Error(err))
 			httpResponses.NotFound(c, e.Message)
-		case "CONFLICT":
+		case "CONFLICT", "ALREADY_EXISTS":
 			h.logger.Warn("Conflict error in "+operation, zap.Error(err))
 			httpResponses.Conflict(c, e.Message, e.Context)
 		default:
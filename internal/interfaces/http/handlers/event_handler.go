@@ -4,6 +4,8 @@ import (
 	"strconv"
 	"time"
 
+	"eventos-backend/internal/domain/checkin"
+	"eventos-backend/internal/domain/checkout"
 	"eventos-backend/internal/domain/event"
 	"eventos-backend/internal/domain/shared/errors"
 	"eventos-backend/internal/domain/shared/value_objects"
@@ -16,34 +18,44 @@ import (
 
 // EventHandler gerencia as operações de evento
 type EventHandler struct {
-	eventService event.Service
-	logger       *zap.Logger
+	eventService    event.Service
+	checkinService  checkin.Service
+	checkoutService checkout.Service
+	logger          *zap.Logger
 }
 
 // NewEventHandler cria uma nova instância do handler de evento
-func NewEventHandler(eventService event.Service, logger *zap.Logger) *EventHandler {
+func NewEventHandler(eventService event.Service, checkinService checkin.Service, checkoutService checkout.Service, logger *zap.Logger) *EventHandler {
 	return &EventHandler{
-		eventService: eventService,
-		logger:       logger,
+		eventService:    eventService,
+		checkinService:  checkinService,
+		checkoutService: checkoutService,
+		logger:          logger,
 	}
 }
 
 // CreateEventRequest representa uma requisição de criação de evento
 type CreateEventRequest struct {
-	Name        string            `json:"name" binding:"required"`
-	Location    string            `json:"location" binding:"required"`
-	FenceEvent  []LocationRequest `json:"fence_event" binding:"required,min=3"`
-	InitialDate string            `json:"initial_date" binding:"required"`
-	FinalDate   string            `json:"final_date" binding:"required"`
+	Name                 string            `json:"name" binding:"required"`
+	Location             string            `json:"location" binding:"required"`
+	FenceEvent           []LocationRequest `json:"fence_event" binding:"required,min=3"`
+	FenceToleranceMeters float64           `json:"fence_tolerance_meters" binding:"min=0,max=1000"`
+	MaxSessionHours      float64           `json:"max_session_hours" binding:"min=0,max=72"`
+	MaxOccupancy         int               `json:"max_occupancy" binding:"min=0,max=1000000"`
+	InitialDate          string            `json:"initial_date" binding:"required"`
+	FinalDate            string            `json:"final_date" binding:"required"`
 }
 
 // UpdateEventRequest representa uma requisição de atualização de evento
 type UpdateEventRequest struct {
-	Name        string            `json:"name" binding:"required"`
-	Location    string            `json:"location" binding:"required"`
-	FenceEvent  []LocationRequest `json:"fence_event" binding:"required,min=3"`
-	InitialDate string            `json:"initial_date" binding:"required"`
-	FinalDate   string            `json:"final_date" binding:"required"`
+	Name                 string            `json:"name" binding:"required"`
+	Location             string            `json:"location" binding:"required"`
+	FenceEvent           []LocationRequest `json:"fence_event" binding:"required,min=3"`
+	FenceToleranceMeters float64           `json:"fence_tolerance_meters" binding:"min=0,max=1000"`
+	MaxSessionHours      float64           `json:"max_session_hours" binding:"min=0,max=72"`
+	MaxOccupancy         int               `json:"max_occupancy" binding:"min=0,max=1000000"`
+	InitialDate          string            `json:"initial_date" binding:"required"`
+	FinalDate            string            `json:"final_date" binding:"required"`
 }
 
 // LocationRequest representa uma coordenada geográfica
@@ -54,19 +66,22 @@ type LocationRequest struct {
 
 // EventResponse representa a resposta de um evento
 type EventResponse struct {
-	ID          string             `json:"id"`
-	TenantID    string             `json:"tenant_id"`
-	Name        string             `json:"name"`
-	Location    string             `json:"location"`
-	FenceEvent  []LocationResponse `json:"fence_event"`
-	InitialDate string             `json:"initial_date"`
-	FinalDate   string             `json:"final_date"`
-	Status      string             `json:"status"`
-	Active      bool               `json:"active"`
-	CreatedAt   string             `json:"created_at"`
-	UpdatedAt   string             `json:"updated_at"`
-	CreatedBy   *string            `json:"created_by,omitempty"`
-	UpdatedBy   *string            `json:"updated_by,omitempty"`
+	ID                   string             `json:"id"`
+	TenantID             string             `json:"tenant_id"`
+	Name                 string             `json:"name"`
+	Location             string             `json:"location"`
+	FenceEvent           []LocationResponse `json:"fence_event"`
+	FenceToleranceMeters float64            `json:"fence_tolerance_meters"`
+	MaxSessionHours      float64            `json:"max_session_hours"`
+	MaxOccupancy         int                `json:"max_occupancy"`
+	InitialDate          string             `json:"initial_date"`
+	FinalDate            string             `json:"final_date"`
+	Status               string             `json:"status"`
+	Active               bool               `json:"active"`
+	CreatedAt            string             `json:"created_at"`
+	UpdatedAt            string             `json:"updated_at"`
+	CreatedBy            *string            `json:"created_by,omitempty"`
+	UpdatedBy            *string            `json:"updated_by,omitempty"`
 }
 
 // LocationResponse representa uma coordenada geográfica na resposta
@@ -83,11 +98,33 @@ type EventListResponse struct {
 
 // EventStatsResponse representa estatísticas de um evento
 type EventStatsResponse struct {
-	EventID        string `json:"event_id"`
-	TotalCheckins  int    `json:"total_checkins"`
-	TotalCheckouts int    `json:"total_checkouts"`
-	ActiveSessions int    `json:"active_sessions"`
-	TotalEmployees int    `json:"total_employees"`
+	EventID          string                 `json:"event_id"`
+	TotalCheckins    int                    `json:"total_checkins"`
+	TotalCheckouts   int                    `json:"total_checkouts"`
+	ActiveSessions   int                    `json:"active_sessions"` // funcionários presentes no evento
+	TotalEmployees   int                    `json:"total_employees"`
+	ValidCheckins    int                    `json:"valid_checkins"`
+	InvalidCheckins  int                    `json:"invalid_checkins"`
+	PendingCheckins  int                    `json:"pending_checkins"`
+	ValidCheckouts   int                    `json:"valid_checkouts"`
+	InvalidCheckouts int                    `json:"invalid_checkouts"`
+	TotalWorkHours   float64                `json:"total_work_hours"`
+	AverageWorkHours float64                `json:"average_work_hours"`
+	FirstCheckinAt   *string                `json:"first_checkin_at,omitempty"`
+	LastCheckinAt    *string                `json:"last_checkin_at,omitempty"`
+	Partners         []PartnerStatsResponse `json:"partners"`
+}
+
+// PartnerStatsResponse representa a presença de um parceiro no evento
+type PartnerStatsResponse struct {
+	PartnerID       string  `json:"partner_id"`
+	TotalCheckins   int     `json:"total_checkins"`
+	ValidCheckins   int     `json:"valid_checkins"`
+	InvalidCheckins int     `json:"invalid_checkins"`
+	PendingCheckins int     `json:"pending_checkins"`
+	TotalEmployees  int     `json:"total_employees"`
+	ActiveSessions  int     `json:"active_sessions"`
+	TotalWorkHours  float64 `json:"total_work_hours"`
 }
 
 // Create cria um novo evento
@@ -148,7 +185,7 @@ func (h *EventHandler) Create(c *gin.Context) {
 	}
 
 	// Criar evento
-	evt, err := h.eventService.CreateEvent(c.Request.Context(), tenantID, req.Name, req.Location, fenceEvent, initialDate, finalDate, userID)
+	evt, err := h.eventService.CreateEvent(c.Request.Context(), tenantID, req.Name, req.Location, fenceEvent, req.FenceToleranceMeters, req.MaxSessionHours, req.MaxOccupancy, initialDate, finalDate, userID)
 	if err != nil {
 		h.handleServiceError(c, err, "create event")
 		return
@@ -251,7 +288,7 @@ func (h *EventHandler) Update(c *gin.Context) {
 		return
 	}
 
-	evt, err := h.eventService.UpdateEvent(c.Request.Context(), id, req.Name, req.Location, fenceEvent, initialDate, finalDate, userID)
+	evt, err := h.eventService.UpdateEvent(c.Request.Context(), id, req.Name, req.Location, fenceEvent, req.FenceToleranceMeters, req.MaxSessionHours, req.MaxOccupancy, initialDate, finalDate, userID)
 	if err != nil {
 		h.handleServiceError(c, err, "update event")
 		return
@@ -375,16 +412,77 @@ func (h *EventHandler) GetStats(c *gin.Context) {
 		return
 	}
 
-	// TODO: Implementar estatísticas reais quando os serviços de check-in estiverem prontos
-	stats := EventStatsResponse{
-		EventID:        id.String(),
-		TotalCheckins:  0,
-		TotalCheckouts: 0,
-		ActiveSessions: 0,
-		TotalEmployees: 0,
+	ctx := c.Request.Context()
+
+	checkinStats, err := h.checkinService.GetEventCheckinStats(ctx, tenantID, id)
+	if err != nil {
+		h.handleServiceError(c, err, "get event checkin stats")
+		return
+	}
+
+	partnerStats, err := h.checkinService.GetEventPartnerStats(ctx, tenantID, id)
+	if err != nil {
+		h.handleServiceError(c, err, "get event partner stats")
+		return
 	}
 
-	httpResponses.Success(c, stats, "Event statistics retrieved successfully")
+	checkoutStats, err := h.checkoutService.GetEventCheckoutStats(ctx, tenantID, id)
+	if err != nil {
+		h.handleServiceError(c, err, "get event checkout stats")
+		return
+	}
+
+	workStats, err := h.checkoutService.GetEventWorkStats(ctx, tenantID, id)
+	if err != nil {
+		h.handleServiceError(c, err, "get event work stats")
+		return
+	}
+
+	httpResponses.Success(c, h.toStatsResponse(id, checkinStats, partnerStats, checkoutStats, workStats), "Event statistics retrieved successfully")
+}
+
+// toStatsResponse combina as estatísticas de check-in, check-out e trabalho do evento
+func (h *EventHandler) toStatsResponse(eventID value_objects.UUID, checkinStats *checkin.CheckinStats, partnerStats []*checkin.PartnerCheckinStats, checkoutStats *checkout.CheckoutStats, workStats *checkout.WorkStats) EventStatsResponse {
+	response := EventStatsResponse{
+		EventID:          eventID.String(),
+		TotalCheckins:    checkinStats.TotalCheckins,
+		TotalCheckouts:   checkoutStats.TotalCheckouts,
+		ActiveSessions:   checkinStats.OpenSessions,
+		TotalEmployees:   checkinStats.UniqueEmployees,
+		ValidCheckins:    checkinStats.ValidCheckins,
+		InvalidCheckins:  checkinStats.InvalidCheckins,
+		PendingCheckins:  checkinStats.PendingCheckins,
+		ValidCheckouts:   checkoutStats.ValidCheckouts,
+		InvalidCheckouts: checkoutStats.InvalidCheckouts,
+		TotalWorkHours:   workStats.TotalWorkHours,
+		AverageWorkHours: workStats.AverageWorkHours,
+		Partners:         make([]PartnerStatsResponse, 0, len(partnerStats)),
+	}
+
+	if checkinStats.FirstCheckinTime != nil {
+		firstCheckin := checkinStats.FirstCheckinTime.Format(time.RFC3339)
+		response.FirstCheckinAt = &firstCheckin
+	}
+
+	if checkinStats.LastCheckinTime != nil {
+		lastCheckin := checkinStats.LastCheckinTime.Format(time.RFC3339)
+		response.LastCheckinAt = &lastCheckin
+	}
+
+	for _, partner := range partnerStats {
+		response.Partners = append(response.Partners, PartnerStatsResponse{
+			PartnerID:       partner.PartnerID.String(),
+			TotalCheckins:   partner.TotalCheckins,
+			ValidCheckins:   partner.ValidCheckins,
+			InvalidCheckins: partner.InvalidCheckins,
+			PendingCheckins: partner.PendingCheckins,
+			TotalEmployees:  partner.UniqueEmployees,
+			ActiveSessions:  partner.OpenSessions,
+			TotalWorkHours:  partner.TotalWorkHours,
+		})
+	}
+
+	return response
 }
 
 // buildListFilters constrói os filtros de listagem a partir dos query parameters
@@ -479,16 +577,19 @@ func (h *EventHandler) convertLocationRequests(locations []LocationRequest) ([]v
 // convertToEventResponse converte Event para EventResponse
 func (h *EventHandler) convertToEventResponse(evt *event.Event) EventResponse {
 	response := EventResponse{
-		ID:          evt.ID.String(),
-		TenantID:    evt.TenantID.String(),
-		Name:        evt.Name,
-		Location:    evt.Location,
-		InitialDate: evt.InitialDate.Format(time.RFC3339),
-		FinalDate:   evt.FinalDate.Format(time.RFC3339),
-		Status:      h.getEventStatus(evt),
-		Active:      evt.Active,
-		CreatedAt:   evt.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   evt.UpdatedAt.Format(time.RFC3339),
+		ID:                   evt.ID.String(),
+		TenantID:             evt.TenantID.String(),
+		Name:                 evt.Name,
+		Location:             evt.Location,
+		FenceToleranceMeters: evt.FenceToleranceMeters,
+		MaxSessionHours:      evt.MaxSessionHours,
+		MaxOccupancy:         evt.MaxOccupancy,
+		InitialDate:          evt.InitialDate.Format(time.RFC3339),
+		FinalDate:            evt.FinalDate.Format(time.RFC3339),
+		Status:               h.getEventStatus(evt),
+		Active:               evt.Active,
+		CreatedAt:            evt.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:            evt.UpdatedAt.Format(time.RFC3339),
 	}
 
 	// Converter fence event
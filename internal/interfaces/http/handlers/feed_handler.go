@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"eventos-backend/internal/domain/event"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
+	"eventos-backend/internal/infrastructure/realtime"
+	httpResponses "eventos-backend/internal/interfaces/http/responses"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// FeedHandler transmite as notificações de check-in e check-out em tempo real (Server-Sent Events)
+type FeedHandler struct {
+	hub          *realtime.Hub
+	eventService event.Service
+	logger       *zap.Logger
+}
+
+// NewFeedHandler cria uma nova instância do handler do feed
+func NewFeedHandler(hub *realtime.Hub, eventService event.Service, logger *zap.Logger) *FeedHandler {
+	return &FeedHandler{
+		hub:          hub,
+		eventService: eventService,
+		logger:       logger,
+	}
+}
+
+// StreamTenant transmite as notificações de todos os eventos do tenant
+func (h *FeedHandler) StreamTenant(c *gin.Context) {
+	h.stream(c, "")
+}
+
+// StreamEvent transmite as notificações de um evento do tenant
+func (h *FeedHandler) StreamEvent(c *gin.Context) {
+	idStr := c.Param("id")
+	if _, err := value_objects.ParseUUID(idStr); err != nil {
+		h.logger.Warn("Invalid event ID", zap.String("id", idStr))
+		httpResponses.BadRequest(c, "Invalid event ID format", nil)
+		return
+	}
+
+	h.stream(c, idStr)
+}
+
+// stream abre a conexão SSE e repassa as notificações até o cliente desconectar ou o token expirar
+func (h *FeedHandler) stream(c *gin.Context, eventIDStr string) {
+	if h.hub == nil {
+		httpResponses.ServiceUnavailable(c, "Real-time feed is not available")
+		return
+	}
+
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return
+	}
+
+	types, ok := h.parseTypes(c)
+	if !ok {
+		return
+	}
+
+	// Verificar se o evento existe e pertence ao tenant
+	if eventIDStr != "" {
+		eventID, _ := value_objects.ParseUUID(eventIDStr)
+		if _, err := h.eventService.GetEventByTenant(c.Request.Context(), eventID, tenantID); err != nil {
+			h.handleServiceError(c, err, "open event feed")
+			return
+		}
+	}
+
+	// Conexão de longa duração: remover o limite de escrita do servidor
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Debug("Failed to clear write deadline for feed", zap.Error(err))
+	}
+
+	sub := h.hub.Subscribe(tenantID.String(), eventIDStr, types)
+	defer h.hub.Unsubscribe(sub)
+
+	heartbeat := time.NewTicker(constants.FeedHeartbeatInterval * time.Second)
+	defer heartbeat.Stop()
+
+	// A conexão não sobrevive ao token que a autenticou
+	var expired <-chan time.Time
+	if claims.ExpiresAt != nil {
+		timer := time.NewTimer(time.Until(claims.ExpiresAt.Time))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	h.logger.Info("Feed subscriber connected",
+		zap.String("tenant_id", claims.TenantID),
+		zap.String("event_id", eventIDStr),
+		zap.String("user_id", claims.UserID),
+	)
+
+	// Intervalo de reconexão sugerido ao EventSource
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", constants.FeedReconnectDelay)
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			h.logDisconnect(claims, eventIDStr, sub)
+			return
+		case <-expired:
+			h.writeEvent(c, "", "session.expired", gin.H{"message": "Token expired, reconnect with a new token"})
+			h.logDisconnect(claims, eventIDStr, sub)
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		case notification, ok := <-sub.Notifications():
+			if !ok {
+				return
+			}
+			h.writeEvent(c, notification.ID, notification.Type, notification)
+		}
+	}
+}
+
+// parseTypes lê o filtro opcional de tipos de notificação (types=checkin.performed,checkout.performed)
+func (h *FeedHandler) parseTypes(c *gin.Context) ([]string, bool) {
+	typesStr := c.Query("types")
+	if typesStr == "" {
+		return nil, true
+	}
+
+	types := strings.Split(typesStr, ",")
+	for i, t := range types {
+		types[i] = strings.TrimSpace(t)
+		if !realtime.IsFeedMessageType(types[i]) {
+			httpResponses.BadRequest(c, "Invalid notification type", map[string]interface{}{
+				"type":    types[i],
+				"allowed": realtime.MessageTypes,
+			})
+			return nil, false
+		}
+	}
+
+	return types, true
+}
+
+// writeEvent escreve um evento SSE e envia imediatamente ao cliente
+func (h *FeedHandler) writeEvent(c *gin.Context, id, eventType string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		h.logger.Error("Failed to marshal feed notification", zap.Error(err), zap.String("type", eventType))
+		return
+	}
+
+	if id != "" {
+		fmt.Fprintf(c.Writer, "id: %s\n", id)
+	}
+	fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", eventType, payload)
+	c.Writer.Flush()
+}
+
+// logDisconnect registra o encerramento da conexão
+func (h *FeedHandler) logDisconnect(claims *jwtService.Claims, eventID string, sub *realtime.Subscription) {
+	h.logger.Info("Feed subscriber disconnected",
+		zap.String("tenant_id", claims.TenantID),
+		zap.String("event_id", eventID),
+		zap.String("user_id", claims.UserID),
+		zap.Int64("dropped", sub.Dropped()),
+	)
+}
+
+// handleServiceError trata erros do serviço de eventos
+func (h *FeedHandler) handleServiceError(c *gin.Context, err error, operation string) {
+	h.logger.Error("Feed service error", zap.Error(err), zap.String("operation", operation))
+
+	if domainErr, ok := err.(*errors.DomainError); ok {
+		switch domainErr.Type {
+		case "ValidationError", "VALIDATION_ERROR":
+			httpResponses.BadRequest(c, domainErr.Message, domainErr.Context)
+		case "NotFoundError", "NOT_FOUND":
+			httpResponses.NotFound(c, domainErr.Message)
+		case "ForbiddenError", "FORBIDDEN":
+			httpResponses.Forbidden(c, domainErr.Message)
+		default:
+			httpResponses.InternalServerError(c, "Failed to "+operation)
+		}
+	} else {
+		httpResponses.InternalServerError(c, "Failed to "+operation)
+	}
+}
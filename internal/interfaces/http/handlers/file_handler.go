@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/domain/storage"
+	httpResponses "eventos-backend/internal/interfaces/http/responses"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SignedFileStore define o armazenamento que serve os downloads assinados pela própria API
+type SignedFileStore interface {
+	VerifySignature(key, expires, signature string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, *storage.Object, error)
+}
+
+// FileHandler serve os arquivos do armazenamento, já decifrados, por meio de URLs assinadas
+type FileHandler struct {
+	store  SignedFileStore
+	logger *zap.Logger
+}
+
+// NewFileHandler cria uma nova instância do handler de arquivos
+func NewFileHandler(store SignedFileStore, logger *zap.Logger) *FileHandler {
+	return &FileHandler{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// Download envia o arquivo quando a assinatura e a validade da URL conferem.
+// A rota é pública: a URL assinada é a própria autorização.
+func (h *FileHandler) Download(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	if err := h.store.VerifySignature(key, c.Query("expires"), c.Query("signature")); err != nil {
+		h.logger.Warn("Rejected file download", zap.String("key", key), zap.Error(err))
+		httpResponses.Forbidden(c, "Invalid or expired download URL")
+		return
+	}
+
+	content, object, err := h.store.Get(c.Request.Context(), key)
+	if err != nil {
+		if stderrors.Is(err, errors.ErrNotFound) {
+			httpResponses.NotFound(c, "File not found")
+			return
+		}
+		h.logger.Error("Failed to open file", zap.String("key", key), zap.Error(err))
+		httpResponses.InternalServerError(c, "Failed to download file")
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, object.Size, object.ContentType, content, map[string]string{
+		"Cache-Control":          "private, no-store",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// openPhotoUpload abre a foto enviada no formulário multipart; responde 400 quando o campo está ausente
+func openPhotoUpload(c *gin.Context, logger *zap.Logger) (multipart.File, *multipart.FileHeader, bool) {
+	header, err := c.FormFile(constants.StorageMultipartField)
+	if err != nil {
+		logger.Warn("Missing photo in multipart form", zap.Error(err))
+		httpResponses.BadRequest(c, fmt.Sprintf("Multipart field %q with the photo is required", constants.StorageMultipartField), nil)
+		return nil, nil, false
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		logger.Error("Failed to open uploaded photo", zap.Error(err))
+		httpResponses.BadRequest(c, "Failed to read uploaded photo", nil)
+		return nil, nil, false
+	}
+
+	return file, header, true
+}
+
+// signPhotoURL gera a URL de download de uma foto armazenada; vazia quando não há foto ou a assinatura falha
+func signPhotoURL(photos storage.Service, tenantID value_objects.UUID, key string, logger *zap.Logger) (string, *time.Time) {
+	if photos == nil || key == "" {
+		return "", nil
+	}
+
+	signed, err := photos.SignedURL(tenantID, key)
+	if err != nil {
+		logger.Warn("Failed to sign photo URL", zap.String("photo_key", key), zap.Error(err))
+		return "", nil
+	}
+
+	return signed.URL, &signed.ExpiresAt
+}
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"eventos-backend/internal/domain/fraud"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
+	httpResponses "eventos-backend/internal/interfaces/http/responses"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// FraudHandler gerencia a revisão dos check-ins sinalizados pela análise de fraude
+type FraudHandler struct {
+	fraudService fraud.Service
+	logger       *zap.Logger
+}
+
+// NewFraudHandler cria uma nova instância do handler de fraude
+func NewFraudHandler(fraudService fraud.Service, logger *zap.Logger) *FraudHandler {
+	return &FraudHandler{
+		fraudService: fraudService,
+		logger:       logger,
+	}
+}
+
+// ReviewFraudRequest representa a decisão do revisor sobre um check-in sinalizado
+type ReviewFraudRequest struct {
+	Status  string `json:"status" binding:"required,oneof=confirmed dismissed"`
+	Comment string `json:"comment" binding:"max=500"`
+}
+
+// FraudFlagResponse representa uma sinalização de fraude
+type FraudFlagResponse struct {
+	Type       string                 `json:"type"`
+	Message    string                 `json:"message"`
+	Evidence   map[string]interface{} `json:"evidence,omitempty"`
+	DetectedAt time.Time              `json:"detected_at"`
+}
+
+// FraudReviewResponse representa a decisão do revisor
+type FraudReviewResponse struct {
+	Status     string    `json:"status"`
+	Comment    string    `json:"comment,omitempty"`
+	ReviewedBy string    `json:"reviewed_by"`
+	ReviewedAt time.Time `json:"reviewed_at"`
+}
+
+// FlaggedCheckinResponse representa um check-in sinalizado
+type FlaggedCheckinResponse struct {
+	CheckinID    string               `json:"checkin_id"`
+	EventID      string               `json:"event_id"`
+	EmployeeID   string               `json:"employee_id"`
+	EmployeeName string               `json:"employee_name,omitempty"`
+	DeviceID     string               `json:"device_id,omitempty"`
+	Method       string               `json:"method"`
+	CheckinTime  time.Time            `json:"checkin_time"`
+	ReviewStatus string               `json:"review_status"`
+	Flags        []FraudFlagResponse  `json:"flags"`
+	Review       *FraudReviewResponse `json:"review,omitempty"`
+}
+
+// FlaggedCheckinListResponse representa a resposta de listagem de check-ins sinalizados
+type FlaggedCheckinListResponse struct {
+	Checkins   []FlaggedCheckinResponse `json:"checkins"`
+	Pagination httpResponses.Pagination `json:"pagination"`
+}
+
+// List lista os check-ins sinalizados do tenant.
+// Filtros: event_id, employee_id, device_id, flag_type, status (pending, confirmed, dismissed), from e to em RFC3339.
+func (h *FraudHandler) List(c *gin.Context) {
+	tenantID, _, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	filters := fraud.ListFilters{}
+	filters.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filters.PageSize, _ = strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	if eventIDStr := c.Query("event_id"); eventIDStr != "" {
+		eventID, err := value_objects.ParseUUID(eventIDStr)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid event ID format", nil)
+			return
+		}
+		filters.EventID = &eventID
+	}
+
+	if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+		employeeID, err := value_objects.ParseUUID(employeeIDStr)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid employee ID format", nil)
+			return
+		}
+		filters.EmployeeID = &employeeID
+	}
+
+	if deviceID := c.Query("device_id"); deviceID != "" {
+		filters.DeviceID = &deviceID
+	}
+
+	if flagType := c.Query("flag_type"); flagType != "" {
+		filters.FlagType = &flagType
+	}
+
+	if status := c.Query("status"); status != "" {
+		filters.Status = &status
+	}
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid from format", map[string]interface{}{"expected": "RFC3339"})
+			return
+		}
+		filters.From = &from
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid to format", map[string]interface{}{"expected": "RFC3339"})
+			return
+		}
+		filters.To = &to
+	}
+
+	flagged, total, err := h.fraudService.ListFlagged(c.Request.Context(), tenantID, filters)
+	if err != nil {
+		h.handleServiceError(c, err, "list flagged checkins")
+		return
+	}
+
+	response := FlaggedCheckinListResponse{
+		Checkins:   make([]FlaggedCheckinResponse, len(flagged)),
+		Pagination: httpResponses.CalculatePagination(filters.Page, filters.PageSize, total),
+	}
+	for i, entry := range flagged {
+		response.Checkins[i] = h.toFlaggedCheckinResponse(entry)
+	}
+
+	httpResponses.Success(c, response, "Check-ins sinalizados recuperados com sucesso")
+}
+
+// GetByID busca um check-in sinalizado com as evidências de cada sinalização
+func (h *FraudHandler) GetByID(c *gin.Context) {
+	checkinID, ok := h.parseCheckinID(c)
+	if !ok {
+		return
+	}
+
+	tenantID, _, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	flagged, err := h.fraudService.GetFlagged(c.Request.Context(), tenantID, checkinID)
+	if err != nil {
+		h.handleServiceError(c, err, "get flagged checkin")
+		return
+	}
+
+	httpResponses.Success(c, h.toFlaggedCheckinResponse(flagged), "Check-in sinalizado recuperado com sucesso")
+}
+
+// Review registra a decisão do revisor sobre um check-in sinalizado
+func (h *FraudHandler) Review(c *gin.Context) {
+	checkinID, ok := h.parseCheckinID(c)
+	if !ok {
+		return
+	}
+
+	var req ReviewFraudRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid fraud review request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	tenantID, userID, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	flagged, err := h.fraudService.ReviewCheckin(c.Request.Context(), tenantID, checkinID, req.Status, req.Comment, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "review flagged checkin")
+		return
+	}
+
+	h.logger.Info("Flagged checkin reviewed",
+		zap.String("checkin_id", checkinID.String()),
+		zap.String("status", req.Status),
+		zap.String("reviewed_by", userID.String()),
+	)
+
+	httpResponses.Success(c, h.toFlaggedCheckinResponse(flagged), "Revisão registrada com sucesso")
+}
+
+// parseCheckinID lê o ID do check-in da rota
+func (h *FraudHandler) parseCheckinID(c *gin.Context) (value_objects.UUID, bool) {
+	idStr := c.Param("checkinId")
+	checkinID, err := value_objects.ParseUUID(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid checkin ID", zap.String("id", idStr))
+		httpResponses.BadRequest(c, "Invalid checkin ID format", nil)
+		return value_objects.UUID{}, false
+	}
+
+	return checkinID, true
+}
+
+// getAuthContext resolve o tenant e o usuário autenticado
+func (h *FraudHandler) getAuthContext(c *gin.Context) (value_objects.UUID, value_objects.UUID, bool) {
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	userID, err := value_objects.ParseUUID(claims.UserID)
+	if err != nil {
+		h.logger.Error("Invalid user ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	return tenantID, userID, true
+}
+
+// toFlaggedCheckinResponse converte o check-in sinalizado para a resposta
+func (h *FraudHandler) toFlaggedCheckinResponse(f *fraud.FlaggedCheckin) FlaggedCheckinResponse {
+	response := FlaggedCheckinResponse{
+		CheckinID:    f.CheckinID.String(),
+		EventID:      f.EventID.String(),
+		EmployeeID:   f.EmployeeID.String(),
+		EmployeeName: f.EmployeeName,
+		DeviceID:     f.DeviceID,
+		Method:       f.Method,
+		CheckinTime:  f.CheckinTime,
+		ReviewStatus: f.ReviewStatus(),
+		Flags:        make([]FraudFlagResponse, len(f.Flags)),
+	}
+
+	for i, flag := range f.Flags {
+		response.Flags[i] = FraudFlagResponse{
+			Type:       flag.Type,
+			Message:    flag.Message,
+			Evidence:   flag.Evidence,
+			DetectedAt: flag.DetectedAt,
+		}
+	}
+
+	if f.Review != nil {
+		response.Review = &FraudReviewResponse{
+			Status:     f.Review.Status,
+			Comment:    f.Review.Comment,
+			ReviewedBy: f.Review.ReviewedBy,
+			ReviewedAt: f.Review.ReviewedAt,
+		}
+	}
+
+	return response
+}
+
+// handleServiceError trata erros do serviço de fraude
+func (h *FraudHandler) handleServiceError(c *gin.Context, err error, operation string) {
+	h.logger.Error("Fraud service error", zap.Error(err), zap.String("operation", operation))
+
+	if domainErr, ok := err.(*errors.DomainError); ok {
+		switch domainErr.Type {
+		case "ValidationError", "VALIDATION_ERROR":
+			httpResponses.BadRequest(c, domainErr.Message, domainErr.Context)
+		case "NotFoundError", "NOT_FOUND":
+			httpResponses.NotFound(c, domainErr.Message)
+		default:
+			httpResponses.InternalServerError(c, "Failed to "+operation)
+		}
+	} else {
+		httpResponses.InternalServerError(c, "Failed to "+operation)
+	}
+}
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"eventos-backend/internal/domain/occupancy"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
+	httpResponses "eventos-backend/internal/interfaces/http/responses"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// OccupancyHandler gerencia a consulta da ocupação dos eventos
+type OccupancyHandler struct {
+	occupancyService occupancy.Service
+	logger           *zap.Logger
+}
+
+// NewOccupancyHandler cria uma nova instância do handler de ocupação
+func NewOccupancyHandler(occupancyService occupancy.Service, logger *zap.Logger) *OccupancyHandler {
+	return &OccupancyHandler{
+		occupancyService: occupancyService,
+		logger:           logger,
+	}
+}
+
+// SetPartnerCapacityRequest representa uma requisição de definição da capacidade de um parceiro
+type SetPartnerCapacityRequest struct {
+	Capacity *int `json:"capacity" binding:"required,min=0,max=1000000"`
+}
+
+// HeadcountResponse representa a ocupação atual de um evento
+type HeadcountResponse struct {
+	EventID        string                     `json:"event_id"`
+	OnSite         int                        `json:"on_site"`
+	Capacity       int                        `json:"capacity"`        // 0 = sem limite
+	AvailableSpots int                        `json:"available_spots"` // -1 = sem limite
+	AtCapacity     bool                       `json:"at_capacity"`
+	Partners       []PartnerHeadcountResponse `json:"partners"`
+	At             time.Time                  `json:"at"`
+}
+
+// PartnerHeadcountResponse representa a ocupação de um parceiro no evento
+type PartnerHeadcountResponse struct {
+	PartnerID  string `json:"partner_id"`
+	OnSite     int    `json:"on_site"`
+	Capacity   int    `json:"capacity"`
+	AtCapacity bool   `json:"at_capacity"`
+}
+
+// RosterEntryResponse representa um funcionário presente no evento
+type RosterEntryResponse struct {
+	CheckinID       string    `json:"checkin_id"`
+	EmployeeID      string    `json:"employee_id"`
+	EmployeeName    string    `json:"employee_name"`
+	PartnerID       string    `json:"partner_id"`
+	Method          string    `json:"method"`
+	CheckinTime     time.Time `json:"checkin_time"`
+	OnSiteSeconds   int64     `json:"on_site_seconds"`
+	PendingApproval bool      `json:"pending_approval"`
+}
+
+// OccupancySampleResponse representa um ponto da série de ocupação
+type OccupancySampleResponse struct {
+	At     time.Time `json:"at"`
+	OnSite int       `json:"on_site"`
+}
+
+// GetHeadcount retorna quantas pessoas estão presentes no evento
+func (h *OccupancyHandler) GetHeadcount(c *gin.Context) {
+	eventID, tenantID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	headcount, err := h.occupancyService.GetHeadcount(c.Request.Context(), tenantID, eventID)
+	if err != nil {
+		h.handleServiceError(c, err, "get event occupancy")
+		return
+	}
+
+	response := HeadcountResponse{
+		EventID:        headcount.EventID.String(),
+		OnSite:         headcount.OnSite,
+		Capacity:       headcount.Capacity,
+		AvailableSpots: headcount.AvailableSpots(),
+		AtCapacity:     occupancy.IsAtCapacity(headcount.OnSite, headcount.Capacity),
+		Partners:       make([]PartnerHeadcountResponse, len(headcount.Partners)),
+		At:             headcount.At,
+	}
+
+	for i, partner := range headcount.Partners {
+		response.Partners[i] = PartnerHeadcountResponse{
+			PartnerID:  partner.PartnerID.String(),
+			OnSite:     partner.OnSite,
+			Capacity:   partner.Capacity,
+			AtCapacity: occupancy.IsAtCapacity(partner.OnSite, partner.Capacity),
+		}
+	}
+
+	c.Header("Cache-Control", "no-store")
+	httpResponses.Success(c, response, "Event occupancy retrieved successfully")
+}
+
+// GetRoster lista os funcionários presentes no evento
+func (h *OccupancyHandler) GetRoster(c *gin.Context) {
+	eventID, tenantID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var partnerID *value_objects.UUID
+	if partnerIDStr := c.Query("partner_id"); partnerIDStr != "" {
+		parsed, err := value_objects.ParseUUID(partnerIDStr)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid partner ID format", nil)
+			return
+		}
+		partnerID = &parsed
+	}
+
+	roster, err := h.occupancyService.GetRoster(c.Request.Context(), tenantID, eventID, partnerID)
+	if err != nil {
+		h.handleServiceError(c, err, "get event roster")
+		return
+	}
+
+	now := time.Now()
+	response := make([]RosterEntryResponse, len(roster))
+	for i, entry := range roster {
+		response[i] = RosterEntryResponse{
+			CheckinID:       entry.CheckinID.String(),
+			EmployeeID:      entry.EmployeeID.String(),
+			EmployeeName:    entry.EmployeeName,
+			PartnerID:       entry.PartnerID.String(),
+			Method:          entry.Method,
+			CheckinTime:     entry.CheckinTime,
+			OnSiteSeconds:   int64(now.Sub(entry.CheckinTime).Seconds()),
+			PendingApproval: entry.PendingApproval,
+		}
+	}
+
+	c.Header("Cache-Control", "no-store")
+	httpResponses.Success(c, response, "Event roster retrieved successfully")
+}
+
+// GetSeries retorna a ocupação do evento ao longo do tempo.
+// Aceita from/to em RFC3339 e interval em segundos ou como duração (ex.: 15m).
+func (h *OccupancyHandler) GetSeries(c *gin.Context) {
+	eventID, tenantID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var filters occupancy.SeriesFilters
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid from format", map[string]interface{}{"expected": "RFC3339"})
+			return
+		}
+		filters.From = from
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid to format", map[string]interface{}{"expected": "RFC3339"})
+			return
+		}
+		filters.To = to
+	}
+
+	if intervalStr := c.Query("interval"); intervalStr != "" {
+		interval, err := parseSeriesInterval(intervalStr)
+		if err != nil {
+			httpResponses.BadRequest(c, "Invalid interval format", map[string]interface{}{"expected": "seconds or duration (e.g. 15m)"})
+			return
+		}
+		filters.Interval = interval
+	}
+
+	series, err := h.occupancyService.GetSeries(c.Request.Context(), tenantID, eventID, filters)
+	if err != nil {
+		h.handleServiceError(c, err, "get occupancy series")
+		return
+	}
+
+	response := make([]OccupancySampleResponse, len(series))
+	for i, sample := range series {
+		response[i] = OccupancySampleResponse{At: sample.At, OnSite: sample.OnSite}
+	}
+
+	httpResponses.Success(c, response, "Occupancy series retrieved successfully")
+}
+
+// SetPartnerCapacity define quantas pessoas do parceiro podem estar presentes no evento
+func (h *OccupancyHandler) SetPartnerCapacity(c *gin.Context) {
+	eventID, tenantID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	partnerID, err := value_objects.ParseUUID(c.Param("partnerId"))
+	if err != nil {
+		httpResponses.BadRequest(c, "Invalid partner ID format", nil)
+		return
+	}
+
+	var req SetPartnerCapacityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request body", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	if err := h.occupancyService.SetPartnerCapacity(c.Request.Context(), tenantID, eventID, partnerID, *req.Capacity); err != nil {
+		h.handleServiceError(c, err, "set partner capacity")
+		return
+	}
+
+	httpResponses.Success(c, gin.H{
+		"event_id":   eventID.String(),
+		"partner_id": partnerID.String(),
+		"capacity":   *req.Capacity,
+	}, "Partner capacity updated successfully")
+}
+
+// parseRequest resolve o evento da rota e o tenant do usuário autenticado
+func (h *OccupancyHandler) parseRequest(c *gin.Context) (value_objects.UUID, value_objects.UUID, bool) {
+	idStr := c.Param("id")
+	eventID, err := value_objects.ParseUUID(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid event ID", zap.String("id", idStr))
+		httpResponses.BadRequest(c, "Invalid event ID format", nil)
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	return eventID, tenantID, true
+}
+
+// parseSeriesInterval interpreta o intervalo da série em segundos ou como duração
+func parseSeriesInterval(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	return time.ParseDuration(value)
+}
+
+// handleServiceError trata erros do serviço de ocupação
+func (h *OccupancyHandler) handleServiceError(c *gin.Context, err error, operation string) {
+	h.logger.Error("Occupancy service error", zap.Error(err), zap.String("operation", operation))
+
+	if domainErr, ok := err.(*errors.DomainError); ok {
+		switch domainErr.Type {
+		case "ValidationError", "VALIDATION_ERROR":
+			httpResponses.BadRequest(c, domainErr.Message, domainErr.Context)
+		case "NotFoundError", "NOT_FOUND":
+			httpResponses.NotFound(c, domainErr.Message)
+		case "ForbiddenError", "FORBIDDEN":
+			httpResponses.Forbidden(c, domainErr.Message)
+		default:
+			httpResponses.InternalServerError(c, "Failed to "+operation)
+		}
+	} else {
+		httpResponses.InternalServerError(c, "Failed to "+operation)
+	}
+}
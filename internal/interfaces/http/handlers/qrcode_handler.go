@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"eventos-backend/internal/domain/qrcode"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
+	httpResponses "eventos-backend/internal/interfaces/http/responses"
+
+	"github.com/gin-gonic/gin"
+	qrencoder "github.com/skip2/go-qrcode"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultQRCodePNGSize = 256
+	minQRCodePNGSize     = 128
+	maxQRCodePNGSize     = 1024
+)
+
+// QRCodeHandler gerencia a exibição dos QR Codes rotativos dos eventos
+type QRCodeHandler struct {
+	qrService qrcode.Service
+	logger    *zap.Logger
+}
+
+// NewQRCodeHandler cria uma nova instância do handler de QR Codes
+func NewQRCodeHandler(qrService qrcode.Service, logger *zap.Logger) *QRCodeHandler {
+	return &QRCodeHandler{
+		qrService: qrService,
+		logger:    logger,
+	}
+}
+
+// QRCodeResponse representa a resposta com o QR Code vigente do evento
+type QRCodeResponse struct {
+	EventID    string    `json:"event_id"`
+	Type       string    `json:"qr_type"`
+	Token      string    `json:"token"`
+	ValidFrom  time.Time `json:"valid_from"`
+	ValidUntil time.Time `json:"valid_until"`
+	RefreshIn  int       `json:"refresh_in"` // segundos até o QR Code expirar
+}
+
+// GetCurrent retorna o token do QR Code vigente do evento
+func (h *QRCodeHandler) GetCurrent(c *gin.Context) {
+	qr, ok := h.currentQRCode(c)
+	if !ok {
+		return
+	}
+
+	refreshIn := int(time.Until(qr.ValidUntil).Seconds())
+	if refreshIn < 0 {
+		refreshIn = 0
+	}
+
+	response := QRCodeResponse{
+		EventID:    qr.EventID.String(),
+		Type:       qr.Type,
+		Token:      qr.Token,
+		ValidFrom:  qr.ValidFrom,
+		ValidUntil: qr.ValidUntil,
+		RefreshIn:  refreshIn,
+	}
+
+	c.Header("Cache-Control", "no-store")
+	httpResponses.Success(c, response, "QR Code recuperado com sucesso")
+}
+
+// GetCurrentPNG retorna o QR Code vigente do evento como imagem PNG
+func (h *QRCodeHandler) GetCurrentPNG(c *gin.Context) {
+	size := defaultQRCodePNGSize
+	if sizeStr := c.Query("size"); sizeStr != "" {
+		parsed, err := strconv.Atoi(sizeStr)
+		if err != nil || parsed < minQRCodePNGSize || parsed > maxQRCodePNGSize {
+			httpResponses.BadRequest(c, "Invalid size parameter", map[string]interface{}{
+				"min": minQRCodePNGSize,
+				"max": maxQRCodePNGSize,
+			})
+			return
+		}
+		size = parsed
+	}
+
+	qr, ok := h.currentQRCode(c)
+	if !ok {
+		return
+	}
+
+	png, err := qrencoder.Encode(qr.Token, qrencoder.Medium, size)
+	if err != nil {
+		h.logger.Error("Failed to render QR code", zap.Error(err), zap.String("qr_code_id", qr.ID.String()))
+		httpResponses.InternalServerError(c, "Failed to render QR code")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-QR-Code-Valid-Until", qr.ValidUntil.Format(time.RFC3339))
+	c.Data(200, "image/png", png)
+}
+
+// currentQRCode resolve o evento e o usuário da requisição e busca o QR Code vigente
+func (h *QRCodeHandler) currentQRCode(c *gin.Context) (*qrcode.QRCode, bool) {
+	idStr := c.Param("id")
+	eventID, err := value_objects.ParseUUID(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid event ID", zap.String("id", idStr))
+		httpResponses.BadRequest(c, "Invalid event ID format", nil)
+		return nil, false
+	}
+
+	qrType := c.DefaultQuery("type", constants.QRTypeCheckin)
+	if !qrcode.IsValidType(qrType) {
+		httpResponses.BadRequest(c, "Invalid QR code type", map[string]interface{}{
+			"allowed": []string{constants.QRTypeCheckin, constants.QRTypeCheckout},
+		})
+		return nil, false
+	}
+
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return nil, false
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return nil, false
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return nil, false
+	}
+
+	userID, err := value_objects.ParseUUID(claims.UserID)
+	if err != nil {
+		h.logger.Error("Invalid user ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return nil, false
+	}
+
+	qr, err := h.qrService.GetCurrentQRCode(c.Request.Context(), tenantID, eventID, qrType, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "get QR code")
+		return nil, false
+	}
+
+	return qr, true
+}
+
+// handleServiceError trata erros do serviço de QR Codes
+func (h *QRCodeHandler) handleServiceError(c *gin.Context, err error, operation string) {
+	h.logger.Error("QR code service error", zap.Error(err), zap.String("operation", operation))
+
+	if domainErr, ok := err.(*errors.DomainError); ok {
+		switch domainErr.Type {
+		case "ValidationError", "VALIDATION_ERROR":
+			httpResponses.BadRequest(c, domainErr.Message, domainErr.Context)
+		case "NotFoundError", "NOT_FOUND":
+			httpResponses.NotFound(c, domainErr.Message)
+		case "ForbiddenError", "FORBIDDEN":
+			httpResponses.Forbidden(c, domainErr.Message)
+		default:
+			httpResponses.InternalServerError(c, "Failed to "+operation)
+		}
+	} else {
+		httpResponses.InternalServerError(c, "Failed to "+operation)
+	}
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"time"
+
+	"eventos-backend/internal/domain/retention"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
+	httpResponses "eventos-backend/internal/interfaces/http/responses"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RetentionHandler gerencia as políticas de retenção de dados e a sua aplicação
+type RetentionHandler struct {
+	retentionService retention.Service
+	logger           *zap.Logger
+}
+
+// NewRetentionHandler cria uma nova instância do handler de retenção
+func NewRetentionHandler(retentionService retention.Service, logger *zap.Logger) *RetentionHandler {
+	return &RetentionHandler{
+		retentionService: retentionService,
+		logger:           logger,
+	}
+}
+
+// RetentionPolicyRequest representa o prazo de retenção de uma classe de dados
+type RetentionPolicyRequest struct {
+	RetentionDays int `json:"retention_days" binding:"required,min=1,max=3650"`
+}
+
+// RetentionPolicyResponse representa uma política de retenção
+type RetentionPolicyResponse struct {
+	DataClass     string    `json:"data_class"`
+	RetentionDays int       `json:"retention_days"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	UpdatedBy     *string   `json:"updated_by,omitempty"`
+}
+
+// ListPolicies lista as políticas de retenção do tenant
+func (h *RetentionHandler) ListPolicies(c *gin.Context) {
+	tenantID, _, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	policies, err := h.retentionService.ListPolicies(c.Request.Context(), tenantID)
+	if err != nil {
+		h.handleServiceError(c, err, "list retention policies")
+		return
+	}
+
+	response := make([]RetentionPolicyResponse, len(policies))
+	for i, policy := range policies {
+		response[i] = h.toPolicyResponse(policy)
+	}
+
+	httpResponses.Success(c, response, "Políticas de retenção recuperadas com sucesso")
+}
+
+// SetPolicy define o prazo de retenção de uma classe de dados: biometrics, photos, checkin_locations ou logs
+func (h *RetentionHandler) SetPolicy(c *gin.Context) {
+	var req RetentionPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid retention policy request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	tenantID, userID, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	policy, err := h.retentionService.SetPolicy(c.Request.Context(), tenantID, c.Param("dataClass"), req.RetentionDays, userID)
+	if err != nil {
+		h.handleServiceError(c, err, "set retention policy")
+		return
+	}
+
+	httpResponses.Success(c, h.toPolicyResponse(policy), "Política de retenção salva com sucesso")
+}
+
+// RemovePolicy remove a política de uma classe de dados, que deixa de ser eliminada
+func (h *RetentionHandler) RemovePolicy(c *gin.Context) {
+	tenantID, _, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.retentionService.RemovePolicy(c.Request.Context(), tenantID, c.Param("dataClass")); err != nil {
+		h.handleServiceError(c, err, "remove retention policy")
+		return
+	}
+
+	httpResponses.Success(c, nil, "Política de retenção removida com sucesso")
+}
+
+// Preview simula a eliminação e informa, por classe, o que a próxima execução removeria
+func (h *RetentionHandler) Preview(c *gin.Context) {
+	tenantID, _, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.retentionService.Preview(c.Request.Context(), tenantID, time.Now().UTC())
+	if err != nil {
+		h.handleServiceError(c, err, "preview retention purge")
+		return
+	}
+
+	httpResponses.Success(c, report, "Simulação da eliminação gerada com sucesso")
+}
+
+// Purge aplica as políticas do tenant imediatamente, sem esperar a execução agendada
+func (h *RetentionHandler) Purge(c *gin.Context) {
+	tenantID, userID, ok := h.getAuthContext(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.retentionService.Purge(c.Request.Context(), tenantID, time.Now().UTC(), constants.RetentionPurgeBatchSize, &userID)
+	if err != nil {
+		h.handleServiceError(c, err, "purge expired data")
+		return
+	}
+
+	h.logger.Info("Retention purge executed",
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("report_id", report.ID),
+		zap.Int("records", report.Total()),
+		zap.String("requested_by", userID.String()),
+	)
+
+	httpResponses.Success(c, report, "Dados vencidos eliminados com sucesso")
+}
+
+// getAuthContext resolve o tenant e o usuário autenticado
+func (h *RetentionHandler) getAuthContext(c *gin.Context) (value_objects.UUID, value_objects.UUID, bool) {
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	userID, err := value_objects.ParseUUID(claims.UserID)
+	if err != nil {
+		h.logger.Error("Invalid user ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid authentication data")
+		return value_objects.UUID{}, value_objects.UUID{}, false
+	}
+
+	return tenantID, userID, true
+}
+
+// toPolicyResponse converte a política para a resposta
+func (h *RetentionHandler) toPolicyResponse(policy *retention.Policy) RetentionPolicyResponse {
+	response := RetentionPolicyResponse{
+		DataClass:     policy.DataClass,
+		RetentionDays: policy.Days,
+		UpdatedAt:     policy.UpdatedAt,
+	}
+
+	if policy.UpdatedBy != nil {
+		updatedBy := policy.UpdatedBy.String()
+		response.UpdatedBy = &updatedBy
+	}
+
+	return response
+}
+
+// handleServiceError trata erros do serviço de retenção
+func (h *RetentionHandler) handleServiceError(c *gin.Context, err error, operation string) {
+	h.logger.Error("Retention service error", zap.Error(err), zap.String("operation", operation))
+
+	if domainErr, ok := err.(*errors.DomainError); ok {
+		switch domainErr.Type {
+		case "ValidationError", "VALIDATION_ERROR":
+			httpResponses.BadRequest(c, domainErr.Message, domainErr.Context)
+		case "NotFoundError", "NOT_FOUND":
+			httpResponses.NotFound(c, domainErr.Message)
+		default:
+			httpResponses.InternalServerError(c, "Failed to "+operation)
+		}
+	} else {
+		httpResponses.InternalServerError(c, "Failed to "+operation)
+	}
+}
@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"eventos-backend/internal/domain/offlinesync"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
+	"eventos-backend/internal/interfaces/http/middleware"
+	httpResponses "eventos-backend/internal/interfaces/http/responses"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SyncHandler gerencia a sincronização de registros offline dos dispositivos de portaria
+type SyncHandler struct {
+	syncService offlinesync.Service
+	logger      *zap.Logger
+}
+
+// NewSyncHandler cria uma nova instância do handler de sincronização
+func NewSyncHandler(syncService offlinesync.Service, logger *zap.Logger) *SyncHandler {
+	return &SyncHandler{
+		syncService: syncService,
+		logger:      logger,
+	}
+}
+
+// SyncBatchRequest representa um lote de registros offline
+// O dispositivo de origem é o autenticado pela credencial; um device_id no corpo é ignorado
+type SyncBatchRequest struct {
+	Records []SyncRecordRequest `json:"records" binding:"required,min=1"`
+}
+
+// SyncRecordRequest representa um check-in ou check-out registrado offline
+type SyncRecordRequest struct {
+	ClientID        string    `json:"client_id"`
+	Type            string    `json:"type"`
+	RecordedAt      time.Time `json:"recorded_at"`
+	EventID         string    `json:"event_id"`
+	EmployeeID      string    `json:"employee_id"`
+	PartnerID       string    `json:"partner_id"`
+	CheckinID       string    `json:"checkin_id"`
+	CheckinClientID string    `json:"checkin_client_id"`
+	Method          string    `json:"method"`
+	Latitude        float64   `json:"latitude"`
+	Longitude       float64   `json:"longitude"`
+	PhotoURL        string    `json:"photo_url"`
+	Notes           string    `json:"notes"`
+	FaceEmbedding   []float32 `json:"face_embedding"`
+	QRCodeData      string    `json:"qr_code_data"`
+	LocationFixRequest
+}
+
+// SyncItemResponse representa o resultado de um registro do lote
+type SyncItemResponse struct {
+	Index      int     `json:"index"`
+	ClientID   string  `json:"client_id"`
+	Type       string  `json:"type"`
+	Status     string  `json:"status"`
+	ResourceID *string `json:"resource_id,omitempty"`
+	IsValid    *bool   `json:"is_valid,omitempty"`
+	ReasonCode string  `json:"reason_code,omitempty"`
+	Message    string  `json:"message,omitempty"`
+}
+
+// SyncBatchResponse representa o resultado da sincronização do lote
+type SyncBatchResponse struct {
+	DeviceID    string             `json:"device_id"`
+	ProcessedAt time.Time          `json:"processed_at"`
+	Accepted    int                `json:"accepted"`
+	Duplicates  int                `json:"duplicates"`
+	Rejected    int                `json:"rejected"`
+	Results     []SyncItemResponse `json:"results"`
+}
+
+// SyncBatch aplica um lote de check-ins e check-outs registrados offline
+func (h *SyncHandler) SyncBatch(c *gin.Context) {
+	var req SyncBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid sync batch request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request data", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	if len(req.Records) > constants.OfflineSyncMaxBatchSize {
+		httpResponses.BadRequest(c, "Batch too large", map[string]interface{}{
+			"max_records": constants.OfflineSyncMaxBatchSize,
+		})
+		return
+	}
+
+	// Obter informações do usuário autenticado
+	userClaims, exists := c.Get("claims")
+	if !exists {
+		httpResponses.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	claims, ok := userClaims.(*jwtService.Claims)
+	if !ok {
+		h.logger.Error("Invalid user claims type")
+		httpResponses.InternalServerError(c, "Authentication error")
+		return
+	}
+
+	tenantID, err := value_objects.ParseUUID(claims.TenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid tenant ID")
+		return
+	}
+
+	userID, err := value_objects.ParseUUID(claims.UserID)
+	if err != nil {
+		h.logger.Error("Invalid user ID in claims", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid user ID")
+		return
+	}
+
+	// Só um dispositivo registrado pode sincronizar; o vínculo ao evento é verificado pelo serviço,
+	// após resolver o check-in de cada check-out
+	authDevice, hasDevice := middleware.GetDevice(c)
+	if !hasDevice {
+		httpResponses.Unauthorized(c, "Device credentials required")
+		return
+	}
+
+	// Registros malformados são rejeitados individualmente para não bloquear a fila do dispositivo
+	results := make([]SyncItemResponse, len(req.Records))
+	var records []offlinesync.Record
+	var positions []int
+
+	for i, item := range req.Records {
+		record, err := h.toRecord(item)
+		if err != nil {
+			results[i] = SyncItemResponse{
+				Index:      i,
+				ClientID:   item.ClientID,
+				Type:       item.Type,
+				Status:     constants.SyncStatusRejected,
+				ReasonCode: constants.SyncRejectInvalidRecord,
+				Message:    err.Error(),
+			}
+			continue
+		}
+		records = append(records, *record)
+		positions = append(positions, i)
+	}
+
+	response := SyncBatchResponse{
+		DeviceID:    authDevice.ID.String(),
+		ProcessedAt: time.Now().UTC(),
+		Rejected:    len(req.Records) - len(records),
+	}
+
+	if len(records) > 0 {
+		batchResult, err := h.syncService.SyncBatch(c.Request.Context(), offlinesync.BatchRequest{
+			TenantID:    tenantID,
+			Device:      authDevice,
+			Records:     records,
+			SubmittedBy: userID,
+		})
+		if err != nil {
+			h.handleServiceError(c, err, "sync offline batch")
+			return
+		}
+
+		for i, item := range batchResult.Results {
+			results[positions[i]] = h.toSyncItemResponse(positions[i], item)
+		}
+
+		response.ProcessedAt = batchResult.ProcessedAt
+		response.Accepted = batchResult.Accepted
+		response.Duplicates = batchResult.Duplicates
+		response.Rejected += batchResult.Rejected
+	}
+
+	response.Results = results
+
+	h.logger.Info("Offline batch synchronized",
+		zap.String("device_id", authDevice.ID.String()),
+		zap.String("tenant_id", tenantID.String()),
+		zap.Int("accepted", response.Accepted),
+		zap.Int("duplicates", response.Duplicates),
+		zap.Int("rejected", response.Rejected),
+	)
+
+	httpResponses.Success(c, response, "Lote sincronizado com sucesso")
+}
+
+// toRecord converte um registro da requisição para o domínio
+func (h *SyncHandler) toRecord(item SyncRecordRequest) (*offlinesync.Record, error) {
+	clientID, err := value_objects.ParseUUID(item.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client_id")
+	}
+
+	record := &offlinesync.Record{
+		ClientID:      clientID,
+		Type:          item.Type,
+		RecordedAt:    item.RecordedAt,
+		Method:        item.Method,
+		PhotoURL:      item.PhotoURL,
+		Notes:         item.Notes,
+		FaceEmbedding: item.FaceEmbedding,
+		QRCodeData:    item.QRCodeData,
+	}
+
+	ids := []struct {
+		name   string
+		value  string
+		target *value_objects.UUID
+	}{
+		{"event_id", item.EventID, &record.EventID},
+		{"employee_id", item.EmployeeID, &record.EmployeeID},
+		{"partner_id", item.PartnerID, &record.PartnerID},
+	}
+
+	for _, id := range ids {
+		if id.value == "" {
+			continue
+		}
+		parsed, err := value_objects.ParseUUID(id.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s", id.name)
+		}
+		*id.target = parsed
+	}
+
+	if item.CheckinID != "" {
+		checkinID, err := value_objects.ParseUUID(item.CheckinID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkin_id")
+		}
+		record.CheckinID = &checkinID
+	}
+
+	if item.CheckinClientID != "" {
+		checkinClientID, err := value_objects.ParseUUID(item.CheckinClientID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkin_client_id")
+		}
+		record.CheckinClientID = &checkinClientID
+	}
+
+	location, err := value_objects.NewLocation(item.Latitude, item.Longitude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location coordinates")
+	}
+	record.Location = location
+
+	locationFix, err := item.toLocationFix()
+	if err != nil {
+		return nil, fmt.Errorf("invalid location fix: %w", err)
+	}
+	record.LocationFix = locationFix
+
+	return record, nil
+}
+
+// toSyncItemResponse converte o resultado de um registro para response
+func (h *SyncHandler) toSyncItemResponse(index int, item offlinesync.ItemResult) SyncItemResponse {
+	response := SyncItemResponse{
+		Index:      index,
+		ClientID:   item.ClientID.String(),
+		Type:       item.Type,
+		Status:     item.Status,
+		IsValid:    item.IsValid,
+		ReasonCode: item.ReasonCode,
+		Message:    item.Message,
+	}
+
+	if item.ResourceID != nil {
+		resourceID := item.ResourceID.String()
+		response.ResourceID = &resourceID
+	}
+
+	return response
+}
+
+// handleServiceError trata erros do serviço de sincronização
+func (h *SyncHandler) handleServiceError(c *gin.Context, err error, operation string) {
+	h.logger.Error("Sync service error", zap.Error(err), zap.String("operation", operation))
+
+	if domainErr, ok := err.(*errors.DomainError); ok {
+		switch domainErr.Type {
+		case "ValidationError", "VALIDATION_ERROR":
+			httpResponses.BadRequest(c, domainErr.Message, domainErr.Context)
+		default:
+			httpResponses.InternalServerError(c, "Failed to "+operation)
+		}
+	} else {
+		httpResponses.InternalServerError(c, "Failed to "+operation)
+	}
+}
@@ -45,6 +45,11 @@ type UpdateTenantRequest struct {
 	Address      string `json:"address,omitempty"`
 }
 
+// UpdateLocationPolicyRequest representa a política do tenant para localizações simuladas
+type UpdateLocationPolicyRequest struct {
+	RejectMockLocations *bool `json:"reject_mock_locations" binding:"required"`
+}
+
 // Create cria um novo tenant
 func (h *TenantHandler) Create(c *gin.Context) {
 	var req CreateTenantRequest
@@ -94,15 +99,16 @@ func (h *TenantHandler) Create(c *gin.Context) {
 
 	// Retornar resposta
 	response := responses.TenantResponse{
-		ID:           newTenant.ID.String(),
-		Name:         newTenant.Name,
-		Identity:     newTenant.Identity,
-		IdentityType: newTenant.IdentityType,
-		Email:        newTenant.Email,
-		Address:      newTenant.Address,
-		Active:       newTenant.Active,
-		CreatedAt:    newTenant.CreatedAt,
-		UpdatedAt:    newTenant.UpdatedAt,
+		ID:                  newTenant.ID.String(),
+		Name:                newTenant.Name,
+		Identity:            newTenant.Identity,
+		IdentityType:        newTenant.IdentityType,
+		Email:               newTenant.Email,
+		Address:             newTenant.Address,
+		Active:              newTenant.Active,
+		RejectMockLocations: newTenant.RejectMockLocations,
+		CreatedAt:           newTenant.CreatedAt,
+		UpdatedAt:           newTenant.UpdatedAt,
 	}
 
 	httpResponses.Created(c, response, "Tenant created successfully")
@@ -132,15 +138,16 @@ func (h *TenantHandler) GetByID(c *gin.Context) {
 
 	// Retornar resposta
 	response := responses.TenantResponse{
-		ID:           foundTenant.ID.String(),
-		Name:         foundTenant.Name,
-		Identity:     foundTenant.Identity,
-		IdentityType: foundTenant.IdentityType,
-		Email:        foundTenant.Email,
-		Address:      foundTenant.Address,
-		Active:       foundTenant.Active,
-		CreatedAt:    foundTenant.CreatedAt,
-		UpdatedAt:    foundTenant.UpdatedAt,
+		ID:                  foundTenant.ID.String(),
+		Name:                foundTenant.Name,
+		Identity:            foundTenant.Identity,
+		IdentityType:        foundTenant.IdentityType,
+		Email:               foundTenant.Email,
+		Address:             foundTenant.Address,
+		Active:              foundTenant.Active,
+		RejectMockLocations: foundTenant.RejectMockLocations,
+		CreatedAt:           foundTenant.CreatedAt,
+		UpdatedAt:           foundTenant.UpdatedAt,
 	}
 
 	httpResponses.Success(c, response, "")
@@ -207,20 +214,81 @@ func (h *TenantHandler) Update(c *gin.Context) {
 
 	// Retornar resposta
 	response := responses.TenantResponse{
-		ID:           updatedTenant.ID.String(),
-		Name:         updatedTenant.Name,
-		Identity:     updatedTenant.Identity,
-		IdentityType: updatedTenant.IdentityType,
-		Email:        updatedTenant.Email,
-		Address:      updatedTenant.Address,
-		Active:       updatedTenant.Active,
-		CreatedAt:    updatedTenant.CreatedAt,
-		UpdatedAt:    updatedTenant.UpdatedAt,
+		ID:                  updatedTenant.ID.String(),
+		Name:                updatedTenant.Name,
+		Identity:            updatedTenant.Identity,
+		IdentityType:        updatedTenant.IdentityType,
+		Email:               updatedTenant.Email,
+		Address:             updatedTenant.Address,
+		Active:              updatedTenant.Active,
+		RejectMockLocations: updatedTenant.RejectMockLocations,
+		CreatedAt:           updatedTenant.CreatedAt,
+		UpdatedAt:           updatedTenant.UpdatedAt,
 	}
 
 	httpResponses.Success(c, response, "Tenant updated successfully")
 }
 
+// UpdateLocationPolicy define se o tenant rejeita check-ins e check-outs com localização simulada
+func (h *TenantHandler) UpdateLocationPolicy(c *gin.Context) {
+	parsedTenantID, err := value_objects.ParseUUID(c.Param("id"))
+	if err != nil {
+		httpResponses.BadRequest(c, "Invalid tenant ID format", nil)
+		return
+	}
+
+	var req UpdateLocationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid location policy request", zap.Error(err))
+		httpResponses.BadRequest(c, "Invalid request format", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	// Obter usuário autenticado
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		httpResponses.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	parsedUserID, err := value_objects.ParseUUID(userID)
+	if err != nil {
+		h.logger.Error("Invalid user ID in token", zap.Error(err))
+		httpResponses.InternalServerError(c, "Invalid user ID")
+		return
+	}
+
+	updatedTenant, err := h.tenantService.SetMockLocationPolicy(c.Request.Context(), parsedTenantID, *req.RejectMockLocations, parsedUserID)
+	if err != nil {
+		h.logger.Error("Failed to update tenant location policy", zap.Error(err))
+		httpResponses.DomainError(c, err)
+		return
+	}
+
+	h.logger.Info("Tenant location policy updated",
+		zap.String("tenant_id", updatedTenant.ID.String()),
+		zap.Bool("reject_mock_locations", updatedTenant.RejectMockLocations),
+		zap.String("updated_by", userID),
+	)
+
+	response := responses.TenantResponse{
+		ID:                  updatedTenant.ID.String(),
+		Name:                updatedTenant.Name,
+		Identity:            updatedTenant.Identity,
+		IdentityType:        updatedTenant.IdentityType,
+		Email:               updatedTenant.Email,
+		Address:             updatedTenant.Address,
+		Active:              updatedTenant.Active,
+		RejectMockLocations: updatedTenant.RejectMockLocations,
+		CreatedAt:           updatedTenant.CreatedAt,
+		UpdatedAt:           updatedTenant.UpdatedAt,
+	}
+
+	httpResponses.Success(c, response, "Tenant location policy updated successfully")
+}
+
 // Delete desativa um tenant
 func (h *TenantHandler) Delete(c *gin.Context) {
 	tenantID := c.Param("id")
@@ -324,15 +392,16 @@ func (h *TenantHandler) List(c *gin.Context) {
 	var tenantResponses []responses.TenantResponse
 	for _, t := range tenants {
 		tenantResponses = append(tenantResponses, responses.TenantResponse{
-			ID:           t.ID.String(),
-			Name:         t.Name,
-			Identity:     t.Identity,
-			IdentityType: t.IdentityType,
-			Email:        t.Email,
-			Address:      t.Address,
-			Active:       t.Active,
-			CreatedAt:    t.CreatedAt,
-			UpdatedAt:    t.UpdatedAt,
+			ID:                  t.ID.String(),
+			Name:                t.Name,
+			Identity:            t.Identity,
+			IdentityType:        t.IdentityType,
+			Email:               t.Email,
+			Address:             t.Address,
+			Active:              t.Active,
+			RejectMockLocations: t.RejectMockLocations,
+			CreatedAt:           t.CreatedAt,
+			UpdatedAt:           t.UpdatedAt,
 		})
 	}
 
@@ -79,6 +79,22 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	}
 }
 
+// RequireStreamAuth exige autenticação em conexões de streaming.
+// O EventSource dos navegadores não envia headers: o mesmo token também é aceito no parâmetro access_token.
+func (m *AuthMiddleware) RequireStreamAuth() gin.HandlerFunc {
+	requireAuth := m.RequireAuth()
+
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := c.Query("access_token"); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+
+		requireAuth(c)
+	}
+}
+
 // OptionalAuth middleware que permite autenticação opcional
 func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
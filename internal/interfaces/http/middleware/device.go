@@ -0,0 +1,207 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+
+	"eventos-backend/internal/domain/device"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
+	"eventos-backend/internal/interfaces/http/responses"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Headers enviados pelos quiosques e coletores registrados
+const (
+	DeviceIDHeader         = "X-Device-ID"
+	DeviceKeyHeader        = "X-Device-Key"
+	DeviceAppVersionHeader = "X-App-Version"
+	DeviceTimestampHeader  = "X-Device-Timestamp"
+	DeviceNonceHeader      = "X-Device-Nonce"
+	DeviceSignatureHeader  = "X-Device-Signature"
+)
+
+// principalDevice identifica requisições autenticadas pelo próprio dispositivo, sem usuário
+const principalDevice = "device"
+
+// DeviceMiddleware identifica o dispositivo que envia check-ins e check-outs
+type DeviceMiddleware struct {
+	deviceService device.Service
+	required      bool
+	logger        *zap.Logger
+}
+
+// NewDeviceMiddleware cria uma nova instância do middleware de dispositivos.
+// Com required ativo, requisições sem credencial de dispositivo são recusadas.
+func NewDeviceMiddleware(deviceService device.Service, required bool, logger *zap.Logger) *DeviceMiddleware {
+	return &DeviceMiddleware{
+		deviceService: deviceService,
+		required:      required,
+		logger:        logger,
+	}
+}
+
+// Identify autentica o dispositivo informado nos headers; deve ser registrado após a autenticação do usuário
+func (m *DeviceMiddleware) Identify() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deviceIDStr := c.GetHeader(DeviceIDHeader)
+		if deviceIDStr == "" {
+			if m.required {
+				responses.Unauthorized(c, "Device credentials required")
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		claims, exists := GetClaims(c)
+		if !exists {
+			responses.Unauthorized(c, "Authentication required")
+			c.Abort()
+			return
+		}
+
+		tenantID, err := value_objects.ParseUUID(claims.TenantID)
+		if err != nil {
+			responses.Unauthorized(c, "Authentication required")
+			c.Abort()
+			return
+		}
+
+		deviceID, err := value_objects.ParseUUID(deviceIDStr)
+		if err != nil {
+			responses.Unauthorized(c, "Invalid device credentials")
+			c.Abort()
+			return
+		}
+
+		d, err := m.deviceService.Authenticate(c.Request.Context(), tenantID, deviceID,
+			c.GetHeader(DeviceKeyHeader), c.GetHeader(DeviceAppVersionHeader))
+		if err != nil {
+			m.handleError(c, err, deviceIDStr)
+			c.Abort()
+			return
+		}
+
+		c.Set("device", d)
+		c.Next()
+	}
+}
+
+// RequireSignature autentica o dispositivo pela assinatura HMAC da requisição, sem token de usuário.
+// É a alternativa a AuthMiddleware.RequireAuth para quiosques: o dispositivo passa a ser o autor dos registros
+// e recebe apenas as permissões de device.HasPermission.
+func (m *DeviceMiddleware) RequireSignature() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deviceID, err := value_objects.ParseUUID(c.GetHeader(DeviceIDHeader))
+		if err != nil {
+			responses.Unauthorized(c, "Device signature required")
+			c.Abort()
+			return
+		}
+
+		timestamp, err := strconv.ParseInt(c.GetHeader(DeviceTimestampHeader), 10, 64)
+		if err != nil || c.GetHeader(DeviceSignatureHeader) == "" {
+			responses.Unauthorized(c, "Device signature required")
+			c.Abort()
+			return
+		}
+
+		// O corpo é lido para o hash da assinatura e restaurado para o handler
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, constants.DeviceMaxSignedBodySize+1))
+		if err != nil {
+			responses.BadRequest(c, "Failed to read request body", nil)
+			c.Abort()
+			return
+		}
+		if len(body) > constants.DeviceMaxSignedBodySize {
+			responses.BadRequest(c, "Request body too large", map[string]interface{}{
+				"max_bytes": constants.DeviceMaxSignedBodySize,
+			})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		d, err := m.deviceService.AuthenticateSigned(c.Request.Context(), device.SignedRequest{
+			DeviceID:   deviceID,
+			Method:     c.Request.Method,
+			Path:       c.Request.URL.RequestURI(),
+			BodyHash:   device.HashBody(body),
+			Timestamp:  timestamp,
+			Nonce:      c.GetHeader(DeviceNonceHeader),
+			Signature:  c.GetHeader(DeviceSignatureHeader),
+			AppVersion: c.GetHeader(DeviceAppVersionHeader),
+		})
+		if err != nil {
+			m.handleError(c, err, deviceID.String())
+			c.Abort()
+			return
+		}
+
+		// O dispositivo é o principal da requisição
+		c.Set("device", d)
+		c.Set("principal", principalDevice)
+		c.Set("user_id", d.ID.String())
+		c.Set("tenant_id", d.TenantID.String())
+		c.Set("claims", &jwtService.Claims{
+			UserID:   d.ID.String(),
+			TenantID: d.TenantID.String(),
+			Username: "device:" + d.Name,
+		})
+
+		m.logger.Debug("Device authenticated by signature",
+			zap.String("device_id", d.ID.String()),
+			zap.String("tenant_id", d.TenantID.String()),
+		)
+
+		c.Next()
+	}
+}
+
+// handleError converte falhas de autenticação do dispositivo em respostas HTTP
+func (m *DeviceMiddleware) handleError(c *gin.Context, err error, deviceID string) {
+	if domainErr, ok := err.(*errors.DomainError); ok {
+		switch domainErr.Type {
+		case "UNAUTHORIZED":
+			m.logger.Warn("Device authentication failed", zap.String("device_id", deviceID), zap.String("reason", domainErr.Message))
+			// Falhas de assinatura informam o motivo (relógio, nonce, repetição) para o dispositivo se corrigir
+			if _, ok := domainErr.Context["reason_code"]; ok {
+				responses.Error(c, http.StatusUnauthorized, domainErr.Message, "UNAUTHORIZED", domainErr.Context)
+				return
+			}
+			responses.Unauthorized(c, "Invalid device credentials")
+			return
+		case "FORBIDDEN":
+			m.logger.Warn("Blocked submission from revoked device", zap.String("device_id", deviceID))
+			responses.Forbidden(c, "Device is revoked or inactive")
+			return
+		}
+	}
+
+	m.logger.Error("Failed to authenticate device", zap.String("device_id", deviceID), zap.Error(err))
+	responses.InternalServerError(c, "Failed to authenticate device")
+}
+
+// IsDevicePrincipal verifica se a requisição foi autenticada pelo dispositivo, sem usuário
+func IsDevicePrincipal(c *gin.Context) bool {
+	return c.GetString("principal") == principalDevice
+}
+
+// GetDevice extrai o dispositivo autenticado do contexto
+func GetDevice(c *gin.Context) (*device.Device, bool) {
+	value, exists := c.Get("device")
+	if !exists {
+		return nil, false
+	}
+
+	d, ok := value.(*device.Device)
+	return d, ok
+}
@@ -156,9 +156,15 @@ func toLower(c byte) byte {
 	return c
 }
 
-// TimeoutMiddleware middleware para timeout de requisições
+// TimeoutMiddleware middleware para timeout de requisições.
+// Conexões de streaming (text/event-stream) ficam abertas e não recebem timeout.
 func TimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if c.GetHeader("Accept") == "text/event-stream" {
+			c.Next()
+			return
+		}
+
 		// Criar contexto com timeout
 		ctx := c.Request.Context()
 
@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"eventos-backend/internal/infrastructure/cache"
+	"eventos-backend/internal/interfaces/http/responses"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// IdempotencyKeyHeader é o header enviado pelo cliente para identificar a operação
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotencyReplayedHeader indica que a resposta foi reproduzida do armazenamento
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+
+	idempotencyStateProcessing = "processing"
+	idempotencyStateCompleted  = "completed"
+)
+
+// idempotencyRecord representa a resposta armazenada para uma chave de idempotência
+type idempotencyRecord struct {
+	State       string    `json:"state"`
+	Fingerprint string    `json:"fingerprint"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	Body        []byte    `json:"body,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// IdempotencyMiddleware garante que requisições mutáveis repetidas com a mesma Idempotency-Key
+// sejam executadas uma única vez por tenant
+type IdempotencyMiddleware struct {
+	store      cache.Cache
+	keyBuilder cache.KeyBuilder
+	ttl        time.Duration
+	lockTTL    time.Duration
+	logger     *zap.Logger
+}
+
+// NewIdempotencyMiddleware cria uma nova instância do middleware de idempotência.
+// Sem cache configurado o middleware não aplica proteção.
+func NewIdempotencyMiddleware(store cache.Cache, keyBuilder cache.KeyBuilder, ttl time.Duration, logger *zap.Logger) *IdempotencyMiddleware {
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+
+	return &IdempotencyMiddleware{
+		store:      store,
+		keyBuilder: keyBuilder,
+		ttl:        ttl,
+		lockTTL:    time.Minute,
+		logger:     logger,
+	}
+}
+
+// Handle retorna o handler gin do middleware; deve ser registrado após a autenticação
+func (m *IdempotencyMiddleware) Handle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idempotencyKey := c.GetHeader(IdempotencyKeyHeader)
+		if m.store == nil || idempotencyKey == "" || !isMutatingMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		if len(idempotencyKey) > maxIdempotencyKeyLength {
+			responses.BadRequest(c, "Idempotency-Key too long", map[string]interface{}{
+				"max_length": maxIdempotencyKeyLength,
+			})
+			c.Abort()
+			return
+		}
+
+		// Ler o corpo para calcular o fingerprint e restaurá-lo para o handler
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			responses.BadRequest(c, "Failed to read request body", nil)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		key := m.keyBuilder.BuildKeyWithTenant(c.GetString("tenant_id"), "idempotency", idempotencyKey)
+		fingerprint := requestFingerprint(c.Request.Method, c.Request.URL.Path, body)
+
+		acquired, err := m.store.SetNX(ctx, key, idempotencyRecord{
+			State:       idempotencyStateProcessing,
+			Fingerprint: fingerprint,
+			CreatedAt:   time.Now().UTC(),
+		}, m.lockTTL)
+		if err != nil {
+			// Falha no cache não deve impedir a operação
+			m.logger.Warn("Idempotency store unavailable, processing request without protection",
+				zap.String("idempotency_key", idempotencyKey),
+				zap.Error(err),
+			)
+			c.Next()
+			return
+		}
+
+		if !acquired {
+			m.handleExisting(c, key, idempotencyKey, fingerprint)
+			return
+		}
+
+		writer := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		// Erros de servidor liberam a chave para que o cliente possa tentar novamente
+		if writer.Status() >= http.StatusInternalServerError {
+			if err := m.store.Delete(ctx, key); err != nil {
+				m.logger.Warn("Failed to release idempotency key", zap.String("idempotency_key", idempotencyKey), zap.Error(err))
+			}
+			return
+		}
+
+		record := idempotencyRecord{
+			State:       idempotencyStateCompleted,
+			Fingerprint: fingerprint,
+			StatusCode:  writer.Status(),
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+			CreatedAt:   time.Now().UTC(),
+		}
+
+		if err := m.store.Set(ctx, key, record, m.ttl); err != nil {
+			m.logger.Warn("Failed to store idempotent response", zap.String("idempotency_key", idempotencyKey), zap.Error(err))
+		}
+	}
+}
+
+// handleExisting responde a uma requisição cuja chave já foi utilizada
+func (m *IdempotencyMiddleware) handleExisting(c *gin.Context, key, idempotencyKey, fingerprint string) {
+	var record idempotencyRecord
+	if err := m.store.Get(c.Request.Context(), key, &record); err != nil {
+		// A chave expirou entre as operações ou o cache falhou: pedir nova tentativa
+		m.logger.Warn("Failed to load idempotency record", zap.String("idempotency_key", idempotencyKey), zap.Error(err))
+		responses.Conflict(c, "Request with this Idempotency-Key is being processed", nil)
+		c.Abort()
+		return
+	}
+
+	if record.Fingerprint != fingerprint {
+		responses.UnprocessableEntity(c, "Idempotency-Key already used with a different payload", map[string]interface{}{
+			"idempotency_key": idempotencyKey,
+		})
+		c.Abort()
+		return
+	}
+
+	if record.State != idempotencyStateCompleted {
+		responses.Conflict(c, "Request with this Idempotency-Key is being processed", nil)
+		c.Abort()
+		return
+	}
+
+	m.logger.Debug("Replaying idempotent response",
+		zap.String("idempotency_key", idempotencyKey),
+		zap.Int("status", record.StatusCode),
+	)
+
+	c.Header(IdempotencyReplayedHeader, "true")
+	c.Data(record.StatusCode, record.ContentType, record.Body)
+	c.Abort()
+}
+
+// isMutatingMethod verifica se o método HTTP altera estado
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// requestFingerprint calcula o fingerprint da requisição (método, caminho e corpo)
+func requestFingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder captura o corpo da resposta enquanto o repassa ao cliente
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
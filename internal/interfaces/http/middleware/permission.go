@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"eventos-backend/internal/domain/device"
+	"eventos-backend/internal/domain/permission"
+	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/interfaces/http/responses"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PermissionMiddleware restringe rotas aos usuários cujos papéis concedem uma permissão
+type PermissionMiddleware struct {
+	permissionService permission.Service
+	logger            *zap.Logger
+}
+
+// NewPermissionMiddleware cria uma nova instância do middleware de permissões
+func NewPermissionMiddleware(permissionService permission.Service, logger *zap.Logger) *PermissionMiddleware {
+	return &PermissionMiddleware{
+		permissionService: permissionService,
+		logger:            logger,
+	}
+}
+
+// Require exige que o usuário autenticado possua a ação no módulo; deve ser registrado após a autenticação
+func (m *PermissionMiddleware) Require(module, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Dispositivos autenticados por assinatura têm um conjunto fixo e restrito de permissões
+		if IsDevicePrincipal(c) {
+			if !device.HasPermission(module, action) {
+				m.logger.Warn("Permission denied for device",
+					zap.String("device_id", c.GetString("user_id")),
+					zap.String("module", module),
+					zap.String("action", action),
+				)
+				responses.Forbidden(c, "Insufficient permissions")
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		claims, exists := GetClaims(c)
+		if !exists {
+			responses.Unauthorized(c, "Authentication required")
+			c.Abort()
+			return
+		}
+
+		userID, err := value_objects.ParseUUID(claims.UserID)
+		if err != nil {
+			m.logger.Warn("Invalid user ID in claims", zap.String("user_id", claims.UserID))
+			responses.Unauthorized(c, "Authentication required")
+			c.Abort()
+			return
+		}
+
+		allowed, err := m.permissionService.UserHasPermission(c.Request.Context(), userID, module, action)
+		if err != nil {
+			m.logger.Error("Failed to check user permission",
+				zap.String("user_id", claims.UserID),
+				zap.String("module", module),
+				zap.String("action", action),
+				zap.Error(err),
+			)
+			responses.InternalServerError(c, "Failed to check permissions")
+			c.Abort()
+			return
+		}
+
+		if !allowed {
+			m.logger.Warn("Permission denied",
+				zap.String("user_id", claims.UserID),
+				zap.String("module", module),
+				zap.String("action", action),
+			)
+			responses.Forbidden(c, "Insufficient permissions")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -7,15 +7,26 @@ import (
 
 	"eventos-backend/internal/domain/checkin"
 	"eventos-backend/internal/domain/checkout"
+	"eventos-backend/internal/domain/datasubject"
+	"eventos-backend/internal/domain/device"
 	"eventos-backend/internal/domain/employee"
 	"eventos-backend/internal/domain/event"
+	"eventos-backend/internal/domain/fraud"
+	"eventos-backend/internal/domain/occupancy"
+	"eventos-backend/internal/domain/offlinesync"
 	"eventos-backend/internal/domain/partner"
 	"eventos-backend/internal/domain/permission"
+	"eventos-backend/internal/domain/qrcode"
+	"eventos-backend/internal/domain/retention"
 	"eventos-backend/internal/domain/role"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/storage"
 	"eventos-backend/internal/domain/tenant"
 	"eventos-backend/internal/domain/user"
 	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
+	"eventos-backend/internal/infrastructure/cache"
 	"eventos-backend/internal/infrastructure/monitoring"
+	"eventos-backend/internal/infrastructure/realtime"
 	"eventos-backend/internal/interfaces/http/handlers"
 	"eventos-backend/internal/interfaces/http/middleware"
 	"eventos-backend/internal/interfaces/http/responses"
@@ -36,18 +47,33 @@ type Router struct {
 
 // Config contém as configurações do router
 type Config struct {
-	Logger            *zap.Logger
-	DB                *sql.DB
-	JWTService        jwtService.Service
-	TenantService     tenant.Service
-	UserService       user.Service
-	EventService      event.Service
-	PartnerService    partner.Service
-	EmployeeService   employee.Service
-	RoleService       role.Service
-	PermissionService permission.Service
-	CheckinService    checkin.Service
-	CheckoutService   checkout.Service
+	Logger             *zap.Logger
+	DB                 *sql.DB
+	JWTService         jwtService.Service
+	TenantService      tenant.Service
+	UserService        user.Service
+	EventService       event.Service
+	PartnerService     partner.Service
+	EmployeeService    employee.Service
+	RoleService        role.Service
+	PermissionService  permission.Service
+	CheckinService     checkin.Service
+	CheckoutService    checkout.Service
+	QRCodeService      qrcode.Service
+	OfflineSyncService offlinesync.Service
+	OccupancyService   occupancy.Service
+	DeviceService      device.Service
+	FraudService       fraud.Service
+	DataSubjectService datasubject.Service
+	RetentionService   retention.Service
+	DeviceAuthRequired bool               // Exige dispositivo registrado nos check-ins e check-outs
+	Publisher          realtime.Publisher // Publicação dos eventos de check-in/check-out (RabbitMQ ou barramento local)
+	FeedHub            *realtime.Hub      // Conexões do feed em tempo real desta instância (nil desabilita)
+	IdempotencyStore   cache.Cache        // Armazenamento das respostas idempotentes (nil desabilita)
+	CacheKeyBuilder    cache.KeyBuilder   // Construtor de chaves de cache
+
+	PhotoStorage storage.Service          // Armazenamento das fotos de funcionários e de evidência dos check-ins
+	FileStore    handlers.SignedFileStore // Downloads assinados servidos pela API (nil desativa a rota)
 	// RolePermissionService role.RolePermissionService // TODO: Implementar quando Permission Handler estiver pronto
 	Debug bool
 }
@@ -120,10 +146,23 @@ func (r *Router) setupRoutes(cfg Config) {
 		// Rotas de autenticação (sem middleware de auth)
 		r.setupAuthRoutes(v1, cfg)
 
+		// Feed em tempo real (autenticação própria para conexões de streaming)
+		r.setupFeedRoutes(v1, cfg)
+
+		// Modo quiosque (dispositivos autenticados por assinatura, sem usuário)
+		r.setupKioskRoutes(v1, cfg)
+
+		// Downloads de arquivos (a URL assinada é a autorização)
+		r.setupFileRoutes(v1, cfg)
+
 		// Rotas protegidas (com middleware de auth)
 		authMiddleware := middleware.NewAuthMiddleware(cfg.JWTService, r.logger)
 		protected := v1.Group("")
 		protected.Use(authMiddleware.RequireAuth())
+
+		// Idempotency-Key para operações mutáveis (depende do tenant autenticado)
+		idempotencyMiddleware := middleware.NewIdempotencyMiddleware(cfg.IdempotencyStore, cfg.CacheKeyBuilder, 24*time.Hour, r.logger)
+		protected.Use(idempotencyMiddleware.Handle())
 		{
 			r.setupTenantRoutes(protected, cfg)
 			r.setupUserRoutes(protected, cfg)
@@ -134,6 +173,11 @@ func (r *Router) setupRoutes(cfg Config) {
 			r.setupPermissionRoutes(protected, cfg)
 			r.setupCheckinRoutes(protected, cfg)
 			r.setupCheckoutRoutes(protected, cfg)
+			r.setupSyncRoutes(protected, cfg)
+			r.setupDeviceRoutes(protected, cfg)
+			r.setupFraudRoutes(protected, cfg)
+			r.setupDataSubjectRoutes(protected, cfg)
+			r.setupRetentionRoutes(protected, cfg)
 		}
 	}
 }
@@ -174,6 +218,52 @@ func (r *Router) setupAuthRoutes(rg *gin.RouterGroup, cfg Config) {
 	}
 }
 
+// setupFeedRoutes configura o feed de check-ins em tempo real (Server-Sent Events)
+func (r *Router) setupFeedRoutes(rg *gin.RouterGroup, cfg Config) {
+	feedHandler := handlers.NewFeedHandler(cfg.FeedHub, cfg.EventService, r.logger)
+	authMiddleware := middleware.NewAuthMiddleware(cfg.JWTService, r.logger)
+
+	feed := rg.Group("/feed")
+	feed.Use(authMiddleware.RequireStreamAuth())
+	{
+		feed.GET("", feedHandler.StreamTenant)
+		feed.GET("/events/:id", feedHandler.StreamEvent)
+	}
+}
+
+// setupFileRoutes configura a rota de download das fotos.
+// As fotos são gravadas cifradas, por isso os downloads passam sempre pela API, qualquer que seja o armazenamento.
+func (r *Router) setupFileRoutes(rg *gin.RouterGroup, cfg Config) {
+	if cfg.FileStore == nil {
+		return
+	}
+
+	fileHandler := handlers.NewFileHandler(cfg.FileStore, r.logger)
+	rg.GET("/files/*key", fileHandler.Download)
+}
+
+// setupKioskRoutes configura as rotas dos dispositivos autenticados por requisições assinadas.
+// Os dispositivos só registram check-ins e check-outs do evento ao qual estão vinculados.
+func (r *Router) setupKioskRoutes(rg *gin.RouterGroup, cfg Config) {
+	checkinHandler := handlers.NewCheckinHandler(cfg.CheckinService, cfg.Publisher, cfg.PhotoStorage, r.logger)
+	checkoutHandler := handlers.NewCheckoutHandler(cfg.CheckoutService, cfg.Publisher, r.logger)
+	syncHandler := handlers.NewSyncHandler(cfg.OfflineSyncService, r.logger)
+	deviceHandler := handlers.NewDeviceHandler(cfg.DeviceService, r.logger)
+	deviceMiddleware := middleware.NewDeviceMiddleware(cfg.DeviceService, true, r.logger)
+	permissionMiddleware := middleware.NewPermissionMiddleware(cfg.PermissionService, r.logger)
+	requireSubmit := permissionMiddleware.Require(constants.ModuleCheckins, constants.PermissionWrite)
+	idempotencyMiddleware := middleware.NewIdempotencyMiddleware(cfg.IdempotencyStore, cfg.CacheKeyBuilder, 24*time.Hour, r.logger)
+
+	kiosk := rg.Group("/kiosk")
+	kiosk.Use(deviceMiddleware.RequireSignature(), idempotencyMiddleware.Handle())
+	{
+		kiosk.POST("/heartbeat", deviceHandler.Heartbeat)
+		kiosk.POST("/checkins", requireSubmit, checkinHandler.PerformCheckin)
+		kiosk.POST("/checkouts", requireSubmit, checkoutHandler.PerformCheckout)
+		kiosk.POST("/sync/batch", requireSubmit, syncHandler.SyncBatch)
+	}
+}
+
 // setupTenantRoutes configura rotas de tenant
 func (r *Router) setupTenantRoutes(rg *gin.RouterGroup, cfg Config) {
 	tenantHandler := handlers.NewTenantHandler(cfg.TenantService, r.logger)
@@ -183,6 +273,7 @@ func (r *Router) setupTenantRoutes(rg *gin.RouterGroup, cfg Config) {
 		tenants.POST("", tenantHandler.Create)
 		tenants.GET("/:id", tenantHandler.GetByID)
 		tenants.PUT("/:id", tenantHandler.Update)
+		tenants.PUT("/:id/location-policy", tenantHandler.UpdateLocationPolicy)
 		tenants.DELETE("/:id", tenantHandler.Delete)
 		tenants.GET("", tenantHandler.List)
 	}
@@ -205,7 +296,11 @@ func (r *Router) setupUserRoutes(rg *gin.RouterGroup, cfg Config) {
 
 // setupEventRoutes configura rotas de evento
 func (r *Router) setupEventRoutes(rg *gin.RouterGroup, cfg Config) {
-	eventHandler := handlers.NewEventHandler(cfg.EventService, r.logger)
+	eventHandler := handlers.NewEventHandler(cfg.EventService, cfg.CheckinService, cfg.CheckoutService, r.logger)
+	qrCodeHandler := handlers.NewQRCodeHandler(cfg.QRCodeService, r.logger)
+	occupancyHandler := handlers.NewOccupancyHandler(cfg.OccupancyService, r.logger)
+	employeeHandler := handlers.NewEmployeeHandler(cfg.EmployeeService, cfg.PhotoStorage, r.logger)
+	permissionMiddleware := middleware.NewPermissionMiddleware(cfg.PermissionService, r.logger)
 
 	events := rg.Group("/events")
 	{
@@ -218,6 +313,23 @@ func (r *Router) setupEventRoutes(rg *gin.RouterGroup, cfg Config) {
 
 		// Operações específicas
 		events.GET("/:id/stats", eventHandler.GetStats)
+
+		// QR Codes rotativos
+		events.GET("/:id/qrcode", qrCodeHandler.GetCurrent)
+		events.GET("/:id/qrcode/png", qrCodeHandler.GetCurrentPNG)
+
+		// Ocupação em tempo real
+		events.GET("/:id/occupancy", occupancyHandler.GetHeadcount)
+		events.GET("/:id/occupancy/roster", occupancyHandler.GetRoster)
+		events.GET("/:id/occupancy/series", occupancyHandler.GetSeries)
+		events.PUT("/:id/partners/:partnerId/capacity",
+			permissionMiddleware.Require(constants.ModuleEvents, constants.PermissionWrite),
+			occupancyHandler.SetPartnerCapacity)
+
+		// Cobertura do consentimento biométrico
+		events.GET("/:id/biometric-consent",
+			permissionMiddleware.Require(constants.ModuleFacial, constants.PermissionRead),
+			employeeHandler.GetEventConsentCoverage)
 	}
 }
 
@@ -242,7 +354,11 @@ func (r *Router) setupPartnerRoutes(rg *gin.RouterGroup, cfg Config) {
 
 // setupEmployeeRoutes configura rotas de funcionário
 func (r *Router) setupEmployeeRoutes(rg *gin.RouterGroup, cfg Config) {
-	employeeHandler := handlers.NewEmployeeHandler(cfg.EmployeeService, r.logger)
+	employeeHandler := handlers.NewEmployeeHandler(cfg.EmployeeService, cfg.PhotoStorage, r.logger)
+	permissionMiddleware := middleware.NewPermissionMiddleware(cfg.PermissionService, r.logger)
+	requireFacialRead := permissionMiddleware.Require(constants.ModuleFacial, constants.PermissionRead)
+	requireFacialWrite := permissionMiddleware.Require(constants.ModuleFacial, constants.PermissionWrite)
+	requireFacialAdmin := permissionMiddleware.Require(constants.ModuleFacial, constants.PermissionAdmin)
 
 	employees := rg.Group("/employees")
 	{
@@ -257,6 +373,23 @@ func (r *Router) setupEmployeeRoutes(rg *gin.RouterGroup, cfg Config) {
 		employees.POST("/:id/photo", employeeHandler.UploadPhoto)
 		employees.POST("/:id/face", employeeHandler.UpdateFaceEmbedding)
 		employees.POST("/:id/recognize", employeeHandler.RecognizeFace)
+
+		// Templates faciais de cadastro
+		employees.POST("/:id/face-templates", requireFacialAdmin, employeeHandler.AddFaceTemplate)
+		employees.GET("/:id/face-templates", requireFacialRead, employeeHandler.ListFaceTemplates)
+		employees.DELETE("/:id/face-templates/:templateId", requireFacialAdmin, employeeHandler.RemoveFaceTemplate)
+
+		// Consentimento biométrico
+		employees.GET("/:id/biometric-consent", requireFacialRead, employeeHandler.ListBiometricConsents)
+		employees.POST("/:id/biometric-consent", requireFacialWrite, employeeHandler.GrantBiometricConsent)
+		employees.POST("/:id/biometric-consent/revoke", requireFacialWrite, employeeHandler.RevokeBiometricConsent)
+	}
+
+	// Termo de consentimento biométrico do tenant
+	consentText := rg.Group("/biometric-consent/text")
+	{
+		consentText.GET("", requireFacialRead, employeeHandler.GetConsentText)
+		consentText.POST("", requireFacialAdmin, employeeHandler.PublishConsentText)
 	}
 }
 
@@ -309,17 +442,29 @@ func (r *Router) setupPermissionRoutes(rg *gin.RouterGroup, cfg Config) {
 
 // setupCheckinRoutes configura rotas de check-in
 func (r *Router) setupCheckinRoutes(rg *gin.RouterGroup, cfg Config) {
-	checkinHandler := handlers.NewCheckinHandler(cfg.CheckinService, r.logger)
+	checkinHandler := handlers.NewCheckinHandler(cfg.CheckinService, cfg.Publisher, cfg.PhotoStorage, r.logger)
+	permissionMiddleware := middleware.NewPermissionMiddleware(cfg.PermissionService, r.logger)
+	requireApprover := permissionMiddleware.Require(constants.ModuleCheckins, constants.PermissionApprove)
+	requireVoider := permissionMiddleware.Require(constants.ModuleCheckins, constants.PermissionVoid)
+	deviceMiddleware := middleware.NewDeviceMiddleware(cfg.DeviceService, cfg.DeviceAuthRequired, r.logger)
 
 	checkins := rg.Group("/checkins")
 	{
 		// Operações básicas
-		checkins.POST("", checkinHandler.PerformCheckin)
+		checkins.POST("", deviceMiddleware.Identify(), checkinHandler.PerformCheckin)
+		checkins.POST("/walk-up", deviceMiddleware.Identify(), checkinHandler.PerformWalkUpCheckin)
 		checkins.GET("/:id", checkinHandler.GetByID)
 		checkins.GET("", checkinHandler.List)
 
 		// Operações específicas
 		checkins.POST("/:id/notes", checkinHandler.AddNote)
+		checkins.POST("/:id/void", requireVoider, checkinHandler.Void)
+		checkins.POST("/:id/photo", checkinHandler.AttachPhoto)
+
+		// Fila de aprovação do supervisor
+		checkins.GET("/pending", requireApprover, checkinHandler.ListPending)
+		checkins.POST("/:id/approve", requireApprover, checkinHandler.Approve)
+		checkins.POST("/:id/reject", requireApprover, checkinHandler.Reject)
 
 		// Estatísticas
 		checkins.GET("/stats", checkinHandler.GetStats)
@@ -333,17 +478,26 @@ func (r *Router) setupCheckinRoutes(rg *gin.RouterGroup, cfg Config) {
 
 // setupCheckoutRoutes configura rotas de check-out
 func (r *Router) setupCheckoutRoutes(rg *gin.RouterGroup, cfg Config) {
-	checkoutHandler := handlers.NewCheckoutHandler(cfg.CheckoutService, r.logger)
+	checkoutHandler := handlers.NewCheckoutHandler(cfg.CheckoutService, cfg.Publisher, r.logger)
+	permissionMiddleware := middleware.NewPermissionMiddleware(cfg.PermissionService, r.logger)
+	requireApprover := permissionMiddleware.Require(constants.ModuleCheckins, constants.PermissionApprove)
+	requireVoider := permissionMiddleware.Require(constants.ModuleCheckins, constants.PermissionVoid)
+	deviceMiddleware := middleware.NewDeviceMiddleware(cfg.DeviceService, cfg.DeviceAuthRequired, r.logger)
 
 	checkouts := rg.Group("/checkouts")
 	{
 		// Operações básicas
-		checkouts.POST("", checkoutHandler.PerformCheckout)
+		checkouts.POST("", deviceMiddleware.Identify(), checkoutHandler.PerformCheckout)
 		checkouts.GET("/:id", checkoutHandler.GetByID)
 		checkouts.GET("", checkoutHandler.List)
 
 		// Operações específicas
 		checkouts.POST("/:id/notes", checkoutHandler.AddNote)
+		checkouts.POST("/:id/void", requireVoider, checkoutHandler.Void)
+
+		// Sessões encerradas automaticamente (revisão do supervisor)
+		checkouts.GET("/auto-closed", requireApprover, checkoutHandler.ListAutoClosed)
+		checkouts.PATCH("/:id/time", requireApprover, checkoutHandler.CorrectTime)
 
 		// Estatísticas
 		checkouts.GET("/stats", checkoutHandler.GetStats)
@@ -363,6 +517,90 @@ func (r *Router) setupCheckoutRoutes(rg *gin.RouterGroup, cfg Config) {
 	}
 }
 
+// setupSyncRoutes configura rotas de sincronização offline dos dispositivos
+func (r *Router) setupSyncRoutes(rg *gin.RouterGroup, cfg Config) {
+	syncHandler := handlers.NewSyncHandler(cfg.OfflineSyncService, r.logger)
+	// A origem dos registros offline é gravada nos check-ins: o lote exige sempre um dispositivo registrado
+	deviceMiddleware := middleware.NewDeviceMiddleware(cfg.DeviceService, true, r.logger)
+
+	sync := rg.Group("/sync")
+	{
+		sync.POST("/batch", deviceMiddleware.Identify(), syncHandler.SyncBatch)
+	}
+}
+
+// setupDeviceRoutes configura rotas do registro de dispositivos
+func (r *Router) setupDeviceRoutes(rg *gin.RouterGroup, cfg Config) {
+	deviceHandler := handlers.NewDeviceHandler(cfg.DeviceService, r.logger)
+	permissionMiddleware := middleware.NewPermissionMiddleware(cfg.PermissionService, r.logger)
+	requireRead := permissionMiddleware.Require(constants.ModuleDevices, constants.PermissionRead)
+	requireAdmin := permissionMiddleware.Require(constants.ModuleDevices, constants.PermissionAdmin)
+
+	// O próprio dispositivo confirma sua credencial e informa a versão do aplicativo
+	requireDevice := middleware.NewDeviceMiddleware(cfg.DeviceService, true, r.logger)
+
+	devices := rg.Group("/devices")
+	{
+		devices.POST("/heartbeat", requireDevice.Identify(), deviceHandler.Heartbeat)
+
+		// Administração
+		devices.POST("", requireAdmin, deviceHandler.Register)
+		devices.GET("", requireRead, deviceHandler.List)
+		devices.GET("/:id", requireRead, deviceHandler.GetByID)
+		devices.PUT("/:id", requireAdmin, deviceHandler.Update)
+		devices.POST("/:id/credentials", requireAdmin, deviceHandler.RotateCredential)
+		devices.POST("/:id/revoke", requireAdmin, deviceHandler.Revoke)
+	}
+}
+
+// setupFraudRoutes configura rotas da revisão de fraudes
+func (r *Router) setupFraudRoutes(rg *gin.RouterGroup, cfg Config) {
+	fraudHandler := handlers.NewFraudHandler(cfg.FraudService, r.logger)
+	permissionMiddleware := middleware.NewPermissionMiddleware(cfg.PermissionService, r.logger)
+	requireRead := permissionMiddleware.Require(constants.ModuleFraud, constants.PermissionRead)
+	requireAdmin := permissionMiddleware.Require(constants.ModuleFraud, constants.PermissionAdmin)
+
+	flags := rg.Group("/fraud/flags")
+	{
+		flags.GET("", requireRead, fraudHandler.List)
+		flags.GET("/:checkinId", requireRead, fraudHandler.GetByID)
+		flags.POST("/:checkinId/review", requireAdmin, fraudHandler.Review)
+	}
+}
+
+// setupDataSubjectRoutes configura rotas das solicitações de titulares (LGPD)
+func (r *Router) setupDataSubjectRoutes(rg *gin.RouterGroup, cfg Config) {
+	dataSubjectHandler := handlers.NewDataSubjectHandler(cfg.DataSubjectService, r.logger)
+	permissionMiddleware := middleware.NewPermissionMiddleware(cfg.PermissionService, r.logger)
+	requireRead := permissionMiddleware.Require(constants.ModulePrivacy, constants.PermissionRead)
+	requireAdmin := permissionMiddleware.Require(constants.ModulePrivacy, constants.PermissionAdmin)
+
+	privacy := rg.Group("/privacy")
+	{
+		privacy.POST("/exports", requireAdmin, dataSubjectHandler.Export)
+		privacy.POST("/erasures", requireAdmin, dataSubjectHandler.Erase)
+		privacy.GET("/requests", requireRead, dataSubjectHandler.List)
+		privacy.GET("/requests/:id", requireRead, dataSubjectHandler.GetByID)
+	}
+}
+
+// setupRetentionRoutes configura rotas das políticas de retenção de dados
+func (r *Router) setupRetentionRoutes(rg *gin.RouterGroup, cfg Config) {
+	retentionHandler := handlers.NewRetentionHandler(cfg.RetentionService, r.logger)
+	permissionMiddleware := middleware.NewPermissionMiddleware(cfg.PermissionService, r.logger)
+	requireRead := permissionMiddleware.Require(constants.ModulePrivacy, constants.PermissionRead)
+	requireAdmin := permissionMiddleware.Require(constants.ModulePrivacy, constants.PermissionAdmin)
+
+	retentionRoutes := rg.Group("/privacy/retention")
+	{
+		retentionRoutes.GET("/policies", requireRead, retentionHandler.ListPolicies)
+		retentionRoutes.PUT("/policies/:dataClass", requireAdmin, retentionHandler.SetPolicy)
+		retentionRoutes.DELETE("/policies/:dataClass", requireAdmin, retentionHandler.RemovePolicy)
+		retentionRoutes.GET("/report", requireRead, retentionHandler.Preview)
+		retentionRoutes.POST("/purge", requireAdmin, retentionHandler.Purge)
+	}
+}
+
 // healthCheck endpoint de verificação de saúde
 func (r *Router) healthCheck(c *gin.Context) {
 	// Verificar saúde do banco de dados
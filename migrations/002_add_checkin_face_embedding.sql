@@ -0,0 +1,5 @@
+-- Migration: 002_add_checkin_face_embedding.sql
+-- Database: PostgreSQL
+-- Description: Armazena o embedding facial capturado no check-in para comparação no check-out
+
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS face_embedding REAL[];
@@ -0,0 +1,5 @@
+-- Migration: 003_add_event_fence_tolerance.sql
+-- Database: PostgreSQL
+-- Description: Tolerância (em metros) aceita fora da cerca geográfica do evento
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS fence_tolerance_meters DOUBLE PRECISION NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+-- Migration: 004_add_event_qr_code_usage.sql
+-- Database: PostgreSQL
+-- Description: Controle de uso dos QR Codes rotativos assinados dos eventos
+
+ALTER TABLE event_qr_code ALTER COLUMN qr_token TYPE VARCHAR(512);
+ALTER TABLE event_qr_code ADD COLUMN IF NOT EXISTS usage_count INT NOT NULL DEFAULT 0;
+ALTER TABLE event_qr_code ADD COLUMN IF NOT EXISTS max_usage INT NOT NULL DEFAULT 1;
+ALTER TABLE event_qr_code ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_event_qr_code_event_type ON event_qr_code(id_event, qr_type, valid_from DESC);
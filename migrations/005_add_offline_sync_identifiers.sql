@@ -0,0 +1,13 @@
+-- Migration: 005_add_offline_sync_identifiers.sql
+-- Database: PostgreSQL
+-- Description: Identificadores de dispositivo e de cliente para a sincronização offline de check-ins e check-outs
+
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS client_id UUID;
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS device_id VARCHAR(100);
+
+ALTER TABLE checkout ADD COLUMN IF NOT EXISTS client_id UUID;
+ALTER TABLE checkout ADD COLUMN IF NOT EXISTS device_id VARCHAR(100);
+
+-- O identificador gerado no dispositivo garante a idempotência do reenvio
+CREATE UNIQUE INDEX IF NOT EXISTS idx_checkin_client_id ON checkin(client_id) WHERE client_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_checkout_client_id ON checkout(client_id) WHERE client_id IS NOT NULL;
@@ -0,0 +1,9 @@
+-- Migration: 006_add_work_session_indexes.sql
+-- Database: PostgreSQL
+-- Description: Suporte a várias sessões de trabalho por funcionário e evento (reentradas e turnos)
+
+-- Busca da sessão aberta do funcionário no evento e listagem das sessões em ordem cronológica
+CREATE INDEX IF NOT EXISTS idx_checkin_employee_event_time ON checkin(id_employee, id_event, checkin_time DESC);
+
+-- Cada check-in fecha no máximo uma sessão
+CREATE UNIQUE INDEX IF NOT EXISTS idx_checkout_id_checkin ON checkout(id_checkin);
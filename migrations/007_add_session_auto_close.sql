@@ -0,0 +1,12 @@
+-- Migration: 007_add_session_auto_close.sql
+-- Database: PostgreSQL
+-- Description: Encerramento automático de sessões abertas no fim do evento ou ao atingir a duração máxima
+
+-- Duração máxima de uma sessão de trabalho no evento, em horas (0 = sem limite)
+ALTER TABLE events ADD COLUMN IF NOT EXISTS max_session_hours DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Check-outs gerados pelo sistema para sessões esquecidas abertas
+ALTER TABLE checkout ADD COLUMN IF NOT EXISTS auto_closed BOOLEAN NOT NULL DEFAULT false;
+
+-- Listagem das sessões encerradas automaticamente para revisão dos supervisores
+CREATE INDEX IF NOT EXISTS idx_checkout_auto_closed ON checkout(id_tenant, checkout_time DESC) WHERE auto_closed;
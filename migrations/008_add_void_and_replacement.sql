@@ -0,0 +1,22 @@
+-- Migration: 008_add_void_and_replacement.sql
+-- Database: PostgreSQL
+-- Description: Anulação de check-ins e check-outs registrados por engano e vínculo com o registro substituto
+
+-- O registro original é mantido; anulados deixam de contar em estatísticas e sessões de trabalho
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS voided_at TIMESTAMP;
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS voided_by UUID;
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS void_reason TEXT;
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS replaces_id UUID REFERENCES checkin(id_checkin);
+
+ALTER TABLE checkout ADD COLUMN IF NOT EXISTS voided_at TIMESTAMP;
+ALTER TABLE checkout ADD COLUMN IF NOT EXISTS voided_by UUID;
+ALTER TABLE checkout ADD COLUMN IF NOT EXISTS void_reason TEXT;
+ALTER TABLE checkout ADD COLUMN IF NOT EXISTS replaces_id UUID REFERENCES checkout(id_checkout);
+
+-- Cada registro anulado tem no máximo um substituto
+CREATE UNIQUE INDEX IF NOT EXISTS idx_checkin_replaces_id ON checkin(replaces_id) WHERE replaces_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_checkout_replaces_id ON checkout(replaces_id) WHERE replaces_id IS NOT NULL;
+
+-- Cada check-in fecha no máximo uma sessão entre os check-outs não anulados
+DROP INDEX IF EXISTS idx_checkout_id_checkin;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_checkout_id_checkin ON checkout(id_checkin) WHERE voided_at IS NULL;
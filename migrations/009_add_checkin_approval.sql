@@ -0,0 +1,14 @@
+-- Migration: 009_add_checkin_approval.sql
+-- Database: PostgreSQL
+-- Description: Fila de aprovação do supervisor para check-ins manuais e de baixa confiança facial
+
+-- NULL quando a aprovação não é exigida; pendentes não contam nas horas trabalhadas
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS approval_status VARCHAR(20)
+    CHECK (approval_status IN ('pending', 'approved', 'rejected'));
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP;
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS reviewed_by UUID;
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS review_comment TEXT;
+
+-- Fila de aprovação por tenant
+CREATE INDEX IF NOT EXISTS idx_checkin_pending_approval ON checkin(id_tenant, checkin_time)
+    WHERE approval_status = 'pending' AND voided_at IS NULL;
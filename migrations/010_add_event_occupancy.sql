@@ -0,0 +1,11 @@
+-- Migration: 010_add_event_occupancy.sql
+-- Database: PostgreSQL
+-- Description: Capacidade dos eventos e dos parceiros para o controle de ocupação
+
+-- Número máximo de pessoas presentes ao mesmo tempo (0 = sem limite)
+ALTER TABLE events ADD COLUMN IF NOT EXISTS max_occupancy INTEGER NOT NULL DEFAULT 0 CHECK (max_occupancy >= 0);
+ALTER TABLE event_partner ADD COLUMN IF NOT EXISTS max_occupancy INTEGER NOT NULL DEFAULT 0 CHECK (max_occupancy >= 0);
+
+-- Contagem e lista das sessões abertas por evento
+CREATE INDEX IF NOT EXISTS idx_checkin_event_open ON checkin(id_event, id_partner, checkin_time DESC)
+    WHERE voided_at IS NULL AND (is_valid = true OR approval_status = 'pending');
@@ -0,0 +1,33 @@
+-- Migration: 011_create_devices.sql
+-- Database: PostgreSQL
+-- Description: Registro de quiosques e coletores portáteis usados nos portões dos eventos
+
+CREATE TABLE IF NOT EXISTS device (
+    id_device UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    id_tenant UUID NOT NULL,
+    device_name VARCHAR(100) NOT NULL,
+    device_type VARCHAR(20) NOT NULL CHECK (device_type IN ('kiosk', 'handheld')),
+    id_event UUID, -- NULL = qualquer evento do tenant
+    gate VARCHAR(100),
+    secret_hash VARCHAR(64), -- SHA-256 da credencial; a credencial não é armazenada
+    credential_issued_at TIMESTAMP,
+    app_version VARCHAR(50),
+    last_seen_at TIMESTAMP,
+    active BOOLEAN DEFAULT true,
+    revoked_at TIMESTAMP,
+    revoked_by UUID,
+    revoke_reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by UUID,
+    updated_by UUID,
+    FOREIGN KEY (id_tenant) REFERENCES tenant(id_tenant),
+    FOREIGN KEY (id_event) REFERENCES events(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_device_tenant ON device(id_tenant, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_device_event ON device(id_event) WHERE id_event IS NOT NULL;
+
+-- Dispositivo autenticado que enviou cada registro
+CREATE INDEX IF NOT EXISTS idx_checkin_device ON checkin(device_id) WHERE device_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_checkout_device ON checkout(device_id) WHERE device_id IS NOT NULL;
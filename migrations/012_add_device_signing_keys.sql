@@ -0,0 +1,6 @@
+-- Migration: 012_add_device_signing_keys.sql
+-- Database: PostgreSQL
+-- Description: Credencial cifrada dos dispositivos para validar requisições assinadas (modo quiosque)
+
+-- Cifrada com a chave do servidor (DEVICE_KEY_SECRET); dispositivos sem chave precisam ter a credencial reemitida
+ALTER TABLE device ADD COLUMN IF NOT EXISTS encrypted_key BYTEA;
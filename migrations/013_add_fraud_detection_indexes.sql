@@ -0,0 +1,21 @@
+-- Migration: 013_add_fraud_detection_indexes.sql
+-- Database: PostgreSQL
+-- Description: Consultas da análise de fraude e fila de revisão dos check-ins sinalizados
+
+-- Registro anterior do funcionário (deslocamento impossível)
+CREATE INDEX IF NOT EXISTS idx_checkin_employee_time ON checkin(id_tenant, id_employee, checkin_time DESC)
+    WHERE voided_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_checkout_employee_time ON checkout(id_tenant, id_employee, checkout_time DESC)
+    WHERE voided_at IS NULL;
+
+-- Funcionários distintos por dispositivo em poucos segundos (registro por terceiros)
+CREATE INDEX IF NOT EXISTS idx_checkin_device_time ON checkin(id_tenant, device_id, checkin_time)
+    WHERE device_id IS NOT NULL AND voided_at IS NULL;
+
+-- Embeddings faciais idênticos dentro do período pesquisado
+CREATE INDEX IF NOT EXISTS idx_checkin_face_embedding_time ON checkin(id_tenant, checkin_time DESC)
+    WHERE face_embedding IS NOT NULL AND voided_at IS NULL;
+
+-- Fila de revisão dos check-ins sinalizados
+CREATE INDEX IF NOT EXISTS idx_checkin_fraud_flags ON checkin(id_tenant, checkin_time DESC)
+    WHERE validation_details::jsonb -> 'fraud_flags' IS NOT NULL;
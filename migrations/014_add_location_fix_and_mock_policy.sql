@@ -0,0 +1,21 @@
+-- Migration: 014_add_location_fix_and_mock_policy.sql
+-- Database: PostgreSQL
+-- Description: Metadados da leitura de GPS nos check-ins e check-outs e política do tenant para localizações simuladas
+
+-- Precisão (metros), altitude (metros), horário da leitura, provedor e simulação informados pelo dispositivo
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS location_accuracy DOUBLE PRECISION;
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS location_altitude DOUBLE PRECISION;
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS location_fix_time TIMESTAMP;
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS location_provider VARCHAR(20)
+    CHECK (location_provider IN ('gps', 'network', 'fused', 'passive'));
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS location_mock BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE checkout ADD COLUMN IF NOT EXISTS location_accuracy DOUBLE PRECISION;
+ALTER TABLE checkout ADD COLUMN IF NOT EXISTS location_altitude DOUBLE PRECISION;
+ALTER TABLE checkout ADD COLUMN IF NOT EXISTS location_fix_time TIMESTAMP;
+ALTER TABLE checkout ADD COLUMN IF NOT EXISTS location_provider VARCHAR(20)
+    CHECK (location_provider IN ('gps', 'network', 'fused', 'passive'));
+ALTER TABLE checkout ADD COLUMN IF NOT EXISTS location_mock BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Quando verdadeiro, check-ins e check-outs com localização simulada são registrados como inválidos
+ALTER TABLE tenant ADD COLUMN IF NOT EXISTS reject_mock_locations BOOLEAN NOT NULL DEFAULT FALSE;
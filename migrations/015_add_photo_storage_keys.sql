@@ -0,0 +1,9 @@
+-- Migration: 015_add_photo_storage_keys.sql
+-- Database: PostgreSQL
+-- Description: Chaves das fotos gravadas no armazenamento de arquivos (sistema de arquivos local ou bucket S3)
+
+-- Chave no formato tenants/{tenant}/{categoria}/{dono}/{arquivo}; photo_url permanece para cadastros anteriores
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS photo_key VARCHAR(500);
+
+-- Foto de evidência do check-in; gravada uma única vez
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS photo_key VARCHAR(500);
@@ -0,0 +1,26 @@
+-- Migration: 016_create_employee_face_templates.sql
+-- Database: PostgreSQL
+-- Description: Templates faciais de cadastro; cada funcionário pode ter vários embeddings e o reconhecimento usa o mais parecido
+
+CREATE TABLE IF NOT EXISTS employee_face_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    embedding REAL[] NOT NULL CHECK (array_length(embedding, 1) = 512),
+    quality REAL NOT NULL CHECK (quality >= 0 AND quality <= 1),
+    model_version VARCHAR(50) NOT NULL,
+    source_photo_key VARCHAR(500), -- Foto de origem no armazenamento de arquivos
+    captured_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by UUID,
+    FOREIGN KEY (employee_id) REFERENCES employees(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_employee_face_templates_employee ON employee_face_templates(employee_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_employee_face_templates_tenant ON employee_face_templates(tenant_id);
+
+-- Cadastros anteriores passam a ter o embedding único como primeiro template
+INSERT INTO employee_face_templates (tenant_id, employee_id, embedding, quality, model_version, source_photo_key, captured_at, created_by)
+SELECT tenant_id, id, face_embedding, 1, 'legacy', photo_key, updated_at, updated_by
+FROM employees
+WHERE face_embedding IS NOT NULL AND array_length(face_embedding, 1) = 512;
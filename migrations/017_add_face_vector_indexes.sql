@@ -0,0 +1,25 @@
+-- Migration: 017_add_face_vector_indexes.sql
+-- Database: PostgreSQL
+-- Description: Índices vetoriais (pgvector, HNSW, distância de cosseno) para a identificação facial 1:N.
+-- Requer pgvector 0.8 ou superior (varredura iterativa usada nas buscas filtradas por tenant e evento); a imagem
+-- docker/postgres já o instala. Com versões anteriores a aplicação busca sem a varredura iterativa.
+
+CREATE EXTENSION IF NOT EXISTS vector;
+
+-- Colunas vetoriais derivadas dos arrays gravados pela aplicação; mantidas pelo banco a cada escrita
+ALTER TABLE employee_face_templates
+    ADD COLUMN IF NOT EXISTS embedding_vector vector(512)
+    GENERATED ALWAYS AS (embedding::vector(512)) STORED;
+
+ALTER TABLE employees
+    ADD COLUMN IF NOT EXISTS face_embedding_vector vector(512)
+    GENERATED ALWAYS AS (CASE WHEN array_length(face_embedding, 1) = 512 THEN face_embedding::vector(512) END) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_employee_face_templates_embedding
+    ON employee_face_templates USING hnsw (embedding_vector vector_cosine_ops);
+
+CREATE INDEX IF NOT EXISTS idx_employees_face_embedding
+    ON employees USING hnsw (face_embedding_vector vector_cosine_ops);
+
+-- Funcionários dos parceiros associados ao evento (identificação no portão)
+CREATE INDEX IF NOT EXISTS idx_partner_employee_employee ON partner_employee(id_employee, id_partner);
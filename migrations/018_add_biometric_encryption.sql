@@ -0,0 +1,52 @@
+-- Migration: 018_add_biometric_encryption.sql
+-- Database: PostgreSQL
+-- Description: Cifragem envelope dos embeddings faciais (LGPD). Cada tenant tem chaves próprias, embrulhadas pela
+-- chave mestra da configuração. Após aplicar, execute `biometric-keys seal` para cifrar os embeddings e as fotos
+-- gravados em texto claro e preencher o índice de busca protegido; até lá, os cadastros antigos não são encontrados
+-- na identificação 1:N.
+
+-- Chaves dos tenants: data cifra embeddings e fotos; search protege o índice vetorial e os digests
+CREATE TABLE IF NOT EXISTS biometric_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL,
+    purpose VARCHAR(20) NOT NULL CHECK (purpose IN ('data', 'search')),
+    version INTEGER NOT NULL CHECK (version > 0),
+    master_key_id VARCHAR(50) NOT NULL, -- Chave mestra que embrulha esta chave
+    wrapped_key BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    retired_at TIMESTAMP, -- Aposentada: só decifra dados antigos
+    UNIQUE (tenant_id, purpose, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_biometric_keys_master_key ON biometric_keys(master_key_id);
+
+-- Embeddings cifrados; as colunas REAL[] ficam apenas com os dados ainda não migrados
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS face_embedding_sealed BYTEA;
+ALTER TABLE employee_face_templates ADD COLUMN IF NOT EXISTS embedding_sealed BYTEA;
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS face_embedding_sealed BYTEA;
+
+ALTER TABLE employee_face_templates DROP CONSTRAINT IF EXISTS employee_face_templates_embedding_check;
+ALTER TABLE employee_face_templates ALTER COLUMN embedding DROP NOT NULL;
+
+-- O índice vetorial deixa de ser derivado do texto claro: a aplicação grava o vetor transformado pela chave de busca do tenant
+DROP INDEX IF EXISTS idx_employee_face_templates_embedding;
+DROP INDEX IF EXISTS idx_employees_face_embedding;
+
+ALTER TABLE employee_face_templates DROP COLUMN IF EXISTS embedding_vector;
+ALTER TABLE employee_face_templates ADD COLUMN embedding_vector vector(512);
+
+ALTER TABLE employees DROP COLUMN IF EXISTS face_embedding_vector;
+ALTER TABLE employees ADD COLUMN face_embedding_vector vector(512);
+
+CREATE INDEX IF NOT EXISTS idx_employee_face_templates_embedding
+    ON employee_face_templates USING hnsw (embedding_vector vector_cosine_ops);
+
+CREATE INDEX IF NOT EXISTS idx_employees_face_embedding
+    ON employees USING hnsw (face_embedding_vector vector_cosine_ops);
+
+-- Embeddings idênticos entre check-ins: comparados pelo HMAC, sem abrir os dados cifrados
+ALTER TABLE checkin ADD COLUMN IF NOT EXISTS face_embedding_digest BYTEA;
+
+DROP INDEX IF EXISTS idx_checkin_face_embedding_time;
+CREATE INDEX IF NOT EXISTS idx_checkin_face_embedding_digest ON checkin(id_tenant, face_embedding_digest, checkin_time DESC)
+    WHERE face_embedding_digest IS NOT NULL AND voided_at IS NULL;
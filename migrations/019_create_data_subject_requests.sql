@@ -0,0 +1,25 @@
+-- Migration: 019_create_data_subject_requests.sql
+-- Database: PostgreSQL
+-- Description: Registro das solicitações dos titulares de dados (LGPD, art. 18): exportação e eliminação dos dados
+-- de funcionários e contatos de parceiros, com a situação e a evidência de atendimento de cada solicitação.
+
+CREATE TABLE IF NOT EXISTS data_subject_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL,
+    subject_type VARCHAR(20) NOT NULL CHECK (subject_type IN ('employee', 'partner')),
+    subject_id UUID NOT NULL, -- Sem chave estrangeira: o registro sobrevive à eliminação do titular
+    request_type VARCHAR(20) NOT NULL CHECK (request_type IN ('export', 'erasure')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('processing', 'completed', 'failed')),
+    reason TEXT,
+    evidence JSONB, -- Registros exportados ou eliminados por categoria, fotos removidas e SHA-256 da exportação
+    failure_reason TEXT,
+    requested_by UUID NOT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_subject_requests_tenant ON data_subject_requests(tenant_id, requested_at DESC);
+CREATE INDEX IF NOT EXISTS idx_data_subject_requests_subject ON data_subject_requests(tenant_id, subject_type, subject_id);
+
+-- Registros de auditoria de um titular, exportados e anonimizados com os seus dados
+CREATE INDEX IF NOT EXISTS idx_audit_log_record_id ON audit_log(id_tenant, record_id);
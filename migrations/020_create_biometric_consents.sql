@@ -0,0 +1,40 @@
+-- Migration: 020_create_biometric_consents.sql
+-- Database: PostgreSQL
+-- Description: Consentimento dos funcionários ao tratamento dos dados biométricos (LGPD, art. 11), com o termo versionado
+-- por tenant. O reconhecimento facial e o cadastro de templates exigem consentimento vigente.
+-- Não há carga inicial: cadastros faciais existentes ficam bloqueados até o funcionário aceitar o termo.
+
+CREATE TABLE IF NOT EXISTS biometric_consent_texts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL,
+    version INTEGER NOT NULL CHECK (version > 0),
+    content TEXT NOT NULL,
+    digest VARCHAR(64) NOT NULL, -- SHA-256 do texto
+    published_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_by UUID,
+    UNIQUE (tenant_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS employee_biometric_consents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    text_version INTEGER NOT NULL,
+    text_digest VARCHAR(64) NOT NULL,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('kiosk', 'mobile_app', 'web', 'paper')),
+    granted_at TIMESTAMP NOT NULL,
+    granted_by UUID,
+    revoked_at TIMESTAMP,
+    revoked_by UUID,
+    revocation_channel VARCHAR(20) CHECK (revocation_channel IN ('kiosk', 'mobile_app', 'web', 'paper', 'system')),
+    revocation_reason VARCHAR(500), -- superseded e erasure são gravados pelo sistema
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (employee_id) REFERENCES employees(id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id, text_version) REFERENCES biometric_consent_texts(tenant_id, version)
+);
+
+-- No máximo um consentimento vigente por funcionário
+CREATE UNIQUE INDEX IF NOT EXISTS idx_employee_biometric_consents_active
+    ON employee_biometric_consents(employee_id) WHERE revoked_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_employee_biometric_consents_employee ON employee_biometric_consents(employee_id, granted_at DESC);
+CREATE INDEX IF NOT EXISTS idx_employee_biometric_consents_tenant ON employee_biometric_consents(tenant_id);
@@ -0,0 +1,24 @@
+-- Migration: 021_create_data_retention_policies.sql
+-- Database: PostgreSQL
+-- Description: Prazos de retenção por tenant e classe de dados, contados a partir do fim de cada evento.
+-- O job de retenção limpa biometria, fotos e localização das presenças (as linhas de checkin e checkout são mantidas
+-- para o histórico das sessões de trabalho) e remove event_log e audit_log vencidos. Cada execução é registrada em
+-- audit_log com table_name = 'data_retention'. Classes sem política não são eliminadas.
+
+CREATE TABLE IF NOT EXISTS data_retention_policies (
+    tenant_id UUID NOT NULL,
+    data_class VARCHAR(30) NOT NULL CHECK (data_class IN ('biometrics', 'photos', 'checkin_locations', 'logs')),
+    retention_days INTEGER NOT NULL CHECK (retention_days BETWEEN 1 AND 3650),
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_by UUID,
+    PRIMARY KEY (tenant_id, data_class)
+);
+
+-- Eventos encerrados do tenant, ponto de partida dos prazos
+CREATE INDEX IF NOT EXISTS idx_events_tenant_final_date ON events(tenant_id, final_date);
+
+-- Lotes de eliminação por tenant e evento
+CREATE INDEX IF NOT EXISTS idx_checkin_tenant_event ON checkin(id_tenant, id_event);
+CREATE INDEX IF NOT EXISTS idx_checkout_tenant_event ON checkout(id_tenant, id_event);
+CREATE INDEX IF NOT EXISTS idx_event_log_tenant_event ON event_log(id_tenant, id_event);
+CREATE INDEX IF NOT EXISTS idx_audit_log_tenant_created_at ON audit_log(id_tenant, created_at);
@@ -1,6 +1,14 @@
 #!/bin/bash
 
 # Script para executar migrações do banco de dados
+# Aplica, em ordem, os arquivos de migrations/ ainda não registrados na tabela schema_migrations.
+# Cada migração roda em uma transação junto com o seu registro: uma falha não deixa migração aplicada pela metade.
+#
+# Uso:
+#   ./scripts/migrate.sh                 aplica as migrações pendentes
+#   ./scripts/migrate.sh status          lista as migrações aplicadas e pendentes
+#   ./scripts/migrate.sh baseline NNN    registra as migrações até NNN como aplicadas, sem executá-las
+#                                        (bancos criados antes do controle de versões)
 
 set -e
 
@@ -10,6 +18,26 @@ DB_PORT=${DB_PORT:-5432}
 DB_NAME=${DB_NAME:-eventos_db}
 DB_USER=${DB_USER:-eventos_user}
 DB_PASSWORD=${DB_PASSWORD:-eventos_password}
+MIGRATIONS_DIR=${MIGRATIONS_DIR:-migrations}
+
+COMMAND=${1:-up}
+
+export PGPASSWORD=$DB_PASSWORD
+
+# psql no banco da aplicação, interrompendo no primeiro erro
+run_psql() {
+    psql -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d "$DB_NAME" -v ON_ERROR_STOP=1 -q "$@"
+}
+
+# Versão da migração: o prefixo numérico do arquivo (001_create_database_schema.sql -> 001)
+migration_version() {
+    basename "$1" | cut -d_ -f1
+}
+
+# Verifica se a versão já está registrada em schema_migrations
+is_applied() {
+    [ -n "$(run_psql -tAc "SELECT 1 FROM schema_migrations WHERE version = '$1';")" ]
+}
 
 echo "Executando migrações do banco de dados..."
 echo "Host: $DB_HOST:$DB_PORT"
@@ -18,9 +46,7 @@ echo "User: $DB_USER"
 
 # Verificar se o PostgreSQL está acessível
 echo "Verificando conexão com o banco de dados..."
-PGPASSWORD=$DB_PASSWORD psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d postgres -c "SELECT 1;" > /dev/null 2>&1
-
-if [ $? -ne 0 ]; then
+if ! psql -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d postgres -c "SELECT 1;" > /dev/null 2>&1; then
     echo "Erro: Não foi possível conectar ao PostgreSQL."
     echo "Verifique se o serviço está rodando e as credenciais estão corretas."
     exit 1
@@ -28,25 +54,80 @@ fi
 
 # Verificar se o banco de dados existe, se não, criar
 echo "Verificando se o banco de dados existe..."
-PGPASSWORD=$DB_PASSWORD psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d postgres -tc "SELECT 1 FROM pg_database WHERE datname = '$DB_NAME';" | grep -q 1
-
-if [ $? -ne 0 ]; then
+if ! psql -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d postgres -tc "SELECT 1 FROM pg_database WHERE datname = '$DB_NAME';" | grep -q 1; then
     echo "Criando banco de dados $DB_NAME..."
-    PGPASSWORD=$DB_PASSWORD psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d postgres -c "CREATE DATABASE $DB_NAME;"
+    psql -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d postgres -c "CREATE DATABASE $DB_NAME;"
 fi
 
-# Executar migrações
-echo "Executando migração: 001_create_database_schema.sql"
-PGPASSWORD=$DB_PASSWORD psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d $DB_NAME -f migrations/001_create_database_schema.sql
+# Controle das migrações aplicadas
+run_psql -c "CREATE TABLE IF NOT EXISTS schema_migrations (
+    version VARCHAR(20) PRIMARY KEY,
+    filename VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);"
 
-if [ $? -eq 0 ]; then
-    echo "Migrações executadas com sucesso!"
-else
-    echo "Erro ao executar migrações!"
+MIGRATIONS=$(ls "$MIGRATIONS_DIR"/[0-9]*.sql 2>/dev/null | sort)
+if [ -z "$MIGRATIONS" ]; then
+    echo "Erro: nenhuma migração encontrada em $MIGRATIONS_DIR"
     exit 1
 fi
 
-echo "Verificando estrutura do banco..."
-PGPASSWORD=$DB_PASSWORD psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d $DB_NAME -c "\dt"
+case "$COMMAND" in
+    up)
+        APPLIED=0
+        for MIGRATION in $MIGRATIONS; do
+            VERSION=$(migration_version "$MIGRATION")
+            if is_applied "$VERSION"; then
+                continue
+            fi
+
+            echo "Executando migração: $(basename "$MIGRATION")"
+            if ! run_psql --single-transaction -f "$MIGRATION" \
+                -c "INSERT INTO schema_migrations (version, filename) VALUES ('$VERSION', '$(basename "$MIGRATION")');"; then
+                echo "Erro ao executar a migração $(basename "$MIGRATION")!"
+                exit 1
+            fi
+            APPLIED=$((APPLIED + 1))
+        done
+
+        if [ $APPLIED -eq 0 ]; then
+            echo "Banco de dados já está atualizado."
+        else
+            echo "$APPLIED migração(ões) executada(s) com sucesso!"
+        fi
+        ;;
+
+    status)
+        for MIGRATION in $MIGRATIONS; do
+            if is_applied "$(migration_version "$MIGRATION")"; then
+                echo "  [aplicada] $(basename "$MIGRATION")"
+            else
+                echo "  [pendente] $(basename "$MIGRATION")"
+            fi
+        done
+        ;;
+
+    baseline)
+        TARGET=$2
+        if [ -z "$TARGET" ]; then
+            echo "Uso: $0 baseline NNN"
+            exit 1
+        fi
+
+        for MIGRATION in $MIGRATIONS; do
+            VERSION=$(migration_version "$MIGRATION")
+            if [ "$((10#$VERSION))" -gt "$((10#$TARGET))" ]; then
+                break
+            fi
+            run_psql -c "INSERT INTO schema_migrations (version, filename) VALUES ('$VERSION', '$(basename "$MIGRATION")') ON CONFLICT (version) DO NOTHING;"
+            echo "  [registrada] $(basename "$MIGRATION")"
+        done
+        ;;
+
+    *)
+        echo "Comando desconhecido: $COMMAND (use up, status ou baseline)"
+        exit 1
+        ;;
+esac
 
 echo "Migração concluída!"
@@ -0,0 +1,666 @@
+package checkin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	. "eventos-backend/internal/domain/checkin"
+	"eventos-backend/internal/domain/employee"
+	"eventos-backend/internal/domain/event"
+	"eventos-backend/internal/domain/partner"
+	"eventos-backend/internal/domain/qrcode"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/domain/storage"
+	"eventos-backend/internal/domain/tenant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// checkinRepoStub implementa apenas os métodos de Repository usados pelo serviço
+type checkinRepoStub struct {
+	Repository
+	open        *Checkin
+	created     []*Checkin
+	byID        map[value_objects.UUID]*Checkin
+	hasCheckout bool
+	createErr   error
+}
+
+func (r *checkinRepoStub) GetByID(ctx context.Context, id value_objects.UUID) (*Checkin, error) {
+	if c, ok := r.byID[id]; ok {
+		return c, nil
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *checkinRepoStub) Update(ctx context.Context, checkin *Checkin) error {
+	r.byID[checkin.ID] = checkin
+	return nil
+}
+
+func (r *checkinRepoStub) HasActiveCheckout(ctx context.Context, checkinID value_objects.UUID) (bool, error) {
+	return r.hasCheckout, nil
+}
+
+func (r *checkinRepoStub) ExistsReplacement(ctx context.Context, voidedID value_objects.UUID) (bool, error) {
+	for _, c := range r.created {
+		if c.ReplacesID != nil && c.ReplacesID.Equals(voidedID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *checkinRepoStub) GetOpenByEmployeeAndEvent(ctx context.Context, employeeID, eventID value_objects.UUID) (*Checkin, error) {
+	if r.open == nil {
+		return nil, errors.ErrNotFound
+	}
+	return r.open, nil
+}
+
+func (r *checkinRepoStub) Create(ctx context.Context, checkin *Checkin) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	r.created = append(r.created, checkin)
+	return nil
+}
+
+// employeeRepoStub implementa apenas os métodos de employee.Repository usados pelo serviço
+type employeeRepoStub struct {
+	employee.Repository
+	employee *employee.Employee
+	enrolled []float32 // Embedding cadastrado, entregue apenas por OpenFaceEmbedding
+	opened   int
+	matches  []*employee.EventFaceMatch
+}
+
+func (r *employeeRepoStub) GetByID(ctx context.Context, id value_objects.UUID) (*employee.Employee, error) {
+	return r.employee, nil
+}
+
+func (r *employeeRepoStub) OpenFaceEmbedding(ctx context.Context, emp *employee.Employee) ([]float32, error) {
+	r.opened++
+	return r.enrolled, nil
+}
+
+func (r *employeeRepoStub) OpenFaceTemplates(ctx context.Context, employeeID value_objects.UUID) ([]*employee.FaceTemplate, error) {
+	return nil, nil
+}
+
+func (r *employeeRepoStub) FindByFaceEmbeddingInEvent(ctx context.Context, tenantID, eventID value_objects.UUID, embedding []float32, threshold float32, limit int) ([]*employee.EventFaceMatch, error) {
+	var matches []*employee.EventFaceMatch
+	for _, match := range r.matches {
+		if match.Similarity >= threshold && len(matches) < limit {
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+// eventRepoStub implementa apenas os métodos de event.Repository usados pelo serviço
+type eventRepoStub struct {
+	event.Repository
+	evt *event.Event
+}
+
+func (r *eventRepoStub) GetByID(ctx context.Context, id value_objects.UUID) (*event.Event, error) {
+	return r.evt, nil
+}
+
+// partnerRepoStub implementa apenas os métodos de partner.Repository usados pelo serviço
+type partnerRepoStub struct {
+	partner.Repository
+	partner  *partner.Partner
+	linked   bool
+	assigned bool
+}
+
+func (r *partnerRepoStub) GetByID(ctx context.Context, id value_objects.UUID) (*partner.Partner, error) {
+	return r.partner, nil
+}
+
+func (r *partnerRepoStub) IsEmployeeLinked(ctx context.Context, partnerID, employeeID value_objects.UUID) (bool, error) {
+	return r.linked, nil
+}
+
+func (r *partnerRepoStub) IsAssignedToEvent(ctx context.Context, partnerID, eventID value_objects.UUID) (bool, error) {
+	return r.assigned, nil
+}
+
+// tenantRepoStub implementa apenas os métodos de tenant.Repository usados pelo serviço
+type tenantRepoStub struct {
+	tenant.Repository
+	tenant *tenant.Tenant
+}
+
+func (r *tenantRepoStub) GetByID(ctx context.Context, id value_objects.UUID) (*tenant.Tenant, error) {
+	return r.tenant, nil
+}
+
+// photoStorageStub registra as fotos gravadas sem validar o conteúdo
+type photoStorageStub struct {
+	storage.Service
+	stored int
+}
+
+func (p *photoStorageStub) StorePhoto(ctx context.Context, upload storage.Upload) (*storage.Object, error) {
+	p.stored++
+	key := storage.ObjectKey(upload.TenantID, upload.Category, upload.OwnerID, upload.ContentType)
+	return &storage.Object{Key: key, ContentType: upload.ContentType, Size: upload.Size}, nil
+}
+
+// qrServiceStub aceita qualquer token e registra os usos devolvidos
+type qrServiceStub struct {
+	qrcode.Service
+	qr       *qrcode.QRCode
+	released []value_objects.UUID
+}
+
+func (s *qrServiceStub) ConsumeQRCode(ctx context.Context, token string, eventID value_objects.UUID, qrType string, scannedAt time.Time) (*qrcode.QRCode, error) {
+	return s.qr, nil
+}
+
+func (s *qrServiceStub) ReleaseQRCode(ctx context.Context, qrCodeID value_objects.UUID) {
+	s.released = append(s.released, qrCodeID)
+}
+
+// ServiceTestSuite é a suíte de testes para o serviço de check-in
+type ServiceTestSuite struct {
+	suite.Suite
+	employee    *employee.Employee
+	employees   *employeeRepoStub
+	event       *event.Event
+	checkinRepo *checkinRepoStub
+	partnerRepo *partnerRepoStub
+	photos      *photoStorageStub
+	qr          *qrServiceStub
+	tenant      *tenant.Tenant
+	service     Service
+}
+
+func TestServiceSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
+
+func (suite *ServiceTestSuite) SetupTest() {
+	suite.employee = &employee.Employee{
+		ID:                  value_objects.NewUUID(),
+		TenantID:            value_objects.NewUUID(),
+		FullName:            "João da Silva",
+		FaceEnrolled:        true,
+		SealedFaceEmbedding: []byte("sealed"),
+		Active:              true,
+	}
+	suite.employee.Consent = &employee.BiometricConsent{EmployeeID: suite.employee.ID, TenantID: suite.employee.TenantID, TextVersion: 1}
+	suite.event = &event.Event{
+		ID:          value_objects.NewUUID(),
+		TenantID:    suite.employee.TenantID,
+		InitialDate: time.Now().UTC().Add(-time.Hour),
+		FinalDate:   time.Now().UTC().Add(time.Hour),
+		Active:      true,
+	}
+	suite.partnerRepo = &partnerRepoStub{
+		partner:  &partner.Partner{ID: value_objects.NewUUID(), TenantID: suite.employee.TenantID, Active: true},
+		linked:   true,
+		assigned: true,
+	}
+	suite.tenant = &tenant.Tenant{ID: suite.employee.TenantID, Active: true}
+	suite.checkinRepo = &checkinRepoStub{byID: make(map[value_objects.UUID]*Checkin)}
+	suite.photos = &photoStorageStub{}
+	suite.employees = &employeeRepoStub{employee: suite.employee, enrolled: embedding(0)}
+	suite.qr = &qrServiceStub{qr: &qrcode.QRCode{ID: value_objects.NewUUID(), EventID: suite.event.ID, ValidUntil: time.Now().UTC().Add(time.Minute)}}
+	suite.service = NewService(
+		suite.checkinRepo,
+		nil,
+		suite.employees,
+		&eventRepoStub{evt: suite.event},
+		suite.partnerRepo,
+		&tenantRepoStub{tenant: suite.tenant},
+		suite.qr,
+		nil,
+		nil,
+		suite.photos,
+		Config{FacialSimilarityThreshold: 0.8},
+	)
+}
+
+// embedding gera um embedding de teste com um componente dominante na posição informada
+func embedding(dominant int) []float32 {
+	values := make([]float32, constants.FaceEmbeddingDimensions)
+	for i := range values {
+		values[i] = 0.01
+	}
+	values[dominant] = 0.9
+	return values
+}
+
+func (suite *ServiceTestSuite) TestValidateFacialRecognition_Match() {
+	// Arrange
+	checkin := &Checkin{EmployeeID: suite.employee.ID, Method: constants.CheckMethodFacialRecognition}
+
+	// Act
+	result, err := suite.service.ValidateFacialRecognition(context.Background(), checkin, embedding(0))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), result.IsValid)
+	assert.NotNil(suite.T(), result.FacialSimilarity)
+	assert.Equal(suite.T(), "high", result.Details["confidence_level"])
+	assert.Equal(suite.T(), 1, suite.employees.opened)
+}
+
+func (suite *ServiceTestSuite) TestValidateFacialRecognition_Mismatch() {
+	// Arrange
+	checkin := &Checkin{EmployeeID: suite.employee.ID, Method: constants.CheckMethodFacialRecognition}
+
+	// Act
+	result, err := suite.service.ValidateFacialRecognition(context.Background(), checkin, embedding(1))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), result.IsValid)
+	assert.Less(suite.T(), *result.FacialSimilarity, 0.8)
+}
+
+func (suite *ServiceTestSuite) TestValidateFacialRecognition_WithoutEnrollment() {
+	// Arrange
+	suite.employee.FaceEnrolled = false
+	suite.employee.SealedFaceEmbedding = nil
+	checkin := &Checkin{EmployeeID: suite.employee.ID, Method: constants.CheckMethodFacialRecognition}
+
+	// Act
+	result, err := suite.service.ValidateFacialRecognition(context.Background(), checkin, embedding(0))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), result.IsValid)
+	assert.Nil(suite.T(), result.FacialSimilarity)
+}
+
+func (suite *ServiceTestSuite) TestValidateFacialRecognition_WithoutConsentDiscardsEmbedding() {
+	// Arrange
+	suite.employee.Consent = nil
+	checkin := &Checkin{EmployeeID: suite.employee.ID, Method: constants.CheckMethodFacialRecognition, FaceEmbedding: embedding(0)}
+
+	// Act
+	result, err := suite.service.ValidateFacialRecognition(context.Background(), checkin, embedding(0))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), result.IsValid)
+	assert.Equal(suite.T(), false, result.Details["biometric_consent"])
+	assert.Nil(suite.T(), checkin.FaceEmbedding)
+	assert.Zero(suite.T(), suite.employees.opened)
+}
+
+func (suite *ServiceTestSuite) TestValidateFacialRecognition_InvalidDimensions() {
+	// Arrange
+	checkin := &Checkin{EmployeeID: suite.employee.ID, Method: constants.CheckMethodFacialRecognition}
+
+	// Act
+	result, err := suite.service.ValidateFacialRecognition(context.Background(), checkin, []float32{0.1, 0.2})
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+}
+
+func (suite *ServiceTestSuite) TestCanEmployeeCheckin_Eligible() {
+	// Act
+	canCheckin, reasonCode, err := suite.service.CanEmployeeCheckin(context.Background(), suite.employee.TenantID, suite.employee.ID, suite.event.ID, suite.partnerRepo.partner.ID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), canCheckin)
+	assert.Empty(suite.T(), reasonCode)
+}
+
+func (suite *ServiceTestSuite) TestCanEmployeeCheckin_ReasonCodes() {
+	testCases := []struct {
+		name     string
+		arrange  func()
+		expected string
+	}{
+		{"funcionário inativo", func() { suite.employee.Active = false }, constants.EligibilityEmployeeInactive},
+		{"evento inativo", func() { suite.event.Active = false }, constants.EligibilityEventInactive},
+		{"evento futuro", func() { suite.event.InitialDate = time.Now().UTC().Add(time.Minute) }, constants.EligibilityEventNotStarted},
+		{"evento encerrado", func() { suite.event.FinalDate = time.Now().UTC().Add(-time.Minute) }, constants.EligibilityEventFinished},
+		{"evento de outro tenant", func() { suite.event.TenantID = value_objects.NewUUID() }, constants.EligibilityTenantMismatch},
+		{"parceiro de outro tenant", func() { suite.partnerRepo.partner.TenantID = value_objects.NewUUID() }, constants.EligibilityTenantMismatch},
+		{"funcionário sem vínculo", func() { suite.partnerRepo.linked = false }, constants.EligibilityEmployeeNotInPartner},
+		{"parceiro fora do evento", func() { suite.partnerRepo.assigned = false }, constants.EligibilityPartnerNotInEvent},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			// Arrange
+			suite.SetupTest()
+			tc.arrange()
+
+			// Act
+			canCheckin, reasonCode, err := suite.service.CanEmployeeCheckin(context.Background(), suite.employee.TenantID, suite.employee.ID, suite.event.ID, suite.partnerRepo.partner.ID)
+
+			// Assert
+			assert.NoError(suite.T(), err)
+			assert.False(suite.T(), canCheckin)
+			assert.Equal(suite.T(), tc.expected, reasonCode)
+		})
+	}
+}
+
+func (suite *ServiceTestSuite) TestPerformCheckin_RejectsCallerFromAnotherTenant() {
+	// Arrange
+	request := suite.manualCheckinRequest()
+	request.TenantID = value_objects.NewUUID()
+
+	// Act
+	created, _, err := suite.service.PerformCheckin(context.Background(), request)
+
+	// Assert
+	suite.Require().Error(err)
+	assert.Nil(suite.T(), created)
+	domainErr, ok := err.(*errors.DomainError)
+	suite.Require().True(ok)
+	assert.Equal(suite.T(), constants.EligibilityTenantMismatch, domainErr.Context["reason_code"])
+	assert.Empty(suite.T(), suite.checkinRepo.created)
+}
+
+func (suite *ServiceTestSuite) manualCheckinRequest() CheckinRequest {
+	return CheckinRequest{
+		TenantID:   suite.employee.TenantID,
+		EventID:    suite.event.ID,
+		EmployeeID: suite.employee.ID,
+		PartnerID:  suite.partnerRepo.partner.ID,
+		Method:     constants.CheckMethodManual,
+		CreatedBy:  value_objects.NewUUID(),
+	}
+}
+
+func (suite *ServiceTestSuite) TestPerformCheckin_MockLocationFollowsTenantPolicy() {
+	// Arrange
+	fix, _ := value_objects.NewLocationFix(8, nil, nil, value_objects.LocationProviderGPS, true)
+	request := suite.manualCheckinRequest()
+	request.LocationFix = fix
+
+	// Act
+	allowed, _, allowedErr := suite.service.PerformCheckin(context.Background(), request)
+	suite.tenant.RejectMockLocations = true
+	rejected, result, rejectedErr := suite.service.PerformCheckin(context.Background(), request)
+
+	// Assert
+	suite.Require().NoError(allowedErr)
+	suite.Require().NoError(rejectedErr)
+	assert.Equal(suite.T(), constants.ApprovalStatusPending, allowed.ApprovalStatus)
+	assert.Equal(suite.T(), true, allowed.ValidationDetails["location_mock"])
+	assert.False(suite.T(), rejected.IsValid)
+	assert.False(suite.T(), result.IsValid)
+	assert.Equal(suite.T(), true, rejected.ValidationDetails["mock_location_rejected"])
+	assert.Same(suite.T(), fix, rejected.LocationFix)
+}
+
+func (suite *ServiceTestSuite) TestPerformCheckin_MissingLocationFailsEventFence() {
+	// Arrange
+	suite.event.FenceEvent = []value_objects.Location{
+		{Latitude: -23.5500, Longitude: -46.6340},
+		{Latitude: -23.5500, Longitude: -46.6330},
+		{Latitude: -23.5510, Longitude: -46.6330},
+	}
+	request := suite.manualCheckinRequest()
+	request.Location = value_objects.Location{}
+
+	// Act
+	created, result, err := suite.service.PerformCheckin(context.Background(), request)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.False(suite.T(), created.IsValid)
+	assert.False(suite.T(), result.IsValid)
+	assert.Equal(suite.T(), true, created.ValidationDetails["location_missing"])
+}
+
+func (suite *ServiceTestSuite) TestPerformCheckin_ReleasesQRCodeWhenNotSaved() {
+	// Arrange
+	request := suite.manualCheckinRequest()
+	request.Method = constants.CheckMethodQRCode
+	request.QRCodeData = "token"
+	suite.checkinRepo.createErr = fmt.Errorf("connection reset")
+
+	// Act
+	created, _, err := suite.service.PerformCheckin(context.Background(), request)
+
+	// Assert
+	suite.Require().Error(err)
+	assert.Nil(suite.T(), created)
+	assert.Equal(suite.T(), []value_objects.UUID{suite.qr.qr.ID}, suite.qr.released)
+}
+
+func (suite *ServiceTestSuite) TestPerformCheckin_BlockedWhileSessionOpen() {
+	// Arrange
+	suite.checkinRepo.open = &Checkin{ID: value_objects.NewUUID(), EmployeeID: suite.employee.ID, EventID: suite.event.ID}
+
+	// Act
+	created, _, err := suite.service.PerformCheckin(context.Background(), suite.manualCheckinRequest())
+
+	// Assert
+	suite.Require().Error(err)
+	assert.Nil(suite.T(), created)
+	domainErr, ok := err.(*errors.DomainError)
+	suite.Require().True(ok)
+	assert.Equal(suite.T(), "ALREADY_EXISTS", domainErr.Type)
+	assert.Equal(suite.T(), constants.EligibilitySessionAlreadyOpen, domainErr.Context["reason_code"])
+	assert.Equal(suite.T(), suite.checkinRepo.open.ID.String(), domainErr.Context["open_checkin_id"])
+	assert.Empty(suite.T(), suite.checkinRepo.created)
+}
+
+func (suite *ServiceTestSuite) TestPerformCheckin_SessionOpenedConcurrentlyIsRejected() {
+	// Arrange - outra requisição abriu a sessão depois da verificação do serviço; a gravação a detecta sob o lock
+	openID := value_objects.NewUUID().String()
+	suite.checkinRepo.createErr = errors.NewAlreadyExistsError("Checkin", "open_session", "employee-event").
+		WithContext("open_checkin_id", openID)
+
+	// Act
+	created, _, err := suite.service.PerformCheckin(context.Background(), suite.manualCheckinRequest())
+
+	// Assert
+	suite.Require().Error(err)
+	assert.Nil(suite.T(), created)
+	domainErr, ok := err.(*errors.DomainError)
+	suite.Require().True(ok)
+	assert.Equal(suite.T(), "ALREADY_EXISTS", domainErr.Type)
+	assert.Equal(suite.T(), constants.EligibilitySessionAlreadyOpen, domainErr.Context["reason_code"])
+	assert.Equal(suite.T(), openID, domainErr.Context["open_checkin_id"])
+}
+
+func (suite *ServiceTestSuite) TestPerformCheckin_ReentryAfterClosedSession() {
+	// Arrange - sessões anteriores já possuem check-out, não há sessão aberta
+
+	// Act
+	first, _, firstErr := suite.service.PerformCheckin(context.Background(), suite.manualCheckinRequest())
+	second, _, secondErr := suite.service.PerformCheckin(context.Background(), suite.manualCheckinRequest())
+
+	// Assert
+	suite.Require().NoError(firstErr)
+	suite.Require().NoError(secondErr)
+	assert.NotEqual(suite.T(), first.ID, second.ID)
+	assert.Len(suite.T(), suite.checkinRepo.created, 2)
+}
+
+func (suite *ServiceTestSuite) TestVoidCheckin_RequiresCheckoutVoidedFirst() {
+	// Arrange
+	existing := &Checkin{ID: value_objects.NewUUID(), TenantID: suite.employee.TenantID}
+	suite.checkinRepo.byID[existing.ID] = existing
+	suite.checkinRepo.hasCheckout = true
+
+	// Act
+	voided, err := suite.service.VoidCheckin(context.Background(), existing.ID, suite.employee.TenantID, "funcionário errado", value_objects.NewUUID())
+
+	// Assert
+	suite.Require().Error(err)
+	assert.Nil(suite.T(), voided)
+	assert.Equal(suite.T(), constants.EligibilityCheckinHasCheckout, err.(*errors.DomainError).Context["reason_code"])
+	assert.False(suite.T(), existing.IsVoided())
+}
+
+func (suite *ServiceTestSuite) TestVoidCheckin_AllowsSingleReplacement() {
+	// Arrange
+	original, _, err := suite.service.PerformCheckin(context.Background(), suite.manualCheckinRequest())
+	suite.Require().NoError(err)
+	suite.checkinRepo.byID[original.ID] = original
+	request := suite.manualCheckinRequest()
+	request.ReplacesID = &original.ID
+
+	// Act
+	voided, voidErr := suite.service.VoidCheckin(context.Background(), original.ID, suite.employee.TenantID, "funcionário errado", value_objects.NewUUID())
+	replacement, _, replaceErr := suite.service.PerformCheckin(context.Background(), request)
+	_, _, secondErr := suite.service.PerformCheckin(context.Background(), request)
+
+	// Assert
+	suite.Require().NoError(voidErr)
+	suite.Require().NoError(replaceErr)
+	assert.Equal(suite.T(), CheckinStatusVoided, voided.GetStatus())
+	assert.Equal(suite.T(), "funcionário errado", voided.VoidReason)
+	assert.Equal(suite.T(), original.ID, *replacement.ReplacesID)
+	suite.Require().Error(secondErr)
+	assert.Equal(suite.T(), constants.EligibilityReplacementInvalid, secondErr.(*errors.DomainError).Context["reason_code"])
+}
+
+func (suite *ServiceTestSuite) TestPerformCheckin_ManualAwaitsApproval() {
+	// Act
+	created, result, err := suite.service.PerformCheckin(context.Background(), suite.manualCheckinRequest())
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.True(suite.T(), result.IsValid)
+	assert.False(suite.T(), created.IsValid)
+	assert.True(suite.T(), created.IsPendingApproval())
+	assert.Equal(suite.T(), CheckinStatusPending, created.GetStatus())
+	assert.Equal(suite.T(), constants.ApprovalReasonManual, created.ValidationDetails["approval_reason"])
+}
+
+func (suite *ServiceTestSuite) TestReviewCheckin_ApproveAndReject() {
+	// Arrange
+	approved, _, err := suite.service.PerformCheckin(context.Background(), suite.manualCheckinRequest())
+	suite.Require().NoError(err)
+	rejected, _, err := suite.service.PerformCheckin(context.Background(), suite.manualCheckinRequest())
+	suite.Require().NoError(err)
+	suite.checkinRepo.byID[approved.ID] = approved
+	suite.checkinRepo.byID[rejected.ID] = rejected
+	supervisor := value_objects.NewUUID()
+
+	// Act
+	_, approveErr := suite.service.ApproveCheckin(context.Background(), approved.ID, suite.employee.TenantID, "conferido na portaria", supervisor)
+	_, missingCommentErr := suite.service.RejectCheckin(context.Background(), rejected.ID, suite.employee.TenantID, "", supervisor)
+	_, rejectErr := suite.service.RejectCheckin(context.Background(), rejected.ID, suite.employee.TenantID, "funcionário não estava presente", supervisor)
+	_, reviewAgainErr := suite.service.ApproveCheckin(context.Background(), rejected.ID, suite.employee.TenantID, "", supervisor)
+
+	// Assert
+	suite.Require().NoError(approveErr)
+	assert.True(suite.T(), approved.IsValid)
+	assert.Equal(suite.T(), constants.ApprovalStatusApproved, approved.ApprovalStatus)
+	assert.Equal(suite.T(), supervisor, *approved.ReviewedBy)
+
+	suite.Require().Error(missingCommentErr)
+	suite.Require().NoError(rejectErr)
+	assert.False(suite.T(), rejected.IsValid)
+	assert.Equal(suite.T(), CheckinStatusInvalid, rejected.GetStatus())
+	assert.Equal(suite.T(), "funcionário não estava presente", rejected.ReviewComment)
+
+	suite.Require().Error(reviewAgainErr)
+	assert.Equal(suite.T(), constants.EligibilityCheckinNotPending, reviewAgainErr.(*errors.DomainError).Context["reason_code"])
+}
+
+func (suite *ServiceTestSuite) TestAttachPhoto_EvidenceIsImmutable() {
+	// Arrange
+	existing := &Checkin{ID: value_objects.NewUUID(), TenantID: suite.employee.TenantID}
+	suite.checkinRepo.byID[existing.ID] = existing
+	photo := func() io.Reader { return bytes.NewReader([]byte{0xFF, 0xD8, 0xFF, 0xE0}) }
+
+	// Act
+	attached, err := suite.service.AttachPhoto(context.Background(), existing.ID, suite.employee.TenantID, "image/jpeg", 4, photo(), value_objects.NewUUID())
+	_, againErr := suite.service.AttachPhoto(context.Background(), existing.ID, suite.employee.TenantID, "image/jpeg", 4, photo(), value_objects.NewUUID())
+	_, otherTenantErr := suite.service.AttachPhoto(context.Background(), existing.ID, value_objects.NewUUID(), "image/jpeg", 4, photo(), value_objects.NewUUID())
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.True(suite.T(), storage.BelongsToTenant(attached.PhotoKey, suite.employee.TenantID))
+	assert.True(suite.T(), attached.HasPhoto())
+	assert.Equal(suite.T(), "ALREADY_EXISTS", againErr.(*errors.DomainError).Type)
+	assert.Equal(suite.T(), "NOT_FOUND", otherTenantErr.(*errors.DomainError).Type)
+	assert.Equal(suite.T(), 1, suite.photos.stored)
+}
+
+func (suite *ServiceTestSuite) walkUpRequest() WalkUpRequest {
+	return WalkUpRequest{
+		TenantID:      suite.employee.TenantID,
+		EventID:       suite.event.ID,
+		FaceEmbedding: embedding(0),
+		CreatedBy:     value_objects.NewUUID(),
+	}
+}
+
+func (suite *ServiceTestSuite) TestPerformWalkUpCheckin_IdentifiesEmployee() {
+	// Arrange
+	suite.employees.matches = []*employee.EventFaceMatch{
+		{Employee: suite.employee, PartnerID: suite.partnerRepo.partner.ID, Similarity: 0.97},
+	}
+
+	// Act
+	created, result, err := suite.service.PerformWalkUpCheckin(context.Background(), suite.walkUpRequest())
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.True(suite.T(), result.IsValid)
+	assert.Equal(suite.T(), suite.employee.ID, created.EmployeeID)
+	assert.Equal(suite.T(), suite.partnerRepo.partner.ID, created.PartnerID)
+	assert.Equal(suite.T(), constants.CheckMethodFacialRecognition, created.Method)
+}
+
+func (suite *ServiceTestSuite) TestPerformWalkUpCheckin_RejectsUnknownAndAmbiguousFaces() {
+	// Arrange
+	other := &employee.Employee{ID: value_objects.NewUUID(), TenantID: suite.employee.TenantID, Active: true}
+	ambiguous := []*employee.EventFaceMatch{
+		{Employee: suite.employee, PartnerID: suite.partnerRepo.partner.ID, Similarity: 0.91},
+		{Employee: other, PartnerID: suite.partnerRepo.partner.ID, Similarity: 0.89},
+	}
+
+	// Act
+	_, _, unknownErr := suite.service.PerformWalkUpCheckin(context.Background(), suite.walkUpRequest())
+	suite.employees.matches = ambiguous
+	_, _, ambiguousErr := suite.service.PerformWalkUpCheckin(context.Background(), suite.walkUpRequest())
+
+	// Assert
+	suite.Require().Error(unknownErr)
+	suite.Require().Error(ambiguousErr)
+	assert.Equal(suite.T(), constants.EligibilityFaceNotRecognized, unknownErr.(*errors.DomainError).Context["reason_code"])
+	assert.Equal(suite.T(), constants.EligibilityFaceAmbiguous, ambiguousErr.(*errors.DomainError).Context["reason_code"])
+	assert.Empty(suite.T(), suite.checkinRepo.created)
+}
+
+func (suite *ServiceTestSuite) TestPerformWalkUpCheckin_NearTieBelowThresholdIsAmbiguous() {
+	// Arrange - o segundo candidato fica abaixo do limite (0.8), mas dentro da margem do melhor
+	other := &employee.Employee{ID: value_objects.NewUUID(), TenantID: suite.employee.TenantID, Active: true}
+	suite.employees.matches = []*employee.EventFaceMatch{
+		{Employee: suite.employee, PartnerID: suite.partnerRepo.partner.ID, Similarity: 0.82},
+		{Employee: other, PartnerID: suite.partnerRepo.partner.ID, Similarity: 0.79},
+	}
+
+	// Act
+	_, _, nearTieErr := suite.service.PerformWalkUpCheckin(context.Background(), suite.walkUpRequest())
+	suite.employees.matches = suite.employees.matches[1:]
+	_, _, belowThresholdErr := suite.service.PerformWalkUpCheckin(context.Background(), suite.walkUpRequest())
+
+	// Assert
+	suite.Require().Error(nearTieErr)
+	suite.Require().Error(belowThresholdErr)
+	assert.Equal(suite.T(), constants.EligibilityFaceAmbiguous, nearTieErr.(*errors.DomainError).Context["reason_code"])
+	assert.Equal(suite.T(), constants.EligibilityFaceNotRecognized, belowThresholdErr.(*errors.DomainError).Context["reason_code"])
+	assert.Empty(suite.T(), suite.checkinRepo.created)
+}
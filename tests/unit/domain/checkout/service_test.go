@@ -0,0 +1,278 @@
+package checkout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eventos-backend/internal/domain/checkin"
+	. "eventos-backend/internal/domain/checkout"
+	"eventos-backend/internal/domain/employee"
+	"eventos-backend/internal/domain/event"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// checkoutRepoStub implementa apenas os métodos de Repository usados pelo serviço
+type checkoutRepoStub struct {
+	Repository
+	byID    map[value_objects.UUID]*Checkout
+	created []*Checkout
+}
+
+func (r *checkoutRepoStub) Create(ctx context.Context, checkout *Checkout) error {
+	r.created = append(r.created, checkout)
+	r.byID[checkout.ID] = checkout
+	return nil
+}
+
+func (r *checkoutRepoStub) GetByID(ctx context.Context, id value_objects.UUID) (*Checkout, error) {
+	if c, ok := r.byID[id]; ok {
+		return c, nil
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *checkoutRepoStub) Update(ctx context.Context, checkout *Checkout) error {
+	r.byID[checkout.ID] = checkout
+	return nil
+}
+
+// checkinRepoStub implementa apenas os métodos de checkin.Repository usados pelo serviço
+type checkinRepoStub struct {
+	checkin.Repository
+	stale    []*checkin.Checkin
+	embedded []float32
+	opened   int
+}
+
+func (r *checkinRepoStub) OpenFaceEmbedding(ctx context.Context, c *checkin.Checkin) ([]float32, error) {
+	r.opened++
+	return r.embedded, nil
+}
+
+func (r *checkinRepoStub) ListStaleOpenSessions(ctx context.Context, now time.Time, limit int) ([]*checkin.Checkin, error) {
+	return r.stale, nil
+}
+
+func (r *checkinRepoStub) GetByID(ctx context.Context, id value_objects.UUID) (*checkin.Checkin, error) {
+	for _, c := range r.stale {
+		if c.ID.Equals(id) {
+			return c, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+// eventRepoStub implementa apenas os métodos de event.Repository usados pelo serviço
+type eventRepoStub struct {
+	event.Repository
+	evt *event.Event
+}
+
+func (r *eventRepoStub) GetByID(ctx context.Context, id value_objects.UUID) (*event.Event, error) {
+	return r.evt, nil
+}
+
+// employeeRepoStub implementa apenas os métodos de employee.Repository usados pelo serviço
+type employeeRepoStub struct {
+	employee.Repository
+	emp      *employee.Employee
+	enrolled []float32 // Embedding cadastrado, entregue apenas por OpenFaceEmbedding
+	opened   int
+}
+
+func (r *employeeRepoStub) GetByID(ctx context.Context, id value_objects.UUID) (*employee.Employee, error) {
+	return r.emp, nil
+}
+
+func (r *employeeRepoStub) OpenFaceEmbedding(ctx context.Context, emp *employee.Employee) ([]float32, error) {
+	r.opened++
+	return r.enrolled, nil
+}
+
+func (r *employeeRepoStub) OpenFaceTemplates(ctx context.Context, employeeID value_objects.UUID) ([]*employee.FaceTemplate, error) {
+	return nil, nil
+}
+
+// ServiceTestSuite é a suíte de testes para o serviço de check-out
+type ServiceTestSuite struct {
+	suite.Suite
+	now          time.Time
+	event        *event.Event
+	employee     *employee.Employee
+	employees    *employeeRepoStub
+	checkoutRepo *checkoutRepoStub
+	checkinRepo  *checkinRepoStub
+	service      Service
+}
+
+func TestServiceSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
+
+func (suite *ServiceTestSuite) SetupTest() {
+	suite.now = time.Now().UTC()
+	suite.event = &event.Event{
+		ID:          value_objects.NewUUID(),
+		TenantID:    value_objects.NewUUID(),
+		InitialDate: suite.now.Add(-48 * time.Hour),
+		FinalDate:   suite.now.Add(-time.Hour),
+		Active:      true,
+	}
+	suite.checkoutRepo = &checkoutRepoStub{byID: make(map[value_objects.UUID]*Checkout)}
+	suite.checkinRepo = &checkinRepoStub{}
+	suite.employee = &employee.Employee{ID: value_objects.NewUUID(), TenantID: suite.event.TenantID, Active: true}
+	suite.employees = &employeeRepoStub{emp: suite.employee}
+	suite.service = NewService(suite.checkoutRepo, nil, suite.checkinRepo, suite.employees, &eventRepoStub{evt: suite.event}, nil, nil, nil, Config{})
+}
+
+func (suite *ServiceTestSuite) openCheckin(checkinTime time.Time) *checkin.Checkin {
+	return &checkin.Checkin{
+		ID:          value_objects.NewUUID(),
+		TenantID:    suite.event.TenantID,
+		EventID:     suite.event.ID,
+		EmployeeID:  value_objects.NewUUID(),
+		PartnerID:   value_objects.NewUUID(),
+		CheckinTime: checkinTime,
+		IsValid:     true,
+	}
+}
+
+func (suite *ServiceTestSuite) TestAutoCloseStaleSessions_ClosesAtEventEnd() {
+	// Arrange
+	ci := suite.openCheckin(suite.event.FinalDate.Add(-3 * time.Hour))
+	suite.checkinRepo.stale = []*checkin.Checkin{ci}
+
+	// Act
+	closed, err := suite.service.AutoCloseStaleSessions(context.Background(), suite.now, 0)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().Len(closed, 1)
+	assert.True(suite.T(), closed[0].AutoClosed)
+	assert.Equal(suite.T(), constants.CheckMethodSystem, closed[0].Method)
+	assert.Equal(suite.T(), ci.ID, closed[0].CheckinID)
+	assert.Equal(suite.T(), suite.event.FinalDate, closed[0].CheckoutTime)
+	assert.Equal(suite.T(), 3*time.Hour, closed[0].WorkDuration)
+	assert.Equal(suite.T(), event.AutoCloseReasonEventFinished, closed[0].ValidationDetails["auto_close_reason"])
+	assert.NotEmpty(suite.T(), closed[0].ValidationDetails["explanation"])
+}
+
+func (suite *ServiceTestSuite) TestCorrectCheckoutTime_OnlyAutoClosed() {
+	// Arrange
+	ci := suite.openCheckin(suite.event.FinalDate.Add(-3 * time.Hour))
+	suite.checkinRepo.stale = []*checkin.Checkin{ci}
+	closed, err := suite.service.AutoCloseStaleSessions(context.Background(), suite.now, 0)
+	suite.Require().NoError(err)
+	manual := &Checkout{ID: value_objects.NewUUID(), TenantID: suite.event.TenantID, CheckinID: ci.ID}
+	suite.checkoutRepo.byID[manual.ID] = manual
+	correctedTime := ci.CheckinTime.Add(2 * time.Hour)
+
+	// Act
+	corrected, correctErr := suite.service.CorrectCheckoutTime(context.Background(), closed[0].ID, suite.event.TenantID, correctedTime, "saída informada pelo supervisor", value_objects.NewUUID())
+	_, manualErr := suite.service.CorrectCheckoutTime(context.Background(), manual.ID, suite.event.TenantID, correctedTime, "ajuste", value_objects.NewUUID())
+	_, tenantErr := suite.service.CorrectCheckoutTime(context.Background(), closed[0].ID, value_objects.NewUUID(), correctedTime, "ajuste", value_objects.NewUUID())
+
+	// Assert
+	suite.Require().NoError(correctErr)
+	assert.Equal(suite.T(), correctedTime, corrected.CheckoutTime)
+	assert.Equal(suite.T(), 2*time.Hour, corrected.WorkDuration)
+	assert.Error(suite.T(), manualErr)
+	assert.True(suite.T(), errors.IsNotFound(tenantErr))
+}
+
+func (suite *ServiceTestSuite) TestVoidCheckout_KeepsRecordAndBlocksCorrection() {
+	// Arrange
+	ci := suite.openCheckin(suite.event.FinalDate.Add(-3 * time.Hour))
+	suite.checkinRepo.stale = []*checkin.Checkin{ci}
+	closed, err := suite.service.AutoCloseStaleSessions(context.Background(), suite.now, 0)
+	suite.Require().NoError(err)
+	voidedBy := value_objects.NewUUID()
+
+	// Act
+	voided, voidErr := suite.service.VoidCheckout(context.Background(), closed[0].ID, suite.event.TenantID, "check-out do funcionário errado", voidedBy)
+	_, againErr := suite.service.VoidCheckout(context.Background(), closed[0].ID, suite.event.TenantID, "repetido", voidedBy)
+	_, correctErr := suite.service.CorrectCheckoutTime(context.Background(), closed[0].ID, suite.event.TenantID, ci.CheckinTime.Add(time.Hour), "ajuste", voidedBy)
+
+	// Assert
+	suite.Require().NoError(voidErr)
+	assert.Equal(suite.T(), CheckoutStatusVoided, voided.GetStatus())
+	assert.Equal(suite.T(), voidedBy, *voided.VoidedBy)
+	assert.Same(suite.T(), voided, suite.checkoutRepo.byID[closed[0].ID])
+	assert.Error(suite.T(), againErr)
+	assert.Error(suite.T(), correctErr)
+}
+
+func (suite *ServiceTestSuite) TestCanEmployeeCheckout_RejectsCallerFromAnotherTenant() {
+	// Arrange
+	ci := suite.openCheckin(suite.event.FinalDate.Add(-3 * time.Hour))
+	suite.checkinRepo.stale = []*checkin.Checkin{ci}
+
+	// Act
+	allowed, allowedReason, allowedErr := suite.service.CanEmployeeCheckout(context.Background(), suite.event.TenantID, ci.EmployeeID, ci.EventID, ci.PartnerID, ci.ID)
+	rejected, rejectedReason, rejectedErr := suite.service.CanEmployeeCheckout(context.Background(), value_objects.NewUUID(), ci.EmployeeID, ci.EventID, ci.PartnerID, ci.ID)
+
+	// Assert
+	suite.Require().NoError(allowedErr)
+	suite.Require().NoError(rejectedErr)
+	assert.True(suite.T(), allowed)
+	assert.Empty(suite.T(), allowedReason)
+	assert.False(suite.T(), rejected)
+	assert.Equal(suite.T(), constants.EligibilityTenantMismatch, rejectedReason)
+}
+
+func (suite *ServiceTestSuite) TestCanEmployeeCheckout_RejectsCheckinRejectedBySupervisor() {
+	// Arrange
+	ci := suite.openCheckin(suite.event.FinalDate.Add(-3 * time.Hour))
+	ci.MarkAsPendingApproval(nil, "check-in manual", value_objects.NewUUID())
+	suite.Require().NoError(ci.Reject("sem evidência de presença", value_objects.NewUUID()))
+	suite.checkinRepo.stale = []*checkin.Checkin{ci}
+
+	// Act
+	allowed, reason, err := suite.service.CanEmployeeCheckout(context.Background(), suite.event.TenantID, ci.EmployeeID, ci.EventID, ci.PartnerID, ci.ID)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.False(suite.T(), allowed)
+	assert.Equal(suite.T(), constants.EligibilityCheckinRejected, reason)
+}
+
+func (suite *ServiceTestSuite) TestValidateFacialRecognition_OpensCheckinEmbeddingForComparison() {
+	// Arrange
+	suite.employee.FaceEnrolled = true
+	suite.employee.SealedFaceEmbedding = []byte("embedding cifrado")
+	suite.employees.enrolled = embedding(0)
+	suite.employee.Consent = &employee.BiometricConsent{EmployeeID: suite.employee.ID, TenantID: suite.employee.TenantID, TextVersion: 1}
+	ci := suite.openCheckin(suite.event.FinalDate.Add(-3 * time.Hour))
+	ci.EmployeeID = suite.employee.ID
+	ci.SealedEmbedding = []byte("embedding cifrado")
+	suite.checkinRepo.stale = []*checkin.Checkin{ci}
+	suite.checkinRepo.embedded = embedding(1)
+	checkout := &Checkout{EmployeeID: suite.employee.ID, CheckinID: ci.ID, Method: constants.CheckMethodFacialRecognition}
+
+	// Act
+	result, err := suite.service.ValidateFacialRecognition(context.Background(), checkout, embedding(0))
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.False(suite.T(), result.IsValid)
+	assert.Contains(suite.T(), result.Details, "checkin_facial_similarity")
+	assert.Equal(suite.T(), 1, suite.checkinRepo.opened)
+	assert.Equal(suite.T(), 1, suite.employees.opened)
+	assert.Nil(suite.T(), ci.FaceEmbedding)
+}
+
+// embedding gera um embedding de teste com um componente dominante na posição informada
+func embedding(dominant int) []float32 {
+	values := make([]float32, constants.FaceEmbeddingDimensions)
+	for i := range values {
+		values[i] = 0.01
+	}
+	values[dominant] = 0.9
+	return values
+}
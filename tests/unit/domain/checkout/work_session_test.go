@@ -0,0 +1,73 @@
+package checkout
+
+import (
+	"testing"
+	"time"
+
+	. "eventos-backend/internal/domain/checkout"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// WorkSessionTestSuite é a suíte de testes para sessões de trabalho
+type WorkSessionTestSuite struct {
+	suite.Suite
+	employeeID value_objects.UUID
+	eventID    value_objects.UUID
+	partnerID  value_objects.UUID
+	start      time.Time
+}
+
+func TestWorkSessionSuite(t *testing.T) {
+	suite.Run(t, new(WorkSessionTestSuite))
+}
+
+func (suite *WorkSessionTestSuite) SetupTest() {
+	suite.employeeID = value_objects.NewUUID()
+	suite.eventID = value_objects.NewUUID()
+	suite.partnerID = value_objects.NewUUID()
+	suite.start = time.Date(2026, 7, 10, 8, 0, 0, 0, time.UTC)
+}
+
+func (suite *WorkSessionTestSuite) closedSession(checkinTime time.Time, duration time.Duration) *WorkSession {
+	return NewWorkSession(value_objects.NewUUID(), value_objects.NewUUID(), suite.employeeID, suite.eventID, suite.partnerID, checkinTime, checkinTime.Add(duration))
+}
+
+func (suite *WorkSessionTestSuite) TestStatus() {
+	// Arrange
+	open := NewOpenWorkSession(value_objects.NewUUID(), suite.employeeID, suite.eventID, suite.partnerID, suite.start)
+	closed := suite.closedSession(suite.start, 4*time.Hour)
+
+	// Assert
+	assert.True(suite.T(), open.IsOpen())
+	assert.Equal(suite.T(), constants.WorkSessionStatusOpen, open.Status())
+	assert.False(suite.T(), closed.IsOpen())
+	assert.Equal(suite.T(), constants.WorkSessionStatusClosed, closed.Status())
+}
+
+func (suite *WorkSessionTestSuite) TestNewWorkStats_AggregatesAllSessions() {
+	// Arrange - dois turnos em dias diferentes, uma reentrada curta e uma sessão ainda aberta
+	sessions := []*WorkSession{
+		suite.closedSession(suite.start, 4*time.Hour),
+		suite.closedSession(suite.start.Add(5*time.Hour), 30*time.Minute),
+		suite.closedSession(suite.start.Add(24*time.Hour), 8*time.Hour),
+		NewOpenWorkSession(value_objects.NewUUID(), suite.employeeID, suite.eventID, suite.partnerID, suite.start.Add(48*time.Hour)),
+	}
+
+	// Act
+	stats := NewWorkStats(sessions)
+
+	// Assert
+	assert.Equal(suite.T(), 4, stats.TotalSessions)
+	assert.Equal(suite.T(), 3, stats.CompleteSessions)
+	assert.Equal(suite.T(), 1, stats.IncompleteSessions)
+	assert.InDelta(suite.T(), 12.5, stats.TotalWorkHours, 0.001)
+	assert.InDelta(suite.T(), 12.5/3, stats.AverageWorkHours, 0.001)
+	assert.InDelta(suite.T(), 0.5, stats.MinWorkHours, 0.001)
+	assert.InDelta(suite.T(), 8, stats.MaxWorkHours, 0.001)
+	assert.Equal(suite.T(), 1, stats.ShortSessions)
+	assert.Equal(suite.T(), 2, stats.NormalSessions)
+}
@@ -0,0 +1,178 @@
+package datasubject
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	. "eventos-backend/internal/domain/datasubject"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/domain/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+// repoStub registra as solicitações gravadas e devolve os dados configurados do titular
+type repoStub struct {
+	Repository
+	updates  []Request
+	export   *Export
+	keys     []string
+	erased   bool
+	notFound bool
+}
+
+func (r *repoStub) Create(ctx context.Context, request *Request) error {
+	return nil
+}
+
+func (r *repoStub) Update(ctx context.Context, request *Request) error {
+	r.updates = append(r.updates, *request)
+	return nil
+}
+
+func (r *repoStub) Collect(ctx context.Context, tenantID value_objects.UUID, subjectType string, subjectID value_objects.UUID) (*Export, error) {
+	if r.notFound {
+		return nil, errors.NewNotFoundError("Data subject", subjectID.String())
+	}
+	return r.export, nil
+}
+
+func (r *repoStub) ListPhotoKeys(ctx context.Context, tenantID value_objects.UUID, subjectType string, subjectID value_objects.UUID) ([]string, error) {
+	return r.keys, nil
+}
+
+func (r *repoStub) Erase(ctx context.Context, tenantID value_objects.UUID, subjectType string, subjectID, erasedBy value_objects.UUID) (*Erasure, error) {
+	r.erased = true
+	return &Erasure{Records: map[string]int{CategoryProfile: 1, CategoryCheckins: 3}}, nil
+}
+
+// photoStorageStub assina URLs fixas e falha ao remover as chaves configuradas
+type photoStorageStub struct {
+	storage.Service
+	deleted  []string
+	failKeys map[string]bool
+}
+
+func (s *photoStorageStub) SignedURL(tenantID value_objects.UUID, key string) (*storage.SignedURL, error) {
+	return &storage.SignedURL{URL: "https://files.example/" + key, ExpiresAt: time.Now().Add(time.Minute)}, nil
+}
+
+func (s *photoStorageStub) Delete(ctx context.Context, tenantID value_objects.UUID, key string) error {
+	if s.failKeys[key] {
+		return fmt.Errorf("storage unavailable")
+	}
+	s.deleted = append(s.deleted, key)
+	return nil
+}
+
+// ServiceTestSuite é a suíte de testes para o atendimento das solicitações de titulares
+type ServiceTestSuite struct {
+	suite.Suite
+	repo     *repoStub
+	photos   *photoStorageStub
+	service  Service
+	tenantID value_objects.UUID
+	subject  value_objects.UUID
+	operator value_objects.UUID
+}
+
+func TestServiceSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
+
+func (suite *ServiceTestSuite) SetupTest() {
+	suite.repo = &repoStub{}
+	suite.photos = &photoStorageStub{failKeys: map[string]bool{}}
+	suite.service = NewService(suite.repo, suite.photos, zap.NewNop())
+	suite.tenantID = value_objects.NewUUID()
+	suite.subject = value_objects.NewUUID()
+	suite.operator = value_objects.NewUUID()
+}
+
+func (suite *ServiceTestSuite) TestExport_SignsPhotosAndRecordsDigest() {
+	// Arrange
+	suite.repo.export = &Export{
+		Profile:        json.RawMessage(`{"full_name":"Maria"}`),
+		Photos:         []Photo{{Key: "tenants/t/employees/e/foto.jpg", Source: "employees"}},
+		FaceEmbeddings: []FaceEmbedding{{Source: "employees", Vector: []float32{0.1, 0.2}}},
+		Checkins:       []json.RawMessage{json.RawMessage(`{}`), json.RawMessage(`{}`)},
+	}
+
+	// Act
+	request, export, err := suite.service.Export(context.Background(), suite.tenantID, constants.DataSubjectEmployee, suite.subject, "protocolo 123", suite.operator)
+
+	// Assert
+	suite.Require().NoError(err)
+	digest, digestErr := export.Digest()
+	suite.Require().NoError(digestErr)
+	assert.Equal(suite.T(), constants.DataSubjectStatusCompleted, request.Status)
+	assert.Equal(suite.T(), "https://files.example/tenants/t/employees/e/foto.jpg", export.Photos[0].URL)
+	assert.Equal(suite.T(), request.ID.String(), export.RequestID)
+	assert.Equal(suite.T(), digest, request.Evidence.ExportDigest)
+	assert.Equal(suite.T(), 2, request.Evidence.Records[CategoryCheckins])
+	assert.Equal(suite.T(), 1, request.Evidence.Records[CategoryFaceEmbeddings])
+}
+
+func (suite *ServiceTestSuite) TestExport_UnknownSubjectIsRecordedAsFailed() {
+	// Arrange
+	suite.repo.notFound = true
+
+	// Act
+	_, _, err := suite.service.Export(context.Background(), suite.tenantID, constants.DataSubjectPartner, suite.subject, "", suite.operator)
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, errors.ErrNotFound)
+	suite.Require().Len(suite.repo.updates, 1)
+	assert.Equal(suite.T(), constants.DataSubjectStatusFailed, suite.repo.updates[0].Status)
+	assert.Equal(suite.T(), "Data subject not found", suite.repo.updates[0].FailureReason)
+}
+
+func (suite *ServiceTestSuite) TestErase_DeletesPhotosBeforeData() {
+	// Arrange
+	suite.repo.keys = []string{"tenants/t/employees/e/foto.jpg", "tenants/t/checkins/c/foto.jpg"}
+
+	// Act
+	request, err := suite.service.Erase(context.Background(), suite.tenantID, constants.DataSubjectEmployee, suite.subject, "", suite.operator)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.True(suite.T(), suite.repo.erased)
+	assert.Equal(suite.T(), suite.repo.keys, suite.photos.deleted)
+	assert.Equal(suite.T(), constants.DataSubjectStatusCompleted, request.Status)
+	assert.Equal(suite.T(), 2, request.Evidence.PhotosDeleted)
+	assert.Equal(suite.T(), 3, request.Evidence.Records[CategoryCheckins])
+}
+
+func (suite *ServiceTestSuite) TestErase_PhotoFailureKeepsDataForRetry() {
+	// Arrange
+	suite.repo.keys = []string{"tenants/t/employees/e/foto.jpg", "tenants/t/checkins/c/foto.jpg"}
+	suite.photos.failKeys["tenants/t/checkins/c/foto.jpg"] = true
+
+	// Act
+	_, err := suite.service.Erase(context.Background(), suite.tenantID, constants.DataSubjectEmployee, suite.subject, "", suite.operator)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.False(suite.T(), suite.repo.erased)
+	suite.Require().Len(suite.repo.updates, 1)
+	assert.Equal(suite.T(), constants.DataSubjectStatusFailed, suite.repo.updates[0].Status)
+	assert.Equal(suite.T(), 1, suite.repo.updates[0].Evidence.PhotosDeleted)
+}
+
+func (suite *ServiceTestSuite) TestExport_RejectsUnknownSubjectType() {
+	// Act
+	_, _, err := suite.service.Export(context.Background(), suite.tenantID, "visitor", suite.subject, "", suite.operator)
+
+	// Assert
+	var domainErr *errors.DomainError
+	suite.Require().ErrorAs(err, &domainErr)
+	assert.Equal(suite.T(), "SubjectType", domainErr.Context["field"])
+	assert.Empty(suite.T(), suite.repo.updates)
+}
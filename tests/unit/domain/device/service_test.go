@@ -0,0 +1,227 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "eventos-backend/internal/domain/device"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// deviceRepoStub mantém os dispositivos em memória
+type deviceRepoStub struct {
+	Repository
+	devices map[value_objects.UUID]*Device
+	touches int
+}
+
+func (r *deviceRepoStub) Create(ctx context.Context, d *Device) error {
+	r.devices[d.ID] = d
+	return nil
+}
+
+func (r *deviceRepoStub) GetByID(ctx context.Context, id value_objects.UUID) (*Device, error) {
+	d, ok := r.devices[id]
+	if !ok {
+		return nil, fmt.Errorf("device not found: %w", errors.ErrNotFound)
+	}
+	copied := *d
+	return &copied, nil
+}
+
+func (r *deviceRepoStub) GetByIDAndTenant(ctx context.Context, id, tenantID value_objects.UUID) (*Device, error) {
+	d, ok := r.devices[id]
+	if !ok || !d.TenantID.Equals(tenantID) {
+		return nil, fmt.Errorf("device not found: %w", errors.ErrNotFound)
+	}
+	copied := *d
+	return &copied, nil
+}
+
+func (r *deviceRepoStub) Update(ctx context.Context, d *Device) error {
+	r.devices[d.ID] = d
+	return nil
+}
+
+func (r *deviceRepoStub) TouchLastSeen(ctx context.Context, id value_objects.UUID, appVersion string, at time.Time) error {
+	r.touches++
+	r.devices[id].RecordSeen(appVersion, at)
+	return nil
+}
+
+// cipherStub devolve a credencial sem cifrar
+type cipherStub struct{}
+
+func (cipherStub) Encrypt(plaintext []byte) ([]byte, error) {
+	return append([]byte{}, plaintext...), nil
+}
+func (cipherStub) Decrypt(ciphertext []byte) ([]byte, error) { return ciphertext, nil }
+
+// nonceStoreStub registra os nonces em memória
+type nonceStoreStub map[string]bool
+
+func (s nonceStoreStub) Claim(ctx context.Context, deviceID value_objects.UUID, nonce string, ttl time.Duration) (bool, error) {
+	key := deviceID.String() + nonce
+	if s[key] {
+		return false, nil
+	}
+	s[key] = true
+	return true, nil
+}
+
+// ServiceTestSuite é a suíte de testes para o serviço de dispositivos
+type ServiceTestSuite struct {
+	suite.Suite
+	tenantID value_objects.UUID
+	adminID  value_objects.UUID
+	repo     *deviceRepoStub
+	service  Service
+}
+
+func TestServiceSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
+
+func (suite *ServiceTestSuite) SetupTest() {
+	suite.tenantID = value_objects.NewUUID()
+	suite.adminID = value_objects.NewUUID()
+	suite.repo = &deviceRepoStub{devices: make(map[value_objects.UUID]*Device)}
+	suite.service = NewService(suite.repo, nil, cipherStub{}, nonceStoreStub{}, Config{SignatureMaxSkew: 5 * time.Minute})
+}
+
+func (suite *ServiceTestSuite) TestAuthenticate_ValidCredentialRecordsLastSeen() {
+	// Arrange
+	d, secret, err := suite.service.RegisterDevice(context.Background(), suite.tenantID, "Portão A", constants.DeviceTypeKiosk, nil, "A", suite.adminID)
+	suite.Require().NoError(err)
+
+	// Act
+	authenticated, err := suite.service.Authenticate(context.Background(), suite.tenantID, d.ID, secret, "1.4.0")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), d.ID, authenticated.ID)
+	assert.Equal(suite.T(), 1, suite.repo.touches)
+	assert.Equal(suite.T(), "1.4.0", suite.repo.devices[d.ID].AppVersion)
+	assert.NotNil(suite.T(), suite.repo.devices[d.ID].LastSeenAt)
+}
+
+func (suite *ServiceTestSuite) TestAuthenticate_RejectsWrongSecretAndOtherTenant() {
+	// Arrange
+	d, secret, err := suite.service.RegisterDevice(context.Background(), suite.tenantID, "Coletor 1", constants.DeviceTypeHandheld, nil, "", suite.adminID)
+	suite.Require().NoError(err)
+
+	// Act
+	_, wrongSecretErr := suite.service.Authenticate(context.Background(), suite.tenantID, d.ID, secret+"x", "")
+	_, otherTenantErr := suite.service.Authenticate(context.Background(), value_objects.NewUUID(), d.ID, secret, "")
+
+	// Assert
+	assert.Equal(suite.T(), "UNAUTHORIZED", wrongSecretErr.(*errors.DomainError).Type)
+	assert.Equal(suite.T(), "UNAUTHORIZED", otherTenantErr.(*errors.DomainError).Type)
+}
+
+func (suite *ServiceTestSuite) TestRevokeDevice_BlocksSubmissionsImmediately() {
+	// Arrange
+	d, secret, err := suite.service.RegisterDevice(context.Background(), suite.tenantID, "Portão B", constants.DeviceTypeKiosk, nil, "B", suite.adminID)
+	suite.Require().NoError(err)
+
+	// Act
+	_, err = suite.service.RevokeDevice(context.Background(), suite.tenantID, d.ID, "Equipamento extraviado", suite.adminID)
+	suite.Require().NoError(err)
+	_, authErr := suite.service.Authenticate(context.Background(), suite.tenantID, d.ID, secret, "")
+
+	// Assert
+	assert.Equal(suite.T(), "FORBIDDEN", authErr.(*errors.DomainError).Type)
+	_, _, rotateErr := suite.service.RotateCredential(context.Background(), suite.tenantID, d.ID, suite.adminID)
+	assert.Error(suite.T(), rotateErr)
+}
+
+func (suite *ServiceTestSuite) TestRotateCredential_InvalidatesPreviousSecret() {
+	// Arrange
+	d, oldSecret, err := suite.service.RegisterDevice(context.Background(), suite.tenantID, "Portão C", constants.DeviceTypeKiosk, nil, "C", suite.adminID)
+	suite.Require().NoError(err)
+
+	// Act
+	_, newSecret, err := suite.service.RotateCredential(context.Background(), suite.tenantID, d.ID, suite.adminID)
+	suite.Require().NoError(err)
+
+	// Assert
+	_, oldErr := suite.service.Authenticate(context.Background(), suite.tenantID, d.ID, oldSecret, "")
+	_, newErr := suite.service.Authenticate(context.Background(), suite.tenantID, d.ID, newSecret, "")
+	assert.Error(suite.T(), oldErr)
+	assert.NoError(suite.T(), newErr)
+}
+
+func (suite *ServiceTestSuite) TestIsBoundTo_RestrictsToEvent() {
+	// Arrange
+	eventID := value_objects.NewUUID()
+	bound, err := NewDevice(suite.tenantID, "Portão D", constants.DeviceTypeKiosk, &eventID, "D", suite.adminID)
+	suite.Require().NoError(err)
+	unbound, err := NewDevice(suite.tenantID, "Coletor 2", constants.DeviceTypeHandheld, nil, "", suite.adminID)
+	suite.Require().NoError(err)
+
+	// Assert
+	assert.True(suite.T(), bound.IsBoundTo(eventID))
+	assert.False(suite.T(), bound.IsBoundTo(value_objects.NewUUID()))
+	assert.True(suite.T(), unbound.IsBoundTo(value_objects.NewUUID()))
+}
+
+// signedRequest assina uma requisição com a credencial do dispositivo
+func signedRequest(deviceID value_objects.UUID, secret, nonce string, at time.Time, body []byte) SignedRequest {
+	req := SignedRequest{
+		DeviceID:  deviceID,
+		Method:    "POST",
+		Path:      "/api/v1/kiosk/checkins",
+		BodyHash:  HashBody(body),
+		Timestamp: at.Unix(),
+		Nonce:     nonce,
+	}
+	req.Signature = Sign([]byte(secret), CanonicalString(req.Method, req.Path, req.BodyHash, req.Timestamp, req.Nonce))
+	return req
+}
+
+func (suite *ServiceTestSuite) TestAuthenticateSigned_AcceptsOnceAndRejectsReplay() {
+	// Arrange
+	d, secret, err := suite.service.RegisterDevice(context.Background(), suite.tenantID, "Quiosque 1", constants.DeviceTypeKiosk, nil, "A", suite.adminID)
+	suite.Require().NoError(err)
+	req := signedRequest(d.ID, secret, "nonce-0123456789abcdef", time.Now(), []byte(`{"event_id":"x"}`))
+
+	// Act
+	authenticated, firstErr := suite.service.AuthenticateSigned(context.Background(), req)
+	_, replayErr := suite.service.AuthenticateSigned(context.Background(), req)
+
+	// Assert
+	assert.NoError(suite.T(), firstErr)
+	assert.Equal(suite.T(), suite.tenantID, authenticated.TenantID)
+	assert.Equal(suite.T(), "REPLAYED_REQUEST", replayErr.(*errors.DomainError).Context["reason_code"])
+}
+
+func (suite *ServiceTestSuite) TestAuthenticateSigned_RejectsSkewAndTampering() {
+	// Arrange
+	d, secret, err := suite.service.RegisterDevice(context.Background(), suite.tenantID, "Quiosque 2", constants.DeviceTypeKiosk, nil, "B", suite.adminID)
+	suite.Require().NoError(err)
+	skewed := signedRequest(d.ID, secret, "nonce-skewed-000000001", time.Now().Add(-10*time.Minute), nil)
+	tampered := signedRequest(d.ID, secret, "nonce-tampered-0000001", time.Now(), []byte(`{"event_id":"x"}`))
+	tampered.BodyHash = HashBody([]byte(`{"event_id":"y"}`))
+
+	// Act
+	_, skewErr := suite.service.AuthenticateSigned(context.Background(), skewed)
+	_, tamperErr := suite.service.AuthenticateSigned(context.Background(), tampered)
+
+	// Assert
+	assert.Equal(suite.T(), "CLOCK_SKEW", skewErr.(*errors.DomainError).Context["reason_code"])
+	assert.Equal(suite.T(), "INVALID_SIGNATURE", tamperErr.(*errors.DomainError).Context["reason_code"])
+}
+
+func (suite *ServiceTestSuite) TestHasPermission_RestrictedToCheckins() {
+	// Assert
+	assert.True(suite.T(), HasPermission(constants.ModuleCheckins, constants.PermissionWrite))
+	assert.False(suite.T(), HasPermission(constants.ModuleCheckins, constants.PermissionApprove))
+	assert.False(suite.T(), HasPermission(constants.ModuleDevices, constants.PermissionAdmin))
+}
@@ -0,0 +1,119 @@
+package employee
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "eventos-backend/internal/domain/employee"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+func (r *employeeRepoStub) GetLatestConsentText(ctx context.Context, tenantID value_objects.UUID) (*ConsentText, error) {
+	if r.text == nil {
+		return nil, errors.NewNotFoundError("consent text", tenantID.String())
+	}
+	return r.text, nil
+}
+
+func (r *employeeRepoStub) CreateConsentText(ctx context.Context, text *ConsentText) error {
+	r.text = text
+	return nil
+}
+
+func (r *employeeRepoStub) CreateBiometricConsent(ctx context.Context, consent *BiometricConsent) error {
+	r.consents = append(r.consents, consent)
+	return nil
+}
+
+func (r *employeeRepoStub) RevokeBiometricConsent(ctx context.Context, consent *BiometricConsent) (*BiometricPurge, error) {
+	r.revoked = append(r.revoked, consent)
+	return &BiometricPurge{}, nil
+}
+
+// BiometricConsentTestSuite é a suíte de testes para o consentimento biométrico do funcionário
+type BiometricConsentTestSuite struct {
+	suite.Suite
+	repo     *employeeRepoStub
+	service  Service
+	employee *Employee
+	userID   value_objects.UUID
+}
+
+func TestBiometricConsentSuite(t *testing.T) {
+	suite.Run(t, new(BiometricConsentTestSuite))
+}
+
+func (suite *BiometricConsentTestSuite) SetupTest() {
+	suite.userID = value_objects.NewUUID()
+
+	emp, err := NewEmployee(value_objects.NewUUID(), "Maria Souza", "12345678901", constants.IdentityTypeCPF, "11999990000", "maria@example.com", nil, suite.userID)
+	suite.Require().NoError(err)
+	suite.employee = emp
+
+	suite.repo = &employeeRepoStub{employee: emp}
+	suite.service = NewDomainService(suite.repo, nil, Config{}, zap.NewNop())
+}
+
+// grant publica o termo e registra o consentimento do funcionário da suíte
+func (suite *BiometricConsentTestSuite) grant() *BiometricConsent {
+	ctx := context.Background()
+	_, err := suite.service.PublishConsentText(ctx, suite.employee.TenantID, "Autorizo o tratamento dos meus dados biométricos.", suite.userID)
+	suite.Require().NoError(err)
+
+	consent, err := suite.service.GrantBiometricConsent(ctx, suite.employee.TenantID, suite.employee.ID, ConsentRequest{TextVersion: 1, Channel: constants.ConsentChannelKiosk}, suite.userID)
+	suite.Require().NoError(err)
+	return consent
+}
+
+func (suite *BiometricConsentTestSuite) TestEnrollment_RequiresActiveConsent() {
+	// Arrange
+	template, err := NewFaceTemplate(suite.employee.TenantID, suite.employee.ID, embedding(0), 0.9, "arcface-r100", "", time.Time{}, suite.userID)
+	suite.Require().NoError(err)
+
+	// Act
+	embeddingErr := suite.employee.UpdateFaceEmbedding(embedding(0), suite.userID)
+	templateErr := suite.employee.AddFaceTemplate(template, 5, suite.userID)
+
+	// Assert
+	assert.Equal(suite.T(), constants.FaceTemplateRejectNoConsent, embeddingErr.(*errors.DomainError).Context["reason_code"])
+	assert.Equal(suite.T(), constants.FaceTemplateRejectNoConsent, templateErr.(*errors.DomainError).Context["reason_code"])
+	assert.False(suite.T(), suite.employee.HasFaceEmbedding())
+}
+
+func (suite *BiometricConsentTestSuite) TestGrant_RecordsCurrentTextVersion() {
+	// Act
+	consent := suite.grant()
+	_, outdatedErr := suite.service.GrantBiometricConsent(context.Background(), suite.employee.TenantID, suite.employee.ID, ConsentRequest{TextVersion: 2, Channel: constants.ConsentChannelWeb}, suite.userID)
+
+	// Assert
+	assert.Equal(suite.T(), 1, consent.TextVersion)
+	assert.Equal(suite.T(), suite.repo.text.Digest, consent.TextDigest)
+	assert.Len(suite.T(), suite.repo.consents, 1)
+	assert.True(suite.T(), suite.employee.HasBiometricConsent())
+	assert.Equal(suite.T(), 1, outdatedErr.(*errors.DomainError).Context["current_version"])
+}
+
+func (suite *BiometricConsentTestSuite) TestRevoke_PurgesEmbeddingsAndBlocksRecognition() {
+	// Arrange
+	suite.grant()
+	suite.Require().NoError(suite.employee.UpdateFaceEmbedding(embedding(0), suite.userID))
+	suite.Require().True(suite.employee.CanPerformFacialRecognition())
+
+	// Act
+	consent, err := suite.service.RevokeBiometricConsent(context.Background(), suite.employee.TenantID, suite.employee.ID, constants.ConsentChannelMobile, "pedido do titular", suite.userID)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.NotNil(suite.T(), consent.RevokedAt)
+	assert.Len(suite.T(), suite.repo.revoked, 1)
+	assert.False(suite.T(), suite.employee.HasFaceEmbedding())
+	assert.False(suite.T(), suite.employee.FaceEnrolled)
+	assert.False(suite.T(), suite.employee.CanPerformFacialRecognition())
+}
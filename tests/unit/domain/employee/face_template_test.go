@@ -0,0 +1,171 @@
+package employee
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "eventos-backend/internal/domain/employee"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+// employeeRepoStub implementa apenas os métodos de Repository usados no cadastro de templates e nos consentimentos
+type employeeRepoStub struct {
+	Repository
+	employee *Employee
+	similar  []*FaceTemplateMatch
+	enrolled []*FaceTemplate // Templates cadastrados, entregues apenas por OpenFaceTemplates
+	opened   int
+	created  []*FaceTemplate
+	updated  int
+	text     *ConsentText
+	consents []*BiometricConsent
+	revoked  []*BiometricConsent
+}
+
+func (r *employeeRepoStub) GetByIDAndTenant(ctx context.Context, id, tenantID value_objects.UUID) (*Employee, error) {
+	return r.employee, nil
+}
+
+func (r *employeeRepoStub) OpenFaceTemplates(ctx context.Context, employeeID value_objects.UUID) ([]*FaceTemplate, error) {
+	r.opened++
+	return r.enrolled, nil
+}
+
+func (r *employeeRepoStub) OpenFaceEmbedding(ctx context.Context, employee *Employee) ([]float32, error) {
+	return nil, nil
+}
+
+func (r *employeeRepoStub) FindSimilarFaceTemplates(ctx context.Context, tenantID value_objects.UUID, embedding []float32, threshold float32, excludeEmployeeID value_objects.UUID, limit int) ([]*FaceTemplateMatch, error) {
+	return r.similar, nil
+}
+
+func (r *employeeRepoStub) CreateFaceTemplate(ctx context.Context, template *FaceTemplate) error {
+	r.created = append(r.created, template)
+	return nil
+}
+
+func (r *employeeRepoStub) Update(ctx context.Context, employee *Employee) error {
+	r.updated++
+	return nil
+}
+
+// FaceTemplateTestSuite é a suíte de testes para os templates faciais do funcionário
+type FaceTemplateTestSuite struct {
+	suite.Suite
+	repo     *employeeRepoStub
+	service  Service
+	employee *Employee
+	userID   value_objects.UUID
+}
+
+func TestFaceTemplateSuite(t *testing.T) {
+	suite.Run(t, new(FaceTemplateTestSuite))
+}
+
+func (suite *FaceTemplateTestSuite) SetupTest() {
+	suite.userID = value_objects.NewUUID()
+
+	emp, err := NewEmployee(value_objects.NewUUID(), "Maria Souza", "12345678901", constants.IdentityTypeCPF, "11999990000", "maria@example.com", nil, suite.userID)
+	suite.Require().NoError(err)
+	emp.Consent = &BiometricConsent{ID: value_objects.NewUUID(), TenantID: emp.TenantID, EmployeeID: emp.ID, TextVersion: 1}
+	suite.employee = emp
+
+	suite.repo = &employeeRepoStub{employee: emp}
+	suite.service = NewDomainService(suite.repo, nil, Config{MaxFaceTemplates: 2}, zap.NewNop())
+}
+
+// embedding monta um embedding concentrado na dimensão informada
+func embedding(axis int) []float32 {
+	values := make([]float32, constants.FaceEmbeddingDimensions)
+	values[axis] = 1
+	return values
+}
+
+// template cria um template do funcionário da suíte com a qualidade informada
+func (suite *FaceTemplateTestSuite) template(axis int, quality float32) *FaceTemplate {
+	template, err := NewFaceTemplate(suite.employee.TenantID, suite.employee.ID, embedding(axis), quality, "arcface-r100", "", time.Time{}, suite.userID)
+	suite.Require().NoError(err)
+	return template
+}
+
+func (suite *FaceTemplateTestSuite) TestCompareFaceEmbedding_UsesBestTemplate() {
+	// Arrange
+	suite.Require().NoError(suite.employee.AddFaceTemplate(suite.template(0, 0.95), 5, suite.userID))
+	suite.Require().NoError(suite.employee.AddFaceTemplate(suite.template(1, 0.7), 5, suite.userID))
+
+	// Act
+	matched, similarity := suite.employee.CompareFaceEmbedding(embedding(1), constants.DefaultFacialSimilarityThreshold)
+
+	// Assert
+	assert.True(suite.T(), matched)
+	assert.InDelta(suite.T(), 1.0, similarity, 0.001)
+	assert.Equal(suite.T(), embedding(0), suite.employee.FaceEmbedding, "embedding should mirror the highest quality template")
+}
+
+func (suite *FaceTemplateTestSuite) TestRemoveFaceTemplate_ClearsEmbeddingWhenNoneRemain() {
+	// Arrange
+	template := suite.template(0, 0.9)
+	suite.Require().NoError(suite.employee.AddFaceTemplate(template, 5, suite.userID))
+
+	// Act
+	removed, err := suite.employee.RemoveFaceTemplate(template.ID, suite.userID)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), template.ID, removed.ID)
+	assert.False(suite.T(), suite.employee.HasFaceEmbedding())
+}
+
+func (suite *FaceTemplateTestSuite) TestAddFaceTemplate_Persists() {
+	// Arrange
+	request := FaceTemplateRequest{Embedding: embedding(2), Quality: 0.8, ModelVersion: "arcface-r100"}
+
+	// Act
+	template, err := suite.service.AddFaceTemplate(context.Background(), suite.employee.TenantID, suite.employee.ID, request, suite.userID)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), suite.repo.created, 1)
+	assert.Equal(suite.T(), 1, suite.repo.updated)
+	assert.Equal(suite.T(), template.Embedding, suite.employee.FaceEmbedding)
+}
+
+func (suite *FaceTemplateTestSuite) TestAddFaceTemplate_RejectsSimilarToOtherEmployee() {
+	// Arrange
+	suite.repo.similar = []*FaceTemplateMatch{{EmployeeID: value_objects.NewUUID(), Similarity: 0.97}}
+	request := FaceTemplateRequest{Embedding: embedding(2), Quality: 0.8, ModelVersion: "arcface-r100"}
+
+	// Act
+	_, err := suite.service.AddFaceTemplate(context.Background(), suite.employee.TenantID, suite.employee.ID, request, suite.userID)
+
+	// Assert
+	domainErr, ok := err.(*errors.DomainError)
+	suite.Require().True(ok)
+	assert.Equal(suite.T(), constants.FaceTemplateRejectSimilarToOther, domainErr.Context["reason_code"])
+	assert.Empty(suite.T(), suite.repo.created)
+}
+
+func (suite *FaceTemplateTestSuite) TestAddFaceTemplate_RejectsLowQualityAndLimit() {
+	// Arrange
+	lowQuality := FaceTemplateRequest{Embedding: embedding(2), Quality: 0.3, ModelVersion: "arcface-r100"}
+	suite.employee.FaceEnrolled = true
+	suite.repo.enrolled = []*FaceTemplate{suite.template(0, 0.9), suite.template(1, 0.9)}
+	valid := FaceTemplateRequest{Embedding: embedding(2), Quality: 0.9, ModelVersion: "arcface-r100"}
+
+	// Act
+	_, lowQualityErr := suite.service.AddFaceTemplate(context.Background(), suite.employee.TenantID, suite.employee.ID, lowQuality, suite.userID)
+	_, limitErr := suite.service.AddFaceTemplate(context.Background(), suite.employee.TenantID, suite.employee.ID, valid, suite.userID)
+
+	// Assert
+	assert.Equal(suite.T(), constants.FaceTemplateRejectLowQuality, lowQualityErr.(*errors.DomainError).Context["reason_code"])
+	assert.Equal(suite.T(), constants.FaceTemplateRejectLimitReached, limitErr.(*errors.DomainError).Context["reason_code"])
+	assert.Empty(suite.T(), suite.repo.created)
+	assert.Equal(suite.T(), 2, suite.repo.opened)
+}
@@ -113,3 +113,126 @@ func (suite *EventTestSuite) TestEventIsActive() {
 	// Assert
 	assert.True(suite.T(), event.IsActive())
 }
+
+// squareFence retorna uma cerca quadrada de aproximadamente 111 metros de lado
+func squareFence() []value_objects.Location {
+	return []value_objects.Location{
+		{Latitude: -23.5500, Longitude: -46.6340},
+		{Latitude: -23.5500, Longitude: -46.6330},
+		{Latitude: -23.5510, Longitude: -46.6330},
+		{Latitude: -23.5510, Longitude: -46.6340},
+	}
+}
+
+func (suite *EventTestSuite) TestCheckLocation_InsideFence() {
+	// Arrange
+	event := &Event{FenceEvent: squareFence()}
+
+	// Act
+	check := event.CheckLocation(value_objects.Location{Latitude: -23.5505, Longitude: -46.6335})
+
+	// Assert
+	assert.True(suite.T(), check.HasFence)
+	assert.True(suite.T(), check.WithinFence)
+	assert.True(suite.T(), check.WithinTolerance)
+	assert.InDelta(suite.T(), 50.0, check.DistanceToEdge, 2.0)
+}
+
+func (suite *EventTestSuite) TestCheckLocation_OutsideFenceWithTolerance() {
+	// Arrange
+	event := &Event{FenceEvent: squareFence()}
+	outside := value_objects.Location{Latitude: -23.5498, Longitude: -46.6335} // ~22 metros ao norte da cerca
+
+	// Act
+	withoutTolerance := event.CheckLocation(outside)
+	assert.NoError(suite.T(), event.SetFenceTolerance(30, value_objects.NewUUID()))
+	withTolerance := event.CheckLocation(outside)
+
+	// Assert
+	assert.False(suite.T(), withoutTolerance.WithinFence)
+	assert.False(suite.T(), withoutTolerance.WithinTolerance)
+	assert.InDelta(suite.T(), 22.2, withoutTolerance.DistanceToEdge, 1.0)
+	assert.False(suite.T(), withTolerance.WithinFence)
+	assert.True(suite.T(), withTolerance.WithinTolerance)
+}
+
+func (suite *EventTestSuite) TestCheckLocation_MissingLocationFailsOnlyWithFence() {
+	// Arrange
+	fenced := &Event{FenceEvent: squareFence()}
+	open := &Event{}
+
+	// Act
+	fencedCheck := fenced.CheckLocation(value_objects.Location{})
+	openCheck := open.CheckLocation(value_objects.Location{})
+
+	// Assert
+	assert.True(suite.T(), fencedCheck.Missing)
+	assert.False(suite.T(), fencedCheck.WithinTolerance)
+	assert.False(suite.T(), openCheck.Missing)
+	assert.True(suite.T(), openCheck.WithinTolerance)
+}
+
+func (suite *EventTestSuite) TestCheckLocationWithAccuracy_RejectsOnlyWholeCircleOutside() {
+	// Arrange
+	event := &Event{FenceEvent: squareFence()}
+	outside := value_objects.Location{Latitude: -23.5498, Longitude: -46.6335} // ~22 metros ao norte da cerca
+	precise, _ := value_objects.NewLocationFix(5, nil, nil, value_objects.LocationProviderGPS, false)
+	coarse, _ := value_objects.NewLocationFix(40, nil, nil, value_objects.LocationProviderNetwork, false)
+	cellTower, _ := value_objects.NewLocationFix(2000, nil, nil, value_objects.LocationProviderNetwork, false)
+	farAway := value_objects.Location{Latitude: -23.5480, Longitude: -46.6335} // ~222 metros ao norte da cerca
+
+	// Act
+	preciseCheck := event.CheckLocationWithAccuracy(outside, precise)
+	coarseCheck := event.CheckLocationWithAccuracy(outside, coarse)
+	cellTowerCheck := event.CheckLocationWithAccuracy(farAway, cellTower)
+
+	// Assert
+	assert.False(suite.T(), preciseCheck.WithinTolerance)
+	assert.True(suite.T(), coarseCheck.WithinTolerance)
+	assert.Equal(suite.T(), 40.0, coarseCheck.AccuracyMeters)
+	assert.False(suite.T(), cellTowerCheck.WithinTolerance)
+	assert.Equal(suite.T(), value_objects.MaxLocationAccuracyCredit, cellTowerCheck.AccuracyMeters)
+}
+
+func (suite *EventTestSuite) TestSetFenceTolerance_Invalid() {
+	// Arrange
+	event := &Event{FenceEvent: squareFence()}
+
+	// Act
+	err := event.SetFenceTolerance(-1, value_objects.NewUUID())
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Zero(suite.T(), event.FenceToleranceMeters)
+}
+
+func (suite *EventTestSuite) TestAutoCloseTime() {
+	// Arrange
+	checkinTime := time.Date(2026, 7, 10, 8, 0, 0, 0, time.UTC)
+	event := &Event{FinalDate: checkinTime.Add(24 * time.Hour)}
+
+	// Act
+	atEventEnd, endReason := event.AutoCloseTime(checkinTime)
+	event.MaxSessionHours = 12
+	atMaxDuration, maxReason := event.AutoCloseTime(checkinTime)
+	afterEnd, _ := event.AutoCloseTime(event.FinalDate.Add(time.Hour))
+
+	// Assert
+	assert.Equal(suite.T(), event.FinalDate, atEventEnd)
+	assert.Equal(suite.T(), AutoCloseReasonEventFinished, endReason)
+	assert.Equal(suite.T(), checkinTime.Add(12*time.Hour), atMaxDuration)
+	assert.Equal(suite.T(), AutoCloseReasonMaxSessionDuration, maxReason)
+	assert.Equal(suite.T(), event.FinalDate.Add(time.Hour), afterEnd)
+}
+
+func (suite *EventTestSuite) TestSetMaxSessionHours_Invalid() {
+	// Arrange
+	event := &Event{}
+
+	// Act
+	err := event.SetMaxSessionHours(MaxSessionHoursLimit+1, value_objects.NewUUID())
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Zero(suite.T(), event.MaxSessionHours)
+}
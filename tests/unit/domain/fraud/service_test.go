@@ -0,0 +1,188 @@
+package fraud
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	. "eventos-backend/internal/domain/fraud"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// fraudRepoStub devolve resultados fixos para as consultas da análise
+type fraudRepoStub struct {
+	Repository
+	previous        *Record
+	deviceEmployees int
+	deviceErr       error
+	matches         []*Record
+	flagged         *FlaggedCheckin
+	reviews         int
+}
+
+func (r *fraudRepoStub) GetPreviousRecord(ctx context.Context, tenantID, employeeID value_objects.UUID, before time.Time) (*Record, error) {
+	if r.previous == nil {
+		return nil, fmt.Errorf("previous record not found: %w", errors.ErrNotFound)
+	}
+	return r.previous, nil
+}
+
+func (r *fraudRepoStub) CountDeviceEmployees(ctx context.Context, tenantID value_objects.UUID, deviceID string, from, to time.Time, excludeEmployeeID value_objects.UUID) (int, error) {
+	return r.deviceEmployees, r.deviceErr
+}
+
+func (r *fraudRepoStub) FindIdenticalEmbeddings(ctx context.Context, tenantID value_objects.UUID, embedding []float32, since time.Time, limit int) ([]*Record, error) {
+	return r.matches, nil
+}
+
+func (r *fraudRepoStub) GetFlagged(ctx context.Context, tenantID, checkinID value_objects.UUID) (*FlaggedCheckin, error) {
+	if r.flagged == nil || !r.flagged.CheckinID.Equals(checkinID) {
+		return nil, fmt.Errorf("flagged checkin not found: %w", errors.ErrNotFound)
+	}
+	copied := *r.flagged
+	return &copied, nil
+}
+
+func (r *fraudRepoStub) SaveReview(ctx context.Context, tenantID, checkinID value_objects.UUID, review *Review) error {
+	r.reviews++
+	r.flagged.Review = review
+	return nil
+}
+
+// notifierStub guarda as sinalizações notificadas
+type notifierStub struct {
+	flags []Flag
+}
+
+func (n *notifierStub) NotifyFraud(ctx context.Context, subject Subject, flags []Flag) {
+	n.flags = append(n.flags, flags...)
+}
+
+// ServiceTestSuite é a suíte de testes para o serviço de fraude
+type ServiceTestSuite struct {
+	suite.Suite
+	repo     *fraudRepoStub
+	notifier *notifierStub
+	service  Service
+	subject  Subject
+}
+
+func TestServiceSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
+
+func (suite *ServiceTestSuite) SetupTest() {
+	suite.repo = &fraudRepoStub{}
+	suite.notifier = &notifierStub{}
+	suite.service = NewService(suite.repo, suite.notifier, Config{
+		MaxTravelSpeed: 900,
+		BuddyWindow:    30 * time.Second,
+		BuddyThreshold: 5,
+	})
+
+	// Check-in na Avenida Paulista, em São Paulo
+	location, _ := value_objects.NewLocation(-23.5614, -46.6559)
+	suite.subject = Subject{
+		CheckinID:  value_objects.NewUUID(),
+		TenantID:   value_objects.NewUUID(),
+		EventID:    value_objects.NewUUID(),
+		EmployeeID: value_objects.NewUUID(),
+		Location:   location,
+		At:         time.Now(),
+	}
+}
+
+// previousAt cria o registro anterior do funcionário no local e instante informados
+func previousAt(latitude, longitude float64, at time.Time) *Record {
+	location, _ := value_objects.NewLocation(latitude, longitude)
+	return &Record{ID: value_objects.NewUUID(), Kind: "checkout", Location: location, At: at}
+}
+
+func (suite *ServiceTestSuite) TestAnalyzeCheckin_FlagsImpossibleTravel() {
+	// Arrange: registro anterior no Rio de Janeiro (~360 km) 10 minutos antes
+	suite.repo.previous = previousAt(-22.9068, -43.1729, suite.subject.At.Add(-10*time.Minute))
+
+	// Act
+	flags, err := suite.service.AnalyzeCheckin(context.Background(), suite.subject)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), flags, 1)
+	assert.Equal(suite.T(), constants.FraudFlagImpossibleTravel, flags[0].Type)
+	assert.Greater(suite.T(), flags[0].Evidence["speed_kmh"], 900.0)
+}
+
+func (suite *ServiceTestSuite) TestAnalyzeCheckin_AcceptsPlausibleTravel() {
+	// Arrange: mesmo trajeto em 6 horas
+	suite.repo.previous = previousAt(-22.9068, -43.1729, suite.subject.At.Add(-6*time.Hour))
+
+	// Act
+	flags, err := suite.service.AnalyzeCheckin(context.Background(), suite.subject)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), flags)
+}
+
+func (suite *ServiceTestSuite) TestAnalyzeCheckin_FlagsBuddyPunchingAndReplayedEmbedding() {
+	// Arrange
+	suite.subject.DeviceID = "kiosk-portao-a"
+	suite.subject.FaceEmbedding = []float32{0.1, 0.2, 0.3}
+	suite.repo.deviceEmployees = 4
+	suite.repo.matches = []*Record{{ID: value_objects.NewUUID(), Kind: "checkin", EmployeeID: value_objects.NewUUID()}}
+
+	// Act
+	flags, err := suite.service.AnalyzeCheckin(context.Background(), suite.subject)
+	suite.service.ReportFlags(context.Background(), suite.subject, flags)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), flags, 2)
+	assert.Equal(suite.T(), constants.FraudFlagBuddyPunching, flags[0].Type)
+	assert.Equal(suite.T(), 5, flags[0].Evidence["distinct_employees"])
+	assert.Equal(suite.T(), constants.FraudFlagDuplicateFaceEmbedding, flags[1].Type)
+	assert.Len(suite.T(), suite.notifier.flags, 2)
+}
+
+func (suite *ServiceTestSuite) TestAnalyzeCheckin_ReportsIncompleteAnalysis() {
+	// Arrange
+	suite.subject.DeviceID = "kiosk-portao-b"
+	suite.subject.FaceEmbedding = []float32{0.4, 0.5}
+	suite.repo.deviceErr = stderrors.New("connection refused")
+	suite.repo.matches = []*Record{{ID: value_objects.NewUUID(), Kind: "checkin", EmployeeID: value_objects.NewUUID()}}
+
+	// Act
+	flags, err := suite.service.AnalyzeCheckin(context.Background(), suite.subject)
+
+	// Assert: as verificações restantes continuam valendo
+	assert.Error(suite.T(), err)
+	assert.Len(suite.T(), flags, 1)
+	assert.Equal(suite.T(), constants.FraudFlagDuplicateFaceEmbedding, flags[0].Type)
+}
+
+func (suite *ServiceTestSuite) TestReviewCheckin_RecordsDecisionOnce() {
+	// Arrange
+	suite.repo.flagged = &FlaggedCheckin{
+		CheckinID: suite.subject.CheckinID,
+		TenantID:  suite.subject.TenantID,
+		Flags:     []Flag{{Type: constants.FraudFlagBuddyPunching}},
+	}
+	reviewerID := value_objects.NewUUID()
+
+	// Act
+	reviewed, err := suite.service.ReviewCheckin(context.Background(), suite.subject.TenantID, suite.subject.CheckinID, "dismissed", "Fila de entrada no turno", reviewerID)
+	_, againErr := suite.service.ReviewCheckin(context.Background(), suite.subject.TenantID, suite.subject.CheckinID, "confirmed", "", reviewerID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.FraudReviewDismissed, reviewed.ReviewStatus())
+	assert.Equal(suite.T(), "VALIDATION_ERROR", againErr.(*errors.DomainError).Type)
+	assert.Equal(suite.T(), 1, suite.repo.reviews)
+}
@@ -0,0 +1,170 @@
+package occupancy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eventos-backend/internal/domain/event"
+	. "eventos-backend/internal/domain/occupancy"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// occupancyRepoStub implementa apenas os métodos de Repository usados pelo serviço
+type occupancyRepoStub struct {
+	Repository
+	onSite     []*PartnerHeadcount
+	capacities map[value_objects.UUID]int
+}
+
+func (r *occupancyRepoStub) CountOnSite(ctx context.Context, eventID value_objects.UUID) ([]*PartnerHeadcount, error) {
+	return r.onSite, nil
+}
+
+func (r *occupancyRepoStub) GetPartnerCapacities(ctx context.Context, eventID value_objects.UUID) (map[value_objects.UUID]int, error) {
+	return r.capacities, nil
+}
+
+// counterStub mantém os contadores em memória
+type counterStub struct {
+	seeded    bool
+	total     int64
+	byPartner map[value_objects.UUID]int64
+}
+
+func (c *counterStub) Get(ctx context.Context, eventID value_objects.UUID) (int64, map[value_objects.UUID]int64, bool, error) {
+	return c.total, c.byPartner, c.seeded, nil
+}
+
+func (c *counterStub) Add(ctx context.Context, eventID, partnerID value_objects.UUID, delta int64) (int64, int64, bool, error) {
+	if !c.seeded {
+		return 0, 0, false, nil
+	}
+	c.total += delta
+	c.byPartner[partnerID] += delta
+	return c.total, c.byPartner[partnerID], true, nil
+}
+
+func (c *counterStub) Reset(ctx context.Context, eventID value_objects.UUID, partners []*PartnerHeadcount) error {
+	c.seeded, c.total, c.byPartner = true, 0, make(map[value_objects.UUID]int64)
+	for _, partner := range partners {
+		c.byPartner[partner.PartnerID] = int64(partner.OnSite)
+		c.total += int64(partner.OnSite)
+	}
+	return nil
+}
+
+func (c *counterStub) Clear(ctx context.Context, eventID value_objects.UUID) error {
+	c.seeded, c.total, c.byPartner = false, 0, nil
+	return nil
+}
+
+// eventRepoStub implementa apenas os métodos de event.Repository usados pelo serviço
+type eventRepoStub struct {
+	event.Repository
+	evt *event.Event
+}
+
+func (r *eventRepoStub) GetByID(ctx context.Context, id value_objects.UUID) (*event.Event, error) {
+	return r.evt, nil
+}
+
+// ServiceTestSuite é a suíte de testes para o serviço de ocupação
+type ServiceTestSuite struct {
+	suite.Suite
+	event     *event.Event
+	partnerID value_objects.UUID
+	repo      *occupancyRepoStub
+	counter   *counterStub
+	service   Service
+}
+
+func TestServiceSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
+
+func (suite *ServiceTestSuite) SetupTest() {
+	suite.event = &event.Event{
+		ID:           value_objects.NewUUID(),
+		TenantID:     value_objects.NewUUID(),
+		InitialDate:  time.Now().UTC().Add(-time.Hour),
+		FinalDate:    time.Now().UTC().Add(time.Hour),
+		MaxOccupancy: 2,
+		Active:       true,
+	}
+	suite.partnerID = value_objects.NewUUID()
+	suite.repo = &occupancyRepoStub{
+		onSite:     []*PartnerHeadcount{{PartnerID: suite.partnerID, OnSite: 1}},
+		capacities: map[value_objects.UUID]int{},
+	}
+	suite.counter = &counterStub{}
+	suite.service = NewService(suite.repo, suite.counter, &eventRepoStub{evt: suite.event})
+}
+
+func (suite *ServiceTestSuite) TestAdmit_SeedsCounterAndReserves() {
+	// Act
+	admitted, reasonCode, err := suite.service.Admit(context.Background(), suite.event.ID, suite.partnerID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), admitted)
+	assert.Empty(suite.T(), reasonCode)
+	assert.Equal(suite.T(), int64(2), suite.counter.total)
+}
+
+func (suite *ServiceTestSuite) TestAdmit_EventAtCapacity() {
+	// Arrange
+	suite.repo.onSite[0].OnSite = 2
+
+	// Act
+	admitted, reasonCode, err := suite.service.Admit(context.Background(), suite.event.ID, suite.partnerID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), admitted)
+	assert.Equal(suite.T(), constants.EligibilityEventAtCapacity, reasonCode)
+	assert.Equal(suite.T(), int64(2), suite.counter.total, "a reserva recusada deve ser desfeita")
+}
+
+func (suite *ServiceTestSuite) TestAdmit_PartnerAtCapacity() {
+	// Arrange
+	suite.event.MaxOccupancy = 0
+	suite.repo.capacities[suite.partnerID] = 1
+
+	// Act
+	admitted, reasonCode, err := suite.service.Admit(context.Background(), suite.event.ID, suite.partnerID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), admitted)
+	assert.Equal(suite.T(), constants.EligibilityPartnerAtCapacity, reasonCode)
+	assert.Equal(suite.T(), int64(1), suite.counter.byPartner[suite.partnerID])
+}
+
+func (suite *ServiceTestSuite) TestAdmit_WithoutCounterUsesDatabaseCount() {
+	// Arrange
+	suite.repo.onSite[0].OnSite = 2
+	service := NewService(suite.repo, nil, &eventRepoStub{evt: suite.event})
+
+	// Act
+	admitted, reasonCode, err := service.Admit(context.Background(), suite.event.ID, suite.partnerID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), admitted)
+	assert.Equal(suite.T(), constants.EligibilityEventAtCapacity, reasonCode)
+}
+
+func (suite *ServiceTestSuite) TestAvailableSpots() {
+	// Arrange
+	limited := &Headcount{OnSite: 3, Capacity: 5}
+	unlimited := &Headcount{OnSite: 3}
+
+	// Act & Assert
+	assert.Equal(suite.T(), 2, limited.AvailableSpots())
+	assert.Equal(suite.T(), -1, unlimited.AvailableSpots())
+}
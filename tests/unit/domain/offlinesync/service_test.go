@@ -0,0 +1,264 @@
+package offlinesync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eventos-backend/internal/domain/checkin"
+	"eventos-backend/internal/domain/checkout"
+	"eventos-backend/internal/domain/device"
+	. "eventos-backend/internal/domain/offlinesync"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// checkinStore simula a persistência de check-ins compartilhada entre o serviço e o repositório
+type checkinStore struct {
+	checkin.Repository
+	byClientID map[value_objects.UUID]*checkin.Checkin
+}
+
+func (r *checkinStore) GetByID(ctx context.Context, id value_objects.UUID) (*checkin.Checkin, error) {
+	for _, c := range r.byClientID {
+		if c.ID.Equals(id) {
+			return c, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *checkinStore) GetByClientID(ctx context.Context, clientID value_objects.UUID) (*checkin.Checkin, error) {
+	if c, ok := r.byClientID[clientID]; ok {
+		return c, nil
+	}
+	return nil, errors.ErrNotFound
+}
+
+// checkoutRepoStub implementa apenas os métodos de checkout.Repository usados pelo serviço
+type checkoutRepoStub struct {
+	checkout.Repository
+}
+
+func (r *checkoutRepoStub) GetByClientID(ctx context.Context, clientID value_objects.UUID) (*checkout.Checkout, error) {
+	return nil, errors.ErrNotFound
+}
+
+// checkinServiceStub registra os check-ins aplicados
+type checkinServiceStub struct {
+	checkin.Service
+	store   *checkinStore
+	applied []time.Time
+	reject  error
+}
+
+func (s *checkinServiceStub) PerformCheckin(ctx context.Context, request checkin.CheckinRequest) (*checkin.Checkin, *checkin.ValidationResult, error) {
+	if s.reject != nil {
+		return nil, nil, s.reject
+	}
+
+	c := &checkin.Checkin{
+		ID:          value_objects.NewUUID(),
+		TenantID:    request.TenantID,
+		EventID:     request.EventID,
+		EmployeeID:  request.EmployeeID,
+		PartnerID:   request.PartnerID,
+		CheckinTime: *request.RecordedAt,
+		ClientID:    request.ClientID,
+		DeviceID:    request.DeviceID,
+		IsValid:     true,
+	}
+	s.store.byClientID[*request.ClientID] = c
+	s.applied = append(s.applied, *request.RecordedAt)
+
+	return c, checkin.NewValidationResult(true, "ok"), nil
+}
+
+// checkoutServiceStub registra os check-outs aplicados
+type checkoutServiceStub struct {
+	checkout.Service
+	requests []checkout.CheckoutRequest
+}
+
+func (s *checkoutServiceStub) PerformCheckout(ctx context.Context, request checkout.CheckoutRequest) (*checkout.Checkout, *checkout.ValidationResult, error) {
+	s.requests = append(s.requests, request)
+	return &checkout.Checkout{ID: value_objects.NewUUID(), IsValid: true}, checkout.NewValidationResult(true, "ok"), nil
+}
+
+// ServiceTestSuite é a suíte de testes para o serviço de sincronização offline
+type ServiceTestSuite struct {
+	suite.Suite
+	tenantID        value_objects.UUID
+	store           *checkinStore
+	checkinService  *checkinServiceStub
+	checkoutService *checkoutServiceStub
+	device          *device.Device
+	service         Service
+}
+
+func TestServiceSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
+
+func (suite *ServiceTestSuite) SetupTest() {
+	suite.tenantID = value_objects.NewUUID()
+	suite.store = &checkinStore{byClientID: make(map[value_objects.UUID]*checkin.Checkin)}
+	suite.checkinService = &checkinServiceStub{store: suite.store}
+	suite.checkoutService = &checkoutServiceStub{}
+	suite.device = &device.Device{ID: value_objects.NewUUID(), TenantID: suite.tenantID, Active: true}
+	suite.service = NewService(suite.checkinService, suite.checkoutService, suite.store, &checkoutRepoStub{})
+}
+
+func (suite *ServiceTestSuite) batch(records ...Record) BatchRequest {
+	return BatchRequest{
+		TenantID:    suite.tenantID,
+		Device:      suite.device,
+		Records:     records,
+		SubmittedBy: value_objects.NewUUID(),
+	}
+}
+
+func checkinRecord(recordedAt time.Time) Record {
+	return Record{
+		ClientID:   value_objects.NewUUID(),
+		Type:       constants.SyncRecordTypeCheckin,
+		RecordedAt: recordedAt,
+		EventID:    value_objects.NewUUID(),
+		EmployeeID: value_objects.NewUUID(),
+		PartnerID:  value_objects.NewUUID(),
+		Method:     constants.CheckMethodManual,
+	}
+}
+
+func (suite *ServiceTestSuite) TestSyncBatch_AppliesInChronologicalOrder() {
+	// Arrange
+	base := time.Now().UTC().Add(-time.Hour)
+	late := checkinRecord(base.Add(10 * time.Minute))
+	early := checkinRecord(base)
+
+	// Act
+	result, err := suite.service.SyncBatch(context.Background(), suite.batch(late, early))
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 2, result.Accepted)
+	assert.Equal(suite.T(), []time.Time{early.RecordedAt, late.RecordedAt}, suite.checkinService.applied)
+	assert.Equal(suite.T(), late.ClientID, result.Results[0].ClientID)
+	assert.Equal(suite.T(), early.ClientID, result.Results[1].ClientID)
+}
+
+func (suite *ServiceTestSuite) TestSyncBatch_ResendIsIdempotent() {
+	// Arrange
+	record := checkinRecord(time.Now().UTC().Add(-time.Minute))
+	first, err := suite.service.SyncBatch(context.Background(), suite.batch(record))
+	suite.Require().NoError(err)
+
+	// Act
+	second, err := suite.service.SyncBatch(context.Background(), suite.batch(record, record))
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 2, second.Duplicates)
+	assert.Len(suite.T(), suite.checkinService.applied, 1)
+	for _, item := range second.Results {
+		assert.Equal(suite.T(), constants.SyncStatusDuplicate, item.Status)
+		assert.Equal(suite.T(), *first.Results[0].ResourceID, *item.ResourceID)
+	}
+}
+
+func (suite *ServiceTestSuite) TestSyncBatch_CheckoutResolvesOfflineCheckin() {
+	// Arrange
+	start := time.Now().UTC().Add(-2 * time.Hour)
+	in := checkinRecord(start)
+	checkinClientID := in.ClientID
+	out := Record{
+		ClientID:        value_objects.NewUUID(),
+		Type:            constants.SyncRecordTypeCheckout,
+		RecordedAt:      start.Add(time.Hour),
+		CheckinClientID: &checkinClientID,
+		Method:          constants.CheckMethodManual,
+	}
+
+	// Act - check-out enviado antes do check-in no lote
+	result, err := suite.service.SyncBatch(context.Background(), suite.batch(out, in))
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 2, result.Accepted)
+	suite.Require().Len(suite.checkoutService.requests, 1)
+	request := suite.checkoutService.requests[0]
+	assert.Equal(suite.T(), suite.store.byClientID[in.ClientID].ID, request.CheckinID)
+	assert.Equal(suite.T(), in.EmployeeID, request.EmployeeID)
+	assert.Equal(suite.T(), suite.device.ID.String(), request.DeviceID)
+}
+
+func (suite *ServiceTestSuite) TestSyncBatch_RejectsWithReason() {
+	// Arrange
+	future := checkinRecord(time.Now().UTC().Add(time.Hour))
+	ineligible := checkinRecord(time.Now().UTC().Add(-time.Minute))
+	orphan := Record{
+		ClientID:   value_objects.NewUUID(),
+		Type:       constants.SyncRecordTypeCheckout,
+		RecordedAt: time.Now().UTC().Add(-time.Minute),
+		Method:     constants.CheckMethodManual,
+	}
+	suite.checkinService.reject = errors.NewValidationError("Checkin", "evento já terminou").
+		WithContext("reason_code", constants.EligibilityEventFinished)
+
+	// Act
+	result, err := suite.service.SyncBatch(context.Background(), suite.batch(future, ineligible, orphan))
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 3, result.Rejected)
+	assert.Equal(suite.T(), constants.SyncRejectFutureTimestamp, result.Results[0].ReasonCode)
+	assert.Equal(suite.T(), constants.EligibilityEventFinished, result.Results[1].ReasonCode)
+	assert.Equal(suite.T(), constants.SyncRejectInvalidRecord, result.Results[2].ReasonCode)
+}
+
+func (suite *ServiceTestSuite) TestSyncBatch_RequiresAuthenticatedDevice() {
+	// Arrange
+	record := checkinRecord(time.Now().UTC().Add(-time.Minute))
+	record.Method = constants.CheckMethodQRCode
+	record.QRCodeData = "token"
+	anonymous := suite.batch(record)
+	anonymous.Device = nil
+
+	// Act
+	result, err := suite.service.SyncBatch(context.Background(), anonymous)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	assert.Empty(suite.T(), suite.checkinService.applied)
+}
+
+func (suite *ServiceTestSuite) TestSyncBatch_CheckoutChecksDeviceBindingOfCheckinEvent() {
+	// Arrange - check-in de outro evento, check-out sem event_id
+	in := checkinRecord(time.Now().UTC().Add(-2 * time.Hour))
+	_, err := suite.service.SyncBatch(context.Background(), suite.batch(in))
+	suite.Require().NoError(err)
+	checkinClientID := in.ClientID
+	out := Record{
+		ClientID:        value_objects.NewUUID(),
+		Type:            constants.SyncRecordTypeCheckout,
+		RecordedAt:      time.Now().UTC().Add(-time.Hour),
+		CheckinClientID: &checkinClientID,
+		Method:          constants.CheckMethodManual,
+	}
+	boundEventID := value_objects.NewUUID()
+	signed := suite.batch(out)
+	signed.Device = &device.Device{ID: value_objects.NewUUID(), TenantID: suite.tenantID, EventID: &boundEventID, Active: true}
+
+	// Act
+	result, err := suite.service.SyncBatch(context.Background(), signed)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), constants.SyncRejectDeviceNotBound, result.Results[0].ReasonCode)
+	assert.Empty(suite.T(), suite.checkoutService.requests)
+}
@@ -0,0 +1,122 @@
+package qrcode
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	. "eventos-backend/internal/domain/qrcode"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/value_objects"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// repoStub devolve o QR Code emitido e registra o instante de cada uso
+type repoStub struct {
+	Repository
+	qr     *QRCode
+	usedAt []time.Time
+}
+
+func (r *repoStub) GetByID(ctx context.Context, id value_objects.UUID) (*QRCode, error) {
+	return r.qr, nil
+}
+
+func (r *repoStub) RegisterUsage(ctx context.Context, id value_objects.UUID, at time.Time) (bool, error) {
+	r.usedAt = append(r.usedAt, at)
+	return true, nil
+}
+
+// QRCodeTestSuite é a suíte de testes para QRCode
+type QRCodeTestSuite struct {
+	suite.Suite
+	secret []byte
+}
+
+func TestQRCodeSuite(t *testing.T) {
+	suite.Run(t, new(QRCodeTestSuite))
+}
+
+func (suite *QRCodeTestSuite) SetupTest() {
+	suite.secret = []byte("segredo-de-teste")
+}
+
+func (suite *QRCodeTestSuite) TestNewQRCode_SignedTokenRoundTrip() {
+	// Arrange
+	eventID := value_objects.NewUUID()
+
+	// Act
+	qr, err := NewQRCode(eventID, constants.QRTypeCheckin, time.Minute, 1, suite.secret, value_objects.NewUUID())
+	suite.Require().NoError(err)
+	claims, err := ParseToken(qr.Token, suite.secret)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), qr.ID, claims.QRCodeID)
+	assert.Equal(suite.T(), eventID, claims.EventID)
+	assert.Equal(suite.T(), constants.QRTypeCheckin, claims.Type)
+	assert.Equal(suite.T(), qr.ValidUntil.Unix(), claims.ValidUntil.Unix())
+	assert.True(suite.T(), qr.IsValidAt(time.Now().UTC()))
+	assert.False(suite.T(), qr.IsValidAt(qr.ValidUntil))
+}
+
+func (suite *QRCodeTestSuite) TestParseToken_TamperedPayload() {
+	// Arrange
+	qr, err := NewQRCode(value_objects.NewUUID(), constants.QRTypeCheckin, time.Minute, 1, suite.secret, value_objects.NewUUID())
+	suite.Require().NoError(err)
+	other, err := NewQRCode(value_objects.NewUUID(), constants.QRTypeCheckout, time.Minute, 1, suite.secret, value_objects.NewUUID())
+	suite.Require().NoError(err)
+
+	// Act - combinar o payload de um token com a assinatura de outro
+	tampered := other.Token[:indexOfDot(other.Token)] + qr.Token[indexOfDot(qr.Token):]
+	_, err = ParseToken(tampered, suite.secret)
+
+	// Assert
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QRCodeTestSuite) TestParseToken_WrongSecret() {
+	// Arrange
+	qr, err := NewQRCode(value_objects.NewUUID(), constants.QRTypeCheckout, time.Minute, 1, suite.secret, value_objects.NewUUID())
+	suite.Require().NoError(err)
+
+	// Act
+	_, err = ParseToken(qr.Token, []byte("outro-segredo"))
+
+	// Assert
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QRCodeTestSuite) TestNewQRCode_InvalidType() {
+	// Act
+	_, err := NewQRCode(value_objects.NewUUID(), "invalid", time.Minute, 1, suite.secret, value_objects.NewUUID())
+
+	// Assert
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QRCodeTestSuite) TestConsumeQRCode_IgnoresStaleScanTime() {
+	// Arrange
+	qr, err := NewQRCode(value_objects.NewUUID(), constants.QRTypeCheckin, time.Minute, 1, suite.secret, value_objects.NewUUID())
+	suite.Require().NoError(err)
+	repo := &repoStub{qr: qr}
+	service := NewService(repo, nil, Config{Secret: string(suite.secret)})
+	staleScan := time.Now().UTC().Add(-(constants.OfflineSyncMaxQRCodeAge + 60) * time.Second)
+
+	// Act - horário de leitura antigo informado pelo dispositivo
+	consumed, err := service.ConsumeQRCode(context.Background(), qr.Token, qr.EventID, constants.QRTypeCheckin, staleScan)
+
+	// Assert - a validade e o uso são registrados no horário do servidor
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 1, consumed.UsageCount)
+	suite.Require().Len(repo.usedAt, 1)
+	assert.True(suite.T(), repo.usedAt[0].After(staleScan.Add(constants.OfflineSyncMaxQRCodeAge*time.Second)))
+}
+
+// indexOfDot retorna a posição do separador entre payload e assinatura
+func indexOfDot(token string) int {
+	return strings.Index(token, ".")
+}
@@ -0,0 +1,179 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "eventos-backend/internal/domain/retention"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/domain/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+// repoStub devolve as políticas configuradas e elimina os registros pendentes de cada classe em lotes
+type repoStub struct {
+	Repository
+	policies []*Policy
+	pending  map[string]int
+	photos   []ExpiredPhoto
+	cutoffs  map[string]time.Time
+	cleared  int
+	recorded []*Report
+}
+
+func (r *repoStub) ListPolicies(ctx context.Context, tenantID value_objects.UUID) ([]*Policy, error) {
+	return r.policies, nil
+}
+
+func (r *repoStub) Count(ctx context.Context, tenantID value_objects.UUID, dataClass string, cutoff time.Time) (int, int, error) {
+	r.cutoffs[dataClass] = cutoff
+	if dataClass == constants.RetentionClassPhotos {
+		return len(r.photos), len(r.photos), nil
+	}
+	return r.pending[dataClass], 0, nil
+}
+
+func (r *repoStub) PurgeBatch(ctx context.Context, tenantID value_objects.UUID, dataClass string, cutoff time.Time, limit int) (int, error) {
+	purged := r.pending[dataClass]
+	if purged > limit {
+		purged = limit
+	}
+	r.pending[dataClass] -= purged
+	return purged, nil
+}
+
+func (r *repoStub) ListExpiredPhotos(ctx context.Context, tenantID value_objects.UUID, cutoff time.Time, limit int) ([]ExpiredPhoto, error) {
+	if len(r.photos) > limit {
+		return r.photos[:limit], nil
+	}
+	return r.photos, nil
+}
+
+func (r *repoStub) ClearPhotos(ctx context.Context, tenantID value_objects.UUID, photos []ExpiredPhoto) (int, error) {
+	r.photos = r.photos[len(photos):]
+	r.cleared += len(photos)
+	return len(photos), nil
+}
+
+func (r *repoStub) RecordPurge(ctx context.Context, report *Report, triggeredBy *value_objects.UUID) error {
+	r.recorded = append(r.recorded, report)
+	return nil
+}
+
+// photoStorageStub registra as remoções e falha nas chaves configuradas
+type photoStorageStub struct {
+	storage.Service
+	deleted  []string
+	failKeys map[string]bool
+}
+
+func (s *photoStorageStub) Delete(ctx context.Context, tenantID value_objects.UUID, key string) error {
+	if s.failKeys[key] {
+		return fmt.Errorf("storage unavailable")
+	}
+	s.deleted = append(s.deleted, key)
+	return nil
+}
+
+// ServiceTestSuite é a suíte de testes para a eliminação por retenção
+type ServiceTestSuite struct {
+	suite.Suite
+	repo     *repoStub
+	photos   *photoStorageStub
+	service  Service
+	tenantID value_objects.UUID
+	now      time.Time
+}
+
+func TestServiceSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
+
+func (suite *ServiceTestSuite) SetupTest() {
+	suite.tenantID = value_objects.NewUUID()
+	suite.now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	suite.repo = &repoStub{pending: map[string]int{}, cutoffs: map[string]time.Time{}}
+	suite.photos = &photoStorageStub{failKeys: map[string]bool{}}
+	suite.service = NewService(suite.repo, suite.photos, zap.NewNop())
+}
+
+// policy cria a política de retenção de uma classe para o tenant da suíte
+func (suite *ServiceTestSuite) policy(dataClass string, days int) *Policy {
+	policy, err := NewPolicy(suite.tenantID, dataClass, days, value_objects.NewUUID())
+	suite.Require().NoError(err)
+	return policy
+}
+
+func (suite *ServiceTestSuite) TestPreview_CountsWithoutPurging() {
+	// Arrange
+	suite.repo.policies = []*Policy{suite.policy(constants.RetentionClassLogs, 365), suite.policy(constants.RetentionClassBiometrics, 30)}
+	suite.repo.pending[constants.RetentionClassBiometrics] = 12
+	suite.repo.pending[constants.RetentionClassLogs] = 40
+
+	// Act
+	report, err := suite.service.Preview(context.Background(), suite.tenantID, suite.now)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.True(suite.T(), report.DryRun)
+	assert.Equal(suite.T(), constants.RetentionClassBiometrics, report.Classes[0].DataClass)
+	assert.Equal(suite.T(), 52, report.Total())
+	assert.Equal(suite.T(), suite.now.AddDate(0, 0, -30), suite.repo.cutoffs[constants.RetentionClassBiometrics])
+	assert.Equal(suite.T(), 12, suite.repo.pending[constants.RetentionClassBiometrics])
+	assert.Empty(suite.T(), suite.repo.recorded)
+}
+
+func (suite *ServiceTestSuite) TestPurge_ProcessesBatchesAndRecordsAudit() {
+	// Arrange
+	suite.repo.policies = []*Policy{suite.policy(constants.RetentionClassLocations, 90)}
+	suite.repo.pending[constants.RetentionClassLocations] = 25
+
+	// Act
+	report, err := suite.service.Purge(context.Background(), suite.tenantID, suite.now, 10, nil)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.False(suite.T(), report.DryRun)
+	assert.Equal(suite.T(), 25, report.Total())
+	assert.Zero(suite.T(), suite.repo.pending[constants.RetentionClassLocations])
+	suite.Require().Len(suite.repo.recorded, 1)
+	assert.Equal(suite.T(), report.ID, suite.repo.recorded[0].ID)
+}
+
+func (suite *ServiceTestSuite) TestPurge_PhotoFailureKeepsReferencesForRetry() {
+	// Arrange
+	suite.repo.policies = []*Policy{suite.policy(constants.RetentionClassPhotos, 180)}
+	suite.repo.photos = []ExpiredPhoto{
+		{Source: "checkin", RecordID: value_objects.NewUUID(), Key: "tenants/t/checkins/a.jpg"},
+		{Source: "checkout", RecordID: value_objects.NewUUID()},
+		{Source: "checkin", RecordID: value_objects.NewUUID(), Key: "tenants/t/checkins/b.jpg"},
+	}
+	suite.photos.failKeys["tenants/t/checkins/b.jpg"] = true
+
+	// Act
+	report, err := suite.service.Purge(context.Background(), suite.tenantID, suite.now, 10, nil)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), []string{"tenants/t/checkins/a.jpg"}, suite.photos.deleted)
+	assert.Zero(suite.T(), suite.repo.cleared)
+	assert.Equal(suite.T(), 1, report.Classes[0].Photos)
+	assert.Empty(suite.T(), suite.repo.recorded)
+}
+
+func (suite *ServiceTestSuite) TestSetPolicy_RejectsUnknownDataClass() {
+	// Act
+	_, err := suite.service.SetPolicy(context.Background(), suite.tenantID, "employees", 30, value_objects.NewUUID())
+
+	// Assert
+	var domainErr *errors.DomainError
+	suite.Require().ErrorAs(err, &domainErr)
+	assert.Equal(suite.T(), "data_class", domainErr.Context["field"])
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	. "eventos-backend/internal/domain/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// blobStoreStub guarda os objetos em memória
+type blobStoreStub struct {
+	objects map[string][]byte
+	deleted []string
+}
+
+func (s *blobStoreStub) Put(ctx context.Context, key, contentType string, size int64, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	s.objects[key] = data
+	return err
+}
+
+func (s *blobStoreStub) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	return io.NopCloser(bytes.NewReader(s.objects[key])), &Object{Key: key}, nil
+}
+
+func (s *blobStoreStub) Delete(ctx context.Context, key string) error {
+	s.deleted = append(s.deleted, key)
+	return nil
+}
+
+func (s *blobStoreStub) SignedURL(key string, ttl time.Duration) (string, error) {
+	return "https://files.example.com/" + key, nil
+}
+
+// ServiceTestSuite é a suíte de testes para o serviço de armazenamento de fotos
+type ServiceTestSuite struct {
+	suite.Suite
+	store    *blobStoreStub
+	service  Service
+	tenantID value_objects.UUID
+}
+
+func TestServiceSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
+
+func (suite *ServiceTestSuite) SetupTest() {
+	suite.store = &blobStoreStub{objects: make(map[string][]byte)}
+	suite.service = NewService(suite.store, Config{MaxPhotoSize: 64, SignedURLTTL: time.Minute})
+	suite.tenantID = value_objects.NewUUID()
+}
+
+// upload monta o envio de uma foto do funcionário com o conteúdo informado
+func (suite *ServiceTestSuite) upload(contentType string, content []byte) Upload {
+	return Upload{
+		TenantID:    suite.tenantID,
+		Category:    constants.StorageCategoryEmployeePhoto,
+		OwnerID:     value_objects.NewUUID(),
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		Content:     bytes.NewReader(content),
+	}
+}
+
+func (suite *ServiceTestSuite) TestStorePhoto_StoresUnderTenantPrefix() {
+	// Arrange
+	png := append([]byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}, 0x00, 0x01)
+
+	// Act
+	object, err := suite.service.StorePhoto(context.Background(), suite.upload("image/png", png))
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.True(suite.T(), strings.HasPrefix(object.Key, "tenants/"+suite.tenantID.String()+"/employee-photos/"))
+	assert.True(suite.T(), strings.HasSuffix(object.Key, ".png"))
+	assert.Equal(suite.T(), png, suite.store.objects[object.Key])
+}
+
+func (suite *ServiceTestSuite) TestStorePhoto_RejectionReasons() {
+	jpeg := []byte{0xFF, 0xD8, 0xFF, 0xE0}
+	oversized := suite.upload("image/jpeg", append(jpeg, make([]byte, 64)...))
+	oversized.Size = 0 // Tamanho declarado pelo cliente não é confiável
+
+	cases := map[string]Upload{
+		constants.StorageRejectUnsupportedType: suite.upload("application/pdf", []byte("%PDF-1.7")),
+		constants.StorageRejectEmpty:           suite.upload("image/jpeg", nil),
+		constants.StorageRejectTooLarge:        oversized,
+		constants.StorageRejectContentMismatch: suite.upload("image/png", jpeg),
+	}
+
+	for reason, upload := range cases {
+		// Act
+		_, err := suite.service.StorePhoto(context.Background(), upload)
+
+		// Assert
+		suite.Require().Error(err, reason)
+		assert.Equal(suite.T(), reason, err.(*errors.DomainError).Context["reason_code"])
+	}
+	assert.Empty(suite.T(), suite.store.objects)
+}
+
+func (suite *ServiceTestSuite) TestSignedURL_HidesOtherTenantsFiles() {
+	// Arrange
+	key := ObjectKey(suite.tenantID, constants.StorageCategoryCheckinPhoto, value_objects.NewUUID(), "image/jpeg")
+
+	// Act
+	signed, err := suite.service.SignedURL(suite.tenantID, key)
+	_, otherErr := suite.service.SignedURL(value_objects.NewUUID(), key)
+	_, traversalErr := suite.service.SignedURL(suite.tenantID, "tenants/"+suite.tenantID.String()+"/../other/photo.jpg")
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.WithinDuration(suite.T(), time.Now().Add(time.Minute), signed.ExpiresAt, 5*time.Second)
+	assert.Equal(suite.T(), "NOT_FOUND", otherErr.(*errors.DomainError).Type)
+	assert.Equal(suite.T(), "NOT_FOUND", traversalErr.(*errors.DomainError).Type)
+}
@@ -0,0 +1,133 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"eventos-backend/internal/domain/occupancy"
+	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/infrastructure/cache"
+	redisClient "eventos-backend/internal/infrastructure/cache/redis"
+)
+
+// OccupancyCounterTestSuite é a suíte de testes para o contador de ocupação no Redis
+type OccupancyCounterTestSuite struct {
+	suite.Suite
+	mini    *miniredis.Miniredis
+	client  *redisClient.Client
+	counter *cache.OccupancyCounter
+	eventID value_objects.UUID
+}
+
+func TestOccupancyCounterSuite(t *testing.T) {
+	suite.Run(t, new(OccupancyCounterTestSuite))
+}
+
+func (suite *OccupancyCounterTestSuite) SetupTest() {
+	mini, err := miniredis.Run()
+	suite.Require().NoError(err)
+	suite.mini = mini
+
+	port, _ := strconv.Atoi(mini.Port())
+	suite.client, err = redisClient.NewClient(redisClient.Config{
+		Host:        mini.Host(),
+		Port:        port,
+		PoolSize:    10,
+		DialTimeout: time.Second,
+	}, zap.NewNop())
+	suite.Require().NoError(err)
+
+	suite.counter = cache.NewOccupancyCounter(suite.client, cache.NewDefaultKeyBuilder("eventos", time.Minute), time.Minute)
+	suite.eventID = value_objects.NewUUID()
+}
+
+func (suite *OccupancyCounterTestSuite) TearDownTest() {
+	suite.client.Close()
+	suite.mini.Close()
+}
+
+func (suite *OccupancyCounterTestSuite) TestAdd_MissingCountersAreNotCreated() {
+	// Act
+	_, _, found, err := suite.counter.Add(context.Background(), suite.eventID, value_objects.NewUUID(), 1)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.False(suite.T(), found)
+	assert.Empty(suite.T(), suite.mini.Keys(), "o incremento não deve recriar contadores expirados")
+}
+
+func (suite *OccupancyCounterTestSuite) TestResetAndAdd_KeepsEventAndPartnerCounts() {
+	// Arrange
+	ctx := context.Background()
+	partnerA, partnerB := value_objects.NewUUID(), value_objects.NewUUID()
+	suite.Require().NoError(suite.counter.Reset(ctx, suite.eventID, []*occupancy.PartnerHeadcount{
+		{PartnerID: partnerA, OnSite: 3},
+		{PartnerID: partnerB, OnSite: 2},
+	}))
+
+	// Act
+	eventCount, partnerCount, found, err := suite.counter.Add(ctx, suite.eventID, partnerA, -1)
+	total, byPartner, cached, getErr := suite.counter.Get(ctx, suite.eventID)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().NoError(getErr)
+	assert.True(suite.T(), found)
+	assert.True(suite.T(), cached)
+	assert.Equal(suite.T(), int64(4), eventCount)
+	assert.Equal(suite.T(), int64(2), partnerCount)
+	assert.Equal(suite.T(), int64(4), total)
+	assert.Equal(suite.T(), map[value_objects.UUID]int64{partnerA: 2, partnerB: 2}, byPartner)
+	assert.Len(suite.T(), suite.mini.Keys(), 1)
+	assert.Greater(suite.T(), suite.mini.TTL(suite.mini.Keys()[0]), time.Duration(0))
+}
+
+func (suite *OccupancyCounterTestSuite) TestAdd_ConcurrentIncrementsAreNotLost() {
+	// Arrange
+	ctx := context.Background()
+	partnerID := value_objects.NewUUID()
+	suite.Require().NoError(suite.counter.Reset(ctx, suite.eventID, nil))
+
+	// Act
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, _, err := suite.counter.Add(ctx, suite.eventID, partnerID, 1)
+			assert.NoError(suite.T(), err)
+		}()
+	}
+	wg.Wait()
+	total, byPartner, _, err := suite.counter.Get(ctx, suite.eventID)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(50), total)
+	assert.Equal(suite.T(), int64(50), byPartner[partnerID])
+}
+
+func (suite *OccupancyCounterTestSuite) TestClear_RemovesCounters() {
+	// Arrange
+	ctx := context.Background()
+	suite.Require().NoError(suite.counter.Reset(ctx, suite.eventID, []*occupancy.PartnerHeadcount{
+		{PartnerID: value_objects.NewUUID(), OnSite: 1},
+	}))
+
+	// Act
+	err := suite.counter.Clear(ctx, suite.eventID)
+	_, _, cached, getErr := suite.counter.Get(ctx, suite.eventID)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().NoError(getErr)
+	assert.False(suite.T(), cached)
+}
@@ -0,0 +1,218 @@
+package envelope
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"eventos-backend/internal/domain/biometric"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	. "eventos-backend/internal/infrastructure/envelope"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+// keyRepoStub guarda as chaves embrulhadas em memória
+type keyRepoStub struct {
+	biometric.KeyRepository
+	mu   sync.Mutex
+	keys []*biometric.DataKey
+}
+
+func (r *keyRepoStub) ListByTenant(ctx context.Context, tenantID value_objects.UUID) ([]*biometric.DataKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result []*biometric.DataKey
+	for _, key := range r.keys {
+		if key.TenantID.Equals(tenantID) {
+			copied := *key
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (r *keyRepoStub) ListAll(ctx context.Context) ([]*biometric.DataKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]*biometric.DataKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		copied := *key
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (r *keyRepoStub) Create(ctx context.Context, key *biometric.DataKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.keys {
+		if existing.TenantID.Equals(key.TenantID) && existing.Purpose == key.Purpose && existing.Version == key.Version {
+			return errors.NewAlreadyExistsError("biometric key", "version", key.Version)
+		}
+	}
+	copied := *key
+	r.keys = append(r.keys, &copied)
+	return nil
+}
+
+func (r *keyRepoStub) UpdateWrapping(ctx context.Context, key *biometric.DataKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.keys {
+		if existing.ID.Equals(key.ID) {
+			existing.MasterKeyID, existing.WrappedKey = key.MasterKeyID, key.WrappedKey
+		}
+	}
+	return nil
+}
+
+func (r *keyRepoStub) Retire(ctx context.Context, id value_objects.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.keys {
+		if existing.ID.Equals(id) {
+			existing.RetiredAt = &at
+		}
+	}
+	return nil
+}
+
+// ServiceTestSuite é a suíte de testes para a cifragem envelope dos dados biométricos
+type ServiceTestSuite struct {
+	suite.Suite
+	repo    *keyRepoStub
+	service *Service
+	tenant  value_objects.UUID
+}
+
+func TestServiceSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
+
+func (suite *ServiceTestSuite) SetupTest() {
+	suite.repo = &keyRepoStub{}
+	suite.service = suite.newService("v1:segredo-v1", "v1")
+	suite.tenant = value_objects.NewUUID()
+}
+
+func (suite *ServiceTestSuite) newService(spec, active string) *Service {
+	keyring, err := ParseKeyring(spec, active)
+	suite.Require().NoError(err)
+	return NewService(suite.repo, keyring, time.Minute, zap.NewNop())
+}
+
+func (suite *ServiceTestSuite) TestSealAndOpen_BindsDataToTenantAndRecord() {
+	// Arrange
+	ctx := context.Background()
+	recordID := value_objects.NewUUID()
+	aad := biometric.AssociatedData(biometric.RecordEmployee, recordID)
+
+	// Act
+	sealed, err := suite.service.Seal(ctx, suite.tenant, []byte("embedding"), aad)
+	suite.Require().NoError(err)
+	opened, openErr := suite.service.Open(ctx, suite.tenant, sealed, aad)
+	_, otherRecordErr := suite.service.Open(ctx, suite.tenant, sealed, biometric.AssociatedData(biometric.RecordEmployee, value_objects.NewUUID()))
+	_, otherTenantErr := suite.service.Open(ctx, value_objects.NewUUID(), sealed, aad)
+	_, legacyErr := suite.service.Open(ctx, suite.tenant, []byte("texto claro"), aad)
+
+	// Assert
+	suite.Require().NoError(openErr)
+	assert.Equal(suite.T(), "embedding", string(opened))
+	assert.NotContains(suite.T(), string(sealed), "embedding")
+	assert.Error(suite.T(), otherRecordErr)
+	assert.Error(suite.T(), otherTenantErr)
+	assert.ErrorIs(suite.T(), legacyErr, biometric.ErrNotSealed)
+}
+
+func (suite *ServiceTestSuite) TestSearchVector_PreservesCosineSimilarityPerTenant() {
+	// Arrange
+	ctx := context.Background()
+	a, b := embedding(1), embedding(2)
+
+	// Act
+	protectedA, err := suite.service.SearchVector(ctx, suite.tenant, a)
+	suite.Require().NoError(err)
+	protectedB, err := suite.service.SearchVector(ctx, suite.tenant, b)
+	suite.Require().NoError(err)
+	otherTenant, err := suite.service.SearchVector(ctx, value_objects.NewUUID(), a)
+	suite.Require().NoError(err)
+
+	// Assert
+	assert.InDelta(suite.T(), cosine(a, b), cosine(protectedA, protectedB), 1e-4)
+	assert.Less(suite.T(), math.Abs(cosine(a, protectedA)), 0.5)
+	assert.Less(suite.T(), math.Abs(cosine(protectedA, otherTenant)), 0.5)
+}
+
+func (suite *ServiceTestSuite) TestRotateDataKey_KeepsOldDataReadable() {
+	// Arrange
+	ctx := context.Background()
+	aad := []byte("tenants/foto.jpg")
+	before, err := suite.service.Seal(ctx, suite.tenant, []byte("antes"), aad)
+	suite.Require().NoError(err)
+
+	// Act
+	key, rotateErr := suite.service.RotateDataKey(ctx, suite.tenant)
+	after, sealErr := suite.service.Seal(ctx, suite.tenant, []byte("depois"), aad)
+	openedBefore, openErr := suite.newService("v1:segredo-v1", "v1").Open(ctx, suite.tenant, before, aad)
+
+	// Assert
+	suite.Require().NoError(rotateErr)
+	suite.Require().NoError(sealErr)
+	suite.Require().NoError(openErr)
+	assert.Equal(suite.T(), 2, key.Version)
+	assert.Equal(suite.T(), "antes", string(openedBefore))
+	assert.NotEqual(suite.T(), before[:8], after[:8])
+}
+
+func (suite *ServiceTestSuite) TestRewrap_MovesKeysToActiveMasterKey() {
+	// Arrange
+	ctx := context.Background()
+	aad := []byte("registro")
+	sealed, err := suite.service.Seal(ctx, suite.tenant, []byte("dado"), aad)
+	suite.Require().NoError(err)
+	rotated := suite.newService("v1:segredo-v1,v2:segredo-v2", "v2")
+
+	// Act
+	rewrapped, rewrapErr := rotated.Rewrap(ctx)
+	opened, openErr := suite.newService("v2:segredo-v2", "v2").Open(ctx, suite.tenant, sealed, aad)
+
+	// Assert
+	suite.Require().NoError(rewrapErr)
+	suite.Require().NoError(openErr)
+	assert.Equal(suite.T(), 2, rewrapped)
+	assert.Equal(suite.T(), "dado", string(opened))
+	for _, key := range suite.repo.keys {
+		assert.Equal(suite.T(), "v2", key.MasterKeyID)
+	}
+}
+
+// embedding gera um embedding determinístico com as dimensões do modelo
+func embedding(seed int) []float32 {
+	values := make([]float32, constants.FaceEmbeddingDimensions)
+	for i := range values {
+		values[i] = float32(math.Sin(float64((i + 1) * seed)))
+	}
+	return values
+}
+
+func cosine(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	return dot / math.Sqrt(normA*normB)
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"eventos-backend/internal/domain/event"
+	"eventos-backend/internal/domain/shared/value_objects"
+	. "eventos-backend/internal/infrastructure/persistence/postgres/repositories"
+)
+
+// EventRepositoryTestSuite compara a verificação da cerca no PostGIS com a da entidade.
+// Requer um PostgreSQL com PostGIS em TEST_DATABASE_URL; sem ele a suíte é ignorada.
+type EventRepositoryTestSuite struct {
+	suite.Suite
+	db         *sqlx.DB
+	repository event.Repository
+	evt        *event.Event
+}
+
+func TestEventRepositorySuite(t *testing.T) {
+	suite.Run(t, new(EventRepositoryTestSuite))
+}
+
+func (suite *EventRepositoryTestSuite) SetupSuite() {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		suite.T().Skip("TEST_DATABASE_URL não definido")
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	suite.Require().NoError(err)
+	db.SetMaxOpenConns(1) // A tabela temporária só existe nesta conexão
+	suite.db = db
+
+	// Tabela temporária com as colunas da cerca; encobre events sem depender das migrações aplicadas
+	_, err = db.Exec(`CREATE TEMP TABLE events (id UUID PRIMARY KEY, fence_event TEXT[], fence_tolerance_meters DOUBLE PRECISION NOT NULL DEFAULT 0)`)
+	suite.Require().NoError(err)
+
+	suite.evt = &event.Event{
+		ID: value_objects.NewUUID(),
+		FenceEvent: []value_objects.Location{
+			{Latitude: -23.5510, Longitude: -46.6343},
+			{Latitude: -23.5510, Longitude: -46.6323},
+			{Latitude: -23.5490, Longitude: -46.6323},
+			{Latitude: -23.5490, Longitude: -46.6343},
+		},
+		FenceToleranceMeters: 20,
+	}
+
+	_, err = db.Exec(`INSERT INTO events (id, fence_event, fence_tolerance_meters) VALUES ($1, $2, $3)`,
+		suite.evt.ID.String(),
+		`{"-23.5510,-46.6343","-23.5510,-46.6323","-23.5490,-46.6323","-23.5490,-46.6343"}`,
+		suite.evt.FenceToleranceMeters,
+	)
+	suite.Require().NoError(err)
+
+	suite.repository = NewEventRepository(db, zap.NewNop())
+}
+
+func (suite *EventRepositoryTestSuite) TearDownSuite() {
+	if suite.db != nil {
+		suite.db.Close()
+	}
+}
+
+func (suite *EventRepositoryTestSuite) TestCheckLocationInFence_AgreesWithEntity() {
+	cases := []struct {
+		name     string
+		location value_objects.Location
+		fix      *value_objects.LocationFix
+	}{
+		{"dentro da cerca", value_objects.Location{Latitude: -23.5500, Longitude: -46.6333}, nil},
+		{"fora, dentro da tolerância", value_objects.Location{Latitude: -23.54891, Longitude: -46.6333}, nil},
+		{"fora da tolerância", value_objects.Location{Latitude: -23.54850, Longitude: -46.6333}, nil},
+		{"fora, coberto pela precisão", value_objects.Location{Latitude: -23.54850, Longitude: -46.6333}, &value_objects.LocationFix{Accuracy: 50}},
+		{"localização ausente", value_objects.Location{}, nil},
+	}
+
+	for _, tc := range cases {
+		suite.Run(tc.name, func() {
+			// Act
+			expected := suite.evt.CheckLocationWithAccuracy(tc.location, tc.fix)
+			actual, err := suite.repository.CheckLocationInFence(context.Background(), suite.evt.ID, tc.location, tc.fix)
+
+			// Assert
+			suite.Require().NoError(err)
+			assert.Equal(suite.T(), expected.HasFence, actual.HasFence)
+			assert.Equal(suite.T(), expected.Missing, actual.Missing)
+			assert.Equal(suite.T(), expected.WithinFence, actual.WithinFence)
+			assert.Equal(suite.T(), expected.WithinTolerance, actual.WithinTolerance)
+			assert.Equal(suite.T(), expected.AccuracyMeters, actual.AccuracyMeters)
+			assert.InDelta(suite.T(), expected.DistanceToEdge, actual.DistanceToEdge, 2)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"eventos-backend/internal/domain/checkin"
+	"eventos-backend/internal/domain/checkout"
+	"eventos-backend/internal/domain/shared/value_objects"
+	"eventos-backend/internal/infrastructure/cache"
+	redisClient "eventos-backend/internal/infrastructure/cache/redis"
+	. "eventos-backend/internal/infrastructure/persistence/postgres/repositories"
+)
+
+// StatsRepositoryTestSuite é a suíte de testes para os repositórios de estatísticas
+type StatsRepositoryTestSuite struct {
+	suite.Suite
+	mini         *miniredis.Miniredis
+	cacheService *cache.CacheService
+	logger       *zap.Logger
+}
+
+func TestStatsRepositorySuite(t *testing.T) {
+	suite.Run(t, new(StatsRepositoryTestSuite))
+}
+
+func (suite *StatsRepositoryTestSuite) SetupTest() {
+	suite.logger = zap.NewNop()
+
+	mini, err := miniredis.Run()
+	suite.Require().NoError(err)
+	suite.mini = mini
+
+	port, _ := strconv.Atoi(mini.Port())
+	client, err := redisClient.NewClient(redisClient.Config{
+		Host:         mini.Host(),
+		Port:         port,
+		PoolSize:     10,
+		DialTimeout:  time.Second,
+		ReadTimeout:  time.Second,
+		WriteTimeout: time.Second,
+	}, suite.logger)
+	suite.Require().NoError(err)
+
+	manager := cache.NewDefaultCacheManager(suite.logger)
+	manager.SetCache("default", client)
+	keyBuilder := cache.NewDefaultKeyBuilder("eventos", 15*time.Minute)
+	suite.cacheService = cache.NewCacheService(manager, keyBuilder, suite.logger, 15*time.Minute)
+}
+
+func (suite *StatsRepositoryTestSuite) TearDownTest() {
+	suite.cacheService.Close()
+	suite.mini.Close()
+}
+
+func (suite *StatsRepositoryTestSuite) TestCheckinStats_ServedFromCache() {
+	// Arrange
+	ctx := context.Background()
+	tenantID := value_objects.NewUUID()
+	eventID := value_objects.NewUUID()
+	cached := &checkin.CheckinStats{TotalCheckins: 42, ValidCheckins: 40, PendingCheckins: 2}
+	err := suite.cacheService.CacheStats(ctx, "default", tenantID.String(), "checkin", cached, time.Minute, "event", eventID.String())
+	suite.Require().NoError(err)
+
+	repository := NewCheckinStatsRepository(&sqlx.DB{}, suite.cacheService, suite.logger) // Banco não é consultado
+
+	// Act
+	stats, err := repository.GetEventStats(ctx, tenantID, eventID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 42, stats.TotalCheckins)
+	assert.Equal(suite.T(), 40, stats.ValidCheckins)
+	assert.Equal(suite.T(), 2, stats.PendingCheckins)
+}
+
+func (suite *StatsRepositoryTestSuite) TestWorkStats_ServedFromCache() {
+	// Arrange
+	ctx := context.Background()
+	tenantID := value_objects.NewUUID()
+	cached := &checkout.WorkStats{TotalSessions: 3, CompleteSessions: 2, TotalWorkHours: 16}
+	err := suite.cacheService.CacheStats(ctx, "default", tenantID.String(), "work", cached, time.Minute, "tenant")
+	suite.Require().NoError(err)
+
+	repository := NewCheckoutStatsRepository(&sqlx.DB{}, suite.cacheService, suite.logger) // Banco não é consultado
+
+	// Act
+	stats, err := repository.GetWorkStats(ctx, tenantID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, stats.TotalSessions)
+	assert.Equal(suite.T(), 2, stats.CompleteSessions)
+	assert.Equal(suite.T(), 16.0, stats.TotalWorkHours)
+}
+
+func (suite *StatsRepositoryTestSuite) TestInvalidateSessionStats_RemovesOnlyTheTenantKeys() {
+	// Arrange
+	ctx := context.Background()
+	tenantID, otherTenantID := value_objects.NewUUID(), value_objects.NewUUID()
+	eventID, employeeID, partnerID := value_objects.NewUUID(), value_objects.NewUUID(), value_objects.NewUUID()
+	stats := &checkin.CheckinStats{TotalCheckins: 1}
+	suite.Require().NoError(suite.cacheService.CacheStats(ctx, "default", tenantID.String(), "checkin", stats, time.Minute, "event", eventID.String()))
+	suite.Require().NoError(suite.cacheService.CacheStats(ctx, "default", tenantID.String(), "work", stats, time.Minute, "employee", employeeID.String()))
+	suite.Require().NoError(suite.cacheService.CacheStats(ctx, "default", tenantID.String(), "checkout", stats, time.Minute, "partner", partnerID.String()))
+	suite.Require().NoError(suite.cacheService.CacheStats(ctx, "default", otherTenantID.String(), "checkin", stats, time.Minute, "event", eventID.String()))
+
+	repository := NewCheckoutStatsRepository(&sqlx.DB{}, suite.cacheService, suite.logger)
+
+	// Act
+	repository.InvalidateSessionStats(ctx, tenantID, eventID, employeeID, partnerID)
+
+	// Assert
+	assert.Len(suite.T(), suite.mini.Keys(), 1, "apenas as estatísticas do outro tenant devem permanecer")
+	var cached checkin.CheckinStats
+	assert.NoError(suite.T(), suite.cacheService.GetStats(ctx, "default", otherTenantID.String(), "checkin", &cached, "event", eventID.String()))
+}
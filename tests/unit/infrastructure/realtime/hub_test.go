@@ -0,0 +1,129 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"eventos-backend/internal/infrastructure/messaging/rabbitmq"
+	. "eventos-backend/internal/infrastructure/realtime"
+)
+
+// HubTestSuite é a suíte de testes para o hub do feed em tempo real
+type HubTestSuite struct {
+	suite.Suite
+	hub       *Hub
+	publisher *LocalPublisher
+}
+
+func TestHubSuite(t *testing.T) {
+	suite.Run(t, new(HubTestSuite))
+}
+
+func (suite *HubTestSuite) SetupTest() {
+	suite.hub = NewHub(zap.NewNop())
+	suite.publisher = NewLocalPublisher(suite.hub)
+}
+
+// checkinPayload gera um payload de check-in do tenant e evento informados
+func checkinPayload(tenantID, eventID string) rabbitmq.CheckinEventPayload {
+	return rabbitmq.CheckinEventPayload{
+		CheckinID:   "checkin-1",
+		TenantID:    tenantID,
+		EventID:     eventID,
+		EmployeeID:  "employee-1",
+		PartnerID:   "partner-1",
+		Method:      "qr_code",
+		IsValid:     true,
+		CheckinTime: time.Now().UTC(),
+	}
+}
+
+func (suite *HubTestSuite) TestBroadcast_ScopedByTenantAndEvent() {
+	// Arrange
+	eventSub := suite.hub.Subscribe("tenant-a", "event-1", nil)
+	tenantSub := suite.hub.Subscribe("tenant-a", "", nil)
+	otherEventSub := suite.hub.Subscribe("tenant-a", "event-2", nil)
+	otherTenantSub := suite.hub.Subscribe("tenant-b", "", nil)
+
+	// Act
+	err := suite.publisher.PublishCheckinEvent(context.Background(), rabbitmq.MessageTypeCheckinPerformed, checkinPayload("tenant-a", "event-1"))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), eventSub.Notifications(), 1)
+	assert.Len(suite.T(), tenantSub.Notifications(), 1)
+	assert.Len(suite.T(), otherEventSub.Notifications(), 0)
+	assert.Len(suite.T(), otherTenantSub.Notifications(), 0)
+
+	notification := <-eventSub.Notifications()
+	assert.Equal(suite.T(), rabbitmq.MessageTypeCheckinPerformed, notification.Type)
+	assert.Equal(suite.T(), "event-1", notification.EventID)
+}
+
+func (suite *HubTestSuite) TestBroadcast_FiltersTypes() {
+	// Arrange
+	sub := suite.hub.Subscribe("tenant-a", "", []string{rabbitmq.MessageTypeCheckinInvalid})
+
+	// Act
+	_ = suite.publisher.PublishCheckinEvent(context.Background(), rabbitmq.MessageTypeCheckinPerformed, checkinPayload("tenant-a", "event-1"))
+	_ = suite.publisher.PublishCheckinEvent(context.Background(), rabbitmq.MessageTypeCheckinInvalid, checkinPayload("tenant-a", "event-1"))
+
+	// Assert
+	assert.Len(suite.T(), sub.Notifications(), 1)
+	assert.Equal(suite.T(), rabbitmq.MessageTypeCheckinInvalid, (<-sub.Notifications()).Type)
+}
+
+func (suite *HubTestSuite) TestBroadcast_DropsWhenSubscriberIsSlow() {
+	// Arrange
+	sub := suite.hub.Subscribe("tenant-a", "", nil)
+	notification := &Notification{ID: "1", Type: rabbitmq.MessageTypeCheckinPerformed, TenantID: "tenant-a", EventID: "event-1"}
+
+	// Act
+	for i := 0; i < cap(sub.Notifications())+5; i++ {
+		suite.hub.Broadcast(notification)
+	}
+
+	// Assert
+	assert.Equal(suite.T(), int64(5), sub.Dropped())
+}
+
+func (suite *HubTestSuite) TestUnsubscribe_ClosesChannel() {
+	// Arrange
+	sub := suite.hub.Subscribe("tenant-a", "", nil)
+
+	// Act
+	suite.hub.Unsubscribe(sub)
+	_, open := <-sub.Notifications()
+
+	// Assert
+	assert.False(suite.T(), open)
+	assert.Equal(suite.T(), 0, suite.hub.SubscriberCount())
+}
+
+func (suite *HubTestSuite) TestNewNotification_FromRabbitMQMessage() {
+	// Arrange: corpo decodificado do RabbitMQ chega como mapa
+	raw, _ := rabbitmq.NewMessage(rabbitmq.MessageTypeCheckoutPerformed, map[string]interface{}{
+		"checkout_id": "checkout-1",
+		"event_id":    "event-1",
+	}).SetTenantID("tenant-a").ToJSON()
+	message, err := rabbitmq.FromJSON(raw)
+	suite.Require().NoError(err)
+
+	// Act
+	notification, err := NewNotification(message)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "tenant-a", notification.TenantID)
+	assert.Equal(suite.T(), "event-1", notification.EventID)
+
+	var data map[string]interface{}
+	assert.NoError(suite.T(), json.Unmarshal(notification.Data, &data))
+	assert.Equal(suite.T(), "checkout-1", data["checkout_id"])
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"eventos-backend/internal/domain/checkin"
+	"eventos-backend/internal/domain/checkout"
+	"eventos-backend/internal/domain/event"
+	"eventos-backend/internal/domain/shared/errors"
+	"eventos-backend/internal/domain/shared/value_objects"
+	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
+	. "eventos-backend/internal/interfaces/http/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+// eventServiceStub devolve o evento apenas para o tenant dono
+type eventServiceStub struct {
+	event.Service
+	evt *event.Event
+}
+
+func (s *eventServiceStub) GetEventByTenant(ctx context.Context, id, tenantID value_objects.UUID) (*event.Event, error) {
+	if !s.evt.ID.Equals(id) || !s.evt.TenantID.Equals(tenantID) {
+		return nil, errors.NewNotFoundError("event", id.String())
+	}
+	return s.evt, nil
+}
+
+// checkinStatsServiceStub devolve estatísticas fixas e registra o tenant consultado
+type checkinStatsServiceStub struct {
+	checkin.Service
+	stats    *checkin.CheckinStats
+	partners []*checkin.PartnerCheckinStats
+	tenants  []value_objects.UUID
+}
+
+func (s *checkinStatsServiceStub) GetEventCheckinStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*checkin.CheckinStats, error) {
+	s.tenants = append(s.tenants, tenantID)
+	return s.stats, nil
+}
+
+func (s *checkinStatsServiceStub) GetEventPartnerStats(ctx context.Context, tenantID, eventID value_objects.UUID) ([]*checkin.PartnerCheckinStats, error) {
+	s.tenants = append(s.tenants, tenantID)
+	return s.partners, nil
+}
+
+// checkoutStatsServiceStub devolve estatísticas fixas e registra o tenant consultado
+type checkoutStatsServiceStub struct {
+	checkout.Service
+	stats   *checkout.CheckoutStats
+	work    *checkout.WorkStats
+	tenants []value_objects.UUID
+}
+
+func (s *checkoutStatsServiceStub) GetEventCheckoutStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*checkout.CheckoutStats, error) {
+	s.tenants = append(s.tenants, tenantID)
+	return s.stats, nil
+}
+
+func (s *checkoutStatsServiceStub) GetEventWorkStats(ctx context.Context, tenantID, eventID value_objects.UUID) (*checkout.WorkStats, error) {
+	s.tenants = append(s.tenants, tenantID)
+	return s.work, nil
+}
+
+// EventHandlerTestSuite é a suíte de testes para o handler de eventos
+type EventHandlerTestSuite struct {
+	suite.Suite
+	router    *gin.Engine
+	claims    *jwtService.Claims
+	evt       *event.Event
+	checkins  *checkinStatsServiceStub
+	checkouts *checkoutStatsServiceStub
+}
+
+func TestEventHandlerSuite(t *testing.T) {
+	suite.Run(t, new(EventHandlerTestSuite))
+}
+
+func (suite *EventHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	suite.evt = &event.Event{ID: value_objects.NewUUID(), TenantID: value_objects.NewUUID()}
+	suite.claims = &jwtService.Claims{UserID: value_objects.NewUUID().String(), TenantID: suite.evt.TenantID.String()}
+	suite.checkins = &checkinStatsServiceStub{}
+	suite.checkouts = &checkoutStatsServiceStub{}
+
+	handler := NewEventHandler(&eventServiceStub{evt: suite.evt}, suite.checkins, suite.checkouts, zap.NewNop())
+
+	suite.router = gin.New()
+	suite.router.GET("/events/:id/stats", func(c *gin.Context) {
+		c.Set("claims", suite.claims)
+		handler.GetStats(c)
+	})
+}
+
+func (suite *EventHandlerTestSuite) getStats() *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/events/"+suite.evt.ID.String()+"/stats", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *EventHandlerTestSuite) TestGetStats_CombinesEventStats() {
+	// Arrange
+	first := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	last := time.Date(2026, 3, 10, 17, 30, 0, 0, time.UTC)
+	partnerID := value_objects.NewUUID()
+	suite.checkins.stats = &checkin.CheckinStats{
+		TotalCheckins:    12,
+		ValidCheckins:    9,
+		InvalidCheckins:  2,
+		PendingCheckins:  1,
+		UniqueEmployees:  8,
+		OpenSessions:     4,
+		FirstCheckinTime: &first,
+		LastCheckinTime:  &last,
+	}
+	suite.checkins.partners = []*checkin.PartnerCheckinStats{{
+		PartnerID:       partnerID,
+		TotalCheckins:   7,
+		ValidCheckins:   5,
+		InvalidCheckins: 1,
+		PendingCheckins: 1,
+		UniqueEmployees: 6,
+		OpenSessions:    3,
+		TotalWorkHours:  21.5,
+	}}
+	suite.checkouts.stats = &checkout.CheckoutStats{TotalCheckouts: 6, ValidCheckouts: 5, InvalidCheckouts: 1}
+	suite.checkouts.work = &checkout.WorkStats{TotalWorkHours: 40.5, AverageWorkHours: 6.75}
+
+	// Act
+	w := suite.getStats()
+
+	// Assert
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var body struct {
+		Data EventStatsResponse `json:"data"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+
+	firstAt, lastAt := first.Format(time.RFC3339), last.Format(time.RFC3339)
+	assert.Equal(suite.T(), EventStatsResponse{
+		EventID:          suite.evt.ID.String(),
+		TotalCheckins:    12,
+		TotalCheckouts:   6,
+		ActiveSessions:   4,
+		TotalEmployees:   8,
+		ValidCheckins:    9,
+		InvalidCheckins:  2,
+		PendingCheckins:  1,
+		ValidCheckouts:   5,
+		InvalidCheckouts: 1,
+		TotalWorkHours:   40.5,
+		AverageWorkHours: 6.75,
+		FirstCheckinAt:   &firstAt,
+		LastCheckinAt:    &lastAt,
+		Partners: []PartnerStatsResponse{{
+			PartnerID:       partnerID.String(),
+			TotalCheckins:   7,
+			ValidCheckins:   5,
+			InvalidCheckins: 1,
+			PendingCheckins: 1,
+			TotalEmployees:  6,
+			ActiveSessions:  3,
+			TotalWorkHours:  21.5,
+		}},
+	}, body.Data)
+
+	// As estatísticas são consultadas no tenant do usuário autenticado
+	assert.Equal(suite.T(), []value_objects.UUID{suite.evt.TenantID, suite.evt.TenantID}, suite.checkins.tenants)
+	assert.Equal(suite.T(), []value_objects.UUID{suite.evt.TenantID, suite.evt.TenantID}, suite.checkouts.tenants)
+}
+
+func (suite *EventHandlerTestSuite) TestGetStats_ForeignTenantGetsNotFound() {
+	// Arrange
+	suite.claims.TenantID = value_objects.NewUUID().String()
+
+	// Act
+	w := suite.getStats()
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	assert.Empty(suite.T(), suite.checkins.tenants, "as estatísticas de outro tenant não devem ser consultadas")
+	assert.Empty(suite.T(), suite.checkouts.tenants)
+}
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"eventos-backend/internal/infrastructure/cache"
+	redisClient "eventos-backend/internal/infrastructure/cache/redis"
+	. "eventos-backend/internal/interfaces/http/middleware"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+// IdempotencyMiddlewareTestSuite é a suíte de testes para o middleware de idempotência
+type IdempotencyMiddlewareTestSuite struct {
+	suite.Suite
+	mini   *miniredis.Miniredis
+	client *redisClient.Client
+	router *gin.Engine
+	calls  int
+	status int
+}
+
+func TestIdempotencyMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyMiddlewareTestSuite))
+}
+
+func (suite *IdempotencyMiddlewareTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	mini, err := miniredis.Run()
+	suite.Require().NoError(err)
+	suite.mini = mini
+
+	port, _ := strconv.Atoi(mini.Port())
+	suite.client, err = redisClient.NewClient(redisClient.Config{
+		Host:         mini.Host(),
+		Port:         port,
+		PoolSize:     5,
+		DialTimeout:  time.Second,
+		ReadTimeout:  time.Second,
+		WriteTimeout: time.Second,
+	}, zap.NewNop())
+	suite.Require().NoError(err)
+
+	suite.calls = 0
+	suite.status = http.StatusCreated
+
+	idempotency := NewIdempotencyMiddleware(suite.client, cache.NewDefaultKeyBuilder("test", time.Minute), time.Hour, zap.NewNop())
+
+	suite.router = gin.New()
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("tenant_id", c.GetHeader("X-Tenant"))
+		c.Next()
+	})
+	suite.router.Use(idempotency.Handle())
+	suite.router.POST("/employees", func(c *gin.Context) {
+		suite.calls++
+		c.JSON(suite.status, gin.H{"call": suite.calls})
+	})
+}
+
+func (suite *IdempotencyMiddlewareTestSuite) TearDownTest() {
+	suite.client.Close()
+	suite.mini.Close()
+}
+
+func (suite *IdempotencyMiddlewareTestSuite) post(key, tenant, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/employees", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant", tenant)
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *IdempotencyMiddlewareTestSuite) TestReplaysStoredResponse() {
+	// Act
+	first := suite.post("key-1", "tenant-a", `{"name":"Ana"}`)
+	second := suite.post("key-1", "tenant-a", `{"name":"Ana"}`)
+
+	// Assert
+	assert.Equal(suite.T(), 1, suite.calls)
+	assert.Equal(suite.T(), http.StatusCreated, second.Code)
+	assert.Equal(suite.T(), first.Body.String(), second.Body.String())
+	assert.Equal(suite.T(), "true", second.Header().Get(IdempotencyReplayedHeader))
+}
+
+func (suite *IdempotencyMiddlewareTestSuite) TestRejectsDifferentPayload() {
+	// Act
+	suite.post("key-1", "tenant-a", `{"name":"Ana"}`)
+	reused := suite.post("key-1", "tenant-a", `{"name":"Bruno"}`)
+
+	// Assert
+	assert.Equal(suite.T(), 1, suite.calls)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, reused.Code)
+}
+
+func (suite *IdempotencyMiddlewareTestSuite) TestKeysAreScopedByTenant() {
+	// Act
+	suite.post("key-1", "tenant-a", `{"name":"Ana"}`)
+	other := suite.post("key-1", "tenant-b", `{"name":"Ana"}`)
+
+	// Assert
+	assert.Equal(suite.T(), 2, suite.calls)
+	assert.Empty(suite.T(), other.Header().Get(IdempotencyReplayedHeader))
+}
+
+func (suite *IdempotencyMiddlewareTestSuite) TestServerErrorReleasesKey() {
+	// Arrange
+	suite.status = http.StatusInternalServerError
+
+	// Act
+	suite.post("key-1", "tenant-a", `{"name":"Ana"}`)
+	suite.status = http.StatusCreated
+	retry := suite.post("key-1", "tenant-a", `{"name":"Ana"}`)
+
+	// Assert
+	assert.Equal(suite.T(), 2, suite.calls)
+	assert.Equal(suite.T(), http.StatusCreated, retry.Code)
+}
+
+func (suite *IdempotencyMiddlewareTestSuite) TestWithoutHeaderIsNotProtected() {
+	// Act
+	suite.post("", "tenant-a", `{"name":"Ana"}`)
+	suite.post("", "tenant-a", `{"name":"Ana"}`)
+
+	// Assert
+	assert.Equal(suite.T(), 2, suite.calls)
+}
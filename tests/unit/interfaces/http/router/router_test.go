@@ -0,0 +1,147 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"eventos-backend/internal/domain/checkout"
+	"eventos-backend/internal/domain/permission"
+	"eventos-backend/internal/domain/shared/constants"
+	"eventos-backend/internal/domain/shared/value_objects"
+	jwtService "eventos-backend/internal/infrastructure/auth/jwt"
+	"eventos-backend/internal/infrastructure/monitoring"
+	. "eventos-backend/internal/interfaces/http/router"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+)
+
+// jwtStub aceita qualquer token e devolve as claims configuradas
+type jwtStub struct {
+	jwtService.Service
+	claims *jwtService.Claims
+}
+
+func (s *jwtStub) ValidateToken(tokenString string) (*jwtService.Claims, error) {
+	return s.claims, nil
+}
+
+// permissionServiceStub concede somente as permissões configuradas
+type permissionServiceStub struct {
+	permission.Service
+	granted map[string]bool
+}
+
+func (s *permissionServiceStub) UserHasPermission(ctx context.Context, userID value_objects.UUID, module, action string) (bool, error) {
+	return s.granted[module+":"+action], nil
+}
+
+// checkoutServiceStub registra as correções de horário recebidas
+type checkoutServiceStub struct {
+	checkout.Service
+	corrected int
+}
+
+func (s *checkoutServiceStub) CorrectCheckoutTime(ctx context.Context, checkoutID, tenantID value_objects.UUID, checkoutTime time.Time, reason string, correctedBy value_objects.UUID) (*checkout.Checkout, error) {
+	s.corrected++
+	return nil, context.Canceled
+}
+
+func (s *checkoutServiceStub) ListAutoClosedCheckouts(ctx context.Context, tenantID value_objects.UUID, filters checkout.ListFilters) ([]*checkout.Checkout, int, error) {
+	return nil, 0, nil
+}
+
+// RouterTestSuite é a suíte de testes para as permissões das rotas
+type RouterTestSuite struct {
+	suite.Suite
+	permissions *permissionServiceStub
+	checkouts   *checkoutServiceStub
+	router      *Router
+}
+
+func TestRouterSuite(t *testing.T) {
+	suite.Run(t, new(RouterTestSuite))
+}
+
+func (suite *RouterTestSuite) SetupTest() {
+	monitoring.Tracer = otel.Tracer("router-test")
+	suite.permissions = &permissionServiceStub{granted: map[string]bool{}}
+	suite.checkouts = &checkoutServiceStub{}
+
+	claims := &jwtService.Claims{
+		UserID:   value_objects.NewUUID().String(),
+		TenantID: value_objects.NewUUID().String(),
+		Username: "operador",
+	}
+
+	suite.router = New(Config{
+		Logger:            zap.NewNop(),
+		JWTService:        &jwtStub{claims: claims},
+		PermissionService: suite.permissions,
+		CheckoutService:   suite.checkouts,
+	})
+}
+
+// request executa a requisição autenticada no roteador da suíte
+func (suite *RouterTestSuite) request(method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+	suite.router.Engine().ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (suite *RouterTestSuite) TestCorrectCheckoutTime_ForbiddenWithoutSupervisorPermission() {
+	// Arrange
+	suite.permissions.granted[constants.ModuleCheckins+":"+constants.PermissionWrite] = true
+	path := "/api/v1/checkouts/" + value_objects.NewUUID().String() + "/time"
+	body := `{"checkout_time":"2026-03-01T18:00:00Z","reason":"Saída registrada pelo supervisor"}`
+
+	// Act
+	recorder := suite.request(http.MethodPatch, path, body)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusForbidden, recorder.Code)
+	assert.Zero(suite.T(), suite.checkouts.corrected)
+}
+
+func (suite *RouterTestSuite) TestListAutoClosed_ForbiddenWithoutSupervisorPermission() {
+	// Act
+	recorder := suite.request(http.MethodGet, "/api/v1/checkouts/auto-closed", "")
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusForbidden, recorder.Code)
+}
+
+func (suite *RouterTestSuite) TestCorrectCheckoutTime_ReachesHandlerWithSupervisorPermission() {
+	// Arrange
+	suite.permissions.granted[constants.ModuleCheckins+":"+constants.PermissionApprove] = true
+	path := "/api/v1/checkouts/" + value_objects.NewUUID().String() + "/time"
+	body := `{"checkout_time":"2026-03-01T18:00:00Z","reason":"Saída registrada pelo supervisor"}`
+
+	// Act
+	recorder := suite.request(http.MethodPatch, path, body)
+
+	// Assert
+	assert.NotEqual(suite.T(), http.StatusForbidden, recorder.Code)
+	assert.Equal(suite.T(), 1, suite.checkouts.corrected)
+}
+
+func (suite *RouterTestSuite) TestSyncBatch_RequiresRegisteredDevice() {
+	// Arrange - identificador livre no corpo, sem credencial de dispositivo
+	body := `{"device_id":"gate-01","records":[{"client_id":"` + value_objects.NewUUID().String() + `","type":"checkin"}]}`
+
+	// Act
+	recorder := suite.request(http.MethodPost, "/api/v1/sync/batch", body)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusUnauthorized, recorder.Code)
+}